@@ -24,10 +24,33 @@ type AuthServiceClient interface {
 	Register(ctx context.Context, in *RegisterIn, opts ...grpc.CallOption) (*RegisterOut, error)
 	RefreshTokens(ctx context.Context, in *RefreshTokensIn, opts ...grpc.CallOption) (*LoginOut, error)
 	VerifyEmail(ctx context.Context, in *VerifyEmailIn, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	VerifyEmailByCode(ctx context.Context, in *VerifyEmailByCodeIn, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ChangePassword(ctx context.Context, in *ChangePasswordIn, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ForgetPassword(ctx context.Context, in *ForgetPasswordIn, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	SendForgetPasswordMessage(ctx context.Context, in *SendForgetPasswordMessageIn, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	SendVerifyEmailMessage(ctx context.Context, in *SendVerifyEmailMessageIn, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListSessions(ctx context.Context, in *ListSessionsIn, opts ...grpc.CallOption) (*ListSessionsOut, error)
+	RevokeSession(ctx context.Context, in *RevokeSessionIn, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	LogoutEverywhere(ctx context.Context, in *LogoutEverywhereIn, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetJWKS(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetJWKSOut, error)
+	IntrospectToken(ctx context.Context, in *IntrospectTokenIn, opts ...grpc.CallOption) (*IntrospectTokenOut, error)
+	CompleteMFALogin(ctx context.Context, in *CompleteMFALoginIn, opts ...grpc.CallOption) (*LoginOut, error)
+	StartTOTPEnrollment(ctx context.Context, in *StartTOTPEnrollmentIn, opts ...grpc.CallOption) (*StartTOTPEnrollmentOut, error)
+	ConfirmTOTPEnrollment(ctx context.Context, in *ConfirmTOTPEnrollmentIn, opts ...grpc.CallOption) (*ConfirmTOTPEnrollmentOut, error)
+	DisableTOTP(ctx context.Context, in *DisableTOTPIn, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	BeginWebAuthnRegistration(ctx context.Context, in *BeginWebAuthnRegistrationIn, opts ...grpc.CallOption) (*WebAuthnOptionsOut, error)
+	FinishWebAuthnRegistration(ctx context.Context, in *FinishWebAuthnRegistrationIn, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	BeginWebAuthnLogin(ctx context.Context, in *BeginWebAuthnLoginIn, opts ...grpc.CallOption) (*WebAuthnOptionsOut, error)
+	FinishWebAuthnLogin(ctx context.Context, in *FinishWebAuthnLoginIn, opts ...grpc.CallOption) (*LoginOut, error)
+	SendLoginLink(ctx context.Context, in *SendLoginLinkIn, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	LoginWithCode(ctx context.Context, in *LoginWithCodeIn, opts ...grpc.CallOption) (*LoginOut, error)
+	IssueClientToken(ctx context.Context, in *IssueClientTokenIn, opts ...grpc.CallOption) (*IssueClientTokenOut, error)
+	BeginFederatedLogin(ctx context.Context, in *BeginFederatedLoginIn, opts ...grpc.CallOption) (*BeginFederatedLoginOut, error)
+	FinishFederatedLogin(ctx context.Context, in *FinishFederatedLoginIn, opts ...grpc.CallOption) (*LoginOut, error)
+	LoginWithTelegram(ctx context.Context, in *TelegramAuthIn, opts ...grpc.CallOption) (*LoginOut, error)
+	LinkTelegram(ctx context.Context, in *LinkTelegramIn, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SendPhoneVerificationCode(ctx context.Context, in *SendPhoneVerificationCodeIn, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	VerifyPhone(ctx context.Context, in *VerifyPhoneIn, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type authServiceClient struct {
@@ -83,6 +106,15 @@ func (c *authServiceClient) VerifyEmail(ctx context.Context, in *VerifyEmailIn,
 	return out, nil
 }
 
+func (c *authServiceClient) VerifyEmailByCode(ctx context.Context, in *VerifyEmailByCodeIn, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthService/VerifyEmailByCode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *authServiceClient) ChangePassword(ctx context.Context, in *ChangePasswordIn, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/auth.AuthService/ChangePassword", in, out, opts...)
@@ -119,6 +151,204 @@ func (c *authServiceClient) SendVerifyEmailMessage(ctx context.Context, in *Send
 	return out, nil
 }
 
+func (c *authServiceClient) ListSessions(ctx context.Context, in *ListSessionsIn, opts ...grpc.CallOption) (*ListSessionsOut, error) {
+	out := new(ListSessionsOut)
+	err := c.cc.Invoke(ctx, "/auth.AuthService/ListSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RevokeSession(ctx context.Context, in *RevokeSessionIn, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthService/RevokeSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) LogoutEverywhere(ctx context.Context, in *LogoutEverywhereIn, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthService/LogoutEverywhere", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) GetJWKS(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetJWKSOut, error) {
+	out := new(GetJWKSOut)
+	err := c.cc.Invoke(ctx, "/auth.AuthService/GetJWKS", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) IntrospectToken(ctx context.Context, in *IntrospectTokenIn, opts ...grpc.CallOption) (*IntrospectTokenOut, error) {
+	out := new(IntrospectTokenOut)
+	err := c.cc.Invoke(ctx, "/auth.AuthService/IntrospectToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) CompleteMFALogin(ctx context.Context, in *CompleteMFALoginIn, opts ...grpc.CallOption) (*LoginOut, error) {
+	out := new(LoginOut)
+	err := c.cc.Invoke(ctx, "/auth.AuthService/CompleteMFALogin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) StartTOTPEnrollment(ctx context.Context, in *StartTOTPEnrollmentIn, opts ...grpc.CallOption) (*StartTOTPEnrollmentOut, error) {
+	out := new(StartTOTPEnrollmentOut)
+	err := c.cc.Invoke(ctx, "/auth.AuthService/StartTOTPEnrollment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ConfirmTOTPEnrollment(ctx context.Context, in *ConfirmTOTPEnrollmentIn, opts ...grpc.CallOption) (*ConfirmTOTPEnrollmentOut, error) {
+	out := new(ConfirmTOTPEnrollmentOut)
+	err := c.cc.Invoke(ctx, "/auth.AuthService/ConfirmTOTPEnrollment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) DisableTOTP(ctx context.Context, in *DisableTOTPIn, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthService/DisableTOTP", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) BeginWebAuthnRegistration(ctx context.Context, in *BeginWebAuthnRegistrationIn, opts ...grpc.CallOption) (*WebAuthnOptionsOut, error) {
+	out := new(WebAuthnOptionsOut)
+	err := c.cc.Invoke(ctx, "/auth.AuthService/BeginWebAuthnRegistration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) FinishWebAuthnRegistration(ctx context.Context, in *FinishWebAuthnRegistrationIn, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthService/FinishWebAuthnRegistration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) BeginWebAuthnLogin(ctx context.Context, in *BeginWebAuthnLoginIn, opts ...grpc.CallOption) (*WebAuthnOptionsOut, error) {
+	out := new(WebAuthnOptionsOut)
+	err := c.cc.Invoke(ctx, "/auth.AuthService/BeginWebAuthnLogin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) FinishWebAuthnLogin(ctx context.Context, in *FinishWebAuthnLoginIn, opts ...grpc.CallOption) (*LoginOut, error) {
+	out := new(LoginOut)
+	err := c.cc.Invoke(ctx, "/auth.AuthService/FinishWebAuthnLogin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) SendLoginLink(ctx context.Context, in *SendLoginLinkIn, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthService/SendLoginLink", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) LoginWithCode(ctx context.Context, in *LoginWithCodeIn, opts ...grpc.CallOption) (*LoginOut, error) {
+	out := new(LoginOut)
+	err := c.cc.Invoke(ctx, "/auth.AuthService/LoginWithCode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) IssueClientToken(ctx context.Context, in *IssueClientTokenIn, opts ...grpc.CallOption) (*IssueClientTokenOut, error) {
+	out := new(IssueClientTokenOut)
+	err := c.cc.Invoke(ctx, "/auth.AuthService/IssueClientToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) BeginFederatedLogin(ctx context.Context, in *BeginFederatedLoginIn, opts ...grpc.CallOption) (*BeginFederatedLoginOut, error) {
+	out := new(BeginFederatedLoginOut)
+	err := c.cc.Invoke(ctx, "/auth.AuthService/BeginFederatedLogin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) FinishFederatedLogin(ctx context.Context, in *FinishFederatedLoginIn, opts ...grpc.CallOption) (*LoginOut, error) {
+	out := new(LoginOut)
+	err := c.cc.Invoke(ctx, "/auth.AuthService/FinishFederatedLogin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) LoginWithTelegram(ctx context.Context, in *TelegramAuthIn, opts ...grpc.CallOption) (*LoginOut, error) {
+	out := new(LoginOut)
+	err := c.cc.Invoke(ctx, "/auth.AuthService/LoginWithTelegram", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) LinkTelegram(ctx context.Context, in *LinkTelegramIn, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthService/LinkTelegram", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) SendPhoneVerificationCode(ctx context.Context, in *SendPhoneVerificationCodeIn, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthService/SendPhoneVerificationCode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) VerifyPhone(ctx context.Context, in *VerifyPhoneIn, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthService/VerifyPhone", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthServiceServer is the server API for AuthService service.
 // All implementations must embed UnimplementedAuthServiceServer
 // for forward compatibility
@@ -128,10 +358,33 @@ type AuthServiceServer interface {
 	Register(context.Context, *RegisterIn) (*RegisterOut, error)
 	RefreshTokens(context.Context, *RefreshTokensIn) (*LoginOut, error)
 	VerifyEmail(context.Context, *VerifyEmailIn) (*emptypb.Empty, error)
+	VerifyEmailByCode(context.Context, *VerifyEmailByCodeIn) (*emptypb.Empty, error)
 	ChangePassword(context.Context, *ChangePasswordIn) (*emptypb.Empty, error)
 	ForgetPassword(context.Context, *ForgetPasswordIn) (*emptypb.Empty, error)
 	SendForgetPasswordMessage(context.Context, *SendForgetPasswordMessageIn) (*emptypb.Empty, error)
 	SendVerifyEmailMessage(context.Context, *SendVerifyEmailMessageIn) (*emptypb.Empty, error)
+	ListSessions(context.Context, *ListSessionsIn) (*ListSessionsOut, error)
+	RevokeSession(context.Context, *RevokeSessionIn) (*emptypb.Empty, error)
+	LogoutEverywhere(context.Context, *LogoutEverywhereIn) (*emptypb.Empty, error)
+	GetJWKS(context.Context, *emptypb.Empty) (*GetJWKSOut, error)
+	IntrospectToken(context.Context, *IntrospectTokenIn) (*IntrospectTokenOut, error)
+	CompleteMFALogin(context.Context, *CompleteMFALoginIn) (*LoginOut, error)
+	StartTOTPEnrollment(context.Context, *StartTOTPEnrollmentIn) (*StartTOTPEnrollmentOut, error)
+	ConfirmTOTPEnrollment(context.Context, *ConfirmTOTPEnrollmentIn) (*ConfirmTOTPEnrollmentOut, error)
+	DisableTOTP(context.Context, *DisableTOTPIn) (*emptypb.Empty, error)
+	BeginWebAuthnRegistration(context.Context, *BeginWebAuthnRegistrationIn) (*WebAuthnOptionsOut, error)
+	FinishWebAuthnRegistration(context.Context, *FinishWebAuthnRegistrationIn) (*emptypb.Empty, error)
+	BeginWebAuthnLogin(context.Context, *BeginWebAuthnLoginIn) (*WebAuthnOptionsOut, error)
+	FinishWebAuthnLogin(context.Context, *FinishWebAuthnLoginIn) (*LoginOut, error)
+	SendLoginLink(context.Context, *SendLoginLinkIn) (*emptypb.Empty, error)
+	LoginWithCode(context.Context, *LoginWithCodeIn) (*LoginOut, error)
+	IssueClientToken(context.Context, *IssueClientTokenIn) (*IssueClientTokenOut, error)
+	BeginFederatedLogin(context.Context, *BeginFederatedLoginIn) (*BeginFederatedLoginOut, error)
+	FinishFederatedLogin(context.Context, *FinishFederatedLoginIn) (*LoginOut, error)
+	LoginWithTelegram(context.Context, *TelegramAuthIn) (*LoginOut, error)
+	LinkTelegram(context.Context, *LinkTelegramIn) (*emptypb.Empty, error)
+	SendPhoneVerificationCode(context.Context, *SendPhoneVerificationCodeIn) (*emptypb.Empty, error)
+	VerifyPhone(context.Context, *VerifyPhoneIn) (*emptypb.Empty, error)
 	mustEmbedUnimplementedAuthServiceServer()
 }
 
@@ -154,6 +407,9 @@ func (UnimplementedAuthServiceServer) RefreshTokens(context.Context, *RefreshTok
 func (UnimplementedAuthServiceServer) VerifyEmail(context.Context, *VerifyEmailIn) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VerifyEmail not implemented")
 }
+func (UnimplementedAuthServiceServer) VerifyEmailByCode(context.Context, *VerifyEmailByCodeIn) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyEmailByCode not implemented")
+}
 func (UnimplementedAuthServiceServer) ChangePassword(context.Context, *ChangePasswordIn) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
 }
@@ -166,6 +422,72 @@ func (UnimplementedAuthServiceServer) SendForgetPasswordMessage(context.Context,
 func (UnimplementedAuthServiceServer) SendVerifyEmailMessage(context.Context, *SendVerifyEmailMessageIn) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendVerifyEmailMessage not implemented")
 }
+func (UnimplementedAuthServiceServer) ListSessions(context.Context, *ListSessionsIn) (*ListSessionsOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedAuthServiceServer) RevokeSession(context.Context, *RevokeSessionIn) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
+}
+func (UnimplementedAuthServiceServer) LogoutEverywhere(context.Context, *LogoutEverywhereIn) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogoutEverywhere not implemented")
+}
+func (UnimplementedAuthServiceServer) GetJWKS(context.Context, *emptypb.Empty) (*GetJWKSOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJWKS not implemented")
+}
+func (UnimplementedAuthServiceServer) IntrospectToken(context.Context, *IntrospectTokenIn) (*IntrospectTokenOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IntrospectToken not implemented")
+}
+func (UnimplementedAuthServiceServer) CompleteMFALogin(context.Context, *CompleteMFALoginIn) (*LoginOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteMFALogin not implemented")
+}
+func (UnimplementedAuthServiceServer) StartTOTPEnrollment(context.Context, *StartTOTPEnrollmentIn) (*StartTOTPEnrollmentOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartTOTPEnrollment not implemented")
+}
+func (UnimplementedAuthServiceServer) ConfirmTOTPEnrollment(context.Context, *ConfirmTOTPEnrollmentIn) (*ConfirmTOTPEnrollmentOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmTOTPEnrollment not implemented")
+}
+func (UnimplementedAuthServiceServer) DisableTOTP(context.Context, *DisableTOTPIn) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableTOTP not implemented")
+}
+func (UnimplementedAuthServiceServer) BeginWebAuthnRegistration(context.Context, *BeginWebAuthnRegistrationIn) (*WebAuthnOptionsOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginWebAuthnRegistration not implemented")
+}
+func (UnimplementedAuthServiceServer) FinishWebAuthnRegistration(context.Context, *FinishWebAuthnRegistrationIn) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishWebAuthnRegistration not implemented")
+}
+func (UnimplementedAuthServiceServer) BeginWebAuthnLogin(context.Context, *BeginWebAuthnLoginIn) (*WebAuthnOptionsOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginWebAuthnLogin not implemented")
+}
+func (UnimplementedAuthServiceServer) FinishWebAuthnLogin(context.Context, *FinishWebAuthnLoginIn) (*LoginOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishWebAuthnLogin not implemented")
+}
+func (UnimplementedAuthServiceServer) SendLoginLink(context.Context, *SendLoginLinkIn) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendLoginLink not implemented")
+}
+func (UnimplementedAuthServiceServer) LoginWithCode(context.Context, *LoginWithCodeIn) (*LoginOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoginWithCode not implemented")
+}
+func (UnimplementedAuthServiceServer) IssueClientToken(context.Context, *IssueClientTokenIn) (*IssueClientTokenOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssueClientToken not implemented")
+}
+func (UnimplementedAuthServiceServer) BeginFederatedLogin(context.Context, *BeginFederatedLoginIn) (*BeginFederatedLoginOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginFederatedLogin not implemented")
+}
+func (UnimplementedAuthServiceServer) FinishFederatedLogin(context.Context, *FinishFederatedLoginIn) (*LoginOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishFederatedLogin not implemented")
+}
+func (UnimplementedAuthServiceServer) LoginWithTelegram(context.Context, *TelegramAuthIn) (*LoginOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoginWithTelegram not implemented")
+}
+func (UnimplementedAuthServiceServer) LinkTelegram(context.Context, *LinkTelegramIn) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LinkTelegram not implemented")
+}
+func (UnimplementedAuthServiceServer) SendPhoneVerificationCode(context.Context, *SendPhoneVerificationCodeIn) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendPhoneVerificationCode not implemented")
+}
+func (UnimplementedAuthServiceServer) VerifyPhone(context.Context, *VerifyPhoneIn) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyPhone not implemented")
+}
 func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
 
 // UnsafeAuthServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -269,6 +591,24 @@ func _AuthService_VerifyEmail_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_VerifyEmailByCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyEmailByCodeIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).VerifyEmailByCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthService/VerifyEmailByCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).VerifyEmailByCode(ctx, req.(*VerifyEmailByCodeIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AuthService_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ChangePasswordIn)
 	if err := dec(in); err != nil {
@@ -341,48 +681,536 @@ func _AuthService_SendVerifyEmailMessage_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
-// AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var AuthService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "auth.AuthService",
-	HandlerType: (*AuthServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Login",
-			Handler:    _AuthService_Login_Handler,
-		},
-		{
-			MethodName: "Logout",
-			Handler:    _AuthService_Logout_Handler,
-		},
-		{
-			MethodName: "Register",
-			Handler:    _AuthService_Register_Handler,
-		},
-		{
-			MethodName: "RefreshTokens",
-			Handler:    _AuthService_RefreshTokens_Handler,
-		},
-		{
-			MethodName: "VerifyEmail",
-			Handler:    _AuthService_VerifyEmail_Handler,
-		},
-		{
-			MethodName: "ChangePassword",
-			Handler:    _AuthService_ChangePassword_Handler,
-		},
-		{
-			MethodName: "ForgetPassword",
-			Handler:    _AuthService_ForgetPassword_Handler,
-		},
-		{
-			MethodName: "SendForgetPasswordMessage",
-			Handler:    _AuthService_SendForgetPasswordMessage_Handler,
-		},
-		{
-			MethodName: "SendVerifyEmailMessage",
-			Handler:    _AuthService_SendVerifyEmailMessage_Handler,
+func _AuthService_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthService/ListSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListSessions(ctx, req.(*ListSessionsIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthService/RevokeSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RevokeSession(ctx, req.(*RevokeSessionIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_LogoutEverywhere_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutEverywhereIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).LogoutEverywhere(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthService/LogoutEverywhere",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).LogoutEverywhere(ctx, req.(*LogoutEverywhereIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetJWKS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetJWKS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthService/GetJWKS",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetJWKS(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_IntrospectToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IntrospectTokenIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).IntrospectToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthService/IntrospectToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).IntrospectToken(ctx, req.(*IntrospectTokenIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_CompleteMFALogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteMFALoginIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).CompleteMFALogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthService/CompleteMFALogin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).CompleteMFALogin(ctx, req.(*CompleteMFALoginIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_StartTOTPEnrollment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartTOTPEnrollmentIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).StartTOTPEnrollment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthService/StartTOTPEnrollment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).StartTOTPEnrollment(ctx, req.(*StartTOTPEnrollmentIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ConfirmTOTPEnrollment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmTOTPEnrollmentIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ConfirmTOTPEnrollment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthService/ConfirmTOTPEnrollment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ConfirmTOTPEnrollment(ctx, req.(*ConfirmTOTPEnrollmentIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_DisableTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableTOTPIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).DisableTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthService/DisableTOTP",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).DisableTOTP(ctx, req.(*DisableTOTPIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_BeginWebAuthnRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeginWebAuthnRegistrationIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).BeginWebAuthnRegistration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthService/BeginWebAuthnRegistration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).BeginWebAuthnRegistration(ctx, req.(*BeginWebAuthnRegistrationIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_FinishWebAuthnRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishWebAuthnRegistrationIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).FinishWebAuthnRegistration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthService/FinishWebAuthnRegistration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).FinishWebAuthnRegistration(ctx, req.(*FinishWebAuthnRegistrationIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_BeginWebAuthnLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeginWebAuthnLoginIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).BeginWebAuthnLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthService/BeginWebAuthnLogin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).BeginWebAuthnLogin(ctx, req.(*BeginWebAuthnLoginIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_FinishWebAuthnLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishWebAuthnLoginIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).FinishWebAuthnLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthService/FinishWebAuthnLogin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).FinishWebAuthnLogin(ctx, req.(*FinishWebAuthnLoginIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_SendLoginLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendLoginLinkIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).SendLoginLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthService/SendLoginLink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).SendLoginLink(ctx, req.(*SendLoginLinkIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_LoginWithCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginWithCodeIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).LoginWithCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthService/LoginWithCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).LoginWithCode(ctx, req.(*LoginWithCodeIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_IssueClientToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueClientTokenIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).IssueClientToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthService/IssueClientToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).IssueClientToken(ctx, req.(*IssueClientTokenIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_BeginFederatedLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeginFederatedLoginIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).BeginFederatedLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthService/BeginFederatedLogin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).BeginFederatedLogin(ctx, req.(*BeginFederatedLoginIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_FinishFederatedLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishFederatedLoginIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).FinishFederatedLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthService/FinishFederatedLogin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).FinishFederatedLogin(ctx, req.(*FinishFederatedLoginIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_LoginWithTelegram_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TelegramAuthIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).LoginWithTelegram(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthService/LoginWithTelegram",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).LoginWithTelegram(ctx, req.(*TelegramAuthIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_LinkTelegram_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkTelegramIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).LinkTelegram(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthService/LinkTelegram",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).LinkTelegram(ctx, req.(*LinkTelegramIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_SendPhoneVerificationCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendPhoneVerificationCodeIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).SendPhoneVerificationCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthService/SendPhoneVerificationCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).SendPhoneVerificationCode(ctx, req.(*SendPhoneVerificationCodeIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_VerifyPhone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyPhoneIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).VerifyPhone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthService/VerifyPhone",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).VerifyPhone(ctx, req.(*VerifyPhoneIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuthService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "auth.AuthService",
+	HandlerType: (*AuthServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Login",
+			Handler:    _AuthService_Login_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _AuthService_Logout_Handler,
+		},
+		{
+			MethodName: "Register",
+			Handler:    _AuthService_Register_Handler,
+		},
+		{
+			MethodName: "RefreshTokens",
+			Handler:    _AuthService_RefreshTokens_Handler,
+		},
+		{
+			MethodName: "VerifyEmail",
+			Handler:    _AuthService_VerifyEmail_Handler,
+		},
+		{
+			MethodName: "VerifyEmailByCode",
+			Handler:    _AuthService_VerifyEmailByCode_Handler,
+		},
+		{
+			MethodName: "ChangePassword",
+			Handler:    _AuthService_ChangePassword_Handler,
+		},
+		{
+			MethodName: "ForgetPassword",
+			Handler:    _AuthService_ForgetPassword_Handler,
+		},
+		{
+			MethodName: "SendForgetPasswordMessage",
+			Handler:    _AuthService_SendForgetPasswordMessage_Handler,
+		},
+		{
+			MethodName: "SendVerifyEmailMessage",
+			Handler:    _AuthService_SendVerifyEmailMessage_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _AuthService_ListSessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _AuthService_RevokeSession_Handler,
+		},
+		{
+			MethodName: "LogoutEverywhere",
+			Handler:    _AuthService_LogoutEverywhere_Handler,
+		},
+		{
+			MethodName: "GetJWKS",
+			Handler:    _AuthService_GetJWKS_Handler,
+		},
+		{
+			MethodName: "IntrospectToken",
+			Handler:    _AuthService_IntrospectToken_Handler,
+		},
+		{
+			MethodName: "CompleteMFALogin",
+			Handler:    _AuthService_CompleteMFALogin_Handler,
+		},
+		{
+			MethodName: "StartTOTPEnrollment",
+			Handler:    _AuthService_StartTOTPEnrollment_Handler,
+		},
+		{
+			MethodName: "ConfirmTOTPEnrollment",
+			Handler:    _AuthService_ConfirmTOTPEnrollment_Handler,
+		},
+		{
+			MethodName: "DisableTOTP",
+			Handler:    _AuthService_DisableTOTP_Handler,
+		},
+		{
+			MethodName: "BeginWebAuthnRegistration",
+			Handler:    _AuthService_BeginWebAuthnRegistration_Handler,
+		},
+		{
+			MethodName: "FinishWebAuthnRegistration",
+			Handler:    _AuthService_FinishWebAuthnRegistration_Handler,
+		},
+		{
+			MethodName: "BeginWebAuthnLogin",
+			Handler:    _AuthService_BeginWebAuthnLogin_Handler,
+		},
+		{
+			MethodName: "FinishWebAuthnLogin",
+			Handler:    _AuthService_FinishWebAuthnLogin_Handler,
+		},
+		{
+			MethodName: "SendLoginLink",
+			Handler:    _AuthService_SendLoginLink_Handler,
+		},
+		{
+			MethodName: "LoginWithCode",
+			Handler:    _AuthService_LoginWithCode_Handler,
+		},
+		{
+			MethodName: "IssueClientToken",
+			Handler:    _AuthService_IssueClientToken_Handler,
+		},
+		{
+			MethodName: "BeginFederatedLogin",
+			Handler:    _AuthService_BeginFederatedLogin_Handler,
+		},
+		{
+			MethodName: "FinishFederatedLogin",
+			Handler:    _AuthService_FinishFederatedLogin_Handler,
+		},
+		{
+			MethodName: "LoginWithTelegram",
+			Handler:    _AuthService_LoginWithTelegram_Handler,
+		},
+		{
+			MethodName: "LinkTelegram",
+			Handler:    _AuthService_LinkTelegram_Handler,
+		},
+		{
+			MethodName: "SendPhoneVerificationCode",
+			Handler:    _AuthService_SendPhoneVerificationCode_Handler,
+		},
+		{
+			MethodName: "VerifyPhone",
+			Handler:    _AuthService_VerifyPhone_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -0,0 +1,210 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package sso
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ClientsServiceClient is the client API for ClientsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ClientsServiceClient interface {
+	RegisterClient(ctx context.Context, in *RegisterClientIn, opts ...grpc.CallOption) (*RegisterClientOut, error)
+	GetClients(ctx context.Context, in *GetClientsIn, opts ...grpc.CallOption) (*GetClientsOut, error)
+	UpdateClient(ctx context.Context, in *UpdateClientIn, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteClient(ctx context.Context, in *DeleteClientIn, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type clientsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewClientsServiceClient(cc grpc.ClientConnInterface) ClientsServiceClient {
+	return &clientsServiceClient{cc}
+}
+
+func (c *clientsServiceClient) RegisterClient(ctx context.Context, in *RegisterClientIn, opts ...grpc.CallOption) (*RegisterClientOut, error) {
+	out := new(RegisterClientOut)
+	err := c.cc.Invoke(ctx, "/clients.ClientsService/RegisterClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) GetClients(ctx context.Context, in *GetClientsIn, opts ...grpc.CallOption) (*GetClientsOut, error) {
+	out := new(GetClientsOut)
+	err := c.cc.Invoke(ctx, "/clients.ClientsService/GetClients", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) UpdateClient(ctx context.Context, in *UpdateClientIn, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/clients.ClientsService/UpdateClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) DeleteClient(ctx context.Context, in *DeleteClientIn, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/clients.ClientsService/DeleteClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ClientsServiceServer is the server API for ClientsService service.
+// All implementations must embed UnimplementedClientsServiceServer
+// for forward compatibility
+type ClientsServiceServer interface {
+	RegisterClient(context.Context, *RegisterClientIn) (*RegisterClientOut, error)
+	GetClients(context.Context, *GetClientsIn) (*GetClientsOut, error)
+	UpdateClient(context.Context, *UpdateClientIn) (*emptypb.Empty, error)
+	DeleteClient(context.Context, *DeleteClientIn) (*emptypb.Empty, error)
+	mustEmbedUnimplementedClientsServiceServer()
+}
+
+// UnimplementedClientsServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedClientsServiceServer struct {
+}
+
+func (UnimplementedClientsServiceServer) RegisterClient(context.Context, *RegisterClientIn) (*RegisterClientOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterClient not implemented")
+}
+func (UnimplementedClientsServiceServer) GetClients(context.Context, *GetClientsIn) (*GetClientsOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClients not implemented")
+}
+func (UnimplementedClientsServiceServer) UpdateClient(context.Context, *UpdateClientIn) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateClient not implemented")
+}
+func (UnimplementedClientsServiceServer) DeleteClient(context.Context, *DeleteClientIn) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteClient not implemented")
+}
+func (UnimplementedClientsServiceServer) mustEmbedUnimplementedClientsServiceServer() {}
+
+// UnsafeClientsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ClientsServiceServer will
+// result in compilation errors.
+type UnsafeClientsServiceServer interface {
+	mustEmbedUnimplementedClientsServiceServer()
+}
+
+func RegisterClientsServiceServer(s grpc.ServiceRegistrar, srv ClientsServiceServer) {
+	s.RegisterService(&ClientsService_ServiceDesc, srv)
+}
+
+func _ClientsService_RegisterClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterClientIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).RegisterClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clients.ClientsService/RegisterClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).RegisterClient(ctx, req.(*RegisterClientIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_GetClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClientsIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).GetClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clients.ClientsService/GetClients",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).GetClients(ctx, req.(*GetClientsIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_UpdateClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateClientIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).UpdateClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clients.ClientsService/UpdateClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).UpdateClient(ctx, req.(*UpdateClientIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_DeleteClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteClientIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).DeleteClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clients.ClientsService/DeleteClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).DeleteClient(ctx, req.(*DeleteClientIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ClientsService_ServiceDesc is the grpc.ServiceDesc for ClientsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ClientsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "clients.ClientsService",
+	HandlerType: (*ClientsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterClient",
+			Handler:    _ClientsService_RegisterClient_Handler,
+		},
+		{
+			MethodName: "GetClients",
+			Handler:    _ClientsService_GetClients_Handler,
+		},
+		{
+			MethodName: "UpdateClient",
+			Handler:    _ClientsService_UpdateClient_Handler,
+		},
+		{
+			MethodName: "DeleteClient",
+			Handler:    _ClientsService_DeleteClient_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "sso/clients.proto",
+}
@@ -24,6 +24,8 @@ type UsersServiceClient interface {
 	GetUsers(ctx context.Context, in *GetUsersIn, opts ...grpc.CallOption) (*GetUsersOut, error)
 	GetMe(ctx context.Context, in *GetMeIn, opts ...grpc.CallOption) (*GetUserOut, error)
 	UpdateUserProfile(ctx context.Context, in *UpdateUserProfileIn, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UnlockUser(ctx context.Context, in *UnlockUserIn, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ImportUsers(ctx context.Context, in *ImportUsersIn, opts ...grpc.CallOption) (*ImportUsersOut, error)
 }
 
 type usersServiceClient struct {
@@ -79,6 +81,24 @@ func (c *usersServiceClient) UpdateUserProfile(ctx context.Context, in *UpdateUs
 	return out, nil
 }
 
+func (c *usersServiceClient) UnlockUser(ctx context.Context, in *UnlockUserIn, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/users.UsersService/UnlockUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersServiceClient) ImportUsers(ctx context.Context, in *ImportUsersIn, opts ...grpc.CallOption) (*ImportUsersOut, error) {
+	out := new(ImportUsersOut)
+	err := c.cc.Invoke(ctx, "/users.UsersService/ImportUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UsersServiceServer is the server API for UsersService service.
 // All implementations must embed UnimplementedUsersServiceServer
 // for forward compatibility
@@ -88,6 +108,8 @@ type UsersServiceServer interface {
 	GetUsers(context.Context, *GetUsersIn) (*GetUsersOut, error)
 	GetMe(context.Context, *GetMeIn) (*GetUserOut, error)
 	UpdateUserProfile(context.Context, *UpdateUserProfileIn) (*emptypb.Empty, error)
+	UnlockUser(context.Context, *UnlockUserIn) (*emptypb.Empty, error)
+	ImportUsers(context.Context, *ImportUsersIn) (*ImportUsersOut, error)
 	mustEmbedUnimplementedUsersServiceServer()
 }
 
@@ -110,6 +132,12 @@ func (UnimplementedUsersServiceServer) GetMe(context.Context, *GetMeIn) (*GetUse
 func (UnimplementedUsersServiceServer) UpdateUserProfile(context.Context, *UpdateUserProfileIn) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserProfile not implemented")
 }
+func (UnimplementedUsersServiceServer) UnlockUser(context.Context, *UnlockUserIn) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlockUser not implemented")
+}
+func (UnimplementedUsersServiceServer) ImportUsers(context.Context, *ImportUsersIn) (*ImportUsersOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportUsers not implemented")
+}
 func (UnimplementedUsersServiceServer) mustEmbedUnimplementedUsersServiceServer() {}
 
 // UnsafeUsersServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -213,6 +241,42 @@ func _UsersService_UpdateUserProfile_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UsersService_UnlockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockUserIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).UnlockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/users.UsersService/UnlockUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).UnlockUser(ctx, req.(*UnlockUserIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsersService_ImportUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportUsersIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).ImportUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/users.UsersService/ImportUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).ImportUsers(ctx, req.(*ImportUsersIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UsersService_ServiceDesc is the grpc.ServiceDesc for UsersService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -240,6 +304,14 @@ var UsersService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateUserProfile",
 			Handler:    _UsersService_UpdateUserProfile_Handler,
 		},
+		{
+			MethodName: "UnlockUser",
+			Handler:    _UsersService_UnlockUser_Handler,
+		},
+		{
+			MethodName: "ImportUsers",
+			Handler:    _UsersService_ImportUsers_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "sso/users.proto",
@@ -9,6 +9,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/DKhorkov/hmtm-sso/api/protobuf/generated/go/sso"
 )
@@ -36,7 +37,17 @@ func main() {
 
 	ctx := metadata.AppendToOutgoingContext(context.Background(), requestid.Key, requestid.New())
 
-	users, err := client.GetUsers(ctx, &sso.GetUsersIn{})
+	// Internal RPCs require token of backend service with users:read and tokens:introspect scopes:
+	clientToken, err := client.IssueClientToken(ctx, &sso.IssueClientTokenIn{
+		ClientID:     "notifications",
+		ClientSecret: "secret from RegisterClient response",
+		Scope:        "users:read tokens:introspect",
+	})
+	fmt.Println("IssueClientToken: ", clientToken, err)
+
+	serviceCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+clientToken.GetAccessToken())
+
+	users, err := client.GetUsers(serviceCtx, &sso.GetUsersIn{})
 	fmt.Println(users, err)
 
 	userID, err := client.Register(ctx, &sso.RegisterIn{
@@ -52,12 +63,43 @@ func main() {
 	})
 	fmt.Println(tokens, err)
 
+	jwks, err := client.GetJWKS(ctx, &emptypb.Empty{})
+	fmt.Println(jwks, err)
+
+	introspection, err := client.IntrospectToken(serviceCtx, &sso.IntrospectTokenIn{
+		Token: tokens.GetAccessToken(),
+	})
+	fmt.Println(introspection, err)
+
+	enrollment, err := client.StartTOTPEnrollment(ctx, &sso.StartTOTPEnrollmentIn{
+		AccessToken: tokens.GetAccessToken(),
+	})
+	fmt.Println(enrollment, err)
+
+	webAuthnOptions, err := client.BeginWebAuthnRegistration(ctx, &sso.BeginWebAuthnRegistrationIn{
+		AccessToken: tokens.GetAccessToken(),
+	})
+	fmt.Println(webAuthnOptions, err)
+
+	sessions, err := client.ListSessions(ctx, &sso.ListSessionsIn{
+		AccessToken: tokens.GetAccessToken(),
+	})
+	fmt.Println(sessions, err)
+
+	_, err = client.LogoutEverywhere(ctx, &sso.LogoutEverywhereIn{
+		AccessToken: tokens.GetAccessToken(),
+	})
+	fmt.Println(err)
+
 	_, logoutErr := client.Logout(ctx, &sso.LogoutIn{
 		AccessToken: tokens.GetAccessToken(),
 	})
 	fmt.Println(logoutErr)
 
-	_, err = client.VerifyEmail(ctx, &sso.VerifyEmailIn{VerifyEmailToken: "MzE"})
+	_, err = client.VerifyEmail(ctx, &sso.VerifyEmailIn{VerifyEmailToken: "token from verify-email message"})
+	fmt.Println(err)
+
+	_, err = client.VerifyEmailByCode(ctx, &sso.VerifyEmailByCodeIn{Email: "alexqwerty35@yandex.ru", Code: "123456"})
 	fmt.Println(err)
 
 	_, err = client.SendVerifyEmailMessage(
@@ -66,6 +108,15 @@ func main() {
 	)
 	fmt.Printf("%v\n", err)
 
+	_, err = client.SendLoginLink(ctx, &sso.SendLoginLinkIn{Email: "alexqwerty35@yandex.ru"})
+	fmt.Println(err)
+
+	loginWithCodeTokens, err := client.LoginWithCode(ctx, &sso.LoginWithCodeIn{
+		Email: "alexqwerty35@yandex.ru",
+		Code:  "code from login-link message",
+	})
+	fmt.Println(loginWithCodeTokens, err)
+
 	_, err = client.ChangePassword(
 		ctx,
 		&sso.ChangePasswordIn{
@@ -76,7 +127,7 @@ func main() {
 	)
 	fmt.Println(err)
 
-	_, err = client.ForgetPassword(ctx, &sso.ForgetPasswordIn{ForgetPasswordToken: "MzE", NewPassword: "Qwer1234@"})
+	_, err = client.ForgetPassword(ctx, &sso.ForgetPasswordIn{ForgetPasswordToken: "token from forget-password message", NewPassword: "Qwer1234@"})
 	fmt.Println(err)
 
 	_, err = client.UpdateUserProfile(ctx, &sso.UpdateUserProfileIn{
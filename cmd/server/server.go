@@ -2,8 +2,9 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 
-	"github.com/DKhorkov/libs/cache"
 	"github.com/DKhorkov/libs/db"
 	"github.com/DKhorkov/libs/logging"
 	"github.com/DKhorkov/libs/tracing"
@@ -14,8 +15,16 @@ import (
 	"github.com/DKhorkov/hmtm-sso/internal/app"
 	"github.com/DKhorkov/hmtm-sso/internal/config"
 	grpccontroller "github.com/DKhorkov/hmtm-sso/internal/controllers/grpc"
+	httpcontroller "github.com/DKhorkov/hmtm-sso/internal/controllers/http"
+	"github.com/DKhorkov/hmtm-sso/internal/federation"
+	"github.com/DKhorkov/hmtm-sso/internal/interfaces"
+	"github.com/DKhorkov/hmtm-sso/internal/limiter"
+	"github.com/DKhorkov/hmtm-sso/internal/memorycache"
+	"github.com/DKhorkov/hmtm-sso/internal/passwords"
+	"github.com/DKhorkov/hmtm-sso/internal/rediscache"
 	"github.com/DKhorkov/hmtm-sso/internal/repositories"
 	"github.com/DKhorkov/hmtm-sso/internal/services"
+	"github.com/DKhorkov/hmtm-sso/internal/signing"
 	"github.com/DKhorkov/hmtm-sso/internal/usecases"
 )
 
@@ -56,11 +65,7 @@ func main() {
 		}
 	}()
 
-	cacheProvider, err := cache.New(
-		cache.WithHost(settings.Cache.Host),
-		cache.WithPort(settings.Cache.Port),
-		cache.WithPassword(settings.Cache.Password),
-	)
+	cacheProvider, err := newCacheProvider(settings.Cache)
 	if err != nil {
 		panic(err)
 	}
@@ -110,10 +115,35 @@ func main() {
 		logger,
 	)
 
+	jwtProvider, err := signing.New(settings.Security.JWT, settings.JWTKeys)
+	if err != nil {
+		panic(err)
+	}
+
+	passwordHasher, err := passwords.New(settings.Passwords)
+	if err != nil {
+		panic(err)
+	}
+
+	federationHTTPClient := &http.Client{Timeout: settings.Federation.Timeout}
+	identityProviders := make([]interfaces.IdentityProvider, 0, len(settings.Federation.Providers))
+	for _, providerConfig := range settings.Federation.Providers {
+		identityProviders = append(identityProviders, federation.New(providerConfig, federationHTTPClient))
+	}
+
 	useCases := usecases.New(
 		authService,
 		usersService,
 		settings.Security,
+		jwtProvider,
+		passwordHasher,
+		settings.AccessTokens,
+		settings.Tokens,
+		settings.WebAuthn,
+		settings.OIDC,
+		identityProviders,
+		settings.Telegram,
+		settings.Lockout,
 		settings.Validation,
 		natsPublisher,
 		settings.NATS,
@@ -125,11 +155,38 @@ func main() {
 		settings.HTTP.Host,
 		settings.HTTP.Port,
 		useCases,
+		limiter.New(cacheProvider),
+		settings.RateLimits,
+		settings.HTTP.TrustedProxies,
 		logger,
 		traceProvider,
 		settings.Tracing.Spans.Root,
 	)
 
-	application := app.New(controller)
+	httpController := httpcontroller.New(
+		settings.Web.Host,
+		settings.Web.Port,
+		useCases,
+		settings.Web.TrustedProxies,
+		logger,
+	)
+
+	application := app.New(controller, httpController)
 	application.Run()
 }
+
+func newCacheProvider(cacheConfig config.CacheConfig) (interfaces.CacheProvider, error) {
+	switch cacheConfig.Driver {
+	case config.RedisCacheDriver:
+		redisCache, err := rediscache.New(cacheConfig.Host, cacheConfig.Port, cacheConfig.Password)
+		if err != nil {
+			return nil, err
+		}
+
+		return redisCache, nil
+	case config.MemoryCacheDriver:
+		return memorycache.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported cache driver: %s", cacheConfig.Driver)
+	}
+}
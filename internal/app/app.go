@@ -8,19 +8,21 @@ import (
 	"github.com/DKhorkov/hmtm-sso/internal/interfaces"
 )
 
-func New(controller interfaces.Controller) *App {
+func New(controllers ...interfaces.Controller) *App {
 	return &App{
-		controller: controller,
+		controllers: controllers,
 	}
 }
 
 type App struct {
-	controller interfaces.Controller
+	controllers []interfaces.Controller
 }
 
 func (application *App) Run() {
 	// Launch asynchronous for graceful shutdown purpose:
-	go application.controller.Run()
+	for _, controller := range application.controllers {
+		go controller.Run()
+	}
 
 	// Graceful shutdown. When system signal will be received, signal.Notify function will write it to channel.
 	// After this event, main goroutine will be unblocked (<-stopChannel blocks it) and application will be
@@ -28,5 +30,8 @@ func (application *App) Run() {
 	stopChannel := make(chan os.Signal, 1)
 	signal.Notify(stopChannel, syscall.SIGINT, syscall.SIGTERM)
 	<-stopChannel
-	application.controller.Stop()
+
+	for _, controller := range application.controllers {
+		controller.Stop()
+	}
 }
@@ -0,0 +1,63 @@
+// Package clientip resolves client's IP of requests, which may be proxied. Proxy headers are honored only for
+// connections from trusted proxies, because other callers can forge them.
+package clientip
+
+import (
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// Resolve returns client's IP by address of connection peer and values of X-Forwarded-For and X-Real-IP headers.
+// If peer is not one of trusted proxies, headers are ignored and IP of peer is returned.
+func Resolve(peerAddr, forwardedFor, realIP string, trustedProxies []netip.Prefix) string {
+	ip := peerAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+
+	peerIP, _ := netip.ParseAddr(ip)
+	if !isTrustedProxy(peerIP, trustedProxies) {
+		return ip
+	}
+
+	if forwardedFor != "" {
+		return getForwardedClientIP(forwardedFor, trustedProxies)
+	}
+
+	if realIP != "" {
+		return realIP
+	}
+
+	return ip
+}
+
+// getForwardedClientIP returns client's IP from X-Forwarded-For, which contains chain of addresses, where each proxy
+// appends address of its peer. Client can put any addresses to the beginning of chain, so chain is walked from
+// the end, and first address, which does not belong to trusted proxy, is client's one.
+func getForwardedClientIP(forwardedFor string, trustedProxies []netip.Prefix) string {
+	chain := strings.Split(forwardedFor, ",")
+	for i := len(chain) - 1; i > 0; i-- {
+		ip, err := netip.ParseAddr(strings.TrimSpace(chain[i]))
+		if err != nil || !isTrustedProxy(ip, trustedProxies) {
+			return strings.TrimSpace(chain[i])
+		}
+	}
+
+	// All addresses belong to trusted proxies, so first one is the closest to client:
+	return strings.TrimSpace(chain[0])
+}
+
+func isTrustedProxy(ip netip.Addr, trustedProxies []netip.Prefix) bool {
+	if !ip.IsValid() {
+		return false
+	}
+
+	for _, proxy := range trustedProxies {
+		if proxy.Contains(ip.Unmap()) {
+			return true
+		}
+	}
+
+	return false
+}
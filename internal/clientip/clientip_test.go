@@ -0,0 +1,82 @@
+package clientip
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolve(t *testing.T) {
+	trustedProxies := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/24"),
+		netip.MustParsePrefix("192.168.1.5/32"),
+	}
+
+	testCases := []struct {
+		name         string
+		peerAddr     string
+		forwardedFor string
+		realIP       string
+		expected     string
+	}{
+		{
+			name:     "no peer",
+			expected: "",
+		},
+		{
+			name:     "IP from peer",
+			peerAddr: "203.0.113.7:52341",
+			expected: "203.0.113.7",
+		},
+		{
+			name:     "IP from peer without port",
+			peerAddr: "203.0.113.7",
+			expected: "203.0.113.7",
+		},
+		{
+			name:         "IP from x-forwarded-for",
+			peerAddr:     "10.0.0.1:52341",
+			forwardedFor: "203.0.113.7, 10.0.0.2",
+			realIP:       "10.0.0.2",
+			expected:     "203.0.113.7",
+		},
+		{
+			name:         "forged IP in x-forwarded-for",
+			peerAddr:     "10.0.0.1:52341",
+			forwardedFor: "1.1.1.1, 203.0.113.7, 192.168.1.5",
+			expected:     "203.0.113.7",
+		},
+		{
+			name:         "x-forwarded-for with trusted proxies only",
+			peerAddr:     "10.0.0.1:52341",
+			forwardedFor: "10.0.0.3, 10.0.0.2",
+			expected:     "10.0.0.3",
+		},
+		{
+			name:     "IP from x-real-ip",
+			peerAddr: "192.168.1.5:52341",
+			realIP:   "203.0.113.7",
+			expected: "203.0.113.7",
+		},
+		{
+			name:         "headers from untrusted peer",
+			peerAddr:     "198.51.100.9:52341",
+			forwardedFor: "203.0.113.7",
+			realIP:       "203.0.113.7",
+			expected:     "198.51.100.9",
+		},
+		{
+			name:         "IPv4-mapped IPv6 peer",
+			peerAddr:     "[::ffff:10.0.0.1]:52341",
+			forwardedFor: "203.0.113.7",
+			expected:     "203.0.113.7",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, Resolve(tc.peerAddr, tc.forwardedFor, tc.realIP, trustedProxies))
+		})
+	}
+}
@@ -2,6 +2,9 @@ package config
 
 import (
 	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/DKhorkov/libs/db"
@@ -20,9 +23,18 @@ func New() Config {
 		HTTP: HTTPConfig{
 			Host: loadenv.GetEnv("HOST", "0.0.0.0"),
 			Port: loadenv.GetEnvAsInt("PORT", 8070),
+			TrustedProxies: loadTrustedProxies(
+				loadenv.GetEnvAsSlice("TRUSTED_PROXIES", []string{}, ","),
+			),
+		},
+		Web: HTTPConfig{
+			Host: loadenv.GetEnv("WEB_HOST", "0.0.0.0"),
+			Port: loadenv.GetEnvAsInt("WEB_PORT", 8071),
+			TrustedProxies: loadTrustedProxies(
+				loadenv.GetEnvAsSlice("TRUSTED_PROXIES", []string{}, ","),
+			),
 		},
 		Security: security.Config{
-			HashCost: loadenv.GetEnvAsInt("HASH_COST", 8), // Auth speed sensitive if large
 			JWT: security.JWTConfig{
 				RefreshTokenTTL: time.Hour * time.Duration(
 					loadenv.GetEnvAsInt("REFRESH_TOKEN_JWT_TTL", 168),
@@ -34,6 +46,127 @@ func New() Config {
 				SecretKey: loadenv.GetEnv("JWT_SECRET", "defaultSecret"),
 			},
 		},
+		Passwords: PasswordsConfig{
+			Algorithm: loadenv.GetEnv("PASSWORD_HASH_ALGORITHM", Argon2idPasswordHashAlgorithm),
+
+			// Defaults are minimal parameters, recommended by OWASP:
+			Argon2id: Argon2idConfig{
+				Memory:      uint32(loadenv.GetEnvAsInt("ARGON2ID_MEMORY", 19456)), //nolint:gosec // set by administrator
+				Iterations:  uint32(loadenv.GetEnvAsInt("ARGON2ID_ITERATIONS", 2)), //nolint:gosec // set by administrator
+				Parallelism: uint8(loadenv.GetEnvAsInt("ARGON2ID_PARALLELISM", 1)), //nolint:gosec // set by administrator
+			},
+			BcryptCost: loadenv.GetEnvAsInt("HASH_COST", 12), // Auth speed sensitive if large
+		},
+		JWTKeys: JWTKeysConfig{
+			SigningKeyID:   loadenv.GetEnv("JWT_SIGNING_KEY_ID", ""),
+			SigningKeyPath: loadenv.GetEnv("JWT_SIGNING_KEY_PATH", ""),
+			VerificationKeys: loadenv.GetEnvAsSlice(
+				"JWT_VERIFICATION_KEYS",
+				[]string{},
+				";",
+			),
+		},
+		AccessTokens: AccessTokensConfig{
+			Issuer:   loadenv.GetEnv("JWT_ISSUER", "hmtm-sso"),
+			Audience: loadenv.GetEnv("JWT_AUDIENCE", "hmtm"),
+			RevocationPolicy: loadRevocationPolicy(
+				loadenv.GetEnv(
+					"ACCESS_TOKEN_REVOCATION_POLICY",
+					FailOpenRevocationPolicy,
+				),
+			),
+		},
+		Tokens: TokensConfig{
+			SecretKey: loadenv.GetEnv("TOKENS_SECRET", "defaultSecret"),
+			VerifyEmail: TokenConfig{
+				TTL: time.Hour * time.Duration(
+					loadenv.GetEnvAsInt("VERIFY_EMAIL_TOKEN_TTL", 24),
+				),
+			},
+			ForgetPassword: TokenConfig{
+				TTL: time.Minute * time.Duration(
+					loadenv.GetEnvAsInt("FORGET_PASSWORD_TOKEN_TTL", 30),
+				),
+			},
+			MFAChallenge: TokenConfig{
+				TTL: time.Minute * time.Duration(
+					loadenv.GetEnvAsInt("MFA_CHALLENGE_TTL", 5),
+				),
+			},
+			Login: TokenConfig{
+				TTL: time.Minute * time.Duration(
+					loadenv.GetEnvAsInt("LOGIN_TOKEN_TTL", 15),
+				),
+			},
+			PhoneVerification: TokenConfig{
+				TTL: time.Minute * time.Duration(
+					loadenv.GetEnvAsInt("PHONE_VERIFICATION_CODE_TTL", 5),
+				),
+			},
+			WebAuthnChallenge: TokenConfig{
+				TTL: time.Minute * time.Duration(
+					loadenv.GetEnvAsInt("WEBAUTHN_CHALLENGE_TTL", 5),
+				),
+			},
+			AuthorizationCode: TokenConfig{
+				TTL: time.Second * time.Duration(
+					loadenv.GetEnvAsInt("AUTHORIZATION_CODE_TTL", 60),
+				),
+			},
+			FederatedLoginState: TokenConfig{
+				TTL: time.Minute * time.Duration(
+					loadenv.GetEnvAsInt("FEDERATED_LOGIN_STATE_TTL", 10),
+				),
+			},
+		},
+		Telegram: TelegramConfig{
+			BotToken: loadenv.GetEnv("TELEGRAM_BOT_TOKEN", ""),
+			AuthTTL: time.Minute * time.Duration(
+				loadenv.GetEnvAsInt("TELEGRAM_AUTH_TTL", 5),
+			),
+		},
+		Lockout: LockoutConfig{
+			Window: time.Minute * time.Duration(
+				loadenv.GetEnvAsInt("LOGIN_FAILURES_WINDOW", 15),
+			),
+			Threshold:   int64(loadenv.GetEnvAsInt("LOGIN_LOCKOUT_THRESHOLD", 10)),
+			IPThreshold: int64(loadenv.GetEnvAsInt("LOGIN_LOCKOUT_IP_THRESHOLD", 50)),
+			BaseDelay: time.Second * time.Duration(
+				loadenv.GetEnvAsInt("LOGIN_BASE_DELAY", 1),
+			),
+			MaxDelay: time.Second * time.Duration(
+				loadenv.GetEnvAsInt("LOGIN_MAX_DELAY", 60),
+			),
+			Duration: time.Minute * time.Duration(
+				loadenv.GetEnvAsInt("LOGIN_LOCKOUT_DURATION", 15),
+			),
+		},
+		RateLimits: RateLimitsConfig{
+			Policies: loadRateLimitPolicies(
+				loadenv.GetEnvAsSlice("RATE_LIMIT_POLICIES", defaultRateLimitPolicies, ";"),
+			),
+		},
+		Federation: FederationConfig{
+			Timeout: time.Second * time.Duration(
+				loadenv.GetEnvAsInt("FEDERATION_TIMEOUT", 10),
+			),
+			Providers: loadIdentityProviders(
+				loadenv.GetEnvAsSlice("FEDERATION_PROVIDERS", []string{}, ","),
+			),
+		},
+		OIDC: OIDCConfig{
+			Issuer:   loadenv.GetEnv("OIDC_ISSUER", "http://localhost:8071"),
+			LoginURL: loadenv.GetEnv("OIDC_LOGIN_URL", "http://localhost:8080/login"),
+		},
+		WebAuthn: WebAuthnConfig{
+			RPID:   loadenv.GetEnv("WEBAUTHN_RP_ID", "localhost"),
+			RPName: loadenv.GetEnv("WEBAUTHN_RP_NAME", "Handmade Toys Marketplace"),
+			Origins: loadenv.GetEnvAsSlice(
+				"WEBAUTHN_ORIGINS",
+				[]string{"http://localhost:8080"},
+				",",
+			),
+		},
 		Database: db.Config{
 			Host:         loadenv.GetEnv("POSTGRES_HOST", "0.0.0.0"),
 			Port:         loadenv.GetEnvAsInt("POSTGRES_PORT", 5432),
@@ -219,12 +352,16 @@ func New() Config {
 			Subjects: NATSSubjects{
 				VerifyEmail:    loadenv.GetEnv("NATS_VERIFY_EMAIL_SUBJECT", "verify-email"),
 				ForgetPassword: loadenv.GetEnv("NATS_FORGET_PASSWORD_SUBJECT", "forget-password"),
+				SecurityEvent:  loadenv.GetEnv("NATS_SECURITY_EVENT_SUBJECT", "security-event"),
+				LoginLink:      loadenv.GetEnv("NATS_LOGIN_LINK_SUBJECT", "login-link"),
+				SMS:            loadenv.GetEnv("NATS_SMS_SUBJECT", "sms"),
 			},
 			Publisher: NATSPublisher{
 				Name: loadenv.GetEnv("NATS_PUBLISHER_NAME", "hmtm-sso-publisher"),
 			},
 		},
 		Cache: CacheConfig{
+			Driver:   loadenv.GetEnv("CACHE_DRIVER", RedisCacheDriver),
 			Password: loadenv.GetEnv("REDIS_PASSWORD", ""),
 			Host:     loadenv.GetEnv("REDIS_HOST", "0.0.0.0"),
 
@@ -235,8 +372,9 @@ func New() Config {
 }
 
 type HTTPConfig struct {
-	Host string
-	Port int
+	Host           string
+	Port           int
+	TrustedProxies []netip.Prefix // proxy headers are accepted only from these addresses
 }
 
 type ValidationConfig struct {
@@ -247,6 +385,332 @@ type ValidationConfig struct {
 	TelegramRegExps    []string
 }
 
+const (
+	// Argon2idPasswordHashAlgorithm is memory-hard and does not limit length of passwords.
+	Argon2idPasswordHashAlgorithm = "argon2id"
+
+	// BcryptPasswordHashAlgorithm is kept for compatibility. Passwords longer than 72 bytes are rejected.
+	BcryptPasswordHashAlgorithm = "bcrypt"
+)
+
+// PasswordsConfig is used for hashing of Users' passwords. New passwords are hashed with Algorithm,
+// while hashes of other algorithms or with other parameters are still verified and are rehashed on login.
+type PasswordsConfig struct {
+	Algorithm  string
+	Argon2id   Argon2idConfig
+	BcryptCost int
+}
+
+type Argon2idConfig struct {
+	Memory      uint32 // KiB
+	Iterations  uint32
+	Parallelism uint8
+}
+
+// JWTKeysConfig is used for asymmetric JWT algorithms (RS256, ES256, EdDSA, etc.).
+// For HMAC algorithms tokens are signed with shared JWT secret.
+type JWTKeysConfig struct {
+	SigningKeyID   string // "kid" header of issued tokens
+	SigningKeyPath string // PEM file with private key
+
+	// "kid=path" pairs of PEM files with keys, which are still accepted during rotation:
+	VerificationKeys []string
+}
+
+const (
+	// FailOpenRevocationPolicy accepts access tokens, when revocation list is unavailable.
+	FailOpenRevocationPolicy = "fail-open"
+
+	// FailClosedRevocationPolicy rejects access tokens, when revocation list is unavailable.
+	FailClosedRevocationPolicy = "fail-closed"
+)
+
+type AccessTokensConfig struct {
+	// Values of "iss" and "aud" claims, which are checked for each access token:
+	Issuer   string
+	Audience string
+
+	RevocationPolicy string
+}
+
+// loadRevocationPolicy checks configured revocation policy. Unknown policy is not replaced with default one,
+// because misspelled fail-closed policy would silently accept revoked tokens.
+func loadRevocationPolicy(policy string) string {
+	if policy != FailOpenRevocationPolicy && policy != FailClosedRevocationPolicy {
+		panic(fmt.Sprintf("invalid access token revocation policy %q", policy))
+	}
+
+	return policy
+}
+
+type TokensConfig struct {
+	SecretKey         string // Used for HMAC of verification tokens and codes and encryption of TOTP secrets.
+	VerifyEmail       TokenConfig
+	ForgetPassword    TokenConfig
+	MFAChallenge      TokenConfig // issued by Login to Users with enabled two-factor authentication
+	Login             TokenConfig // magic link and one-time code for passwordless login
+	PhoneVerification TokenConfig // one-time code, which is sent via SMS to verify phone
+	WebAuthnChallenge TokenConfig // signed by authenticator during passkey registration and login
+	AuthorizationCode TokenConfig // issued to OpenID Connect clients and exchanged for tokens
+
+	// State of login via external identity provider, which is passed through provider back to SSO:
+	FederatedLoginState TokenConfig
+}
+
+type TokenConfig struct {
+	TTL time.Duration
+}
+
+// WebAuthnConfig describes Relying Party for passkeys. RPID is domain, which credentials are bound to,
+// so changing it makes all registered passkeys unusable.
+type WebAuthnConfig struct {
+	RPID    string
+	RPName  string
+	Origins []string // origins of frontends, which are allowed to use passkeys
+}
+
+// OIDCConfig describes OpenID Connect provider. Issuer is public URL of HTTP server for public endpoints.
+// Users, who are not logged in, are redirected to LoginURL with authorization request URL in "return_to" parameter.
+type OIDCConfig struct {
+	Issuer   string
+	LoginURL string
+}
+
+// IdentityProviderConfig describes external OAuth 2.0 or OpenID Connect provider, which Users can log in with.
+// Claims are names of fields in response of user info endpoint, nested fields are separated by dots.
+type IdentityProviderConfig struct {
+	Name         string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string // page of frontend, which receives code and state from provider
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+	Scopes       []string
+
+	// Scheme of Authorization header for user info endpoint, some providers do not accept "Bearer":
+	UserInfoAuthScheme string
+
+	SubjectClaim       string
+	EmailClaim         string
+	EmailVerifiedClaim string // if empty, provider returns only verified emails
+	NameClaim          string
+}
+
+// TelegramConfig is used for login via Telegram Login Widget. Login is disabled, if bot token is not set.
+type TelegramConfig struct {
+	BotToken string
+	AuthTTL  time.Duration // max age of auth data, which has been signed by Telegram
+}
+
+// LockoutConfig protects password login from brute force. Failed attempts are counted per account and per client IP
+// during Window. Each failed attempt delays next attempt to account, starting with BaseDelay and doubling up to MaxDelay.
+// After Threshold failed attempts to account or IPThreshold failed attempts from IP login is locked for Duration.
+type LockoutConfig struct {
+	Window      time.Duration
+	Threshold   int64
+	IPThreshold int64 // higher, than Threshold, since IP can be shared by many Users
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	Duration    time.Duration
+}
+
+const (
+	// IPRateLimitKey counts calls from each client IP separately.
+	IPRateLimitKey = "ip"
+
+	// UserRateLimitKey counts calls of each User, who owns access token from request, separately.
+	UserRateLimitKey = "user"
+
+	// EmailRateLimitKey counts calls with each email from request separately.
+	EmailRateLimitKey = "email"
+
+	// PhoneRateLimitKey counts calls for each phone from profile of User, who owns access token from request,
+	// separately. So several accounts with the same phone share limit.
+	PhoneRateLimitKey = "phone"
+)
+
+// RateLimitPolicy allows Limit calls of gRPC method during Window for each value of Key.
+type RateLimitPolicy struct {
+	Method string // full name of method, for example "/auth.AuthService/Login"
+	Key    string
+	Limit  int64
+	Window time.Duration
+}
+
+type RateLimitsConfig struct {
+	Policies []RateLimitPolicy
+}
+
+// defaultRateLimitPolicies are written in "<method> <key> <limit>/<window>" format of RATE_LIMIT_POLICIES.
+var defaultRateLimitPolicies = []string{
+	"/auth.AuthService/SendVerifyEmailMessage email 3/1m",
+	"/auth.AuthService/SendVerifyEmailMessage ip 30/1h",
+	"/auth.AuthService/SendForgetPasswordMessage email 3/1m",
+	"/auth.AuthService/SendForgetPasswordMessage ip 30/1h",
+	"/auth.AuthService/SendLoginLink email 3/1m",
+	"/auth.AuthService/SendLoginLink ip 30/1h",
+	"/auth.AuthService/Register ip 20/1h",
+	"/auth.AuthService/SendPhoneVerificationCode phone 1/1m",
+	"/auth.AuthService/SendPhoneVerificationCode phone 5/24h",
+	"/auth.AuthService/SendPhoneVerificationCode ip 30/1h",
+}
+
+// loadTrustedProxies parses addresses and networks in CIDR notation of proxies, which pass client's IP in headers.
+func loadTrustedProxies(specs []string) []netip.Prefix {
+	proxies := make([]netip.Prefix, 0, len(specs))
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+
+		if !strings.Contains(spec, "/") {
+			addr, err := netip.ParseAddr(spec)
+			if err != nil {
+				panic(fmt.Sprintf("invalid trusted proxy %q", spec))
+			}
+
+			proxies = append(proxies, netip.PrefixFrom(addr, addr.BitLen()))
+
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(spec)
+		if err != nil {
+			panic(fmt.Sprintf("invalid trusted proxy %q", spec))
+		}
+
+		proxies = append(proxies, prefix.Masked())
+	}
+
+	return proxies
+}
+
+// loadRateLimitPolicies parses policies in "<method> <key> <limit>/<window>" format.
+// Invalid policy is not skipped, because service should not start without expected limits.
+func loadRateLimitPolicies(specs []string) []RateLimitPolicy {
+	policies := make([]RateLimitPolicy, 0, len(specs))
+	for _, spec := range specs {
+		if strings.TrimSpace(spec) == "" {
+			continue
+		}
+
+		fields := strings.Fields(spec)
+		if len(fields) != 3 {
+			panic(fmt.Sprintf("invalid rate limit policy %q", spec))
+		}
+
+		if fields[1] != IPRateLimitKey && fields[1] != UserRateLimitKey && fields[1] != EmailRateLimitKey &&
+			fields[1] != PhoneRateLimitKey {
+			panic(fmt.Sprintf("invalid key of rate limit policy %q", spec))
+		}
+
+		strLimit, strWindow, _ := strings.Cut(fields[2], "/")
+		limit, err := strconv.ParseInt(strLimit, 10, 64)
+		if err != nil || limit <= 0 {
+			panic(fmt.Sprintf("invalid limit of rate limit policy %q", spec))
+		}
+
+		window, err := time.ParseDuration(strWindow)
+		if err != nil || window <= 0 {
+			panic(fmt.Sprintf("invalid window of rate limit policy %q", spec))
+		}
+
+		policies = append(
+			policies,
+			RateLimitPolicy{
+				Method: fields[0],
+				Key:    fields[1],
+				Limit:  limit,
+				Window: window,
+			},
+		)
+	}
+
+	return policies
+}
+
+type FederationConfig struct {
+	Timeout   time.Duration // of requests to identity providers
+	Providers []IdentityProviderConfig
+}
+
+// identityProviderPresets contain endpoints and claims of well-known providers, so only credentials
+// of registered application should be configured for them.
+var identityProviderPresets = map[string]IdentityProviderConfig{
+	"google": {
+		AuthURL:            "https://accounts.google.com/o/oauth2/v2/auth",
+		TokenURL:           "https://oauth2.googleapis.com/token",
+		UserInfoURL:        "https://openidconnect.googleapis.com/v1/userinfo",
+		Scopes:             []string{"openid", "email", "profile"},
+		UserInfoAuthScheme: "Bearer",
+		SubjectClaim:       "sub",
+		EmailClaim:         "email",
+		EmailVerifiedClaim: "email_verified",
+		NameClaim:          "name",
+	},
+	"yandex": {
+		AuthURL:            "https://oauth.yandex.ru/authorize",
+		TokenURL:           "https://oauth.yandex.ru/token",
+		UserInfoURL:        "https://login.yandex.ru/info?format=json",
+		Scopes:             []string{"login:email", "login:info"},
+		UserInfoAuthScheme: "OAuth",
+		SubjectClaim:       "id",
+		EmailClaim:         "default_email",
+		NameClaim:          "real_name",
+	},
+}
+
+// loadIdentityProviders reads settings of each provider from environment variables with provider's prefix.
+// Settings of well-known providers default to their presets.
+func loadIdentityProviders(names []string) []IdentityProviderConfig {
+	providers := make([]IdentityProviderConfig, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		preset, ok := identityProviderPresets[name]
+		if !ok {
+			preset = IdentityProviderConfig{
+				Scopes:             []string{"openid", "email", "profile"},
+				UserInfoAuthScheme: "Bearer",
+				SubjectClaim:       "sub",
+				EmailClaim:         "email",
+				EmailVerifiedClaim: "email_verified",
+				NameClaim:          "name",
+			}
+		}
+
+		prefix := "FEDERATION_" + strings.ToUpper(name) + "_"
+		providers = append(
+			providers,
+			IdentityProviderConfig{
+				Name:         name,
+				ClientID:     loadenv.GetEnv(prefix+"CLIENT_ID", ""),
+				ClientSecret: loadenv.GetEnv(prefix+"CLIENT_SECRET", ""),
+				RedirectURL: loadenv.GetEnv(
+					prefix+"REDIRECT_URL",
+					"http://localhost:8080/login/"+name+"/callback",
+				),
+				AuthURL:            loadenv.GetEnv(prefix+"AUTH_URL", preset.AuthURL),
+				TokenURL:           loadenv.GetEnv(prefix+"TOKEN_URL", preset.TokenURL),
+				UserInfoURL:        loadenv.GetEnv(prefix+"USERINFO_URL", preset.UserInfoURL),
+				Scopes:             loadenv.GetEnvAsSlice(prefix+"SCOPES", preset.Scopes, " "),
+				UserInfoAuthScheme: loadenv.GetEnv(prefix+"USERINFO_AUTH_SCHEME", preset.UserInfoAuthScheme),
+				SubjectClaim:       loadenv.GetEnv(prefix+"SUBJECT_CLAIM", preset.SubjectClaim),
+				EmailClaim:         loadenv.GetEnv(prefix+"EMAIL_CLAIM", preset.EmailClaim),
+				EmailVerifiedClaim: loadenv.GetEnv(prefix+"EMAIL_VERIFIED_CLAIM", preset.EmailVerifiedClaim),
+				NameClaim:          loadenv.GetEnv(prefix+"NAME_CLAIM", preset.NameClaim),
+			},
+		)
+	}
+
+	return providers
+}
+
 type TracingConfig struct {
 	Server tracing.Config
 	Spans  SpansConfig
@@ -271,27 +735,52 @@ type NATSConfig struct {
 type NATSSubjects struct {
 	VerifyEmail    string
 	ForgetPassword string
+	SecurityEvent  string
+	LoginLink      string
+	SMS            string // one-time codes, which are sent to User's phone
 }
 
 type NATSPublisher struct {
 	Name string
 }
 
+const (
+	// RedisCacheDriver stores cache in Redis, so it is shared by all instances of service.
+	RedisCacheDriver = "redis"
+
+	// MemoryCacheDriver stores cache in memory of process, so Redis is not required for single-node and local runs.
+	MemoryCacheDriver = "memory"
+)
+
 type CacheConfig struct {
+	Driver string
+
+	// Connection to Redis, which is used only by RedisCacheDriver:
 	Host     string
 	Port     int
 	Password string
 }
 
 type Config struct {
-	HTTP        HTTPConfig
-	Security    security.Config
-	Database    db.Config
-	Logging     logging.Config
-	Validation  ValidationConfig
-	Tracing     TracingConfig
-	Environment string
-	Version     string
-	NATS        NATSConfig
-	Cache       CacheConfig
+	HTTP         HTTPConfig // gRPC server
+	Web          HTTPConfig // HTTP server for public endpoints
+	Security     security.Config
+	Passwords    PasswordsConfig
+	JWTKeys      JWTKeysConfig
+	AccessTokens AccessTokensConfig
+	Tokens       TokensConfig
+	WebAuthn     WebAuthnConfig
+	OIDC         OIDCConfig
+	Telegram     TelegramConfig
+	Lockout      LockoutConfig
+	RateLimits   RateLimitsConfig
+	Federation   FederationConfig
+	Database     db.Config
+	Logging      logging.Config
+	Validation   ValidationConfig
+	Tracing      TracingConfig
+	Environment  string
+	Version      string
+	NATS         NATSConfig
+	Cache        CacheConfig
 }
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/DKhorkov/hmtm-sso/api/protobuf/generated/go/sso"
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+)
+
+func mapTokensToOut(tokensDTO *entities.TokensDTO) *sso.LoginOut {
+	if tokensDTO.MFAChallenge != nil {
+		return &sso.LoginOut{
+			MfaChallenge: &sso.MFAChallengeOut{
+				Token:     tokensDTO.MFAChallenge.Token,
+				ExpiresIn: int64(tokensDTO.MFAChallenge.ExpiresIn.Seconds()),
+			},
+		}
+	}
+
+	return &sso.LoginOut{
+		AccessToken:  tokensDTO.AccessToken,
+		RefreshToken: tokensDTO.RefreshToken,
+		ExpiresIn:    int64(tokensDTO.ExpiresIn.Seconds()),
+		TokenType:    tokensDTO.TokenType,
+	}
+}
+
+func mapSessionToOut(session entities.Session, currentSessionID uint64) *sso.SessionOut {
+	return &sso.SessionOut{
+		ID:         session.ID,
+		DeviceName: session.DeviceName,
+		UserAgent:  session.UserAgent,
+		Ip:         session.IP,
+		Current:    session.ID == currentSessionID,
+		CreatedAt:  timestamppb.New(session.CreatedAt),
+		LastUsedAt: timestamppb.New(session.UpdatedAt),
+		Ttl:        timestamppb.New(session.TTL),
+	}
+}
+
+func mapJWKToOut(jwk entities.JWK) *sso.JWKOut {
+	return &sso.JWKOut{
+		Kty: jwk.KeyType,
+		Kid: jwk.KeyID,
+		Use: jwk.Use,
+		Alg: jwk.Algorithm,
+		N:   jwk.N,
+		E:   jwk.E,
+		Crv: jwk.Curve,
+		X:   jwk.X,
+		Y:   jwk.Y,
+	}
+}
+
+func mapTokenIntrospectionToOut(tokenIntrospection *entities.TokenIntrospection) *sso.IntrospectTokenOut {
+	// Nothing is disclosed about inactive tokens:
+	if !tokenIntrospection.Active {
+		return &sso.IntrospectTokenOut{Active: false}
+	}
+
+	return &sso.IntrospectTokenOut{
+		Active:    true,
+		TokenType: tokenIntrospection.TokenType,
+		UserID:    tokenIntrospection.UserID,
+		SessionID: tokenIntrospection.SessionID,
+		ClientID:  tokenIntrospection.ClientID,
+		Scopes:    tokenIntrospection.Scopes,
+		Roles:     tokenIntrospection.Roles,
+		IssuedAt:  timestamppb.New(tokenIntrospection.IssuedAt),
+		ExpiresAt: timestamppb.New(tokenIntrospection.ExpiresAt),
+	}
+}
+
+func mapClientTokenToOut(clientToken *entities.ClientTokenDTO) *sso.IssueClientTokenOut {
+	return &sso.IssueClientTokenOut{
+		AccessToken: clientToken.AccessToken,
+		TokenType:   clientToken.TokenType,
+		ExpiresIn:   int64(clientToken.ExpiresIn.Seconds()),
+		Scope:       clientToken.Scope,
+	}
+}
+
+func mapTelegramAuthIn(in *sso.TelegramAuthIn) entities.TelegramAuthDTO {
+	return entities.TelegramAuthDTO{
+		ID:        in.GetId(),
+		FirstName: in.GetFirstName(),
+		LastName:  in.GetLastName(),
+		Username:  in.GetUsername(),
+		PhotoURL:  in.GetPhotoURL(),
+		AuthDate:  in.GetAuthDate(),
+		Hash:      in.GetHash(),
+	}
+}
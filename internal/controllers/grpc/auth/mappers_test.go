@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DKhorkov/hmtm-sso/api/protobuf/generated/go/sso"
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+)
+
+func TestMapTokensToOut(t *testing.T) {
+	tokensDTO := &entities.TokensDTO{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		ExpiresIn:    15 * time.Minute,
+		TokenType:    entities.BearerTokenType,
+	}
+
+	result := mapTokensToOut(tokensDTO)
+	require.Equal(t, tokensDTO.AccessToken, result.GetAccessToken())
+	require.Equal(t, tokensDTO.RefreshToken, result.GetRefreshToken())
+	require.Equal(t, int64(900), result.GetExpiresIn())
+	require.Equal(t, "Bearer", result.GetTokenType())
+	require.Nil(t, result.GetMfaChallenge())
+
+	// Tokens are not issued until two-factor authentication is completed:
+	tokensDTO = &entities.TokensDTO{
+		MFAChallenge: &entities.MFAChallengeDTO{
+			Token:     "challenge",
+			ExpiresIn: 5 * time.Minute,
+		},
+	}
+
+	result = mapTokensToOut(tokensDTO)
+	require.Empty(t, result.GetAccessToken())
+	require.Empty(t, result.GetRefreshToken())
+	require.Equal(t, "challenge", result.GetMfaChallenge().GetToken())
+	require.Equal(t, int64(300), result.GetMfaChallenge().GetExpiresIn())
+}
+
+func TestMapSessionToOut(t *testing.T) {
+	session := entities.Session{
+		ID:         2,
+		UserID:     1,
+		DeviceName: "iPhone",
+		UserAgent:  "Mozilla/5.0",
+		IP:         "127.0.0.1",
+		TTL:        time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:  time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	testCases := []struct {
+		name             string
+		currentSessionID uint64
+		expectedCurrent  bool
+	}{
+		{
+			name:             "current session",
+			currentSessionID: 2,
+			expectedCurrent:  true,
+		},
+		{
+			name:             "another session",
+			currentSessionID: 3,
+			expectedCurrent:  false,
+		},
+		{
+			name:             "token without session",
+			currentSessionID: 0,
+			expectedCurrent:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := mapSessionToOut(session, tc.currentSessionID)
+
+			require.Equal(t, session.ID, result.GetID())
+			require.Equal(t, session.DeviceName, result.GetDeviceName())
+			require.Equal(t, session.UserAgent, result.GetUserAgent())
+			require.Equal(t, session.IP, result.GetIp())
+			require.Equal(t, tc.expectedCurrent, result.GetCurrent())
+
+			// Last use of Session is the last time, when refresh token was issued for it:
+			require.Equal(t, session.CreatedAt, result.GetCreatedAt().AsTime())
+			require.Equal(t, session.UpdatedAt, result.GetLastUsedAt().AsTime())
+			require.Equal(t, session.TTL, result.GetTtl().AsTime())
+		})
+	}
+}
+
+func TestMapJWKToOut(t *testing.T) {
+	jwk := entities.JWK{
+		KeyType:   "EC",
+		KeyID:     "current",
+		Use:       "sig",
+		Algorithm: "ES256",
+		Curve:     "P-256",
+		X:         "x",
+		Y:         "y",
+	}
+
+	result := mapJWKToOut(jwk)
+	require.Equal(t, jwk.KeyType, result.GetKty())
+	require.Equal(t, jwk.KeyID, result.GetKid())
+	require.Equal(t, jwk.Use, result.GetUse())
+	require.Equal(t, jwk.Algorithm, result.GetAlg())
+	require.Equal(t, jwk.Curve, result.GetCrv())
+	require.Equal(t, jwk.X, result.GetX())
+	require.Equal(t, jwk.Y, result.GetY())
+	require.Empty(t, result.GetN())
+	require.Empty(t, result.GetE())
+}
+
+func TestMapTokenIntrospectionToOut(t *testing.T) {
+	tokenIntrospection := &entities.TokenIntrospection{
+		Active:    true,
+		TokenType: entities.RefreshTokenType,
+		UserID:    1,
+		SessionID: 2,
+		ClientID:  "shop",
+		IssuedAt:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		ExpiresAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	result := mapTokenIntrospectionToOut(tokenIntrospection)
+	require.True(t, result.GetActive())
+	require.Equal(t, tokenIntrospection.TokenType, result.GetTokenType())
+	require.Equal(t, tokenIntrospection.UserID, result.GetUserID())
+	require.Equal(t, tokenIntrospection.SessionID, result.GetSessionID())
+	require.Equal(t, tokenIntrospection.ClientID, result.GetClientID())
+	require.Empty(t, result.GetScopes())
+	require.Empty(t, result.GetRoles())
+	require.Equal(t, tokenIntrospection.IssuedAt, result.GetIssuedAt().AsTime())
+	require.Equal(t, tokenIntrospection.ExpiresAt, result.GetExpiresAt().AsTime())
+
+	// Inactive token is reported without any details:
+	tokenIntrospection.Active = false
+	require.Equal(t, &sso.IntrospectTokenOut{Active: false}, mapTokenIntrospectionToOut(tokenIntrospection))
+}
+
+func TestMapClientTokenToOut(t *testing.T) {
+	clientToken := &entities.ClientTokenDTO{
+		AccessToken: "access",
+		TokenType:   entities.BearerTokenType,
+		ExpiresIn:   5 * time.Minute,
+		Scope:       "users:read",
+	}
+
+	result := mapClientTokenToOut(clientToken)
+	require.Equal(t, "access", result.GetAccessToken())
+	require.Equal(t, "Bearer", result.GetTokenType())
+	require.Equal(t, int64(300), result.GetExpiresIn())
+	require.Equal(t, "users:read", result.GetScope())
+}
+
+func TestMapTelegramAuthIn(t *testing.T) {
+	in := &sso.TelegramAuthIn{
+		Id:        42,
+		FirstName: "Иван",
+		LastName:  "Петров",
+		Username:  "ivan_petrov",
+		PhotoURL:  "https://t.me/i/userpic/320/ivan_petrov.jpg",
+		AuthDate:  1700000000,
+		Hash:      "hash",
+	}
+
+	expected := entities.TelegramAuthDTO{
+		ID:        42,
+		FirstName: "Иван",
+		LastName:  "Петров",
+		Username:  "ivan_petrov",
+		PhotoURL:  "https://t.me/i/userpic/320/ivan_petrov.jpg",
+		AuthDate:  1700000000,
+		Hash:      "hash",
+	}
+
+	require.Equal(t, expected, mapTelegramAuthIn(in))
+	require.Equal(t, entities.TelegramAuthDTO{}, mapTelegramAuthIn(nil))
+}
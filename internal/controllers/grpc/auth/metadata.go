@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+	"math"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/DKhorkov/hmtm-sso/internal/clientip"
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+)
+
+const (
+	deviceNameMetadataKey   = "x-device-name"
+	userAgentMetadataKey    = "user-agent"
+	forwardedForMetadataKey = "x-forwarded-for"
+	realIPMetadataKey       = "x-real-ip"
+	clientIDMetadataKey     = "x-client-id"
+	clientSecretMetadataKey = "x-client-secret"
+	retryAfterMetadataKey   = "retry-after"
+)
+
+// GetClientInfo retrieves info about client's device and registered Client application from gRPC metadata.
+// Client's IP is taken from proxy headers only if request was proxied by one of trusted proxies, because other
+// callers can forge such headers. Otherwise, IP of connection peer is used.
+func GetClientInfo(ctx context.Context, trustedProxies []netip.Prefix) entities.ClientInfo {
+	var peerAddr string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		peerAddr = p.Addr.String()
+	}
+
+	// Metadata is nil without incoming context, but values still can be got from it:
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	return entities.ClientInfo{
+		DeviceName:   getFirstMetadataValue(md, deviceNameMetadataKey),
+		UserAgent:    getFirstMetadataValue(md, userAgentMetadataKey),
+		ClientID:     getFirstMetadataValue(md, clientIDMetadataKey),
+		ClientSecret: getFirstMetadataValue(md, clientSecretMetadataKey),
+		IP: clientip.Resolve(
+			peerAddr,
+			strings.Join(md.Get(forwardedForMetadataKey), ","),
+			getFirstMetadataValue(md, realIPMetadataKey),
+			trustedProxies,
+		),
+	}
+}
+
+func getFirstMetadataValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// SetRetryAfter sends number of seconds, after which request can be retried, in response header.
+func SetRetryAfter(ctx context.Context, retryAfter time.Duration) {
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+
+	// Header can not be set outside of server stream, but error itself contains retry-after too:
+	_ = grpc.SetHeader(ctx, metadata.Pairs(retryAfterMetadataKey, strconv.FormatInt(seconds, 10)))
+}
@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+)
+
+func TestGetClientInfo(t *testing.T) {
+	trustedProxies := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/24"),
+		netip.MustParsePrefix("192.168.1.5/32"),
+	}
+
+	peerCtx := peer.NewContext(
+		context.Background(),
+		&peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 52341}},
+	)
+
+	untrustedPeerCtx := peer.NewContext(
+		context.Background(),
+		&peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("198.51.100.9"), Port: 52341}},
+	)
+
+	testCases := []struct {
+		name     string
+		ctx      context.Context
+		expected entities.ClientInfo
+	}{
+		{
+			name:     "no metadata and peer",
+			ctx:      context.Background(),
+			expected: entities.ClientInfo{},
+		},
+		{
+			name: "IP from peer",
+			ctx: metadata.NewIncomingContext(
+				peerCtx,
+				metadata.Pairs(
+					"x-device-name", "iPhone",
+					"user-agent", "grpc-go/1.70.0",
+				),
+			),
+			expected: entities.ClientInfo{
+				DeviceName: "iPhone",
+				UserAgent:  "grpc-go/1.70.0",
+				IP:         "10.0.0.1",
+			},
+		},
+		{
+			name: "IP from x-forwarded-for",
+			ctx: metadata.NewIncomingContext(
+				peerCtx,
+				metadata.Pairs(
+					"x-forwarded-for", "203.0.113.7, 10.0.0.2",
+					"x-real-ip", "10.0.0.2",
+				),
+			),
+			expected: entities.ClientInfo{
+				IP: "203.0.113.7",
+			},
+		},
+		{
+			name: "forged IP in x-forwarded-for",
+			ctx: metadata.NewIncomingContext(
+				peerCtx,
+				metadata.Pairs("x-forwarded-for", "1.1.1.1, 203.0.113.7, 192.168.1.5"),
+			),
+			expected: entities.ClientInfo{
+				IP: "203.0.113.7",
+			},
+		},
+		{
+			name: "x-forwarded-for from untrusted peer",
+			ctx: metadata.NewIncomingContext(
+				untrustedPeerCtx,
+				metadata.Pairs(
+					"x-forwarded-for", "203.0.113.7",
+					"x-real-ip", "203.0.113.7",
+				),
+			),
+			expected: entities.ClientInfo{
+				IP: "198.51.100.9",
+			},
+		},
+		{
+			name: "x-real-ip without peer",
+			ctx: metadata.NewIncomingContext(
+				context.Background(),
+				metadata.Pairs("x-real-ip", "203.0.113.8"),
+			),
+			expected: entities.ClientInfo{},
+		},
+		{
+			name: "IP from x-real-ip",
+			ctx: metadata.NewIncomingContext(
+				peerCtx,
+				metadata.Pairs("x-real-ip", "203.0.113.8"),
+			),
+			expected: entities.ClientInfo{
+				IP: "203.0.113.8",
+			},
+		},
+		{
+			name: "registered client",
+			ctx: metadata.NewIncomingContext(
+				peerCtx,
+				metadata.Pairs(
+					"x-client-id", "shop",
+					"x-client-secret", "secret",
+				),
+			),
+			expected: entities.ClientInfo{
+				IP:           "10.0.0.1",
+				ClientID:     "shop",
+				ClientSecret: "secret",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, GetClientInfo(tc.ctx, trustedProxies))
+		})
+	}
+}
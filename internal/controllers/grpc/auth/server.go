@@ -3,6 +3,8 @@ package auth
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/netip"
 
 	"github.com/DKhorkov/libs/logging"
 	"github.com/DKhorkov/libs/security"
@@ -22,24 +24,59 @@ import (
 var (
 	userNotFoundError                           = &customerrors.UserNotFoundError{}
 	userAlreadyExistsError                      = &customerrors.UserAlreadyExistsError{}
+	userBlockedError                            = &customerrors.UserBlockedError{}
 	emailAlreadyConfirmedError                  = &customerrors.EmailAlreadyConfirmedError{}
 	emailIsNotConfirmedError                    = &customerrors.EmailIsNotConfirmedError{}
 	invalidJWTError                             = &security.InvalidJWTError{}
 	wrongPasswordError                          = &customerrors.WrongPasswordError{}
 	accessTokenDoesNotBelongToRefreshTokenError = &customerrors.AccessTokenDoesNotBelongToRefreshTokenError{}
+	invalidVerifyEmailTokenError                = &customerrors.InvalidVerifyEmailTokenError{}
+	invalidForgetPasswordTokenError             = &customerrors.InvalidForgetPasswordTokenError{}
+	sessionNotFoundError                        = &customerrors.SessionNotFoundError{}
+	refreshTokenReuseDetectedError              = &customerrors.RefreshTokenReuseDetectedError{}
+	mfaNotEnabledError                          = &customerrors.MFANotEnabledError{}
+	mfaAlreadyEnabledError                      = &customerrors.MFAAlreadyEnabledError{}
+	invalidMFACodeError                         = &customerrors.InvalidMFACodeError{}
+	invalidMFAChallengeError                    = &customerrors.InvalidMFAChallengeError{}
+	invalidWebAuthnChallengeError               = &customerrors.InvalidWebAuthnChallengeError{}
+	invalidWebAuthnCredentialError              = &customerrors.InvalidWebAuthnCredentialError{}
+	webAuthnCredentialNotFoundError             = &customerrors.WebAuthnCredentialNotFoundError{}
+	invalidLoginTokenError                      = &customerrors.InvalidLoginTokenError{}
+	invalidClientError                          = &customerrors.InvalidClientError{}
+	unauthorizedClientError                     = &customerrors.UnauthorizedClientError{}
+	invalidScopeError                           = &customerrors.InvalidScopeError{}
+	identityProviderNotFoundError               = &customerrors.IdentityProviderNotFoundError{}
+	invalidFederatedLoginStateError             = &customerrors.InvalidFederatedLoginStateError{}
+	federatedLoginError                         = &customerrors.FederatedLoginError{}
+	userIdentityNotFoundError                   = &customerrors.UserIdentityNotFoundError{}
+	userIdentityAlreadyExistsError              = &customerrors.UserIdentityAlreadyExistsError{}
+	invalidTelegramAuthError                    = &customerrors.InvalidTelegramAuthError{}
+	phoneIsNotSetError                          = &customerrors.PhoneIsNotSetError{}
+	phoneAlreadyConfirmedError                  = &customerrors.PhoneAlreadyConfirmedError{}
+	invalidPhoneVerificationCodeError           = &customerrors.InvalidPhoneVerificationCodeError{}
+	limitExceededError                          = &customerrors.LimitExceededError{}
 	validationError                             = &validation.Error{}
 )
 
 // RegisterServer handler (serverAPI) for AuthServer to gRPC server:.
-func RegisterServer(gRPCServer *grpc.Server, useCases interfaces.UseCases, logger logging.Logger) {
-	sso.RegisterAuthServiceServer(gRPCServer, &ServerAPI{useCases: useCases, logger: logger})
+func RegisterServer(
+	gRPCServer *grpc.Server,
+	useCases interfaces.UseCases,
+	trustedProxies []netip.Prefix,
+	logger logging.Logger,
+) {
+	sso.RegisterAuthServiceServer(
+		gRPCServer,
+		&ServerAPI{useCases: useCases, trustedProxies: trustedProxies, logger: logger},
+	)
 }
 
 type ServerAPI struct {
 	// Helps to test single endpoints, if others is not implemented yet
 	sso.UnimplementedAuthServiceServer
-	useCases interfaces.UseCases
-	logger   logging.Logger
+	useCases       interfaces.UseCases
+	trustedProxies []netip.Prefix
+	logger         logging.Logger
 }
 
 func (api *ServerAPI) SendForgetPasswordMessage(ctx context.Context, in *sso.SendForgetPasswordMessageIn) (*emptypb.Empty, error) {
@@ -92,6 +129,29 @@ func (api *ServerAPI) SendVerifyEmailMessage(
 	return &emptypb.Empty{}, nil
 }
 
+// SendLoginLink handler sends magic link and one-time code for passwordless login.
+func (api *ServerAPI) SendLoginLink(ctx context.Context, in *sso.SendLoginLinkIn) (*emptypb.Empty, error) {
+	if err := api.useCases.SendLoginLink(ctx, in.GetEmail()); err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			"Error occurred while trying to send login-link message to User with email="+in.GetEmail(),
+			err,
+		)
+
+		switch {
+		case errors.As(err, &userNotFoundError):
+			return nil, &customgrpc.BaseError{Status: codes.NotFound, Message: err.Error()}
+		case errors.As(err, &emailIsNotConfirmedError):
+			return nil, &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
 func (api *ServerAPI) ChangePassword(
 	ctx context.Context,
 	in *sso.ChangePasswordIn,
@@ -122,14 +182,15 @@ func (api *ServerAPI) ForgetPassword(
 		logging.LogErrorContext(
 			ctx,
 			api.logger,
-			"Error occurred while trying to change forgotten password for User with forgetPasswordToken="+in.GetForgetPasswordToken(),
+			"Error occurred while trying to change forgotten password for User",
 			err,
 		)
 
 		switch {
 		case errors.As(err, &userNotFoundError):
 			return nil, &customgrpc.BaseError{Status: codes.NotFound, Message: err.Error()}
-		case errors.As(err, &validationError):
+		case errors.As(err, &validationError),
+			errors.As(err, &invalidForgetPasswordTokenError):
 			return nil, &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: err.Error()}
 		default:
 			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
@@ -152,7 +213,35 @@ func (api *ServerAPI) VerifyEmail(
 		)
 
 		switch {
-		case errors.As(err, &emailAlreadyConfirmedError):
+		case errors.As(err, &emailAlreadyConfirmedError),
+			errors.As(err, &invalidVerifyEmailTokenError):
+			return nil, &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: err.Error()}
+		case errors.As(err, &userNotFoundError):
+			return nil, &customgrpc.BaseError{Status: codes.NotFound, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// VerifyEmailByCode handler verifies User's email with code, which is convenient for mobile clients.
+func (api *ServerAPI) VerifyEmailByCode(
+	ctx context.Context,
+	in *sso.VerifyEmailByCodeIn,
+) (*emptypb.Empty, error) {
+	if err := api.useCases.VerifyUserEmailByCode(ctx, in.GetEmail(), in.GetCode()); err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			"Error occurred while trying to verify email by code for User with email="+in.GetEmail(),
+			err,
+		)
+
+		switch {
+		case errors.As(err, &emailAlreadyConfirmedError),
+			errors.As(err, &invalidVerifyEmailTokenError):
 			return nil, &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: err.Error()}
 		case errors.As(err, &userNotFoundError):
 			return nil, &customgrpc.BaseError{Status: codes.NotFound, Message: err.Error()}
@@ -172,6 +261,109 @@ func (api *ServerAPI) Logout(ctx context.Context, in *sso.LogoutIn) (*emptypb.Em
 	return &emptypb.Empty{}, nil
 }
 
+// LogoutEverywhere handler ends all User's Sessions except the current one.
+func (api *ServerAPI) LogoutEverywhere(ctx context.Context, in *sso.LogoutEverywhereIn) (*emptypb.Empty, error) {
+	if err := api.useCases.LogoutUserEverywhere(ctx, in.GetAccessToken()); err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			"Error occurred while trying to logout User everywhere",
+			err,
+		)
+
+		switch {
+		case errors.As(err, &invalidJWTError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ListSessions handler returns active Sessions of User with client info for each of them.
+func (api *ServerAPI) ListSessions(ctx context.Context, in *sso.ListSessionsIn) (*sso.ListSessionsOut, error) {
+	sessionsDTO, err := api.useCases.GetUserSessions(ctx, in.GetAccessToken())
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			"Error occurred while trying to get User's Sessions",
+			err,
+		)
+
+		switch {
+		case errors.As(err, &invalidJWTError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	sessions := make([]*sso.SessionOut, len(sessionsDTO.Sessions))
+	for i, session := range sessionsDTO.Sessions {
+		sessions[i] = mapSessionToOut(session, sessionsDTO.CurrentSessionID)
+	}
+
+	return &sso.ListSessionsOut{Sessions: sessions}, nil
+}
+
+// RevokeSession handler ends User's Session with provided ID.
+func (api *ServerAPI) RevokeSession(ctx context.Context, in *sso.RevokeSessionIn) (*emptypb.Empty, error) {
+	if err := api.useCases.RevokeSession(ctx, in.GetAccessToken(), in.GetSessionID()); err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			fmt.Sprintf("Error occurred while trying to revoke Session with ID=%d", in.GetSessionID()),
+			err,
+		)
+
+		switch {
+		case errors.As(err, &invalidJWTError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &sessionNotFoundError):
+			return nil, &customgrpc.BaseError{Status: codes.NotFound, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// GetJWKS handler returns public keys, which are used to verify access tokens.
+func (api *ServerAPI) GetJWKS(_ context.Context, _ *emptypb.Empty) (*sso.GetJWKSOut, error) {
+	jwks := api.useCases.GetJWKS()
+
+	keys := make([]*sso.JWKOut, len(jwks.Keys))
+	for i, jwk := range jwks.Keys {
+		keys[i] = mapJWKToOut(jwk)
+	}
+
+	return &sso.GetJWKSOut{Keys: keys}, nil
+}
+
+// IntrospectToken is used by other services to check access or refresh token on each request.
+func (api *ServerAPI) IntrospectToken(
+	ctx context.Context,
+	in *sso.IntrospectTokenIn,
+) (*sso.IntrospectTokenOut, error) {
+	tokenIntrospection, err := api.useCases.IntrospectToken(ctx, in.GetToken())
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			"Error occurred while trying to introspect token",
+			err,
+		)
+
+		return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+	}
+
+	return mapTokenIntrospectionToOut(tokenIntrospection), nil
+}
+
 // Register handler registers new User with provided data.
 func (api *ServerAPI) Register(ctx context.Context, in *sso.RegisterIn) (*sso.RegisterOut, error) {
 	userData := entities.RegisterUserDTO{
@@ -204,9 +396,16 @@ func (api *ServerAPI) Register(ctx context.Context, in *sso.RegisterIn) (*sso.Re
 
 // Login handler authenticates User if provided credentials are valid and logs User in system.
 func (api *ServerAPI) Login(ctx context.Context, in *sso.LoginIn) (*sso.LoginOut, error) {
+	// Clients, which were built before identifier was introduced, send email:
+	identifier := in.GetIdentifier()
+	if identifier == "" {
+		identifier = in.GetEmail()
+	}
+
 	userData := entities.LoginUserDTO{
-		Email:    in.GetEmail(),
-		Password: in.GetPassword(),
+		Identifier: identifier,
+		Password:   in.GetPassword(),
+		ClientInfo: GetClientInfo(ctx, api.trustedProxies),
 	}
 
 	tokensDTO, err := api.useCases.LoginUser(ctx, userData)
@@ -214,26 +413,506 @@ func (api *ServerAPI) Login(ctx context.Context, in *sso.LoginIn) (*sso.LoginOut
 		logging.LogErrorContext(
 			ctx,
 			api.logger,
-			"Error occurred while trying to login User with email="+userData.Email,
+			"Error occurred while trying to login User with identifier="+userData.Identifier,
 			err,
 		)
 
+		// Retry-after of returned error is needed, so it is not matched against shared variable:
+		var accountLockedError *customerrors.AccountLockedError
+
 		switch {
 		case errors.As(err, &userNotFoundError):
 			return nil, &customgrpc.BaseError{Status: codes.NotFound, Message: err.Error()}
 		case errors.As(err, &wrongPasswordError):
 			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &accountLockedError):
+			SetRetryAfter(ctx, accountLockedError.RetryAfter)
+			return nil, &customgrpc.BaseError{Status: codes.ResourceExhausted, Message: err.Error()}
+		case errors.As(err, &invalidClientError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &unauthorizedClientError), errors.As(err, &userBlockedError):
+			return nil, &customgrpc.BaseError{Status: codes.PermissionDenied, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return mapTokensToOut(tokensDTO), nil
+}
+
+// CompleteMFALogin handler issues tokens for User, who has received MFA challenge from Login.
+func (api *ServerAPI) CompleteMFALogin(ctx context.Context, in *sso.CompleteMFALoginIn) (*sso.LoginOut, error) {
+	loginData := entities.CompleteMFALoginDTO{
+		MFAChallenge: in.GetMfaChallenge(),
+		Code:         in.GetCode(),
+		ClientInfo:   GetClientInfo(ctx, api.trustedProxies),
+	}
+
+	tokensDTO, err := api.useCases.CompleteMFALogin(ctx, loginData)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			"Error occurred while trying to complete MFA login",
+			err,
+		)
+
+		switch {
+		case errors.As(err, &invalidMFAChallengeError),
+			errors.As(err, &invalidMFACodeError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &mfaNotEnabledError):
+			return nil, &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: err.Error()}
+		case errors.As(err, &userNotFoundError):
+			return nil, &customgrpc.BaseError{Status: codes.NotFound, Message: err.Error()}
+		case errors.As(err, &invalidClientError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &unauthorizedClientError), errors.As(err, &userBlockedError):
+			return nil, &customgrpc.BaseError{Status: codes.PermissionDenied, Message: err.Error()}
 		default:
 			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
 		}
 	}
 
-	return &sso.LoginOut{
-		AccessToken:  tokensDTO.AccessToken,
-		RefreshToken: tokensDTO.RefreshToken,
+	return mapTokensToOut(tokensDTO), nil
+}
+
+// StartTOTPEnrollment handler returns new TOTP secret for User's authenticator app.
+func (api *ServerAPI) StartTOTPEnrollment(
+	ctx context.Context,
+	in *sso.StartTOTPEnrollmentIn,
+) (*sso.StartTOTPEnrollmentOut, error) {
+	enrollment, err := api.useCases.StartTOTPEnrollment(ctx, in.GetAccessToken())
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			"Error occurred while trying to start TOTP enrollment",
+			err,
+		)
+
+		switch {
+		case errors.As(err, &invalidJWTError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &userNotFoundError):
+			return nil, &customgrpc.BaseError{Status: codes.NotFound, Message: err.Error()}
+		case errors.As(err, &mfaAlreadyEnabledError):
+			return nil, &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return &sso.StartTOTPEnrollmentOut{
+		Secret: enrollment.Secret,
+		Uri:    enrollment.URI,
 	}, nil
 }
 
+// ConfirmTOTPEnrollment handler enables two-factor authentication and returns recovery codes.
+func (api *ServerAPI) ConfirmTOTPEnrollment(
+	ctx context.Context,
+	in *sso.ConfirmTOTPEnrollmentIn,
+) (*sso.ConfirmTOTPEnrollmentOut, error) {
+	recoveryCodes, err := api.useCases.ConfirmTOTPEnrollment(ctx, in.GetAccessToken(), in.GetCode())
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			"Error occurred while trying to confirm TOTP enrollment",
+			err,
+		)
+
+		switch {
+		case errors.As(err, &invalidJWTError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &mfaNotEnabledError),
+			errors.As(err, &mfaAlreadyEnabledError),
+			errors.As(err, &invalidMFACodeError):
+			return nil, &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return &sso.ConfirmTOTPEnrollmentOut{RecoveryCodes: recoveryCodes}, nil
+}
+
+// DisableTOTP handler disables two-factor authentication.
+func (api *ServerAPI) DisableTOTP(ctx context.Context, in *sso.DisableTOTPIn) (*emptypb.Empty, error) {
+	if err := api.useCases.DisableTOTP(ctx, in.GetAccessToken(), in.GetCode()); err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			"Error occurred while trying to disable TOTP",
+			err,
+		)
+
+		switch {
+		case errors.As(err, &invalidJWTError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &mfaNotEnabledError),
+			errors.As(err, &invalidMFACodeError):
+			return nil, &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// BeginWebAuthnRegistration handler returns options for registration of new passkey.
+func (api *ServerAPI) BeginWebAuthnRegistration(
+	ctx context.Context,
+	in *sso.BeginWebAuthnRegistrationIn,
+) (*sso.WebAuthnOptionsOut, error) {
+	options, err := api.useCases.BeginWebAuthnRegistration(ctx, in.GetAccessToken())
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			"Error occurred while trying to begin WebAuthn registration",
+			err,
+		)
+
+		switch {
+		case errors.As(err, &invalidJWTError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &userNotFoundError):
+			return nil, &customgrpc.BaseError{Status: codes.NotFound, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return &sso.WebAuthnOptionsOut{Options: options}, nil
+}
+
+// FinishWebAuthnRegistration handler saves passkey, which was created by authenticator.
+func (api *ServerAPI) FinishWebAuthnRegistration(
+	ctx context.Context,
+	in *sso.FinishWebAuthnRegistrationIn,
+) (*emptypb.Empty, error) {
+	registrationData := entities.FinishWebAuthnRegistrationDTO{
+		AccessToken:       in.GetAccessToken(),
+		CredentialID:      in.GetCredentialID(),
+		ClientDataJSON:    in.GetClientDataJSON(),
+		AttestationObject: in.GetAttestationObject(),
+		Transports:        in.GetTransports(),
+	}
+
+	if err := api.useCases.FinishWebAuthnRegistration(ctx, registrationData); err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			"Error occurred while trying to finish WebAuthn registration",
+			err,
+		)
+
+		switch {
+		case errors.As(err, &invalidJWTError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &invalidWebAuthnChallengeError),
+			errors.As(err, &invalidWebAuthnCredentialError):
+			return nil, &customgrpc.BaseError{Status: codes.InvalidArgument, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// BeginWebAuthnLogin handler returns options for login with passkey.
+func (api *ServerAPI) BeginWebAuthnLogin(
+	ctx context.Context,
+	in *sso.BeginWebAuthnLoginIn,
+) (*sso.WebAuthnOptionsOut, error) {
+	options, err := api.useCases.BeginWebAuthnLogin(ctx, in.GetEmail())
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			"Error occurred while trying to begin WebAuthn login",
+			err,
+		)
+
+		switch {
+		case errors.As(err, &userNotFoundError),
+			errors.As(err, &webAuthnCredentialNotFoundError):
+			return nil, &customgrpc.BaseError{Status: codes.NotFound, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return &sso.WebAuthnOptionsOut{Options: options}, nil
+}
+
+// FinishWebAuthnLogin handler issues tokens for User after verification of passkey assertion.
+func (api *ServerAPI) FinishWebAuthnLogin(ctx context.Context, in *sso.FinishWebAuthnLoginIn) (*sso.LoginOut, error) {
+	loginData := entities.FinishWebAuthnLoginDTO{
+		CredentialID:      in.GetCredentialID(),
+		ClientDataJSON:    in.GetClientDataJSON(),
+		AuthenticatorData: in.GetAuthenticatorData(),
+		Signature:         in.GetSignature(),
+		UserHandle:        in.GetUserHandle(),
+		ClientInfo:        GetClientInfo(ctx, api.trustedProxies),
+	}
+
+	tokensDTO, err := api.useCases.FinishWebAuthnLogin(ctx, loginData)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			"Error occurred while trying to finish WebAuthn login",
+			err,
+		)
+
+		switch {
+		case errors.As(err, &invalidWebAuthnChallengeError),
+			errors.As(err, &invalidWebAuthnCredentialError),
+			errors.As(err, &webAuthnCredentialNotFoundError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &emailIsNotConfirmedError):
+			return nil, &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: err.Error()}
+		case errors.As(err, &userNotFoundError):
+			return nil, &customgrpc.BaseError{Status: codes.NotFound, Message: err.Error()}
+		case errors.As(err, &invalidClientError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &unauthorizedClientError), errors.As(err, &userBlockedError):
+			return nil, &customgrpc.BaseError{Status: codes.PermissionDenied, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return mapTokensToOut(tokensDTO), nil
+}
+
+// LoginWithCode handler issues tokens for User by login token from magic link or by email and one-time code.
+func (api *ServerAPI) LoginWithCode(ctx context.Context, in *sso.LoginWithCodeIn) (*sso.LoginOut, error) {
+	loginData := entities.LoginWithCodeDTO{
+		Token:      in.GetToken(),
+		Email:      in.GetEmail(),
+		Code:       in.GetCode(),
+		ClientInfo: GetClientInfo(ctx, api.trustedProxies),
+	}
+
+	tokensDTO, err := api.useCases.LoginWithCode(ctx, loginData)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			"Error occurred while trying to login with code",
+			err,
+		)
+
+		switch {
+		case errors.As(err, &invalidLoginTokenError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &emailIsNotConfirmedError):
+			return nil, &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: err.Error()}
+		case errors.As(err, &userNotFoundError):
+			return nil, &customgrpc.BaseError{Status: codes.NotFound, Message: err.Error()}
+		case errors.As(err, &invalidClientError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &unauthorizedClientError), errors.As(err, &userBlockedError):
+			return nil, &customgrpc.BaseError{Status: codes.PermissionDenied, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return mapTokensToOut(tokensDTO), nil
+}
+
+// BeginFederatedLogin handler returns URL of identity provider, which User should be redirected to for login.
+func (api *ServerAPI) BeginFederatedLogin(
+	ctx context.Context,
+	in *sso.BeginFederatedLoginIn,
+) (*sso.BeginFederatedLoginOut, error) {
+	authorizationURL, err := api.useCases.BeginFederatedLogin(ctx, in.GetProvider())
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			"Error occurred while trying to begin login via identity provider="+in.GetProvider(),
+			err,
+		)
+
+		switch {
+		case errors.As(err, &identityProviderNotFoundError):
+			return nil, &customgrpc.BaseError{Status: codes.NotFound, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return &sso.BeginFederatedLoginOut{AuthorizationURL: authorizationURL}, nil
+}
+
+// FinishFederatedLogin handler issues tokens for User, who has been redirected back from identity provider.
+func (api *ServerAPI) FinishFederatedLogin(ctx context.Context, in *sso.FinishFederatedLoginIn) (*sso.LoginOut, error) {
+	loginData := entities.FinishFederatedLoginDTO{
+		Provider:   in.GetProvider(),
+		Code:       in.GetCode(),
+		State:      in.GetState(),
+		ClientInfo: GetClientInfo(ctx, api.trustedProxies),
+	}
+
+	tokensDTO, err := api.useCases.FinishFederatedLogin(ctx, loginData)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			"Error occurred while trying to finish login via identity provider="+in.GetProvider(),
+			err,
+		)
+
+		switch {
+		case errors.As(err, &identityProviderNotFoundError), errors.As(err, &userNotFoundError):
+			return nil, &customgrpc.BaseError{Status: codes.NotFound, Message: err.Error()}
+		case errors.As(err, &invalidFederatedLoginStateError),
+			errors.As(err, &federatedLoginError),
+			errors.As(err, &invalidClientError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &emailIsNotConfirmedError):
+			return nil, &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: err.Error()}
+		case errors.As(err, &unauthorizedClientError), errors.As(err, &userBlockedError):
+			return nil, &customgrpc.BaseError{Status: codes.PermissionDenied, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return mapTokensToOut(tokensDTO), nil
+}
+
+// LoginWithTelegram handler issues tokens for User, who has linked Telegram account, by Telegram Login Widget data.
+func (api *ServerAPI) LoginWithTelegram(ctx context.Context, in *sso.TelegramAuthIn) (*sso.LoginOut, error) {
+	loginData := entities.LoginWithTelegramDTO{
+		TelegramAuth: mapTelegramAuthIn(in),
+		ClientInfo:   GetClientInfo(ctx, api.trustedProxies),
+	}
+
+	tokensDTO, err := api.useCases.LoginWithTelegram(ctx, loginData)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			fmt.Sprintf("Error occurred while trying to login via Telegram with ID=%d", in.GetId()),
+			err,
+		)
+
+		switch {
+		case errors.As(err, &invalidTelegramAuthError),
+			errors.As(err, &invalidClientError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &identityProviderNotFoundError),
+			errors.As(err, &userIdentityNotFoundError),
+			errors.As(err, &userNotFoundError):
+			return nil, &customgrpc.BaseError{Status: codes.NotFound, Message: err.Error()}
+		case errors.As(err, &unauthorizedClientError), errors.As(err, &userBlockedError):
+			return nil, &customgrpc.BaseError{Status: codes.PermissionDenied, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return mapTokensToOut(tokensDTO), nil
+}
+
+// LinkTelegram handler links Telegram account to User and confirms Telegram handle of User, if it matches.
+func (api *ServerAPI) LinkTelegram(ctx context.Context, in *sso.LinkTelegramIn) (*emptypb.Empty, error) {
+	linkData := entities.LinkTelegramDTO{
+		AccessToken:  in.GetAccessToken(),
+		TelegramAuth: mapTelegramAuthIn(in.GetTelegramAuth()),
+	}
+
+	if err := api.useCases.LinkTelegram(ctx, linkData); err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			"Error occurred while trying to link Telegram account",
+			err,
+		)
+
+		switch {
+		case errors.As(err, &invalidJWTError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &invalidTelegramAuthError):
+			return nil, &customgrpc.BaseError{Status: codes.InvalidArgument, Message: err.Error()}
+		case errors.As(err, &userIdentityAlreadyExistsError):
+			return nil, &customgrpc.BaseError{Status: codes.AlreadyExists, Message: err.Error()}
+		case errors.As(err, &identityProviderNotFoundError), errors.As(err, &userNotFoundError):
+			return nil, &customgrpc.BaseError{Status: codes.NotFound, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// SendPhoneVerificationCode handler sends one-time code via SMS to phone from profile of User.
+func (api *ServerAPI) SendPhoneVerificationCode(
+	ctx context.Context,
+	in *sso.SendPhoneVerificationCodeIn,
+) (*emptypb.Empty, error) {
+	if err := api.useCases.SendPhoneVerificationCode(ctx, in.GetAccessToken()); err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			"Error occurred while trying to send phone verification code",
+			err,
+		)
+
+		switch {
+		case errors.As(err, &invalidJWTError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &phoneIsNotSetError), errors.As(err, &phoneAlreadyConfirmedError):
+			return nil, &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: err.Error()}
+		case errors.As(err, &limitExceededError):
+			return nil, &customgrpc.BaseError{Status: codes.ResourceExhausted, Message: err.Error()}
+		case errors.As(err, &userNotFoundError):
+			return nil, &customgrpc.BaseError{Status: codes.NotFound, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// VerifyPhone handler confirms phone of User with code, which was sent via SMS.
+func (api *ServerAPI) VerifyPhone(ctx context.Context, in *sso.VerifyPhoneIn) (*emptypb.Empty, error) {
+	if err := api.useCases.VerifyPhone(ctx, in.GetAccessToken(), in.GetCode()); err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			"Error occurred while trying to verify phone",
+			err,
+		)
+
+		switch {
+		case errors.As(err, &invalidJWTError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &phoneIsNotSetError),
+			errors.As(err, &phoneAlreadyConfirmedError),
+			errors.As(err, &invalidPhoneVerificationCodeError):
+			return nil, &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: err.Error()}
+		case errors.As(err, &userNotFoundError):
+			return nil, &customgrpc.BaseError{Status: codes.NotFound, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
 // RefreshTokens handler updates User auth tokens.
 func (api *ServerAPI) RefreshTokens(
 	ctx context.Context,
@@ -250,17 +929,50 @@ func (api *ServerAPI) RefreshTokens(
 
 		switch {
 		case errors.As(err, &invalidJWTError),
-			errors.As(err, &accessTokenDoesNotBelongToRefreshTokenError):
+			errors.As(err, &accessTokenDoesNotBelongToRefreshTokenError),
+			errors.As(err, &refreshTokenReuseDetectedError):
 			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
 		case errors.As(err, &userNotFoundError):
 			return nil, &customgrpc.BaseError{Status: codes.NotFound, Message: err.Error()}
+		case errors.As(err, &userBlockedError):
+			return nil, &customgrpc.BaseError{Status: codes.PermissionDenied, Message: err.Error()}
 		default:
 			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
 		}
 	}
 
-	return &sso.LoginOut{
-		AccessToken:  tokensDTO.AccessToken,
-		RefreshToken: tokensDTO.RefreshToken,
-	}, nil
+	return mapTokensToOut(tokensDTO), nil
+}
+
+// IssueClientToken handler issues access token to backend service, which authenticates with its own credentials.
+func (api *ServerAPI) IssueClientToken(
+	ctx context.Context,
+	in *sso.IssueClientTokenIn,
+) (*sso.IssueClientTokenOut, error) {
+	tokenRequest := entities.IssueClientTokenDTO{
+		ClientID:     in.GetClientID(),
+		ClientSecret: in.GetClientSecret(),
+		Scope:        in.GetScope(),
+	}
+
+	clientToken, err := api.useCases.IssueClientToken(ctx, tokenRequest)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			"Error occurred while trying to issue token for Client with ClientID="+in.GetClientID(),
+			err,
+		)
+
+		switch {
+		case errors.As(err, &invalidClientError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &unauthorizedClientError), errors.As(err, &invalidScopeError):
+			return nil, &customgrpc.BaseError{Status: codes.PermissionDenied, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return mapClientTokenToOut(clientToken), nil
 }
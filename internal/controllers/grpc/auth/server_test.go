@@ -5,11 +5,13 @@ import (
 	"errors"
 	"github.com/DKhorkov/libs/validation"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	customgrpc "github.com/DKhorkov/libs/grpc"
 	mocklogging "github.com/DKhorkov/libs/logging/mocks"
@@ -350,6 +352,27 @@ func TestServerAPI_ForgetPassword(t *testing.T) {
 			expectedErr:   &customgrpc.BaseError{Status: codes.NotFound, Message: "user not found"},
 			errorExpected: true,
 		},
+		{
+			name: "invalid token",
+			in:   &sso.ForgetPasswordIn{ForgetPasswordToken: "invalid-token", NewPassword: "newpass"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					ForgetPassword(gomock.Any(), "invalid-token", "newpass").
+					Return(&customerrors.InvalidForgetPasswordTokenError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.FailedPrecondition,
+				Message: "forget-password token is invalid or expired",
+			},
+			errorExpected: true,
+		},
 		{
 			name: "invalid password",
 			in:   &sso.ForgetPasswordIn{ForgetPasswordToken: "valid-token", NewPassword: "weak"},
@@ -473,6 +496,24 @@ func TestServerAPI_VerifyEmail(t *testing.T) {
 			expectedErr:   &customgrpc.BaseError{Status: codes.NotFound, Message: "user not found"},
 			errorExpected: true,
 		},
+		{
+			name: "invalid token",
+			in:   &sso.VerifyEmailIn{VerifyEmailToken: "invalid-token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					VerifyUserEmail(gomock.Any(), "invalid-token").
+					Return(&customerrors.InvalidVerifyEmailTokenError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: "verify-email token is invalid or expired"},
+			errorExpected: true,
+		},
 		{
 			name: "internal error",
 			in:   &sso.VerifyEmailIn{VerifyEmailToken: "valid-token"},
@@ -513,7 +554,7 @@ func TestServerAPI_VerifyEmail(t *testing.T) {
 	}
 }
 
-func TestServerAPI_Logout(t *testing.T) {
+func TestServerAPI_VerifyEmailByCode(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	useCases := mockusecases.NewMockUseCases(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
@@ -524,33 +565,92 @@ func TestServerAPI_Logout(t *testing.T) {
 
 	testCases := []struct {
 		name          string
-		in            *sso.LogoutIn
+		in            *sso.VerifyEmailByCodeIn
 		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
 		expectedErr   error
 		errorExpected bool
 	}{
 		{
 			name: "success",
-			in:   &sso.LogoutIn{AccessToken: "valid-token"},
+			in:   &sso.VerifyEmailByCodeIn{Email: "test@example.com", Code: "123456"},
 			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
 				useCases.
 					EXPECT().
-					LogoutUser(gomock.Any(), "valid-token").
+					VerifyUserEmailByCode(gomock.Any(), "test@example.com", "123456").
 					Return(nil).
 					Times(1)
 			},
 			expectedErr:   nil,
 			errorExpected: false,
 		},
+		{
+			name: "invalid code",
+			in:   &sso.VerifyEmailByCodeIn{Email: "test@example.com", Code: "654321"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					VerifyUserEmailByCode(gomock.Any(), "test@example.com", "654321").
+					Return(&customerrors.InvalidVerifyEmailTokenError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: "verify-email token is invalid or expired"},
+			errorExpected: true,
+		},
+		{
+			name: "email already confirmed",
+			in:   &sso.VerifyEmailByCodeIn{Email: "test@example.com", Code: "123456"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					VerifyUserEmailByCode(gomock.Any(), "test@example.com", "123456").
+					Return(&customerrors.EmailAlreadyConfirmedError{Message: "email already confirmed"}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: "email already confirmed"},
+			errorExpected: true,
+		},
+		{
+			name: "user not found",
+			in:   &sso.VerifyEmailByCodeIn{Email: "test@example.com", Code: "123456"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					VerifyUserEmailByCode(gomock.Any(), "test@example.com", "123456").
+					Return(&customerrors.UserNotFoundError{Message: "user not found"}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.NotFound, Message: "user not found"},
+			errorExpected: true,
+		},
 		{
 			name: "internal error",
-			in:   &sso.LogoutIn{AccessToken: "valid-token"},
+			in:   &sso.VerifyEmailByCodeIn{Email: "test@example.com", Code: "123456"},
 			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
 				useCases.
 					EXPECT().
-					LogoutUser(gomock.Any(), "valid-token").
+					VerifyUserEmailByCode(gomock.Any(), "test@example.com", "123456").
 					Return(errors.New("internal error")).
 					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
 			},
 			expectedErr:   &customgrpc.BaseError{Status: codes.Internal, Message: "internal error"},
 			errorExpected: true,
@@ -563,10 +663,10 @@ func TestServerAPI_Logout(t *testing.T) {
 				tc.setupMocks(useCases, logger)
 			}
 
-			resp, err := api.Logout(context.Background(), tc.in)
+			resp, err := api.VerifyEmailByCode(context.Background(), tc.in)
 			if tc.errorExpected {
 				require.Error(t, err)
-				require.IsType(t, tc.expectedErr, err)
+				require.Equal(t, tc.expectedErr, err)
 				require.Nil(t, resp)
 			} else {
 				require.NoError(t, err)
@@ -577,7 +677,7 @@ func TestServerAPI_Logout(t *testing.T) {
 	}
 }
 
-func TestServerAPI_Register(t *testing.T) {
+func TestServerAPI_Logout(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	useCases := mockusecases.NewMockUseCases(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
@@ -588,76 +688,96 @@ func TestServerAPI_Register(t *testing.T) {
 
 	testCases := []struct {
 		name          string
-		in            *sso.RegisterIn
+		in            *sso.LogoutIn
 		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
-		expectedOut   *sso.RegisterOut
 		expectedErr   error
 		errorExpected bool
 	}{
 		{
 			name: "success",
-			in: &sso.RegisterIn{
-				DisplayName: "John Doe",
-				Email:       "john@example.com",
-				Password:    "password123",
-			},
+			in:   &sso.LogoutIn{AccessToken: "valid-token"},
 			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
 				useCases.
 					EXPECT().
-					RegisterUser(gomock.Any(), entities.RegisterUserDTO{
-						DisplayName: "John Doe",
-						Email:       "john@example.com",
-						Password:    "password123",
-					}).
-					Return(uint64(1), nil).
+					LogoutUser(gomock.Any(), "valid-token").
+					Return(nil).
 					Times(1)
 			},
-			expectedOut:   &sso.RegisterOut{UserID: 1},
 			expectedErr:   nil,
 			errorExpected: false,
 		},
 		{
-			name: "user already exists",
-			in: &sso.RegisterIn{
-				DisplayName: "John Doe",
-				Email:       "john@example.com",
-				Password:    "password123",
-			},
+			name: "internal error",
+			in:   &sso.LogoutIn{AccessToken: "valid-token"},
 			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
 				useCases.
 					EXPECT().
-					RegisterUser(gomock.Any(), entities.RegisterUserDTO{
-						DisplayName: "John Doe",
-						Email:       "john@example.com",
-						Password:    "password123",
-					}).
-					Return(uint64(0), &customerrors.UserAlreadyExistsError{Message: "user already exists"}).
+					LogoutUser(gomock.Any(), "valid-token").
+					Return(errors.New("internal error")).
 					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Internal, Message: "internal error"},
+			errorExpected: true,
+		},
+	}
 
-				logger.
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.Logout(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+				require.IsType(t, &emptypb.Empty{}, resp)
+			}
+		})
+	}
+}
+
+func TestServerAPI_LogoutEverywhere(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	testCases := []struct {
+		name          string
+		in            *sso.LogoutEverywhereIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in:   &sso.LogoutEverywhereIn{AccessToken: "valid-token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					LogoutUserEverywhere(gomock.Any(), "valid-token").
+					Return(nil).
 					Times(1)
 			},
-			expectedErr:   &customgrpc.BaseError{Status: codes.AlreadyExists, Message: "user already exists"},
-			errorExpected: true,
+			expectedErr:   nil,
+			errorExpected: false,
 		},
 		{
-			name: "invalid email",
-			in: &sso.RegisterIn{
-				DisplayName: "John Doe",
-				Email:       "invalid",
-				Password:    "password123",
-			},
+			name: "invalid token",
+			in:   &sso.LogoutEverywhereIn{AccessToken: "invalid-token"},
 			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
 				useCases.
 					EXPECT().
-					RegisterUser(gomock.Any(), entities.RegisterUserDTO{
-						DisplayName: "John Doe",
-						Email:       "invalid",
-						Password:    "password123",
-					}).
-					Return(uint64(0), &validation.Error{Message: "invalid email"}).
+					LogoutUserEverywhere(gomock.Any(), "invalid-token").
+					Return(&security.InvalidJWTError{}).
 					Times(1)
 
 				logger.
@@ -665,25 +785,17 @@ func TestServerAPI_Register(t *testing.T) {
 					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1)
 			},
-			expectedErr:   &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: "invalid email"},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated},
 			errorExpected: true,
 		},
 		{
 			name: "internal error",
-			in: &sso.RegisterIn{
-				DisplayName: "John Doe",
-				Email:       "john@example.com",
-				Password:    "password123",
-			},
+			in:   &sso.LogoutEverywhereIn{AccessToken: "valid-token"},
 			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
 				useCases.
 					EXPECT().
-					RegisterUser(gomock.Any(), entities.RegisterUserDTO{
-						DisplayName: "John Doe",
-						Email:       "john@example.com",
-						Password:    "password123",
-					}).
-					Return(uint64(0), errors.New("internal error")).
+					LogoutUserEverywhere(gomock.Any(), "valid-token").
+					Return(errors.New("internal error")).
 					Times(1)
 
 				logger.
@@ -702,20 +814,22 @@ func TestServerAPI_Register(t *testing.T) {
 				tc.setupMocks(useCases, logger)
 			}
 
-			resp, err := api.Register(context.Background(), tc.in)
+			resp, err := api.LogoutEverywhere(context.Background(), tc.in)
 			if tc.errorExpected {
 				require.Error(t, err)
 				require.IsType(t, tc.expectedErr, err)
+				require.Equal(t, tc.expectedErr.(*customgrpc.BaseError).Status, err.(*customgrpc.BaseError).Status)
 				require.Nil(t, resp)
 			} else {
 				require.NoError(t, err)
-				require.Equal(t, tc.expectedOut, resp)
+				require.NotNil(t, resp)
+				require.IsType(t, &emptypb.Empty{}, resp)
 			}
 		})
 	}
 }
 
-func TestServerAPI_Login(t *testing.T) {
+func TestServerAPI_ListSessions(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	useCases := mockusecases.NewMockUseCases(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
@@ -724,79 +838,61 @@ func TestServerAPI_Login(t *testing.T) {
 		logger:   logger,
 	}
 
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
 	testCases := []struct {
 		name          string
-		in            *sso.LoginIn
+		in            *sso.ListSessionsIn
 		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
-		expectedOut   *sso.LoginOut
+		expectedIDs   []uint64
 		expectedErr   error
 		errorExpected bool
 	}{
 		{
 			name: "success",
-			in: &sso.LoginIn{
-				Email:    "john@example.com",
-				Password: "password123",
-			},
+			in:   &sso.ListSessionsIn{AccessToken: "valid-token"},
 			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
-				tokens := &entities.TokensDTO{
-					AccessToken:  "access-token",
-					RefreshToken: "refresh-token",
-				}
 				useCases.
 					EXPECT().
-					LoginUser(gomock.Any(), entities.LoginUserDTO{
-						Email:    "john@example.com",
-						Password: "password123",
-					}).
-					Return(tokens, nil).
+					GetUserSessions(gomock.Any(), "valid-token").
+					Return(
+						&entities.SessionsDTO{
+							Sessions: []entities.Session{
+								{ID: 2, UserID: 1, DeviceName: "iPhone", TTL: now, CreatedAt: now, UpdatedAt: now},
+								{ID: 1, UserID: 1, DeviceName: "Desktop", TTL: now, CreatedAt: now, UpdatedAt: now},
+							},
+							CurrentSessionID: 2,
+						},
+						nil,
+					).
 					Times(1)
 			},
-			expectedOut: &sso.LoginOut{
-				AccessToken:  "access-token",
-				RefreshToken: "refresh-token",
-			},
+			expectedIDs:   []uint64{2, 1},
 			expectedErr:   nil,
 			errorExpected: false,
 		},
 		{
-			name: "user not found",
-			in: &sso.LoginIn{
-				Email:    "john@example.com",
-				Password: "password123",
-			},
+			name: "no sessions",
+			in:   &sso.ListSessionsIn{AccessToken: "valid-token"},
 			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
 				useCases.
 					EXPECT().
-					LoginUser(gomock.Any(), entities.LoginUserDTO{
-						Email:    "john@example.com",
-						Password: "password123",
-					}).
-					Return(nil, &customerrors.UserNotFoundError{Message: "user not found"}).
-					Times(1)
-
-				logger.
-					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					GetUserSessions(gomock.Any(), "valid-token").
+					Return(&entities.SessionsDTO{}, nil).
 					Times(1)
 			},
-			expectedErr:   &customgrpc.BaseError{Status: codes.NotFound, Message: "user not found"},
-			errorExpected: true,
+			expectedIDs:   []uint64{},
+			expectedErr:   nil,
+			errorExpected: false,
 		},
 		{
-			name: "wrong password",
-			in: &sso.LoginIn{
-				Email:    "john@example.com",
-				Password: "wrongpass",
-			},
+			name: "invalid token",
+			in:   &sso.ListSessionsIn{AccessToken: "invalid-token"},
 			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
 				useCases.
 					EXPECT().
-					LoginUser(gomock.Any(), entities.LoginUserDTO{
-						Email:    "john@example.com",
-						Password: "wrongpass",
-					}).
-					Return(nil, &customerrors.WrongPasswordError{Message: "wrong password"}).
+					GetUserSessions(gomock.Any(), "invalid-token").
+					Return(nil, &security.InvalidJWTError{}).
 					Times(1)
 
 				logger.
@@ -804,22 +900,16 @@ func TestServerAPI_Login(t *testing.T) {
 					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1)
 			},
-			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated, Message: "wrong password"},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated},
 			errorExpected: true,
 		},
 		{
 			name: "internal error",
-			in: &sso.LoginIn{
-				Email:    "john@example.com",
-				Password: "password123",
-			},
+			in:   &sso.ListSessionsIn{AccessToken: "valid-token"},
 			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
 				useCases.
 					EXPECT().
-					LoginUser(gomock.Any(), entities.LoginUserDTO{
-						Email:    "john@example.com",
-						Password: "password123",
-					}).
+					GetUserSessions(gomock.Any(), "valid-token").
 					Return(nil, errors.New("internal error")).
 					Times(1)
 
@@ -839,20 +929,2835 @@ func TestServerAPI_Login(t *testing.T) {
 				tc.setupMocks(useCases, logger)
 			}
 
-			resp, err := api.Login(context.Background(), tc.in)
+			resp, err := api.ListSessions(context.Background(), tc.in)
 			if tc.errorExpected {
 				require.Error(t, err)
 				require.IsType(t, tc.expectedErr, err)
+				require.Equal(t, tc.expectedErr.(*customgrpc.BaseError).Status, err.(*customgrpc.BaseError).Status)
 				require.Nil(t, resp)
 			} else {
 				require.NoError(t, err)
-				require.Equal(t, tc.expectedOut, resp)
+				require.Len(t, resp.GetSessions(), len(tc.expectedIDs))
+
+				for i, session := range resp.GetSessions() {
+					require.Equal(t, tc.expectedIDs[i], session.GetID())
+				}
 			}
 		})
 	}
 }
 
-func TestServerAPI_RefreshTokens(t *testing.T) {
+func TestServerAPI_GetJWKS(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	testCases := []struct {
+		name         string
+		setupMocks   func(useCases *mockusecases.MockUseCases)
+		expectedKIDs []string
+	}{
+		{
+			name: "success",
+			setupMocks: func(useCases *mockusecases.MockUseCases) {
+				useCases.
+					EXPECT().
+					GetJWKS().
+					Return(
+						entities.JWKS{
+							Keys: []entities.JWK{
+								{KeyType: "RSA", KeyID: "current", Use: "sig", Algorithm: "RS256", N: "n", E: "AQAB"},
+								{KeyType: "OKP", KeyID: "previous", Use: "sig", Algorithm: "EdDSA", Curve: "Ed25519", X: "x"},
+							},
+						},
+					).
+					Times(1)
+			},
+			expectedKIDs: []string{"current", "previous"},
+		},
+		{
+			name: "shared secret is used",
+			setupMocks: func(useCases *mockusecases.MockUseCases) {
+				useCases.
+					EXPECT().
+					GetJWKS().
+					Return(entities.JWKS{}).
+					Times(1)
+			},
+			expectedKIDs: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases)
+			}
+
+			resp, err := api.GetJWKS(context.Background(), &emptypb.Empty{})
+			require.NoError(t, err)
+			require.Len(t, resp.GetKeys(), len(tc.expectedKIDs))
+
+			for i, key := range resp.GetKeys() {
+				require.Equal(t, tc.expectedKIDs[i], key.GetKid())
+			}
+		})
+	}
+}
+
+func TestServerAPI_RevokeSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	testCases := []struct {
+		name          string
+		in            *sso.RevokeSessionIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in:   &sso.RevokeSessionIn{AccessToken: "valid-token", SessionID: 2},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					RevokeSession(gomock.Any(), "valid-token", uint64(2)).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "invalid token",
+			in:   &sso.RevokeSessionIn{AccessToken: "invalid-token", SessionID: 2},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					RevokeSession(gomock.Any(), "invalid-token", uint64(2)).
+					Return(&security.InvalidJWTError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated},
+			errorExpected: true,
+		},
+		{
+			name: "session not found",
+			in:   &sso.RevokeSessionIn{AccessToken: "valid-token", SessionID: 3},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					RevokeSession(gomock.Any(), "valid-token", uint64(3)).
+					Return(&customerrors.SessionNotFoundError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.NotFound},
+			errorExpected: true,
+		},
+		{
+			name: "internal error",
+			in:   &sso.RevokeSessionIn{AccessToken: "valid-token", SessionID: 2},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					RevokeSession(gomock.Any(), "valid-token", uint64(2)).
+					Return(errors.New("internal error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Internal, Message: "internal error"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.RevokeSession(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Equal(t, tc.expectedErr.(*customgrpc.BaseError).Status, err.(*customgrpc.BaseError).Status)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+				require.IsType(t, &emptypb.Empty{}, resp)
+			}
+		})
+	}
+}
+
+func TestServerAPI_Register(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	testCases := []struct {
+		name          string
+		in            *sso.RegisterIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedOut   *sso.RegisterOut
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in: &sso.RegisterIn{
+				DisplayName: "John Doe",
+				Email:       "john@example.com",
+				Password:    "password123",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					RegisterUser(gomock.Any(), entities.RegisterUserDTO{
+						DisplayName: "John Doe",
+						Email:       "john@example.com",
+						Password:    "password123",
+					}).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectedOut:   &sso.RegisterOut{UserID: 1},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "user already exists",
+			in: &sso.RegisterIn{
+				DisplayName: "John Doe",
+				Email:       "john@example.com",
+				Password:    "password123",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					RegisterUser(gomock.Any(), entities.RegisterUserDTO{
+						DisplayName: "John Doe",
+						Email:       "john@example.com",
+						Password:    "password123",
+					}).
+					Return(uint64(0), &customerrors.UserAlreadyExistsError{Message: "user already exists"}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.AlreadyExists, Message: "user already exists"},
+			errorExpected: true,
+		},
+		{
+			name: "invalid email",
+			in: &sso.RegisterIn{
+				DisplayName: "John Doe",
+				Email:       "invalid",
+				Password:    "password123",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					RegisterUser(gomock.Any(), entities.RegisterUserDTO{
+						DisplayName: "John Doe",
+						Email:       "invalid",
+						Password:    "password123",
+					}).
+					Return(uint64(0), &validation.Error{Message: "invalid email"}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: "invalid email"},
+			errorExpected: true,
+		},
+		{
+			name: "internal error",
+			in: &sso.RegisterIn{
+				DisplayName: "John Doe",
+				Email:       "john@example.com",
+				Password:    "password123",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					RegisterUser(gomock.Any(), entities.RegisterUserDTO{
+						DisplayName: "John Doe",
+						Email:       "john@example.com",
+						Password:    "password123",
+					}).
+					Return(uint64(0), errors.New("internal error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Internal, Message: "internal error"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.Register(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedOut, resp)
+			}
+		})
+	}
+}
+
+func TestServerAPI_Login(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	testCases := []struct {
+		name          string
+		in            *sso.LoginIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedOut   *sso.LoginOut
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in: &sso.LoginIn{
+				Email:    "john@example.com",
+				Password: "password123",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				tokens := &entities.TokensDTO{
+					AccessToken:  "access-token",
+					RefreshToken: "refresh-token",
+				}
+				useCases.
+					EXPECT().
+					LoginUser(gomock.Any(), entities.LoginUserDTO{
+						Identifier: "john@example.com",
+						Password:   "password123",
+					}).
+					Return(tokens, nil).
+					Times(1)
+			},
+			expectedOut: &sso.LoginOut{
+				AccessToken:  "access-token",
+				RefreshToken: "refresh-token",
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "success with identifier",
+			in: &sso.LoginIn{
+				Identifier: "@john",
+				Password:   "password123",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				tokens := &entities.TokensDTO{
+					AccessToken:  "access-token",
+					RefreshToken: "refresh-token",
+				}
+				useCases.
+					EXPECT().
+					LoginUser(gomock.Any(), entities.LoginUserDTO{
+						Identifier: "@john",
+						Password:   "password123",
+					}).
+					Return(tokens, nil).
+					Times(1)
+			},
+			expectedOut: &sso.LoginOut{
+				AccessToken:  "access-token",
+				RefreshToken: "refresh-token",
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "user not found",
+			in: &sso.LoginIn{
+				Email:    "john@example.com",
+				Password: "password123",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					LoginUser(gomock.Any(), entities.LoginUserDTO{
+						Identifier: "john@example.com",
+						Password:   "password123",
+					}).
+					Return(nil, &customerrors.UserNotFoundError{Message: "user not found"}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.NotFound, Message: "user not found"},
+			errorExpected: true,
+		},
+		{
+			name: "wrong password",
+			in: &sso.LoginIn{
+				Email:    "john@example.com",
+				Password: "wrongpass",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					LoginUser(gomock.Any(), entities.LoginUserDTO{
+						Identifier: "john@example.com",
+						Password:   "wrongpass",
+					}).
+					Return(nil, &customerrors.WrongPasswordError{Message: "wrong password"}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated, Message: "wrong password"},
+			errorExpected: true,
+		},
+		{
+			name: "user blocked",
+			in: &sso.LoginIn{
+				Email:    "john@example.com",
+				Password: "password123",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					LoginUser(gomock.Any(), entities.LoginUserDTO{
+						Identifier: "john@example.com",
+						Password:   "password123",
+					}).
+					Return(nil, &customerrors.UserBlockedError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.PermissionDenied, Message: "user has been blocked"},
+			errorExpected: true,
+		},
+		{
+			name: "account is locked",
+			in: &sso.LoginIn{
+				Email:    "john@example.com",
+				Password: "wrongpass",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					LoginUser(gomock.Any(), entities.LoginUserDTO{
+						Identifier: "john@example.com",
+						Password:   "wrongpass",
+					}).
+					Return(nil, &customerrors.AccountLockedError{RetryAfter: time.Minute}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.ResourceExhausted,
+				Message: "account is temporarily locked due to failed login attempts. Retry after 1m0s",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "invalid client",
+			in: &sso.LoginIn{
+				Email:    "john@example.com",
+				Password: "password123",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					LoginUser(gomock.Any(), entities.LoginUserDTO{
+						Identifier: "john@example.com",
+						Password:   "password123",
+					}).
+					Return(nil, &customerrors.InvalidClientError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated, Message: "client authentication failed"},
+			errorExpected: true,
+		},
+		{
+			name: "unauthorized client",
+			in: &sso.LoginIn{
+				Email:    "john@example.com",
+				Password: "password123",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					LoginUser(gomock.Any(), entities.LoginUserDTO{
+						Identifier: "john@example.com",
+						Password:   "password123",
+					}).
+					Return(nil, &customerrors.UnauthorizedClientError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.PermissionDenied,
+				Message: "client is not allowed to use this grant type",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "internal error",
+			in: &sso.LoginIn{
+				Email:    "john@example.com",
+				Password: "password123",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					LoginUser(gomock.Any(), entities.LoginUserDTO{
+						Identifier: "john@example.com",
+						Password:   "password123",
+					}).
+					Return(nil, errors.New("internal error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Internal, Message: "internal error"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.Login(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedOut, resp)
+			}
+		})
+	}
+}
+
+func TestServerAPI_RefreshTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	testCases := []struct {
+		name          string
+		in            *sso.RefreshTokensIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedOut   *sso.LoginOut
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in:   &sso.RefreshTokensIn{RefreshToken: "valid-refresh-token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				tokens := &entities.TokensDTO{
+					AccessToken:  "new-access-token",
+					RefreshToken: "new-refresh-token",
+				}
+				useCases.
+					EXPECT().
+					RefreshTokens(gomock.Any(), "valid-refresh-token").
+					Return(tokens, nil).
+					Times(1)
+			},
+			expectedOut: &sso.LoginOut{
+				AccessToken:  "new-access-token",
+				RefreshToken: "new-refresh-token",
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "invalid JWT",
+			in:   &sso.RefreshTokensIn{RefreshToken: "invalid-refresh-token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					RefreshTokens(gomock.Any(), "invalid-refresh-token").
+					Return(nil, &security.InvalidJWTError{Message: "invalid token"}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated, Message: "invalid token"},
+			errorExpected: true,
+		},
+		{
+			name: "access token mismatch",
+			in:   &sso.RefreshTokensIn{RefreshToken: "valid-refresh-token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					RefreshTokens(gomock.Any(), "valid-refresh-token").
+					Return(nil, &customerrors.AccessTokenDoesNotBelongToRefreshTokenError{Message: "token mismatch"}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated, Message: "token mismatch"},
+			errorExpected: true,
+		},
+		{
+			name: "refresh token reuse detected",
+			in:   &sso.RefreshTokensIn{RefreshToken: "rotated-refresh-token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					RefreshTokens(gomock.Any(), "rotated-refresh-token").
+					Return(nil, &customerrors.RefreshTokenReuseDetectedError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated, Message: "refresh token has been already used"},
+			errorExpected: true,
+		},
+		{
+			name: "user blocked",
+			in:   &sso.RefreshTokensIn{RefreshToken: "valid-refresh-token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					RefreshTokens(gomock.Any(), "valid-refresh-token").
+					Return(nil, &customerrors.UserBlockedError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.PermissionDenied, Message: "user has been blocked"},
+			errorExpected: true,
+		},
+		{
+			name: "user not found",
+			in:   &sso.RefreshTokensIn{RefreshToken: "valid-refresh-token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					RefreshTokens(gomock.Any(), "valid-refresh-token").
+					Return(nil, &customerrors.UserNotFoundError{Message: "user not found"}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.NotFound, Message: "user not found"},
+			errorExpected: true,
+		},
+		{
+			name: "internal error",
+			in:   &sso.RefreshTokensIn{RefreshToken: "valid-refresh-token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					RefreshTokens(gomock.Any(), "valid-refresh-token").
+					Return(nil, errors.New("internal error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Internal, Message: "internal error"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.RefreshTokens(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedOut, resp)
+			}
+		})
+	}
+}
+
+func TestServerAPI_IntrospectToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	testCases := []struct {
+		name          string
+		in            *sso.IntrospectTokenIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedOut   *sso.IntrospectTokenOut
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "active token",
+			in:   &sso.IntrospectTokenIn{Token: "access-token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					IntrospectToken(gomock.Any(), "access-token").
+					Return(
+						&entities.TokenIntrospection{
+							Active:    true,
+							TokenType: entities.AccessTokenType,
+							UserID:    1,
+							SessionID: 2,
+							Scopes:    []string{"openid"},
+							Roles:     []string{entities.UserRole},
+						},
+						nil,
+					).
+					Times(1)
+			},
+			expectedOut: &sso.IntrospectTokenOut{
+				Active:    true,
+				TokenType: entities.AccessTokenType,
+				UserID:    1,
+				SessionID: 2,
+				Scopes:    []string{"openid"},
+				Roles:     []string{entities.UserRole},
+				IssuedAt:  timestamppb.New(time.Time{}),
+				ExpiresAt: timestamppb.New(time.Time{}),
+			},
+		},
+		{
+			name: "inactive token",
+			in:   &sso.IntrospectTokenIn{Token: "invalid-token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					IntrospectToken(gomock.Any(), "invalid-token").
+					Return(&entities.TokenIntrospection{Active: false}, nil).
+					Times(1)
+			},
+			expectedOut: &sso.IntrospectTokenOut{Active: false},
+		},
+		{
+			name: "internal error",
+			in:   &sso.IntrospectTokenIn{Token: "refresh-token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					IntrospectToken(gomock.Any(), "refresh-token").
+					Return(nil, errors.New("internal error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Internal, Message: "internal error"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.IntrospectToken(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedOut, resp)
+			}
+		})
+	}
+}
+
+func TestServerAPI_CompleteMFALogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	testCases := []struct {
+		name          string
+		in            *sso.CompleteMFALoginIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedOut   *sso.LoginOut
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in: &sso.CompleteMFALoginIn{
+				MfaChallenge: "challenge",
+				Code:         "123456",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					CompleteMFALogin(gomock.Any(), entities.CompleteMFALoginDTO{
+						MFAChallenge: "challenge",
+						Code:         "123456",
+					}).
+					Return(&entities.TokensDTO{AccessToken: "access-token", RefreshToken: "refresh-token"}, nil).
+					Times(1)
+			},
+			expectedOut: &sso.LoginOut{
+				AccessToken:  "access-token",
+				RefreshToken: "refresh-token",
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "invalid mfa challenge",
+			in: &sso.CompleteMFALoginIn{
+				MfaChallenge: "challenge",
+				Code:         "123456",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					CompleteMFALogin(gomock.Any(), gomock.Any()).
+					Return(nil, &customerrors.InvalidMFAChallengeError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated, Message: "invalid or expired mfa challenge"},
+			errorExpected: true,
+		},
+		{
+			name: "invalid code",
+			in: &sso.CompleteMFALoginIn{
+				MfaChallenge: "challenge",
+				Code:         "000000",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					CompleteMFALogin(gomock.Any(), gomock.Any()).
+					Return(nil, &customerrors.InvalidMFACodeError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated, Message: "invalid two-factor authentication code"},
+			errorExpected: true,
+		},
+		{
+			name: "mfa not enabled",
+			in: &sso.CompleteMFALoginIn{
+				MfaChallenge: "challenge",
+				Code:         "123456",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					CompleteMFALogin(gomock.Any(), gomock.Any()).
+					Return(nil, &customerrors.MFANotEnabledError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.FailedPrecondition,
+				Message: "two-factor authentication is not enabled",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "internal error",
+			in: &sso.CompleteMFALoginIn{
+				MfaChallenge: "challenge",
+				Code:         "123456",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					CompleteMFALogin(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("internal error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Internal, Message: "internal error"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.CompleteMFALogin(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedOut, resp)
+			}
+		})
+	}
+}
+
+func TestServerAPI_StartTOTPEnrollment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	testCases := []struct {
+		name          string
+		in            *sso.StartTOTPEnrollmentIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedOut   *sso.StartTOTPEnrollmentOut
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in:   &sso.StartTOTPEnrollmentIn{AccessToken: "access-token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					StartTOTPEnrollment(gomock.Any(), "access-token").
+					Return(&entities.TOTPEnrollmentDTO{Secret: "SECRET", URI: "otpauth://totp/hmtm"}, nil).
+					Times(1)
+			},
+			expectedOut: &sso.StartTOTPEnrollmentOut{
+				Secret: "SECRET",
+				Uri:    "otpauth://totp/hmtm",
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "invalid token",
+			in:   &sso.StartTOTPEnrollmentIn{AccessToken: "invalid-token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					StartTOTPEnrollment(gomock.Any(), "invalid-token").
+					Return(nil, &security.InvalidJWTError{Message: "invalid token"}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated, Message: "invalid token"},
+			errorExpected: true,
+		},
+		{
+			name: "user not found",
+			in:   &sso.StartTOTPEnrollmentIn{AccessToken: "access-token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					StartTOTPEnrollment(gomock.Any(), "access-token").
+					Return(nil, &customerrors.UserNotFoundError{Message: "user not found"}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.NotFound, Message: "user not found"},
+			errorExpected: true,
+		},
+		{
+			name: "already enabled",
+			in:   &sso.StartTOTPEnrollmentIn{AccessToken: "access-token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					StartTOTPEnrollment(gomock.Any(), "access-token").
+					Return(nil, &customerrors.MFAAlreadyEnabledError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.FailedPrecondition,
+				Message: "two-factor authentication is already enabled",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "internal error",
+			in:   &sso.StartTOTPEnrollmentIn{AccessToken: "access-token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					StartTOTPEnrollment(gomock.Any(), "access-token").
+					Return(nil, errors.New("internal error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Internal, Message: "internal error"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.StartTOTPEnrollment(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedOut, resp)
+			}
+		})
+	}
+}
+
+func TestServerAPI_ConfirmTOTPEnrollment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	testCases := []struct {
+		name          string
+		in            *sso.ConfirmTOTPEnrollmentIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedOut   *sso.ConfirmTOTPEnrollmentOut
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in: &sso.ConfirmTOTPEnrollmentIn{
+				AccessToken: "access-token",
+				Code:        "123456",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					ConfirmTOTPEnrollment(gomock.Any(), "access-token", "123456").
+					Return([]string{"AAAAA-BBBBB", "CCCCC-DDDDD"}, nil).
+					Times(1)
+			},
+			expectedOut: &sso.ConfirmTOTPEnrollmentOut{
+				RecoveryCodes: []string{"AAAAA-BBBBB", "CCCCC-DDDDD"},
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "invalid token",
+			in: &sso.ConfirmTOTPEnrollmentIn{
+				AccessToken: "invalid-token",
+				Code:        "123456",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					ConfirmTOTPEnrollment(gomock.Any(), "invalid-token", "123456").
+					Return(nil, &security.InvalidJWTError{Message: "invalid token"}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated, Message: "invalid token"},
+			errorExpected: true,
+		},
+		{
+			name: "invalid code",
+			in: &sso.ConfirmTOTPEnrollmentIn{
+				AccessToken: "access-token",
+				Code:        "000000",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					ConfirmTOTPEnrollment(gomock.Any(), "access-token", "000000").
+					Return(nil, &customerrors.InvalidMFACodeError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.FailedPrecondition,
+				Message: "invalid two-factor authentication code",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "internal error",
+			in: &sso.ConfirmTOTPEnrollmentIn{
+				AccessToken: "access-token",
+				Code:        "123456",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					ConfirmTOTPEnrollment(gomock.Any(), "access-token", "123456").
+					Return(nil, errors.New("internal error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Internal, Message: "internal error"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.ConfirmTOTPEnrollment(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedOut, resp)
+			}
+		})
+	}
+}
+
+func TestServerAPI_DisableTOTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	testCases := []struct {
+		name          string
+		in            *sso.DisableTOTPIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in: &sso.DisableTOTPIn{
+				AccessToken: "access-token",
+				Code:        "123456",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					DisableTOTP(gomock.Any(), "access-token", "123456").
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "invalid token",
+			in: &sso.DisableTOTPIn{
+				AccessToken: "invalid-token",
+				Code:        "123456",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					DisableTOTP(gomock.Any(), "invalid-token", "123456").
+					Return(&security.InvalidJWTError{Message: "invalid token"}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated, Message: "invalid token"},
+			errorExpected: true,
+		},
+		{
+			name: "mfa not enabled",
+			in: &sso.DisableTOTPIn{
+				AccessToken: "access-token",
+				Code:        "123456",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					DisableTOTP(gomock.Any(), "access-token", "123456").
+					Return(&customerrors.MFANotEnabledError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.FailedPrecondition,
+				Message: "two-factor authentication is not enabled",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "internal error",
+			in: &sso.DisableTOTPIn{
+				AccessToken: "access-token",
+				Code:        "123456",
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					DisableTOTP(gomock.Any(), "access-token", "123456").
+					Return(errors.New("internal error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Internal, Message: "internal error"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.DisableTOTP(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, &emptypb.Empty{}, resp)
+			}
+		})
+	}
+}
+
+func TestServerAPI_BeginWebAuthnRegistration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	testCases := []struct {
+		name          string
+		in            *sso.BeginWebAuthnRegistrationIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedOut   *sso.WebAuthnOptionsOut
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in:   &sso.BeginWebAuthnRegistrationIn{AccessToken: "token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					BeginWebAuthnRegistration(gomock.Any(), "token").
+					Return(`{"challenge":"challenge"}`, nil).
+					Times(1)
+			},
+			expectedOut:   &sso.WebAuthnOptionsOut{Options: `{"challenge":"challenge"}`},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "invalid token",
+			in:   &sso.BeginWebAuthnRegistrationIn{AccessToken: "token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					BeginWebAuthnRegistration(gomock.Any(), gomock.Any()).
+					Return("", &security.InvalidJWTError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated, Message: "invalid JWT"},
+			errorExpected: true,
+		},
+		{
+			name: "user not found",
+			in:   &sso.BeginWebAuthnRegistrationIn{AccessToken: "token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					BeginWebAuthnRegistration(gomock.Any(), gomock.Any()).
+					Return("", &customerrors.UserNotFoundError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.NotFound, Message: "user not found"},
+			errorExpected: true,
+		},
+		{
+			name: "internal error",
+			in:   &sso.BeginWebAuthnRegistrationIn{AccessToken: "token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					BeginWebAuthnRegistration(gomock.Any(), gomock.Any()).
+					Return("", errors.New("internal error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Internal, Message: "internal error"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.BeginWebAuthnRegistration(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedOut, resp)
+			}
+		})
+	}
+}
+
+func TestServerAPI_FinishWebAuthnRegistration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	testCases := []struct {
+		name          string
+		in            *sso.FinishWebAuthnRegistrationIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in: &sso.FinishWebAuthnRegistrationIn{
+				AccessToken:       "token",
+				CredentialID:      "credential",
+				ClientDataJSON:    []byte("client-data"),
+				AttestationObject: []byte("attestation-object"),
+				Transports:        []string{"internal"},
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					FinishWebAuthnRegistration(gomock.Any(), entities.FinishWebAuthnRegistrationDTO{
+						AccessToken:       "token",
+						CredentialID:      "credential",
+						ClientDataJSON:    []byte("client-data"),
+						AttestationObject: []byte("attestation-object"),
+						Transports:        []string{"internal"},
+					}).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "invalid token",
+			in:   &sso.FinishWebAuthnRegistrationIn{AccessToken: "token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					FinishWebAuthnRegistration(gomock.Any(), gomock.Any()).
+					Return(&security.InvalidJWTError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated, Message: "invalid JWT"},
+			errorExpected: true,
+		},
+		{
+			name: "invalid webauthn challenge",
+			in:   &sso.FinishWebAuthnRegistrationIn{AccessToken: "token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					FinishWebAuthnRegistration(gomock.Any(), gomock.Any()).
+					Return(&customerrors.InvalidWebAuthnChallengeError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.InvalidArgument,
+				Message: "invalid or expired webauthn challenge",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "invalid webauthn credential",
+			in:   &sso.FinishWebAuthnRegistrationIn{AccessToken: "token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					FinishWebAuthnRegistration(gomock.Any(), gomock.Any()).
+					Return(&customerrors.InvalidWebAuthnCredentialError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.InvalidArgument, Message: "invalid webauthn credential"},
+			errorExpected: true,
+		},
+		{
+			name: "internal error",
+			in:   &sso.FinishWebAuthnRegistrationIn{AccessToken: "token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					FinishWebAuthnRegistration(gomock.Any(), gomock.Any()).
+					Return(errors.New("internal error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Internal, Message: "internal error"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.FinishWebAuthnRegistration(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, &emptypb.Empty{}, resp)
+			}
+		})
+	}
+}
+
+func TestServerAPI_BeginWebAuthnLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	testCases := []struct {
+		name          string
+		in            *sso.BeginWebAuthnLoginIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedOut   *sso.WebAuthnOptionsOut
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in:   &sso.BeginWebAuthnLoginIn{Email: "test@example.com"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					BeginWebAuthnLogin(gomock.Any(), "test@example.com").
+					Return(`{"challenge":"challenge"}`, nil).
+					Times(1)
+			},
+			expectedOut:   &sso.WebAuthnOptionsOut{Options: `{"challenge":"challenge"}`},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "user not found",
+			in:   &sso.BeginWebAuthnLoginIn{Email: "test@example.com"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					BeginWebAuthnLogin(gomock.Any(), gomock.Any()).
+					Return("", &customerrors.UserNotFoundError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.NotFound, Message: "user not found"},
+			errorExpected: true,
+		},
+		{
+			name: "webauthn credential not found",
+			in:   &sso.BeginWebAuthnLoginIn{Email: "test@example.com"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					BeginWebAuthnLogin(gomock.Any(), gomock.Any()).
+					Return("", &customerrors.WebAuthnCredentialNotFoundError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.NotFound, Message: "webauthn credential not found"},
+			errorExpected: true,
+		},
+		{
+			name: "internal error",
+			in:   &sso.BeginWebAuthnLoginIn{Email: "test@example.com"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					BeginWebAuthnLogin(gomock.Any(), gomock.Any()).
+					Return("", errors.New("internal error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Internal, Message: "internal error"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.BeginWebAuthnLogin(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedOut, resp)
+			}
+		})
+	}
+}
+
+func TestServerAPI_FinishWebAuthnLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	testCases := []struct {
+		name          string
+		in            *sso.FinishWebAuthnLoginIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedOut   *sso.LoginOut
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in: &sso.FinishWebAuthnLoginIn{
+				CredentialID:      "credential",
+				ClientDataJSON:    []byte("client-data"),
+				AuthenticatorData: []byte("authenticator-data"),
+				Signature:         []byte("signature"),
+				UserHandle:        []byte("1"),
+			},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					FinishWebAuthnLogin(gomock.Any(), entities.FinishWebAuthnLoginDTO{
+						CredentialID:      "credential",
+						ClientDataJSON:    []byte("client-data"),
+						AuthenticatorData: []byte("authenticator-data"),
+						Signature:         []byte("signature"),
+						UserHandle:        []byte("1"),
+					}).
+					Return(&entities.TokensDTO{AccessToken: "access-token", RefreshToken: "refresh-token"}, nil).
+					Times(1)
+			},
+			expectedOut: &sso.LoginOut{
+				AccessToken:  "access-token",
+				RefreshToken: "refresh-token",
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "invalid webauthn challenge",
+			in:   &sso.FinishWebAuthnLoginIn{CredentialID: "credential"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					FinishWebAuthnLogin(gomock.Any(), gomock.Any()).
+					Return(nil, &customerrors.InvalidWebAuthnChallengeError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.Unauthenticated,
+				Message: "invalid or expired webauthn challenge",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "webauthn credential not found",
+			in:   &sso.FinishWebAuthnLoginIn{CredentialID: "credential"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					FinishWebAuthnLogin(gomock.Any(), gomock.Any()).
+					Return(nil, &customerrors.WebAuthnCredentialNotFoundError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated, Message: "webauthn credential not found"},
+			errorExpected: true,
+		},
+		{
+			name: "email is not confirmed",
+			in:   &sso.FinishWebAuthnLoginIn{CredentialID: "credential"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					FinishWebAuthnLogin(gomock.Any(), gomock.Any()).
+					Return(nil, &customerrors.EmailIsNotConfirmedError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: "provided email is not confirmed"},
+			errorExpected: true,
+		},
+		{
+			name: "internal error",
+			in:   &sso.FinishWebAuthnLoginIn{CredentialID: "credential"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					FinishWebAuthnLogin(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("internal error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Internal, Message: "internal error"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.FinishWebAuthnLogin(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedOut, resp)
+			}
+		})
+	}
+}
+
+func TestServerAPI_SendLoginLink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	testCases := []struct {
+		name          string
+		in            *sso.SendLoginLinkIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedOut   *emptypb.Empty
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in:   &sso.SendLoginLinkIn{Email: "test@example.com"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					SendLoginLink(gomock.Any(), "test@example.com").
+					Return(nil).
+					Times(1)
+			},
+			expectedOut:   &emptypb.Empty{},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "user not found",
+			in:   &sso.SendLoginLinkIn{Email: "test@example.com"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					SendLoginLink(gomock.Any(), "test@example.com").
+					Return(&customerrors.UserNotFoundError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.NotFound, Message: "user not found"},
+			errorExpected: true,
+		},
+		{
+			name: "email is not confirmed",
+			in:   &sso.SendLoginLinkIn{Email: "test@example.com"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					SendLoginLink(gomock.Any(), "test@example.com").
+					Return(&customerrors.EmailIsNotConfirmedError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: "provided email is not confirmed"},
+			errorExpected: true,
+		},
+		{
+			name: "internal error",
+			in:   &sso.SendLoginLinkIn{Email: "test@example.com"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					SendLoginLink(gomock.Any(), "test@example.com").
+					Return(errors.New("internal error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Internal, Message: "internal error"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.SendLoginLink(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedOut, resp)
+			}
+		})
+	}
+}
+
+func TestServerAPI_BeginFederatedLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	testCases := []struct {
+		name          string
+		in            *sso.BeginFederatedLoginIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedOut   *sso.BeginFederatedLoginOut
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in:   &sso.BeginFederatedLoginIn{Provider: "google"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					BeginFederatedLogin(gomock.Any(), "google").
+					Return("https://accounts.google.com/o/oauth2/v2/auth?state=state", nil).
+					Times(1)
+			},
+			expectedOut: &sso.BeginFederatedLoginOut{
+				AuthorizationURL: "https://accounts.google.com/o/oauth2/v2/auth?state=state",
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "identity provider not found",
+			in:   &sso.BeginFederatedLoginIn{Provider: "unknown"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					BeginFederatedLogin(gomock.Any(), "unknown").
+					Return("", &customerrors.IdentityProviderNotFoundError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.NotFound, Message: "identity provider not found"},
+			errorExpected: true,
+		},
+		{
+			name: "internal error",
+			in:   &sso.BeginFederatedLoginIn{Provider: "google"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					BeginFederatedLogin(gomock.Any(), gomock.Any()).
+					Return("", errors.New("internal error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Internal, Message: "internal error"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.BeginFederatedLogin(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedOut, resp)
+			}
+		})
+	}
+}
+
+func TestServerAPI_FinishFederatedLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	in := &sso.FinishFederatedLoginIn{
+		Provider: "google",
+		Code:     "code",
+		State:    "state",
+	}
+
+	testCases := []struct {
+		name          string
+		in            *sso.FinishFederatedLoginIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedOut   *sso.LoginOut
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					FinishFederatedLogin(gomock.Any(), entities.FinishFederatedLoginDTO{
+						Provider: "google",
+						Code:     "code",
+						State:    "state",
+					}).
+					Return(&entities.TokensDTO{AccessToken: "access-token", RefreshToken: "refresh-token"}, nil).
+					Times(1)
+			},
+			expectedOut: &sso.LoginOut{
+				AccessToken:  "access-token",
+				RefreshToken: "refresh-token",
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "identity provider not found",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					FinishFederatedLogin(gomock.Any(), gomock.Any()).
+					Return(nil, &customerrors.IdentityProviderNotFoundError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.NotFound, Message: "identity provider not found"},
+			errorExpected: true,
+		},
+		{
+			name: "invalid federated login state",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					FinishFederatedLogin(gomock.Any(), gomock.Any()).
+					Return(nil, &customerrors.InvalidFederatedLoginStateError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.Unauthenticated,
+				Message: "federated login state is invalid or expired",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "federated login error",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					FinishFederatedLogin(gomock.Any(), gomock.Any()).
+					Return(nil, &customerrors.FederatedLoginError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.Unauthenticated,
+				Message: "failed to authenticate via identity provider",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "email is not confirmed",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					FinishFederatedLogin(gomock.Any(), gomock.Any()).
+					Return(nil, &customerrors.EmailIsNotConfirmedError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: "provided email is not confirmed"},
+			errorExpected: true,
+		},
+		{
+			name: "unauthorized client",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					FinishFederatedLogin(gomock.Any(), gomock.Any()).
+					Return(nil, &customerrors.UnauthorizedClientError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.PermissionDenied,
+				Message: (&customerrors.UnauthorizedClientError{}).Error(),
+			},
+			errorExpected: true,
+		},
+		{
+			name: "internal error",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					FinishFederatedLogin(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("internal error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Internal, Message: "internal error"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.FinishFederatedLogin(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedOut, resp)
+			}
+		})
+	}
+}
+
+func TestServerAPI_LoginWithTelegram(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	in := &sso.TelegramAuthIn{
+		Id:       42,
+		Username: "ivan_petrov",
+		AuthDate: 1700000000,
+		Hash:     "hash",
+	}
+
+	testCases := []struct {
+		name          string
+		in            *sso.TelegramAuthIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedOut   *sso.LoginOut
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					LoginWithTelegram(gomock.Any(), entities.LoginWithTelegramDTO{
+						TelegramAuth: entities.TelegramAuthDTO{
+							ID:       42,
+							Username: "ivan_petrov",
+							AuthDate: 1700000000,
+							Hash:     "hash",
+						},
+					}).
+					Return(&entities.TokensDTO{AccessToken: "access-token", RefreshToken: "refresh-token"}, nil).
+					Times(1)
+			},
+			expectedOut: &sso.LoginOut{
+				AccessToken:  "access-token",
+				RefreshToken: "refresh-token",
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "invalid telegram auth",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					LoginWithTelegram(gomock.Any(), gomock.Any()).
+					Return(nil, &customerrors.InvalidTelegramAuthError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated, Message: "invalid telegram auth data"},
+			errorExpected: true,
+		},
+		{
+			name: "telegram is not linked",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					LoginWithTelegram(gomock.Any(), gomock.Any()).
+					Return(nil, &customerrors.UserIdentityNotFoundError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.NotFound, Message: "user identity not found"},
+			errorExpected: true,
+		},
+		{
+			name: "unauthorized client",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					LoginWithTelegram(gomock.Any(), gomock.Any()).
+					Return(nil, &customerrors.UnauthorizedClientError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.PermissionDenied,
+				Message: (&customerrors.UnauthorizedClientError{}).Error(),
+			},
+			errorExpected: true,
+		},
+		{
+			name: "internal error",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					LoginWithTelegram(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("internal error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Internal, Message: "internal error"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.LoginWithTelegram(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedOut, resp)
+			}
+		})
+	}
+}
+
+func TestServerAPI_LinkTelegram(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	in := &sso.LinkTelegramIn{
+		AccessToken: "access-token",
+		TelegramAuth: &sso.TelegramAuthIn{
+			Id:       42,
+			Username: "ivan_petrov",
+			AuthDate: 1700000000,
+			Hash:     "hash",
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		in            *sso.LinkTelegramIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					LinkTelegram(gomock.Any(), entities.LinkTelegramDTO{
+						AccessToken: "access-token",
+						TelegramAuth: entities.TelegramAuthDTO{
+							ID:       42,
+							Username: "ivan_petrov",
+							AuthDate: 1700000000,
+							Hash:     "hash",
+						},
+					}).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "invalid jwt",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					LinkTelegram(gomock.Any(), gomock.Any()).
+					Return(&security.InvalidJWTError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.Unauthenticated,
+				Message: (&security.InvalidJWTError{}).Error(),
+			},
+			errorExpected: true,
+		},
+		{
+			name: "invalid telegram auth",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					LinkTelegram(gomock.Any(), gomock.Any()).
+					Return(&customerrors.InvalidTelegramAuthError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.InvalidArgument, Message: "invalid telegram auth data"},
+			errorExpected: true,
+		},
+		{
+			name: "telegram is linked to another user",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					LinkTelegram(gomock.Any(), gomock.Any()).
+					Return(&customerrors.UserIdentityAlreadyExistsError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.AlreadyExists,
+				Message: "user identity is already linked to another user",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "internal error",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					LinkTelegram(gomock.Any(), gomock.Any()).
+					Return(errors.New("internal error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Internal, Message: "internal error"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.LinkTelegram(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+			}
+		})
+	}
+}
+
+func TestServerAPI_SendPhoneVerificationCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	in := &sso.SendPhoneVerificationCodeIn{AccessToken: "access-token"}
+
+	testCases := []struct {
+		name          string
+		in            *sso.SendPhoneVerificationCodeIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					SendPhoneVerificationCode(gomock.Any(), "access-token").
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "invalid jwt",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					SendPhoneVerificationCode(gomock.Any(), gomock.Any()).
+					Return(&security.InvalidJWTError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.Unauthenticated,
+				Message: (&security.InvalidJWTError{}).Error(),
+			},
+			errorExpected: true,
+		},
+		{
+			name: "phone is not set",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					SendPhoneVerificationCode(gomock.Any(), gomock.Any()).
+					Return(&customerrors.PhoneIsNotSetError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.FailedPrecondition,
+				Message: "phone is not set",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "limit exceeded",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					SendPhoneVerificationCode(gomock.Any(), gomock.Any()).
+					Return(&customerrors.LimitExceededError{Message: "Too many tries to send SMS. Limit per minute is 1"}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.ResourceExhausted,
+				Message: "limit exceeded: Too many tries to send SMS. Limit per minute is 1",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "internal error",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					SendPhoneVerificationCode(gomock.Any(), gomock.Any()).
+					Return(errors.New("internal error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.Internal,
+				Message: "internal error",
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.SendPhoneVerificationCode(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+			}
+		})
+	}
+}
+
+func TestServerAPI_VerifyPhone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	in := &sso.VerifyPhoneIn{AccessToken: "access-token", Code: "123456"}
+
+	testCases := []struct {
+		name          string
+		in            *sso.VerifyPhoneIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					VerifyPhone(gomock.Any(), "access-token", "123456").
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "invalid jwt",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					VerifyPhone(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&security.InvalidJWTError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.Unauthenticated,
+				Message: (&security.InvalidJWTError{}).Error(),
+			},
+			errorExpected: true,
+		},
+		{
+			name: "invalid code",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					VerifyPhone(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&customerrors.InvalidPhoneVerificationCodeError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.FailedPrecondition,
+				Message: "phone verification code is invalid or expired",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "phone is already confirmed",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					VerifyPhone(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&customerrors.PhoneAlreadyConfirmedError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.FailedPrecondition,
+				Message: "provided phone has been already confirmed",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "user not found",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					VerifyPhone(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&customerrors.UserNotFoundError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.NotFound,
+				Message: "user not found",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "internal error",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					VerifyPhone(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("internal error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.Internal,
+				Message: "internal error",
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.VerifyPhone(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+			}
+		})
+	}
+}
+
+func TestServerAPI_LoginWithCode(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	useCases := mockusecases.NewMockUseCases(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
@@ -863,41 +3768,60 @@ func TestServerAPI_RefreshTokens(t *testing.T) {
 
 	testCases := []struct {
 		name          string
-		in            *sso.RefreshTokensIn
+		in            *sso.LoginWithCodeIn
 		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
 		expectedOut   *sso.LoginOut
 		expectedErr   error
 		errorExpected bool
 	}{
 		{
-			name: "success",
-			in:   &sso.RefreshTokensIn{RefreshToken: "valid-refresh-token"},
+			name: "success with code",
+			in: &sso.LoginWithCodeIn{
+				Email: "test@example.com",
+				Code:  "123456",
+			},
 			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
-				tokens := &entities.TokensDTO{
-					AccessToken:  "new-access-token",
-					RefreshToken: "new-refresh-token",
-				}
 				useCases.
 					EXPECT().
-					RefreshTokens(gomock.Any(), "valid-refresh-token").
-					Return(tokens, nil).
+					LoginWithCode(gomock.Any(), entities.LoginWithCodeDTO{
+						Email: "test@example.com",
+						Code:  "123456",
+					}).
+					Return(&entities.TokensDTO{AccessToken: "access-token", RefreshToken: "refresh-token"}, nil).
 					Times(1)
 			},
 			expectedOut: &sso.LoginOut{
-				AccessToken:  "new-access-token",
-				RefreshToken: "new-refresh-token",
+				AccessToken:  "access-token",
+				RefreshToken: "refresh-token",
 			},
 			expectedErr:   nil,
 			errorExpected: false,
 		},
 		{
-			name: "invalid JWT",
-			in:   &sso.RefreshTokensIn{RefreshToken: "invalid-refresh-token"},
+			name: "success with token",
+			in:   &sso.LoginWithCodeIn{Token: "login-token"},
 			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
 				useCases.
 					EXPECT().
-					RefreshTokens(gomock.Any(), "invalid-refresh-token").
-					Return(nil, &security.InvalidJWTError{Message: "invalid token"}).
+					LoginWithCode(gomock.Any(), entities.LoginWithCodeDTO{Token: "login-token"}).
+					Return(&entities.TokensDTO{AccessToken: "access-token", RefreshToken: "refresh-token"}, nil).
+					Times(1)
+			},
+			expectedOut: &sso.LoginOut{
+				AccessToken:  "access-token",
+				RefreshToken: "refresh-token",
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "invalid login token",
+			in:   &sso.LoginWithCodeIn{Token: "login-token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					LoginWithCode(gomock.Any(), gomock.Any()).
+					Return(nil, &customerrors.InvalidLoginTokenError{}).
 					Times(1)
 
 				logger.
@@ -905,17 +3829,17 @@ func TestServerAPI_RefreshTokens(t *testing.T) {
 					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1)
 			},
-			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated, Message: "invalid token"},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated, Message: "login token is invalid or expired"},
 			errorExpected: true,
 		},
 		{
-			name: "access token mismatch",
-			in:   &sso.RefreshTokensIn{RefreshToken: "valid-refresh-token"},
+			name: "email is not confirmed",
+			in:   &sso.LoginWithCodeIn{Token: "login-token"},
 			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
 				useCases.
 					EXPECT().
-					RefreshTokens(gomock.Any(), "valid-refresh-token").
-					Return(nil, &customerrors.AccessTokenDoesNotBelongToRefreshTokenError{Message: "token mismatch"}).
+					LoginWithCode(gomock.Any(), gomock.Any()).
+					Return(nil, &customerrors.EmailIsNotConfirmedError{}).
 					Times(1)
 
 				logger.
@@ -923,17 +3847,20 @@ func TestServerAPI_RefreshTokens(t *testing.T) {
 					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1)
 			},
-			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated, Message: "token mismatch"},
+			expectedErr:   &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: "provided email is not confirmed"},
 			errorExpected: true,
 		},
 		{
 			name: "user not found",
-			in:   &sso.RefreshTokensIn{RefreshToken: "valid-refresh-token"},
+			in: &sso.LoginWithCodeIn{
+				Email: "test@example.com",
+				Code:  "123456",
+			},
 			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
 				useCases.
 					EXPECT().
-					RefreshTokens(gomock.Any(), "valid-refresh-token").
-					Return(nil, &customerrors.UserNotFoundError{Message: "user not found"}).
+					LoginWithCode(gomock.Any(), gomock.Any()).
+					Return(nil, &customerrors.UserNotFoundError{}).
 					Times(1)
 
 				logger.
@@ -946,11 +3873,11 @@ func TestServerAPI_RefreshTokens(t *testing.T) {
 		},
 		{
 			name: "internal error",
-			in:   &sso.RefreshTokensIn{RefreshToken: "valid-refresh-token"},
+			in:   &sso.LoginWithCodeIn{Token: "login-token"},
 			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
 				useCases.
 					EXPECT().
-					RefreshTokens(gomock.Any(), "valid-refresh-token").
+					LoginWithCode(gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("internal error")).
 					Times(1)
 
@@ -970,7 +3897,7 @@ func TestServerAPI_RefreshTokens(t *testing.T) {
 				tc.setupMocks(useCases, logger)
 			}
 
-			resp, err := api.RefreshTokens(context.Background(), tc.in)
+			resp, err := api.LoginWithCode(context.Background(), tc.in)
 			if tc.errorExpected {
 				require.Error(t, err)
 				require.IsType(t, tc.expectedErr, err)
@@ -982,3 +3909,156 @@ func TestServerAPI_RefreshTokens(t *testing.T) {
 		})
 	}
 }
+
+func TestServerAPI_IssueClientToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	in := &sso.IssueClientTokenIn{
+		ClientID:     "notifications",
+		ClientSecret: "client-secret",
+		Scope:        "users:read",
+	}
+
+	testCases := []struct {
+		name          string
+		in            *sso.IssueClientTokenIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedOut   *sso.IssueClientTokenOut
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					IssueClientToken(gomock.Any(), entities.IssueClientTokenDTO{
+						ClientID:     "notifications",
+						ClientSecret: "client-secret",
+						Scope:        "users:read",
+					}).
+					Return(
+						&entities.ClientTokenDTO{
+							AccessToken: "access-token",
+							TokenType:   entities.BearerTokenType,
+							ExpiresIn:   time.Minute * 5,
+							Scope:       "users:read",
+						},
+						nil,
+					).
+					Times(1)
+			},
+			expectedOut: &sso.IssueClientTokenOut{
+				AccessToken: "access-token",
+				TokenType:   entities.BearerTokenType,
+				ExpiresIn:   300,
+				Scope:       "users:read",
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "invalid client",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					IssueClientToken(gomock.Any(), gomock.Any()).
+					Return(nil, &customerrors.InvalidClientError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated, Message: "client authentication failed"},
+			errorExpected: true,
+		},
+		{
+			name: "unauthorized client",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					IssueClientToken(gomock.Any(), gomock.Any()).
+					Return(nil, &customerrors.UnauthorizedClientError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.PermissionDenied,
+				Message: "client is not allowed to use this grant type",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "invalid scope",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					IssueClientToken(gomock.Any(), gomock.Any()).
+					Return(nil, &customerrors.InvalidScopeError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.PermissionDenied,
+				Message: "requested scope is not allowed for client",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "internal error",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					IssueClientToken(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("internal error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Internal, Message: "internal error"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.IssueClientToken(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedOut, resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package clients
+
+import (
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/DKhorkov/hmtm-sso/api/protobuf/generated/go/sso"
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+)
+
+// Lists of Client settings are stored as strings:
+const (
+	redirectURIsSeparator = ","
+	grantTypesSeparator   = ","
+	scopesSeparator       = " "
+)
+
+func mapClientToOut(client entities.Client) *sso.GetClientOut {
+	return &sso.GetClientOut{
+		ID:           client.ID,
+		ClientID:     client.ClientID,
+		Confidential: client.SecretHash != "",
+		Settings: &sso.ClientSettings{
+			RedirectURIs:    splitSetting(client.RedirectURIs, redirectURIsSeparator),
+			GrantTypes:      splitSetting(client.GrantTypes, grantTypesSeparator),
+			Scopes:          splitSetting(client.Scopes, scopesSeparator),
+			AccessTokenTTL:  uint64(client.AccessTokenTTL),
+			RefreshTokenTTL: uint64(client.RefreshTokenTTL),
+		},
+		CreatedAt: timestamppb.New(client.CreatedAt),
+		UpdatedAt: timestamppb.New(client.UpdatedAt),
+	}
+}
+
+func mapSettingsFromIn(settings *sso.ClientSettings) entities.ClientSettingsDTO {
+	return entities.ClientSettingsDTO{
+		RedirectURIs:    settings.GetRedirectURIs(),
+		GrantTypes:      settings.GetGrantTypes(),
+		Scopes:          settings.GetScopes(),
+		AccessTokenTTL:  time.Duration(settings.GetAccessTokenTTL()) * time.Second,
+		RefreshTokenTTL: time.Duration(settings.GetRefreshTokenTTL()) * time.Second,
+	}
+}
+
+// splitSetting returns empty list instead of list with empty string for empty setting.
+func splitSetting(setting, separator string) []string {
+	if setting == "" {
+		return []string{}
+	}
+
+	return strings.Split(setting, separator)
+}
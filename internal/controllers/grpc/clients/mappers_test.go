@@ -0,0 +1,111 @@
+package clients
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DKhorkov/hmtm-sso/api/protobuf/generated/go/sso"
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+)
+
+func TestMapClientToOut(t *testing.T) {
+	testCases := []struct {
+		name     string
+		client   entities.Client
+		expected *sso.GetClientOut
+	}{
+		{
+			name: "confidential client",
+			client: entities.Client{
+				ID:              1,
+				ClientID:        "shop",
+				SecretHash:      "secret_hash",
+				RedirectURIs:    "https://shop.example.com/callback,https://shop.example.com/silent",
+				GrantTypes:      "authorization_code,refresh_token",
+				Scopes:          "openid email",
+				AccessTokenTTL:  300,
+				RefreshTokenTTL: 86400,
+				CreatedAt:       time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:       time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+			expected: &sso.GetClientOut{
+				ID:           1,
+				ClientID:     "shop",
+				Confidential: true,
+				Settings: &sso.ClientSettings{
+					RedirectURIs:    []string{"https://shop.example.com/callback", "https://shop.example.com/silent"},
+					GrantTypes:      []string{"authorization_code", "refresh_token"},
+					Scopes:          []string{"openid", "email"},
+					AccessTokenTTL:  300,
+					RefreshTokenTTL: 86400,
+				},
+			},
+		},
+		{
+			name: "public client with default settings",
+			client: entities.Client{
+				ID:         2,
+				ClientID:   "mobile",
+				GrantTypes: "password",
+				CreatedAt:  time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:  time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC),
+			},
+			expected: &sso.GetClientOut{
+				ID:           2,
+				ClientID:     "mobile",
+				Confidential: false,
+				Settings: &sso.ClientSettings{
+					RedirectURIs: []string{},
+					GrantTypes:   []string{"password"},
+					Scopes:       []string{},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := mapClientToOut(tc.client)
+
+			require.Equal(t, tc.expected.ID, result.ID)
+			require.Equal(t, tc.expected.ClientID, result.ClientID)
+			require.Equal(t, tc.expected.Confidential, result.Confidential)
+			require.Equal(t, tc.expected.Settings.RedirectURIs, result.Settings.RedirectURIs)
+			require.Equal(t, tc.expected.Settings.GrantTypes, result.Settings.GrantTypes)
+			require.Equal(t, tc.expected.Settings.Scopes, result.Settings.Scopes)
+			require.Equal(t, tc.expected.Settings.AccessTokenTTL, result.Settings.AccessTokenTTL)
+			require.Equal(t, tc.expected.Settings.RefreshTokenTTL, result.Settings.RefreshTokenTTL)
+			require.Equal(t, tc.client.CreatedAt, result.CreatedAt.AsTime())
+			require.Equal(t, tc.client.UpdatedAt, result.UpdatedAt.AsTime())
+		})
+	}
+}
+
+func TestMapSettingsFromIn(t *testing.T) {
+	settings := mapSettingsFromIn(
+		&sso.ClientSettings{
+			RedirectURIs:    []string{"https://shop.example.com/callback"},
+			GrantTypes:      []string{"authorization_code"},
+			Scopes:          []string{"openid"},
+			AccessTokenTTL:  300,
+			RefreshTokenTTL: 86400,
+		},
+	)
+
+	require.Equal(
+		t,
+		entities.ClientSettingsDTO{
+			RedirectURIs:    []string{"https://shop.example.com/callback"},
+			GrantTypes:      []string{"authorization_code"},
+			Scopes:          []string{"openid"},
+			AccessTokenTTL:  time.Minute * 5,
+			RefreshTokenTTL: time.Hour * 24,
+		},
+		settings,
+	)
+
+	// Nil settings are validated by use cases:
+	require.Equal(t, entities.ClientSettingsDTO{}, mapSettingsFromIn(nil))
+}
@@ -0,0 +1,165 @@
+package clients
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	customgrpc "github.com/DKhorkov/libs/grpc"
+	"github.com/DKhorkov/libs/logging"
+	"github.com/DKhorkov/libs/security"
+	"github.com/DKhorkov/libs/validation"
+
+	"github.com/DKhorkov/hmtm-sso/api/protobuf/generated/go/sso"
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+	"github.com/DKhorkov/hmtm-sso/internal/interfaces"
+)
+
+var (
+	invalidJWTError          = &security.InvalidJWTError{}
+	validationError          = &validation.Error{}
+	permissionDeniedError    = &customerrors.PermissionDeniedError{}
+	clientNotFoundError      = &customerrors.ClientNotFoundError{}
+	clientAlreadyExistsError = &customerrors.ClientAlreadyExistsError{}
+)
+
+// RegisterServer handler (serverAPI) for ClientsServer to gRPC server:.
+func RegisterServer(gRPCServer *grpc.Server, useCases interfaces.UseCases, logger logging.Logger) {
+	sso.RegisterClientsServiceServer(gRPCServer, &ServerAPI{useCases: useCases, logger: logger})
+}
+
+type ServerAPI struct {
+	// Helps to test single endpoints, if others is not implemented yet
+	sso.UnimplementedClientsServiceServer
+	useCases interfaces.UseCases
+	logger   logging.Logger
+}
+
+// RegisterClient handler registers new Client application and returns its secret for confidential Client.
+func (api *ServerAPI) RegisterClient(
+	ctx context.Context,
+	in *sso.RegisterClientIn,
+) (*sso.RegisterClientOut, error) {
+	registeredClient, err := api.useCases.RegisterClient(
+		ctx,
+		entities.RegisterClientDTO{
+			AccessToken:  in.GetAccessToken(),
+			ClientID:     in.GetClientID(),
+			Confidential: in.GetConfidential(),
+			Settings:     mapSettingsFromIn(in.GetSettings()),
+		},
+	)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			"Error occurred while trying to register Client with ClientID="+in.GetClientID(),
+			err,
+		)
+
+		switch {
+		case errors.As(err, &invalidJWTError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &permissionDeniedError):
+			return nil, &customgrpc.BaseError{Status: codes.PermissionDenied, Message: err.Error()}
+		case errors.As(err, &validationError):
+			return nil, &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: err.Error()}
+		case errors.As(err, &clientAlreadyExistsError):
+			return nil, &customgrpc.BaseError{Status: codes.AlreadyExists, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return &sso.RegisterClientOut{
+		Client:       mapClientToOut(registeredClient.Client),
+		ClientSecret: registeredClient.Secret,
+	}, nil
+}
+
+// GetClients handler returns all registered Clients.
+func (api *ServerAPI) GetClients(ctx context.Context, in *sso.GetClientsIn) (*sso.GetClientsOut, error) {
+	clients, err := api.useCases.GetClients(ctx, in.GetAccessToken())
+	if err != nil {
+		logging.LogErrorContext(ctx, api.logger, "Error occurred while trying to get all Clients", err)
+
+		switch {
+		case errors.As(err, &invalidJWTError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &permissionDeniedError):
+			return nil, &customgrpc.BaseError{Status: codes.PermissionDenied, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	processedClients := make([]*sso.GetClientOut, len(clients))
+	for i, client := range clients {
+		processedClients[i] = mapClientToOut(client)
+	}
+
+	return &sso.GetClientsOut{Clients: processedClients}, nil
+}
+
+// UpdateClient handler replaces settings of registered Client.
+func (api *ServerAPI) UpdateClient(ctx context.Context, in *sso.UpdateClientIn) (*emptypb.Empty, error) {
+	if err := api.useCases.UpdateClient(
+		ctx,
+		entities.UpdateClientDTO{
+			AccessToken: in.GetAccessToken(),
+			ClientID:    in.GetClientID(),
+			Settings:    mapSettingsFromIn(in.GetSettings()),
+		},
+	); err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			"Error occurred while trying to update Client with ClientID="+in.GetClientID(),
+			err,
+		)
+
+		switch {
+		case errors.As(err, &invalidJWTError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &permissionDeniedError):
+			return nil, &customgrpc.BaseError{Status: codes.PermissionDenied, Message: err.Error()}
+		case errors.As(err, &validationError):
+			return nil, &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: err.Error()}
+		case errors.As(err, &clientNotFoundError):
+			return nil, &customgrpc.BaseError{Status: codes.NotFound, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// DeleteClient handler removes Client from registry.
+func (api *ServerAPI) DeleteClient(ctx context.Context, in *sso.DeleteClientIn) (*emptypb.Empty, error) {
+	if err := api.useCases.DeleteClient(ctx, in.GetAccessToken(), in.GetClientID()); err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			"Error occurred while trying to delete Client with ClientID="+in.GetClientID(),
+			err,
+		)
+
+		switch {
+		case errors.As(err, &invalidJWTError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &permissionDeniedError):
+			return nil, &customgrpc.BaseError{Status: codes.PermissionDenied, Message: err.Error()}
+		case errors.As(err, &clientNotFoundError):
+			return nil, &customgrpc.BaseError{Status: codes.NotFound, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,464 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	customgrpc "github.com/DKhorkov/libs/grpc"
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/libs/security"
+	"github.com/DKhorkov/libs/validation"
+
+	"github.com/DKhorkov/hmtm-sso/api/protobuf/generated/go/sso"
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+	mockusecases "github.com/DKhorkov/hmtm-sso/mocks/usecases"
+)
+
+func TestServerAPI_RegisterClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	in := &sso.RegisterClientIn{
+		AccessToken:  "admin-token",
+		ClientID:     "shop",
+		Confidential: true,
+		Settings: &sso.ClientSettings{
+			RedirectURIs: []string{"https://shop.example.com/callback"},
+			GrantTypes:   []string{"authorization_code"},
+			Scopes:       []string{"openid"},
+		},
+	}
+	clientData := entities.RegisterClientDTO{
+		AccessToken:  "admin-token",
+		ClientID:     "shop",
+		Confidential: true,
+		Settings: entities.ClientSettingsDTO{
+			RedirectURIs: []string{"https://shop.example.com/callback"},
+			GrantTypes:   []string{"authorization_code"},
+			Scopes:       []string{"openid"},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		in            *sso.RegisterClientIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedOut   *sso.RegisterClientOut
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, _ *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					RegisterClient(gomock.Any(), clientData).
+					Return(
+						&entities.RegisteredClientDTO{
+							Client: entities.Client{ID: 1, ClientID: "shop", SecretHash: "hash"},
+							Secret: "secret",
+						},
+						nil,
+					).
+					Times(1)
+			},
+			expectedOut: &sso.RegisterClientOut{
+				Client:       &sso.GetClientOut{ID: 1, ClientID: "shop", Confidential: true},
+				ClientSecret: "secret",
+			},
+			errorExpected: false,
+		},
+		{
+			name: "not administrator",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					RegisterClient(gomock.Any(), clientData).
+					Return(nil, &customerrors.PermissionDeniedError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.PermissionDenied, Message: "permission denied"},
+			errorExpected: true,
+		},
+		{
+			name: "invalid JWT",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					RegisterClient(gomock.Any(), clientData).
+					Return(nil, &security.InvalidJWTError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.Unauthenticated,
+				Message: (&security.InvalidJWTError{}).Error(),
+			},
+			errorExpected: true,
+		},
+		{
+			name: "invalid settings",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					RegisterClient(gomock.Any(), clientData).
+					Return(nil, &validation.Error{Message: "invalid client ID"}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: "invalid client ID"},
+			errorExpected: true,
+		},
+		{
+			name: "client already exists",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					RegisterClient(gomock.Any(), clientData).
+					Return(nil, &customerrors.ClientAlreadyExistsError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.AlreadyExists, Message: "client already exists"},
+			errorExpected: true,
+		},
+		{
+			name: "internal error",
+			in:   in,
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					RegisterClient(gomock.Any(), clientData).
+					Return(nil, errors.New("internal error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Internal, Message: "internal error"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.RegisterClient(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedOut.ClientSecret, resp.ClientSecret)
+				require.Equal(t, tc.expectedOut.Client.ID, resp.Client.ID)
+				require.Equal(t, tc.expectedOut.Client.ClientID, resp.Client.ClientID)
+				require.Equal(t, tc.expectedOut.Client.Confidential, resp.Client.Confidential)
+			}
+		})
+	}
+}
+
+func TestServerAPI_GetClients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	testCases := []struct {
+		name          string
+		in            *sso.GetClientsIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedCount int
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in:   &sso.GetClientsIn{AccessToken: "admin-token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, _ *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					GetClients(gomock.Any(), "admin-token").
+					Return([]entities.Client{{ID: 1, ClientID: "shop"}, {ID: 2, ClientID: "mobile"}}, nil).
+					Times(1)
+			},
+			expectedCount: 2,
+			errorExpected: false,
+		},
+		{
+			name: "not administrator",
+			in:   &sso.GetClientsIn{AccessToken: "user-token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					GetClients(gomock.Any(), "user-token").
+					Return(nil, &customerrors.PermissionDeniedError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.PermissionDenied, Message: "permission denied"},
+			errorExpected: true,
+		},
+		{
+			name: "internal error",
+			in:   &sso.GetClientsIn{AccessToken: "admin-token"},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					GetClients(gomock.Any(), "admin-token").
+					Return(nil, errors.New("internal error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Internal, Message: "internal error"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.GetClients(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, resp.GetClients(), tc.expectedCount)
+			}
+		})
+	}
+}
+
+func TestServerAPI_UpdateClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	in := &sso.UpdateClientIn{
+		AccessToken: "admin-token",
+		ClientID:    "shop",
+		Settings:    &sso.ClientSettings{GrantTypes: []string{"password"}},
+	}
+	clientData := entities.UpdateClientDTO{
+		AccessToken: "admin-token",
+		ClientID:    "shop",
+		Settings:    entities.ClientSettingsDTO{GrantTypes: []string{"password"}},
+	}
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(useCases *mockusecases.MockUseCases, _ *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					UpdateClient(gomock.Any(), clientData).
+					Return(nil).
+					Times(1)
+			},
+			errorExpected: false,
+		},
+		{
+			name: "client not found",
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					UpdateClient(gomock.Any(), clientData).
+					Return(&customerrors.ClientNotFoundError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.NotFound, Message: "client not found"},
+			errorExpected: true,
+		},
+		{
+			name: "invalid settings",
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					UpdateClient(gomock.Any(), clientData).
+					Return(&validation.Error{Message: "invalid scope"}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.FailedPrecondition, Message: "invalid scope"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.UpdateClient(context.Background(), in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.IsType(t, &emptypb.Empty{}, resp)
+			}
+		})
+	}
+}
+
+func TestServerAPI_DeleteClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	in := &sso.DeleteClientIn{AccessToken: "admin-token", ClientID: "shop"}
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(useCases *mockusecases.MockUseCases, _ *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					DeleteClient(gomock.Any(), "admin-token", "shop").
+					Return(nil).
+					Times(1)
+			},
+			errorExpected: false,
+		},
+		{
+			name: "client not found",
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					DeleteClient(gomock.Any(), "admin-token", "shop").
+					Return(&customerrors.ClientNotFoundError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.NotFound, Message: "client not found"},
+			errorExpected: true,
+		},
+		{
+			name: "invalid JWT",
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					DeleteClient(gomock.Any(), "admin-token", "shop").
+					Return(&security.InvalidJWTError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.Unauthenticated,
+				Message: (&security.InvalidJWTError{}).Error(),
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.DeleteClient(context.Background(), in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.IsType(t, &emptypb.Empty{}, resp)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package grpccontroller
 import (
 	"fmt"
 	"net"
+	"net/netip"
 
 	"github.com/DKhorkov/libs/logging"
 	"github.com/DKhorkov/libs/tracing"
@@ -10,7 +11,9 @@ import (
 
 	customgrpc "github.com/DKhorkov/libs/grpc/interceptors"
 
+	"github.com/DKhorkov/hmtm-sso/internal/config"
 	"github.com/DKhorkov/hmtm-sso/internal/controllers/grpc/auth"
+	"github.com/DKhorkov/hmtm-sso/internal/controllers/grpc/clients"
 	"github.com/DKhorkov/hmtm-sso/internal/controllers/grpc/users"
 	"github.com/DKhorkov/hmtm-sso/internal/interfaces"
 )
@@ -20,6 +23,9 @@ func New(
 	host string,
 	port int,
 	useCases interfaces.UseCases,
+	rateLimiter interfaces.RateLimiter,
+	rateLimitsConfig config.RateLimitsConfig,
+	trustedProxies []netip.Prefix,
 	logger logging.Logger,
 	traceProvider tracing.Provider,
 	spanConfig tracing.SpanConfig,
@@ -28,12 +34,15 @@ func New(
 		grpc.ChainUnaryInterceptor(
 			customgrpc.UnaryServerTracingInterceptor(traceProvider, spanConfig),
 			customgrpc.UnaryServerLoggingInterceptor(logger),
+			unaryServerRateLimitInterceptor(useCases, rateLimiter, rateLimitsConfig.Policies, trustedProxies, logger),
+			unaryServerScopesInterceptor(useCases, internalMethodScopes),
 		),
 	)
 
 	// Connects our gRPC services to grpcServer:
-	auth.RegisterServer(grpcServer, useCases, logger)
+	auth.RegisterServer(grpcServer, useCases, trustedProxies, logger)
 	users.RegisterServer(grpcServer, useCases, logger)
+	clients.RegisterServer(grpcServer, useCases, logger)
 
 	return &Controller{
 		grpcServer: grpcServer,
@@ -0,0 +1,144 @@
+package grpccontroller
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	customgrpc "github.com/DKhorkov/libs/grpc"
+	"github.com/DKhorkov/libs/logging"
+
+	"github.com/DKhorkov/hmtm-sso/internal/config"
+	"github.com/DKhorkov/hmtm-sso/internal/controllers/grpc/auth"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+	"github.com/DKhorkov/hmtm-sso/internal/interfaces"
+	"github.com/DKhorkov/hmtm-sso/internal/limiter"
+)
+
+// unaryServerRateLimitInterceptor rejects calls, which exceed limits of policies for called method.
+// If limiter is unavailable, calls are not limited, so failure of cache does not make service unavailable.
+func unaryServerRateLimitInterceptor(
+	useCases interfaces.UseCases,
+	rateLimiter interfaces.RateLimiter,
+	policies []config.RateLimitPolicy,
+	trustedProxies []netip.Prefix,
+	logger logging.Logger,
+) grpc.UnaryServerInterceptor {
+	methodPolicies := make(map[string][]config.RateLimitPolicy)
+	for _, policy := range policies {
+		methodPolicies[policy.Method] = append(methodPolicies[policy.Method], policy)
+	}
+
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		// Several policies of method can share key, so its value, which may require query, is got once per call:
+		values := make(map[string]string)
+		for _, policy := range methodPolicies[info.FullMethod] {
+			value, ok := values[policy.Key]
+			if !ok {
+				value = getRateLimitValue(ctx, useCases, req, policy.Key, trustedProxies)
+				values[policy.Key] = value
+			}
+
+			// Calls without value of key, for example without email, are not limited by policy:
+			if value == "" {
+				continue
+			}
+
+			result, err := rateLimiter.Allow(
+				ctx,
+				fmt.Sprintf("%s-%s-%s", policy.Method, policy.Key, value),
+				limiter.Policy{
+					Limit:  policy.Limit,
+					Window: policy.Window,
+				},
+			)
+			if err != nil {
+				logging.LogErrorContext(
+					ctx,
+					logger,
+					fmt.Sprintf("Failed to check rate limit of %s by %s", policy.Method, policy.Key),
+					err,
+				)
+
+				continue
+			}
+
+			if !result.Allowed {
+				auth.SetRetryAfter(ctx, result.RetryAfter)
+
+				return nil, &customgrpc.BaseError{
+					Status: codes.ResourceExhausted,
+					Message: customerrors.LimitExceededError{
+						Message: fmt.Sprintf(
+							"too many calls by %s, limit is %d per %s",
+							policy.Key,
+							policy.Limit,
+							policy.Window,
+						),
+					}.Error(),
+				}
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// getRateLimitValue returns value of request, by which calls are counted for provided key.
+func getRateLimitValue(
+	ctx context.Context,
+	useCases interfaces.UseCases,
+	req any,
+	key string,
+	trustedProxies []netip.Prefix,
+) string {
+	switch key {
+	case config.IPRateLimitKey:
+		return auth.GetClientInfo(ctx, trustedProxies).IP
+	case config.EmailRateLimitKey:
+		if in, ok := req.(interface{ GetEmail() string }); ok {
+			return strings.ToLower(strings.TrimSpace(in.GetEmail()))
+		}
+	case config.UserRateLimitKey:
+		accessToken := getRequestAccessToken(ctx, req)
+		if accessToken == "" {
+			return ""
+		}
+
+		// Invalid tokens are rejected by handlers, so they are not counted:
+		if accessTokenPayload, err := useCases.VerifyUserToken(accessToken); err == nil {
+			return strconv.FormatUint(accessTokenPayload.UserID, 10)
+		}
+	case config.PhoneRateLimitKey:
+		accessToken := getRequestAccessToken(ctx, req)
+		if accessToken == "" {
+			return ""
+		}
+
+		// Users without phone are rejected by handlers, so they are not counted:
+		if phone, err := useCases.GetUserPhone(ctx, accessToken); err == nil {
+			return phone
+		}
+	}
+
+	return ""
+}
+
+// getRequestAccessToken returns access token from request or from authorization header.
+func getRequestAccessToken(ctx context.Context, req any) string {
+	if in, ok := req.(interface{ GetAccessToken() string }); ok && in.GetAccessToken() != "" {
+		return in.GetAccessToken()
+	}
+
+	return getBearerToken(ctx)
+}
@@ -0,0 +1,307 @@
+package grpccontroller
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	customgrpc "github.com/DKhorkov/libs/grpc"
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/libs/security"
+
+	"github.com/DKhorkov/hmtm-sso/api/protobuf/generated/go/sso"
+	"github.com/DKhorkov/hmtm-sso/internal/config"
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+	"github.com/DKhorkov/hmtm-sso/internal/limiter"
+	mocklimiter "github.com/DKhorkov/hmtm-sso/mocks/limiter"
+	mockusecases "github.com/DKhorkov/hmtm-sso/mocks/usecases"
+)
+
+func TestUnaryServerRateLimitInterceptor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	rateLimiter := mocklimiter.NewMockRateLimiter(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+
+	const (
+		sendVerifyEmailMethod = "/auth.AuthService/SendVerifyEmailMessage"
+		getMeMethod           = "/users.UsersService/GetMe"
+		sendPhoneCodeMethod   = "/auth.AuthService/SendPhoneVerificationCode"
+	)
+
+	policies := []config.RateLimitPolicy{
+		{Method: sendVerifyEmailMethod, Key: config.EmailRateLimitKey, Limit: 3, Window: time.Minute},
+		{Method: sendVerifyEmailMethod, Key: config.IPRateLimitKey, Limit: 30, Window: time.Hour},
+		{Method: getMeMethod, Key: config.UserRateLimitKey, Limit: 10, Window: time.Minute},
+		{Method: sendPhoneCodeMethod, Key: config.PhoneRateLimitKey, Limit: 1, Window: time.Minute},
+		{Method: sendPhoneCodeMethod, Key: config.PhoneRateLimitKey, Limit: 5, Window: time.Hour * 24},
+	}
+
+	trustedProxies := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+	interceptor := unaryServerRateLimitInterceptor(useCases, rateLimiter, policies, trustedProxies, logger)
+
+	handler := func(context.Context, any) (any, error) {
+		return "response", nil
+	}
+
+	ctxWithIP := metadata.NewIncomingContext(
+		peer.NewContext(
+			context.Background(),
+			&peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 52341}},
+		),
+		metadata.Pairs("x-forwarded-for", "203.0.113.7"),
+	)
+
+	// Caller, which is not trusted proxy, can not choose IP, by which its calls are counted:
+	ctxWithForgedIP := metadata.NewIncomingContext(
+		peer.NewContext(
+			context.Background(),
+			&peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("198.51.100.9"), Port: 52341}},
+		),
+		metadata.Pairs("x-forwarded-for", "203.0.113.7"),
+	)
+
+	emailPolicy := limiter.Policy{Limit: 3, Window: time.Minute}
+	ipPolicy := limiter.Policy{Limit: 30, Window: time.Hour}
+	userPolicy := limiter.Policy{Limit: 10, Window: time.Minute}
+	phonePolicy := limiter.Policy{Limit: 1, Window: time.Minute}
+	dailyPhonePolicy := limiter.Policy{Limit: 5, Window: time.Hour * 24}
+
+	testCases := []struct {
+		name       string
+		ctx        context.Context
+		req        any
+		fullMethod string
+		setupMocks func(
+			useCases *mockusecases.MockUseCases,
+			rateLimiter *mocklimiter.MockRateLimiter,
+			logger *mocklogging.MockLogger,
+		)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:       "success",
+			ctx:        ctxWithIP,
+			req:        &sso.SendVerifyEmailMessageIn{Email: " Test@Example.com"},
+			fullMethod: sendVerifyEmailMethod,
+			setupMocks: func(
+				_ *mockusecases.MockUseCases,
+				rateLimiter *mocklimiter.MockRateLimiter,
+				_ *mocklogging.MockLogger,
+			) {
+				rateLimiter.
+					EXPECT().
+					Allow(gomock.Any(), sendVerifyEmailMethod+"-email-test@example.com", emailPolicy).
+					Return(limiter.Result{Allowed: true}, nil).
+					Times(1)
+
+				rateLimiter.
+					EXPECT().
+					Allow(gomock.Any(), sendVerifyEmailMethod+"-ip-203.0.113.7", ipPolicy).
+					Return(limiter.Result{Allowed: true}, nil).
+					Times(1)
+			},
+			errorExpected: false,
+		},
+		{
+			name:       "forged x-forwarded-for from untrusted peer",
+			ctx:        ctxWithForgedIP,
+			req:        &sso.SendVerifyEmailMessageIn{Email: "test@example.com"},
+			fullMethod: sendVerifyEmailMethod,
+			setupMocks: func(
+				_ *mockusecases.MockUseCases,
+				rateLimiter *mocklimiter.MockRateLimiter,
+				_ *mocklogging.MockLogger,
+			) {
+				rateLimiter.
+					EXPECT().
+					Allow(gomock.Any(), sendVerifyEmailMethod+"-email-test@example.com", emailPolicy).
+					Return(limiter.Result{Allowed: true}, nil).
+					Times(1)
+
+				rateLimiter.
+					EXPECT().
+					Allow(gomock.Any(), sendVerifyEmailMethod+"-ip-198.51.100.9", ipPolicy).
+					Return(limiter.Result{Allowed: true}, nil).
+					Times(1)
+			},
+			errorExpected: false,
+		},
+		{
+			name:          "method without policies",
+			ctx:           ctxWithIP,
+			req:           &sso.GetUserByEmailIn{Email: "test@example.com"},
+			fullMethod:    "/users.UsersService/GetUserByEmail",
+			errorExpected: false,
+		},
+		{
+			name:       "limit exceeded",
+			ctx:        ctxWithIP,
+			req:        &sso.SendVerifyEmailMessageIn{Email: "test@example.com"},
+			fullMethod: sendVerifyEmailMethod,
+			setupMocks: func(
+				_ *mockusecases.MockUseCases,
+				rateLimiter *mocklimiter.MockRateLimiter,
+				_ *mocklogging.MockLogger,
+			) {
+				rateLimiter.
+					EXPECT().
+					Allow(gomock.Any(), sendVerifyEmailMethod+"-email-test@example.com", emailPolicy).
+					Return(limiter.Result{Allowed: false, RetryAfter: time.Second * 20}, nil).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.ResourceExhausted,
+				Message: "limit exceeded: too many calls by email, limit is 3 per 1m0s",
+			},
+			errorExpected: true,
+		},
+		{
+			name:       "limiter error",
+			ctx:        ctxWithIP,
+			req:        &sso.SendVerifyEmailMessageIn{},
+			fullMethod: sendVerifyEmailMethod,
+			setupMocks: func(
+				_ *mockusecases.MockUseCases,
+				rateLimiter *mocklimiter.MockRateLimiter,
+				logger *mocklogging.MockLogger,
+			) {
+				rateLimiter.
+					EXPECT().
+					Allow(gomock.Any(), sendVerifyEmailMethod+"-ip-203.0.113.7", ipPolicy).
+					Return(limiter.Result{}, errors.New("cache is unavailable")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			errorExpected: false,
+		},
+		{
+			name:       "limited by user",
+			ctx:        context.Background(),
+			req:        &sso.GetMeIn{AccessToken: "access-token"},
+			fullMethod: getMeMethod,
+			setupMocks: func(
+				useCases *mockusecases.MockUseCases,
+				rateLimiter *mocklimiter.MockRateLimiter,
+				_ *mocklogging.MockLogger,
+			) {
+				useCases.
+					EXPECT().
+					VerifyUserToken("access-token").
+					Return(&entities.AccessTokenPayload{UserID: 1}, nil).
+					Times(1)
+
+				rateLimiter.
+					EXPECT().
+					Allow(gomock.Any(), getMeMethod+"-user-1", userPolicy).
+					Return(limiter.Result{Allowed: true}, nil).
+					Times(1)
+			},
+			errorExpected: false,
+		},
+		{
+			name:       "invalid access token",
+			ctx:        context.Background(),
+			req:        &sso.GetMeIn{AccessToken: "invalid"},
+			fullMethod: getMeMethod,
+			setupMocks: func(
+				useCases *mockusecases.MockUseCases,
+				_ *mocklimiter.MockRateLimiter,
+				_ *mocklogging.MockLogger,
+			) {
+				useCases.
+					EXPECT().
+					VerifyUserToken("invalid").
+					Return(nil, &security.InvalidJWTError{}).
+					Times(1)
+			},
+			errorExpected: false,
+		},
+		{
+			name:       "limited by phone",
+			ctx:        context.Background(),
+			req:        &sso.SendPhoneVerificationCodeIn{AccessToken: "access-token"},
+			fullMethod: sendPhoneCodeMethod,
+			setupMocks: func(
+				useCases *mockusecases.MockUseCases,
+				rateLimiter *mocklimiter.MockRateLimiter,
+				_ *mocklogging.MockLogger,
+			) {
+				useCases.
+					EXPECT().
+					GetUserPhone(gomock.Any(), "access-token").
+					Return("79991234567", nil).
+					Times(1)
+
+				rateLimiter.
+					EXPECT().
+					Allow(gomock.Any(), sendPhoneCodeMethod+"-phone-79991234567", phonePolicy).
+					Return(limiter.Result{Allowed: true}, nil).
+					Times(1)
+
+				rateLimiter.
+					EXPECT().
+					Allow(gomock.Any(), sendPhoneCodeMethod+"-phone-79991234567", dailyPhonePolicy).
+					Return(limiter.Result{Allowed: false, RetryAfter: time.Hour}, nil).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.ResourceExhausted,
+				Message: "limit exceeded: too many calls by phone, limit is 5 per 24h0m0s",
+			},
+			errorExpected: true,
+		},
+		{
+			name:       "user without phone",
+			ctx:        context.Background(),
+			req:        &sso.SendPhoneVerificationCodeIn{AccessToken: "access-token"},
+			fullMethod: sendPhoneCodeMethod,
+			setupMocks: func(
+				useCases *mockusecases.MockUseCases,
+				_ *mocklimiter.MockRateLimiter,
+				_ *mocklogging.MockLogger,
+			) {
+				useCases.
+					EXPECT().
+					GetUserPhone(gomock.Any(), "access-token").
+					Return("", &customerrors.PhoneIsNotSetError{}).
+					Times(1)
+			},
+			errorExpected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, rateLimiter, logger)
+			}
+
+			resp, err := interceptor(tc.ctx, tc.req, &grpc.UnaryServerInfo{FullMethod: tc.fullMethod}, handler)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "response", resp)
+			}
+		})
+	}
+}
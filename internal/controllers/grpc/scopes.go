@@ -0,0 +1,95 @@
+package grpccontroller
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	customgrpc "github.com/DKhorkov/libs/grpc"
+
+	"github.com/DKhorkov/hmtm-sso/api/protobuf/generated/go/sso"
+	"github.com/DKhorkov/hmtm-sso/internal/interfaces"
+)
+
+const (
+	authorizationMetadataKey = "authorization"
+	bearerPrefix             = "Bearer "
+
+	// usersReadScope allows backend services to read data of any User.
+	usersReadScope = "users:read"
+
+	// tokensIntrospectScope allows backend services to check state of tokens, presented by Users.
+	tokensIntrospectScope = "tokens:introspect"
+)
+
+// internalMethodScopes are scopes, which Client's token must contain to call internal-only RPCs.
+// Such RPCs are called only by backend services, which authenticate with client credentials grant.
+var internalMethodScopes = map[string][]string{
+	"/" + sso.UsersService_ServiceDesc.ServiceName + "/GetUsers":       {usersReadScope},
+	"/" + sso.UsersService_ServiceDesc.ServiceName + "/GetUserByEmail": {usersReadScope},
+	"/" + sso.AuthService_ServiceDesc.ServiceName + "/IntrospectToken": {tokensIntrospectScope},
+}
+
+// unaryServerScopesInterceptor requires Bearer token of Client with all scopes of called method.
+// Methods, which are not listed in methodScopes, are handled without any checks.
+func unaryServerScopesInterceptor(
+	useCases interfaces.UseCases,
+	methodScopes map[string][]string,
+) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		requiredScopes, ok := methodScopes[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		accessToken := getBearerToken(ctx)
+		if accessToken == "" {
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: "client token is required"}
+		}
+
+		clientTokenPayload, err := useCases.VerifyClientToken(accessToken)
+		if err != nil {
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		}
+
+		for _, scope := range requiredScopes {
+			if !slices.Contains(clientTokenPayload.Scopes, scope) {
+				return nil, &customgrpc.BaseError{
+					Status:  codes.PermissionDenied,
+					Message: "client token does not contain required scope: " + scope,
+				}
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// getBearerToken retrieves access token from authorization metadata the same way as from HTTP header (RFC 6750).
+func getBearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get(authorizationMetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+
+	authorization := values[0]
+	if len(authorization) > len(bearerPrefix) && strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+		return authorization[len(bearerPrefix):]
+	}
+
+	return ""
+}
@@ -0,0 +1,200 @@
+package grpccontroller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	customgrpc "github.com/DKhorkov/libs/grpc"
+	"github.com/DKhorkov/libs/security"
+
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	mockusecases "github.com/DKhorkov/hmtm-sso/mocks/usecases"
+)
+
+func TestUnaryServerScopesInterceptor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	interceptor := unaryServerScopesInterceptor(useCases, internalMethodScopes)
+
+	handler := func(context.Context, any) (any, error) {
+		return "response", nil
+	}
+
+	withAuthorization := func(authorization string) context.Context {
+		return metadata.NewIncomingContext(
+			context.Background(),
+			metadata.Pairs(authorizationMetadataKey, authorization),
+		)
+	}
+
+	testCases := []struct {
+		name          string
+		ctx           context.Context
+		fullMethod    string
+		setupMocks    func(useCases *mockusecases.MockUseCases)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:       "success",
+			ctx:        withAuthorization("Bearer client-token"),
+			fullMethod: "/users.UsersService/GetUsers",
+			setupMocks: func(useCases *mockusecases.MockUseCases) {
+				useCases.
+					EXPECT().
+					VerifyClientToken("client-token").
+					Return(
+						&entities.ClientTokenPayload{
+							ClientID: "notifications",
+							Scopes:   []string{usersReadScope},
+						},
+						nil,
+					).
+					Times(1)
+			},
+			errorExpected: false,
+		},
+		{
+			name:          "method without required scopes",
+			ctx:           context.Background(),
+			fullMethod:    "/users.UsersService/GetMe",
+			errorExpected: false,
+		},
+		{
+			name:       "token is missing",
+			ctx:        context.Background(),
+			fullMethod: "/users.UsersService/GetUserByEmail",
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.Unauthenticated,
+				Message: "client token is required",
+			},
+			errorExpected: true,
+		},
+		{
+			name:       "not bearer token",
+			ctx:        withAuthorization("Basic client-token"),
+			fullMethod: "/users.UsersService/GetUserByEmail",
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.Unauthenticated,
+				Message: "client token is required",
+			},
+			errorExpected: true,
+		},
+		{
+			name:       "invalid token",
+			ctx:        withAuthorization("Bearer user-token"),
+			fullMethod: "/users.UsersService/GetUserByEmail",
+			setupMocks: func(useCases *mockusecases.MockUseCases) {
+				useCases.
+					EXPECT().
+					VerifyClientToken("user-token").
+					Return(nil, &security.InvalidJWTError{}).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.Unauthenticated,
+				Message: (&security.InvalidJWTError{}).Error(),
+			},
+			errorExpected: true,
+		},
+		{
+			name:       "token introspection",
+			ctx:        withAuthorization("Bearer client-token"),
+			fullMethod: "/auth.AuthService/IntrospectToken",
+			setupMocks: func(useCases *mockusecases.MockUseCases) {
+				useCases.
+					EXPECT().
+					VerifyClientToken("client-token").
+					Return(
+						&entities.ClientTokenPayload{
+							ClientID: "orders",
+							Scopes:   []string{tokensIntrospectScope},
+						},
+						nil,
+					).
+					Times(1)
+			},
+			errorExpected: false,
+		},
+		{
+			name:       "token introspection without token",
+			ctx:        context.Background(),
+			fullMethod: "/auth.AuthService/IntrospectToken",
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.Unauthenticated,
+				Message: "client token is required",
+			},
+			errorExpected: true,
+		},
+		{
+			name:       "token introspection with users scope only",
+			ctx:        withAuthorization("Bearer client-token"),
+			fullMethod: "/auth.AuthService/IntrospectToken",
+			setupMocks: func(useCases *mockusecases.MockUseCases) {
+				useCases.
+					EXPECT().
+					VerifyClientToken("client-token").
+					Return(
+						&entities.ClientTokenPayload{
+							ClientID: "notifications",
+							Scopes:   []string{usersReadScope},
+						},
+						nil,
+					).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.PermissionDenied,
+				Message: "client token does not contain required scope: tokens:introspect",
+			},
+			errorExpected: true,
+		},
+		{
+			name:       "required scope is missing",
+			ctx:        withAuthorization("bearer client-token"),
+			fullMethod: "/users.UsersService/GetUsers",
+			setupMocks: func(useCases *mockusecases.MockUseCases) {
+				useCases.
+					EXPECT().
+					VerifyClientToken("client-token").
+					Return(
+						&entities.ClientTokenPayload{
+							ClientID: "orders",
+							Scopes:   []string{"orders:read"},
+						},
+						nil,
+					).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.PermissionDenied,
+				Message: "client token does not contain required scope: users:read",
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases)
+			}
+
+			resp, err := interceptor(tc.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tc.fullMethod}, handler)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "response", resp)
+			}
+		})
+	}
+}
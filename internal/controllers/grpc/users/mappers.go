@@ -22,3 +22,21 @@ func mapUserToOut(user entities.User) *sso.GetUserOut {
 		UpdatedAt:         timestamppb.New(user.UpdatedAt),
 	}
 }
+
+func mapImportUsersResultToOut(result entities.ImportUsersResult) *sso.ImportUsersOut {
+	importErrors := make([]*sso.ImportUserError, 0, len(result.Errors))
+	for _, importError := range result.Errors {
+		importErrors = append(
+			importErrors,
+			&sso.ImportUserError{
+				Line:    importError.Line,
+				Message: importError.Message,
+			},
+		)
+	}
+
+	return &sso.ImportUsersOut{
+		Imported: result.Imported,
+		Errors:   importErrors,
+	}
+}
@@ -21,9 +21,10 @@ import (
 )
 
 var (
-	userNotFoundError = &customerrors.UserNotFoundError{}
-	invalidJWTError   = &security.InvalidJWTError{}
-	validationError   = &validation.Error{}
+	userNotFoundError     = &customerrors.UserNotFoundError{}
+	permissionDeniedError = &customerrors.PermissionDeniedError{}
+	invalidJWTError       = &security.InvalidJWTError{}
+	validationError       = &validation.Error{}
 )
 
 // RegisterServer handler (serverAPI) for UsersServer to gRPC server:.
@@ -76,6 +77,50 @@ func (api *ServerAPI) UpdateUserProfile(
 	return &emptypb.Empty{}, nil
 }
 
+// UnlockUser handler removes lockout of User's account after failed login attempts.
+func (api *ServerAPI) UnlockUser(ctx context.Context, in *sso.UnlockUserIn) (*emptypb.Empty, error) {
+	if err := api.useCases.UnlockUser(ctx, in.GetAccessToken(), in.GetID()); err != nil {
+		logging.LogErrorContext(
+			ctx,
+			api.logger,
+			fmt.Sprintf("Error occurred while trying to unlock User with ID=%d", in.GetID()),
+			err,
+		)
+
+		switch {
+		case errors.As(err, &invalidJWTError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &permissionDeniedError):
+			return nil, &customgrpc.BaseError{Status: codes.PermissionDenied, Message: err.Error()}
+		case errors.As(err, &userNotFoundError):
+			return nil, &customgrpc.BaseError{Status: codes.NotFound, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ImportUsers handler imports Users from JSONL data. Lines, which were not imported, are returned with errors.
+func (api *ServerAPI) ImportUsers(ctx context.Context, in *sso.ImportUsersIn) (*sso.ImportUsersOut, error) {
+	result, err := api.useCases.ImportUsers(ctx, in.GetAccessToken(), in.GetUsers())
+	if err != nil {
+		logging.LogErrorContext(ctx, api.logger, "Error occurred while trying to import Users", err)
+
+		switch {
+		case errors.As(err, &invalidJWTError):
+			return nil, &customgrpc.BaseError{Status: codes.Unauthenticated, Message: err.Error()}
+		case errors.As(err, &permissionDeniedError):
+			return nil, &customgrpc.BaseError{Status: codes.PermissionDenied, Message: err.Error()}
+		default:
+			return nil, &customgrpc.BaseError{Status: codes.Internal, Message: err.Error()}
+		}
+	}
+
+	return mapImportUsersResultToOut(*result), nil
+}
+
 func (api *ServerAPI) GetUserByEmail(
 	ctx context.Context,
 	in *sso.GetUserByEmailIn,
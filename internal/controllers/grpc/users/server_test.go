@@ -617,3 +617,211 @@ func TestServerAPI_GetMe(t *testing.T) {
 		})
 	}
 }
+
+func TestServerAPI_UnlockUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	testCases := []struct {
+		name          string
+		in            *sso.UnlockUserIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in:   &sso.UnlockUserIn{AccessToken: "admin-token", ID: 1},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					UnlockUser(gomock.Any(), "admin-token", uint64(1)).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "invalid JWT",
+			in:   &sso.UnlockUserIn{AccessToken: "invalid-token", ID: 1},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					UnlockUser(gomock.Any(), "invalid-token", uint64(1)).
+					Return(&security.InvalidJWTError{Message: "token invalid"}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated, Message: "token invalid"},
+			errorExpected: true,
+		},
+		{
+			name: "permission denied",
+			in:   &sso.UnlockUserIn{AccessToken: "user-token", ID: 1},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					UnlockUser(gomock.Any(), "user-token", uint64(1)).
+					Return(&customerrors.PermissionDeniedError{Message: "administrator role is required"}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.PermissionDenied,
+				Message: "administrator role is required",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "user not found",
+			in:   &sso.UnlockUserIn{AccessToken: "admin-token", ID: 2},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					UnlockUser(gomock.Any(), "admin-token", uint64(2)).
+					Return(&customerrors.UserNotFoundError{Message: "user not found"}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.NotFound, Message: "user not found"},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.UnlockUser(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, &emptypb.Empty{}, resp)
+			}
+		})
+	}
+}
+
+func TestServerAPI_ImportUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	api := &ServerAPI{
+		useCases: useCases,
+		logger:   logger,
+	}
+
+	users := []byte(`{"email":"test@example.com","displayName":"Test","passwordHash":"$sha1$c2FsdA$a2V5"}`)
+
+	testCases := []struct {
+		name          string
+		in            *sso.ImportUsersIn
+		setupMocks    func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedOut   *sso.ImportUsersOut
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			in:   &sso.ImportUsersIn{AccessToken: "admin-token", Users: users},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					ImportUsers(gomock.Any(), "admin-token", users).
+					Return(
+						&entities.ImportUsersResult{
+							Imported: 1,
+							Errors:   []entities.ImportUserError{{Line: 2, Message: "invalid email address"}},
+						},
+						nil,
+					).
+					Times(1)
+			},
+			expectedOut: &sso.ImportUsersOut{
+				Imported: 1,
+				Errors:   []*sso.ImportUserError{{Line: 2, Message: "invalid email address"}},
+			},
+			errorExpected: false,
+		},
+		{
+			name: "invalid JWT",
+			in:   &sso.ImportUsersIn{AccessToken: "invalid-token", Users: users},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					ImportUsers(gomock.Any(), "invalid-token", users).
+					Return(nil, &security.InvalidJWTError{Message: "token invalid"}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr:   &customgrpc.BaseError{Status: codes.Unauthenticated, Message: "token invalid"},
+			errorExpected: true,
+		},
+		{
+			name: "permission denied",
+			in:   &sso.ImportUsersIn{AccessToken: "user-token", Users: users},
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					ImportUsers(gomock.Any(), "user-token", users).
+					Return(nil, &customerrors.PermissionDeniedError{Message: "administrator role is required"}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customgrpc.BaseError{
+				Status:  codes.PermissionDenied,
+				Message: "administrator role is required",
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			resp, err := api.ImportUsers(context.Background(), tc.in)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedOut, resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package httpcontroller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+
+	"github.com/DKhorkov/hmtm-sso/internal/interfaces"
+)
+
+const (
+	readHeaderTimeout = 5 * time.Second
+	shutdownTimeout   = 10 * time.Second
+)
+
+// New creates an instance of HTTP Controller, which serves public endpoints for other services
+// and endpoints of OpenID Connect provider.
+func New(
+	host string,
+	port int,
+	useCases interfaces.UseCases,
+	trustedProxies []netip.Prefix,
+	logger logging.Logger,
+) *Controller {
+	mux := http.NewServeMux()
+	mux.Handle("GET /.well-known/jwks.json", &jwksHandler{useCases: useCases, logger: logger})
+	mux.Handle(
+		"GET /.well-known/openid-configuration",
+		&openIDConfigurationHandler{useCases: useCases, logger: logger},
+	)
+	mux.Handle("GET /authorize", &authorizeHandler{useCases: useCases, logger: logger})
+	mux.Handle(
+		"POST /token",
+		&tokenHandler{useCases: useCases, trustedProxies: trustedProxies, logger: logger},
+	)
+
+	userInfo := &userInfoHandler{useCases: useCases, logger: logger}
+	mux.Handle("GET /userinfo", userInfo)
+	mux.Handle("POST /userinfo", userInfo)
+
+	return &Controller{
+		httpServer: &http.Server{
+			Addr:              fmt.Sprintf("%s:%d", host, port),
+			Handler:           mux,
+			ReadHeaderTimeout: readHeaderTimeout,
+		},
+		host:   host,
+		port:   port,
+		logger: logger,
+	}
+}
+
+type Controller struct {
+	httpServer *http.Server
+	host       string
+	port       int
+	logger     logging.Logger
+}
+
+// Run HTTP server.
+func (controller *Controller) Run() {
+	logging.LogInfo(
+		controller.logger,
+		fmt.Sprintf("Starting HTTP Server at http://%s:%d", controller.host, controller.port),
+	)
+
+	if err := controller.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logging.LogError(controller.logger, "Error occurred while listening to HTTP server", err)
+		panic(err)
+	}
+
+	logging.LogInfo(controller.logger, "Stopped serving new HTTP connections.")
+}
+
+// Stop HTTP server gracefully (graceful shutdown).
+func (controller *Controller) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := controller.httpServer.Shutdown(ctx); err != nil {
+		logging.LogError(controller.logger, "Failed to shutdown HTTP server gracefully", err)
+		return
+	}
+
+	logging.LogInfo(controller.logger, "HTTP server graceful shutdown completed.")
+}
@@ -0,0 +1,34 @@
+package httpcontroller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/DKhorkov/libs/logging"
+
+	"github.com/DKhorkov/hmtm-sso/internal/interfaces"
+)
+
+// Other services may cache keys for this period, so new key should be published as verification key
+// before it is used for signing:
+const jwksCacheControl = "public, max-age=300"
+
+// jwksHandler returns public keys in JWK Set format (RFC 7517) for verification of access tokens.
+type jwksHandler struct {
+	useCases interfaces.UseCases
+	logger   logging.Logger
+}
+
+func (handler *jwksHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Header().Set("Cache-Control", jwksCacheControl)
+
+	if err := json.NewEncoder(writer).Encode(handler.useCases.GetJWKS()); err != nil {
+		logging.LogErrorContext(
+			request.Context(),
+			handler.logger,
+			"Error occurred while trying to write JWKS",
+			err,
+		)
+	}
+}
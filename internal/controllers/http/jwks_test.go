@@ -0,0 +1,78 @@
+package httpcontroller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	mockusecases "github.com/DKhorkov/hmtm-sso/mocks/usecases"
+)
+
+func TestJWKSHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	controller := New("0.0.0.0", 8071, useCases, nil, logger)
+
+	jwks := entities.JWKS{
+		Keys: []entities.JWK{
+			{KeyType: "RSA", KeyID: "current", Use: "sig", Algorithm: "RS256", N: "n", E: "AQAB"},
+		},
+	}
+
+	testCases := []struct {
+		name               string
+		method             string
+		setupMocks         func(useCases *mockusecases.MockUseCases)
+		expectedStatusCode int
+	}{
+		{
+			name:   "success",
+			method: http.MethodGet,
+			setupMocks: func(useCases *mockusecases.MockUseCases) {
+				useCases.
+					EXPECT().
+					GetJWKS().
+					Return(jwks).
+					Times(1)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "method not allowed",
+			method:             http.MethodPost,
+			expectedStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases)
+			}
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(tc.method, "/.well-known/jwks.json", nil)
+			controller.httpServer.Handler.ServeHTTP(recorder, request)
+
+			require.Equal(t, tc.expectedStatusCode, recorder.Code)
+			if tc.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			require.Equal(t, jwksCacheControl, recorder.Header().Get("Cache-Control"))
+
+			var result entities.JWKS
+			require.NoError(t, json.NewDecoder(recorder.Body).Decode(&result))
+			require.Equal(t, jwks, result)
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package httpcontroller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/netip"
+	"net/url"
+
+	"github.com/DKhorkov/libs/logging"
+	"github.com/DKhorkov/libs/security"
+
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+	"github.com/DKhorkov/hmtm-sso/internal/interfaces"
+)
+
+const serverErrorCode = "server_error"
+
+var (
+	invalidJWTError   = &security.InvalidJWTError{}
+	userNotFoundError = &customerrors.UserNotFoundError{}
+)
+
+type errorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// tokenResponse is successful response of token endpoint according to RFC 6749 and OpenID Connect Core 1.0.
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"` // not issued by client credentials grant
+	IDToken      string `json:"id_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+func writeJSON(writer http.ResponseWriter, statusCode int, response any) error {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(statusCode)
+
+	return json.NewEncoder(writer).Encode(response)
+}
+
+// writeError responds with OAuth error. Errors, which are not OAuth errors, are hidden from clients.
+func writeError(writer http.ResponseWriter, err error) error {
+	var oauthError *customerrors.OAuthError
+	if errors.As(err, &oauthError) {
+		statusCode := http.StatusBadRequest
+		if oauthError.Code == customerrors.InvalidClientOAuthErrorCode {
+			statusCode = http.StatusUnauthorized
+		}
+
+		return writeJSON(
+			writer,
+			statusCode,
+			errorResponse{Error: oauthError.Code, ErrorDescription: oauthError.Message},
+		)
+	}
+
+	return writeJSON(writer, http.StatusInternalServerError, errorResponse{Error: serverErrorCode})
+}
+
+// openIDConfigurationHandler returns metadata of OpenID Connect provider for discovery by clients.
+type openIDConfigurationHandler struct {
+	useCases interfaces.UseCases
+	logger   logging.Logger
+}
+
+func (handler *openIDConfigurationHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	writer.Header().Set("Cache-Control", jwksCacheControl)
+
+	if err := writeJSON(writer, http.StatusOK, handler.useCases.GetOpenIDConfiguration()); err != nil {
+		logging.LogErrorContext(
+			request.Context(),
+			handler.logger,
+			"Error occurred while trying to write OpenID configuration",
+			err,
+		)
+	}
+}
+
+// authorizeHandler redirects User to client with authorization code or to login page, if User has not logged in yet.
+type authorizeHandler struct {
+	useCases interfaces.UseCases
+	logger   logging.Logger
+}
+
+func (handler *authorizeHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	authorizeData := entities.AuthorizeDTO{
+		ClientID:            query.Get("client_id"),
+		RedirectURI:         query.Get("redirect_uri"),
+		ResponseType:        query.Get("response_type"),
+		Scope:               query.Get("scope"),
+		State:               query.Get("state"),
+		Nonce:               query.Get("nonce"),
+		Prompt:              query.Get("prompt"),
+		CodeChallenge:       query.Get("code_challenge"),
+		CodeChallengeMethod: query.Get("code_challenge_method"),
+		AccessToken:         getAccessToken(request),
+	}
+
+	redirectURL, err := handler.useCases.Authorize(request.Context(), authorizeData)
+	if err != nil {
+		logging.LogErrorContext(
+			request.Context(),
+			handler.logger,
+			"Error occurred while trying to authorize User for client with ID="+authorizeData.ClientID,
+			err,
+		)
+
+		if err = writeError(writer, err); err != nil {
+			logging.LogErrorContext(
+				request.Context(),
+				handler.logger,
+				"Error occurred while trying to write authorization error",
+				err,
+			)
+		}
+
+		return
+	}
+
+	http.Redirect(writer, request, redirectURL, http.StatusFound)
+}
+
+// tokenHandler exchanges authorization code, refresh token or client credentials for tokens.
+type tokenHandler struct {
+	useCases       interfaces.UseCases
+	trustedProxies []netip.Prefix
+	logger         logging.Logger
+}
+
+func (handler *tokenHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	// Tokens must not be cached according to RFC 6749:
+	writer.Header().Set("Cache-Control", "no-store")
+	writer.Header().Set("Pragma", "no-cache")
+
+	if err := request.ParseForm(); err != nil {
+		handler.writeError(
+			writer,
+			request,
+			&customerrors.OAuthError{Code: customerrors.InvalidRequestOAuthErrorCode, BaseErr: err},
+		)
+
+		return
+	}
+
+	clientID, clientSecret := getClientCredentials(request)
+	tokens, err := handler.useCases.ExchangeOIDCToken(
+		request.Context(),
+		entities.OIDCTokenRequestDTO{
+			GrantType:    request.PostForm.Get("grant_type"),
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			Code:         request.PostForm.Get("code"),
+			RedirectURI:  request.PostForm.Get("redirect_uri"),
+			CodeVerifier: request.PostForm.Get("code_verifier"),
+			RefreshToken: request.PostForm.Get("refresh_token"),
+			Scope:        request.PostForm.Get("scope"),
+			ClientInfo:   getClientInfo(request, handler.trustedProxies),
+		},
+	)
+	if err != nil {
+		handler.writeError(writer, request, err)
+		return
+	}
+
+	if err = writeJSON(
+		writer,
+		http.StatusOK,
+		tokenResponse{
+			AccessToken:  tokens.AccessToken,
+			TokenType:    tokens.TokenType,
+			ExpiresIn:    int64(tokens.ExpiresIn.Seconds()),
+			RefreshToken: tokens.RefreshToken,
+			IDToken:      tokens.IDToken,
+			Scope:        tokens.Scope,
+		},
+	); err != nil {
+		logging.LogErrorContext(
+			request.Context(),
+			handler.logger,
+			"Error occurred while trying to write tokens",
+			err,
+		)
+	}
+}
+
+// getClientCredentials retrieves credentials of client from HTTP Basic authentication or from form parameters.
+// Credentials in Basic authentication are URL-encoded according to RFC 6749.
+func getClientCredentials(request *http.Request) (clientID, clientSecret string) {
+	username, password, ok := request.BasicAuth()
+	if !ok {
+		return request.PostForm.Get("client_id"), request.PostForm.Get("client_secret")
+	}
+
+	var err error
+	if clientID, err = url.QueryUnescape(username); err != nil {
+		clientID = username
+	}
+
+	if clientSecret, err = url.QueryUnescape(password); err != nil {
+		clientSecret = password
+	}
+
+	return clientID, clientSecret
+}
+
+func (handler *tokenHandler) writeError(writer http.ResponseWriter, request *http.Request, err error) {
+	logging.LogErrorContext(
+		request.Context(),
+		handler.logger,
+		"Error occurred while trying to issue tokens for client with ID="+request.PostForm.Get("client_id"),
+		err,
+	)
+
+	if err = writeError(writer, err); err != nil {
+		logging.LogErrorContext(
+			request.Context(),
+			handler.logger,
+			"Error occurred while trying to write token error",
+			err,
+		)
+	}
+}
+
+// userInfoHandler returns claims of User, who access token has been issued for.
+type userInfoHandler struct {
+	useCases interfaces.UseCases
+	logger   logging.Logger
+}
+
+func (handler *userInfoHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	userInfo, err := handler.useCases.GetUserInfo(request.Context(), getBearerToken(request))
+	if err != nil {
+		logging.LogErrorContext(
+			request.Context(),
+			handler.logger,
+			"Error occurred while trying to get UserInfo",
+			err,
+		)
+
+		switch {
+		case errors.As(err, &invalidJWTError), errors.As(err, &userNotFoundError):
+			// Error of protected resource request according to RFC 6750:
+			writer.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			writer.WriteHeader(http.StatusUnauthorized)
+		default:
+			writer.WriteHeader(http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	if err = writeJSON(writer, http.StatusOK, userInfo); err != nil {
+		logging.LogErrorContext(
+			request.Context(),
+			handler.logger,
+			"Error occurred while trying to write UserInfo",
+			err,
+		)
+	}
+}
@@ -0,0 +1,486 @@
+package httpcontroller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/libs/security"
+
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+	mockusecases "github.com/DKhorkov/hmtm-sso/mocks/usecases"
+)
+
+func TestOpenIDConfigurationHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	controller := New("0.0.0.0", 8071, useCases, nil, logger)
+
+	configuration := entities.OpenIDConfiguration{
+		Issuer:                "https://sso.example.com",
+		AuthorizationEndpoint: "https://sso.example.com/authorize",
+		TokenEndpoint:         "https://sso.example.com/token",
+	}
+
+	useCases.
+		EXPECT().
+		GetOpenIDConfiguration().
+		Return(configuration).
+		Times(1)
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/.well-known/openid-configuration", nil)
+	controller.httpServer.Handler.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, jwksCacheControl, recorder.Header().Get("Cache-Control"))
+
+	var result entities.OpenIDConfiguration
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&result))
+	require.Equal(t, configuration, result)
+}
+
+func TestAuthorizeHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	controller := New("0.0.0.0", 8071, useCases, nil, logger)
+
+	query := url.Values{}
+	query.Set("client_id", "shop")
+	query.Set("redirect_uri", "https://shop.example.com/callback")
+	query.Set("response_type", entities.CodeResponseType)
+	query.Set("scope", entities.OpenIDScope)
+	query.Set("state", "state")
+
+	testCases := []struct {
+		name               string
+		accessTokenCookie  string
+		setupMocks         func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedStatusCode int
+		expectedLocation   string
+		expectedError      string
+	}{
+		{
+			name:              "success",
+			accessTokenCookie: "accessToken",
+			setupMocks: func(useCases *mockusecases.MockUseCases, _ *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					Authorize(
+						gomock.Any(),
+						entities.AuthorizeDTO{
+							ClientID:     "shop",
+							RedirectURI:  "https://shop.example.com/callback",
+							ResponseType: entities.CodeResponseType,
+							Scope:        entities.OpenIDScope,
+							State:        "state",
+							AccessToken:  "accessToken",
+						},
+					).
+					Return("https://shop.example.com/callback?code=code&state=state", nil).
+					Times(1)
+			},
+			expectedStatusCode: http.StatusFound,
+			expectedLocation:   "https://shop.example.com/callback?code=code&state=state",
+		},
+		{
+			name: "invalid client",
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					Authorize(gomock.Any(), gomock.Any()).
+					Return("", &customerrors.OAuthError{Code: customerrors.InvalidClientOAuthErrorCode}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedError:      customerrors.InvalidClientOAuthErrorCode,
+		},
+		{
+			name: "internal error",
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					Authorize(gomock.Any(), gomock.Any()).
+					Return("", errors.New("test error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedError:      serverErrorCode,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/authorize?"+query.Encode(), nil)
+			if tc.accessTokenCookie != "" {
+				request.AddCookie(&http.Cookie{Name: accessTokenCookieName, Value: tc.accessTokenCookie})
+			}
+
+			controller.httpServer.Handler.ServeHTTP(recorder, request)
+
+			require.Equal(t, tc.expectedStatusCode, recorder.Code)
+			if tc.expectedLocation != "" {
+				require.Equal(t, tc.expectedLocation, recorder.Header().Get("Location"))
+			}
+
+			if tc.expectedError != "" {
+				var result errorResponse
+				require.NoError(t, json.NewDecoder(recorder.Body).Decode(&result))
+				require.Equal(t, tc.expectedError, result.Error)
+			}
+		})
+	}
+}
+
+func TestTokenHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	controller := New("0.0.0.0", 8071, useCases, nil, logger)
+
+	form := url.Values{}
+	form.Set("grant_type", entities.AuthorizationCodeGrantType)
+	form.Set("client_id", "shop")
+	form.Set("code", "code")
+	form.Set("redirect_uri", "https://shop.example.com/callback")
+	form.Set("code_verifier", "verifier")
+
+	testCases := []struct {
+		name               string
+		setupMocks         func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedStatusCode int
+		expectedResponse   *tokenResponse
+		expectedError      string
+	}{
+		{
+			name: "success",
+			setupMocks: func(useCases *mockusecases.MockUseCases, _ *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					ExchangeOIDCToken(
+						gomock.Any(),
+						entities.OIDCTokenRequestDTO{
+							GrantType:    entities.AuthorizationCodeGrantType,
+							ClientID:     "shop",
+							Code:         "code",
+							RedirectURI:  "https://shop.example.com/callback",
+							CodeVerifier: "verifier",
+							ClientInfo:   entities.ClientInfo{IP: "192.0.2.1"},
+						},
+					).
+					Return(
+						&entities.OIDCTokensDTO{
+							TokensDTO: entities.TokensDTO{
+								AccessToken:  "accessToken",
+								RefreshToken: "refreshToken",
+								TokenType:    "Bearer",
+								ExpiresIn:    time.Hour,
+							},
+							IDToken: "idToken",
+							Scope:   entities.OpenIDScope,
+						},
+						nil,
+					).
+					Times(1)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: &tokenResponse{
+				AccessToken:  "accessToken",
+				TokenType:    "Bearer",
+				ExpiresIn:    3600,
+				RefreshToken: "refreshToken",
+				IDToken:      "idToken",
+				Scope:        entities.OpenIDScope,
+			},
+		},
+		{
+			name: "invalid grant",
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					ExchangeOIDCToken(gomock.Any(), gomock.Any()).
+					Return(nil, &customerrors.OAuthError{Code: customerrors.InvalidGrantOAuthErrorCode}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      customerrors.InvalidGrantOAuthErrorCode,
+		},
+		{
+			name: "internal error",
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					ExchangeOIDCToken(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("test error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedError:      serverErrorCode,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "/token", strings.NewReader(form.Encode()))
+			request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			controller.httpServer.Handler.ServeHTTP(recorder, request)
+
+			require.Equal(t, tc.expectedStatusCode, recorder.Code)
+			require.Equal(t, "no-store", recorder.Header().Get("Cache-Control"))
+
+			if tc.expectedResponse != nil {
+				var result tokenResponse
+				require.NoError(t, json.NewDecoder(recorder.Body).Decode(&result))
+				require.Equal(t, *tc.expectedResponse, result)
+			}
+
+			if tc.expectedError != "" {
+				var result errorResponse
+				require.NoError(t, json.NewDecoder(recorder.Body).Decode(&result))
+				require.Equal(t, tc.expectedError, result.Error)
+			}
+		})
+	}
+}
+
+func TestTokenHandlerClientCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	controller := New("0.0.0.0", 8071, useCases, nil, logger)
+
+	form := url.Values{}
+	form.Set("grant_type", entities.ClientCredentialsGrantType)
+	form.Set("scope", "users:read")
+
+	useCases.
+		EXPECT().
+		ExchangeOIDCToken(
+			gomock.Any(),
+			entities.OIDCTokenRequestDTO{
+				GrantType:    entities.ClientCredentialsGrantType,
+				ClientID:     "orders",
+				ClientSecret: "secret",
+				Scope:        "users:read",
+				ClientInfo:   entities.ClientInfo{IP: "192.0.2.1"},
+			},
+		).
+		Return(
+			&entities.OIDCTokensDTO{
+				TokensDTO: entities.TokensDTO{
+					AccessToken: "accessToken",
+					TokenType:   "Bearer",
+					ExpiresIn:   time.Minute * 5,
+				},
+				Scope: "users:read",
+			},
+			nil,
+		).
+		Times(1)
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, "/token", strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.SetBasicAuth("orders", "secret")
+	controller.httpServer.Handler.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var result map[string]any
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&result))
+	require.Equal(t, "accessToken", result["access_token"])
+	require.Equal(t, "users:read", result["scope"])
+
+	// Refresh token is not issued for client credentials grant:
+	require.NotContains(t, result, "refresh_token")
+}
+
+func TestUserInfoHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	useCases := mockusecases.NewMockUseCases(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	controller := New("0.0.0.0", 8071, useCases, nil, logger)
+
+	userInfo := &entities.UserInfo{
+		Subject:       "1",
+		Email:         "user@example.com",
+		EmailVerified: true,
+		Name:          "User",
+	}
+
+	testCases := []struct {
+		name               string
+		setupMocks         func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger)
+		expectedStatusCode int
+		expectedUserInfo   *entities.UserInfo
+	}{
+		{
+			name: "success",
+			setupMocks: func(useCases *mockusecases.MockUseCases, _ *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					GetUserInfo(gomock.Any(), "accessToken").
+					Return(userInfo, nil).
+					Times(1)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedUserInfo:   userInfo,
+		},
+		{
+			name: "invalid token",
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					GetUserInfo(gomock.Any(), "accessToken").
+					Return(nil, &security.InvalidJWTError{}).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name: "internal error",
+			setupMocks: func(useCases *mockusecases.MockUseCases, logger *mocklogging.MockLogger) {
+				useCases.
+					EXPECT().
+					GetUserInfo(gomock.Any(), "accessToken").
+					Return(nil, errors.New("test error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(useCases, logger)
+			}
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/userinfo", nil)
+			request.Header.Set("Authorization", "Bearer accessToken")
+			controller.httpServer.Handler.ServeHTTP(recorder, request)
+
+			require.Equal(t, tc.expectedStatusCode, recorder.Code)
+			if tc.expectedStatusCode == http.StatusUnauthorized {
+				require.Equal(t, `Bearer error="invalid_token"`, recorder.Header().Get("WWW-Authenticate"))
+			}
+
+			if tc.expectedUserInfo != nil {
+				var result entities.UserInfo
+				require.NoError(t, json.NewDecoder(recorder.Body).Decode(&result))
+				require.Equal(t, *tc.expectedUserInfo, result)
+			}
+		})
+	}
+}
+
+func TestGetClientCredentials(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		request              func() *http.Request
+		expectedClientID     string
+		expectedClientSecret string
+	}{
+		{
+			name: "client_secret_post",
+			request: func() *http.Request {
+				form := url.Values{}
+				form.Set("client_id", "shop")
+				form.Set("client_secret", "secret")
+
+				request := httptest.NewRequest(http.MethodPost, "/token", strings.NewReader(form.Encode()))
+				request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+				return request
+			},
+			expectedClientID:     "shop",
+			expectedClientSecret: "secret",
+		},
+		{
+			name: "client_secret_basic",
+			request: func() *http.Request {
+				request := httptest.NewRequest(http.MethodPost, "/token", nil)
+				request.SetBasicAuth(url.QueryEscape("shop"), url.QueryEscape("secret/+="))
+
+				return request
+			},
+			expectedClientID:     "shop",
+			expectedClientSecret: "secret/+=",
+		},
+		{
+			name: "public client",
+			request: func() *http.Request {
+				request := httptest.NewRequest(http.MethodPost, "/token", strings.NewReader("client_id=shop"))
+				request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+				return request
+			},
+			expectedClientID:     "shop",
+			expectedClientSecret: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := tc.request()
+			require.NoError(t, request.ParseForm())
+
+			clientID, clientSecret := getClientCredentials(request)
+			require.Equal(t, tc.expectedClientID, clientID)
+			require.Equal(t, tc.expectedClientSecret, clientSecret)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package httpcontroller
+
+import (
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/DKhorkov/hmtm-sso/internal/clientip"
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+)
+
+const (
+	deviceNameHeader      = "X-Device-Name"
+	forwardedForHeader    = "X-Forwarded-For"
+	realIPHeader          = "X-Real-Ip"
+	bearerPrefix          = "Bearer "
+	accessTokenCookieName = "access_token"
+)
+
+// getClientInfo retrieves info about client's device from HTTP request.
+// Client's IP is taken from proxy headers only if request was proxied by one of trusted proxies, because other
+// callers can forge such headers. Otherwise, IP of connection is used.
+func getClientInfo(request *http.Request, trustedProxies []netip.Prefix) entities.ClientInfo {
+	return entities.ClientInfo{
+		DeviceName: request.Header.Get(deviceNameHeader),
+		UserAgent:  request.UserAgent(),
+		IP: clientip.Resolve(
+			request.RemoteAddr,
+			strings.Join(request.Header.Values(forwardedForHeader), ","),
+			request.Header.Get(realIPHeader),
+			trustedProxies,
+		),
+	}
+}
+
+// getBearerToken retrieves access token from Authorization header according to RFC 6750.
+func getBearerToken(request *http.Request) string {
+	authorization := request.Header.Get("Authorization")
+	if len(authorization) > len(bearerPrefix) && strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+		return authorization[len(bearerPrefix):]
+	}
+
+	return ""
+}
+
+// getAccessToken retrieves access token of User, who has logged in on SSO login page.
+// Browser sends it as cookie during redirect to authorization endpoint.
+func getAccessToken(request *http.Request) string {
+	if accessToken := getBearerToken(request); accessToken != "" {
+		return accessToken
+	}
+
+	if cookie, err := request.Cookie(accessTokenCookieName); err == nil {
+		return cookie.Value
+	}
+
+	return ""
+}
@@ -0,0 +1,72 @@
+package httpcontroller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+)
+
+func TestGetClientInfo(t *testing.T) {
+	trustedProxies := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/24")}
+
+	testCases := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string][]string
+		expected   entities.ClientInfo
+	}{
+		{
+			name:       "IP from connection",
+			remoteAddr: "203.0.113.7:52341",
+			headers: map[string][]string{
+				"X-Device-Name": {"iPhone"},
+				"User-Agent":    {"Safari"},
+			},
+			expected: entities.ClientInfo{
+				DeviceName: "iPhone",
+				UserAgent:  "Safari",
+				IP:         "203.0.113.7",
+			},
+		},
+		{
+			name:       "IP from x-forwarded-for of trusted proxy",
+			remoteAddr: "10.0.0.1:52341",
+			headers: map[string][]string{
+				"X-Forwarded-For": {"1.1.1.1, 203.0.113.7", "10.0.0.2"},
+			},
+			expected: entities.ClientInfo{IP: "203.0.113.7"},
+		},
+		{
+			name:       "IP from x-real-ip of trusted proxy",
+			remoteAddr: "10.0.0.1:52341",
+			headers: map[string][]string{
+				"X-Real-Ip": {"203.0.113.7"},
+			},
+			expected: entities.ClientInfo{IP: "203.0.113.7"},
+		},
+		{
+			name:       "forged headers from untrusted caller",
+			remoteAddr: "198.51.100.9:52341",
+			headers: map[string][]string{
+				"X-Forwarded-For": {"203.0.113.7"},
+				"X-Real-Ip":       {"203.0.113.7"},
+			},
+			expected: entities.ClientInfo{IP: "198.51.100.9"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "/token", nil)
+			request.RemoteAddr = tc.remoteAddr
+			request.Header = tc.headers
+
+			require.Equal(t, tc.expected, getClientInfo(request, trustedProxies))
+		})
+	}
+}
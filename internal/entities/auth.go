@@ -3,17 +3,99 @@ package entities
 import "time"
 
 type RefreshToken struct {
-	ID        uint64    `json:"id"`
+	ID        uint64     `json:"id"`
+	UserID    uint64     `json:"userId"`
+	TTL       time.Time  `json:"ttl"`
+	Value     string     `json:"value"` // SHA-256 digest of opaque refresh token or JWT for legacy refresh tokens
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	SessionID *uint64    `json:"sessionId,omitempty"` // nil for refresh tokens, created before sessions were introduced
+	FamilyID  *string    `json:"familyId,omitempty"`  // shared by all refresh tokens of one rotation chain
+	RotatedAt *time.Time `json:"rotatedAt,omitempty"` // not nil, if refresh token has been already exchanged for new one
+	ClientID  *string    `json:"clientId,omitempty"`  // nil for refresh tokens, issued without registered Client
+}
+
+type CreateRefreshTokenDTO struct {
+	UserID    uint64        `json:"userId"`
+	SessionID uint64        `json:"sessionId"`
+	FamilyID  string        `json:"familyId"`
+	Value     string        `json:"value"` // SHA-256 digest of opaque refresh token, which is never stored as is
+	TTL       time.Duration `json:"ttl"`
+	ClientID  *string       `json:"clientId,omitempty"`
+}
+
+// Session represents one logged in device of User. Each Session has its own refresh token.
+type Session struct {
+	ID         uint64    `json:"id"`
+	UserID     uint64    `json:"userId"`
+	DeviceName string    `json:"deviceName"`
+	UserAgent  string    `json:"userAgent"`
+	IP         string    `json:"ip"`
+	TTL        time.Time `json:"ttl"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// SessionsDTO contains active Sessions of User and ID of Session, from which request was made.
+type SessionsDTO struct {
+	Sessions         []Session `json:"sessions"`
+	CurrentSessionID uint64    `json:"currentSessionId"`
+}
+
+// ClientInfo describes device, from which User made request, and registered Client application,
+// which request was made through. Tokens are issued with default settings, if ClientID is empty.
+type ClientInfo struct {
+	DeviceName   string `json:"deviceName"`
+	UserAgent    string `json:"userAgent"`
+	IP           string `json:"ip"`
+	ClientID     string `json:"clientId,omitempty"`
+	ClientSecret string `json:"-"`
+}
+
+type CreateSessionDTO struct {
+	UserID     uint64        `json:"userId"`
+	ClientInfo ClientInfo    `json:"clientInfo"`
+	TTL        time.Duration `json:"ttl"`
+}
+
+// AccessTokenPayload is retrieved from access token claims and identifies User and Session,
+// for which token was issued.
+type AccessTokenPayload struct {
+	ID        string    `json:"id"`
 	UserID    uint64    `json:"userId"`
-	TTL       time.Time `json:"ttl"`
-	Value     string    `json:"value"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	SessionID uint64    `json:"sessionId"`
+	ClientID  string    `json:"clientId"`
+	Roles     []string  `json:"roles"`
+	Scopes    []string  `json:"scopes"`
+	IssuedAt  time.Time `json:"issuedAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Token types according to RFC 7662.
+const (
+	AccessTokenType  = "access_token"
+	RefreshTokenType = "refresh_token"
+)
+
+// TokenIntrospection describes state of access or refresh token according to RFC 7662.
+// Only Active field is filled for inactive tokens to not disclose any information about them.
+type TokenIntrospection struct {
+	Active    bool      `json:"active"`
+	TokenType string    `json:"tokenType"`
+	UserID    uint64    `json:"userId"`
+	SessionID uint64    `json:"sessionId"` // 0 for tokens, which do not belong to any Session
+	ClientID  string    `json:"clientId"`  // empty for tokens, issued without registered Client
+	Scopes    []string  `json:"scopes"`    // granted scopes, empty for tokens, issued without scopes
+	Roles     []string  `json:"roles"`     // roles of User, filled only for access tokens
+	IssuedAt  time.Time `json:"issuedAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
 }
 
+// LoginUserDTO contains email, confirmed phone or confirmed Telegram handle of User as identifier.
 type LoginUserDTO struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Identifier string     `json:"identifier"`
+	Password   string     `json:"password"`
+	ClientInfo ClientInfo `json:"clientInfo"`
 }
 
 type RegisterUserDTO struct {
@@ -22,7 +104,95 @@ type RegisterUserDTO struct {
 	Password    string `json:"password"`
 }
 
+const BearerTokenType = "Bearer"
+
 type TokensDTO struct {
-	AccessToken  string `json:"accessToken"`
-	RefreshToken string `json:"refreshToken"`
+	AccessToken  string        `json:"accessToken"`
+	RefreshToken string        `json:"refreshToken"`
+	ExpiresIn    time.Duration `json:"expiresIn"` // lifetime of access token
+	TokenType    string        `json:"tokenType"`
+
+	// MFAChallenge is returned instead of tokens, if User has enabled two-factor authentication:
+	MFAChallenge *MFAChallengeDTO `json:"mfaChallenge,omitempty"`
+}
+
+// VerifyEmailToken stores only hashes of token and code, which were sent to User.
+type VerifyEmailToken struct {
+	ID        uint64    `json:"id"`
+	UserID    uint64    `json:"userId"`
+	Email     string    `json:"email"`
+	TokenHash string    `json:"tokenHash"`
+	CodeHash  string    `json:"codeHash"`
+	TTL       time.Time `json:"ttl"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type CreateVerifyEmailTokenDTO struct {
+	UserID    uint64        `json:"userId"`
+	Email     string        `json:"email"`
+	TokenHash string        `json:"tokenHash"`
+	CodeHash  string        `json:"codeHash"`
+	TTL       time.Duration `json:"ttl"`
+}
+
+// ForgetPasswordToken stores only hash of token, which was sent to User.
+type ForgetPasswordToken struct {
+	ID        uint64    `json:"id"`
+	UserID    uint64    `json:"userId"`
+	TokenHash string    `json:"tokenHash"`
+	TTL       time.Time `json:"ttl"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type CreateForgetPasswordTokenDTO struct {
+	UserID    uint64        `json:"userId"`
+	TokenHash string        `json:"tokenHash"`
+	TTL       time.Duration `json:"ttl"`
+}
+
+// LoginToken is issued for passwordless login and stores only hashes of magic-link token and code,
+// which were sent to User.
+type LoginToken struct {
+	ID        uint64    `json:"id"`
+	UserID    uint64    `json:"userId"`
+	TokenHash string    `json:"tokenHash"`
+	CodeHash  string    `json:"codeHash"`
+	TTL       time.Time `json:"ttl"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type CreateLoginTokenDTO struct {
+	UserID    uint64        `json:"userId"`
+	TokenHash string        `json:"tokenHash"`
+	CodeHash  string        `json:"codeHash"`
+	TTL       time.Duration `json:"ttl"`
+}
+
+// PhoneVerificationCode stores only hash of code, which was sent to User via SMS.
+type PhoneVerificationCode struct {
+	ID        uint64    `json:"id"`
+	UserID    uint64    `json:"userId"`
+	Phone     string    `json:"phone"`
+	CodeHash  string    `json:"codeHash"`
+	TTL       time.Time `json:"ttl"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type CreatePhoneVerificationCodeDTO struct {
+	UserID   uint64        `json:"userId"`
+	Phone    string        `json:"phone"`
+	CodeHash string        `json:"codeHash"`
+	TTL      time.Duration `json:"ttl"`
+}
+
+// LoginWithCodeDTO contains either token from magic link or email with one-time code.
+type LoginWithCodeDTO struct {
+	Token      string     `json:"token,omitempty"`
+	Email      string     `json:"email,omitempty"`
+	Code       string     `json:"code,omitempty"`
+	ClientInfo ClientInfo `json:"clientInfo"`
 }
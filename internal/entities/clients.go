@@ -0,0 +1,97 @@
+package entities
+
+import "time"
+
+const (
+	// PasswordGrantType is granted to Clients, which log Users in via SSO RPCs directly: by password,
+	// login link, passkey or second factor.
+	PasswordGrantType = "password"
+
+	// ClientCredentialsGrantType is granted to confidential Clients, which are backend services and
+	// receive tokens on their own behalf for service-to-service calls.
+	ClientCredentialsGrantType = "client_credentials"
+)
+
+// Client is registered application, which Users log in through. Lists are stored as strings:
+// redirect URIs and grant types are comma-separated, scopes are space-separated as in OAuth 2.0.
+type Client struct {
+	ID              uint64    `json:"id"`
+	ClientID        string    `json:"clientId"`
+	SecretHash      string    `json:"-"` // empty for public Clients, which can not keep secret
+	RedirectURIs    string    `json:"redirectUris"`
+	GrantTypes      string    `json:"grantTypes"`
+	Scopes          string    `json:"scopes"`
+	AccessTokenTTL  int64     `json:"accessTokenTtl"`  // in seconds, 0 means default TTL from config
+	RefreshTokenTTL int64     `json:"refreshTokenTtl"` // in seconds, 0 means default TTL from config
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+type ClientSettingsDTO struct {
+	RedirectURIs    []string      `json:"redirectUris"`
+	GrantTypes      []string      `json:"grantTypes"`
+	Scopes          []string      `json:"scopes"`
+	AccessTokenTTL  time.Duration `json:"accessTokenTtl"`
+	RefreshTokenTTL time.Duration `json:"refreshTokenTtl"`
+}
+
+type RegisterClientDTO struct {
+	AccessToken  string            `json:"accessToken"` // access token of administrator
+	ClientID     string            `json:"clientId"`
+	Confidential bool              `json:"confidential"` // secret is generated only for confidential Clients
+	Settings     ClientSettingsDTO `json:"settings"`
+}
+
+// RegisteredClientDTO contains generated secret of Client, which is shown only once.
+type RegisteredClientDTO struct {
+	Client Client `json:"client"`
+	Secret string `json:"secret,omitempty"`
+}
+
+type UpdateClientDTO struct {
+	AccessToken string            `json:"accessToken"` // access token of administrator
+	ClientID    string            `json:"clientId"`
+	Settings    ClientSettingsDTO `json:"settings"`
+}
+
+type CreateClientDTO struct {
+	ClientID        string        `json:"clientId"`
+	SecretHash      string        `json:"secretHash"`
+	RedirectURIs    string        `json:"redirectUris"`
+	GrantTypes      string        `json:"grantTypes"`
+	Scopes          string        `json:"scopes"`
+	AccessTokenTTL  time.Duration `json:"accessTokenTtl"`
+	RefreshTokenTTL time.Duration `json:"refreshTokenTtl"`
+}
+
+type UpdateClientSettingsDTO struct {
+	ClientID        string        `json:"clientId"`
+	RedirectURIs    string        `json:"redirectUris"`
+	GrantTypes      string        `json:"grantTypes"`
+	Scopes          string        `json:"scopes"`
+	AccessTokenTTL  time.Duration `json:"accessTokenTtl"`
+	RefreshTokenTTL time.Duration `json:"refreshTokenTtl"`
+}
+
+type IssueClientTokenDTO struct {
+	ClientID     string `json:"clientId"`
+	ClientSecret string `json:"-"`
+	Scope        string `json:"scope"` // space-separated, all scopes of Client are granted, if empty
+}
+
+// ClientTokenDTO is access token of Client. Client can always request new token with its credentials,
+// so refresh token is not issued.
+type ClientTokenDTO struct {
+	AccessToken string        `json:"accessToken"`
+	TokenType   string        `json:"tokenType"`
+	ExpiresIn   time.Duration `json:"expiresIn"`
+	Scope       string        `json:"scope"`
+}
+
+// ClientTokenPayload contains verified claims of Client's access token.
+type ClientTokenPayload struct {
+	ID        string    `json:"id"`
+	ClientID  string    `json:"clientId"`
+	Scopes    []string  `json:"scopes"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
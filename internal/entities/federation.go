@@ -0,0 +1,39 @@
+package entities
+
+import "time"
+
+// UserIdentity links User to account of external identity provider. Subject is ID of account,
+// which is unique only within its provider.
+type UserIdentity struct {
+	ID        uint64    `json:"id"`
+	UserID    uint64    `json:"userId"`
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"`
+	Email     string    `json:"email"` // email, which was reported by provider during linking
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type CreateUserIdentityDTO struct {
+	UserID   uint64 `json:"userId"`
+	Provider string `json:"provider"`
+	Subject  string `json:"subject"`
+	Email    string `json:"email"`
+}
+
+// ExternalIdentity is account of User, which has been received from external identity provider after login.
+type ExternalIdentity struct {
+	Provider      string `json:"provider"`
+	Subject       string `json:"subject"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"emailVerified"`
+	Name          string `json:"name"`
+}
+
+// FinishFederatedLoginDTO contains parameters, which identity provider has redirected User back with.
+type FinishFederatedLoginDTO struct {
+	Provider   string     `json:"provider"`
+	Code       string     `json:"code"`
+	State      string     `json:"state"`
+	ClientInfo ClientInfo `json:"clientInfo"`
+}
@@ -0,0 +1,71 @@
+package entities
+
+import "time"
+
+// TOTPSecret is shared with User's authenticator app. Secret is stored encrypted, because it is needed
+// to verify codes. Two-factor authentication is enabled only after Secret was confirmed with first code.
+type TOTPSecret struct {
+	ID           uint64    `json:"id"`
+	UserID       uint64    `json:"userId"`
+	Secret       string    `json:"secret"`
+	Confirmed    bool      `json:"confirmed"`
+	LastUsedStep int64     `json:"lastUsedStep"` // codes of this and previous steps can not be used again
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+type CreateTOTPSecretDTO struct {
+	UserID uint64 `json:"userId"`
+	Secret string `json:"secret"`
+}
+
+// TOTPEnrollmentDTO contains data for authenticator app, which is shown to User only once.
+type TOTPEnrollmentDTO struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"` // otpauth:// URI, which is encoded to QR code
+}
+
+// RecoveryCode stores only hash of one-time code, which can be used instead of TOTP code.
+type RecoveryCode struct {
+	ID        uint64     `json:"id"`
+	UserID    uint64     `json:"userId"`
+	CodeHash  string     `json:"codeHash"`
+	UsedAt    *time.Time `json:"usedAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+type ConfirmTOTPSecretDTO struct {
+	TOTPSecretID       uint64   `json:"totpSecretId"`
+	UserID             uint64   `json:"userId"`
+	Step               int64    `json:"step"` // step of code, which confirmed TOTP secret
+	RecoveryCodeHashes []string `json:"recoveryCodeHashes"`
+}
+
+// MFAChallenge is issued instead of tokens to User with enabled two-factor authentication,
+// who has provided valid password. Only hash of challenge token is stored.
+type MFAChallenge struct {
+	ID        uint64    `json:"id"`
+	UserID    uint64    `json:"userId"`
+	TokenHash string    `json:"tokenHash"`
+	TTL       time.Time `json:"ttl"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type CreateMFAChallengeDTO struct {
+	UserID    uint64        `json:"userId"`
+	TokenHash string        `json:"tokenHash"`
+	TTL       time.Duration `json:"ttl"`
+}
+
+type MFAChallengeDTO struct {
+	Token     string        `json:"token"`
+	ExpiresIn time.Duration `json:"expiresIn"`
+}
+
+type CompleteMFALoginDTO struct {
+	MFAChallenge string     `json:"mfaChallenge"`
+	Code         string     `json:"code"` // TOTP code or recovery code
+	ClientInfo   ClientInfo `json:"clientInfo"`
+}
@@ -0,0 +1,33 @@
+package entities
+
+import (
+	notifications "github.com/DKhorkov/hmtm-notifications/dto"
+)
+
+// VerifyEmailDTO extends notifications contract with credentials, which User needs to verify email.
+type VerifyEmailDTO struct {
+	notifications.VerifyEmailDTO
+	Token string `json:"token"`
+	Code  string `json:"code"`
+}
+
+// ForgetPasswordDTO extends notifications contract with token, which User needs to reset password.
+type ForgetPasswordDTO struct {
+	notifications.ForgetPasswordDTO
+	Token string `json:"token"`
+}
+
+// LoginLinkDTO contains credentials for passwordless login. Notifications contract has no such message yet,
+// so it is defined by SSO.
+type LoginLinkDTO struct {
+	UserID uint64 `json:"userId"`
+	Token  string `json:"token"`
+	Code   string `json:"code"`
+}
+
+// PhoneVerificationCodeDTO contains code, which is sent to User via SMS to verify phone.
+type PhoneVerificationCodeDTO struct {
+	UserID uint64 `json:"userId"`
+	Phone  string `json:"phone"`
+	Code   string `json:"code"`
+}
@@ -0,0 +1,108 @@
+package entities
+
+import "time"
+
+// OpenID Connect scopes and OAuth 2.0 values, which are supported by provider:
+const (
+	OpenIDScope  = "openid"
+	EmailScope   = "email"
+	ProfileScope = "profile"
+
+	CodeResponseType = "code"
+
+	AuthorizationCodeGrantType = "authorization_code"
+	RefreshTokenGrantType      = "refresh_token"
+
+	S256CodeChallengeMethod = "S256"
+
+	NonePrompt = "none"
+)
+
+// AuthorizeDTO contains parameters of authorization request and access token of User,
+// who has already logged in on SSO login page.
+type AuthorizeDTO struct {
+	ClientID            string `json:"clientId"`
+	RedirectURI         string `json:"redirectUri"`
+	ResponseType        string `json:"responseType"`
+	Scope               string `json:"scope"`
+	State               string `json:"state"`
+	Nonce               string `json:"nonce"`
+	Prompt              string `json:"prompt"`
+	CodeChallenge       string `json:"codeChallenge"`
+	CodeChallengeMethod string `json:"codeChallengeMethod"`
+	AccessToken         string `json:"accessToken"`
+}
+
+// AuthorizationCode is issued to client after User's authorization and can be exchanged for tokens only once.
+type AuthorizationCode struct {
+	ID            uint64    `json:"id"`
+	UserID        uint64    `json:"userId"`
+	ClientID      string    `json:"clientId"`
+	CodeHash      string    `json:"codeHash"`
+	RedirectURI   string    `json:"redirectUri"`
+	Scope         string    `json:"scope"`
+	Nonce         string    `json:"nonce"`
+	CodeChallenge string    `json:"codeChallenge"` // S256 of code verifier
+	TTL           time.Time `json:"ttl"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+type CreateAuthorizationCodeDTO struct {
+	UserID        uint64        `json:"userId"`
+	ClientID      string        `json:"clientId"`
+	CodeHash      string        `json:"codeHash"`
+	RedirectURI   string        `json:"redirectUri"`
+	Scope         string        `json:"scope"`
+	Nonce         string        `json:"nonce"`
+	CodeChallenge string        `json:"codeChallenge"`
+	TTL           time.Duration `json:"ttl"`
+}
+
+// OIDCTokenRequestDTO contains parameters of token request. Set of used parameters depends on grant type.
+type OIDCTokenRequestDTO struct {
+	GrantType    string     `json:"grantType"`
+	ClientID     string     `json:"clientId"`
+	ClientSecret string     `json:"-"` // empty for public clients
+	Code         string     `json:"code"`
+	RedirectURI  string     `json:"redirectUri"`
+	CodeVerifier string     `json:"codeVerifier"`
+	RefreshToken string     `json:"refreshToken"`
+	Scope        string     `json:"scope"`
+	ClientInfo   ClientInfo `json:"clientInfo"`
+}
+
+// OIDCTokensDTO are tokens, which are issued by token endpoint. ID token is issued only for authorization code.
+type OIDCTokensDTO struct {
+	TokensDTO
+	IDToken string `json:"idToken,omitempty"`
+	Scope   string `json:"scope,omitempty"`
+}
+
+// UserInfo contains standard claims of User according to OpenID Connect Core 1.0.
+type UserInfo struct {
+	Subject             string `json:"sub"`
+	Email               string `json:"email,omitempty"`
+	EmailVerified       bool   `json:"email_verified"`
+	Name                string `json:"name,omitempty"`
+	Picture             string `json:"picture,omitempty"`
+	PhoneNumber         string `json:"phone_number,omitempty"`
+	PhoneNumberVerified bool   `json:"phone_number_verified"`
+}
+
+// OpenIDConfiguration is provider metadata according to OpenID Connect Discovery 1.0.
+type OpenIDConfiguration struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
@@ -0,0 +1,31 @@
+package entities
+
+import "time"
+
+const RefreshTokenReuseSecurityEvent = "refresh_token_reuse"
+
+// SecurityEventDTO is published via NATS to notify other services about suspicious activity on User's account.
+type SecurityEventDTO struct {
+	Type       string    `json:"type"`
+	UserID     uint64    `json:"userId"`
+	SessionID  *uint64   `json:"sessionId,omitempty"`
+	FamilyID   string    `json:"familyId,omitempty"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+// JWK is public key in JSON Web Key format (RFC 7517), which is used by other services to verify JWT.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`   // RSA modulus
+	E         string `json:"e,omitempty"`   // RSA public exponent
+	Curve     string `json:"crv,omitempty"` // EC and OKP curve
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
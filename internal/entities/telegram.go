@@ -0,0 +1,25 @@
+package entities
+
+// TelegramIdentityProvider is provider of User identities, which are linked via Telegram Login Widget.
+const TelegramIdentityProvider = "telegram"
+
+// TelegramAuthDTO contains data of Telegram User, which has been signed by Telegram Login Widget.
+type TelegramAuthDTO struct {
+	ID        int64  `json:"id"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	Username  string `json:"username"`
+	PhotoURL  string `json:"photoUrl"`
+	AuthDate  int64  `json:"authDate"`
+	Hash      string `json:"hash"`
+}
+
+type LoginWithTelegramDTO struct {
+	TelegramAuth TelegramAuthDTO `json:"telegramAuth"`
+	ClientInfo   ClientInfo      `json:"clientInfo"`
+}
+
+type LinkTelegramDTO struct {
+	AccessToken  string          `json:"accessToken"`
+	TelegramAuth TelegramAuthDTO `json:"telegramAuth"`
+}
@@ -2,6 +2,11 @@ package entities
 
 import "time"
 
+const (
+	UserRole  = "user"
+	AdminRole = "admin"
+)
+
 type User struct {
 	ID                uint64    `json:"id"`
 	DisplayName       string    `json:"displayName"`
@@ -15,6 +20,8 @@ type User struct {
 	Avatar            *string   `json:"avatar,omitempty"`
 	CreatedAt         time.Time `json:"createdAt"`
 	UpdatedAt         time.Time `json:"updatedAt"`
+	Role              string    `json:"role"`
+	Blocked           bool      `json:"blocked"` // blocked User can not use issued tokens
 }
 
 type RawUpdateUserProfileDTO struct {
@@ -32,3 +39,23 @@ type UpdateUserProfileDTO struct {
 	Telegram    *string `json:"telegram,omitempty"`
 	Avatar      *string `json:"avatar,omitempty"`
 }
+
+// ImportUserDTO is line of JSONL file with Users, which are imported from other platform.
+// Password is already hashed with one of supported algorithms.
+type ImportUserDTO struct {
+	DisplayName    string `json:"displayName"`
+	Email          string `json:"email"`
+	PasswordHash   string `json:"passwordHash"`
+	EmailConfirmed bool   `json:"emailConfirmed"`
+}
+
+type ImportUserError struct {
+	Line    uint64 `json:"line"`
+	Message string `json:"message"`
+}
+
+// ImportUsersResult contains number of imported Users and errors of lines, which were not imported.
+type ImportUsersResult struct {
+	Imported uint64            `json:"imported"`
+	Errors   []ImportUserError `json:"errors"`
+}
@@ -0,0 +1,65 @@
+package entities
+
+import "time"
+
+// Ceremonies, which WebAuthn challenges are issued for:
+const (
+	WebAuthnRegistrationCeremony = "registration"
+	WebAuthnLoginCeremony        = "login"
+)
+
+// WebAuthnCredential is passkey, which User has registered with authenticator.
+type WebAuthnCredential struct {
+	ID           uint64    `json:"id"`
+	UserID       uint64    `json:"userId"`
+	CredentialID string    `json:"credentialId"` // base64url encoded, as it is provided by browser
+	PublicKey    []byte    `json:"publicKey"`    // COSE_Key
+	SignCount    uint32    `json:"signCount"`
+	Transports   string    `json:"transports"` // comma-separated hints for browser, such as "internal" or "usb"
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+type CreateWebAuthnCredentialDTO struct {
+	UserID       uint64 `json:"userId"`
+	CredentialID string `json:"credentialId"`
+	PublicKey    []byte `json:"publicKey"`
+	SignCount    uint32 `json:"signCount"`
+	Transports   string `json:"transports"`
+}
+
+// WebAuthnChallenge is issued at the beginning of WebAuthn ceremony and can be used only once.
+// Login challenge has no User, if User is going to choose discoverable credential.
+type WebAuthnChallenge struct {
+	ID            uint64    `json:"id"`
+	UserID        *uint64   `json:"userId,omitempty"`
+	ChallengeHash string    `json:"challengeHash"`
+	Ceremony      string    `json:"ceremony"`
+	TTL           time.Time `json:"ttl"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+type CreateWebAuthnChallengeDTO struct {
+	UserID        *uint64       `json:"userId,omitempty"`
+	ChallengeHash string        `json:"challengeHash"`
+	Ceremony      string        `json:"ceremony"`
+	TTL           time.Duration `json:"ttl"`
+}
+
+type FinishWebAuthnRegistrationDTO struct {
+	AccessToken       string   `json:"accessToken"`
+	CredentialID      string   `json:"credentialId"`
+	ClientDataJSON    []byte   `json:"clientDataJson"`
+	AttestationObject []byte   `json:"attestationObject"`
+	Transports        []string `json:"transports"`
+}
+
+type FinishWebAuthnLoginDTO struct {
+	CredentialID      string     `json:"credentialId"`
+	ClientDataJSON    []byte     `json:"clientDataJson"`
+	AuthenticatorData []byte     `json:"authenticatorData"`
+	Signature         []byte     `json:"signature"`
+	UserHandle        []byte     `json:"userHandle,omitempty"`
+	ClientInfo        ClientInfo `json:"clientInfo"`
+}
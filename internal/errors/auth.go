@@ -133,3 +133,25 @@ func (e EmailIsNotConfirmedError) Error() string {
 func (e EmailIsNotConfirmedError) Unwrap() error {
 	return e.BaseErr
 }
+
+type SessionNotFoundError struct {
+	Message string
+	BaseErr error
+}
+
+func (e SessionNotFoundError) Error() string {
+	template := "session not found"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e SessionNotFoundError) Unwrap() error {
+	return e.BaseErr
+}
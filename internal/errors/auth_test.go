@@ -27,6 +27,8 @@ func testError(t *testing.T, err interface {
 			*v = EmailAlreadyConfirmedError{}
 		case *EmailIsNotConfirmedError:
 			*v = EmailIsNotConfirmedError{}
+		case *SessionNotFoundError:
+			*v = SessionNotFoundError{}
 		}
 
 		require.Equal(t, defaultMessage, e.Error())
@@ -48,6 +50,8 @@ func testError(t *testing.T, err interface {
 			*v = EmailAlreadyConfirmedError{Message: customMessage}
 		case *EmailIsNotConfirmedError:
 			*v = EmailIsNotConfirmedError{Message: customMessage}
+		case *SessionNotFoundError:
+			*v = SessionNotFoundError{Message: customMessage}
 		}
 
 		require.Equal(t, customMessage, e.Error())
@@ -69,6 +73,8 @@ func testError(t *testing.T, err interface {
 			*v = EmailAlreadyConfirmedError{BaseErr: baseErr}
 		case *EmailIsNotConfirmedError:
 			*v = EmailIsNotConfirmedError{BaseErr: baseErr}
+		case *SessionNotFoundError:
+			*v = SessionNotFoundError{BaseErr: baseErr}
 		}
 
 		expected := defaultMessage + ". Base error: " + baseErr.Error()
@@ -125,6 +131,12 @@ func TestErrors(t *testing.T) {
 			defaultMessage: "provided email is not confirmed",
 			customMessage:  "email test@example.com not verified",
 		},
+		{
+			name:           "SessionNotFoundError",
+			err:            &SessionNotFoundError{},
+			defaultMessage: "session not found",
+			customMessage:  "session with ID=1 not found",
+		},
 	}
 
 	for _, tc := range tests {
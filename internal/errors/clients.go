@@ -0,0 +1,135 @@
+package errors
+
+import "fmt"
+
+type ClientNotFoundError struct {
+	Message string
+	BaseErr error
+}
+
+func (e ClientNotFoundError) Error() string {
+	template := "client not found"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e ClientNotFoundError) Unwrap() error {
+	return e.BaseErr
+}
+
+type ClientAlreadyExistsError struct {
+	Message string
+	BaseErr error
+}
+
+func (e ClientAlreadyExistsError) Error() string {
+	template := "client already exists"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e ClientAlreadyExistsError) Unwrap() error {
+	return e.BaseErr
+}
+
+type InvalidClientError struct {
+	Message string
+	BaseErr error
+}
+
+func (e InvalidClientError) Error() string {
+	template := "client authentication failed"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e InvalidClientError) Unwrap() error {
+	return e.BaseErr
+}
+
+type UnauthorizedClientError struct {
+	Message string
+	BaseErr error
+}
+
+func (e UnauthorizedClientError) Error() string {
+	template := "client is not allowed to use this grant type"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e UnauthorizedClientError) Unwrap() error {
+	return e.BaseErr
+}
+
+type PermissionDeniedError struct {
+	Message string
+	BaseErr error
+}
+
+func (e PermissionDeniedError) Error() string {
+	template := "permission denied"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e PermissionDeniedError) Unwrap() error {
+	return e.BaseErr
+}
+
+type InvalidScopeError struct {
+	Message string
+	BaseErr error
+}
+
+func (e InvalidScopeError) Error() string {
+	template := "requested scope is not allowed for client"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e InvalidScopeError) Unwrap() error {
+	return e.BaseErr
+}
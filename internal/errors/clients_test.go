@@ -0,0 +1,254 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientNotFoundError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            ClientNotFoundError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            ClientNotFoundError{},
+			expectedString: "client not found",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            ClientNotFoundError{Message: "client has been deleted"},
+			expectedString: "client has been deleted",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            ClientNotFoundError{BaseErr: errors.New("db error")},
+			expectedString: "client not found. Base error: db error",
+			expectedBase:   errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
+
+func TestClientAlreadyExistsError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            ClientAlreadyExistsError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            ClientAlreadyExistsError{},
+			expectedString: "client already exists",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            ClientAlreadyExistsError{Message: "client ID is taken"},
+			expectedString: "client ID is taken",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            ClientAlreadyExistsError{BaseErr: errors.New("db error")},
+			expectedString: "client already exists. Base error: db error",
+			expectedBase:   errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
+
+func TestInvalidClientError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            InvalidClientError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            InvalidClientError{},
+			expectedString: "client authentication failed",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            InvalidClientError{Message: "wrong client secret"},
+			expectedString: "wrong client secret",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            InvalidClientError{BaseErr: errors.New("db error")},
+			expectedString: "client authentication failed. Base error: db error",
+			expectedBase:   errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
+
+func TestUnauthorizedClientError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            UnauthorizedClientError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            UnauthorizedClientError{},
+			expectedString: "client is not allowed to use this grant type",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            UnauthorizedClientError{Message: "refresh_token grant is not allowed"},
+			expectedString: "refresh_token grant is not allowed",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            UnauthorizedClientError{BaseErr: errors.New("db error")},
+			expectedString: "client is not allowed to use this grant type. Base error: db error",
+			expectedBase:   errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
+
+func TestPermissionDeniedError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            PermissionDeniedError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            PermissionDeniedError{},
+			expectedString: "permission denied",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            PermissionDeniedError{Message: "administrator role is required"},
+			expectedString: "administrator role is required",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            PermissionDeniedError{BaseErr: errors.New("db error")},
+			expectedString: "permission denied. Base error: db error",
+			expectedBase:   errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
+
+func TestInvalidScopeError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            InvalidScopeError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            InvalidScopeError{},
+			expectedString: "requested scope is not allowed for client",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            InvalidScopeError{Message: "scope is not allowed: users:write"},
+			expectedString: "scope is not allowed: users:write",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            InvalidScopeError{BaseErr: errors.New("db error")},
+			expectedString: "requested scope is not allowed for client. Base error: db error",
+			expectedBase:   errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package errors
+
+import "fmt"
+
+type IdentityProviderNotFoundError struct {
+	Message string
+	BaseErr error
+}
+
+func (e IdentityProviderNotFoundError) Error() string {
+	template := "identity provider not found"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e IdentityProviderNotFoundError) Unwrap() error {
+	return e.BaseErr
+}
+
+type UserIdentityNotFoundError struct {
+	Message string
+	BaseErr error
+}
+
+func (e UserIdentityNotFoundError) Error() string {
+	template := "user identity not found"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e UserIdentityNotFoundError) Unwrap() error {
+	return e.BaseErr
+}
+
+type InvalidFederatedLoginStateError struct {
+	Message string
+	BaseErr error
+}
+
+func (e InvalidFederatedLoginStateError) Error() string {
+	template := "federated login state is invalid or expired"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e InvalidFederatedLoginStateError) Unwrap() error {
+	return e.BaseErr
+}
+
+type FederatedLoginError struct {
+	Message string
+	BaseErr error
+}
+
+func (e FederatedLoginError) Error() string {
+	template := "failed to authenticate via identity provider"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e FederatedLoginError) Unwrap() error {
+	return e.BaseErr
+}
+
+type UserIdentityAlreadyExistsError struct {
+	Message string
+	BaseErr error
+}
+
+func (e UserIdentityAlreadyExistsError) Error() string {
+	template := "user identity is already linked to another user"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e UserIdentityAlreadyExistsError) Unwrap() error {
+	return e.BaseErr
+}
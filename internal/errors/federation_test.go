@@ -0,0 +1,213 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentityProviderNotFoundError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            IdentityProviderNotFoundError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            IdentityProviderNotFoundError{},
+			expectedString: "identity provider not found",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            IdentityProviderNotFoundError{Message: "unknown identity provider: github"},
+			expectedString: "unknown identity provider: github",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            IdentityProviderNotFoundError{BaseErr: errors.New("db error")},
+			expectedString: "identity provider not found. Base error: db error",
+			expectedBase:   errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
+
+func TestUserIdentityNotFoundError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            UserIdentityNotFoundError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            UserIdentityNotFoundError{},
+			expectedString: "user identity not found",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            UserIdentityNotFoundError{Message: "identity is not linked"},
+			expectedString: "identity is not linked",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            UserIdentityNotFoundError{BaseErr: errors.New("db error")},
+			expectedString: "user identity not found. Base error: db error",
+			expectedBase:   errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
+
+func TestInvalidFederatedLoginStateError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            InvalidFederatedLoginStateError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            InvalidFederatedLoginStateError{},
+			expectedString: "federated login state is invalid or expired",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            InvalidFederatedLoginStateError{Message: "state has been issued for another provider"},
+			expectedString: "state has been issued for another provider",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            InvalidFederatedLoginStateError{BaseErr: errors.New("db error")},
+			expectedString: "federated login state is invalid or expired. Base error: db error",
+			expectedBase:   errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
+
+func TestFederatedLoginError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            FederatedLoginError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            FederatedLoginError{},
+			expectedString: "failed to authenticate via identity provider",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            FederatedLoginError{Message: "identity provider has not returned subject"},
+			expectedString: "identity provider has not returned subject",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            FederatedLoginError{BaseErr: errors.New("db error")},
+			expectedString: "failed to authenticate via identity provider. Base error: db error",
+			expectedBase:   errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
+
+func TestUserIdentityAlreadyExistsError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            UserIdentityAlreadyExistsError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            UserIdentityAlreadyExistsError{},
+			expectedString: "user identity is already linked to another user",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            UserIdentityAlreadyExistsError{Message: "telegram account is already linked to another user"},
+			expectedString: "telegram account is already linked to another user",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            UserIdentityAlreadyExistsError{BaseErr: errors.New("db error")},
+			expectedString: "user identity is already linked to another user. Base error: db error",
+			expectedBase:   errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package errors
+
+import (
+	"fmt"
+	"time"
+)
+
+// AccountLockedError is returned, when login is temporarily not allowed due to failed attempts.
+// RetryAfter is time, after which next attempt is allowed.
+type AccountLockedError struct {
+	Message    string
+	BaseErr    error
+	RetryAfter time.Duration
+}
+
+func (e AccountLockedError) Error() string {
+	template := "account is temporarily locked due to failed login attempts"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.RetryAfter > 0 {
+		template = fmt.Sprintf(template+". Retry after %s", e.RetryAfter)
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e AccountLockedError) Unwrap() error {
+	return e.BaseErr
+}
@@ -0,0 +1,50 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountLockedError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            AccountLockedError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            AccountLockedError{},
+			expectedString: "account is temporarily locked due to failed login attempts",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, with retry after",
+			err:            AccountLockedError{Message: "too many failed login attempts", RetryAfter: time.Second * 30},
+			expectedString: "too many failed login attempts. Retry after 30s",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            AccountLockedError{BaseErr: errors.New("lockout")},
+			expectedString: "account is temporarily locked due to failed login attempts. Base error: lockout",
+			expectedBase:   errors.New("lockout"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package errors
+
+import "fmt"
+
+type MFANotEnabledError struct {
+	Message string
+	BaseErr error
+}
+
+func (e MFANotEnabledError) Error() string {
+	template := "two-factor authentication is not enabled"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e MFANotEnabledError) Unwrap() error {
+	return e.BaseErr
+}
+
+type MFAAlreadyEnabledError struct {
+	Message string
+	BaseErr error
+}
+
+func (e MFAAlreadyEnabledError) Error() string {
+	template := "two-factor authentication is already enabled"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e MFAAlreadyEnabledError) Unwrap() error {
+	return e.BaseErr
+}
+
+type InvalidMFACodeError struct {
+	Message string
+	BaseErr error
+}
+
+func (e InvalidMFACodeError) Error() string {
+	template := "invalid two-factor authentication code"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e InvalidMFACodeError) Unwrap() error {
+	return e.BaseErr
+}
+
+type InvalidMFAChallengeError struct {
+	Message string
+	BaseErr error
+}
+
+func (e InvalidMFAChallengeError) Error() string {
+	template := "invalid or expired mfa challenge"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e InvalidMFAChallengeError) Unwrap() error {
+	return e.BaseErr
+}
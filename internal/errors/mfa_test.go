@@ -0,0 +1,172 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMFANotEnabledError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            MFANotEnabledError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            MFANotEnabledError{},
+			expectedString: "two-factor authentication is not enabled",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            MFANotEnabledError{Message: "totp is not confirmed"},
+			expectedString: "totp is not confirmed",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            MFANotEnabledError{BaseErr: errors.New("db error")},
+			expectedString: "two-factor authentication is not enabled. Base error: db error",
+			expectedBase:   errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
+
+func TestMFAAlreadyEnabledError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            MFAAlreadyEnabledError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            MFAAlreadyEnabledError{},
+			expectedString: "two-factor authentication is already enabled",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            MFAAlreadyEnabledError{Message: "totp is already confirmed"},
+			expectedString: "totp is already confirmed",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            MFAAlreadyEnabledError{BaseErr: errors.New("db error")},
+			expectedString: "two-factor authentication is already enabled. Base error: db error",
+			expectedBase:   errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
+
+func TestInvalidMFACodeError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            InvalidMFACodeError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            InvalidMFACodeError{},
+			expectedString: "invalid two-factor authentication code",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            InvalidMFACodeError{Message: "code has been already used"},
+			expectedString: "code has been already used",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            InvalidMFACodeError{BaseErr: errors.New("db error")},
+			expectedString: "invalid two-factor authentication code. Base error: db error",
+			expectedBase:   errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
+
+func TestInvalidMFAChallengeError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            InvalidMFAChallengeError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            InvalidMFAChallengeError{},
+			expectedString: "invalid or expired mfa challenge",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            InvalidMFAChallengeError{Message: "mfa challenge has been already used"},
+			expectedString: "mfa challenge has been already used",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            InvalidMFAChallengeError{BaseErr: errors.New("db error")},
+			expectedString: "invalid or expired mfa challenge. Base error: db error",
+			expectedBase:   errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
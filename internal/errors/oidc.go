@@ -0,0 +1,62 @@
+package errors
+
+import "fmt"
+
+// Error codes of OAuth 2.0 (RFC 6749) and OpenID Connect, which are returned to clients.
+const (
+	InvalidRequestOAuthErrorCode          = "invalid_request"
+	InvalidClientOAuthErrorCode           = "invalid_client"
+	InvalidGrantOAuthErrorCode            = "invalid_grant"
+	UnauthorizedClientOAuthErrorCode      = "unauthorized_client"
+	InvalidScopeOAuthErrorCode            = "invalid_scope"
+	UnsupportedGrantTypeOAuthErrorCode    = "unsupported_grant_type"
+	UnsupportedResponseTypeOAuthErrorCode = "unsupported_response_type"
+	LoginRequiredOAuthErrorCode           = "login_required"
+)
+
+// OAuthError is error of authorization or token request. Code is one of OAuth error codes
+// and Message is its human-readable description.
+type OAuthError struct {
+	Code    string
+	Message string
+	BaseErr error
+}
+
+func (e OAuthError) Error() string {
+	template := e.Code
+	if e.Message != "" {
+		template = fmt.Sprintf(template+": %s", e.Message)
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e OAuthError) Unwrap() error {
+	return e.BaseErr
+}
+
+type InvalidAuthorizationCodeError struct {
+	Message string
+	BaseErr error
+}
+
+func (e InvalidAuthorizationCodeError) Error() string {
+	template := "authorization code is invalid or expired"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e InvalidAuthorizationCodeError) Unwrap() error {
+	return e.BaseErr
+}
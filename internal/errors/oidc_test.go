@@ -0,0 +1,94 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOAuthError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            OAuthError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "code only",
+			err:            OAuthError{Code: InvalidRequestOAuthErrorCode},
+			expectedString: "invalid_request",
+			expectedBase:   nil,
+		},
+		{
+			name:           "code with message",
+			err:            OAuthError{Code: InvalidGrantOAuthErrorCode, Message: "code verifier does not match"},
+			expectedString: "invalid_grant: code verifier does not match",
+			expectedBase:   nil,
+		},
+		{
+			name: "code with message and base error",
+			err: OAuthError{
+				Code:    InvalidGrantOAuthErrorCode,
+				Message: "invalid code",
+				BaseErr: errors.New("db error"),
+			},
+			expectedString: "invalid_grant: invalid code. Base error: db error",
+			expectedBase:   errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
+
+func TestInvalidAuthorizationCodeError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            InvalidAuthorizationCodeError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            InvalidAuthorizationCodeError{},
+			expectedString: "authorization code is invalid or expired",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            InvalidAuthorizationCodeError{Message: "code has been used"},
+			expectedString: "code has been used",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            InvalidAuthorizationCodeError{BaseErr: errors.New("db error")},
+			expectedString: "authorization code is invalid or expired. Base error: db error",
+			expectedBase:   errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package errors
+
+import "fmt"
+
+type PhoneIsNotSetError struct {
+	Message string
+	BaseErr error
+}
+
+func (e PhoneIsNotSetError) Error() string {
+	template := "phone is not set"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e PhoneIsNotSetError) Unwrap() error {
+	return e.BaseErr
+}
+
+type PhoneAlreadyConfirmedError struct {
+	Message string
+	BaseErr error
+}
+
+func (e PhoneAlreadyConfirmedError) Error() string {
+	template := "provided phone has been already confirmed"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e PhoneAlreadyConfirmedError) Unwrap() error {
+	return e.BaseErr
+}
+
+type InvalidPhoneVerificationCodeError struct {
+	Message string
+	BaseErr error
+}
+
+func (e InvalidPhoneVerificationCodeError) Error() string {
+	template := "phone verification code is invalid or expired"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e InvalidPhoneVerificationCodeError) Unwrap() error {
+	return e.BaseErr
+}
@@ -0,0 +1,131 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhoneIsNotSetError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            PhoneIsNotSetError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            PhoneIsNotSetError{},
+			expectedString: "phone is not set",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            PhoneIsNotSetError{Message: "user with ID=1 has no phone"},
+			expectedString: "user with ID=1 has no phone",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            PhoneIsNotSetError{BaseErr: errors.New("user not found")},
+			expectedString: "phone is not set. Base error: user not found",
+			expectedBase:   errors.New("user not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
+
+func TestPhoneAlreadyConfirmedError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            PhoneAlreadyConfirmedError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            PhoneAlreadyConfirmedError{},
+			expectedString: "provided phone has been already confirmed",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            PhoneAlreadyConfirmedError{Message: "phone +79991234567 already verified"},
+			expectedString: "phone +79991234567 already verified",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            PhoneAlreadyConfirmedError{BaseErr: errors.New("db error")},
+			expectedString: "provided phone has been already confirmed. Base error: db error",
+			expectedBase:   errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
+
+func TestInvalidPhoneVerificationCodeError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            InvalidPhoneVerificationCodeError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            InvalidPhoneVerificationCodeError{},
+			expectedString: "phone verification code is invalid or expired",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            InvalidPhoneVerificationCodeError{Message: "too many attempts to verify phone"},
+			expectedString: "too many attempts to verify phone",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            InvalidPhoneVerificationCodeError{BaseErr: errors.New("sql: no rows in result set")},
+			expectedString: "phone verification code is invalid or expired. Base error: sql: no rows in result set",
+			expectedBase:   errors.New("sql: no rows in result set"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package errors
+
+import "fmt"
+
+type InvalidTelegramAuthError struct {
+	Message string
+	BaseErr error
+}
+
+func (e InvalidTelegramAuthError) Error() string {
+	template := "invalid telegram auth data"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e InvalidTelegramAuthError) Unwrap() error {
+	return e.BaseErr
+}
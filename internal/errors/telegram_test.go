@@ -0,0 +1,49 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvalidTelegramAuthError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            InvalidTelegramAuthError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            InvalidTelegramAuthError{},
+			expectedString: "invalid telegram auth data",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            InvalidTelegramAuthError{Message: "telegram auth data has already been used"},
+			expectedString: "telegram auth data has already been used",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            InvalidTelegramAuthError{BaseErr: errors.New("hash mismatch")},
+			expectedString: "invalid telegram auth data. Base error: hash mismatch",
+			expectedBase:   errors.New("hash mismatch"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
@@ -23,3 +23,91 @@ func (e AccessTokenDoesNotBelongToRefreshTokenError) Error() string {
 func (e AccessTokenDoesNotBelongToRefreshTokenError) Unwrap() error {
 	return e.BaseErr
 }
+
+type InvalidVerifyEmailTokenError struct {
+	Message string
+	BaseErr error
+}
+
+func (e InvalidVerifyEmailTokenError) Error() string {
+	template := "verify-email token is invalid or expired"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e InvalidVerifyEmailTokenError) Unwrap() error {
+	return e.BaseErr
+}
+
+type InvalidForgetPasswordTokenError struct {
+	Message string
+	BaseErr error
+}
+
+func (e InvalidForgetPasswordTokenError) Error() string {
+	template := "forget-password token is invalid or expired"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e InvalidForgetPasswordTokenError) Unwrap() error {
+	return e.BaseErr
+}
+
+type RefreshTokenReuseDetectedError struct {
+	Message string
+	BaseErr error
+}
+
+func (e RefreshTokenReuseDetectedError) Error() string {
+	template := "refresh token has been already used"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e RefreshTokenReuseDetectedError) Unwrap() error {
+	return e.BaseErr
+}
+
+type InvalidLoginTokenError struct {
+	Message string
+	BaseErr error
+}
+
+func (e InvalidLoginTokenError) Error() string {
+	template := "login token is invalid or expired"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e InvalidLoginTokenError) Unwrap() error {
+	return e.BaseErr
+}
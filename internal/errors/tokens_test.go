@@ -66,3 +66,207 @@ func TestAccessTokenDoesNotBelongToRefreshTokenError(t *testing.T) {
 		})
 	}
 }
+
+func TestInvalidVerifyEmailTokenError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            InvalidVerifyEmailTokenError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            InvalidVerifyEmailTokenError{},
+			expectedString: "verify-email token is invalid or expired",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            InvalidVerifyEmailTokenError{Message: "token was already used"},
+			expectedString: "token was already used",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            InvalidVerifyEmailTokenError{BaseErr: errors.New("no rows")},
+			expectedString: "verify-email token is invalid or expired. Base error: no rows",
+			expectedBase:   errors.New("no rows"),
+		},
+		{
+			name:           "custom message, with base error",
+			err:            InvalidVerifyEmailTokenError{Message: "token was already used", BaseErr: errors.New("no rows")},
+			expectedString: "token was already used. Base error: no rows",
+			expectedBase:   errors.New("no rows"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+
+			var err interface{} = tc.err
+			_, ok := err.(error)
+			require.True(t, ok, "InvalidVerifyEmailTokenError should implement error interface")
+		})
+	}
+}
+
+func TestInvalidForgetPasswordTokenError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            InvalidForgetPasswordTokenError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            InvalidForgetPasswordTokenError{},
+			expectedString: "forget-password token is invalid or expired",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            InvalidForgetPasswordTokenError{Message: "token was already used"},
+			expectedString: "token was already used",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            InvalidForgetPasswordTokenError{BaseErr: errors.New("no rows")},
+			expectedString: "forget-password token is invalid or expired. Base error: no rows",
+			expectedBase:   errors.New("no rows"),
+		},
+		{
+			name:           "custom message, with base error",
+			err:            InvalidForgetPasswordTokenError{Message: "token was already used", BaseErr: errors.New("no rows")},
+			expectedString: "token was already used. Base error: no rows",
+			expectedBase:   errors.New("no rows"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+
+			var err interface{} = tc.err
+			_, ok := err.(error)
+			require.True(t, ok, "InvalidForgetPasswordTokenError should implement error interface")
+		})
+	}
+}
+
+func TestRefreshTokenReuseDetectedError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            RefreshTokenReuseDetectedError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            RefreshTokenReuseDetectedError{},
+			expectedString: "refresh token has been already used",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            RefreshTokenReuseDetectedError{Message: "token family was revoked"},
+			expectedString: "token family was revoked",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            RefreshTokenReuseDetectedError{BaseErr: errors.New("no rows")},
+			expectedString: "refresh token has been already used. Base error: no rows",
+			expectedBase:   errors.New("no rows"),
+		},
+		{
+			name:           "custom message, with base error",
+			err:            RefreshTokenReuseDetectedError{Message: "token family was revoked", BaseErr: errors.New("no rows")},
+			expectedString: "token family was revoked. Base error: no rows",
+			expectedBase:   errors.New("no rows"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+
+			var err interface{} = tc.err
+			_, ok := err.(error)
+			require.True(t, ok, "RefreshTokenReuseDetectedError should implement error interface")
+		})
+	}
+}
+
+func TestInvalidLoginTokenError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            InvalidLoginTokenError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            InvalidLoginTokenError{},
+			expectedString: "login token is invalid or expired",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            InvalidLoginTokenError{Message: "token was already used"},
+			expectedString: "token was already used",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            InvalidLoginTokenError{BaseErr: errors.New("no rows")},
+			expectedString: "login token is invalid or expired. Base error: no rows",
+			expectedBase:   errors.New("no rows"),
+		},
+		{
+			name:           "custom message, with base error",
+			err:            InvalidLoginTokenError{Message: "token was already used", BaseErr: errors.New("no rows")},
+			expectedString: "token was already used. Base error: no rows",
+			expectedBase:   errors.New("no rows"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+
+			var err interface{} = tc.err
+			_, ok := err.(error)
+			require.True(t, ok, "InvalidLoginTokenError should implement error interface")
+		})
+	}
+}
@@ -23,3 +23,25 @@ func (e UserNotFoundError) Error() string {
 func (e UserNotFoundError) Unwrap() error {
 	return e.BaseErr
 }
+
+type UserBlockedError struct {
+	Message string
+	BaseErr error
+}
+
+func (e UserBlockedError) Error() string {
+	template := "user has been blocked"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e UserBlockedError) Unwrap() error {
+	return e.BaseErr
+}
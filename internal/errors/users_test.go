@@ -66,3 +66,63 @@ func TestUserNotFoundError(t *testing.T) {
 		})
 	}
 }
+
+func TestUserBlockedError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            UserBlockedError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            UserBlockedError{},
+			expectedString: "user has been blocked",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            UserBlockedError{Message: "user with ID=1 has been blocked"},
+			expectedString: "user with ID=1 has been blocked",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            UserBlockedError{BaseErr: errors.New("database error")},
+			expectedString: "user has been blocked. Base error: database error",
+			expectedBase:   errors.New("database error"),
+		},
+		{
+			name:           "custom message, with base error",
+			err:            UserBlockedError{Message: "user with ID=1 has been blocked", BaseErr: errors.New("database error")},
+			expectedString: "user with ID=1 has been blocked. Base error: database error",
+			expectedBase:   errors.New("database error"),
+		},
+		{
+			name:           "empty message, with base error",
+			err:            UserBlockedError{Message: "", BaseErr: errors.New("database error")},
+			expectedString: "user has been blocked. Base error: database error",
+			expectedBase:   errors.New("database error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Проверка строки ошибки
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			// Проверка базовой ошибки через Unwrap
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+
+			// Проверка, что ошибка реализует интерфейс error
+			var err interface{} = tc.err
+			_, ok := err.(error)
+			require.True(t, ok, "UserBlockedError should implement error interface")
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package errors
+
+import "fmt"
+
+type InvalidWebAuthnChallengeError struct {
+	Message string
+	BaseErr error
+}
+
+func (e InvalidWebAuthnChallengeError) Error() string {
+	template := "invalid or expired webauthn challenge"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e InvalidWebAuthnChallengeError) Unwrap() error {
+	return e.BaseErr
+}
+
+type InvalidWebAuthnCredentialError struct {
+	Message string
+	BaseErr error
+}
+
+func (e InvalidWebAuthnCredentialError) Error() string {
+	template := "invalid webauthn credential"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e InvalidWebAuthnCredentialError) Unwrap() error {
+	return e.BaseErr
+}
+
+type WebAuthnCredentialNotFoundError struct {
+	Message string
+	BaseErr error
+}
+
+func (e WebAuthnCredentialNotFoundError) Error() string {
+	template := "webauthn credential not found"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e WebAuthnCredentialNotFoundError) Unwrap() error {
+	return e.BaseErr
+}
@@ -0,0 +1,131 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvalidWebAuthnChallengeError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            InvalidWebAuthnChallengeError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            InvalidWebAuthnChallengeError{},
+			expectedString: "invalid or expired webauthn challenge",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            InvalidWebAuthnChallengeError{Message: "challenge was issued for another ceremony"},
+			expectedString: "challenge was issued for another ceremony",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            InvalidWebAuthnChallengeError{BaseErr: errors.New("db error")},
+			expectedString: "invalid or expired webauthn challenge. Base error: db error",
+			expectedBase:   errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
+
+func TestInvalidWebAuthnCredentialError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            InvalidWebAuthnCredentialError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            InvalidWebAuthnCredentialError{},
+			expectedString: "invalid webauthn credential",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            InvalidWebAuthnCredentialError{Message: "invalid signature"},
+			expectedString: "invalid signature",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            InvalidWebAuthnCredentialError{BaseErr: errors.New("db error")},
+			expectedString: "invalid webauthn credential. Base error: db error",
+			expectedBase:   errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
+
+func TestWebAuthnCredentialNotFoundError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            WebAuthnCredentialNotFoundError
+		expectedString string
+		expectedBase   error
+	}{
+		{
+			name:           "default message, no base error",
+			err:            WebAuthnCredentialNotFoundError{},
+			expectedString: "webauthn credential not found",
+			expectedBase:   nil,
+		},
+		{
+			name:           "custom message, no base error",
+			err:            WebAuthnCredentialNotFoundError{Message: "passkey not found"},
+			expectedString: "passkey not found",
+			expectedBase:   nil,
+		},
+		{
+			name:           "default message, with base error",
+			err:            WebAuthnCredentialNotFoundError{BaseErr: errors.New("db error")},
+			expectedString: "webauthn credential not found. Base error: db error",
+			expectedBase:   errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedString, tc.err.Error())
+
+			baseErr := tc.err.Unwrap()
+			if tc.expectedBase == nil {
+				require.Nil(t, baseErr)
+			} else {
+				require.Equal(t, tc.expectedBase.Error(), baseErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+// Package federationtest provides stub OAuth 2.0 identity provider, which issues authorization codes
+// without browser, so federated login can be tested offline.
+package federationtest
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/DKhorkov/hmtm-sso/internal/config"
+)
+
+const (
+	tokenPath    = "/token"
+	userInfoPath = "/userinfo"
+
+	authScheme = "Bearer"
+)
+
+// IdentityProvider is local identity provider, which checks client credentials, authorization codes
+// and PKCE verifiers the same way as real providers do.
+type IdentityProvider struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+
+	server *httptest.Server
+	mu     sync.Mutex
+	grants map[string]grant          // authorization code -> grant
+	tokens map[string]map[string]any // access token -> user info
+}
+
+type grant struct {
+	codeChallenge string
+	userInfo      map[string]any
+}
+
+// New starts IdentityProvider. Server must be stopped with Close.
+func New(clientID, clientSecret, redirectURL string) *IdentityProvider {
+	provider := &IdentityProvider{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		grants:       make(map[string]grant),
+		tokens:       make(map[string]map[string]any),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(tokenPath, provider.token)
+	mux.HandleFunc(userInfoPath, provider.userInfo)
+	provider.server = httptest.NewServer(mux)
+
+	return provider
+}
+
+// Config returns configuration of provider with OpenID Connect standard claims.
+func (provider *IdentityProvider) Config(name string) config.IdentityProviderConfig {
+	return config.IdentityProviderConfig{
+		Name:               name,
+		ClientID:           provider.ClientID,
+		ClientSecret:       provider.ClientSecret,
+		RedirectURL:        provider.RedirectURL,
+		AuthURL:            provider.server.URL + "/authorize",
+		TokenURL:           provider.server.URL + tokenPath,
+		UserInfoURL:        provider.server.URL + userInfoPath,
+		Scopes:             []string{"openid", "email", "profile"},
+		UserInfoAuthScheme: authScheme,
+		SubjectClaim:       "sub",
+		EmailClaim:         "email",
+		EmailVerifiedClaim: "email_verified",
+		NameClaim:          "name",
+	}
+}
+
+// Client returns HTTP client of provider's server.
+func (provider *IdentityProvider) Client() *http.Client {
+	return provider.server.Client()
+}
+
+// Close stops provider's server.
+func (provider *IdentityProvider) Close() {
+	provider.server.Close()
+}
+
+// Authorize emulates consent of User and returns authorization code, which is bound to provided
+// S256 code challenge. Provided user info is returned by user info endpoint after code exchange.
+func (provider *IdentityProvider) Authorize(codeChallenge string, userInfo map[string]any) string {
+	code := randomString()
+
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+
+	provider.grants[code] = grant{codeChallenge: codeChallenge, userInfo: userInfo}
+
+	return code
+}
+
+func (provider *IdentityProvider) token(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost || request.ParseForm() != nil {
+		writeError(writer, http.StatusBadRequest, "invalid_request")
+		return
+	}
+
+	if request.PostForm.Get("client_id") != provider.ClientID ||
+		request.PostForm.Get("client_secret") != provider.ClientSecret {
+		writeError(writer, http.StatusUnauthorized, "invalid_client")
+		return
+	}
+
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+
+	code := request.PostForm.Get("code")
+	codeGrant, ok := provider.grants[code]
+	if !ok ||
+		request.PostForm.Get("grant_type") != "authorization_code" ||
+		request.PostForm.Get("redirect_uri") != provider.RedirectURL {
+		writeError(writer, http.StatusBadRequest, "invalid_grant")
+		return
+	}
+
+	// Authorization code is one-time, even if exchange failed:
+	delete(provider.grants, code)
+
+	verifierHash := sha256.Sum256([]byte(request.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(verifierHash[:]) != codeGrant.codeChallenge {
+		writeError(writer, http.StatusBadRequest, "invalid_grant")
+		return
+	}
+
+	accessToken := randomString()
+	provider.tokens[accessToken] = codeGrant.userInfo
+
+	writeJSON(writer, http.StatusOK, map[string]any{
+		"access_token": accessToken,
+		"token_type":   authScheme,
+		"expires_in":   3600,
+	})
+}
+
+func (provider *IdentityProvider) userInfo(writer http.ResponseWriter, request *http.Request) {
+	authorization := request.Header.Get("Authorization")
+	prefix := authScheme + " "
+	if len(authorization) <= len(prefix) || authorization[:len(prefix)] != prefix {
+		writeError(writer, http.StatusUnauthorized, "invalid_token")
+		return
+	}
+
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+
+	userInfo, ok := provider.tokens[authorization[len(prefix):]]
+	if !ok {
+		writeError(writer, http.StatusUnauthorized, "invalid_token")
+		return
+	}
+
+	writeJSON(writer, http.StatusOK, userInfo)
+}
+
+func writeError(writer http.ResponseWriter, status int, code string) {
+	writeJSON(writer, status, map[string]string{"error": code})
+}
+
+func writeJSON(writer http.ResponseWriter, status int, body any) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	_ = json.NewEncoder(writer).Encode(body)
+}
+
+func randomString() string {
+	value := make([]byte, 16)
+	_, _ = rand.Read(value)
+
+	return base64.RawURLEncoding.EncodeToString(value)
+}
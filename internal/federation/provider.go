@@ -0,0 +1,240 @@
+// Package federation implements login via external OAuth 2.0 and OpenID Connect providers with authorization
+// code flow and S256 PKCE. Identity of User is received from user info endpoint of provider.
+package federation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/DKhorkov/hmtm-sso/internal/config"
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+)
+
+const (
+	claimPathSeparator = "."
+
+	// Responses of provider are limited to protect from misbehaving upstreams:
+	maxResponseSize = 1 << 20
+)
+
+var errSubjectIsMissing = errors.New("subject is missing in user info")
+
+func New(providerConfig config.IdentityProviderConfig, httpClient *http.Client) *Provider {
+	return &Provider{
+		config:     providerConfig,
+		httpClient: httpClient,
+	}
+}
+
+// Provider is generic OAuth 2.0 or OpenID Connect provider, which is configured by its endpoints and claims.
+type Provider struct {
+	config     config.IdentityProviderConfig
+	httpClient *http.Client
+}
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (provider *Provider) Name() string {
+	return provider.config.Name
+}
+
+// AuthCodeURL returns URL of provider, which User should be redirected to for authorization.
+func (provider *Provider) AuthCodeURL(state, codeChallenge string) string {
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", provider.config.ClientID)
+	params.Set("redirect_uri", provider.config.RedirectURL)
+	params.Set("state", state)
+	params.Set("code_challenge", codeChallenge)
+	params.Set("code_challenge_method", entities.S256CodeChallengeMethod)
+
+	if len(provider.config.Scopes) > 0 {
+		params.Set("scope", strings.Join(provider.config.Scopes, " "))
+	}
+
+	separator := "?"
+	if strings.Contains(provider.config.AuthURL, "?") {
+		separator = "&"
+	}
+
+	return provider.config.AuthURL + separator + params.Encode()
+}
+
+// Exchange exchanges authorization code for access token of provider and returns identity of User,
+// who has authorized SSO.
+func (provider *Provider) Exchange(
+	ctx context.Context,
+	code string,
+	codeVerifier string,
+) (*entities.ExternalIdentity, error) {
+	accessToken, err := provider.exchangeCode(ctx, code, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	userInfo, err := provider.getUserInfo(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &entities.ExternalIdentity{
+		Provider: provider.config.Name,
+		Subject:  getStringClaim(userInfo, provider.config.SubjectClaim),
+		Email:    strings.ToLower(getStringClaim(userInfo, provider.config.EmailClaim)),
+		Name:     getStringClaim(userInfo, provider.config.NameClaim),
+	}
+
+	if identity.Subject == "" {
+		return nil, errSubjectIsMissing
+	}
+
+	// Some providers return only verified emails and do not report verification status:
+	if provider.config.EmailVerifiedClaim == "" {
+		identity.EmailVerified = identity.Email != ""
+	} else {
+		identity.EmailVerified = getBoolClaim(userInfo, provider.config.EmailVerifiedClaim)
+	}
+
+	return identity, nil
+}
+
+// exchangeCode sends token request with client credentials in form parameters (client_secret_post),
+// which are supported by most providers.
+func (provider *Provider) exchangeCode(ctx context.Context, code, codeVerifier string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", entities.AuthorizationCodeGrantType)
+	form.Set("code", code)
+	form.Set("redirect_uri", provider.config.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+	form.Set("client_id", provider.config.ClientID)
+	form.Set("client_secret", provider.config.ClientSecret)
+
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		provider.config.TokenURL,
+		strings.NewReader(form.Encode()),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+
+	var response tokenResponse
+	if err = provider.do(request, &response); err != nil {
+		if response.Error != "" {
+			return "", fmt.Errorf("token request failed with %s: %s", response.Error, response.ErrorDescription)
+		}
+
+		return "", err
+	}
+
+	if response.AccessToken == "" {
+		return "", errors.New("access token is missing in token response")
+	}
+
+	return response.AccessToken, nil
+}
+
+func (provider *Provider) getUserInfo(ctx context.Context, accessToken string) (map[string]any, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, provider.config.UserInfoURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := provider.config.UserInfoAuthScheme
+	if scheme == "" {
+		scheme = entities.BearerTokenType
+	}
+
+	request.Header.Set("Authorization", scheme+" "+accessToken)
+	request.Header.Set("Accept", "application/json")
+
+	var userInfo map[string]any
+	if err = provider.do(request, &userInfo); err != nil {
+		return nil, err
+	}
+
+	return userInfo, nil
+}
+
+// do sends request to provider and decodes JSON response. Error responses are also decoded,
+// so OAuth error can be reported.
+func (provider *Provider) do(request *http.Request, result any) error {
+	response, err := provider.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxResponseSize))
+	if err != nil {
+		return err
+	}
+
+	decodeErr := json.Unmarshal(body, result)
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s responded with status %d", request.Method, request.URL.Path, response.StatusCode)
+	}
+
+	return decodeErr
+}
+
+// getClaim returns value of claim, which can be nested into objects, for example "response.user.id".
+func getClaim(claims map[string]any, path string) any {
+	if path == "" {
+		return nil
+	}
+
+	var value any = claims
+	for _, key := range strings.Split(path, claimPathSeparator) {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		value = object[key]
+	}
+
+	return value
+}
+
+// getStringClaim returns claim as string. Numeric IDs are formatted without exponent.
+func getStringClaim(claims map[string]any, path string) string {
+	switch value := getClaim(claims, path).(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
+
+// getBoolClaim returns claim as bool. Some providers return booleans as strings.
+func getBoolClaim(claims map[string]any, path string) bool {
+	switch value := getClaim(claims, path).(type) {
+	case bool:
+		return value
+	case string:
+		verified, err := strconv.ParseBool(value)
+		return err == nil && verified
+	default:
+		return false
+	}
+}
@@ -0,0 +1,183 @@
+package federation_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DKhorkov/hmtm-sso/internal/config"
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	"github.com/DKhorkov/hmtm-sso/internal/federation"
+	"github.com/DKhorkov/hmtm-sso/internal/federation/federationtest"
+)
+
+const (
+	providerName = "stub"
+	clientID     = "hmtm-sso"
+	clientSecret = "secret"
+	redirectURL  = "http://localhost:8080/login/stub/callback"
+	codeVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+)
+
+func codeChallenge(verifier string) string {
+	hash := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func newIdentityProvider(t *testing.T) *federationtest.IdentityProvider {
+	t.Helper()
+
+	identityProvider := federationtest.New(clientID, clientSecret, redirectURL)
+	t.Cleanup(identityProvider.Close)
+
+	return identityProvider
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	identityProvider := newIdentityProvider(t)
+	provider := federation.New(identityProvider.Config(providerName), identityProvider.Client())
+	require.Equal(t, providerName, provider.Name())
+
+	authCodeURL, err := url.Parse(provider.AuthCodeURL("state", codeChallenge(codeVerifier)))
+	require.NoError(t, err)
+
+	params := authCodeURL.Query()
+	require.Equal(t, "code", params.Get("response_type"))
+	require.Equal(t, clientID, params.Get("client_id"))
+	require.Equal(t, redirectURL, params.Get("redirect_uri"))
+	require.Equal(t, "openid email profile", params.Get("scope"))
+	require.Equal(t, "state", params.Get("state"))
+	require.Equal(t, codeChallenge(codeVerifier), params.Get("code_challenge"))
+	require.Equal(t, entities.S256CodeChallengeMethod, params.Get("code_challenge_method"))
+
+	providerConfig := identityProvider.Config(providerName)
+	providerConfig.AuthURL = "https://login.example.com/info?format=json"
+	authCodeURL, err = url.Parse(federation.New(providerConfig, nil).AuthCodeURL("state", "challenge"))
+	require.NoError(t, err)
+	require.Equal(t, "json", authCodeURL.Query().Get("format"))
+	require.Equal(t, "state", authCodeURL.Query().Get("state"))
+}
+
+func TestExchange(t *testing.T) {
+	identityProvider := newIdentityProvider(t)
+
+	testCases := []struct {
+		name          string
+		configure     func(providerConfig *config.IdentityProviderConfig)
+		userInfo      map[string]any
+		verifier      string
+		expected      *entities.ExternalIdentity
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			userInfo: map[string]any{
+				"sub":            "108",
+				"email":          "User@Example.com",
+				"email_verified": true,
+				"name":           "Иван",
+			},
+			verifier: codeVerifier,
+			expected: &entities.ExternalIdentity{
+				Provider:      providerName,
+				Subject:       "108",
+				Email:         "user@example.com",
+				EmailVerified: true,
+				Name:          "Иван",
+			},
+		},
+		{
+			name: "email verification as string",
+			userInfo: map[string]any{
+				"sub":            "108",
+				"email":          "user@example.com",
+				"email_verified": "false",
+			},
+			verifier: codeVerifier,
+			expected: &entities.ExternalIdentity{
+				Provider: providerName,
+				Subject:  "108",
+				Email:    "user@example.com",
+			},
+		},
+		{
+			name: "nested numeric subject without verification claim",
+			configure: func(providerConfig *config.IdentityProviderConfig) {
+				providerConfig.SubjectClaim = "user.id"
+				providerConfig.EmailClaim = "user.email"
+				providerConfig.EmailVerifiedClaim = ""
+			},
+			userInfo: map[string]any{
+				"user": map[string]any{
+					"id":    1130000012345678,
+					"email": "user@example.com",
+				},
+			},
+			verifier: codeVerifier,
+			expected: &entities.ExternalIdentity{
+				Provider:      providerName,
+				Subject:       "1130000012345678",
+				Email:         "user@example.com",
+				EmailVerified: true,
+			},
+		},
+		{
+			name:          "subject is missing",
+			userInfo:      map[string]any{"email": "user@example.com"},
+			verifier:      codeVerifier,
+			errorExpected: true,
+		},
+		{
+			name:          "invalid code verifier",
+			userInfo:      map[string]any{"sub": "108"},
+			verifier:      "invalid",
+			errorExpected: true,
+		},
+		{
+			name: "invalid client secret",
+			configure: func(providerConfig *config.IdentityProviderConfig) {
+				providerConfig.ClientSecret = "invalid"
+			},
+			userInfo:      map[string]any{"sub": "108"},
+			verifier:      codeVerifier,
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			providerConfig := identityProvider.Config(providerName)
+			if tc.configure != nil {
+				tc.configure(&providerConfig)
+			}
+
+			provider := federation.New(providerConfig, identityProvider.Client())
+			code := identityProvider.Authorize(codeChallenge(codeVerifier), tc.userInfo)
+
+			identity, err := provider.Exchange(context.Background(), code, tc.verifier)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Nil(t, identity)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expected, identity)
+			}
+		})
+	}
+}
+
+func TestExchangeCodeIsOneTime(t *testing.T) {
+	identityProvider := newIdentityProvider(t)
+	provider := federation.New(identityProvider.Config(providerName), identityProvider.Client())
+	code := identityProvider.Authorize(codeChallenge(codeVerifier), map[string]any{"sub": "108"})
+
+	_, err := provider.Exchange(context.Background(), code, codeVerifier)
+	require.NoError(t, err)
+
+	_, err = provider.Exchange(context.Background(), code, codeVerifier)
+	require.Error(t, err)
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/DKhorkov/libs/cache"
+)
+
+//go:generate mockgen -source=cache.go -destination=../../mocks/cache/cache.go -package=mockcache
+type CacheProvider interface {
+	cache.Provider
+	IncrWithTTL(ctx context.Context, key string, ttl time.Duration) (int64, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+)
+
+// IdentityProvider is external OAuth 2.0 or OpenID Connect provider, which Users can log in with.
+//
+//go:generate mockgen -source=federation.go -destination=../../mocks/federation/identity_provider.go -package=mockfederation
+type IdentityProvider interface {
+	Name() string
+	AuthCodeURL(state, codeChallenge string) string
+	Exchange(ctx context.Context, code, codeVerifier string) (*entities.ExternalIdentity, error)
+}
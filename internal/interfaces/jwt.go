@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+)
+
+//go:generate mockgen -source=jwt.go -destination=../../mocks/jwt/jwt_provider.go -package=mockjwt
+type JWTProvider interface {
+	Sign(claims jwt.Claims) (string, error)
+	Parse(token string, claims jwt.Claims, opts ...jwt.ParserOption) error
+	GetJWKS() entities.JWKS
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/DKhorkov/hmtm-sso/internal/limiter"
+)
+
+//go:generate mockgen -source=limiter.go -destination=../../mocks/limiter/limiter.go -package=mocklimiter
+type RateLimiter interface {
+	Allow(ctx context.Context, key string, policy limiter.Policy) (limiter.Result, error)
+}
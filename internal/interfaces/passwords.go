@@ -0,0 +1,9 @@
+package interfaces
+
+//go:generate mockgen -source=passwords.go -destination=../../mocks/passwords/passwords.go -package=mockpasswords
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(password, hash string) bool
+	Supports(hash string) bool
+	NeedsRehash(hash string) bool
+}
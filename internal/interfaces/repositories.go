@@ -2,7 +2,6 @@ package interfaces
 
 import (
 	"context"
-	"time"
 
 	"github.com/DKhorkov/hmtm-sso/internal/entities"
 )
@@ -12,21 +11,91 @@ type UsersRepository interface {
 	GetUserByID(ctx context.Context, id uint64) (*entities.User, error)
 	GetUsers(ctx context.Context, pagination *entities.Pagination) ([]entities.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*entities.User, error)
+	GetUserByPhone(ctx context.Context, phone string) (*entities.User, error)
+	GetUserByTelegram(ctx context.Context, telegram string) (*entities.User, error)
+	IsUserBlocked(ctx context.Context, id uint64) (bool, error)
 	UpdateUserProfile(ctx context.Context, userProfileData entities.UpdateUserProfileDTO) error
 }
 
 //go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/auth_repository.go -package=mockrepositories -exclude_interfaces=UsersRepository
 type AuthRepository interface {
 	RegisterUser(ctx context.Context, userData entities.RegisterUserDTO) (userID uint64, err error)
+	ImportUser(ctx context.Context, userData entities.ImportUserDTO) error
+	CreateSession(ctx context.Context, sessionData entities.CreateSessionDTO) (sessionID uint64, err error)
 	CreateRefreshToken(
 		ctx context.Context,
-		userID uint64,
-		refreshToken string,
-		ttl time.Duration,
+		refreshTokenData entities.CreateRefreshTokenDTO,
 	) (refreshTokenID uint64, err error)
-	GetRefreshTokenByUserID(ctx context.Context, userID uint64) (*entities.RefreshToken, error)
+	GetRefreshTokenByValue(ctx context.Context, refreshToken string) (*entities.RefreshToken, error)
 	ExpireRefreshToken(ctx context.Context, refreshToken string) error
+	RotateRefreshToken(ctx context.Context, refreshTokenID uint64, familyID string) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+	GetUserSessions(ctx context.Context, userID uint64) ([]entities.Session, error)
+	ExpireSession(ctx context.Context, userID, sessionID uint64) error
+	ExpireUserSessions(ctx context.Context, userID, exceptSessionID uint64) error
+	CreateVerifyEmailToken(
+		ctx context.Context,
+		tokenData entities.CreateVerifyEmailTokenDTO,
+	) (verifyEmailTokenID uint64, err error)
+	GetVerifyEmailTokenByHash(ctx context.Context, tokenHash string) (*entities.VerifyEmailToken, error)
+	GetVerifyEmailTokenByUserID(ctx context.Context, userID uint64) (*entities.VerifyEmailToken, error)
 	VerifyUserEmail(ctx context.Context, userID uint64) error
-	ForgetPassword(ctx context.Context, userID uint64, newPassword string) error
+	ConfirmUserTelegram(ctx context.Context, userID uint64, telegram string) error
+	CreateForgetPasswordToken(
+		ctx context.Context,
+		tokenData entities.CreateForgetPasswordTokenDTO,
+	) (uint64, error)
+	GetForgetPasswordTokenByHash(ctx context.Context, tokenHash string) (*entities.ForgetPasswordToken, error)
+	ExpireForgetPasswordTokens(ctx context.Context, userID uint64) error
+	ForgetPassword(ctx context.Context, userID, forgetPasswordTokenID uint64, newPassword string) error
 	ChangePassword(ctx context.Context, userID uint64, newPassword string) error
+	CreateLoginToken(ctx context.Context, tokenData entities.CreateLoginTokenDTO) (loginTokenID uint64, err error)
+	GetLoginTokenByHash(ctx context.Context, tokenHash string) (*entities.LoginToken, error)
+	GetLoginTokenByUserID(ctx context.Context, userID uint64) (*entities.LoginToken, error)
+	UseLoginToken(ctx context.Context, userID, loginTokenID uint64) error
+	CreatePhoneVerificationCode(
+		ctx context.Context,
+		codeData entities.CreatePhoneVerificationCodeDTO,
+	) (phoneVerificationCodeID uint64, err error)
+	GetPhoneVerificationCodeByUserID(ctx context.Context, userID uint64) (*entities.PhoneVerificationCode, error)
+	VerifyUserPhone(ctx context.Context, userID, phoneVerificationCodeID uint64) error
+	CreateAuthorizationCode(
+		ctx context.Context,
+		codeData entities.CreateAuthorizationCodeDTO,
+	) (authorizationCodeID uint64, err error)
+	GetAuthorizationCodeByHash(ctx context.Context, codeHash string) (*entities.AuthorizationCode, error)
+	UseAuthorizationCode(ctx context.Context, authorizationCodeID uint64) error
+	CreateClient(ctx context.Context, clientData entities.CreateClientDTO) (clientID uint64, err error)
+	GetClientByClientID(ctx context.Context, clientID string) (*entities.Client, error)
+	GetClients(ctx context.Context) ([]entities.Client, error)
+	UpdateClient(ctx context.Context, clientData entities.UpdateClientSettingsDTO) error
+	DeleteClient(ctx context.Context, clientID string) error
+	CreateUserIdentity(ctx context.Context, identityData entities.CreateUserIdentityDTO) (identityID uint64, err error)
+	GetUserIdentity(ctx context.Context, provider, subject string) (*entities.UserIdentity, error)
+	CreateTOTPSecret(ctx context.Context, totpSecretData entities.CreateTOTPSecretDTO) (totpSecretID uint64, err error)
+	GetTOTPSecretByUserID(ctx context.Context, userID uint64) (*entities.TOTPSecret, error)
+	ConfirmTOTPSecret(ctx context.Context, confirmData entities.ConfirmTOTPSecretDTO) error
+	UseTOTPStep(ctx context.Context, totpSecretID uint64, step int64) error
+	UseRecoveryCode(ctx context.Context, userID uint64, codeHash string) error
+	DeleteTOTPSecret(ctx context.Context, userID uint64) error
+	CreateMFAChallenge(ctx context.Context, challengeData entities.CreateMFAChallengeDTO) (challengeID uint64, err error)
+	GetMFAChallengeByHash(ctx context.Context, tokenHash string) (*entities.MFAChallenge, error)
+	ExpireMFAChallenge(ctx context.Context, challengeID uint64) error
+	CreateWebAuthnCredential(
+		ctx context.Context,
+		credentialData entities.CreateWebAuthnCredentialDTO,
+	) (credentialID uint64, err error)
+	GetWebAuthnCredential(ctx context.Context, credentialID string) (*entities.WebAuthnCredential, error)
+	GetUserWebAuthnCredentials(ctx context.Context, userID uint64) ([]entities.WebAuthnCredential, error)
+	UpdateWebAuthnCredentialSignCount(ctx context.Context, credentialID uint64, signCount uint32) error
+	CreateWebAuthnChallenge(
+		ctx context.Context,
+		challengeData entities.CreateWebAuthnChallengeDTO,
+	) (challengeID uint64, err error)
+	GetWebAuthnChallengeByHash(
+		ctx context.Context,
+		challengeHash string,
+		ceremony string,
+	) (*entities.WebAuthnChallenge, error)
+	ExpireWebAuthnChallenge(ctx context.Context, challengeID uint64) error
 }
@@ -16,14 +16,50 @@ type UseCases interface {
 		ctx context.Context,
 		rawUserProfileData entities.RawUpdateUserProfileDTO,
 	) error
+	UnlockUser(ctx context.Context, accessToken string, userID uint64) error
+	ImportUsers(ctx context.Context, accessToken string, data []byte) (*entities.ImportUsersResult, error)
 
 	RegisterUser(ctx context.Context, userData entities.RegisterUserDTO) (userID uint64, err error)
 	LoginUser(ctx context.Context, userData entities.LoginUserDTO) (*entities.TokensDTO, error)
+	LoginWithCode(ctx context.Context, loginData entities.LoginWithCodeDTO) (*entities.TokensDTO, error)
+	CompleteMFALogin(ctx context.Context, loginData entities.CompleteMFALoginDTO) (*entities.TokensDTO, error)
+	StartTOTPEnrollment(ctx context.Context, accessToken string) (*entities.TOTPEnrollmentDTO, error)
+	ConfirmTOTPEnrollment(ctx context.Context, accessToken, code string) (recoveryCodes []string, err error)
+	DisableTOTP(ctx context.Context, accessToken, code string) error
+	BeginWebAuthnRegistration(ctx context.Context, accessToken string) (options string, err error)
+	FinishWebAuthnRegistration(ctx context.Context, registrationData entities.FinishWebAuthnRegistrationDTO) error
+	BeginWebAuthnLogin(ctx context.Context, email string) (options string, err error)
+	FinishWebAuthnLogin(ctx context.Context, loginData entities.FinishWebAuthnLoginDTO) (*entities.TokensDTO, error)
+	BeginFederatedLogin(ctx context.Context, provider string) (authorizationURL string, err error)
+	FinishFederatedLogin(ctx context.Context, loginData entities.FinishFederatedLoginDTO) (*entities.TokensDTO, error)
+	LoginWithTelegram(ctx context.Context, loginData entities.LoginWithTelegramDTO) (*entities.TokensDTO, error)
+	LinkTelegram(ctx context.Context, linkData entities.LinkTelegramDTO) error
+	SendPhoneVerificationCode(ctx context.Context, accessToken string) error
+	VerifyPhone(ctx context.Context, accessToken, code string) error
 	LogoutUser(ctx context.Context, accessToken string) error
+	LogoutUserEverywhere(ctx context.Context, accessToken string) error
+	GetUserSessions(ctx context.Context, accessToken string) (*entities.SessionsDTO, error)
+	RevokeSession(ctx context.Context, accessToken string, sessionID uint64) error
 	RefreshTokens(ctx context.Context, refreshToken string) (*entities.TokensDTO, error)
+	GetJWKS() entities.JWKS
+	GetOpenIDConfiguration() entities.OpenIDConfiguration
+	Authorize(ctx context.Context, authorizeData entities.AuthorizeDTO) (redirectURL string, err error)
+	ExchangeOIDCToken(ctx context.Context, tokenRequest entities.OIDCTokenRequestDTO) (*entities.OIDCTokensDTO, error)
+	GetUserInfo(ctx context.Context, accessToken string) (*entities.UserInfo, error)
+	RegisterClient(ctx context.Context, clientData entities.RegisterClientDTO) (*entities.RegisteredClientDTO, error)
+	GetClients(ctx context.Context, accessToken string) ([]entities.Client, error)
+	UpdateClient(ctx context.Context, clientData entities.UpdateClientDTO) error
+	DeleteClient(ctx context.Context, accessToken, clientID string) error
+	IssueClientToken(ctx context.Context, tokenRequest entities.IssueClientTokenDTO) (*entities.ClientTokenDTO, error)
+	VerifyUserToken(accessToken string) (*entities.AccessTokenPayload, error)
+	VerifyClientToken(accessToken string) (*entities.ClientTokenPayload, error)
+	GetUserPhone(ctx context.Context, accessToken string) (string, error)
+	IntrospectToken(ctx context.Context, token string) (*entities.TokenIntrospection, error)
 	VerifyUserEmail(ctx context.Context, verifyEmailToken string) error
+	VerifyUserEmailByCode(ctx context.Context, email, code string) error
 	ForgetPassword(ctx context.Context, forgetPasswordToken, newPassword string) error
 	SendForgetPasswordMessage(ctx context.Context, email string) error
+	SendLoginLink(ctx context.Context, email string) error
 	ChangePassword(ctx context.Context, accessToken, oldPassword, newPassword string) error
 	SendVerifyEmailMessage(ctx context.Context, email string) error
 }
@@ -0,0 +1,111 @@
+// Package limiter limits rate of actions with sliding window counters, which are stored in cache.
+// Counters are shared by all instances of service, which use the same cache.
+package limiter
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const cachePrefix = "rate-limit"
+
+// Policy allows Limit actions during Window. Policy without limit or window does not limit anything.
+type Policy struct {
+	Limit  int64
+	Window time.Duration
+}
+
+// Result of counting action. RetryAfter is set only for actions, which are not allowed.
+type Result struct {
+	Allowed    bool
+	RetryAfter time.Duration
+}
+
+// Counters stores counters of fixed windows. Counter gets TTL in the same atomic operation, which creates it,
+// so concurrent increments are not lost and counter does not stay in cache after its windows.
+type Counters interface {
+	Get(ctx context.Context, key string) (string, error)
+	IncrWithTTL(ctx context.Context, key string, ttl time.Duration) (int64, error)
+}
+
+func New(counters Counters) *Limiter {
+	return &Limiter{
+		counters: counters,
+		now:      time.Now,
+	}
+}
+
+type Limiter struct {
+	counters Counters
+	now      func() time.Time
+}
+
+// Allow counts action under provided key and checks, that limit of policy is not exceeded.
+//
+// Sliding window is approximated by counters of current and previous fixed windows: counter of previous window
+// is weighted by part of it, which still overlaps with sliding window. Counter is incremented atomically before
+// check, so concurrent actions can not exceed limit together. Rejected actions are counted too, so clients,
+// which do not respect retry-after, stay limited.
+func (limiter *Limiter) Allow(ctx context.Context, key string, policy Policy) (Result, error) {
+	if policy.Limit <= 0 || policy.Window <= 0 {
+		return Result{Allowed: true}, nil
+	}
+
+	now := limiter.now()
+	window := now.UnixNano() / int64(policy.Window)
+	elapsed := time.Duration(now.UnixNano() % int64(policy.Window))
+
+	// Counter lives during two windows, because it is used as previous one during the next window:
+	current, err := limiter.counters.IncrWithTTL(ctx, windowCacheKey(key, policy.Window, window), policy.Window*2)
+	if err != nil {
+		return Result{}, err
+	}
+
+	previous := limiter.getCounter(ctx, windowCacheKey(key, policy.Window, window-1))
+	overlap := 1 - float64(elapsed)/float64(policy.Window)
+	if float64(previous)*overlap+float64(current) <= float64(policy.Limit) {
+		return Result{Allowed: true}, nil
+	}
+
+	return Result{
+		Allowed:    false,
+		RetryAfter: retryAfter(policy, previous, current, elapsed),
+	}, nil
+}
+
+// getCounter returns counter of fixed window. Missing counter means, that there were no actions during window.
+func (limiter *Limiter) getCounter(ctx context.Context, cacheKey string) int64 {
+	strCounter, err := limiter.counters.Get(ctx, cacheKey)
+	if err != nil || strCounter == "" {
+		return 0
+	}
+
+	counter, err := strconv.ParseInt(strCounter, 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return counter
+}
+
+// retryAfter calculates time, after which next action will be allowed, if there are no other actions until then.
+func retryAfter(policy Policy, previous, current int64, elapsed time.Duration) time.Duration {
+	allowed := float64(policy.Limit - 1) // actions, which can be counted before the next one
+
+	// Next action fits into current window, when enough of previous window slides out:
+	if float64(current) <= allowed {
+		overlap := (allowed - float64(current)) / float64(previous)
+		return max(time.Duration(float64(policy.Window)*(1-overlap))-elapsed, 0)
+	}
+
+	// Otherwise current window should become previous one and slide out enough:
+	overlap := allowed / float64(current)
+
+	return policy.Window - elapsed + time.Duration(float64(policy.Window)*(1-overlap))
+}
+
+func windowCacheKey(key string, window time.Duration, index int64) string {
+	return fmt.Sprintf("%s-%s-%s-%d", cachePrefix, key, window, index)
+}
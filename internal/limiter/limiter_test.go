@@ -0,0 +1,200 @@
+package limiter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/DKhorkov/hmtm-sso/internal/memorycache"
+	mockcache "github.com/DKhorkov/hmtm-sso/mocks/cache"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	policy := Policy{Limit: 3, Window: time.Minute}
+	currentKey := windowCacheKey("key", policy.Window, 100)
+	previousKey := windowCacheKey("key", policy.Window, 99)
+
+	testCases := []struct {
+		name           string
+		policy         Policy
+		elapsed        time.Duration
+		setupMocks     func(cacheProvider *mockcache.MockCacheProvider)
+		expectedResult Result
+		errorExpected  bool
+	}{
+		{
+			name:    "first action",
+			policy:  policy,
+			elapsed: time.Second * 10,
+			setupMocks: func(cacheProvider *mockcache.MockCacheProvider) {
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), currentKey, policy.Window*2).
+					Return(int64(1), nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), previousKey).
+					Return("", errors.New("redis: nil")).
+					Times(1)
+			},
+			expectedResult: Result{Allowed: true},
+		},
+		{
+			name:    "limit is exceeded in current window",
+			policy:  policy,
+			elapsed: time.Second * 10,
+			setupMocks: func(cacheProvider *mockcache.MockCacheProvider) {
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), currentKey, policy.Window*2).
+					Return(int64(4), nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), previousKey).
+					Return("", errors.New("redis: nil")).
+					Times(1)
+			},
+			expectedResult: Result{
+				Allowed: false,
+				// 50 seconds until end of window and half of the next window, while 2 of 4 actions slide out:
+				RetryAfter: time.Second * 80,
+			},
+		},
+		{
+			name:    "previous window is weighted",
+			policy:  policy,
+			elapsed: time.Second * 45,
+			setupMocks: func(cacheProvider *mockcache.MockCacheProvider) {
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), currentKey, policy.Window*2).
+					Return(int64(2), nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), previousKey).
+					Return("4", nil).
+					Times(1)
+			},
+			expectedResult: Result{Allowed: true},
+		},
+		{
+			name:    "limit is exceeded due to previous window",
+			policy:  policy,
+			elapsed: time.Second * 30,
+			setupMocks: func(cacheProvider *mockcache.MockCacheProvider) {
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), currentKey, policy.Window*2).
+					Return(int64(2), nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), previousKey).
+					Return("4", nil).
+					Times(1)
+			},
+			expectedResult: Result{
+				Allowed:    false,
+				RetryAfter: time.Second * 30,
+			},
+		},
+		{
+			name:           "policy without limit",
+			policy:         Policy{Window: time.Minute},
+			expectedResult: Result{Allowed: true},
+		},
+		{
+			name:    "cache error",
+			policy:  policy,
+			elapsed: time.Second * 10,
+			setupMocks: func(cacheProvider *mockcache.MockCacheProvider) {
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), currentKey, policy.Window*2).
+					Return(int64(0), errors.New("connection refused")).
+					Times(1)
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(cacheProvider)
+			}
+
+			limiter := New(cacheProvider)
+			limiter.now = func() time.Time {
+				return time.Unix(0, int64(policy.Window)*100+int64(tc.elapsed))
+			}
+
+			result, err := limiter.Allow(context.Background(), "key", tc.policy)
+			if tc.errorExpected {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestLimiter_AllowConcurrently(t *testing.T) {
+	cacheProvider := memorycache.New()
+	t.Cleanup(func() {
+		require.NoError(t, cacheProvider.Close())
+	})
+
+	policy := Policy{Limit: 50, Window: time.Minute}
+	limiter := New(cacheProvider)
+	limiter.now = func() time.Time {
+		return time.Unix(0, int64(policy.Window)*100)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		allowed atomic.Int64
+	)
+
+	for range 100 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			result, err := limiter.Allow(context.Background(), "key", policy)
+			require.NoError(t, err)
+
+			if result.Allowed {
+				allowed.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	// Every action is counted, so exactly limit of actions is allowed:
+	require.Equal(t, policy.Limit, allowed.Load())
+
+	counter, err := cacheProvider.Get(context.Background(), windowCacheKey("key", policy.Window, 100))
+	require.NoError(t, err)
+	require.Equal(t, "100", counter)
+}
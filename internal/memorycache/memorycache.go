@@ -0,0 +1,188 @@
+// Package memorycache stores values in memory of process and implements cache.Provider, so service can run
+// without Redis. Values are not shared between instances of service and are lost on restart, so it is suitable only
+// for single-node and local runs.
+package memorycache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// cleanupInterval is period, after which expired values are removed, even if they are not accessed anymore.
+const cleanupInterval = time.Minute
+
+var (
+	errKeyNotFound = errors.New("cache key not found")
+	errNotInteger  = errors.New("cache value is not an integer")
+	errClosed      = errors.New("cache is closed")
+)
+
+type item struct {
+	value     string
+	expiresAt time.Time // zero time means, that value does not expire
+}
+
+func (i item) expired(now time.Time) bool {
+	return !i.expiresAt.IsZero() && !now.Before(i.expiresAt)
+}
+
+func New() *Cache {
+	c := &Cache{
+		items: make(map[string]item),
+		now:   time.Now,
+		done:  make(chan struct{}),
+	}
+
+	go c.cleanup()
+
+	return c
+}
+
+type Cache struct {
+	mu     sync.Mutex
+	items  map[string]item
+	now    func() time.Time
+	done   chan struct{}
+	closed bool
+}
+
+// Get returns value under provided key or error, if there is no value or it has expired, as Redis does.
+func (c *Cache) Get(_ context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i, ok := c.items[key]
+	if !ok || i.expired(c.now()) {
+		return "", errKeyNotFound
+	}
+
+	return i.value, nil
+}
+
+// Set stores value under provided key. Zero TTL means, that value does not expire.
+func (c *Cache) Set(_ context.Context, key string, value any, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := item{value: formatValue(value)}
+	if ttl > 0 {
+		i.expiresAt = c.now().Add(ttl)
+	}
+
+	c.items[key] = i
+
+	return nil
+}
+
+// Incr atomically increments integer value under provided key and keeps its TTL.
+// Missing value is treated as zero and does not expire, as Redis does.
+func (c *Cache) Incr(_ context.Context, key string) (int64, error) {
+	return c.incr(key, 0)
+}
+
+// IncrWithTTL atomically increments integer value under provided key. Missing value is treated as zero and expires
+// after provided TTL, while TTL of existing value is kept.
+func (c *Cache) IncrWithTTL(_ context.Context, key string, ttl time.Duration) (int64, error) {
+	return c.incr(key, ttl)
+}
+
+// incr increments value and sets TTL of value, which is created by increment. Zero TTL means, that such value
+// does not expire.
+func (c *Cache) incr(key string, ttl time.Duration) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i, ok := c.items[key]
+	if !ok || i.expired(c.now()) {
+		i = item{value: "0"}
+		if ttl > 0 {
+			i.expiresAt = c.now().Add(ttl)
+		}
+	}
+
+	counter, err := strconv.ParseInt(i.value, 10, 64)
+	if err != nil {
+		return 0, errNotInteger
+	}
+
+	counter++
+	i.value = strconv.FormatInt(counter, 10)
+	c.items[key] = i
+
+	return counter, nil
+}
+
+func (c *Cache) Ping(_ context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return "", errClosed
+	}
+
+	return "PONG", nil
+}
+
+// Close stops removal of expired values. Values are still available after closing.
+func (c *Cache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.closed {
+		c.closed = true
+		close(c.done)
+	}
+
+	return nil
+}
+
+func (c *Cache) cleanup() {
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			c.removeExpired()
+		}
+	}
+}
+
+func (c *Cache) removeExpired() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for key, i := range c.items {
+		if i.expired(now) {
+			delete(c.items, key)
+		}
+	}
+}
+
+// formatValue converts value to string in the same way as Redis client does, so values can be read back
+// regardless of provider.
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case bool:
+		if v {
+			return "1"
+		}
+
+		return "0"
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,209 @@
+package memorycache
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCache(t *testing.T) (*Cache, *time.Time) {
+	t.Helper()
+
+	now := time.Unix(1000, 0)
+	c := New()
+	c.now = func() time.Time {
+		return now
+	}
+
+	t.Cleanup(func() {
+		require.NoError(t, c.Close())
+	})
+
+	return c, &now
+}
+
+func TestCache_GetSet(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name          string
+		value         any
+		ttl           time.Duration
+		elapsed       time.Duration
+		expectedValue string
+		errorExpected bool
+	}{
+		{
+			name:          "string",
+			value:         "value",
+			expectedValue: "value",
+		},
+		{
+			name:          "integer",
+			value:         int64(5),
+			expectedValue: "5",
+		},
+		{
+			name:          "bool",
+			value:         true,
+			expectedValue: "1",
+		},
+		{
+			name:          "not expired",
+			value:         1,
+			ttl:           time.Minute,
+			elapsed:       time.Second * 59,
+			expectedValue: "1",
+		},
+		{
+			name:          "expired",
+			value:         1,
+			ttl:           time.Minute,
+			elapsed:       time.Minute,
+			errorExpected: true,
+		},
+		{
+			name:          "without TTL",
+			value:         1,
+			elapsed:       time.Hour * 24 * 365,
+			expectedValue: "1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, now := newTestCache(t)
+
+			require.NoError(t, c.Set(ctx, "key", tc.value, tc.ttl))
+			*now = now.Add(tc.elapsed)
+
+			value, err := c.Get(ctx, "key")
+			if tc.errorExpected {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedValue, value)
+		})
+	}
+}
+
+func TestCache_GetMissingKey(t *testing.T) {
+	c, _ := newTestCache(t)
+
+	value, err := c.Get(context.Background(), "missing")
+	require.Error(t, err)
+	require.Empty(t, value)
+}
+
+func TestCache_Incr(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("missing key", func(t *testing.T) {
+		c, _ := newTestCache(t)
+
+		counter, err := c.Incr(ctx, "counter")
+		require.NoError(t, err)
+		require.Equal(t, int64(1), counter)
+	})
+
+	t.Run("keeps TTL", func(t *testing.T) {
+		c, now := newTestCache(t)
+
+		require.NoError(t, c.Set(ctx, "counter", 1, time.Minute))
+
+		counter, err := c.Incr(ctx, "counter")
+		require.NoError(t, err)
+		require.Equal(t, int64(2), counter)
+
+		*now = now.Add(time.Minute)
+		_, err = c.Get(ctx, "counter")
+		require.Error(t, err)
+
+		// Expired counter starts from zero:
+		counter, err = c.Incr(ctx, "counter")
+		require.NoError(t, err)
+		require.Equal(t, int64(1), counter)
+	})
+
+	t.Run("with TTL", func(t *testing.T) {
+		c, now := newTestCache(t)
+
+		counter, err := c.IncrWithTTL(ctx, "counter", time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), counter)
+
+		// TTL of existing counter is not prolonged:
+		*now = now.Add(time.Second * 30)
+		counter, err = c.IncrWithTTL(ctx, "counter", time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), counter)
+
+		*now = now.Add(time.Second * 30)
+		_, err = c.Get(ctx, "counter")
+		require.Error(t, err)
+	})
+
+	t.Run("not integer", func(t *testing.T) {
+		c, _ := newTestCache(t)
+
+		require.NoError(t, c.Set(ctx, "counter", "value", 0))
+
+		_, err := c.Incr(ctx, "counter")
+		require.Error(t, err)
+	})
+
+	t.Run("concurrent increments", func(t *testing.T) {
+		c, _ := newTestCache(t)
+
+		var wg sync.WaitGroup
+		for range 100 {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				_, err := c.Incr(ctx, "counter")
+				require.NoError(t, err)
+			}()
+		}
+
+		wg.Wait()
+
+		value, err := c.Get(ctx, "counter")
+		require.NoError(t, err)
+		require.Equal(t, "100", value)
+	})
+}
+
+func TestCache_RemoveExpired(t *testing.T) {
+	ctx := context.Background()
+	c, now := newTestCache(t)
+
+	require.NoError(t, c.Set(ctx, "expiring", 1, time.Minute))
+	require.NoError(t, c.Set(ctx, "persistent", 1, 0))
+
+	*now = now.Add(time.Minute)
+	c.removeExpired()
+
+	require.NotContains(t, c.items, "expiring")
+	require.Contains(t, c.items, "persistent")
+}
+
+func TestCache_PingClose(t *testing.T) {
+	c := New()
+
+	pong, err := c.Ping(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "PONG", pong)
+
+	require.NoError(t, c.Close())
+	require.NoError(t, c.Close())
+
+	_, err = c.Ping(context.Background())
+	require.Error(t, err)
+}
@@ -0,0 +1,320 @@
+// Package passwords hashes passwords of Users. Hashes are stored in PHC string format, so they contain algorithm
+// and its parameters, and hashes of different algorithms and parameters can be verified at the same time.
+//
+// Hashes of legacy algorithms, which are imported from other platforms, are only verified:
+//   - PBKDF2-SHA256 in "$pbkdf2-sha256$i=<iterations>$<salt>$<key>" format;
+//   - salted SHA-1 of salt followed by password in "$sha1$<salt>$<digest>" format.
+//
+// Salts, keys and digests are encoded with standard base64 without padding.
+package passwords
+
+import (
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // legacy hashes of imported Users, which are rehashed on login
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/DKhorkov/libs/validation"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/DKhorkov/hmtm-sso/internal/config"
+)
+
+const (
+	argon2idSaltLength = 16
+	argon2idKeyLength  = 32
+
+	pbkdf2SHA256Algorithm = "pbkdf2-sha256"
+	saltedSHA1Algorithm   = "sha1"
+)
+
+// Parameters are taken from stored hashes, which can be imported from other platforms, so they are limited
+// to prevent crafted hash from making each login attempt arbitrarily expensive. Limits are far above
+// recommended parameters:
+const (
+	maxArgon2idMemory         = 256 * 1024 // KiB
+	maxArgon2idIterations     = 16
+	maxArgon2idParallelism    = 16
+	maxPBKDF2SHA256Iterations = 2_000_000
+	maxKeyLength              = 64
+)
+
+var errInvalidHash = errors.New("invalid password hash")
+
+// bcrypt hashes have their own format, which was used before PHC format, so they are recognized by prefix:
+var bcryptPrefixes = []string{"$2a$", "$2b$", "$2y$"}
+
+// New creates Hasher, which hashes new passwords with algorithm from config.
+func New(passwordsConfig config.PasswordsConfig) (*Hasher, error) {
+	switch passwordsConfig.Algorithm {
+	case config.Argon2idPasswordHashAlgorithm:
+		if passwordsConfig.Argon2id.Memory == 0 ||
+			passwordsConfig.Argon2id.Iterations == 0 ||
+			passwordsConfig.Argon2id.Parallelism == 0 {
+			return nil, errors.New("argon2id memory, iterations and parallelism must be positive")
+		}
+
+		// Otherwise new hashes could not be verified:
+		if passwordsConfig.Argon2id.Memory > maxArgon2idMemory ||
+			passwordsConfig.Argon2id.Iterations > maxArgon2idIterations ||
+			passwordsConfig.Argon2id.Parallelism > maxArgon2idParallelism {
+			return nil, fmt.Errorf(
+				"argon2id memory, iterations and parallelism must not exceed %d KiB, %d and %d",
+				maxArgon2idMemory,
+				maxArgon2idIterations,
+				maxArgon2idParallelism,
+			)
+		}
+	case config.BcryptPasswordHashAlgorithm:
+		if passwordsConfig.BcryptCost < bcrypt.MinCost || passwordsConfig.BcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported password hash algorithm %q", passwordsConfig.Algorithm)
+	}
+
+	return &Hasher{config: passwordsConfig}, nil
+}
+
+type Hasher struct {
+	config config.PasswordsConfig
+}
+
+// Hash hashes password with configured algorithm.
+func (h *Hasher) Hash(password string) (string, error) {
+	if h.config.Algorithm == config.BcryptPasswordHashAlgorithm {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), h.config.BcryptCost)
+		if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+			// bcrypt uses only first 72 bytes, so longer password is rejected instead of being truncated silently:
+			return "", &validation.Error{Message: "password is too long", BaseErr: err}
+		}
+
+		return string(hash), err
+	}
+
+	salt := make([]byte, argon2idSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	params := argon2idParams{
+		version:     argon2.Version,
+		memory:      h.config.Argon2id.Memory,
+		iterations:  h.config.Argon2id.Iterations,
+		parallelism: h.config.Argon2id.Parallelism,
+	}
+
+	return params.encode(salt, params.key(password, salt, argon2idKeyLength)), nil
+}
+
+// Verify checks, that password matches hash of any supported algorithm.
+func (h *Hasher) Verify(password, hash string) bool {
+	switch {
+	case isBcryptHash(hash):
+		return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+	case hasAlgorithm(hash, pbkdf2SHA256Algorithm):
+		iterations, salt, key, err := decodePBKDF2SHA256(hash)
+		if err != nil {
+			return false
+		}
+
+		expected := pbkdf2.Key([]byte(password), salt, iterations, len(key), sha256.New)
+
+		return subtle.ConstantTimeCompare(key, expected) == 1
+	case hasAlgorithm(hash, saltedSHA1Algorithm):
+		salt, digest, err := decodeSaltedSHA1(hash)
+		if err != nil {
+			return false
+		}
+
+		expected := sha1.Sum(append(salt, password...)) //nolint:gosec // legacy hash
+
+		return subtle.ConstantTimeCompare(digest, expected[:]) == 1
+	default:
+		params, salt, key, err := decodeArgon2id(hash)
+		if err != nil {
+			return false
+		}
+
+		//nolint:gosec // length of key is limited by length of hash
+		return subtle.ConstantTimeCompare(key, params.key(password, salt, uint32(len(key)))) == 1
+	}
+}
+
+// Supports reports, that hash has format of supported algorithm and parameters within limits, so it can be verified.
+func (h *Hasher) Supports(hash string) bool {
+	var err error
+
+	switch {
+	case isBcryptHash(hash):
+		_, err = bcrypt.Cost([]byte(hash))
+	case hasAlgorithm(hash, pbkdf2SHA256Algorithm):
+		_, _, _, err = decodePBKDF2SHA256(hash)
+	case hasAlgorithm(hash, saltedSHA1Algorithm):
+		_, _, err = decodeSaltedSHA1(hash)
+	default:
+		_, _, _, err = decodeArgon2id(hash)
+	}
+
+	return err == nil
+}
+
+// NeedsRehash reports, that hash was made with other algorithm or parameters than configured ones,
+// so password should be hashed again, when it is known.
+func (h *Hasher) NeedsRehash(hash string) bool {
+	if isBcryptHash(hash) {
+		if h.config.Algorithm != config.BcryptPasswordHashAlgorithm {
+			return true
+		}
+
+		cost, err := bcrypt.Cost([]byte(hash))
+
+		return err != nil || cost != h.config.BcryptCost
+	}
+
+	// Hashes of legacy algorithms are always upgraded, because they can not be configured:
+	if h.config.Algorithm != config.Argon2idPasswordHashAlgorithm ||
+		!hasAlgorithm(hash, config.Argon2idPasswordHashAlgorithm) {
+		return true
+	}
+
+	params, salt, key, err := decodeArgon2id(hash)
+
+	return err != nil ||
+		params.version != argon2.Version ||
+		params.memory != h.config.Argon2id.Memory ||
+		params.iterations != h.config.Argon2id.Iterations ||
+		params.parallelism != h.config.Argon2id.Parallelism ||
+		len(salt) != argon2idSaltLength ||
+		len(key) != argon2idKeyLength
+}
+
+func isBcryptHash(hash string) bool {
+	for _, prefix := range bcryptPrefixes {
+		if strings.HasPrefix(hash, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasAlgorithm reports, that hash in PHC string format was made with provided algorithm.
+func hasAlgorithm(hash, algorithm string) bool {
+	return strings.HasPrefix(hash, "$"+algorithm+"$")
+}
+
+type argon2idParams struct {
+	version     int
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+func (p argon2idParams) key(password string, salt []byte, length uint32) []byte {
+	return argon2.IDKey([]byte(password), salt, p.iterations, p.memory, p.parallelism, length)
+}
+
+// encode returns hash in "$argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<key>" format.
+func (p argon2idParams) encode(salt, key []byte) string {
+	return fmt.Sprintf(
+		"$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		config.Argon2idPasswordHashAlgorithm,
+		p.version,
+		p.memory,
+		p.iterations,
+		p.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	)
+}
+
+func decodeArgon2id(hash string) (argon2idParams, []byte, []byte, error) {
+	var params argon2idParams
+
+	// Leading "$" produces empty first part:
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != config.Argon2idPasswordHashAlgorithm {
+		return params, nil, nil, errInvalidHash
+	}
+
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &params.version); err != nil {
+		return params, nil, nil, errInvalidHash
+	}
+
+	if _, err := fmt.Sscanf(
+		parts[3],
+		"m=%d,t=%d,p=%d",
+		&params.memory,
+		&params.iterations,
+		&params.parallelism,
+	); err != nil ||
+		params.memory > maxArgon2idMemory ||
+		params.iterations == 0 || params.iterations > maxArgon2idIterations ||
+		params.parallelism == 0 || params.parallelism > maxArgon2idParallelism {
+		return params, nil, nil, errInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, errInvalidHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 || len(key) > maxKeyLength {
+		return params, nil, nil, errInvalidHash
+	}
+
+	return params, salt, key, nil
+}
+
+func decodePBKDF2SHA256(hash string) (int, []byte, []byte, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 5 || parts[0] != "" || parts[1] != pbkdf2SHA256Algorithm {
+		return 0, nil, nil, errInvalidHash
+	}
+
+	var iterations int
+	if _, err := fmt.Sscanf(parts[2], "i=%d", &iterations); err != nil ||
+		iterations <= 0 || iterations > maxPBKDF2SHA256Iterations {
+		return 0, nil, nil, errInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return 0, nil, nil, errInvalidHash
+	}
+
+	// Cost of PBKDF2 grows with length of derived key as well as with iterations:
+	key, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(key) == 0 || len(key) > maxKeyLength {
+		return 0, nil, nil, errInvalidHash
+	}
+
+	return iterations, salt, key, nil
+}
+
+func decodeSaltedSHA1(hash string) ([]byte, []byte, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 4 || parts[0] != "" || parts[1] != saltedSHA1Algorithm {
+		return nil, nil, errInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, nil, errInvalidHash
+	}
+
+	digest, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil || len(digest) != sha1.Size {
+		return nil, nil, errInvalidHash
+	}
+
+	return salt, digest, nil
+}
@@ -0,0 +1,356 @@
+package passwords
+
+import (
+	"crypto/sha1" //nolint:gosec // legacy hashes are tested
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/DKhorkov/libs/validation"
+
+	"github.com/DKhorkov/hmtm-sso/internal/config"
+)
+
+var (
+	argon2idConfig = config.PasswordsConfig{
+		Algorithm: config.Argon2idPasswordHashAlgorithm,
+		Argon2id: config.Argon2idConfig{
+			Memory:      1024,
+			Iterations:  1,
+			Parallelism: 1,
+		},
+		BcryptCost: 4,
+	}
+	bcryptConfig = config.PasswordsConfig{
+		Algorithm:  config.BcryptPasswordHashAlgorithm,
+		Argon2id:   argon2idConfig.Argon2id,
+		BcryptCost: 4,
+	}
+)
+
+// pbkdf2SHA256Hash and saltedSHA1Hash are hashes of "password123", which are imported from other platform:
+var (
+	pbkdf2SHA256Hash = "$pbkdf2-sha256$i=1000$" +
+		base64.RawStdEncoding.EncodeToString([]byte("salt")) + "$" +
+		base64.RawStdEncoding.EncodeToString(pbkdf2.Key([]byte("password123"), []byte("salt"), 1000, 32, sha256.New))
+	saltedSHA1Digest = sha1.Sum([]byte("saltpassword123")) //nolint:gosec // legacy hashes are tested
+	saltedSHA1Hash   = "$sha1$" +
+		base64.RawStdEncoding.EncodeToString([]byte("salt")) + "$" +
+		base64.RawStdEncoding.EncodeToString(saltedSHA1Digest[:])
+)
+
+func newHasher(t *testing.T, passwordsConfig config.PasswordsConfig) *Hasher {
+	t.Helper()
+
+	hasher, err := New(passwordsConfig)
+	require.NoError(t, err)
+
+	return hasher
+}
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		name          string
+		config        config.PasswordsConfig
+		errorExpected bool
+	}{
+		{
+			name:   "argon2id",
+			config: argon2idConfig,
+		},
+		{
+			name:   "bcrypt",
+			config: bcryptConfig,
+		},
+		{
+			name: "unsupported algorithm",
+			config: config.PasswordsConfig{
+				Algorithm: "md5",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "invalid argon2id parameters",
+			config: config.PasswordsConfig{
+				Algorithm: config.Argon2idPasswordHashAlgorithm,
+			},
+			errorExpected: true,
+		},
+		{
+			name: "too expensive argon2id parameters",
+			config: config.PasswordsConfig{
+				Algorithm: config.Argon2idPasswordHashAlgorithm,
+				Argon2id: config.Argon2idConfig{
+					Memory:      4194304,
+					Iterations:  1,
+					Parallelism: 1,
+				},
+			},
+			errorExpected: true,
+		},
+		{
+			name: "invalid bcrypt cost",
+			config: config.PasswordsConfig{
+				Algorithm:  config.BcryptPasswordHashAlgorithm,
+				BcryptCost: 100,
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hasher, err := New(tc.config)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Nil(t, hasher)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, hasher)
+		})
+	}
+}
+
+func TestHasher_Hash(t *testing.T) {
+	t.Run("argon2id", func(t *testing.T) {
+		hasher := newHasher(t, argon2idConfig)
+
+		hash, err := hasher.Hash("password123")
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+		require.True(t, hasher.Verify("password123", hash))
+		require.False(t, hasher.Verify("password124", hash))
+
+		// Salt is random, so hashes of the same password differ:
+		otherHash, err := hasher.Hash("password123")
+		require.NoError(t, err)
+		require.NotEqual(t, hash, otherHash)
+	})
+
+	t.Run("argon2id long password", func(t *testing.T) {
+		hasher := newHasher(t, argon2idConfig)
+		password := strings.Repeat("a", 72)
+
+		hash, err := hasher.Hash(password + "b")
+		require.NoError(t, err)
+		require.True(t, hasher.Verify(password+"b", hash))
+		require.False(t, hasher.Verify(password+"c", hash))
+	})
+
+	t.Run("bcrypt", func(t *testing.T) {
+		hasher := newHasher(t, bcryptConfig)
+
+		hash, err := hasher.Hash("password123")
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(hash, "$2a$04$"))
+		require.True(t, hasher.Verify("password123", hash))
+		require.False(t, hasher.Verify("password124", hash))
+	})
+
+	t.Run("bcrypt long password", func(t *testing.T) {
+		hasher := newHasher(t, bcryptConfig)
+
+		hash, err := hasher.Hash(strings.Repeat("a", 73))
+		require.Error(t, err)
+		require.IsType(t, &validation.Error{}, err)
+		require.Empty(t, hash)
+	})
+}
+
+func TestHasher_Verify(t *testing.T) {
+	argon2idHasher := newHasher(t, argon2idConfig)
+	bcryptHasher := newHasher(t, bcryptConfig)
+
+	argon2idHash, err := argon2idHasher.Hash("password123")
+	require.NoError(t, err)
+
+	bcryptHash, err := bcryptHasher.Hash("password123")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		hash     string
+		expected bool
+	}{
+		{
+			name:     "argon2id hash",
+			hash:     argon2idHash,
+			expected: true,
+		},
+		{
+			name:     "bcrypt hash",
+			hash:     bcryptHash,
+			expected: true,
+		},
+		{
+			name:     "pbkdf2-sha256 hash",
+			hash:     pbkdf2SHA256Hash,
+			expected: true,
+		},
+		{
+			name:     "salted sha1 hash",
+			hash:     saltedSHA1Hash,
+			expected: true,
+		},
+		{
+			name:     "unknown format",
+			hash:     "password123",
+			expected: false,
+		},
+		{
+			name:     "invalid pbkdf2-sha256 iterations",
+			hash:     "$pbkdf2-sha256$i=0$c2FsdA$a2V5",
+			expected: false,
+		},
+		{
+			name:     "too many pbkdf2-sha256 iterations",
+			hash:     "$pbkdf2-sha256$i=2147483647$c2FsdA$a2V5",
+			expected: false,
+		},
+		{
+			name:     "too long pbkdf2-sha256 key",
+			hash:     "$pbkdf2-sha256$i=1000$c2FsdA$" + base64.RawStdEncoding.EncodeToString(make([]byte, 65)),
+			expected: false,
+		},
+		{
+			name:     "invalid salted sha1 digest",
+			hash:     "$sha1$c2FsdA$a2V5",
+			expected: false,
+		},
+		{
+			name:     "invalid parameters",
+			hash:     "$argon2id$v=19$m=1024,t=0,p=1$c2FsdA$a2V5",
+			expected: false,
+		},
+		{
+			name:     "too much argon2id memory",
+			hash:     "$argon2id$v=19$m=4194304,t=1,p=1$c2FsdA$a2V5",
+			expected: false,
+		},
+		{
+			name:     "too many argon2id iterations",
+			hash:     "$argon2id$v=19$m=1024,t=4294967295,p=1$c2FsdA$a2V5",
+			expected: false,
+		},
+		{
+			name:     "too high argon2id parallelism",
+			hash:     "$argon2id$v=19$m=1024,t=1,p=255$c2FsdA$a2V5",
+			expected: false,
+		},
+		{
+			name:     "too long argon2id key",
+			hash:     "$argon2id$v=19$m=1024,t=1,p=1$c2FsdA$" + base64.RawStdEncoding.EncodeToString(make([]byte, 65)),
+			expected: false,
+		},
+		{
+			name:     "invalid salt",
+			hash:     "$argon2id$v=19$m=1024,t=1,p=1$!$a2V5",
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Both hashers verify hashes of any algorithm:
+			require.Equal(t, tc.expected, argon2idHasher.Verify("password123", tc.hash))
+			require.Equal(t, tc.expected, bcryptHasher.Verify("password123", tc.hash))
+			require.Equal(t, tc.expected, argon2idHasher.Supports(tc.hash))
+
+			if tc.expected {
+				require.False(t, argon2idHasher.Verify("password124", tc.hash))
+			}
+		})
+	}
+}
+
+func TestHasher_NeedsRehash(t *testing.T) {
+	argon2idHasher := newHasher(t, argon2idConfig)
+	bcryptHasher := newHasher(t, bcryptConfig)
+
+	argon2idHash, err := argon2idHasher.Hash("password123")
+	require.NoError(t, err)
+
+	bcryptHash, err := bcryptHasher.Hash("password123")
+	require.NoError(t, err)
+
+	strongerArgon2idConfig := argon2idConfig
+	strongerArgon2idConfig.Argon2id.Memory = 2048
+
+	strongerBcryptConfig := bcryptConfig
+	strongerBcryptConfig.BcryptCost = 5
+
+	testCases := []struct {
+		name     string
+		hasher   *Hasher
+		hash     string
+		expected bool
+	}{
+		{
+			name:     "current argon2id hash",
+			hasher:   argon2idHasher,
+			hash:     argon2idHash,
+			expected: false,
+		},
+		{
+			name:     "current bcrypt hash",
+			hasher:   bcryptHasher,
+			hash:     bcryptHash,
+			expected: false,
+		},
+		{
+			name:     "bcrypt hash after migration to argon2id",
+			hasher:   argon2idHasher,
+			hash:     bcryptHash,
+			expected: true,
+		},
+		{
+			name:     "argon2id hash after migration to bcrypt",
+			hasher:   bcryptHasher,
+			hash:     argon2idHash,
+			expected: true,
+		},
+		{
+			name:     "outdated argon2id parameters",
+			hasher:   newHasher(t, strongerArgon2idConfig),
+			hash:     argon2idHash,
+			expected: true,
+		},
+		{
+			name:     "outdated bcrypt cost",
+			hasher:   newHasher(t, strongerBcryptConfig),
+			hash:     bcryptHash,
+			expected: true,
+		},
+		{
+			name:     "pbkdf2-sha256 hash",
+			hasher:   argon2idHasher,
+			hash:     pbkdf2SHA256Hash,
+			expected: true,
+		},
+		{
+			name:     "salted sha1 hash",
+			hasher:   bcryptHasher,
+			hash:     saltedSHA1Hash,
+			expected: true,
+		},
+		{
+			name:     "unknown format",
+			hasher:   argon2idHasher,
+			hash:     "password123",
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.hasher.NeedsRehash(tc.hash))
+		})
+	}
+}
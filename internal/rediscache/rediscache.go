@@ -0,0 +1,69 @@
+// Package rediscache stores values in Redis and implements cache.Provider. Besides operations of cache.Provider it
+// creates counters with TTL atomically, which is required by rate limits.
+package rediscache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// incrWithTTLScript increments counter and sets its TTL in milliseconds, when counter is created by increment.
+// Scripts are executed by Redis atomically, so concurrent increments are not lost and counter always has TTL:
+var incrWithTTLScript = redis.NewScript(`
+local counter = redis.call("INCR", KEYS[1])
+if counter == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return counter
+`)
+
+// New connects to Redis and checks connection, so unavailable Redis is detected on start.
+func New(host string, port int, password string) (*Cache, error) {
+	client := redis.NewClient(
+		&redis.Options{
+			Addr:     fmt.Sprintf("%s:%d", host, port),
+			Password: password,
+		},
+	)
+
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		return nil, errors.Join(err, client.Close())
+	}
+
+	return &Cache{client: client}, nil
+}
+
+type Cache struct {
+	client *redis.Client
+}
+
+func (c *Cache) Get(ctx context.Context, key string) (string, error) {
+	return c.client.Get(ctx, key).Result()
+}
+
+// Set stores value under provided key. Zero TTL means, that value does not expire.
+func (c *Cache) Set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (c *Cache) Incr(ctx context.Context, key string) (int64, error) {
+	return c.client.Incr(ctx, key).Result()
+}
+
+// IncrWithTTL atomically increments counter under provided key. Counter, which is created by increment, expires
+// after provided TTL, while TTL of existing counter is kept.
+func (c *Cache) IncrWithTTL(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	return incrWithTTLScript.Run(ctx, c.client, []string{key}, ttl.Milliseconds()).Int64()
+}
+
+func (c *Cache) Ping(ctx context.Context) (string, error) {
+	return c.client.Ping(ctx).Result()
+}
+
+func (c *Cache) Close() error {
+	return c.client.Close()
+}
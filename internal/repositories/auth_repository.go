@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/DKhorkov/libs/db"
@@ -13,6 +14,7 @@ import (
 	sq "github.com/Masterminds/squirrel"
 
 	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
 )
 
 const (
@@ -23,6 +25,55 @@ const (
 	updatedAtColumnName         = "updated_at"
 	returningIDSuffix           = "RETURNING id"
 	userIDColumnName            = "user_id"
+	verifyEmailTokensTableName  = "verify_email_tokens"
+	verifyEmailTokenEmailColumn = "email"
+	tokenHashColumnName         = "token_hash"
+	codeHashColumnName          = "code_hash"
+	tokenTTLColumnName          = "ttl"
+	forgetPasswordTokensTable   = "forget_password_tokens"
+	loginTokensTableName        = "login_tokens"
+	sessionsTableName           = "sessions"
+	sessionIDColumnName         = "session_id"
+	sessionDeviceNameColumnName = "device_name"
+	sessionUserAgentColumnName  = "user_agent"
+	sessionIPColumnName         = "ip"
+	sessionTTLColumnName        = "ttl"
+	familyIDColumnName          = "family_id"
+	rotatedAtColumnName         = "rotated_at"
+	totpSecretsTableName        = "totp_secrets"
+	totpSecretColumnName        = "secret"
+	totpConfirmedColumnName     = "confirmed"
+	totpLastUsedStepColumnName  = "last_used_step"
+	recoveryCodesTableName      = "recovery_codes"
+	recoveryCodeUsedAtColumn    = "used_at"
+	mfaChallengesTableName      = "mfa_challenges"
+	webAuthnCredentialsTable    = "webauthn_credentials"
+	webAuthnChallengesTable     = "webauthn_challenges"
+	credentialIDColumnName      = "credential_id"
+	publicKeyColumnName         = "public_key"
+	signCountColumnName         = "sign_count"
+	transportsColumnName        = "transports"
+	challengeHashColumnName     = "challenge_hash"
+	ceremonyColumnName          = "ceremony"
+	authorizationCodesTable     = "authorization_codes"
+	clientIDColumnName          = "client_id"
+	redirectURIColumnName       = "redirect_uri"
+	scopeColumnName             = "scope"
+	nonceColumnName             = "nonce"
+	codeChallengeColumnName     = "code_challenge"
+	clientsTableName            = "clients"
+	secretHashColumnName        = "secret_hash"
+	redirectURIsColumnName      = "redirect_uris"
+	grantTypesColumnName        = "grant_types"
+	scopesColumnName            = "scopes"
+	clientAccessTokenTTLColumn  = "access_token_ttl"
+	clientRefreshTokenTTLColumn = "refresh_token_ttl"
+	userIdentitiesTableName     = "user_identities"
+	providerColumnName          = "provider"
+	subjectColumnName           = "subject"
+	userIdentityEmailColumn     = "email"
+	phoneVerificationCodesTable = "phone_verification_codes"
+	verificationPhoneColumn     = "phone"
 )
 
 type AuthRepository struct {
@@ -90,11 +141,50 @@ func (repo *AuthRepository) RegisterUser(
 	return userID, nil
 }
 
-func (repo *AuthRepository) CreateRefreshToken(
+// ImportUser inserts User, who is imported from other platform, together with state of email confirmation,
+// so User is never imported partially.
+func (repo *AuthRepository) ImportUser(ctx context.Context, userData entities.ImportUserDTO) error {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Insert(usersTableName).
+		Columns(
+			userDisplayNameColumnName,
+			userEmailColumnName,
+			userPasswordColumnName,
+			userEmailConfirmedColumnName,
+		).
+		Values(
+			userData.DisplayName,
+			userData.Email,
+			userData.PasswordHash,
+			userData.EmailConfirmed,
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.ExecContext(ctx, stmt, params...)
+
+	return err
+}
+
+func (repo *AuthRepository) CreateSession(
 	ctx context.Context,
-	userID uint64,
-	refreshToken string,
-	ttl time.Duration,
+	sessionData entities.CreateSessionDTO,
 ) (uint64, error) {
 	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
 	defer span.End()
@@ -109,17 +199,79 @@ func (repo *AuthRepository) CreateRefreshToken(
 
 	defer db.CloseConnectionContext(ctx, connection, repo.logger)
 
+	stmt, params, err := sq.
+		Insert(sessionsTableName).
+		Columns(
+			userIDColumnName,
+			sessionDeviceNameColumnName,
+			sessionUserAgentColumnName,
+			sessionIPColumnName,
+			sessionTTLColumnName,
+		).
+		Values(
+			sessionData.UserID,
+			sessionData.ClientInfo.DeviceName,
+			sessionData.ClientInfo.UserAgent,
+			sessionData.ClientInfo.IP,
+			time.Now().UTC().Add(sessionData.TTL),
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var sessionID uint64
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(&sessionID); err != nil {
+		return 0, err
+	}
+
+	return sessionID, nil
+}
+
+// CreateRefreshToken saves refresh token for Session and prolongs Session for refresh token's TTL.
+func (repo *AuthRepository) CreateRefreshToken(
+	ctx context.Context,
+	refreshTokenData entities.CreateRefreshTokenDTO,
+) (uint64, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	transaction, err := repo.dbConnector.Transaction(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Rollback transaction according Go best practises https://go.dev/doc/database/execute-transactions.
+	defer func() {
+		if err = transaction.Rollback(); err != nil {
+			logging.LogErrorContext(ctx, repo.logger, "failed to rollback db transaction", err)
+		}
+	}()
+
+	refreshTokenTTL := time.Now().UTC().Add(refreshTokenData.TTL)
+
 	stmt, params, err := sq.
 		Insert(refreshTokensTableName).
 		Columns(
 			userIDColumnName,
+			sessionIDColumnName,
+			familyIDColumnName,
 			refreshTokenValueColumnName,
 			refreshTokenTTLColumnName,
+			clientIDColumnName,
 		).
 		Values(
-			userID,
-			refreshToken,
-			time.Now().UTC().Add(ttl),
+			refreshTokenData.UserID,
+			refreshTokenData.SessionID,
+			refreshTokenData.FamilyID,
+			refreshTokenData.Value,
+			refreshTokenTTL,
+			refreshTokenData.ClientID,
 		).
 		Suffix(returningIDSuffix).
 		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
@@ -129,16 +281,37 @@ func (repo *AuthRepository) CreateRefreshToken(
 	}
 
 	var refreshTokenID uint64
-	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(&refreshTokenID); err != nil {
+	if err = transaction.QueryRowContext(ctx, stmt, params...).Scan(&refreshTokenID); err != nil {
+		return 0, err
+	}
+
+	stmt, params, err = sq.
+		Update(sessionsTableName).
+		Where(sq.Eq{idColumnName: refreshTokenData.SessionID}).
+		Set(sessionTTLColumnName, refreshTokenTTL).
+		Set(updatedAtColumnName, time.Now().UTC()).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err = transaction.ExecContext(ctx, stmt, params...); err != nil {
+		return 0, err
+	}
+
+	if err = transaction.Commit(); err != nil {
 		return 0, err
 	}
 
 	return refreshTokenID, nil
 }
 
-func (repo *AuthRepository) GetRefreshTokenByUserID(
+// GetRefreshTokenByValue returns refresh token with provided stored value (SHA-256 digest of opaque refresh token)
+// even if it has been already expired or rotated, so that reuse of rotated refresh tokens could be detected.
+func (repo *AuthRepository) GetRefreshTokenByValue(
 	ctx context.Context,
-	userID uint64,
+	refreshToken string,
 ) (*entities.RefreshToken, error) {
 	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
 	defer span.End()
@@ -156,28 +329,24 @@ func (repo *AuthRepository) GetRefreshTokenByUserID(
 	stmt, params, err := sq.
 		Select(selectAllColumns).
 		From(refreshTokensTableName).
-		Where(sq.Eq{userIDColumnName: userID}).
-		Where(
-			sq.Expr(
-				refreshTokenTTLColumnName + " > CURRENT_TIMESTAMP",
-			),
-		).
+		Where(sq.Eq{refreshTokenValueColumnName: refreshToken}).
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	refreshToken := &entities.RefreshToken{}
+	dbRefreshToken := &entities.RefreshToken{}
 
-	columns := db.GetEntityColumns(refreshToken)
+	columns := db.GetEntityColumns(dbRefreshToken)
 	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
 		return nil, err
 	}
 
-	return refreshToken, nil
+	return dbRefreshToken, nil
 }
 
+// ExpireRefreshToken expires refresh token with provided stored value (SHA-256 digest of opaque refresh token).
 func (repo *AuthRepository) ExpireRefreshToken(ctx context.Context, refreshToken string) error {
 	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
 	defer span.End()
@@ -214,7 +383,9 @@ func (repo *AuthRepository) ExpireRefreshToken(ctx context.Context, refreshToken
 	return err
 }
 
-func (repo *AuthRepository) VerifyUserEmail(ctx context.Context, userID uint64) error {
+// RotateRefreshToken marks refresh token as exchanged for new one of provided family.
+// Returns RefreshTokenReuseDetectedError, if refresh token has been already rotated.
+func (repo *AuthRepository) RotateRefreshToken(ctx context.Context, refreshTokenID uint64, familyID string) error {
 	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
 	defer span.End()
 
@@ -228,30 +399,43 @@ func (repo *AuthRepository) VerifyUserEmail(ctx context.Context, userID uint64)
 
 	defer db.CloseConnectionContext(ctx, connection, repo.logger)
 
+	// Condition on rotated_at guarantees, that only one of concurrent requests will rotate refresh token:
 	stmt, params, err := sq.
-		Update(usersTableName).
-		Where(sq.Eq{idColumnName: userID}).
-		Set(userEmailConfirmedColumnName, true).
+		Update(refreshTokensTableName).
+		Where(sq.Eq{idColumnName: refreshTokenID}).
+		Where(sq.Eq{rotatedAtColumnName: nil}).
+		Set(familyIDColumnName, familyID).
+		Set(rotatedAtColumnName, time.Now().UTC()).
+		Set(
+			refreshTokenTTLColumnName,
+			time.Now().UTC().Add(time.Hour*time.Duration(-24)),
+		).
+		Set(updatedAtColumnName, time.Now().UTC()).
 		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
 		ToSql()
 	if err != nil {
 		return err
 	}
 
-	_, err = connection.ExecContext(
-		ctx,
-		stmt,
-		params...,
-	)
+	result, err := connection.ExecContext(ctx, stmt, params...)
+	if err != nil {
+		return err
+	}
 
-	return err
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return &customerrors.RefreshTokenReuseDetectedError{}
+	}
+
+	return nil
 }
 
-func (repo *AuthRepository) ForgetPassword(
-	ctx context.Context,
-	userID uint64,
-	newPassword string,
-) error {
+// RevokeRefreshTokenFamily expires all refresh tokens of provided family and Sessions, to which they belong.
+func (repo *AuthRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
 	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
 	defer span.End()
 
@@ -270,10 +454,18 @@ func (repo *AuthRepository) ForgetPassword(
 		}
 	}()
 
+	familySessions := sq.
+		Select(sessionIDColumnName).
+		From(refreshTokensTableName).
+		Where(sq.Eq{familyIDColumnName: familyID})
+
 	stmt, params, err := sq.
-		Update(usersTableName).
-		Where(sq.Eq{idColumnName: userID}).
-		Set(userPasswordColumnName, newPassword).
+		Update(sessionsTableName).
+		Where(sq.Expr(idColumnName+" IN (?)", familySessions)).
+		Set(
+			sessionTTLColumnName,
+			time.Now().UTC().Add(time.Hour*time.Duration(-24)),
+		).
 		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
 		ToSql()
 	if err != nil {
@@ -285,56 +477,32 @@ func (repo *AuthRepository) ForgetPassword(
 	}
 
 	stmt, params, err = sq.
-		Select(selectAllColumns).
-		From(refreshTokensTableName).
-		Where(sq.Eq{userIDColumnName: userID}).
+		Update(refreshTokensTableName).
+		Where(sq.Eq{familyIDColumnName: familyID}).
 		Where(
 			sq.Expr(
-				refreshTokenTTLColumnName + " > CURRENT_TIMESTAMP",
+				refreshTokenTTLColumnName+" > CURRENT_TIMESTAMP",
 			),
 		).
-		PlaceholderFormat(sq.Dollar).
+		Set(
+			refreshTokenTTLColumnName,
+			time.Now().UTC().Add(time.Hour*time.Duration(-24)),
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
 		ToSql()
 	if err != nil {
 		return err
 	}
 
-	// Getting refresh token for expiring:
-	refreshToken := &entities.RefreshToken{}
-	columns := db.GetEntityColumns(refreshToken)
-
-	err = transaction.QueryRowContext(ctx, stmt, params...).Scan(columns...)
-
-	switch {
-	case err != nil && !errors.Is(err, sql.ErrNoRows):
+	if _, err = transaction.ExecContext(ctx, stmt, params...); err != nil {
 		return err
-	case err == nil: // if active refresh token is found - expire it
-		stmt, params, err = sq.
-			Update(refreshTokensTableName).
-			Where(sq.Eq{refreshTokenValueColumnName: refreshToken.Value}).
-			Set(
-				refreshTokenTTLColumnName,
-				time.Now().UTC().Add(time.Hour*time.Duration(-24)),
-			).
-			PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
-			ToSql()
-		if err != nil {
-			return err
-		}
-
-		if _, err = transaction.ExecContext(ctx, stmt, params...); err != nil {
-			return err
-		}
 	}
 
 	return transaction.Commit()
 }
 
-func (repo *AuthRepository) ChangePassword(
-	ctx context.Context,
-	userID uint64,
-	newPassword string,
-) error {
+// GetUserSessions returns active Sessions of User, starting with the most recently used one.
+func (repo *AuthRepository) GetUserSessions(ctx context.Context, userID uint64) ([]entities.Session, error) {
 	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
 	defer span.End()
 
@@ -343,26 +511,2457 @@ func (repo *AuthRepository) ChangePassword(
 
 	connection, err := repo.dbConnector.Connection(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	defer db.CloseConnectionContext(ctx, connection, repo.logger)
 
 	stmt, params, err := sq.
-		Update(usersTableName).
-		Where(sq.Eq{idColumnName: userID}).
-		Set(userPasswordColumnName, newPassword).
+		Select(selectAllColumns).
+		From(sessionsTableName).
+		Where(sq.Eq{userIDColumnName: userID}).
+		Where(
+			sq.Expr(
+				sessionTTLColumnName + " > CURRENT_TIMESTAMP",
+			),
+		).
+		OrderBy(fmt.Sprintf("%s %s", updatedAtColumnName, DESC)).
 		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
 		ToSql()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = connection.ExecContext(
+	rows, err := connection.QueryContext(
 		ctx,
 		stmt,
 		params...,
 	)
+	if err != nil {
+		return nil, err
+	}
 
-	return err
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logging.LogErrorContext(
+				ctx,
+				repo.logger,
+				"error during closing SQL rows",
+				err,
+			)
+		}
+	}()
+
+	var sessions []entities.Session
+
+	for rows.Next() {
+		session := entities.Session{}
+		columns := db.GetEntityColumns(&session) // Only pointer to use rows.Scan() successfully
+
+		err = rows.Scan(columns...)
+		if err != nil {
+			return nil, err
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// ExpireSession ends Session of User with provided ID and expires Session's refresh tokens.
+func (repo *AuthRepository) ExpireSession(ctx context.Context, userID, sessionID uint64) error {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	transaction, err := repo.dbConnector.Transaction(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Rollback transaction according Go best practises https://go.dev/doc/database/execute-transactions.
+	defer func() {
+		if err = transaction.Rollback(); err != nil {
+			logging.LogErrorContext(ctx, repo.logger, "failed to rollback db transaction", err)
+		}
+	}()
+
+	stmt, params, err := sq.
+		Update(sessionsTableName).
+		Where(sq.Eq{idColumnName: sessionID}).
+		Where(sq.Eq{userIDColumnName: userID}).
+		Set(
+			sessionTTLColumnName,
+			time.Now().UTC().Add(time.Hour*time.Duration(-24)),
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := transaction.ExecContext(ctx, stmt, params...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	// Session does not exist or belongs to another User:
+	if rowsAffected == 0 {
+		return &customerrors.SessionNotFoundError{}
+	}
+
+	stmt, params, err = sq.
+		Update(refreshTokensTableName).
+		Where(sq.Eq{sessionIDColumnName: sessionID}).
+		Where(sq.Eq{userIDColumnName: userID}).
+		Where(
+			sq.Expr(
+				refreshTokenTTLColumnName+" > CURRENT_TIMESTAMP",
+			),
+		).
+		Set(
+			refreshTokenTTLColumnName,
+			time.Now().UTC().Add(time.Hour*time.Duration(-24)),
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = transaction.ExecContext(ctx, stmt, params...); err != nil {
+		return err
+	}
+
+	return transaction.Commit()
+}
+
+// ExpireUserSessions ends all Sessions of User except Session with provided ID and expires their refresh tokens.
+// Refresh tokens, which do not belong to any Session, are expired as well.
+func (repo *AuthRepository) ExpireUserSessions(ctx context.Context, userID, exceptSessionID uint64) error {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	transaction, err := repo.dbConnector.Transaction(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Rollback transaction according Go best practises https://go.dev/doc/database/execute-transactions.
+	defer func() {
+		if err = transaction.Rollback(); err != nil {
+			logging.LogErrorContext(ctx, repo.logger, "failed to rollback db transaction", err)
+		}
+	}()
+
+	stmt, params, err := sq.
+		Update(sessionsTableName).
+		Where(sq.Eq{userIDColumnName: userID}).
+		Where(sq.NotEq{idColumnName: exceptSessionID}).
+		Where(
+			sq.Expr(
+				sessionTTLColumnName+" > CURRENT_TIMESTAMP",
+			),
+		).
+		Set(
+			sessionTTLColumnName,
+			time.Now().UTC().Add(time.Hour*time.Duration(-24)),
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = transaction.ExecContext(ctx, stmt, params...); err != nil {
+		return err
+	}
+
+	stmt, params, err = sq.
+		Update(refreshTokensTableName).
+		Where(sq.Eq{userIDColumnName: userID}).
+		Where(
+			sq.Or{
+				sq.Eq{sessionIDColumnName: nil},
+				sq.NotEq{sessionIDColumnName: exceptSessionID},
+			},
+		).
+		Where(
+			sq.Expr(
+				refreshTokenTTLColumnName+" > CURRENT_TIMESTAMP",
+			),
+		).
+		Set(
+			refreshTokenTTLColumnName,
+			time.Now().UTC().Add(time.Hour*time.Duration(-24)),
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = transaction.ExecContext(ctx, stmt, params...); err != nil {
+		return err
+	}
+
+	return transaction.Commit()
+}
+
+func (repo *AuthRepository) CreateVerifyEmailToken(
+	ctx context.Context,
+	tokenData entities.CreateVerifyEmailTokenDTO,
+) (uint64, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Insert(verifyEmailTokensTableName).
+		Columns(
+			userIDColumnName,
+			verifyEmailTokenEmailColumn,
+			tokenHashColumnName,
+			codeHashColumnName,
+			tokenTTLColumnName,
+		).
+		Values(
+			tokenData.UserID,
+			tokenData.Email,
+			tokenData.TokenHash,
+			tokenData.CodeHash,
+			time.Now().UTC().Add(tokenData.TTL),
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var tokenID uint64
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(&tokenID); err != nil {
+		return 0, err
+	}
+
+	return tokenID, nil
+}
+
+func (repo *AuthRepository) GetVerifyEmailTokenByHash(
+	ctx context.Context,
+	tokenHash string,
+) (*entities.VerifyEmailToken, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(verifyEmailTokensTableName).
+		Where(sq.Eq{tokenHashColumnName: tokenHash}).
+		Where(
+			sq.Expr(
+				tokenTTLColumnName + " > CURRENT_TIMESTAMP",
+			),
+		).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	verifyEmailToken := &entities.VerifyEmailToken{}
+
+	columns := db.GetEntityColumns(verifyEmailToken)
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+		return nil, err
+	}
+
+	return verifyEmailToken, nil
+}
+
+// GetVerifyEmailTokenByUserID returns latest active verify-email token for User with provided ID.
+func (repo *AuthRepository) GetVerifyEmailTokenByUserID(
+	ctx context.Context,
+	userID uint64,
+) (*entities.VerifyEmailToken, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(verifyEmailTokensTableName).
+		Where(sq.Eq{userIDColumnName: userID}).
+		Where(
+			sq.Expr(
+				tokenTTLColumnName + " > CURRENT_TIMESTAMP",
+			),
+		).
+		OrderBy(fmt.Sprintf("%s %s", idColumnName, DESC)).
+		Limit(1).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	verifyEmailToken := &entities.VerifyEmailToken{}
+
+	columns := db.GetEntityColumns(verifyEmailToken)
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+		return nil, err
+	}
+
+	return verifyEmailToken, nil
+}
+
+// VerifyUserEmail confirms User's email and expires all User's verify-email tokens for them to be single-use.
+func (repo *AuthRepository) VerifyUserEmail(ctx context.Context, userID uint64) error {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	transaction, err := repo.dbConnector.Transaction(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Rollback transaction according Go best practises https://go.dev/doc/database/execute-transactions.
+	defer func() {
+		if err = transaction.Rollback(); err != nil {
+			logging.LogErrorContext(ctx, repo.logger, "failed to rollback db transaction", err)
+		}
+	}()
+
+	stmt, params, err := sq.
+		Update(usersTableName).
+		Where(sq.Eq{idColumnName: userID}).
+		Set(userEmailConfirmedColumnName, true).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = transaction.ExecContext(ctx, stmt, params...); err != nil {
+		return err
+	}
+
+	stmt, params, err = sq.
+		Update(verifyEmailTokensTableName).
+		Where(sq.Eq{userIDColumnName: userID}).
+		Where(
+			sq.Expr(
+				tokenTTLColumnName+" > CURRENT_TIMESTAMP",
+			),
+		).
+		Set(
+			tokenTTLColumnName,
+			time.Now().UTC().Add(time.Hour*time.Duration(-24)),
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = transaction.ExecContext(ctx, stmt, params...); err != nil {
+		return err
+	}
+
+	return transaction.Commit()
+}
+
+// ConfirmUserTelegram sets Telegram handle of User, which has been confirmed via Telegram Login Widget.
+func (repo *AuthRepository) ConfirmUserTelegram(ctx context.Context, userID uint64, telegram string) error {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Update(usersTableName).
+		Where(sq.Eq{idColumnName: userID}).
+		Set(userTelegramColumnName, telegram).
+		Set(userTelegramConfirmedColumnName, true).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.ExecContext(ctx, stmt, params...)
+
+	return err
+}
+
+func (repo *AuthRepository) CreateForgetPasswordToken(
+	ctx context.Context,
+	tokenData entities.CreateForgetPasswordTokenDTO,
+) (uint64, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Insert(forgetPasswordTokensTable).
+		Columns(
+			userIDColumnName,
+			tokenHashColumnName,
+			tokenTTLColumnName,
+		).
+		Values(
+			tokenData.UserID,
+			tokenData.TokenHash,
+			time.Now().UTC().Add(tokenData.TTL),
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var tokenID uint64
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(&tokenID); err != nil {
+		return 0, err
+	}
+
+	return tokenID, nil
+}
+
+func (repo *AuthRepository) GetForgetPasswordTokenByHash(
+	ctx context.Context,
+	tokenHash string,
+) (*entities.ForgetPasswordToken, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(forgetPasswordTokensTable).
+		Where(sq.Eq{tokenHashColumnName: tokenHash}).
+		Where(
+			sq.Expr(
+				tokenTTLColumnName + " > CURRENT_TIMESTAMP",
+			),
+		).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	forgetPasswordToken := &entities.ForgetPasswordToken{}
+
+	columns := db.GetEntityColumns(forgetPasswordToken)
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+		return nil, err
+	}
+
+	return forgetPasswordToken, nil
+}
+
+// ExpireForgetPasswordTokens expires all outstanding forget-password tokens of User with provided ID.
+func (repo *AuthRepository) ExpireForgetPasswordTokens(ctx context.Context, userID uint64) error {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := expireForgetPasswordTokensQuery(userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// ForgetPassword consumes forget-password token and sets new password for User in one transaction,
+// so one token can not be used twice.
+func (repo *AuthRepository) ForgetPassword(
+	ctx context.Context,
+	userID uint64,
+	forgetPasswordTokenID uint64,
+	newPassword string,
+) error {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	transaction, err := repo.dbConnector.Transaction(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Rollback transaction according Go best practises https://go.dev/doc/database/execute-transactions.
+	defer func() {
+		if err = transaction.Rollback(); err != nil {
+			logging.LogErrorContext(ctx, repo.logger, "failed to rollback db transaction", err)
+		}
+	}()
+
+	// Consuming token only if it is still active, which prevents concurrent usage of one token:
+	stmt, params, err := sq.
+		Update(forgetPasswordTokensTable).
+		Where(sq.Eq{idColumnName: forgetPasswordTokenID}).
+		Where(sq.Eq{userIDColumnName: userID}).
+		Where(
+			sq.Expr(
+				tokenTTLColumnName+" > CURRENT_TIMESTAMP",
+			),
+		).
+		Set(
+			tokenTTLColumnName,
+			time.Now().UTC().Add(time.Hour*time.Duration(-24)),
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := transaction.ExecContext(ctx, stmt, params...)
+	if err != nil {
+		return err
+	}
+
+	consumedTokens, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if consumedTokens == 0 {
+		return &customerrors.InvalidForgetPasswordTokenError{}
+	}
+
+	// Expiring other outstanding tokens of User:
+	stmt, params, err = expireForgetPasswordTokensQuery(userID)
+	if err != nil {
+		return err
+	}
+
+	if _, err = transaction.ExecContext(ctx, stmt, params...); err != nil {
+		return err
+	}
+
+	stmt, params, err = sq.
+		Update(usersTableName).
+		Where(sq.Eq{idColumnName: userID}).
+		Set(userPasswordColumnName, newPassword).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = transaction.ExecContext(ctx, stmt, params...); err != nil {
+		return err
+	}
+
+	// Password was reset, so all Sessions of User should be ended:
+	stmt, params, err = sq.
+		Update(refreshTokensTableName).
+		Where(sq.Eq{userIDColumnName: userID}).
+		Where(
+			sq.Expr(
+				refreshTokenTTLColumnName+" > CURRENT_TIMESTAMP",
+			),
+		).
+		Set(
+			refreshTokenTTLColumnName,
+			time.Now().UTC().Add(time.Hour*time.Duration(-24)),
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = transaction.ExecContext(ctx, stmt, params...); err != nil {
+		return err
+	}
+
+	stmt, params, err = sq.
+		Update(sessionsTableName).
+		Where(sq.Eq{userIDColumnName: userID}).
+		Where(
+			sq.Expr(
+				sessionTTLColumnName+" > CURRENT_TIMESTAMP",
+			),
+		).
+		Set(
+			sessionTTLColumnName,
+			time.Now().UTC().Add(time.Hour*time.Duration(-24)),
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = transaction.ExecContext(ctx, stmt, params...); err != nil {
+		return err
+	}
+
+	return transaction.Commit()
+}
+
+// ChangePassword sets new password for User and expires all User's forget-password tokens.
+func (repo *AuthRepository) ChangePassword(
+	ctx context.Context,
+	userID uint64,
+	newPassword string,
+) error {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	transaction, err := repo.dbConnector.Transaction(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Rollback transaction according Go best practises https://go.dev/doc/database/execute-transactions.
+	defer func() {
+		if err = transaction.Rollback(); err != nil {
+			logging.LogErrorContext(ctx, repo.logger, "failed to rollback db transaction", err)
+		}
+	}()
+
+	stmt, params, err := sq.
+		Update(usersTableName).
+		Where(sq.Eq{idColumnName: userID}).
+		Set(userPasswordColumnName, newPassword).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = transaction.ExecContext(ctx, stmt, params...); err != nil {
+		return err
+	}
+
+	stmt, params, err = expireForgetPasswordTokensQuery(userID)
+	if err != nil {
+		return err
+	}
+
+	if _, err = transaction.ExecContext(ctx, stmt, params...); err != nil {
+		return err
+	}
+
+	return transaction.Commit()
+}
+
+func expireForgetPasswordTokensQuery(userID uint64) (string, []any, error) {
+	return sq.
+		Update(forgetPasswordTokensTable).
+		Where(sq.Eq{userIDColumnName: userID}).
+		Where(
+			sq.Expr(
+				tokenTTLColumnName+" > CURRENT_TIMESTAMP",
+			),
+		).
+		Set(
+			tokenTTLColumnName,
+			time.Now().UTC().Add(time.Hour*time.Duration(-24)),
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+}
+
+// CreateTOTPSecret saves new TOTP secret for User. Not confirmed secret of User is replaced,
+// so User can restart enrollment at any time.
+func (repo *AuthRepository) CreateTOTPSecret(
+	ctx context.Context,
+	totpSecretData entities.CreateTOTPSecretDTO,
+) (uint64, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	transaction, err := repo.dbConnector.Transaction(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Rollback transaction according Go best practises https://go.dev/doc/database/execute-transactions.
+	defer func() {
+		if err = transaction.Rollback(); err != nil {
+			logging.LogErrorContext(ctx, repo.logger, "failed to rollback db transaction", err)
+		}
+	}()
+
+	stmt, params, err := sq.
+		Delete(totpSecretsTableName).
+		Where(sq.Eq{userIDColumnName: totpSecretData.UserID}).
+		Where(sq.Eq{totpConfirmedColumnName: false}).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err = transaction.ExecContext(ctx, stmt, params...); err != nil {
+		return 0, err
+	}
+
+	stmt, params, err = sq.
+		Insert(totpSecretsTableName).
+		Columns(
+			userIDColumnName,
+			totpSecretColumnName,
+		).
+		Values(
+			totpSecretData.UserID,
+			totpSecretData.Secret,
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var totpSecretID uint64
+	if err = transaction.QueryRowContext(ctx, stmt, params...).Scan(&totpSecretID); err != nil {
+		return 0, err
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return 0, err
+	}
+
+	return totpSecretID, nil
+}
+
+// GetTOTPSecretByUserID returns MFANotEnabledError, if User has never started TOTP enrollment.
+func (repo *AuthRepository) GetTOTPSecretByUserID(ctx context.Context, userID uint64) (*entities.TOTPSecret, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(totpSecretsTableName).
+		Where(sq.Eq{userIDColumnName: userID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	totpSecret := &entities.TOTPSecret{}
+
+	columns := db.GetEntityColumns(totpSecret)
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &customerrors.MFANotEnabledError{BaseErr: err}
+		}
+
+		return nil, err
+	}
+
+	return totpSecret, nil
+}
+
+// ConfirmTOTPSecret enables two-factor authentication for User and replaces User's recovery codes.
+func (repo *AuthRepository) ConfirmTOTPSecret(ctx context.Context, confirmData entities.ConfirmTOTPSecretDTO) error {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	transaction, err := repo.dbConnector.Transaction(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Rollback transaction according Go best practises https://go.dev/doc/database/execute-transactions.
+	defer func() {
+		if err = transaction.Rollback(); err != nil {
+			logging.LogErrorContext(ctx, repo.logger, "failed to rollback db transaction", err)
+		}
+	}()
+
+	// Conditions guarantee, that secret is confirmed only once and confirmation code is not reused:
+	stmt, params, err := sq.
+		Update(totpSecretsTableName).
+		Where(sq.Eq{idColumnName: confirmData.TOTPSecretID}).
+		Where(sq.Eq{userIDColumnName: confirmData.UserID}).
+		Where(sq.Eq{totpConfirmedColumnName: false}).
+		Where(sq.Lt{totpLastUsedStepColumnName: confirmData.Step}).
+		Set(totpConfirmedColumnName, true).
+		Set(totpLastUsedStepColumnName, confirmData.Step).
+		Set(updatedAtColumnName, time.Now().UTC()).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := transaction.ExecContext(ctx, stmt, params...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return &customerrors.InvalidMFACodeError{}
+	}
+
+	stmt, params, err = sq.
+		Delete(recoveryCodesTableName).
+		Where(sq.Eq{userIDColumnName: confirmData.UserID}).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = transaction.ExecContext(ctx, stmt, params...); err != nil {
+		return err
+	}
+
+	builder := sq.
+		Insert(recoveryCodesTableName).
+		Columns(
+			userIDColumnName,
+			codeHashColumnName,
+		)
+
+	for _, codeHash := range confirmData.RecoveryCodeHashes {
+		builder = builder.Values(confirmData.UserID, codeHash)
+	}
+
+	stmt, params, err = builder.
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = transaction.ExecContext(ctx, stmt, params...); err != nil {
+		return err
+	}
+
+	return transaction.Commit()
+}
+
+// UseTOTPStep remembers step of used TOTP code. Returns InvalidMFACodeError, if code of the same
+// or later step has been already used, which prevents replay of intercepted codes.
+func (repo *AuthRepository) UseTOTPStep(ctx context.Context, totpSecretID uint64, step int64) error {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Update(totpSecretsTableName).
+		Where(sq.Eq{idColumnName: totpSecretID}).
+		Where(sq.Lt{totpLastUsedStepColumnName: step}).
+		Set(totpLastUsedStepColumnName, step).
+		Set(updatedAtColumnName, time.Now().UTC()).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := connection.ExecContext(ctx, stmt, params...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return &customerrors.InvalidMFACodeError{Message: "two-factor authentication code has been already used"}
+	}
+
+	return nil
+}
+
+// UseRecoveryCode marks recovery code of User as used. Returns InvalidMFACodeError,
+// if there is no such code or it has been already used.
+func (repo *AuthRepository) UseRecoveryCode(ctx context.Context, userID uint64, codeHash string) error {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Update(recoveryCodesTableName).
+		Where(sq.Eq{userIDColumnName: userID}).
+		Where(sq.Eq{codeHashColumnName: codeHash}).
+		Where(sq.Eq{recoveryCodeUsedAtColumn: nil}).
+		Set(recoveryCodeUsedAtColumn, time.Now().UTC()).
+		Set(updatedAtColumnName, time.Now().UTC()).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := connection.ExecContext(ctx, stmt, params...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return &customerrors.InvalidMFACodeError{}
+	}
+
+	return nil
+}
+
+// DeleteTOTPSecret disables two-factor authentication for User and removes User's recovery codes.
+func (repo *AuthRepository) DeleteTOTPSecret(ctx context.Context, userID uint64) error {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	transaction, err := repo.dbConnector.Transaction(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Rollback transaction according Go best practises https://go.dev/doc/database/execute-transactions.
+	defer func() {
+		if err = transaction.Rollback(); err != nil {
+			logging.LogErrorContext(ctx, repo.logger, "failed to rollback db transaction", err)
+		}
+	}()
+
+	var (
+		stmt   string
+		params []any
+	)
+
+	for _, tableName := range []string{totpSecretsTableName, recoveryCodesTableName} {
+		stmt, params, err = sq.
+			Delete(tableName).
+			Where(sq.Eq{userIDColumnName: userID}).
+			PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+			ToSql()
+		if err != nil {
+			return err
+		}
+
+		if _, err = transaction.ExecContext(ctx, stmt, params...); err != nil {
+			return err
+		}
+	}
+
+	return transaction.Commit()
+}
+
+func (repo *AuthRepository) CreateMFAChallenge(
+	ctx context.Context,
+	challengeData entities.CreateMFAChallengeDTO,
+) (uint64, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Insert(mfaChallengesTableName).
+		Columns(
+			userIDColumnName,
+			tokenHashColumnName,
+			tokenTTLColumnName,
+		).
+		Values(
+			challengeData.UserID,
+			challengeData.TokenHash,
+			time.Now().UTC().Add(challengeData.TTL),
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var challengeID uint64
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(&challengeID); err != nil {
+		return 0, err
+	}
+
+	return challengeID, nil
+}
+
+// GetMFAChallengeByHash returns only not expired MFA challenge.
+func (repo *AuthRepository) GetMFAChallengeByHash(
+	ctx context.Context,
+	tokenHash string,
+) (*entities.MFAChallenge, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(mfaChallengesTableName).
+		Where(sq.Eq{tokenHashColumnName: tokenHash}).
+		Where(
+			sq.Expr(
+				tokenTTLColumnName + " > CURRENT_TIMESTAMP",
+			),
+		).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	challenge := &entities.MFAChallenge{}
+
+	columns := db.GetEntityColumns(challenge)
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+		return nil, err
+	}
+
+	return challenge, nil
+}
+
+// ExpireMFAChallenge consumes MFA challenge. Returns InvalidMFAChallengeError, if challenge is already expired,
+// so one challenge can not be completed twice by concurrent requests.
+func (repo *AuthRepository) ExpireMFAChallenge(ctx context.Context, challengeID uint64) error {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Update(mfaChallengesTableName).
+		Where(sq.Eq{idColumnName: challengeID}).
+		Where(
+			sq.Expr(
+				tokenTTLColumnName+" > CURRENT_TIMESTAMP",
+			),
+		).
+		Set(
+			tokenTTLColumnName,
+			time.Now().UTC().Add(time.Hour*time.Duration(-24)),
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := connection.ExecContext(ctx, stmt, params...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return &customerrors.InvalidMFAChallengeError{}
+	}
+
+	return nil
+}
+
+func (repo *AuthRepository) CreateWebAuthnCredential(
+	ctx context.Context,
+	credentialData entities.CreateWebAuthnCredentialDTO,
+) (uint64, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Insert(webAuthnCredentialsTable).
+		Columns(
+			userIDColumnName,
+			credentialIDColumnName,
+			publicKeyColumnName,
+			signCountColumnName,
+			transportsColumnName,
+		).
+		Values(
+			credentialData.UserID,
+			credentialData.CredentialID,
+			credentialData.PublicKey,
+			credentialData.SignCount,
+			credentialData.Transports,
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var credentialID uint64
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(&credentialID); err != nil {
+		return 0, err
+	}
+
+	return credentialID, nil
+}
+
+// GetWebAuthnCredential returns credential by its ID, which is provided by authenticator.
+func (repo *AuthRepository) GetWebAuthnCredential(
+	ctx context.Context,
+	credentialID string,
+) (*entities.WebAuthnCredential, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(webAuthnCredentialsTable).
+		Where(sq.Eq{credentialIDColumnName: credentialID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	credential := &entities.WebAuthnCredential{}
+
+	columns := db.GetEntityColumns(credential)
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &customerrors.WebAuthnCredentialNotFoundError{BaseErr: err}
+		}
+
+		return nil, err
+	}
+
+	return credential, nil
+}
+
+func (repo *AuthRepository) GetUserWebAuthnCredentials(
+	ctx context.Context,
+	userID uint64,
+) ([]entities.WebAuthnCredential, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(webAuthnCredentialsTable).
+		Where(sq.Eq{userIDColumnName: userID}).
+		OrderBy(idColumnName).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := connection.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logging.LogErrorContext(
+				ctx,
+				repo.logger,
+				"error during closing SQL rows",
+				err,
+			)
+		}
+	}()
+
+	var credentials []entities.WebAuthnCredential
+
+	for rows.Next() {
+		credential := entities.WebAuthnCredential{}
+		columns := db.GetEntityColumns(&credential) // Only pointer to use rows.Scan() successfully
+
+		err = rows.Scan(columns...)
+		if err != nil {
+			return nil, err
+		}
+
+		credentials = append(credentials, credential)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return credentials, nil
+}
+
+// UpdateWebAuthnCredentialSignCount saves sign count of credential after login. Returns InvalidWebAuthnCredentialError,
+// if sign count has not increased, which means, that credential was cloned or assertion was replayed.
+func (repo *AuthRepository) UpdateWebAuthnCredentialSignCount(
+	ctx context.Context,
+	credentialID uint64,
+	signCount uint32,
+) error {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Update(webAuthnCredentialsTable).
+		Where(sq.Eq{idColumnName: credentialID}).
+		Where(sq.Lt{signCountColumnName: signCount}).
+		Set(signCountColumnName, signCount).
+		Set(updatedAtColumnName, time.Now().UTC()).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := connection.ExecContext(ctx, stmt, params...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return &customerrors.InvalidWebAuthnCredentialError{Message: "sign count of webauthn credential has not increased"}
+	}
+
+	return nil
+}
+
+func (repo *AuthRepository) CreateWebAuthnChallenge(
+	ctx context.Context,
+	challengeData entities.CreateWebAuthnChallengeDTO,
+) (uint64, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Insert(webAuthnChallengesTable).
+		Columns(
+			userIDColumnName,
+			challengeHashColumnName,
+			ceremonyColumnName,
+			tokenTTLColumnName,
+		).
+		Values(
+			challengeData.UserID,
+			challengeData.ChallengeHash,
+			challengeData.Ceremony,
+			time.Now().UTC().Add(challengeData.TTL),
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var challengeID uint64
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(&challengeID); err != nil {
+		return 0, err
+	}
+
+	return challengeID, nil
+}
+
+// GetWebAuthnChallengeByHash returns only not expired WebAuthn challenge of provided ceremony.
+func (repo *AuthRepository) GetWebAuthnChallengeByHash(
+	ctx context.Context,
+	challengeHash string,
+	ceremony string,
+) (*entities.WebAuthnChallenge, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(webAuthnChallengesTable).
+		Where(sq.Eq{challengeHashColumnName: challengeHash}).
+		Where(sq.Eq{ceremonyColumnName: ceremony}).
+		Where(
+			sq.Expr(
+				tokenTTLColumnName + " > CURRENT_TIMESTAMP",
+			),
+		).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	challenge := &entities.WebAuthnChallenge{}
+
+	columns := db.GetEntityColumns(challenge)
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+		return nil, err
+	}
+
+	return challenge, nil
+}
+
+// ExpireWebAuthnChallenge consumes WebAuthn challenge. Returns InvalidWebAuthnChallengeError,
+// if challenge is already expired, so one challenge can not be used by concurrent requests.
+func (repo *AuthRepository) ExpireWebAuthnChallenge(ctx context.Context, challengeID uint64) error {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Update(webAuthnChallengesTable).
+		Where(sq.Eq{idColumnName: challengeID}).
+		Where(
+			sq.Expr(
+				tokenTTLColumnName+" > CURRENT_TIMESTAMP",
+			),
+		).
+		Set(
+			tokenTTLColumnName,
+			time.Now().UTC().Add(time.Hour*time.Duration(-24)),
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := connection.ExecContext(ctx, stmt, params...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return &customerrors.InvalidWebAuthnChallengeError{}
+	}
+
+	return nil
+}
+
+func (repo *AuthRepository) CreateLoginToken(
+	ctx context.Context,
+	tokenData entities.CreateLoginTokenDTO,
+) (uint64, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Insert(loginTokensTableName).
+		Columns(
+			userIDColumnName,
+			tokenHashColumnName,
+			codeHashColumnName,
+			tokenTTLColumnName,
+		).
+		Values(
+			tokenData.UserID,
+			tokenData.TokenHash,
+			tokenData.CodeHash,
+			time.Now().UTC().Add(tokenData.TTL),
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var tokenID uint64
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(&tokenID); err != nil {
+		return 0, err
+	}
+
+	return tokenID, nil
+}
+
+func (repo *AuthRepository) GetLoginTokenByHash(
+	ctx context.Context,
+	tokenHash string,
+) (*entities.LoginToken, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(loginTokensTableName).
+		Where(sq.Eq{tokenHashColumnName: tokenHash}).
+		Where(
+			sq.Expr(
+				tokenTTLColumnName + " > CURRENT_TIMESTAMP",
+			),
+		).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	loginToken := &entities.LoginToken{}
+
+	columns := db.GetEntityColumns(loginToken)
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+		return nil, err
+	}
+
+	return loginToken, nil
+}
+
+// GetLoginTokenByUserID returns the latest not expired login token of User, so only the latest sent code is valid.
+func (repo *AuthRepository) GetLoginTokenByUserID(
+	ctx context.Context,
+	userID uint64,
+) (*entities.LoginToken, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(loginTokensTableName).
+		Where(sq.Eq{userIDColumnName: userID}).
+		Where(
+			sq.Expr(
+				tokenTTLColumnName + " > CURRENT_TIMESTAMP",
+			),
+		).
+		OrderBy(fmt.Sprintf("%s %s", idColumnName, DESC)).
+		Limit(1).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	loginToken := &entities.LoginToken{}
+
+	columns := db.GetEntityColumns(loginToken)
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+		return nil, err
+	}
+
+	return loginToken, nil
+}
+
+// UseLoginToken consumes login token and expires all other User's login tokens. Returns InvalidLoginTokenError,
+// if token is already expired, so one token can not be used by concurrent requests.
+func (repo *AuthRepository) UseLoginToken(ctx context.Context, userID, loginTokenID uint64) error {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	transaction, err := repo.dbConnector.Transaction(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Rollback transaction according Go best practises https://go.dev/doc/database/execute-transactions.
+	defer func() {
+		if err = transaction.Rollback(); err != nil {
+			logging.LogErrorContext(ctx, repo.logger, "failed to rollback db transaction", err)
+		}
+	}()
+
+	stmt, params, err := sq.
+		Update(loginTokensTableName).
+		Where(sq.Eq{idColumnName: loginTokenID}).
+		Where(sq.Eq{userIDColumnName: userID}).
+		Where(
+			sq.Expr(
+				tokenTTLColumnName+" > CURRENT_TIMESTAMP",
+			),
+		).
+		Set(
+			tokenTTLColumnName,
+			time.Now().UTC().Add(time.Hour*time.Duration(-24)),
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := transaction.ExecContext(ctx, stmt, params...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return &customerrors.InvalidLoginTokenError{}
+	}
+
+	stmt, params, err = sq.
+		Update(loginTokensTableName).
+		Where(sq.Eq{userIDColumnName: userID}).
+		Where(
+			sq.Expr(
+				tokenTTLColumnName+" > CURRENT_TIMESTAMP",
+			),
+		).
+		Set(
+			tokenTTLColumnName,
+			time.Now().UTC().Add(time.Hour*time.Duration(-24)),
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = transaction.ExecContext(ctx, stmt, params...); err != nil {
+		return err
+	}
+
+	return transaction.Commit()
+}
+
+func (repo *AuthRepository) CreatePhoneVerificationCode(
+	ctx context.Context,
+	codeData entities.CreatePhoneVerificationCodeDTO,
+) (uint64, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Insert(phoneVerificationCodesTable).
+		Columns(
+			userIDColumnName,
+			verificationPhoneColumn,
+			codeHashColumnName,
+			tokenTTLColumnName,
+		).
+		Values(
+			codeData.UserID,
+			codeData.Phone,
+			codeData.CodeHash,
+			time.Now().UTC().Add(codeData.TTL),
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var codeID uint64
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(&codeID); err != nil {
+		return 0, err
+	}
+
+	return codeID, nil
+}
+
+// GetPhoneVerificationCodeByUserID returns the latest not expired phone verification code of User,
+// so only the latest sent code is valid.
+func (repo *AuthRepository) GetPhoneVerificationCodeByUserID(
+	ctx context.Context,
+	userID uint64,
+) (*entities.PhoneVerificationCode, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(phoneVerificationCodesTable).
+		Where(sq.Eq{userIDColumnName: userID}).
+		Where(
+			sq.Expr(
+				tokenTTLColumnName + " > CURRENT_TIMESTAMP",
+			),
+		).
+		OrderBy(fmt.Sprintf("%s %s", idColumnName, DESC)).
+		Limit(1).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	phoneVerificationCode := &entities.PhoneVerificationCode{}
+
+	columns := db.GetEntityColumns(phoneVerificationCode)
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+		return nil, err
+	}
+
+	return phoneVerificationCode, nil
+}
+
+// VerifyUserPhone consumes phone verification code, expires all other User's codes and confirms User's phone,
+// if it is still the same as phone, which code was sent to. Returns InvalidPhoneVerificationCodeError,
+// if code is already expired, so one code can not be used by concurrent requests.
+func (repo *AuthRepository) VerifyUserPhone(ctx context.Context, userID, phoneVerificationCodeID uint64) error {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	transaction, err := repo.dbConnector.Transaction(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Rollback transaction according Go best practises https://go.dev/doc/database/execute-transactions.
+	defer func() {
+		if err = transaction.Rollback(); err != nil {
+			logging.LogErrorContext(ctx, repo.logger, "failed to rollback db transaction", err)
+		}
+	}()
+
+	stmt, params, err := sq.
+		Update(phoneVerificationCodesTable).
+		Where(sq.Eq{idColumnName: phoneVerificationCodeID}).
+		Where(sq.Eq{userIDColumnName: userID}).
+		Where(
+			sq.Expr(
+				tokenTTLColumnName+" > CURRENT_TIMESTAMP",
+			),
+		).
+		Set(
+			tokenTTLColumnName,
+			time.Now().UTC().Add(time.Hour*time.Duration(-24)),
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := transaction.ExecContext(ctx, stmt, params...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return &customerrors.InvalidPhoneVerificationCodeError{}
+	}
+
+	stmt, params, err = sq.
+		Update(phoneVerificationCodesTable).
+		Where(sq.Eq{userIDColumnName: userID}).
+		Where(
+			sq.Expr(
+				tokenTTLColumnName+" > CURRENT_TIMESTAMP",
+			),
+		).
+		Set(
+			tokenTTLColumnName,
+			time.Now().UTC().Add(time.Hour*time.Duration(-24)),
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = transaction.ExecContext(ctx, stmt, params...); err != nil {
+		return err
+	}
+
+	stmt, params, err = sq.
+		Update(usersTableName).
+		Where(sq.Eq{idColumnName: userID}).
+		Where(
+			sq.Expr(
+				userPhoneColumnName+" = (SELECT "+verificationPhoneColumn+
+					" FROM "+phoneVerificationCodesTable+" WHERE "+idColumnName+" = ?)",
+				phoneVerificationCodeID,
+			),
+		).
+		Set(userPhoneConfirmedColumnName, true).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = transaction.ExecContext(ctx, stmt, params...); err != nil {
+		return err
+	}
+
+	return transaction.Commit()
+}
+
+func (repo *AuthRepository) CreateAuthorizationCode(
+	ctx context.Context,
+	codeData entities.CreateAuthorizationCodeDTO,
+) (uint64, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Insert(authorizationCodesTable).
+		Columns(
+			userIDColumnName,
+			clientIDColumnName,
+			codeHashColumnName,
+			redirectURIColumnName,
+			scopeColumnName,
+			nonceColumnName,
+			codeChallengeColumnName,
+			tokenTTLColumnName,
+		).
+		Values(
+			codeData.UserID,
+			codeData.ClientID,
+			codeData.CodeHash,
+			codeData.RedirectURI,
+			codeData.Scope,
+			codeData.Nonce,
+			codeData.CodeChallenge,
+			time.Now().UTC().Add(codeData.TTL),
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var codeID uint64
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(&codeID); err != nil {
+		return 0, err
+	}
+
+	return codeID, nil
+}
+
+func (repo *AuthRepository) GetAuthorizationCodeByHash(
+	ctx context.Context,
+	codeHash string,
+) (*entities.AuthorizationCode, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(authorizationCodesTable).
+		Where(sq.Eq{codeHashColumnName: codeHash}).
+		Where(
+			sq.Expr(
+				tokenTTLColumnName + " > CURRENT_TIMESTAMP",
+			),
+		).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	authorizationCode := &entities.AuthorizationCode{}
+
+	columns := db.GetEntityColumns(authorizationCode)
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+		return nil, err
+	}
+
+	return authorizationCode, nil
+}
+
+// UseAuthorizationCode consumes authorization code. Returns InvalidAuthorizationCodeError,
+// if code is already expired, so one code can not be exchanged for tokens by concurrent requests.
+func (repo *AuthRepository) UseAuthorizationCode(ctx context.Context, codeID uint64) error {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Update(authorizationCodesTable).
+		Where(sq.Eq{idColumnName: codeID}).
+		Where(
+			sq.Expr(
+				tokenTTLColumnName+" > CURRENT_TIMESTAMP",
+			),
+		).
+		Set(
+			tokenTTLColumnName,
+			time.Now().UTC().Add(time.Hour*time.Duration(-24)),
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := connection.ExecContext(ctx, stmt, params...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return &customerrors.InvalidAuthorizationCodeError{}
+	}
+
+	return nil
+}
+
+// CreateClient registers Client. TTLs are stored in seconds.
+func (repo *AuthRepository) CreateClient(ctx context.Context, clientData entities.CreateClientDTO) (uint64, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Insert(clientsTableName).
+		Columns(
+			clientIDColumnName,
+			secretHashColumnName,
+			redirectURIsColumnName,
+			grantTypesColumnName,
+			scopesColumnName,
+			clientAccessTokenTTLColumn,
+			clientRefreshTokenTTLColumn,
+		).
+		Values(
+			clientData.ClientID,
+			clientData.SecretHash,
+			clientData.RedirectURIs,
+			clientData.GrantTypes,
+			clientData.Scopes,
+			int64(clientData.AccessTokenTTL.Seconds()),
+			int64(clientData.RefreshTokenTTL.Seconds()),
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var clientID uint64
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(&clientID); err != nil {
+		return 0, err
+	}
+
+	return clientID, nil
+}
+
+// GetClientByClientID returns ClientNotFoundError, if Client has not been registered or has been deleted.
+func (repo *AuthRepository) GetClientByClientID(ctx context.Context, clientID string) (*entities.Client, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(clientsTableName).
+		Where(sq.Eq{clientIDColumnName: clientID}).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	client := &entities.Client{}
+
+	columns := db.GetEntityColumns(client)
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &customerrors.ClientNotFoundError{BaseErr: err}
+		}
+
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (repo *AuthRepository) GetClients(ctx context.Context) ([]entities.Client, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(clientsTableName).
+		OrderBy(idColumnName).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := connection.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err = rows.Close(); err != nil {
+			logging.LogErrorContext(
+				ctx,
+				repo.logger,
+				"error during closing SQL rows",
+				err,
+			)
+		}
+	}()
+
+	var clients []entities.Client
+
+	for rows.Next() {
+		client := entities.Client{}
+		columns := db.GetEntityColumns(&client) // Only pointer to use rows.Scan() successfully
+
+		err = rows.Scan(columns...)
+		if err != nil {
+			return nil, err
+		}
+
+		clients = append(clients, client)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return clients, nil
+}
+
+// UpdateClient replaces settings of Client. Secret of Client is never changed.
+func (repo *AuthRepository) UpdateClient(ctx context.Context, clientData entities.UpdateClientSettingsDTO) error {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Update(clientsTableName).
+		Where(sq.Eq{clientIDColumnName: clientData.ClientID}).
+		Set(redirectURIsColumnName, clientData.RedirectURIs).
+		Set(grantTypesColumnName, clientData.GrantTypes).
+		Set(scopesColumnName, clientData.Scopes).
+		Set(clientAccessTokenTTLColumn, int64(clientData.AccessTokenTTL.Seconds())).
+		Set(clientRefreshTokenTTLColumn, int64(clientData.RefreshTokenTTL.Seconds())).
+		Set(updatedAtColumnName, time.Now().UTC()).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := connection.ExecContext(ctx, stmt, params...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return &customerrors.ClientNotFoundError{}
+	}
+
+	return nil
+}
+
+// DeleteClient removes Client from registry. Refresh tokens of deleted Client can not be used anymore.
+func (repo *AuthRepository) DeleteClient(ctx context.Context, clientID string) error {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Delete(clientsTableName).
+		Where(sq.Eq{clientIDColumnName: clientID}).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := connection.ExecContext(ctx, stmt, params...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return &customerrors.ClientNotFoundError{}
+	}
+
+	return nil
+}
+
+func (repo *AuthRepository) CreateUserIdentity(
+	ctx context.Context,
+	identityData entities.CreateUserIdentityDTO,
+) (uint64, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Insert(userIdentitiesTableName).
+		Columns(
+			userIDColumnName,
+			providerColumnName,
+			subjectColumnName,
+			userIdentityEmailColumn,
+		).
+		Values(
+			identityData.UserID,
+			identityData.Provider,
+			identityData.Subject,
+			identityData.Email,
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var identityID uint64
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(&identityID); err != nil {
+		return 0, err
+	}
+
+	return identityID, nil
+}
+
+// GetUserIdentity returns UserIdentityNotFoundError, if account of identity provider has not been linked to any User.
+func (repo *AuthRepository) GetUserIdentity(
+	ctx context.Context,
+	provider string,
+	subject string,
+) (*entities.UserIdentity, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(userIdentitiesTableName).
+		Where(
+			sq.And{
+				sq.Eq{providerColumnName: provider},
+				sq.Eq{subjectColumnName: subject},
+			},
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &entities.UserIdentity{}
+
+	columns := db.GetEntityColumns(identity)
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &customerrors.UserIdentityNotFoundError{BaseErr: err}
+		}
+
+		return nil, err
+	}
+
+	return identity, nil
 }
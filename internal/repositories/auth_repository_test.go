@@ -4,13 +4,16 @@ package repositories_test
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"fmt"
 	"os"
 	"path"
 	"testing"
 	"time"
 
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/mattn/go-sqlite3"
 	"github.com/pressly/goose/v3"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -23,21 +26,65 @@ import (
 	mocktracing "github.com/DKhorkov/libs/tracing/mocks"
 
 	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
 	"github.com/DKhorkov/hmtm-sso/internal/interfaces"
 	"github.com/DKhorkov/hmtm-sso/internal/repositories"
 )
 
 const (
 	driver = "sqlite3"
+	// sqlDriver is SQLite driver with PostgreSQL functions, which are used by migrations:
+	sqlDriver = "sqlite3_with_postgres_functions"
 	//dsn    = "file::memory:?cache=shared"
 	dsn              = "../../test.db"
 	migrationsDir    = "/migrations"
 	gooseZeroVersion = 0
-	userID           = 1
-	email            = "user@example.com"
-	refreshTokenID   = 1
+	// hashLegacyRefreshTokensVersion is version of migration, which replaces legacy refresh tokens with digests:
+	hashLegacyRefreshTokensVersion = 20261017121300
+	userID                         = 1
+	email                          = "user@example.com"
+	refreshTokenID                 = 1
+	sessionID                      = 1
+	familyID                       = "family"
 )
 
+func init() {
+	sql.Register(
+		sqlDriver,
+		&sqlite3.SQLiteDriver{
+			ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+				if err := conn.RegisterFunc("convert_to", convertTo, true); err != nil {
+					return err
+				}
+
+				if err := conn.RegisterFunc("sha256", sha256Digest, true); err != nil {
+					return err
+				}
+
+				return conn.RegisterFunc("encode", encode, true)
+			},
+		},
+	)
+}
+
+func convertTo(value, _ string) []byte {
+	return []byte(value)
+}
+
+func sha256Digest(data []byte) []byte {
+	digest := sha256.Sum256(data)
+
+	return digest[:]
+}
+
+func encode(data []byte, format string) (string, error) {
+	if format != "hex" {
+		return "", fmt.Errorf("unsupported format %q", format)
+	}
+
+	return hex.EncodeToString(data), nil
+}
+
 var (
 	ctx         = context.Background()
 	testUserDTO = entities.RegisterUserDTO{
@@ -61,6 +108,113 @@ var (
 		Value:  "refresh_token",
 		TTL:    time.Now().UTC().Add(ttl),
 	}
+
+	verifyEmailToken = &entities.VerifyEmailToken{
+		ID:        2,
+		UserID:    userID,
+		Email:     email,
+		TokenHash: "token_hash",
+		CodeHash:  "code_hash",
+		TTL:       time.Now().UTC().Add(ttl),
+	}
+
+	refreshTokenDTO = entities.CreateRefreshTokenDTO{
+		UserID:    userID,
+		SessionID: sessionID,
+		FamilyID:  familyID,
+		Value:     refreshToken.Value,
+		TTL:       ttl,
+	}
+
+	session = entities.CreateSessionDTO{
+		UserID: userID,
+		ClientInfo: entities.ClientInfo{
+			DeviceName: "iPhone",
+			UserAgent:  "Mozilla/5.0",
+			IP:         "127.0.0.1",
+		},
+		TTL: ttl,
+	}
+
+	forgetPasswordToken = &entities.ForgetPasswordToken{
+		ID:        1,
+		UserID:    userID,
+		TokenHash: "forget_password_token_hash",
+		TTL:       time.Now().UTC().Add(ttl),
+	}
+
+	totpSecret = &entities.TOTPSecret{
+		ID:     1,
+		UserID: userID,
+		Secret: "encrypted_secret",
+	}
+
+	mfaChallenge = &entities.MFAChallenge{
+		ID:        1,
+		UserID:    userID,
+		TokenHash: "mfa_challenge_token_hash",
+		TTL:       time.Now().UTC().Add(ttl),
+	}
+
+	webAuthnCredential = &entities.WebAuthnCredential{
+		ID:           1,
+		UserID:       userID,
+		CredentialID: "credential_id",
+		PublicKey:    []byte("public_key"),
+		SignCount:    1,
+		Transports:   "internal,hybrid",
+	}
+
+	webAuthnChallenge = &entities.WebAuthnChallenge{
+		ID:            1,
+		UserID:        pointers.New[uint64](userID),
+		ChallengeHash: "webauthn_challenge_hash",
+		Ceremony:      entities.WebAuthnLoginCeremony,
+		TTL:           time.Now().UTC().Add(ttl),
+	}
+	loginToken = &entities.LoginToken{
+		ID:        1,
+		UserID:    userID,
+		TokenHash: "login_token_hash",
+		CodeHash:  "login_code_hash",
+		TTL:       time.Now().UTC().Add(ttl),
+	}
+
+	authorizationCode = &entities.AuthorizationCode{
+		ID:            1,
+		UserID:        userID,
+		ClientID:      "shop",
+		CodeHash:      "authorization_code_hash",
+		RedirectURI:   "https://shop.example.com/callback",
+		Scope:         "openid email",
+		Nonce:         "nonce",
+		CodeChallenge: "code_challenge",
+		TTL:           time.Now().UTC().Add(ttl),
+	}
+	client = &entities.Client{
+		ID:              1,
+		ClientID:        "shop",
+		SecretHash:      "secret_hash",
+		RedirectURIs:    "https://shop.example.com/callback",
+		GrantTypes:      "authorization_code,refresh_token",
+		Scopes:          "openid email",
+		AccessTokenTTL:  60,
+		RefreshTokenTTL: 3600,
+	}
+	userIdentity = &entities.UserIdentity{
+		ID:       1,
+		UserID:   userID,
+		Provider: "google",
+		Subject:  "google_subject",
+		Email:    email,
+	}
+	phoneVerificationCode = &entities.PhoneVerificationCode{
+		ID:       1,
+		UserID:   userID,
+		Phone:    "+79991234567",
+		CodeHash: "phone_code_hash",
+		TTL:      time.Now().UTC().Add(ttl),
+	}
 )
 
 func TestAuthRepositoryTestSuite(t *testing.T) {
@@ -86,7 +240,7 @@ func (s *AuthRepositoryTestSuite) SetupSuite() {
 	ctrl := gomock.NewController(s.T())
 	s.ctx = context.Background()
 	s.logger = loggermock.NewMockLogger(ctrl)
-	dbConnector, err := db.New(dsn, driver, s.logger)
+	dbConnector, err := db.New(dsn, sqlDriver, s.logger)
 	s.NoError(err)
 
 	cwd, err := os.Getwd()
@@ -173,11 +327,81 @@ func (s *AuthRepositoryTestSuite) TestRegisterUserFailEmailAlreadyExists() {
 	s.Zero(userID)
 }
 
+func (s *AuthRepositoryTestSuite) TestImportUserSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	err := s.authRepository.ImportUser(
+		ctx,
+		entities.ImportUserDTO{
+			DisplayName:    testUserDTO.DisplayName,
+			Email:          testUserDTO.Email,
+			PasswordHash:   testUserDTO.Password,
+			EmailConfirmed: true,
+		},
+	)
+	s.NoError(err)
+
+	var (
+		password       string
+		emailConfirmed bool
+	)
+
+	err = s.connection.QueryRowContext(
+		ctx,
+		"SELECT password, email_confirmed FROM users WHERE email = $1",
+		testUserDTO.Email,
+	).Scan(&password, &emailConfirmed)
+	s.NoError(err)
+	s.Equal(testUserDTO.Password, password)
+	s.True(emailConfirmed)
+}
+
+func (s *AuthRepositoryTestSuite) TestImportUserFailEmailAlreadyExists() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO users (id, display_name, email, password) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		userID,
+		testUserDTO.DisplayName,
+		testUserDTO.Email,
+		testUserDTO.Password,
+	)
+
+	s.NoError(err)
+
+	err = s.authRepository.ImportUser(
+		ctx,
+		entities.ImportUserDTO{
+			DisplayName:  testUserDTO.DisplayName,
+			Email:        testUserDTO.Email,
+			PasswordHash: testUserDTO.Password,
+		},
+	)
+	s.Error(err)
+}
+
 func (s *AuthRepositoryTestSuite) TestVerifyUserEmailSuccess() {
 	s.traceProvider.
 		EXPECT().
 		Span(gomock.Any(), gomock.Any()).
 		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(2)
+
+	s.logger.
+		EXPECT().
+		ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
 		Times(1)
 
 	_, err := s.connection.ExecContext(
@@ -194,8 +418,29 @@ func (s *AuthRepositoryTestSuite) TestVerifyUserEmailSuccess() {
 
 	s.NoError(err)
 
+	_, err = s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO verify_email_tokens (id, user_id, email, token_hash, code_hash, ttl) 
+				VALUES ($1, $2, $3, $4, $5, $6)
+			`,
+		verifyEmailToken.ID,
+		verifyEmailToken.UserID,
+		verifyEmailToken.Email,
+		verifyEmailToken.TokenHash,
+		verifyEmailToken.CodeHash,
+		verifyEmailToken.TTL,
+	)
+
+	s.NoError(err)
+
 	err = s.authRepository.VerifyUserEmail(ctx, uint64(1))
 	s.NoError(err)
+
+	// Token must be expired after email verification to be single-use:
+	token, err := s.authRepository.GetVerifyEmailTokenByHash(ctx, verifyEmailToken.TokenHash)
+	s.Error(err)
+	s.Nil(token)
 }
 
 func (s *AuthRepositoryTestSuite) TestVerifyUserEmailUserDoesNotExist() {
@@ -205,31 +450,55 @@ func (s *AuthRepositoryTestSuite) TestVerifyUserEmailUserDoesNotExist() {
 		Return(context.Background(), mocktracing.NewMockSpan()).
 		Times(1)
 
+	s.logger.
+		EXPECT().
+		ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1)
+
 	err := s.authRepository.VerifyUserEmail(ctx, uint64(1))
 	s.NoError(err)
 }
 
-func (s *AuthRepositoryTestSuite) TestCreateRefreshTokenSuccess() {
+func (s *AuthRepositoryTestSuite) TestConfirmUserTelegramSuccess() {
 	s.traceProvider.
 		EXPECT().
 		Span(gomock.Any(), gomock.Any()).
 		Return(context.Background(), mocktracing.NewMockSpan()).
 		Times(1)
 
-	// Error and zero userID due to returning nil ID after register.
-	// SQLite inner realization without AUTO_INCREMENT for SERIAL PRIMARY KEY
-	refreshTokenID, err := s.authRepository.CreateRefreshToken(
+	_, err := s.connection.ExecContext(
 		ctx,
+		`
+				INSERT INTO users (id, display_name, email, password) 
+				VALUES ($1, $2, $3, $4)
+			`,
 		userID,
-		refreshToken.Value,
-		ttl,
+		testUserDTO.DisplayName,
+		testUserDTO.Email,
+		testUserDTO.Password,
 	)
 
-	s.Error(err)
-	s.Zero(refreshTokenID)
+	s.NoError(err)
+
+	err = s.authRepository.ConfirmUserTelegram(ctx, userID, "@ivan_petrov")
+	s.NoError(err)
+
+	var (
+		telegram          string
+		telegramConfirmed bool
+	)
+
+	err = s.connection.QueryRowContext(
+		ctx,
+		"SELECT telegram, telegram_confirmed FROM users WHERE id = $1",
+		userID,
+	).Scan(&telegram, &telegramConfirmed)
+	s.NoError(err)
+	s.Equal("@ivan_petrov", telegram)
+	s.True(telegramConfirmed)
 }
 
-func (s *AuthRepositoryTestSuite) TestCreateRefreshTokenAlreadyExists() {
+func (s *AuthRepositoryTestSuite) TestCreateVerifyEmailTokenSuccess() {
 	s.traceProvider.
 		EXPECT().
 		Span(gomock.Any(), gomock.Any()).
@@ -239,29 +508,35 @@ func (s *AuthRepositoryTestSuite) TestCreateRefreshTokenAlreadyExists() {
 	_, err := s.connection.ExecContext(
 		ctx,
 		`
-				INSERT INTO refresh_tokens (id, user_id, value, ttl) 
+				INSERT INTO users (id, display_name, email, password) 
 				VALUES ($1, $2, $3, $4)
 			`,
-		refreshTokenID,
 		userID,
-		refreshToken.Value,
-		refreshToken.TTL,
+		testUserDTO.DisplayName,
+		testUserDTO.Email,
+		testUserDTO.Password,
 	)
 
 	s.NoError(err)
 
-	refreshTokenID, err := s.authRepository.CreateRefreshToken(
+	// Error and zero ID due to returning nil ID after register.
+	// SQLite inner realization without AUTO_INCREMENT for SERIAL PRIMARY KEY
+	tokenID, err := s.authRepository.CreateVerifyEmailToken(
 		ctx,
-		userID,
-		refreshToken.Value,
-		ttl,
+		entities.CreateVerifyEmailTokenDTO{
+			UserID:    userID,
+			Email:     email,
+			TokenHash: verifyEmailToken.TokenHash,
+			CodeHash:  verifyEmailToken.CodeHash,
+			TTL:       ttl,
+		},
 	)
 
 	s.Error(err)
-	s.Zero(refreshTokenID)
+	s.Zero(tokenID)
 }
 
-func (s *AuthRepositoryTestSuite) TestGetRefreshTokenByUserIDSuccess() {
+func (s *AuthRepositoryTestSuite) TestGetVerifyEmailTokenByHashSuccess() {
 	s.traceProvider.
 		EXPECT().
 		Span(gomock.Any(), gomock.Any()).
@@ -271,71 +546,115 @@ func (s *AuthRepositoryTestSuite) TestGetRefreshTokenByUserIDSuccess() {
 	_, err := s.connection.ExecContext(
 		ctx,
 		`
-				INSERT INTO refresh_tokens (id, user_id, value, ttl) 
-				VALUES ($1, $2, $3, $4)
+				INSERT INTO verify_email_tokens (id, user_id, email, token_hash, code_hash, ttl) 
+				VALUES ($1, $2, $3, $4, $5, $6)
 			`,
-		refreshTokenID,
-		userID,
-		refreshToken.Value,
-		refreshToken.TTL,
+		verifyEmailToken.ID,
+		verifyEmailToken.UserID,
+		verifyEmailToken.Email,
+		verifyEmailToken.TokenHash,
+		verifyEmailToken.CodeHash,
+		verifyEmailToken.TTL,
 	)
 
 	s.NoError(err)
 
-	refreshToken, err := s.authRepository.GetRefreshTokenByUserID(ctx, userID)
+	token, err := s.authRepository.GetVerifyEmailTokenByHash(ctx, verifyEmailToken.TokenHash)
 	s.NoError(err)
-	s.NotNil(refreshToken)
+	s.NotNil(token)
+	s.Equal(verifyEmailToken.UserID, token.UserID)
+	s.Equal(verifyEmailToken.Email, token.Email)
+	s.Equal(verifyEmailToken.CodeHash, token.CodeHash)
 }
 
-func (s *AuthRepositoryTestSuite) TestGetRefreshTokenByUserIDNotFound() {
+func (s *AuthRepositoryTestSuite) TestGetVerifyEmailTokenByHashExpired() {
 	s.traceProvider.
 		EXPECT().
 		Span(gomock.Any(), gomock.Any()).
 		Return(context.Background(), mocktracing.NewMockSpan()).
 		Times(1)
 
-	refreshToken, err := s.authRepository.GetRefreshTokenByUserID(ctx, userID)
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO verify_email_tokens (id, user_id, email, token_hash, code_hash, ttl) 
+				VALUES ($1, $2, $3, $4, $5, $6)
+			`,
+		verifyEmailToken.ID,
+		verifyEmailToken.UserID,
+		verifyEmailToken.Email,
+		verifyEmailToken.TokenHash,
+		verifyEmailToken.CodeHash,
+		time.Now().UTC().Add(-ttl),
+	)
+
+	s.NoError(err)
+
+	token, err := s.authRepository.GetVerifyEmailTokenByHash(ctx, verifyEmailToken.TokenHash)
 	s.Error(err)
-	s.Nil(refreshToken)
+	s.Nil(token)
 }
 
-func (s *AuthRepositoryTestSuite) TestExpireRefreshTokenSuccess() {
+func (s *AuthRepositoryTestSuite) TestGetVerifyEmailTokenByUserIDSuccess() {
 	s.traceProvider.
 		EXPECT().
 		Span(gomock.Any(), gomock.Any()).
 		Return(context.Background(), mocktracing.NewMockSpan()).
 		Times(1)
 
-	_, err := s.connection.ExecContext(
-		ctx,
-		`
-				INSERT INTO refresh_tokens (id, user_id, value, ttl) 
-				VALUES ($1, $2, $3, $4)
+	for i, tokenHash := range []string{"old_token_hash", verifyEmailToken.TokenHash} {
+		_, err := s.connection.ExecContext(
+			ctx,
+			`
+				INSERT INTO verify_email_tokens (id, user_id, email, token_hash, code_hash, ttl) 
+				VALUES ($1, $2, $3, $4, $5, $6)
 			`,
-		refreshTokenID,
-		userID,
-		refreshToken.Value,
-		refreshToken.TTL,
-	)
+			i+1,
+			verifyEmailToken.UserID,
+			verifyEmailToken.Email,
+			tokenHash,
+			verifyEmailToken.CodeHash,
+			verifyEmailToken.TTL,
+		)
 
-	s.NoError(err)
+		s.NoError(err)
+	}
 
-	err = s.authRepository.ExpireRefreshToken(ctx, refreshToken.Value)
+	// Latest token should be returned:
+	token, err := s.authRepository.GetVerifyEmailTokenByUserID(ctx, userID)
 	s.NoError(err)
+	s.NotNil(token)
+	s.Equal(verifyEmailToken.TokenHash, token.TokenHash)
 }
 
-func (s *AuthRepositoryTestSuite) TestExpireRefreshTokenDoesNotExist() {
+func (s *AuthRepositoryTestSuite) TestGetVerifyEmailTokenByUserIDNotFound() {
 	s.traceProvider.
 		EXPECT().
 		Span(gomock.Any(), gomock.Any()).
 		Return(context.Background(), mocktracing.NewMockSpan()).
 		Times(1)
 
-	err := s.authRepository.ExpireRefreshToken(ctx, refreshToken.Value)
-	s.NoError(err)
+	token, err := s.authRepository.GetVerifyEmailTokenByUserID(ctx, userID)
+	s.Error(err)
+	s.Nil(token)
 }
 
-func (s *AuthRepositoryTestSuite) TestChangePasswordSuccess() {
+func (s *AuthRepositoryTestSuite) TestCreateRefreshTokenSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	// Error and zero userID due to returning nil ID after register.
+	// SQLite inner realization without AUTO_INCREMENT for SERIAL PRIMARY KEY
+	refreshTokenID, err := s.authRepository.CreateRefreshToken(ctx, refreshTokenDTO)
+
+	s.Error(err)
+	s.Zero(refreshTokenID)
+}
+
+func (s *AuthRepositoryTestSuite) TestCreateRefreshTokenAlreadyExists() {
 	s.traceProvider.
 		EXPECT().
 		Span(gomock.Any(), gomock.Any()).
@@ -345,127 +664,2111 @@ func (s *AuthRepositoryTestSuite) TestChangePasswordSuccess() {
 	_, err := s.connection.ExecContext(
 		ctx,
 		`
-				INSERT INTO users (id, display_name, email, password) 
+				INSERT INTO refresh_tokens (id, user_id, value, ttl) 
 				VALUES ($1, $2, $3, $4)
 			`,
+		refreshTokenID,
 		userID,
-		testUserDTO.DisplayName,
-		testUserDTO.Email,
-		testUserDTO.Password,
+		refreshToken.Value,
+		refreshToken.TTL,
 	)
 
 	s.NoError(err)
 
-	err = s.authRepository.ChangePassword(ctx, userID, "new password")
-	s.NoError(err)
-}
-
-func (s *AuthRepositoryTestSuite) TestChangePasswordUserDoesNotExist() {
-	s.traceProvider.
-		EXPECT().
-		Span(gomock.Any(), gomock.Any()).
-		Return(context.Background(), mocktracing.NewMockSpan()).
-		Times(1)
+	refreshTokenID, err := s.authRepository.CreateRefreshToken(ctx, refreshTokenDTO)
 
-	err := s.authRepository.ChangePassword(ctx, userID, "new password")
-	s.NoError(err)
+	s.Error(err)
+	s.Zero(refreshTokenID)
 }
 
-func (s *AuthRepositoryTestSuite) TestForgetPasswordSuccess() {
+func (s *AuthRepositoryTestSuite) TestGetRefreshTokenByValueSuccess() {
 	s.traceProvider.
 		EXPECT().
 		Span(gomock.Any(), gomock.Any()).
 		Return(context.Background(), mocktracing.NewMockSpan()).
 		Times(1)
 
-	s.logger.
-		EXPECT().
-		ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
-		Times(1)
-
 	_, err := s.connection.ExecContext(
 		ctx,
 		`
-				INSERT INTO users (id, display_name, email, password) 
-				VALUES ($1, $2, $3, $4)
+				INSERT INTO refresh_tokens (id, user_id, value, ttl, session_id) 
+				VALUES ($1, $2, $3, $4, $5)
 			`,
+		refreshTokenID,
 		userID,
-		testUserDTO.DisplayName,
-		testUserDTO.Email,
-		testUserDTO.Password,
+		refreshToken.Value,
+		refreshToken.TTL,
+		sessionID,
 	)
 
 	s.NoError(err)
 
-	_, err = s.connection.ExecContext(
+	dbRefreshToken, err := s.authRepository.GetRefreshTokenByValue(ctx, refreshToken.Value)
+	s.NoError(err)
+	s.NotNil(dbRefreshToken)
+	s.Equal(uint64(userID), dbRefreshToken.UserID)
+	s.Equal(pointers.New[uint64](sessionID), dbRefreshToken.SessionID)
+}
+
+func (s *AuthRepositoryTestSuite) TestHashLegacyRefreshTokensMigration() {
+	migrationsPath := path.Dir(path.Dir(s.cwd)) + migrationsDir
+	s.NoError(goose.DownTo(s.dbConnector.Pool(), migrationsPath, hashLegacyRefreshTokensVersion-1))
+
+	const legacyRefreshToken = "header.payload.signature"
+
+	_, err := s.connection.ExecContext(
 		ctx,
 		`
 				INSERT INTO refresh_tokens (id, user_id, value, ttl) 
-				VALUES ($1, $2, $3, $4)
+				VALUES ($1, $2, $3, $4), ($5, $6, $7, $8)
 			`,
 		refreshTokenID,
 		userID,
+		legacyRefreshToken,
+		refreshToken.TTL,
+		refreshTokenID+1,
+		userID,
 		refreshToken.Value,
 		refreshToken.TTL,
 	)
 
 	s.NoError(err)
+	s.NoError(goose.Up(s.dbConnector.Pool(), migrationsPath))
 
-	err = s.authRepository.ForgetPassword(ctx, userID, "new password")
+	rows, err := s.connection.QueryContext(ctx, "SELECT value FROM refresh_tokens ORDER BY id")
 	s.NoError(err)
+
+	var values []string
+	for rows.Next() {
+		var value string
+		s.NoError(rows.Scan(&value))
+
+		values = append(values, value)
+	}
+
+	s.NoError(rows.Err())
+	s.NoError(rows.Close())
+
+	// Only legacy JWT refresh token is replaced with its digest:
+	legacyRefreshTokenDigest := sha256.Sum256([]byte(legacyRefreshToken))
+	s.Equal([]string{hex.EncodeToString(legacyRefreshTokenDigest[:]), refreshToken.Value}, values)
 }
 
-func (s *AuthRepositoryTestSuite) TestForgetPasswordNoActiveRefreshToken() {
+func (s *AuthRepositoryTestSuite) TestGetRefreshTokenByValueWithClient() {
 	s.traceProvider.
 		EXPECT().
 		Span(gomock.Any(), gomock.Any()).
 		Return(context.Background(), mocktracing.NewMockSpan()).
 		Times(1)
 
-	s.logger.
-		EXPECT().
-		ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
-		Times(1)
-
 	_, err := s.connection.ExecContext(
 		ctx,
 		`
-				INSERT INTO users (id, display_name, email, password) 
-				VALUES ($1, $2, $3, $4)
+				INSERT INTO refresh_tokens (id, user_id, value, ttl, client_id) 
+				VALUES ($1, $2, $3, $4, $5)
 			`,
+		refreshTokenID,
 		userID,
-		testUserDTO.DisplayName,
-		testUserDTO.Email,
-		testUserDTO.Password,
+		refreshToken.Value,
+		refreshToken.TTL,
+		client.ClientID,
 	)
 
 	s.NoError(err)
 
-	err = s.authRepository.ForgetPassword(ctx, userID, "new password")
+	dbRefreshToken, err := s.authRepository.GetRefreshTokenByValue(ctx, refreshToken.Value)
 	s.NoError(err)
+	s.NotNil(dbRefreshToken)
+	s.Equal(pointers.New(client.ClientID), dbRefreshToken.ClientID)
 }
 
-func (s *AuthRepositoryTestSuite) TestForgetPasswordUserWithProvidedIDDoesNotExist() {
+func (s *AuthRepositoryTestSuite) TestGetRefreshTokenByValueNotFound() {
 	s.traceProvider.
 		EXPECT().
 		Span(gomock.Any(), gomock.Any()).
 		Return(context.Background(), mocktracing.NewMockSpan()).
 		Times(1)
 
-	s.logger.
-		EXPECT().
-		ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
-		Times(1)
+	dbRefreshToken, err := s.authRepository.GetRefreshTokenByValue(ctx, refreshToken.Value)
+	s.Error(err)
+	s.Nil(dbRefreshToken)
+}
 
-	err := s.authRepository.ForgetPassword(ctx, userID, "new password")
+func (s *AuthRepositoryTestSuite) TestGetRefreshTokenByValueExpired() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO refresh_tokens (id, user_id, value, ttl) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		refreshTokenID,
+		userID,
+		refreshToken.Value,
+		time.Now().UTC().Add(-ttl),
+	)
+
+	s.NoError(err)
+
+	// Expired refresh tokens are returned to distinguish them from rotated ones:
+	dbRefreshToken, err := s.authRepository.GetRefreshTokenByValue(ctx, refreshToken.Value)
+	s.NoError(err)
+	s.NotNil(dbRefreshToken)
+	s.True(dbRefreshToken.TTL.Before(time.Now().UTC()))
+	s.Nil(dbRefreshToken.RotatedAt)
+}
+
+func (s *AuthRepositoryTestSuite) TestExpireRefreshTokenSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO refresh_tokens (id, user_id, value, ttl) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		refreshTokenID,
+		userID,
+		refreshToken.Value,
+		refreshToken.TTL,
+	)
+
+	s.NoError(err)
+
+	err = s.authRepository.ExpireRefreshToken(ctx, refreshToken.Value)
+	s.NoError(err)
+}
+
+func (s *AuthRepositoryTestSuite) TestExpireRefreshTokenDoesNotExist() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	err := s.authRepository.ExpireRefreshToken(ctx, refreshToken.Value)
+	s.NoError(err)
+}
+
+func (s *AuthRepositoryTestSuite) TestCreateSessionSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	// Error and zero sessionID due to returning nil ID after insert.
+	// SQLite inner realization without AUTO_INCREMENT for SERIAL PRIMARY KEY
+	sessionID, err := s.authRepository.CreateSession(ctx, session)
+	s.Error(err)
+	s.Zero(sessionID)
+}
+
+func (s *AuthRepositoryTestSuite) TestExpireSessionSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(2)
+
+	s.logger.
+		EXPECT().
+		ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO sessions (id, user_id, device_name, user_agent, ip, ttl) 
+				VALUES ($1, $2, $3, $4, $5, $6)
+			`,
+		sessionID,
+		userID,
+		session.ClientInfo.DeviceName,
+		session.ClientInfo.UserAgent,
+		session.ClientInfo.IP,
+		time.Now().UTC().Add(session.TTL),
+	)
+
+	s.NoError(err)
+
+	_, err = s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO refresh_tokens (id, user_id, value, ttl, session_id) 
+				VALUES ($1, $2, $3, $4, $5)
+			`,
+		refreshTokenID,
+		userID,
+		refreshToken.Value,
+		refreshToken.TTL,
+		sessionID,
+	)
+
+	s.NoError(err)
+
+	err = s.authRepository.ExpireSession(ctx, userID, sessionID)
+	s.NoError(err)
+
+	// Refresh tokens of expired Session can not be used anymore:
+	dbRefreshToken, err := s.authRepository.GetRefreshTokenByValue(ctx, refreshToken.Value)
+	s.NoError(err)
+	s.True(dbRefreshToken.TTL.Before(time.Now().UTC()))
+}
+
+func (s *AuthRepositoryTestSuite) TestExpireSessionOfAnotherUser() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(2)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO sessions (id, user_id, device_name, user_agent, ip, ttl) 
+				VALUES ($1, $2, $3, $4, $5, $6)
+			`,
+		sessionID,
+		userID,
+		session.ClientInfo.DeviceName,
+		session.ClientInfo.UserAgent,
+		session.ClientInfo.IP,
+		time.Now().UTC().Add(session.TTL),
+	)
+
+	s.NoError(err)
+
+	_, err = s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO refresh_tokens (id, user_id, value, ttl, session_id) 
+				VALUES ($1, $2, $3, $4, $5)
+			`,
+		refreshTokenID,
+		userID,
+		refreshToken.Value,
+		refreshToken.TTL,
+		sessionID,
+	)
+
+	s.NoError(err)
+
+	err = s.authRepository.ExpireSession(ctx, userID+1, sessionID)
+	s.Error(err)
+	s.IsType(&customerrors.SessionNotFoundError{}, err)
+
+	dbRefreshToken, err := s.authRepository.GetRefreshTokenByValue(ctx, refreshToken.Value)
+	s.NoError(err)
+	s.True(dbRefreshToken.TTL.After(time.Now().UTC()))
+}
+
+func (s *AuthRepositoryTestSuite) TestExpireSessionDoesNotExist() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	err := s.authRepository.ExpireSession(ctx, userID, sessionID)
+	s.Error(err)
+	s.IsType(&customerrors.SessionNotFoundError{}, err)
+}
+
+func (s *AuthRepositoryTestSuite) TestRotateRefreshTokenSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(2)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO refresh_tokens (id, user_id, value, ttl) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		refreshTokenID,
+		userID,
+		refreshToken.Value,
+		refreshToken.TTL,
+	)
+
+	s.NoError(err)
+
+	err = s.authRepository.RotateRefreshToken(ctx, refreshTokenID, familyID)
+	s.NoError(err)
+
+	// Refresh token without family joins family of its successor:
+	dbRefreshToken, err := s.authRepository.GetRefreshTokenByValue(ctx, refreshToken.Value)
+	s.NoError(err)
+	s.NotNil(dbRefreshToken.RotatedAt)
+	s.Equal(pointers.New(familyID), dbRefreshToken.FamilyID)
+	s.True(dbRefreshToken.TTL.Before(time.Now().UTC()))
+}
+
+func (s *AuthRepositoryTestSuite) TestRotateRefreshTokenAlreadyRotated() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(2)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO refresh_tokens (id, user_id, value, ttl, family_id) 
+				VALUES ($1, $2, $3, $4, $5)
+			`,
+		refreshTokenID,
+		userID,
+		refreshToken.Value,
+		refreshToken.TTL,
+		familyID,
+	)
+
+	s.NoError(err)
+
+	err = s.authRepository.RotateRefreshToken(ctx, refreshTokenID, familyID)
+	s.NoError(err)
+
+	err = s.authRepository.RotateRefreshToken(ctx, refreshTokenID, familyID)
+	s.Error(err)
+	s.IsType(&customerrors.RefreshTokenReuseDetectedError{}, err)
+}
+
+func (s *AuthRepositoryTestSuite) TestRevokeRefreshTokenFamilySuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(4)
+
+	s.logger.
+		EXPECT().
+		ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1)
+
+	for _, id := range []uint64{sessionID, sessionID + 1} {
+		_, err := s.connection.ExecContext(
+			ctx,
+			`
+				INSERT INTO sessions (id, user_id, device_name, user_agent, ip, ttl) 
+				VALUES ($1, $2, $3, $4, $5, $6)
+			`,
+			id,
+			userID,
+			session.ClientInfo.DeviceName,
+			session.ClientInfo.UserAgent,
+			session.ClientInfo.IP,
+			time.Now().UTC().Add(session.TTL),
+		)
+
+		s.NoError(err)
+	}
+
+	refreshTokens := []struct {
+		value     string
+		sessionID uint64
+		familyID  string
+	}{
+		{value: "compromised_token", sessionID: sessionID, familyID: familyID},
+		{value: "another_family_token", sessionID: sessionID + 1, familyID: "another_family"},
+	}
+
+	for i, token := range refreshTokens {
+		_, err := s.connection.ExecContext(
+			ctx,
+			`
+				INSERT INTO refresh_tokens (id, user_id, value, ttl, session_id, family_id) 
+				VALUES ($1, $2, $3, $4, $5, $6)
+			`,
+			i+1,
+			userID,
+			token.value,
+			refreshToken.TTL,
+			token.sessionID,
+			token.familyID,
+		)
+
+		s.NoError(err)
+	}
+
+	err := s.authRepository.RevokeRefreshTokenFamily(ctx, familyID)
+	s.NoError(err)
+
+	// Session of compromised family is ended, while other Sessions stay active:
+	sessions, err := s.authRepository.GetUserSessions(ctx, userID)
+	s.NoError(err)
+	s.Len(sessions, 1)
+	s.Equal(uint64(sessionID+1), sessions[0].ID)
+
+	dbRefreshToken, err := s.authRepository.GetRefreshTokenByValue(ctx, "compromised_token")
+	s.NoError(err)
+	s.True(dbRefreshToken.TTL.Before(time.Now().UTC()))
+
+	dbRefreshToken, err = s.authRepository.GetRefreshTokenByValue(ctx, "another_family_token")
+	s.NoError(err)
+	s.True(dbRefreshToken.TTL.After(time.Now().UTC()))
+}
+
+func (s *AuthRepositoryTestSuite) TestGetUserSessionsSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO sessions (id, user_id, device_name, user_agent, ip, ttl) 
+				VALUES ($1, $2, $3, $4, $5, $6)
+			`,
+		sessionID,
+		userID,
+		session.ClientInfo.DeviceName,
+		session.ClientInfo.UserAgent,
+		session.ClientInfo.IP,
+		time.Now().UTC().Add(session.TTL),
+	)
+
+	s.NoError(err)
+
+	// Expired Session should not be returned:
+	_, err = s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO sessions (id, user_id, device_name, user_agent, ip, ttl) 
+				VALUES ($1, $2, $3, $4, $5, $6)
+			`,
+		sessionID+1,
+		userID,
+		session.ClientInfo.DeviceName,
+		session.ClientInfo.UserAgent,
+		session.ClientInfo.IP,
+		time.Now().UTC().Add(-session.TTL),
+	)
+
+	s.NoError(err)
+
+	sessions, err := s.authRepository.GetUserSessions(ctx, userID)
+	s.NoError(err)
+	s.Len(sessions, 1)
+	s.Equal(uint64(sessionID), sessions[0].ID)
+	s.Equal(session.ClientInfo.DeviceName, sessions[0].DeviceName)
+	s.Equal(session.ClientInfo.UserAgent, sessions[0].UserAgent)
+	s.Equal(session.ClientInfo.IP, sessions[0].IP)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetUserSessionsEmpty() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	sessions, err := s.authRepository.GetUserSessions(ctx, userID)
+	s.NoError(err)
+	s.Empty(sessions)
+}
+
+func (s *AuthRepositoryTestSuite) TestExpireUserSessionsSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(5)
+
+	s.logger.
+		EXPECT().
+		ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1)
+
+	for _, id := range []uint64{sessionID, sessionID + 1} {
+		_, err := s.connection.ExecContext(
+			ctx,
+			`
+				INSERT INTO sessions (id, user_id, device_name, user_agent, ip, ttl) 
+				VALUES ($1, $2, $3, $4, $5, $6)
+			`,
+			id,
+			userID,
+			session.ClientInfo.DeviceName,
+			session.ClientInfo.UserAgent,
+			session.ClientInfo.IP,
+			time.Now().UTC().Add(session.TTL),
+		)
+
+		s.NoError(err)
+	}
+
+	refreshTokens := []struct {
+		value     string
+		sessionID *uint64
+	}{
+		{value: "current_session_token", sessionID: pointers.New[uint64](sessionID)},
+		{value: "another_session_token", sessionID: pointers.New[uint64](sessionID + 1)},
+		{value: "token_without_session", sessionID: nil},
+	}
+
+	for i, token := range refreshTokens {
+		_, err := s.connection.ExecContext(
+			ctx,
+			`
+				INSERT INTO refresh_tokens (id, user_id, value, ttl, session_id) 
+				VALUES ($1, $2, $3, $4, $5)
+			`,
+			i+1,
+			userID,
+			token.value,
+			refreshToken.TTL,
+			token.sessionID,
+		)
+
+		s.NoError(err)
+	}
+
+	err := s.authRepository.ExpireUserSessions(ctx, userID, sessionID)
+	s.NoError(err)
+
+	// Only current Session and its refresh token stay active:
+	sessions, err := s.authRepository.GetUserSessions(ctx, userID)
+	s.NoError(err)
+	s.Len(sessions, 1)
+	s.Equal(uint64(sessionID), sessions[0].ID)
+
+	dbRefreshToken, err := s.authRepository.GetRefreshTokenByValue(ctx, "current_session_token")
+	s.NoError(err)
+	s.True(dbRefreshToken.TTL.After(time.Now().UTC()))
+
+	dbRefreshToken, err = s.authRepository.GetRefreshTokenByValue(ctx, "another_session_token")
+	s.NoError(err)
+	s.True(dbRefreshToken.TTL.Before(time.Now().UTC()))
+
+	dbRefreshToken, err = s.authRepository.GetRefreshTokenByValue(ctx, "token_without_session")
+	s.NoError(err)
+	s.True(dbRefreshToken.TTL.Before(time.Now().UTC()))
+}
+
+func (s *AuthRepositoryTestSuite) TestChangePasswordSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(2)
+
+	s.logger.
+		EXPECT().
+		ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO users (id, display_name, email, password) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		userID,
+		testUserDTO.DisplayName,
+		testUserDTO.Email,
+		testUserDTO.Password,
+	)
+
+	s.NoError(err)
+
+	_, err = s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO forget_password_tokens (id, user_id, token_hash, ttl) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		forgetPasswordToken.ID,
+		userID,
+		forgetPasswordToken.TokenHash,
+		forgetPasswordToken.TTL,
+	)
+
+	s.NoError(err)
+
+	err = s.authRepository.ChangePassword(ctx, userID, "new password")
+	s.NoError(err)
+
+	// Forget-password tokens must be expired after password change:
+	token, err := s.authRepository.GetForgetPasswordTokenByHash(ctx, forgetPasswordToken.TokenHash)
+	s.Error(err)
+	s.Nil(token)
+}
+
+func (s *AuthRepositoryTestSuite) TestChangePasswordUserDoesNotExist() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.logger.
+		EXPECT().
+		ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1)
+
+	err := s.authRepository.ChangePassword(ctx, userID, "new password")
+	s.NoError(err)
+}
+
+func (s *AuthRepositoryTestSuite) TestCreateForgetPasswordTokenSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO users (id, display_name, email, password) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		userID,
+		testUserDTO.DisplayName,
+		testUserDTO.Email,
+		testUserDTO.Password,
+	)
+
+	s.NoError(err)
+
+	// Error and zero ID due to returning nil ID after register.
+	// SQLite inner realization without AUTO_INCREMENT for SERIAL PRIMARY KEY
+	tokenID, err := s.authRepository.CreateForgetPasswordToken(
+		ctx,
+		entities.CreateForgetPasswordTokenDTO{
+			UserID:    userID,
+			TokenHash: forgetPasswordToken.TokenHash,
+			TTL:       ttl,
+		},
+	)
+
+	s.Error(err)
+	s.Zero(tokenID)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetForgetPasswordTokenByHashSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO forget_password_tokens (id, user_id, token_hash, ttl) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		forgetPasswordToken.ID,
+		userID,
+		forgetPasswordToken.TokenHash,
+		forgetPasswordToken.TTL,
+	)
+
+	s.NoError(err)
+
+	token, err := s.authRepository.GetForgetPasswordTokenByHash(ctx, forgetPasswordToken.TokenHash)
+	s.NoError(err)
+	s.NotNil(token)
+	s.Equal(forgetPasswordToken.ID, token.ID)
+	s.Equal(forgetPasswordToken.UserID, token.UserID)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetForgetPasswordTokenByHashExpired() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO forget_password_tokens (id, user_id, token_hash, ttl) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		forgetPasswordToken.ID,
+		userID,
+		forgetPasswordToken.TokenHash,
+		time.Now().UTC().Add(-ttl),
+	)
+
+	s.NoError(err)
+
+	token, err := s.authRepository.GetForgetPasswordTokenByHash(ctx, forgetPasswordToken.TokenHash)
+	s.Error(err)
+	s.Nil(token)
+}
+
+func (s *AuthRepositoryTestSuite) TestExpireForgetPasswordTokensSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(2)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO forget_password_tokens (id, user_id, token_hash, ttl) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		forgetPasswordToken.ID,
+		userID,
+		forgetPasswordToken.TokenHash,
+		forgetPasswordToken.TTL,
+	)
+
+	s.NoError(err)
+
+	err = s.authRepository.ExpireForgetPasswordTokens(ctx, userID)
+	s.NoError(err)
+
+	token, err := s.authRepository.GetForgetPasswordTokenByHash(ctx, forgetPasswordToken.TokenHash)
+	s.Error(err)
+	s.Nil(token)
+}
+
+func (s *AuthRepositoryTestSuite) TestForgetPasswordSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(2)
+
+	s.logger.
+		EXPECT().
+		ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO users (id, display_name, email, password) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		userID,
+		testUserDTO.DisplayName,
+		testUserDTO.Email,
+		testUserDTO.Password,
+	)
+
+	s.NoError(err)
+
+	_, err = s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO refresh_tokens (id, user_id, value, ttl) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		refreshTokenID,
+		userID,
+		refreshToken.Value,
+		refreshToken.TTL,
+	)
+
+	s.NoError(err)
+
+	_, err = s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO forget_password_tokens (id, user_id, token_hash, ttl) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		forgetPasswordToken.ID,
+		userID,
+		forgetPasswordToken.TokenHash,
+		forgetPasswordToken.TTL,
+	)
+
+	s.NoError(err)
+
+	_, err = s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO forget_password_tokens (id, user_id, token_hash, ttl) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		forgetPasswordToken.ID+1,
+		userID,
+		"another_token_hash",
+		forgetPasswordToken.TTL,
+	)
+
+	s.NoError(err)
+
+	err = s.authRepository.ForgetPassword(ctx, userID, forgetPasswordToken.ID, "new password")
+	s.NoError(err)
+
+	// All outstanding tokens of User must be expired after password reset:
+	token, err := s.authRepository.GetForgetPasswordTokenByHash(ctx, "another_token_hash")
+	s.Error(err)
+	s.Nil(token)
+}
+
+func (s *AuthRepositoryTestSuite) TestForgetPasswordNoActiveRefreshToken() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.logger.
+		EXPECT().
+		ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO users (id, display_name, email, password) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		userID,
+		testUserDTO.DisplayName,
+		testUserDTO.Email,
+		testUserDTO.Password,
+	)
+
+	s.NoError(err)
+
+	_, err = s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO forget_password_tokens (id, user_id, token_hash, ttl) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		forgetPasswordToken.ID,
+		userID,
+		forgetPasswordToken.TokenHash,
+		forgetPasswordToken.TTL,
+	)
+
+	s.NoError(err)
+
+	err = s.authRepository.ForgetPassword(ctx, userID, forgetPasswordToken.ID, "new password")
+	s.NoError(err)
+}
+
+func (s *AuthRepositoryTestSuite) TestForgetPasswordTokenIsExpired() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO users (id, display_name, email, password) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		userID,
+		testUserDTO.DisplayName,
+		testUserDTO.Email,
+		testUserDTO.Password,
+	)
+
+	s.NoError(err)
+
+	_, err = s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO forget_password_tokens (id, user_id, token_hash, ttl) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		forgetPasswordToken.ID,
+		userID,
+		forgetPasswordToken.TokenHash,
+		time.Now().UTC().Add(-ttl),
+	)
+
+	s.NoError(err)
+
+	err = s.authRepository.ForgetPassword(ctx, userID, forgetPasswordToken.ID, "new password")
+	s.Error(err)
+	s.IsType(&customerrors.InvalidForgetPasswordTokenError{}, err)
+}
+
+func (s *AuthRepositoryTestSuite) TestForgetPasswordTokenDoesNotExist() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	err := s.authRepository.ForgetPassword(ctx, userID, forgetPasswordToken.ID, "new password")
+	s.Error(err)
+	s.IsType(&customerrors.InvalidForgetPasswordTokenError{}, err)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetTOTPSecretByUserIDSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO totp_secrets (id, user_id, secret, confirmed) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		totpSecret.ID,
+		userID,
+		totpSecret.Secret,
+		true,
+	)
+
+	s.NoError(err)
+
+	secret, err := s.authRepository.GetTOTPSecretByUserID(ctx, userID)
+	s.NoError(err)
+	s.NotNil(secret)
+	s.Equal(totpSecret.ID, secret.ID)
+	s.Equal(totpSecret.Secret, secret.Secret)
+	s.True(secret.Confirmed)
+	s.Zero(secret.LastUsedStep)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetTOTPSecretByUserIDNotFound() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	secret, err := s.authRepository.GetTOTPSecretByUserID(ctx, userID)
+	s.Error(err)
+	s.IsType(&customerrors.MFANotEnabledError{}, err)
+	s.Nil(secret)
+}
+
+func (s *AuthRepositoryTestSuite) TestConfirmTOTPSecretSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(3)
+
+	s.logger.
+		EXPECT().
+		ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO totp_secrets (id, user_id, secret) 
+				VALUES ($1, $2, $3)
+			`,
+		totpSecret.ID,
+		userID,
+		totpSecret.Secret,
+	)
+
+	s.NoError(err)
+
+	err = s.authRepository.ConfirmTOTPSecret(
+		ctx,
+		entities.ConfirmTOTPSecretDTO{
+			TOTPSecretID:       totpSecret.ID,
+			UserID:             userID,
+			Step:               10,
+			RecoveryCodeHashes: []string{"first_code_hash", "second_code_hash"},
+		},
+	)
+	s.NoError(err)
+
+	secret, err := s.authRepository.GetTOTPSecretByUserID(ctx, userID)
+	s.NoError(err)
+	s.True(secret.Confirmed)
+	s.Equal(int64(10), secret.LastUsedStep)
+
+	// Recovery codes are usable only after confirmation:
+	err = s.authRepository.UseRecoveryCode(ctx, userID, "second_code_hash")
+	s.NoError(err)
+}
+
+func (s *AuthRepositoryTestSuite) TestConfirmTOTPSecretAlreadyConfirmed() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO totp_secrets (id, user_id, secret, confirmed) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		totpSecret.ID,
+		userID,
+		totpSecret.Secret,
+		true,
+	)
+
+	s.NoError(err)
+
+	err = s.authRepository.ConfirmTOTPSecret(
+		ctx,
+		entities.ConfirmTOTPSecretDTO{
+			TOTPSecretID:       totpSecret.ID,
+			UserID:             userID,
+			Step:               10,
+			RecoveryCodeHashes: []string{"code_hash"},
+		},
+	)
+	s.Error(err)
+	s.IsType(&customerrors.InvalidMFACodeError{}, err)
+}
+
+func (s *AuthRepositoryTestSuite) TestUseTOTPStepSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(2)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO totp_secrets (id, user_id, secret, confirmed, last_used_step) 
+				VALUES ($1, $2, $3, $4, $5)
+			`,
+		totpSecret.ID,
+		userID,
+		totpSecret.Secret,
+		true,
+		10,
+	)
+
+	s.NoError(err)
+
+	err = s.authRepository.UseTOTPStep(ctx, totpSecret.ID, 11)
+	s.NoError(err)
+
+	secret, err := s.authRepository.GetTOTPSecretByUserID(ctx, userID)
+	s.NoError(err)
+	s.Equal(int64(11), secret.LastUsedStep)
+}
+
+func (s *AuthRepositoryTestSuite) TestUseTOTPStepAlreadyUsed() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO totp_secrets (id, user_id, secret, confirmed, last_used_step) 
+				VALUES ($1, $2, $3, $4, $5)
+			`,
+		totpSecret.ID,
+		userID,
+		totpSecret.Secret,
+		true,
+		10,
+	)
+
+	s.NoError(err)
+
+	err = s.authRepository.UseTOTPStep(ctx, totpSecret.ID, 10)
+	s.Error(err)
+	s.IsType(&customerrors.InvalidMFACodeError{}, err)
+}
+
+func (s *AuthRepositoryTestSuite) TestUseRecoveryCodeAlreadyUsed() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(2)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO recovery_codes (id, user_id, code_hash) 
+				VALUES ($1, $2, $3)
+			`,
+		1,
+		userID,
+		"code_hash",
+	)
+
+	s.NoError(err)
+
+	err = s.authRepository.UseRecoveryCode(ctx, userID, "code_hash")
+	s.NoError(err)
+
+	err = s.authRepository.UseRecoveryCode(ctx, userID, "code_hash")
+	s.Error(err)
+	s.IsType(&customerrors.InvalidMFACodeError{}, err)
+}
+
+func (s *AuthRepositoryTestSuite) TestUseRecoveryCodeOfAnotherUser() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO recovery_codes (id, user_id, code_hash) 
+				VALUES ($1, $2, $3)
+			`,
+		1,
+		userID+1,
+		"code_hash",
+	)
+
+	s.NoError(err)
+
+	err = s.authRepository.UseRecoveryCode(ctx, userID, "code_hash")
+	s.Error(err)
+	s.IsType(&customerrors.InvalidMFACodeError{}, err)
+}
+
+func (s *AuthRepositoryTestSuite) TestDeleteTOTPSecretSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(3)
+
+	s.logger.
+		EXPECT().
+		ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO totp_secrets (id, user_id, secret, confirmed) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		totpSecret.ID,
+		userID,
+		totpSecret.Secret,
+		true,
+	)
+
+	s.NoError(err)
+
+	_, err = s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO recovery_codes (id, user_id, code_hash) 
+				VALUES ($1, $2, $3)
+			`,
+		1,
+		userID,
+		"code_hash",
+	)
+
+	s.NoError(err)
+
+	err = s.authRepository.DeleteTOTPSecret(ctx, userID)
+	s.NoError(err)
+
+	secret, err := s.authRepository.GetTOTPSecretByUserID(ctx, userID)
+	s.Error(err)
+	s.IsType(&customerrors.MFANotEnabledError{}, err)
+	s.Nil(secret)
+
+	err = s.authRepository.UseRecoveryCode(ctx, userID, "code_hash")
+	s.Error(err)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetMFAChallengeByHashSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO mfa_challenges (id, user_id, token_hash, ttl) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		mfaChallenge.ID,
+		userID,
+		mfaChallenge.TokenHash,
+		mfaChallenge.TTL,
+	)
+
+	s.NoError(err)
+
+	challenge, err := s.authRepository.GetMFAChallengeByHash(ctx, mfaChallenge.TokenHash)
+	s.NoError(err)
+	s.NotNil(challenge)
+	s.Equal(mfaChallenge.ID, challenge.ID)
+	s.Equal(mfaChallenge.UserID, challenge.UserID)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetMFAChallengeByHashExpired() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO mfa_challenges (id, user_id, token_hash, ttl) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		mfaChallenge.ID,
+		userID,
+		mfaChallenge.TokenHash,
+		time.Now().UTC().Add(-ttl),
+	)
+
+	s.NoError(err)
+
+	challenge, err := s.authRepository.GetMFAChallengeByHash(ctx, mfaChallenge.TokenHash)
+	s.Error(err)
+	s.Nil(challenge)
+}
+
+func (s *AuthRepositoryTestSuite) TestExpireMFAChallengeSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(3)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO mfa_challenges (id, user_id, token_hash, ttl) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		mfaChallenge.ID,
+		userID,
+		mfaChallenge.TokenHash,
+		mfaChallenge.TTL,
+	)
+
+	s.NoError(err)
+
+	err = s.authRepository.ExpireMFAChallenge(ctx, mfaChallenge.ID)
+	s.NoError(err)
+
+	challenge, err := s.authRepository.GetMFAChallengeByHash(ctx, mfaChallenge.TokenHash)
+	s.Error(err)
+	s.Nil(challenge)
+
+	// Challenge can be completed only once:
+	err = s.authRepository.ExpireMFAChallenge(ctx, mfaChallenge.ID)
+	s.Error(err)
+	s.IsType(&customerrors.InvalidMFAChallengeError{}, err)
+}
+
+func (s *AuthRepositoryTestSuite) insertWebAuthnCredential(id, userID uint64, credentialID string) {
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO webauthn_credentials (id, user_id, credential_id, public_key, sign_count, transports) 
+				VALUES ($1, $2, $3, $4, $5, $6)
+			`,
+		id,
+		userID,
+		credentialID,
+		webAuthnCredential.PublicKey,
+		webAuthnCredential.SignCount,
+		webAuthnCredential.Transports,
+	)
+
+	s.NoError(err)
+}
+
+func (s *AuthRepositoryTestSuite) insertWebAuthnChallenge(ttl time.Time) {
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO webauthn_challenges (id, user_id, challenge_hash, ceremony, ttl) 
+				VALUES ($1, $2, $3, $4, $5)
+			`,
+		webAuthnChallenge.ID,
+		webAuthnChallenge.UserID,
+		webAuthnChallenge.ChallengeHash,
+		webAuthnChallenge.Ceremony,
+		ttl,
+	)
+
+	s.NoError(err)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetWebAuthnCredentialSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.insertWebAuthnCredential(webAuthnCredential.ID, userID, webAuthnCredential.CredentialID)
+
+	credential, err := s.authRepository.GetWebAuthnCredential(ctx, webAuthnCredential.CredentialID)
+	s.NoError(err)
+	s.NotNil(credential)
+	s.Equal(webAuthnCredential.ID, credential.ID)
+	s.Equal(webAuthnCredential.UserID, credential.UserID)
+	s.Equal(webAuthnCredential.PublicKey, credential.PublicKey)
+	s.Equal(webAuthnCredential.SignCount, credential.SignCount)
+	s.Equal(webAuthnCredential.Transports, credential.Transports)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetWebAuthnCredentialNotFound() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	credential, err := s.authRepository.GetWebAuthnCredential(ctx, webAuthnCredential.CredentialID)
+	s.Error(err)
+	s.IsType(&customerrors.WebAuthnCredentialNotFoundError{}, err)
+	s.Nil(credential)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetUserWebAuthnCredentialsSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.insertWebAuthnCredential(1, userID, "first_credential_id")
+	s.insertWebAuthnCredential(2, userID+1, "another_user_credential_id")
+	s.insertWebAuthnCredential(3, userID, "second_credential_id")
+
+	credentials, err := s.authRepository.GetUserWebAuthnCredentials(ctx, userID)
+	s.NoError(err)
+	s.Len(credentials, 2)
+	s.Equal("first_credential_id", credentials[0].CredentialID)
+	s.Equal("second_credential_id", credentials[1].CredentialID)
+}
+
+func (s *AuthRepositoryTestSuite) TestUpdateWebAuthnCredentialSignCountSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(2)
+
+	s.insertWebAuthnCredential(webAuthnCredential.ID, userID, webAuthnCredential.CredentialID)
+
+	err := s.authRepository.UpdateWebAuthnCredentialSignCount(ctx, webAuthnCredential.ID, 5)
+	s.NoError(err)
+
+	credential, err := s.authRepository.GetWebAuthnCredential(ctx, webAuthnCredential.CredentialID)
+	s.NoError(err)
+	s.Equal(uint32(5), credential.SignCount)
+}
+
+func (s *AuthRepositoryTestSuite) TestUpdateWebAuthnCredentialSignCountNotIncreased() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.insertWebAuthnCredential(webAuthnCredential.ID, userID, webAuthnCredential.CredentialID)
+
+	// Sign count, which is equal to saved one, means cloned authenticator or replayed assertion:
+	err := s.authRepository.UpdateWebAuthnCredentialSignCount(ctx, webAuthnCredential.ID, webAuthnCredential.SignCount)
+	s.Error(err)
+	s.IsType(&customerrors.InvalidWebAuthnCredentialError{}, err)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetWebAuthnChallengeByHashSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(2)
+
+	s.insertWebAuthnChallenge(webAuthnChallenge.TTL)
+
+	challenge, err := s.authRepository.GetWebAuthnChallengeByHash(
+		ctx,
+		webAuthnChallenge.ChallengeHash,
+		webAuthnChallenge.Ceremony,
+	)
+	s.NoError(err)
+	s.NotNil(challenge)
+	s.Equal(webAuthnChallenge.ID, challenge.ID)
+	s.Equal(webAuthnChallenge.UserID, challenge.UserID)
+
+	// Challenge of login can not be used for registration:
+	challenge, err = s.authRepository.GetWebAuthnChallengeByHash(
+		ctx,
+		webAuthnChallenge.ChallengeHash,
+		entities.WebAuthnRegistrationCeremony,
+	)
+	s.Error(err)
+	s.Nil(challenge)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetWebAuthnChallengeByHashExpired() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.insertWebAuthnChallenge(time.Now().UTC().Add(-ttl))
+
+	challenge, err := s.authRepository.GetWebAuthnChallengeByHash(
+		ctx,
+		webAuthnChallenge.ChallengeHash,
+		webAuthnChallenge.Ceremony,
+	)
+	s.Error(err)
+	s.Nil(challenge)
+}
+
+func (s *AuthRepositoryTestSuite) TestExpireWebAuthnChallengeSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(3)
+
+	s.insertWebAuthnChallenge(webAuthnChallenge.TTL)
+
+	err := s.authRepository.ExpireWebAuthnChallenge(ctx, webAuthnChallenge.ID)
+	s.NoError(err)
+
+	challenge, err := s.authRepository.GetWebAuthnChallengeByHash(
+		ctx,
+		webAuthnChallenge.ChallengeHash,
+		webAuthnChallenge.Ceremony,
+	)
+	s.Error(err)
+	s.Nil(challenge)
+
+	// Challenge can be used only once:
+	err = s.authRepository.ExpireWebAuthnChallenge(ctx, webAuthnChallenge.ID)
+	s.Error(err)
+	s.IsType(&customerrors.InvalidWebAuthnChallengeError{}, err)
+}
+
+func (s *AuthRepositoryTestSuite) insertLoginToken(id uint64, tokenHash string, ttl time.Time) {
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO login_tokens (id, user_id, token_hash, code_hash, ttl) 
+				VALUES ($1, $2, $3, $4, $5)
+			`,
+		id,
+		loginToken.UserID,
+		tokenHash,
+		loginToken.CodeHash,
+		ttl,
+	)
+
+	s.NoError(err)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetLoginTokenByHashSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.insertLoginToken(loginToken.ID, loginToken.TokenHash, loginToken.TTL)
+
+	token, err := s.authRepository.GetLoginTokenByHash(ctx, loginToken.TokenHash)
+	s.NoError(err)
+	s.NotNil(token)
+	s.Equal(loginToken.ID, token.ID)
+	s.Equal(loginToken.UserID, token.UserID)
+	s.Equal(loginToken.CodeHash, token.CodeHash)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetLoginTokenByHashExpired() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.insertLoginToken(loginToken.ID, loginToken.TokenHash, time.Now().UTC().Add(-ttl))
+
+	token, err := s.authRepository.GetLoginTokenByHash(ctx, loginToken.TokenHash)
+	s.Error(err)
+	s.Nil(token)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetLoginTokenByUserIDReturnsLatest() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.insertLoginToken(loginToken.ID, loginToken.TokenHash, loginToken.TTL)
+	s.insertLoginToken(loginToken.ID+1, "latest_login_token_hash", loginToken.TTL)
+
+	token, err := s.authRepository.GetLoginTokenByUserID(ctx, loginToken.UserID)
+	s.NoError(err)
+	s.NotNil(token)
+	s.Equal(loginToken.ID+1, token.ID)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetLoginTokenByUserIDNotFound() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	token, err := s.authRepository.GetLoginTokenByUserID(ctx, loginToken.UserID)
+	s.Error(err)
+	s.Nil(token)
+}
+
+func (s *AuthRepositoryTestSuite) TestUseLoginTokenSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(3)
+
+	s.logger.
+		EXPECT().
+		ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1)
+
+	s.insertLoginToken(loginToken.ID, loginToken.TokenHash, loginToken.TTL)
+	s.insertLoginToken(loginToken.ID+1, "other_login_token_hash", loginToken.TTL)
+
+	err := s.authRepository.UseLoginToken(ctx, loginToken.UserID, loginToken.ID)
+	s.NoError(err)
+
+	// Used token and all other tokens of User are expired:
+	token, err := s.authRepository.GetLoginTokenByHash(ctx, loginToken.TokenHash)
+	s.Error(err)
+	s.Nil(token)
+
+	token, err = s.authRepository.GetLoginTokenByHash(ctx, "other_login_token_hash")
+	s.Error(err)
+	s.Nil(token)
+}
+
+func (s *AuthRepositoryTestSuite) TestUseLoginTokenAlreadyUsed() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.insertLoginToken(loginToken.ID, loginToken.TokenHash, time.Now().UTC().Add(-ttl))
+
+	err := s.authRepository.UseLoginToken(ctx, loginToken.UserID, loginToken.ID)
+	s.Error(err)
+	s.IsType(&customerrors.InvalidLoginTokenError{}, err)
+}
+
+func (s *AuthRepositoryTestSuite) insertPhoneVerificationCode(id uint64, phone string, ttl time.Time) {
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO phone_verification_codes (id, user_id, phone, code_hash, ttl) 
+				VALUES ($1, $2, $3, $4, $5)
+			`,
+		id,
+		phoneVerificationCode.UserID,
+		phone,
+		phoneVerificationCode.CodeHash,
+		ttl,
+	)
+
+	s.NoError(err)
+}
+
+func (s *AuthRepositoryTestSuite) insertUserWithPhone(phone string) {
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO users (id, display_name, email, password, phone) 
+				VALUES ($1, $2, $3, $4, $5)
+			`,
+		userID,
+		testUserDTO.DisplayName,
+		testUserDTO.Email,
+		testUserDTO.Password,
+		phone,
+	)
+
+	s.NoError(err)
+}
+
+func (s *AuthRepositoryTestSuite) getUserPhoneConfirmed() bool {
+	var phoneConfirmed bool
+
+	err := s.connection.QueryRowContext(
+		ctx,
+		"SELECT phone_confirmed FROM users WHERE id = $1",
+		userID,
+	).Scan(&phoneConfirmed)
+	s.NoError(err)
+
+	return phoneConfirmed
+}
+
+func (s *AuthRepositoryTestSuite) TestCreatePhoneVerificationCodeSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	// Error and zero ID due to returning nil ID after insert.
+	// SQLite inner realization without AUTO_INCREMENT for SERIAL PRIMARY KEY
+	codeID, err := s.authRepository.CreatePhoneVerificationCode(
+		ctx,
+		entities.CreatePhoneVerificationCodeDTO{
+			UserID:   phoneVerificationCode.UserID,
+			Phone:    phoneVerificationCode.Phone,
+			CodeHash: phoneVerificationCode.CodeHash,
+			TTL:      ttl,
+		},
+	)
+
+	s.Error(err)
+	s.Zero(codeID)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetPhoneVerificationCodeByUserIDReturnsLatest() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.insertPhoneVerificationCode(phoneVerificationCode.ID, phoneVerificationCode.Phone, phoneVerificationCode.TTL)
+	s.insertPhoneVerificationCode(phoneVerificationCode.ID+1, phoneVerificationCode.Phone, phoneVerificationCode.TTL)
+
+	code, err := s.authRepository.GetPhoneVerificationCodeByUserID(ctx, phoneVerificationCode.UserID)
+	s.NoError(err)
+	s.NotNil(code)
+	s.Equal(phoneVerificationCode.ID+1, code.ID)
+	s.Equal(phoneVerificationCode.Phone, code.Phone)
+	s.Equal(phoneVerificationCode.CodeHash, code.CodeHash)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetPhoneVerificationCodeByUserIDExpired() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.insertPhoneVerificationCode(
+		phoneVerificationCode.ID,
+		phoneVerificationCode.Phone,
+		time.Now().UTC().Add(-ttl),
+	)
+
+	code, err := s.authRepository.GetPhoneVerificationCodeByUserID(ctx, phoneVerificationCode.UserID)
+	s.Error(err)
+	s.Nil(code)
+}
+
+func (s *AuthRepositoryTestSuite) TestVerifyUserPhoneSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(2)
+
+	s.logger.
+		EXPECT().
+		ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1)
+
+	s.insertUserWithPhone(phoneVerificationCode.Phone)
+	s.insertPhoneVerificationCode(phoneVerificationCode.ID, phoneVerificationCode.Phone, phoneVerificationCode.TTL)
+	s.insertPhoneVerificationCode(phoneVerificationCode.ID+1, phoneVerificationCode.Phone, phoneVerificationCode.TTL)
+
+	err := s.authRepository.VerifyUserPhone(ctx, phoneVerificationCode.UserID, phoneVerificationCode.ID)
+	s.NoError(err)
+	s.True(s.getUserPhoneConfirmed())
+
+	// Used code and all other codes of User are expired:
+	code, err := s.authRepository.GetPhoneVerificationCodeByUserID(ctx, phoneVerificationCode.UserID)
+	s.Error(err)
+	s.Nil(code)
+}
+
+func (s *AuthRepositoryTestSuite) TestVerifyUserPhoneChangedPhone() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.logger.
+		EXPECT().
+		ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1)
+
+	// Code was sent to previous phone of User:
+	s.insertUserWithPhone("+79997654321")
+	s.insertPhoneVerificationCode(phoneVerificationCode.ID, phoneVerificationCode.Phone, phoneVerificationCode.TTL)
+
+	err := s.authRepository.VerifyUserPhone(ctx, phoneVerificationCode.UserID, phoneVerificationCode.ID)
+	s.NoError(err)
+	s.False(s.getUserPhoneConfirmed())
+}
+
+func (s *AuthRepositoryTestSuite) TestVerifyUserPhoneCodeIsExpired() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.insertUserWithPhone(phoneVerificationCode.Phone)
+	s.insertPhoneVerificationCode(
+		phoneVerificationCode.ID,
+		phoneVerificationCode.Phone,
+		time.Now().UTC().Add(-ttl),
+	)
+
+	err := s.authRepository.VerifyUserPhone(ctx, phoneVerificationCode.UserID, phoneVerificationCode.ID)
+	s.Error(err)
+	s.IsType(&customerrors.InvalidPhoneVerificationCodeError{}, err)
+	s.False(s.getUserPhoneConfirmed())
+}
+
+func (s *AuthRepositoryTestSuite) insertAuthorizationCode(ttl time.Time) {
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO authorization_codes (
+					id, user_id, client_id, code_hash, redirect_uri, scope, nonce, code_challenge, ttl
+				) 
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			`,
+		authorizationCode.ID,
+		authorizationCode.UserID,
+		authorizationCode.ClientID,
+		authorizationCode.CodeHash,
+		authorizationCode.RedirectURI,
+		authorizationCode.Scope,
+		authorizationCode.Nonce,
+		authorizationCode.CodeChallenge,
+		ttl,
+	)
+
+	s.NoError(err)
+}
+
+func (s *AuthRepositoryTestSuite) TestCreateAuthorizationCodeSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	// Error and zero ID due to returning nil ID after insert.
+	// SQLite inner realization without AUTO_INCREMENT for SERIAL PRIMARY KEY
+	codeID, err := s.authRepository.CreateAuthorizationCode(
+		ctx,
+		entities.CreateAuthorizationCodeDTO{
+			UserID:        authorizationCode.UserID,
+			ClientID:      authorizationCode.ClientID,
+			CodeHash:      authorizationCode.CodeHash,
+			RedirectURI:   authorizationCode.RedirectURI,
+			Scope:         authorizationCode.Scope,
+			Nonce:         authorizationCode.Nonce,
+			CodeChallenge: authorizationCode.CodeChallenge,
+			TTL:           ttl,
+		},
+	)
+
+	s.Error(err)
+	s.Zero(codeID)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetAuthorizationCodeByHashSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.insertAuthorizationCode(authorizationCode.TTL)
+
+	code, err := s.authRepository.GetAuthorizationCodeByHash(ctx, authorizationCode.CodeHash)
+	s.NoError(err)
+	s.NotNil(code)
+	s.Equal(authorizationCode.ID, code.ID)
+	s.Equal(authorizationCode.UserID, code.UserID)
+	s.Equal(authorizationCode.RedirectURI, code.RedirectURI)
+	s.Equal(authorizationCode.Scope, code.Scope)
+	s.Equal(authorizationCode.Nonce, code.Nonce)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetAuthorizationCodeByHashExpired() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.insertAuthorizationCode(time.Now().UTC().Add(-ttl))
+
+	code, err := s.authRepository.GetAuthorizationCodeByHash(ctx, authorizationCode.CodeHash)
+	s.Error(err)
+	s.Nil(code)
+}
+
+func (s *AuthRepositoryTestSuite) TestUseAuthorizationCodeSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(2)
+
+	s.insertAuthorizationCode(authorizationCode.TTL)
+
+	err := s.authRepository.UseAuthorizationCode(ctx, authorizationCode.ID)
+	s.NoError(err)
+
+	code, err := s.authRepository.GetAuthorizationCodeByHash(ctx, authorizationCode.CodeHash)
+	s.Error(err)
+	s.Nil(code)
+}
+
+func (s *AuthRepositoryTestSuite) TestUseAuthorizationCodeAlreadyUsed() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.insertAuthorizationCode(time.Now().UTC().Add(-ttl))
+
+	err := s.authRepository.UseAuthorizationCode(ctx, authorizationCode.ID)
+	s.Error(err)
+	s.IsType(&customerrors.InvalidAuthorizationCodeError{}, err)
+}
+
+func (s *AuthRepositoryTestSuite) insertClient() {
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO clients (
+					id, client_id, secret_hash, redirect_uris, grant_types, scopes, access_token_ttl, refresh_token_ttl
+				) 
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			`,
+		client.ID,
+		client.ClientID,
+		client.SecretHash,
+		client.RedirectURIs,
+		client.GrantTypes,
+		client.Scopes,
+		client.AccessTokenTTL,
+		client.RefreshTokenTTL,
+	)
+
+	s.NoError(err)
+}
+
+func (s *AuthRepositoryTestSuite) TestCreateClientSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	// Error and zero ID due to returning nil ID after insert.
+	// SQLite inner realization without AUTO_INCREMENT for SERIAL PRIMARY KEY
+	id, err := s.authRepository.CreateClient(
+		ctx,
+		entities.CreateClientDTO{
+			ClientID:        client.ClientID,
+			SecretHash:      client.SecretHash,
+			RedirectURIs:    client.RedirectURIs,
+			GrantTypes:      client.GrantTypes,
+			Scopes:          client.Scopes,
+			AccessTokenTTL:  time.Minute,
+			RefreshTokenTTL: time.Hour,
+		},
+	)
+
+	s.Error(err)
+	s.Zero(id)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetClientByClientIDSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.insertClient()
+
+	dbClient, err := s.authRepository.GetClientByClientID(ctx, client.ClientID)
+	s.NoError(err)
+	s.NotNil(dbClient)
+	s.Equal(client.ID, dbClient.ID)
+	s.Equal(client.SecretHash, dbClient.SecretHash)
+	s.Equal(client.RedirectURIs, dbClient.RedirectURIs)
+	s.Equal(client.GrantTypes, dbClient.GrantTypes)
+	s.Equal(client.Scopes, dbClient.Scopes)
+	s.Equal(client.AccessTokenTTL, dbClient.AccessTokenTTL)
+	s.Equal(client.RefreshTokenTTL, dbClient.RefreshTokenTTL)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetClientByClientIDNotFound() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	dbClient, err := s.authRepository.GetClientByClientID(ctx, client.ClientID)
+	s.Error(err)
+	s.IsType(&customerrors.ClientNotFoundError{}, err)
+	s.Nil(dbClient)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetClientsSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.insertClient()
+
+	clients, err := s.authRepository.GetClients(ctx)
+	s.NoError(err)
+	s.Len(clients, 1)
+	s.Equal(client.ClientID, clients[0].ClientID)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetClientsEmpty() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	clients, err := s.authRepository.GetClients(ctx)
+	s.NoError(err)
+	s.Empty(clients)
+}
+
+func (s *AuthRepositoryTestSuite) TestUpdateClientSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(2)
+
+	s.insertClient()
+
+	err := s.authRepository.UpdateClient(
+		ctx,
+		entities.UpdateClientSettingsDTO{
+			ClientID:       client.ClientID,
+			GrantTypes:     "password",
+			Scopes:         "openid",
+			AccessTokenTTL: time.Minute * 2,
+		},
+	)
+	s.NoError(err)
+
+	dbClient, err := s.authRepository.GetClientByClientID(ctx, client.ClientID)
+	s.NoError(err)
+	s.Equal(client.SecretHash, dbClient.SecretHash)
+	s.Empty(dbClient.RedirectURIs)
+	s.Equal("password", dbClient.GrantTypes)
+	s.Equal("openid", dbClient.Scopes)
+	s.Equal(int64(120), dbClient.AccessTokenTTL)
+	s.Zero(dbClient.RefreshTokenTTL)
+}
+
+func (s *AuthRepositoryTestSuite) TestUpdateClientNotFound() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	err := s.authRepository.UpdateClient(ctx, entities.UpdateClientSettingsDTO{ClientID: client.ClientID})
+	s.Error(err)
+	s.IsType(&customerrors.ClientNotFoundError{}, err)
+}
+
+func (s *AuthRepositoryTestSuite) TestDeleteClientSuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(2)
+
+	s.insertClient()
+
+	err := s.authRepository.DeleteClient(ctx, client.ClientID)
 	s.NoError(err)
+
+	dbClient, err := s.authRepository.GetClientByClientID(ctx, client.ClientID)
+	s.Error(err)
+	s.Nil(dbClient)
+}
+
+func (s *AuthRepositoryTestSuite) TestDeleteClientNotFound() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	err := s.authRepository.DeleteClient(ctx, client.ClientID)
+	s.Error(err)
+	s.IsType(&customerrors.ClientNotFoundError{}, err)
 }
 
 func BenchmarkAuthRepository_RegisterUser(b *testing.B) {
 	spanConfig := tracing.SpanConfig{}
 	ctrl := gomock.NewController(b)
 	logger := loggermock.NewMockLogger(ctrl)
-	dbConnector, err := db.New(dsn, driver, logger)
+	dbConnector, err := db.New(dsn, sqlDriver, logger)
 	require.NoError(b, err)
 
 	defer func() {
@@ -489,3 +2792,76 @@ func BenchmarkAuthRepository_RegisterUser(b *testing.B) {
 		)
 	}
 }
+
+func (s *AuthRepositoryTestSuite) insertUserIdentity() {
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO user_identities (id, user_id, provider, subject, email) 
+				VALUES ($1, $2, $3, $4, $5)
+			`,
+		userIdentity.ID,
+		userIdentity.UserID,
+		userIdentity.Provider,
+		userIdentity.Subject,
+		userIdentity.Email,
+	)
+
+	s.NoError(err)
+}
+
+func (s *AuthRepositoryTestSuite) TestCreateUserIdentitySuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	// Error and zero ID due to returning nil ID after insert.
+	// SQLite inner realization without AUTO_INCREMENT for SERIAL PRIMARY KEY
+	id, err := s.authRepository.CreateUserIdentity(
+		ctx,
+		entities.CreateUserIdentityDTO{
+			UserID:   userIdentity.UserID,
+			Provider: userIdentity.Provider,
+			Subject:  userIdentity.Subject,
+			Email:    userIdentity.Email,
+		},
+	)
+
+	s.Error(err)
+	s.Zero(id)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetUserIdentitySuccess() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.insertUserIdentity()
+
+	dbIdentity, err := s.authRepository.GetUserIdentity(ctx, userIdentity.Provider, userIdentity.Subject)
+	s.NoError(err)
+	s.NotNil(dbIdentity)
+	s.Equal(userIdentity.ID, dbIdentity.ID)
+	s.Equal(userIdentity.UserID, dbIdentity.UserID)
+	s.Equal(userIdentity.Email, dbIdentity.Email)
+}
+
+func (s *AuthRepositoryTestSuite) TestGetUserIdentityOfAnotherProvider() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.insertUserIdentity()
+
+	// Subjects are unique only within their providers:
+	dbIdentity, err := s.authRepository.GetUserIdentity(ctx, "yandex", userIdentity.Subject)
+	s.Error(err)
+	s.IsType(&customerrors.UserIdentityNotFoundError{}, err)
+	s.Nil(dbIdentity)
+}
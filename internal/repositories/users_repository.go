@@ -26,6 +26,7 @@ const (
 	userTelegramColumnName          = "telegram"
 	userTelegramConfirmedColumnName = "telegram_confirmed"
 	userAvatarColumnName            = "avatar"
+	userBlockedColumnName           = "blocked"
 	DESC                            = "DESC"
 	ASC                             = "ASC"
 )
@@ -85,6 +86,39 @@ func (repo *UsersRepository) GetUserByID(ctx context.Context, id uint64) (*entit
 	return user, nil
 }
 
+// IsUserBlocked selects only blocked flag of User, which is cheaper, than selecting the whole User.
+func (repo *UsersRepository) IsUserBlocked(ctx context.Context, id uint64) (bool, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Select(userBlockedColumnName).
+		From(usersTableName).
+		Where(sq.Eq{idColumnName: id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	var blocked bool
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(&blocked); err != nil {
+		return false, err
+	}
+
+	return blocked, nil
+}
+
 func (repo *UsersRepository) GetUserByEmail(
 	ctx context.Context,
 	email string,
@@ -122,6 +156,99 @@ func (repo *UsersRepository) GetUserByEmail(
 	return user, nil
 }
 
+// GetUserByPhone returns User with confirmed phone, which matches provided phone in normalized form.
+// Phones are stored as Users typed them, so stored phone is normalized during comparison.
+func (repo *UsersRepository) GetUserByPhone(
+	ctx context.Context,
+	phone string,
+) (*entities.User, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(usersTableName).
+		Where(sq.Expr(normalizedPhoneExpr()+" = ?", phone)).
+		Where(sq.Eq{userPhoneConfirmedColumnName: true}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	user := &entities.User{}
+
+	columns := db.GetEntityColumns(user)
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// GetUserByTelegram returns User with confirmed Telegram handle. Telegram usernames are case-insensitive.
+func (repo *UsersRepository) GetUserByTelegram(
+	ctx context.Context,
+	telegram string,
+) (*entities.User, error) {
+	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
+	defer span.End()
+
+	span.AddEvent(repo.spanConfig.Events.Start.Name, repo.spanConfig.Events.Start.Opts...)
+	defer span.AddEvent(repo.spanConfig.Events.End.Name, repo.spanConfig.Events.End.Opts...)
+
+	connection, err := repo.dbConnector.Connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.CloseConnectionContext(ctx, connection, repo.logger)
+
+	stmt, params, err := sq.
+		Select(selectAllColumns).
+		From(usersTableName).
+		Where(sq.Expr("LOWER("+userTelegramColumnName+") = LOWER(?)", telegram)).
+		Where(sq.Eq{userTelegramConfirmedColumnName: true}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	user := &entities.User{}
+
+	columns := db.GetEntityColumns(user)
+	if err = connection.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// normalizedPhoneExpr leaves only digits of stored phone and replaces trunk prefix 8 of Russian phones
+// with country code 7, because default phone rules allow both forms.
+func normalizedPhoneExpr() string {
+	digits := userPhoneColumnName
+	for _, symbol := range []string{"+", " ", "-", "(", ")"} {
+		digits = fmt.Sprintf("REPLACE(%s, '%s', '')", digits, symbol)
+	}
+
+	return fmt.Sprintf(
+		"CASE WHEN %[1]s LIKE '8__________' THEN '7' || SUBSTR(%[1]s, 2) ELSE %[1]s END",
+		digits,
+	)
+}
+
 func (repo *UsersRepository) GetUsers(ctx context.Context, pagination *entities.Pagination) ([]entities.User, error) {
 	ctx, span := repo.traceProvider.Span(ctx, tracing.CallerName(tracing.DefaultSkipLevel))
 	defer span.End()
@@ -228,14 +355,32 @@ func (repo *UsersRepository) UpdateUserProfile(
 		builder = builder.Set(userAvatarColumnName, userProfileData.Avatar)
 	}
 
-	// If user deletes phone - we should update phone-confirmed field:
+	// If user deletes or changes phone - we should update phone-confirmed field:
 	if userProfileData.Phone == nil {
 		builder = builder.Set(userPhoneConfirmedColumnName, false)
+	} else {
+		builder = builder.Set(
+			userPhoneConfirmedColumnName,
+			sq.Expr(
+				"CASE WHEN "+userPhoneColumnName+" = ? THEN "+userPhoneConfirmedColumnName+" ELSE FALSE END",
+				*userProfileData.Phone,
+			),
+		)
 	}
 
-	// If user deletes telegram - we should update telegram-confirmed field:
+	// If user deletes or changes telegram - we should update telegram-confirmed field.
+	// Telegram usernames are case-insensitive, so case change keeps confirmation:
 	if userProfileData.Telegram == nil {
 		builder = builder.Set(userTelegramConfirmedColumnName, false)
+	} else {
+		builder = builder.Set(
+			userTelegramConfirmedColumnName,
+			sq.Expr(
+				"CASE WHEN LOWER("+userTelegramColumnName+") = LOWER(?) THEN "+
+					userTelegramConfirmedColumnName+" ELSE FALSE END",
+				*userProfileData.Telegram,
+			),
+		)
 	}
 
 	stmt, params, err := builder.ToSql()
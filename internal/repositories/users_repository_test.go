@@ -50,7 +50,7 @@ func (s *UsersRepositoryTestSuite) SetupSuite() {
 	ctrl := gomock.NewController(s.T())
 	s.ctx = context.Background()
 	s.logger = loggermock.NewMockLogger(ctrl)
-	dbConnector, err := db.New(dsn, driver, s.logger)
+	dbConnector, err := db.New(dsn, sqlDriver, s.logger)
 	s.NoError(err)
 
 	cwd, err := os.Getwd()
@@ -121,6 +121,8 @@ func (s *UsersRepositoryTestSuite) TestGetExistingUserByID() {
 	user, err := s.usersRepository.GetUserByID(ctx, userID)
 	s.NoError(err)
 	s.NotNil(user)
+	s.Equal(entities.UserRole, user.Role)
+	s.False(user.Blocked)
 }
 
 func (s *UsersRepositoryTestSuite) TestGetNonExistingUserByID() {
@@ -135,6 +137,51 @@ func (s *UsersRepositoryTestSuite) TestGetNonExistingUserByID() {
 	s.Nil(user)
 }
 
+func (s *UsersRepositoryTestSuite) TestIsUserBlocked() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(2)
+
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO users (id, display_name, email, password) 
+				VALUES ($1, $2, $3, $4)
+			`,
+		userID,
+		testUserDTO.DisplayName,
+		testUserDTO.Email,
+		testUserDTO.Password,
+	)
+
+	s.NoError(err)
+
+	blocked, err := s.usersRepository.IsUserBlocked(ctx, userID)
+	s.NoError(err)
+	s.False(blocked)
+
+	_, err = s.connection.ExecContext(ctx, `UPDATE users SET blocked = TRUE WHERE id = $1`, userID)
+	s.NoError(err)
+
+	blocked, err = s.usersRepository.IsUserBlocked(ctx, userID)
+	s.NoError(err)
+	s.True(blocked)
+}
+
+func (s *UsersRepositoryTestSuite) TestIsNonExistingUserBlocked() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	blocked, err := s.usersRepository.IsUserBlocked(ctx, userID)
+	s.Error(err)
+	s.False(blocked)
+}
+
 func (s *UsersRepositoryTestSuite) TestGetExistingUserByEmail() {
 	s.traceProvider.
 		EXPECT().
@@ -173,6 +220,89 @@ func (s *UsersRepositoryTestSuite) TestGetNonExistingUserByEmail() {
 	s.Nil(user)
 }
 
+func (s *UsersRepositoryTestSuite) insertUserWithIdentifiers(phone, telegram string, confirmed bool) {
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO users (id, display_name, email, password, phone, phone_confirmed, telegram, telegram_confirmed) 
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			`,
+		userID,
+		testUserDTO.DisplayName,
+		testUserDTO.Email,
+		testUserDTO.Password,
+		phone,
+		confirmed,
+		telegram,
+		confirmed,
+	)
+
+	s.NoError(err)
+}
+
+func (s *UsersRepositoryTestSuite) TestGetExistingUserByPhone() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(2)
+
+	s.insertUserWithIdentifiers("8 (999) 123-45-67", "@ivan_petrov", true)
+
+	// Stored phone with trunk prefix matches phone with country code:
+	user, err := s.usersRepository.GetUserByPhone(ctx, "79991234567")
+	s.NoError(err)
+	s.NotNil(user)
+	s.Equal(uint64(userID), user.ID)
+
+	user, err = s.usersRepository.GetUserByPhone(ctx, "79997654321")
+	s.Error(err)
+	s.Nil(user)
+}
+
+func (s *UsersRepositoryTestSuite) TestGetUserByNotConfirmedPhone() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.insertUserWithIdentifiers("+7 999 123-45-67", "@ivan_petrov", false)
+
+	user, err := s.usersRepository.GetUserByPhone(ctx, "79991234567")
+	s.Error(err)
+	s.Nil(user)
+}
+
+func (s *UsersRepositoryTestSuite) TestGetExistingUserByTelegram() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.insertUserWithIdentifiers("+79991234567", "@Ivan_Petrov", true)
+
+	user, err := s.usersRepository.GetUserByTelegram(ctx, "@ivan_petrov")
+	s.NoError(err)
+	s.NotNil(user)
+	s.Equal(uint64(userID), user.ID)
+}
+
+func (s *UsersRepositoryTestSuite) TestGetUserByNotConfirmedTelegram() {
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(1)
+
+	s.insertUserWithIdentifiers("+79991234567", "@ivan_petrov", false)
+
+	user, err := s.usersRepository.GetUserByTelegram(ctx, "@ivan_petrov")
+	s.Error(err)
+	s.Nil(user)
+}
+
 func (s *UsersRepositoryTestSuite) TestGetUsersWithExistingUsers() {
 	s.traceProvider.
 		EXPECT().
@@ -275,6 +405,100 @@ func (s *UsersRepositoryTestSuite) TestUpdateUserProfileSuccess() {
 	s.NoError(err)
 }
 
+func (s *UsersRepositoryTestSuite) TestUpdateUserProfileTelegramConfirmation() {
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO users (id, display_name, email, password, telegram, telegram_confirmed) 
+				VALUES ($1, $2, $3, $4, $5, $6)
+			`,
+		userID,
+		testUserDTO.DisplayName,
+		testUserDTO.Email,
+		testUserDTO.Password,
+		"@ivan_petrov",
+		true,
+	)
+
+	s.NoError(err)
+
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(4)
+
+	// Same handle in another case is still confirmed:
+	err = s.usersRepository.UpdateUserProfile(
+		ctx,
+		entities.UpdateUserProfileDTO{UserID: userID, Telegram: pointers.New("@Ivan_Petrov")},
+	)
+	s.NoError(err)
+
+	user, err := s.usersRepository.GetUserByID(ctx, userID)
+	s.NoError(err)
+	s.True(user.TelegramConfirmed)
+
+	// Another handle must be confirmed again:
+	err = s.usersRepository.UpdateUserProfile(
+		ctx,
+		entities.UpdateUserProfileDTO{UserID: userID, Telegram: pointers.New("@another_user")},
+	)
+	s.NoError(err)
+
+	user, err = s.usersRepository.GetUserByID(ctx, userID)
+	s.NoError(err)
+	s.False(user.TelegramConfirmed)
+	s.Equal("@another_user", *user.Telegram)
+}
+
+func (s *UsersRepositoryTestSuite) TestUpdateUserProfilePhoneConfirmation() {
+	_, err := s.connection.ExecContext(
+		ctx,
+		`
+				INSERT INTO users (id, display_name, email, password, phone, phone_confirmed) 
+				VALUES ($1, $2, $3, $4, $5, $6)
+			`,
+		userID,
+		testUserDTO.DisplayName,
+		testUserDTO.Email,
+		testUserDTO.Password,
+		"+79991234567",
+		true,
+	)
+
+	s.NoError(err)
+
+	s.traceProvider.
+		EXPECT().
+		Span(gomock.Any(), gomock.Any()).
+		Return(context.Background(), mocktracing.NewMockSpan()).
+		Times(4)
+
+	// Same phone is still confirmed:
+	err = s.usersRepository.UpdateUserProfile(
+		ctx,
+		entities.UpdateUserProfileDTO{UserID: userID, Phone: pointers.New("+79991234567")},
+	)
+	s.NoError(err)
+
+	user, err := s.usersRepository.GetUserByID(ctx, userID)
+	s.NoError(err)
+	s.True(user.PhoneConfirmed)
+
+	// Another phone must be confirmed again:
+	err = s.usersRepository.UpdateUserProfile(
+		ctx,
+		entities.UpdateUserProfileDTO{UserID: userID, Phone: pointers.New("+79997654321")},
+	)
+	s.NoError(err)
+
+	user, err = s.usersRepository.GetUserByID(ctx, userID)
+	s.NoError(err)
+	s.False(user.PhoneConfirmed)
+	s.Equal("+79997654321", *user.Phone)
+}
+
 func (s *UsersRepositoryTestSuite) TestUpdateUserProfileUserDoesNotExists() {
 	s.traceProvider.
 		EXPECT().
@@ -300,7 +524,7 @@ func BenchmarkUsersRepository_GetUserByID(b *testing.B) {
 	spanConfig := tracing.SpanConfig{}
 	ctrl := gomock.NewController(b)
 	logger := loggermock.NewMockLogger(ctrl)
-	dbConnector, err := db.New(dsn, driver, logger)
+	dbConnector, err := db.New(dsn, sqlDriver, logger)
 	require.NoError(b, err)
 
 	defer func() {
@@ -329,7 +553,7 @@ func BenchmarkUsersRepository_GetUserByEmail(b *testing.B) {
 	spanConfig := tracing.SpanConfig{}
 	ctrl := gomock.NewController(b)
 	logger := loggermock.NewMockLogger(ctrl)
-	dbConnector, err := db.New(dsn, driver, logger)
+	dbConnector, err := db.New(dsn, sqlDriver, logger)
 	require.NoError(b, err)
 
 	defer func() {
@@ -358,7 +582,7 @@ func BenchmarkUsersRepository_GetUsers(b *testing.B) {
 	spanConfig := tracing.SpanConfig{}
 	ctrl := gomock.NewController(b)
 	logger := loggermock.NewMockLogger(ctrl)
-	dbConnector, err := db.New(dsn, driver, logger)
+	dbConnector, err := db.New(dsn, sqlDriver, logger)
 	require.NoError(b, err)
 
 	defer func() {
@@ -2,7 +2,6 @@ package services
 
 import (
 	"context"
-	"time"
 
 	"github.com/DKhorkov/libs/logging"
 
@@ -41,41 +40,114 @@ func (service *AuthService) RegisterUser(
 	return service.authRepository.RegisterUser(ctx, userData)
 }
 
+func (service *AuthService) ImportUser(ctx context.Context, userData entities.ImportUserDTO) error {
+	user, _ := service.usersRepository.GetUserByEmail(ctx, userData.Email)
+	if user != nil {
+		return &customerrors.UserAlreadyExistsError{}
+	}
+
+	return service.authRepository.ImportUser(ctx, userData)
+}
+
+func (service *AuthService) CreateSession(
+	ctx context.Context,
+	sessionData entities.CreateSessionDTO,
+) (uint64, error) {
+	return service.authRepository.CreateSession(ctx, sessionData)
+}
+
 func (service *AuthService) CreateRefreshToken(
 	ctx context.Context,
-	userID uint64,
-	refreshToken string,
-	ttl time.Duration,
+	refreshTokenData entities.CreateRefreshTokenDTO,
 ) (uint64, error) {
-	return service.authRepository.CreateRefreshToken(
-		ctx,
-		userID,
-		refreshToken,
-		ttl,
-	)
+	return service.authRepository.CreateRefreshToken(ctx, refreshTokenData)
 }
 
-func (service *AuthService) GetRefreshTokenByUserID(
+func (service *AuthService) GetRefreshTokenByValue(
 	ctx context.Context,
-	userID uint64,
+	refreshToken string,
 ) (*entities.RefreshToken, error) {
-	return service.authRepository.GetRefreshTokenByUserID(ctx, userID)
+	return service.authRepository.GetRefreshTokenByValue(ctx, refreshToken)
 }
 
 func (service *AuthService) ExpireRefreshToken(ctx context.Context, refreshToken string) error {
 	return service.authRepository.ExpireRefreshToken(ctx, refreshToken)
 }
 
+func (service *AuthService) RotateRefreshToken(ctx context.Context, refreshTokenID uint64, familyID string) error {
+	return service.authRepository.RotateRefreshToken(ctx, refreshTokenID, familyID)
+}
+
+func (service *AuthService) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	return service.authRepository.RevokeRefreshTokenFamily(ctx, familyID)
+}
+
+func (service *AuthService) GetUserSessions(ctx context.Context, userID uint64) ([]entities.Session, error) {
+	return service.authRepository.GetUserSessions(ctx, userID)
+}
+
+func (service *AuthService) ExpireSession(ctx context.Context, userID, sessionID uint64) error {
+	return service.authRepository.ExpireSession(ctx, userID, sessionID)
+}
+
+func (service *AuthService) ExpireUserSessions(ctx context.Context, userID, exceptSessionID uint64) error {
+	return service.authRepository.ExpireUserSessions(ctx, userID, exceptSessionID)
+}
+
+func (service *AuthService) CreateVerifyEmailToken(
+	ctx context.Context,
+	tokenData entities.CreateVerifyEmailTokenDTO,
+) (uint64, error) {
+	return service.authRepository.CreateVerifyEmailToken(ctx, tokenData)
+}
+
+func (service *AuthService) GetVerifyEmailTokenByHash(
+	ctx context.Context,
+	tokenHash string,
+) (*entities.VerifyEmailToken, error) {
+	return service.authRepository.GetVerifyEmailTokenByHash(ctx, tokenHash)
+}
+
+func (service *AuthService) GetVerifyEmailTokenByUserID(
+	ctx context.Context,
+	userID uint64,
+) (*entities.VerifyEmailToken, error) {
+	return service.authRepository.GetVerifyEmailTokenByUserID(ctx, userID)
+}
+
 func (service *AuthService) VerifyUserEmail(ctx context.Context, userID uint64) error {
 	return service.authRepository.VerifyUserEmail(ctx, userID)
 }
 
+func (service *AuthService) ConfirmUserTelegram(ctx context.Context, userID uint64, telegram string) error {
+	return service.authRepository.ConfirmUserTelegram(ctx, userID, telegram)
+}
+
+func (service *AuthService) CreateForgetPasswordToken(
+	ctx context.Context,
+	tokenData entities.CreateForgetPasswordTokenDTO,
+) (uint64, error) {
+	return service.authRepository.CreateForgetPasswordToken(ctx, tokenData)
+}
+
+func (service *AuthService) GetForgetPasswordTokenByHash(
+	ctx context.Context,
+	tokenHash string,
+) (*entities.ForgetPasswordToken, error) {
+	return service.authRepository.GetForgetPasswordTokenByHash(ctx, tokenHash)
+}
+
+func (service *AuthService) ExpireForgetPasswordTokens(ctx context.Context, userID uint64) error {
+	return service.authRepository.ExpireForgetPasswordTokens(ctx, userID)
+}
+
 func (service *AuthService) ForgetPassword(
 	ctx context.Context,
 	userID uint64,
+	forgetPasswordTokenID uint64,
 	newPassword string,
 ) error {
-	return service.authRepository.ForgetPassword(ctx, userID, newPassword)
+	return service.authRepository.ForgetPassword(ctx, userID, forgetPasswordTokenID, newPassword)
 }
 
 func (service *AuthService) ChangePassword(
@@ -85,3 +157,192 @@ func (service *AuthService) ChangePassword(
 ) error {
 	return service.authRepository.ChangePassword(ctx, userID, newPassword)
 }
+
+func (service *AuthService) CreateTOTPSecret(
+	ctx context.Context,
+	totpSecretData entities.CreateTOTPSecretDTO,
+) (uint64, error) {
+	return service.authRepository.CreateTOTPSecret(ctx, totpSecretData)
+}
+
+func (service *AuthService) GetTOTPSecretByUserID(ctx context.Context, userID uint64) (*entities.TOTPSecret, error) {
+	return service.authRepository.GetTOTPSecretByUserID(ctx, userID)
+}
+
+func (service *AuthService) ConfirmTOTPSecret(ctx context.Context, confirmData entities.ConfirmTOTPSecretDTO) error {
+	return service.authRepository.ConfirmTOTPSecret(ctx, confirmData)
+}
+
+func (service *AuthService) UseTOTPStep(ctx context.Context, totpSecretID uint64, step int64) error {
+	return service.authRepository.UseTOTPStep(ctx, totpSecretID, step)
+}
+
+func (service *AuthService) UseRecoveryCode(ctx context.Context, userID uint64, codeHash string) error {
+	return service.authRepository.UseRecoveryCode(ctx, userID, codeHash)
+}
+
+func (service *AuthService) DeleteTOTPSecret(ctx context.Context, userID uint64) error {
+	return service.authRepository.DeleteTOTPSecret(ctx, userID)
+}
+
+func (service *AuthService) CreateMFAChallenge(
+	ctx context.Context,
+	challengeData entities.CreateMFAChallengeDTO,
+) (uint64, error) {
+	return service.authRepository.CreateMFAChallenge(ctx, challengeData)
+}
+
+func (service *AuthService) GetMFAChallengeByHash(
+	ctx context.Context,
+	tokenHash string,
+) (*entities.MFAChallenge, error) {
+	return service.authRepository.GetMFAChallengeByHash(ctx, tokenHash)
+}
+
+func (service *AuthService) ExpireMFAChallenge(ctx context.Context, challengeID uint64) error {
+	return service.authRepository.ExpireMFAChallenge(ctx, challengeID)
+}
+
+func (service *AuthService) CreateWebAuthnCredential(
+	ctx context.Context,
+	credentialData entities.CreateWebAuthnCredentialDTO,
+) (uint64, error) {
+	return service.authRepository.CreateWebAuthnCredential(ctx, credentialData)
+}
+
+func (service *AuthService) GetWebAuthnCredential(
+	ctx context.Context,
+	credentialID string,
+) (*entities.WebAuthnCredential, error) {
+	return service.authRepository.GetWebAuthnCredential(ctx, credentialID)
+}
+
+func (service *AuthService) GetUserWebAuthnCredentials(
+	ctx context.Context,
+	userID uint64,
+) ([]entities.WebAuthnCredential, error) {
+	return service.authRepository.GetUserWebAuthnCredentials(ctx, userID)
+}
+
+func (service *AuthService) UpdateWebAuthnCredentialSignCount(
+	ctx context.Context,
+	credentialID uint64,
+	signCount uint32,
+) error {
+	return service.authRepository.UpdateWebAuthnCredentialSignCount(ctx, credentialID, signCount)
+}
+
+func (service *AuthService) CreateWebAuthnChallenge(
+	ctx context.Context,
+	challengeData entities.CreateWebAuthnChallengeDTO,
+) (uint64, error) {
+	return service.authRepository.CreateWebAuthnChallenge(ctx, challengeData)
+}
+
+func (service *AuthService) GetWebAuthnChallengeByHash(
+	ctx context.Context,
+	challengeHash string,
+	ceremony string,
+) (*entities.WebAuthnChallenge, error) {
+	return service.authRepository.GetWebAuthnChallengeByHash(ctx, challengeHash, ceremony)
+}
+
+func (service *AuthService) ExpireWebAuthnChallenge(ctx context.Context, challengeID uint64) error {
+	return service.authRepository.ExpireWebAuthnChallenge(ctx, challengeID)
+}
+
+func (service *AuthService) CreateLoginToken(
+	ctx context.Context,
+	tokenData entities.CreateLoginTokenDTO,
+) (uint64, error) {
+	return service.authRepository.CreateLoginToken(ctx, tokenData)
+}
+
+func (service *AuthService) GetLoginTokenByHash(
+	ctx context.Context,
+	tokenHash string,
+) (*entities.LoginToken, error) {
+	return service.authRepository.GetLoginTokenByHash(ctx, tokenHash)
+}
+
+func (service *AuthService) GetLoginTokenByUserID(
+	ctx context.Context,
+	userID uint64,
+) (*entities.LoginToken, error) {
+	return service.authRepository.GetLoginTokenByUserID(ctx, userID)
+}
+
+func (service *AuthService) UseLoginToken(ctx context.Context, userID, loginTokenID uint64) error {
+	return service.authRepository.UseLoginToken(ctx, userID, loginTokenID)
+}
+
+func (service *AuthService) CreatePhoneVerificationCode(
+	ctx context.Context,
+	codeData entities.CreatePhoneVerificationCodeDTO,
+) (uint64, error) {
+	return service.authRepository.CreatePhoneVerificationCode(ctx, codeData)
+}
+
+func (service *AuthService) GetPhoneVerificationCodeByUserID(
+	ctx context.Context,
+	userID uint64,
+) (*entities.PhoneVerificationCode, error) {
+	return service.authRepository.GetPhoneVerificationCodeByUserID(ctx, userID)
+}
+
+func (service *AuthService) VerifyUserPhone(ctx context.Context, userID, phoneVerificationCodeID uint64) error {
+	return service.authRepository.VerifyUserPhone(ctx, userID, phoneVerificationCodeID)
+}
+
+func (service *AuthService) CreateAuthorizationCode(
+	ctx context.Context,
+	codeData entities.CreateAuthorizationCodeDTO,
+) (uint64, error) {
+	return service.authRepository.CreateAuthorizationCode(ctx, codeData)
+}
+
+func (service *AuthService) GetAuthorizationCodeByHash(
+	ctx context.Context,
+	codeHash string,
+) (*entities.AuthorizationCode, error) {
+	return service.authRepository.GetAuthorizationCodeByHash(ctx, codeHash)
+}
+
+func (service *AuthService) UseAuthorizationCode(ctx context.Context, authorizationCodeID uint64) error {
+	return service.authRepository.UseAuthorizationCode(ctx, authorizationCodeID)
+}
+
+func (service *AuthService) CreateClient(ctx context.Context, clientData entities.CreateClientDTO) (uint64, error) {
+	return service.authRepository.CreateClient(ctx, clientData)
+}
+
+func (service *AuthService) GetClientByClientID(ctx context.Context, clientID string) (*entities.Client, error) {
+	return service.authRepository.GetClientByClientID(ctx, clientID)
+}
+
+func (service *AuthService) GetClients(ctx context.Context) ([]entities.Client, error) {
+	return service.authRepository.GetClients(ctx)
+}
+
+func (service *AuthService) UpdateClient(ctx context.Context, clientData entities.UpdateClientSettingsDTO) error {
+	return service.authRepository.UpdateClient(ctx, clientData)
+}
+
+func (service *AuthService) DeleteClient(ctx context.Context, clientID string) error {
+	return service.authRepository.DeleteClient(ctx, clientID)
+}
+
+func (service *AuthService) CreateUserIdentity(
+	ctx context.Context,
+	identityData entities.CreateUserIdentityDTO,
+) (uint64, error) {
+	return service.authRepository.CreateUserIdentity(ctx, identityData)
+}
+
+func (service *AuthService) GetUserIdentity(
+	ctx context.Context,
+	provider string,
+	subject string,
+) (*entities.UserIdentity, error) {
+	return service.authRepository.GetUserIdentity(ctx, provider, subject)
+}
@@ -112,32 +112,115 @@ func TestAuthService_RegisterUser(t *testing.T) {
 	}
 }
 
-func TestAuthService_CreateRefreshToken(t *testing.T) {
+func TestAuthService_ImportUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	userData := entities.ImportUserDTO{
+		Email:          "test@example.com",
+		PasswordHash:   "$sha1$c2FsdA$ynTsj4lLd3s2pvX/v1T39sGuNS8",
+		EmailConfirmed: true,
+	}
+
+	testCases := []struct {
+		name        string
+		setupMocks  func(authRepository *mockrepositories.MockAuthRepository, usersRepository *mockrepositories.MockUsersRepository)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository, usersRepository *mockrepositories.MockUsersRepository) {
+				usersRepository.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(nil, nil).
+					Times(1)
+
+				authRepository.
+					EXPECT().
+					ImportUser(gomock.Any(), userData).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "user already exists",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository, usersRepository *mockrepositories.MockUsersRepository) {
+				usersRepository.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{ID: 1, Email: "test@example.com"}, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.UserAlreadyExistsError{},
+		},
+		{
+			name: "auth repo error",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository, usersRepository *mockrepositories.MockUsersRepository) {
+				usersRepository.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(nil, nil).
+					Times(1)
+
+				authRepository.
+					EXPECT().
+					ImportUser(gomock.Any(), userData).
+					Return(errors.New("import failed")).
+					Times(1)
+			},
+			expectedErr: errors.New("import failed"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository, usersRepository)
+			}
+
+			err := service.ImportUser(context.Background(), userData)
+			require.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestAuthService_CreateSession(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
 	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
 	service := NewAuthService(authRepository, usersRepository, logger)
 
+	sessionData := entities.CreateSessionDTO{
+		UserID: 1,
+		ClientInfo: entities.ClientInfo{
+			DeviceName: "iPhone",
+			UserAgent:  "grpc-go/1.70.0",
+			IP:         "127.0.0.1",
+		},
+		TTL: time.Hour,
+	}
+
 	testCases := []struct {
 		name          string
-		userID        uint64
-		refreshToken  string
-		ttl           time.Duration
+		sessionData   entities.CreateSessionDTO
 		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
 		expectedID    uint64
 		expectedErr   error
 		errorExpected bool
 	}{
 		{
-			name:         "success",
-			userID:       1,
-			refreshToken: "token123",
-			ttl:          time.Hour,
+			name:        "success",
+			sessionData: sessionData,
 			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
 				authRepository.
 					EXPECT().
-					CreateRefreshToken(gomock.Any(), uint64(1), "token123", time.Hour).
+					CreateSession(gomock.Any(), sessionData).
 					Return(uint64(1), nil).
 					Times(1)
 			},
@@ -146,14 +229,84 @@ func TestAuthService_CreateRefreshToken(t *testing.T) {
 			errorExpected: false,
 		},
 		{
-			name:         "repo error",
-			userID:       1,
-			refreshToken: "token123",
-			ttl:          time.Hour,
+			name:        "repo error",
+			sessionData: sessionData,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreateSession(gomock.Any(), sessionData).
+					Return(uint64(0), errors.New("session creation failed")).
+					Times(1)
+			},
+			expectedID:    0,
+			expectedErr:   errors.New("session creation failed"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			id, err := service.CreateSession(context.Background(), tc.sessionData)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expectedID, id)
+		})
+	}
+}
+
+func TestAuthService_CreateRefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	refreshTokenData := entities.CreateRefreshTokenDTO{
+		UserID:    1,
+		SessionID: 2,
+		FamilyID:  "family",
+		Value:     "token123",
+		TTL:       time.Hour,
+	}
+
+	testCases := []struct {
+		name             string
+		refreshTokenData entities.CreateRefreshTokenDTO
+		setupMocks       func(authRepository *mockrepositories.MockAuthRepository)
+		expectedID       uint64
+		expectedErr      error
+		errorExpected    bool
+	}{
+		{
+			name:             "success",
+			refreshTokenData: refreshTokenData,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreateRefreshToken(gomock.Any(), refreshTokenData).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectedID:    1,
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:             "repo error",
+			refreshTokenData: refreshTokenData,
 			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
 				authRepository.
 					EXPECT().
-					CreateRefreshToken(gomock.Any(), uint64(1), "token123", time.Hour).
+					CreateRefreshToken(gomock.Any(), refreshTokenData).
 					Return(uint64(0), errors.New("token creation failed")).
 					Times(1)
 			},
@@ -169,7 +322,7 @@ func TestAuthService_CreateRefreshToken(t *testing.T) {
 				tc.setupMocks(authRepository)
 			}
 
-			id, err := service.CreateRefreshToken(context.Background(), tc.userID, tc.refreshToken, tc.ttl)
+			id, err := service.CreateRefreshToken(context.Background(), tc.refreshTokenData)
 			if tc.errorExpected {
 				require.Error(t, err)
 				require.Equal(t, tc.expectedErr, err)
@@ -182,7 +335,7 @@ func TestAuthService_CreateRefreshToken(t *testing.T) {
 	}
 }
 
-func TestAuthService_GetRefreshTokenByUserID(t *testing.T) {
+func TestAuthService_GetRefreshTokenByValue(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
 	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
@@ -191,20 +344,20 @@ func TestAuthService_GetRefreshTokenByUserID(t *testing.T) {
 
 	testCases := []struct {
 		name          string
-		userID        uint64
+		refreshToken  string
 		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
 		expectedToken *entities.RefreshToken
 		expectedErr   error
 		errorExpected bool
 	}{
 		{
-			name:   "success",
-			userID: 1,
+			name:         "success",
+			refreshToken: "token123",
 			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
 				token := &entities.RefreshToken{ID: 1, UserID: 1, Value: "token123"}
 				authRepository.
 					EXPECT().
-					GetRefreshTokenByUserID(gomock.Any(), uint64(1)).
+					GetRefreshTokenByValue(gomock.Any(), "token123").
 					Return(token, nil).
 					Times(1)
 			},
@@ -213,12 +366,12 @@ func TestAuthService_GetRefreshTokenByUserID(t *testing.T) {
 			errorExpected: false,
 		},
 		{
-			name:   "repo error",
-			userID: 1,
+			name:         "repo error",
+			refreshToken: "token123",
 			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
 				authRepository.
 					EXPECT().
-					GetRefreshTokenByUserID(gomock.Any(), uint64(1)).
+					GetRefreshTokenByValue(gomock.Any(), "token123").
 					Return(nil, errors.New("token not found")).
 					Times(1)
 			},
@@ -234,7 +387,7 @@ func TestAuthService_GetRefreshTokenByUserID(t *testing.T) {
 				tc.setupMocks(authRepository)
 			}
 
-			token, err := service.GetRefreshTokenByUserID(context.Background(), tc.userID)
+			token, err := service.GetRefreshTokenByValue(context.Background(), tc.refreshToken)
 			if tc.errorExpected {
 				require.Error(t, err)
 				require.Equal(t, tc.expectedErr, err)
@@ -306,7 +459,69 @@ func TestAuthService_ExpireRefreshToken(t *testing.T) {
 	}
 }
 
-func TestAuthService_VerifyUserEmail(t *testing.T) {
+func TestAuthService_RotateRefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name           string
+		refreshTokenID uint64
+		familyID       string
+		setupMocks     func(authRepository *mockrepositories.MockAuthRepository)
+		expectedErr    error
+		errorExpected  bool
+	}{
+		{
+			name:           "success",
+			refreshTokenID: 1,
+			familyID:       "family",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					RotateRefreshToken(gomock.Any(), uint64(1), "family").
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:           "already rotated",
+			refreshTokenID: 1,
+			familyID:       "family",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					RotateRefreshToken(gomock.Any(), uint64(1), "family").
+					Return(&customerrors.RefreshTokenReuseDetectedError{}).
+					Times(1)
+			},
+			expectedErr:   &customerrors.RefreshTokenReuseDetectedError{},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			err := service.RotateRefreshToken(context.Background(), tc.refreshTokenID, tc.familyID)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_RevokeRefreshTokenFamily(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
 	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
@@ -315,18 +530,18 @@ func TestAuthService_VerifyUserEmail(t *testing.T) {
 
 	testCases := []struct {
 		name          string
-		userID        uint64
+		familyID      string
 		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
 		expectedErr   error
 		errorExpected bool
 	}{
 		{
-			name:   "success",
-			userID: 1,
+			name:     "success",
+			familyID: "family",
 			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
 				authRepository.
 					EXPECT().
-					VerifyUserEmail(gomock.Any(), uint64(1)).
+					RevokeRefreshTokenFamily(gomock.Any(), "family").
 					Return(nil).
 					Times(1)
 			},
@@ -334,16 +549,16 @@ func TestAuthService_VerifyUserEmail(t *testing.T) {
 			errorExpected: false,
 		},
 		{
-			name:   "repo error",
-			userID: 1,
+			name:     "repo error",
+			familyID: "family",
 			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
 				authRepository.
 					EXPECT().
-					VerifyUserEmail(gomock.Any(), uint64(1)).
-					Return(errors.New("verification failed")).
+					RevokeRefreshTokenFamily(gomock.Any(), "family").
+					Return(errors.New("revocation failed")).
 					Times(1)
 			},
-			expectedErr:   errors.New("verification failed"),
+			expectedErr:   errors.New("revocation failed"),
 			errorExpected: true,
 		},
 	}
@@ -354,7 +569,7 @@ func TestAuthService_VerifyUserEmail(t *testing.T) {
 				tc.setupMocks(authRepository)
 			}
 
-			err := service.VerifyUserEmail(context.Background(), tc.userID)
+			err := service.RevokeRefreshTokenFamily(context.Background(), tc.familyID)
 			if tc.errorExpected {
 				require.Error(t, err)
 				require.Equal(t, tc.expectedErr, err)
@@ -365,7 +580,7 @@ func TestAuthService_VerifyUserEmail(t *testing.T) {
 	}
 }
 
-func TestAuthService_ForgetPassword(t *testing.T) {
+func TestAuthService_GetUserSessions(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
 	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
@@ -375,37 +590,34 @@ func TestAuthService_ForgetPassword(t *testing.T) {
 	testCases := []struct {
 		name          string
 		userID        uint64
-		newPassword   string
 		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
-		expectedErr   error
+		expected      []entities.Session
 		errorExpected bool
 	}{
 		{
-			name:        "success",
-			userID:      1,
-			newPassword: "newpass123",
+			name:   "success",
+			userID: 1,
 			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
 				authRepository.
 					EXPECT().
-					ForgetPassword(gomock.Any(), uint64(1), "newpass123").
-					Return(nil).
+					GetUserSessions(gomock.Any(), uint64(1)).
+					Return([]entities.Session{{ID: 2, UserID: 1}}, nil).
 					Times(1)
 			},
-			expectedErr:   nil,
+			expected:      []entities.Session{{ID: 2, UserID: 1}},
 			errorExpected: false,
 		},
 		{
-			name:        "repo error",
-			userID:      1,
-			newPassword: "newpass123",
+			name:   "repo error",
+			userID: 1,
 			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
 				authRepository.
 					EXPECT().
-					ForgetPassword(gomock.Any(), uint64(1), "newpass123").
-					Return(errors.New("reset failed")).
+					GetUserSessions(gomock.Any(), uint64(1)).
+					Return(nil, errors.New("db error")).
 					Times(1)
 			},
-			expectedErr:   errors.New("reset failed"),
+			expected:      nil,
 			errorExpected: true,
 		},
 	}
@@ -416,18 +628,19 @@ func TestAuthService_ForgetPassword(t *testing.T) {
 				tc.setupMocks(authRepository)
 			}
 
-			err := service.ForgetPassword(context.Background(), tc.userID, tc.newPassword)
+			sessions, err := service.GetUserSessions(context.Background(), tc.userID)
 			if tc.errorExpected {
 				require.Error(t, err)
-				require.Equal(t, tc.expectedErr, err)
 			} else {
 				require.NoError(t, err)
 			}
+
+			require.Equal(t, tc.expected, sessions)
 		})
 	}
 }
 
-func TestAuthService_ChangePassword(t *testing.T) {
+func TestAuthService_ExpireSession(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
 	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
@@ -437,19 +650,19 @@ func TestAuthService_ChangePassword(t *testing.T) {
 	testCases := []struct {
 		name          string
 		userID        uint64
-		newPassword   string
+		sessionID     uint64
 		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
 		expectedErr   error
 		errorExpected bool
 	}{
 		{
-			name:        "success",
-			userID:      1,
-			newPassword: "newpass123",
+			name:      "success",
+			userID:    1,
+			sessionID: 2,
 			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
 				authRepository.
 					EXPECT().
-					ChangePassword(gomock.Any(), uint64(1), "newpass123").
+					ExpireSession(gomock.Any(), uint64(1), uint64(2)).
 					Return(nil).
 					Times(1)
 			},
@@ -457,17 +670,17 @@ func TestAuthService_ChangePassword(t *testing.T) {
 			errorExpected: false,
 		},
 		{
-			name:        "repo error",
-			userID:      1,
-			newPassword: "newpass123",
+			name:      "repo error",
+			userID:    1,
+			sessionID: 2,
 			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
 				authRepository.
 					EXPECT().
-					ChangePassword(gomock.Any(), uint64(1), "newpass123").
-					Return(errors.New("change failed")).
+					ExpireSession(gomock.Any(), uint64(1), uint64(2)).
+					Return(errors.New("expiration failed")).
 					Times(1)
 			},
-			expectedErr:   errors.New("change failed"),
+			expectedErr:   errors.New("expiration failed"),
 			errorExpected: true,
 		},
 	}
@@ -478,12 +691,2869 @@ func TestAuthService_ChangePassword(t *testing.T) {
 				tc.setupMocks(authRepository)
 			}
 
-			err := service.ChangePassword(context.Background(), tc.userID, tc.newPassword)
+			err := service.ExpireSession(context.Background(), tc.userID, tc.sessionID)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_ExpireUserSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name            string
+		userID          uint64
+		exceptSessionID uint64
+		setupMocks      func(authRepository *mockrepositories.MockAuthRepository)
+		expectedErr     error
+		errorExpected   bool
+	}{
+		{
+			name:            "success",
+			userID:          1,
+			exceptSessionID: 2,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					ExpireUserSessions(gomock.Any(), uint64(1), uint64(2)).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:            "repo error",
+			userID:          1,
+			exceptSessionID: 2,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					ExpireUserSessions(gomock.Any(), uint64(1), uint64(2)).
+					Return(errors.New("expiration failed")).
+					Times(1)
+			},
+			expectedErr:   errors.New("expiration failed"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			err := service.ExpireUserSessions(context.Background(), tc.userID, tc.exceptSessionID)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_CreateVerifyEmailToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		tokenData     entities.CreateVerifyEmailTokenDTO
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedID    uint64
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			tokenData: entities.CreateVerifyEmailTokenDTO{
+				UserID:    1,
+				Email:     "test@example.com",
+				TokenHash: "tokenHash",
+				CodeHash:  "codeHash",
+				TTL:       time.Hour,
+			},
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreateVerifyEmailToken(gomock.Any(), gomock.Any()).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectedID:    1,
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "repo error",
+			tokenData: entities.CreateVerifyEmailTokenDTO{
+				UserID: 1,
+			},
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreateVerifyEmailToken(gomock.Any(), gomock.Any()).
+					Return(uint64(0), errors.New("creation failed")).
+					Times(1)
+			},
+			expectedID:    0,
+			expectedErr:   errors.New("creation failed"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			tokenID, err := service.CreateVerifyEmailToken(context.Background(), tc.tokenData)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expectedID, tokenID)
+		})
+	}
+}
+
+func TestAuthService_GetVerifyEmailTokenByHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		tokenHash     string
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedToken *entities.VerifyEmailToken
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:      "success",
+			tokenHash: "tokenHash",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetVerifyEmailTokenByHash(gomock.Any(), "tokenHash").
+					Return(&entities.VerifyEmailToken{ID: 1, UserID: 1, TokenHash: "tokenHash"}, nil).
+					Times(1)
+			},
+			expectedToken: &entities.VerifyEmailToken{ID: 1, UserID: 1, TokenHash: "tokenHash"},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:      "repo error",
+			tokenHash: "tokenHash",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetVerifyEmailTokenByHash(gomock.Any(), "tokenHash").
+					Return(nil, errors.New("token not found")).
+					Times(1)
+			},
+			expectedToken: nil,
+			expectedErr:   errors.New("token not found"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			token, err := service.GetVerifyEmailTokenByHash(context.Background(), tc.tokenHash)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, token)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedToken, token)
+			}
+		})
+	}
+}
+
+func TestAuthService_GetVerifyEmailTokenByUserID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		userID        uint64
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedToken *entities.VerifyEmailToken
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:   "success",
+			userID: 1,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetVerifyEmailTokenByUserID(gomock.Any(), uint64(1)).
+					Return(&entities.VerifyEmailToken{ID: 1, UserID: 1, CodeHash: "codeHash"}, nil).
+					Times(1)
+			},
+			expectedToken: &entities.VerifyEmailToken{ID: 1, UserID: 1, CodeHash: "codeHash"},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:   "repo error",
+			userID: 1,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetVerifyEmailTokenByUserID(gomock.Any(), uint64(1)).
+					Return(nil, errors.New("token not found")).
+					Times(1)
+			},
+			expectedToken: nil,
+			expectedErr:   errors.New("token not found"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			token, err := service.GetVerifyEmailTokenByUserID(context.Background(), tc.userID)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, token)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedToken, token)
+			}
+		})
+	}
+}
+
+func TestAuthService_VerifyUserEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		userID        uint64
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:   "success",
+			userID: 1,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					VerifyUserEmail(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:   "repo error",
+			userID: 1,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					VerifyUserEmail(gomock.Any(), uint64(1)).
+					Return(errors.New("verification failed")).
+					Times(1)
+			},
+			expectedErr:   errors.New("verification failed"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			err := service.VerifyUserEmail(context.Background(), tc.userID)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_ConfirmUserTelegram(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		userID        uint64
+		telegram      string
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:     "success",
+			userID:   1,
+			telegram: "@ivan_petrov",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					ConfirmUserTelegram(gomock.Any(), uint64(1), "@ivan_petrov").
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:     "repo error",
+			userID:   1,
+			telegram: "@ivan_petrov",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					ConfirmUserTelegram(gomock.Any(), uint64(1), "@ivan_petrov").
+					Return(errors.New("confirmation failed")).
+					Times(1)
+			},
+			expectedErr:   errors.New("confirmation failed"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			err := service.ConfirmUserTelegram(context.Background(), tc.userID, tc.telegram)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_CreateForgetPasswordToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		tokenData     entities.CreateForgetPasswordTokenDTO
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedID    uint64
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			tokenData: entities.CreateForgetPasswordTokenDTO{
+				UserID:    1,
+				TokenHash: "tokenHash",
+				TTL:       time.Minute,
+			},
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreateForgetPasswordToken(gomock.Any(), gomock.Any()).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectedID:    1,
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "repo error",
+			tokenData: entities.CreateForgetPasswordTokenDTO{
+				UserID: 1,
+			},
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreateForgetPasswordToken(gomock.Any(), gomock.Any()).
+					Return(uint64(0), errors.New("creation failed")).
+					Times(1)
+			},
+			expectedID:    0,
+			expectedErr:   errors.New("creation failed"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			tokenID, err := service.CreateForgetPasswordToken(context.Background(), tc.tokenData)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expectedID, tokenID)
+		})
+	}
+}
+
+func TestAuthService_GetForgetPasswordTokenByHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		tokenHash     string
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedToken *entities.ForgetPasswordToken
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:      "success",
+			tokenHash: "tokenHash",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetForgetPasswordTokenByHash(gomock.Any(), "tokenHash").
+					Return(&entities.ForgetPasswordToken{ID: 1, UserID: 1, TokenHash: "tokenHash"}, nil).
+					Times(1)
+			},
+			expectedToken: &entities.ForgetPasswordToken{ID: 1, UserID: 1, TokenHash: "tokenHash"},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:      "repo error",
+			tokenHash: "tokenHash",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetForgetPasswordTokenByHash(gomock.Any(), "tokenHash").
+					Return(nil, errors.New("token not found")).
+					Times(1)
+			},
+			expectedToken: nil,
+			expectedErr:   errors.New("token not found"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			token, err := service.GetForgetPasswordTokenByHash(context.Background(), tc.tokenHash)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, token)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedToken, token)
+			}
+		})
+	}
+}
+
+func TestAuthService_ExpireForgetPasswordTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		userID        uint64
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:   "success",
+			userID: 1,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					ExpireForgetPasswordTokens(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:   "repo error",
+			userID: 1,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					ExpireForgetPasswordTokens(gomock.Any(), uint64(1)).
+					Return(errors.New("expiration failed")).
+					Times(1)
+			},
+			expectedErr:   errors.New("expiration failed"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			err := service.ExpireForgetPasswordTokens(context.Background(), tc.userID)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_ForgetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name                  string
+		userID                uint64
+		forgetPasswordTokenID uint64
+		newPassword           string
+		setupMocks            func(authRepository *mockrepositories.MockAuthRepository)
+		expectedErr           error
+		errorExpected         bool
+	}{
+		{
+			name:                  "success",
+			userID:                1,
+			forgetPasswordTokenID: 2,
+			newPassword:           "newpass123",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					ForgetPassword(gomock.Any(), uint64(1), uint64(2), "newpass123").
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:                  "repo error",
+			userID:                1,
+			forgetPasswordTokenID: 2,
+			newPassword:           "newpass123",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					ForgetPassword(gomock.Any(), uint64(1), uint64(2), "newpass123").
+					Return(errors.New("reset failed")).
+					Times(1)
+			},
+			expectedErr:   errors.New("reset failed"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			err := service.ForgetPassword(
+				context.Background(),
+				tc.userID,
+				tc.forgetPasswordTokenID,
+				tc.newPassword,
+			)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_ChangePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		userID        uint64
+		newPassword   string
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:        "success",
+			userID:      1,
+			newPassword: "newpass123",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					ChangePassword(gomock.Any(), uint64(1), "newpass123").
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:        "repo error",
+			userID:      1,
+			newPassword: "newpass123",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					ChangePassword(gomock.Any(), uint64(1), "newpass123").
+					Return(errors.New("change failed")).
+					Times(1)
+			},
+			expectedErr:   errors.New("change failed"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			err := service.ChangePassword(context.Background(), tc.userID, tc.newPassword)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_CreateTOTPSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name           string
+		totpSecretData entities.CreateTOTPSecretDTO
+		setupMocks     func(authRepository *mockrepositories.MockAuthRepository)
+		expectedID     uint64
+		expectedErr    error
+		errorExpected  bool
+	}{
+		{
+			name:           "success",
+			totpSecretData: entities.CreateTOTPSecretDTO{UserID: 1, Secret: "secret"},
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreateTOTPSecret(gomock.Any(), entities.CreateTOTPSecretDTO{UserID: 1, Secret: "secret"}).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectedID:    uint64(1),
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:           "repo error",
+			totpSecretData: entities.CreateTOTPSecretDTO{UserID: 1, Secret: "secret"},
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreateTOTPSecret(gomock.Any(), entities.CreateTOTPSecretDTO{UserID: 1, Secret: "secret"}).
+					Return(uint64(0), errors.New("repo error")).
+					Times(1)
+			},
+			expectedID:    uint64(0),
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			result, err := service.CreateTOTPSecret(context.Background(), tc.totpSecretData)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedID, result)
+			}
+		})
+	}
+}
+
+func TestAuthService_GetTOTPSecretByUserID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name               string
+		userID             uint64
+		setupMocks         func(authRepository *mockrepositories.MockAuthRepository)
+		expectedTOTPSecret *entities.TOTPSecret
+		expectedErr        error
+		errorExpected      bool
+	}{
+		{
+			name:   "success",
+			userID: 1,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(&entities.TOTPSecret{ID: 1, UserID: 1, Confirmed: true}, nil).
+					Times(1)
+			},
+			expectedTOTPSecret: &entities.TOTPSecret{ID: 1, UserID: 1, Confirmed: true},
+			expectedErr:        nil,
+			errorExpected:      false,
+		},
+		{
+			name:   "repo error",
+			userID: 1,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(nil, errors.New("repo error")).
+					Times(1)
+			},
+			expectedTOTPSecret: nil,
+			expectedErr:        errors.New("repo error"),
+			errorExpected:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			result, err := service.GetTOTPSecretByUserID(context.Background(), tc.userID)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedTOTPSecret, result)
+			}
+		})
+	}
+}
+
+func TestAuthService_ConfirmTOTPSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		confirmData   entities.ConfirmTOTPSecretDTO
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:        "success",
+			confirmData: entities.ConfirmTOTPSecretDTO{TOTPSecretID: 1, UserID: 1, Step: 2, RecoveryCodeHashes: []string{"hash"}},
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					ConfirmTOTPSecret(gomock.Any(), entities.ConfirmTOTPSecretDTO{TOTPSecretID: 1, UserID: 1, Step: 2, RecoveryCodeHashes: []string{"hash"}}).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:        "repo error",
+			confirmData: entities.ConfirmTOTPSecretDTO{TOTPSecretID: 1, UserID: 1, Step: 2, RecoveryCodeHashes: []string{"hash"}},
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					ConfirmTOTPSecret(gomock.Any(), entities.ConfirmTOTPSecretDTO{TOTPSecretID: 1, UserID: 1, Step: 2, RecoveryCodeHashes: []string{"hash"}}).
+					Return(errors.New("repo error")).
+					Times(1)
+			},
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			err := service.ConfirmTOTPSecret(context.Background(), tc.confirmData)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_UseTOTPStep(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		totpSecretID  uint64
+		step          int64
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:         "success",
+			totpSecretID: 1,
+			step:         2,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					UseTOTPStep(gomock.Any(), uint64(1), int64(2)).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:         "repo error",
+			totpSecretID: 1,
+			step:         2,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					UseTOTPStep(gomock.Any(), uint64(1), int64(2)).
+					Return(errors.New("repo error")).
+					Times(1)
+			},
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			err := service.UseTOTPStep(context.Background(), tc.totpSecretID, tc.step)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_UseRecoveryCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		userID        uint64
+		codeHash      string
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:     "success",
+			userID:   1,
+			codeHash: "hash",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					UseRecoveryCode(gomock.Any(), uint64(1), "hash").
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:     "repo error",
+			userID:   1,
+			codeHash: "hash",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					UseRecoveryCode(gomock.Any(), uint64(1), "hash").
+					Return(errors.New("repo error")).
+					Times(1)
+			},
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			err := service.UseRecoveryCode(context.Background(), tc.userID, tc.codeHash)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_DeleteTOTPSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		userID        uint64
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:   "success",
+			userID: 1,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					DeleteTOTPSecret(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:   "repo error",
+			userID: 1,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					DeleteTOTPSecret(gomock.Any(), uint64(1)).
+					Return(errors.New("repo error")).
+					Times(1)
+			},
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			err := service.DeleteTOTPSecret(context.Background(), tc.userID)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_CreateMFAChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		challengeData entities.CreateMFAChallengeDTO
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedID    uint64
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:          "success",
+			challengeData: entities.CreateMFAChallengeDTO{UserID: 1, TokenHash: "hash", TTL: time.Minute},
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreateMFAChallenge(gomock.Any(), entities.CreateMFAChallengeDTO{UserID: 1, TokenHash: "hash", TTL: time.Minute}).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectedID:    uint64(1),
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:          "repo error",
+			challengeData: entities.CreateMFAChallengeDTO{UserID: 1, TokenHash: "hash", TTL: time.Minute},
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreateMFAChallenge(gomock.Any(), entities.CreateMFAChallengeDTO{UserID: 1, TokenHash: "hash", TTL: time.Minute}).
+					Return(uint64(0), errors.New("repo error")).
+					Times(1)
+			},
+			expectedID:    uint64(0),
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			result, err := service.CreateMFAChallenge(context.Background(), tc.challengeData)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedID, result)
+			}
+		})
+	}
+}
+
+func TestAuthService_GetMFAChallengeByHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name              string
+		tokenHash         string
+		setupMocks        func(authRepository *mockrepositories.MockAuthRepository)
+		expectedChallenge *entities.MFAChallenge
+		expectedErr       error
+		errorExpected     bool
+	}{
+		{
+			name:      "success",
+			tokenHash: "hash",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetMFAChallengeByHash(gomock.Any(), "hash").
+					Return(&entities.MFAChallenge{ID: 1, UserID: 1, TokenHash: "hash"}, nil).
+					Times(1)
+			},
+			expectedChallenge: &entities.MFAChallenge{ID: 1, UserID: 1, TokenHash: "hash"},
+			expectedErr:       nil,
+			errorExpected:     false,
+		},
+		{
+			name:      "repo error",
+			tokenHash: "hash",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetMFAChallengeByHash(gomock.Any(), "hash").
+					Return(nil, errors.New("repo error")).
+					Times(1)
+			},
+			expectedChallenge: nil,
+			expectedErr:       errors.New("repo error"),
+			errorExpected:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			result, err := service.GetMFAChallengeByHash(context.Background(), tc.tokenHash)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedChallenge, result)
+			}
+		})
+	}
+}
+
+func TestAuthService_ExpireMFAChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		challengeID   uint64
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:        "success",
+			challengeID: 1,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					ExpireMFAChallenge(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:        "repo error",
+			challengeID: 1,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					ExpireMFAChallenge(gomock.Any(), uint64(1)).
+					Return(errors.New("repo error")).
+					Times(1)
+			},
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			err := service.ExpireMFAChallenge(context.Background(), tc.challengeID)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_CreateWebAuthnCredential(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name           string
+		credentialData entities.CreateWebAuthnCredentialDTO
+		setupMocks     func(authRepository *mockrepositories.MockAuthRepository)
+		expectedID     uint64
+		expectedErr    error
+		errorExpected  bool
+	}{
+		{
+			name: "success",
+			credentialData: entities.CreateWebAuthnCredentialDTO{
+				UserID:       1,
+				CredentialID: "credential",
+				PublicKey:    []byte("key"),
+				Transports:   "internal",
+			},
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreateWebAuthnCredential(gomock.Any(), entities.CreateWebAuthnCredentialDTO{
+						UserID:       1,
+						CredentialID: "credential",
+						PublicKey:    []byte("key"),
+						Transports:   "internal",
+					}).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectedID:    uint64(1),
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "repo error",
+			credentialData: entities.CreateWebAuthnCredentialDTO{
+				UserID:       1,
+				CredentialID: "credential",
+				PublicKey:    []byte("key"),
+				Transports:   "internal",
+			},
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreateWebAuthnCredential(gomock.Any(), entities.CreateWebAuthnCredentialDTO{
+						UserID:       1,
+						CredentialID: "credential",
+						PublicKey:    []byte("key"),
+						Transports:   "internal",
+					}).
+					Return(uint64(0), errors.New("repo error")).
+					Times(1)
+			},
+			expectedID:    uint64(0),
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			result, err := service.CreateWebAuthnCredential(context.Background(), tc.credentialData)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedID, result)
+			}
+		})
+	}
+}
+
+func TestAuthService_GetWebAuthnCredential(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name               string
+		credentialID       string
+		setupMocks         func(authRepository *mockrepositories.MockAuthRepository)
+		expectedCredential *entities.WebAuthnCredential
+		expectedErr        error
+		errorExpected      bool
+	}{
+		{
+			name:         "success",
+			credentialID: "credential",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetWebAuthnCredential(gomock.Any(), "credential").
+					Return(&entities.WebAuthnCredential{ID: 1, UserID: 1, CredentialID: "credential"}, nil).
+					Times(1)
+			},
+			expectedCredential: &entities.WebAuthnCredential{ID: 1, UserID: 1, CredentialID: "credential"},
+			expectedErr:        nil,
+			errorExpected:      false,
+		},
+		{
+			name:         "repo error",
+			credentialID: "credential",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetWebAuthnCredential(gomock.Any(), "credential").
+					Return(nil, errors.New("repo error")).
+					Times(1)
+			},
+			expectedCredential: nil,
+			expectedErr:        errors.New("repo error"),
+			errorExpected:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			result, err := service.GetWebAuthnCredential(context.Background(), tc.credentialID)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedCredential, result)
+			}
+		})
+	}
+}
+
+func TestAuthService_GetUserWebAuthnCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name                string
+		userID              uint64
+		setupMocks          func(authRepository *mockrepositories.MockAuthRepository)
+		expectedCredentials []entities.WebAuthnCredential
+		expectedErr         error
+		errorExpected       bool
+	}{
+		{
+			name:   "success",
+			userID: 1,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetUserWebAuthnCredentials(gomock.Any(), uint64(1)).
+					Return([]entities.WebAuthnCredential{{ID: 1, UserID: 1, CredentialID: "credential"}}, nil).
+					Times(1)
+			},
+			expectedCredentials: []entities.WebAuthnCredential{{ID: 1, UserID: 1, CredentialID: "credential"}},
+			expectedErr:         nil,
+			errorExpected:       false,
+		},
+		{
+			name:   "repo error",
+			userID: 1,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetUserWebAuthnCredentials(gomock.Any(), uint64(1)).
+					Return(nil, errors.New("repo error")).
+					Times(1)
+			},
+			expectedCredentials: nil,
+			expectedErr:         errors.New("repo error"),
+			errorExpected:       true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			result, err := service.GetUserWebAuthnCredentials(context.Background(), tc.userID)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedCredentials, result)
+			}
+		})
+	}
+}
+
+func TestAuthService_UpdateWebAuthnCredentialSignCount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		credentialID  uint64
+		signCount     uint32
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:         "success",
+			credentialID: 1,
+			signCount:    2,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					UpdateWebAuthnCredentialSignCount(gomock.Any(), uint64(1), uint32(2)).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:         "repo error",
+			credentialID: 1,
+			signCount:    2,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					UpdateWebAuthnCredentialSignCount(gomock.Any(), uint64(1), uint32(2)).
+					Return(errors.New("repo error")).
+					Times(1)
+			},
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			err := service.UpdateWebAuthnCredentialSignCount(context.Background(), tc.credentialID, tc.signCount)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_CreateWebAuthnChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		challengeData entities.CreateWebAuthnChallengeDTO
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedID    uint64
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			challengeData: entities.CreateWebAuthnChallengeDTO{
+				ChallengeHash: "hash",
+				Ceremony:      entities.WebAuthnLoginCeremony,
+				TTL:           time.Minute,
+			},
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreateWebAuthnChallenge(gomock.Any(), entities.CreateWebAuthnChallengeDTO{
+						ChallengeHash: "hash",
+						Ceremony:      entities.WebAuthnLoginCeremony,
+						TTL:           time.Minute,
+					}).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectedID:    uint64(1),
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "repo error",
+			challengeData: entities.CreateWebAuthnChallengeDTO{
+				ChallengeHash: "hash",
+				Ceremony:      entities.WebAuthnLoginCeremony,
+				TTL:           time.Minute,
+			},
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreateWebAuthnChallenge(gomock.Any(), entities.CreateWebAuthnChallengeDTO{
+						ChallengeHash: "hash",
+						Ceremony:      entities.WebAuthnLoginCeremony,
+						TTL:           time.Minute,
+					}).
+					Return(uint64(0), errors.New("repo error")).
+					Times(1)
+			},
+			expectedID:    uint64(0),
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			result, err := service.CreateWebAuthnChallenge(context.Background(), tc.challengeData)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedID, result)
+			}
+		})
+	}
+}
+
+func TestAuthService_GetWebAuthnChallengeByHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name              string
+		challengeHash     string
+		ceremony          string
+		setupMocks        func(authRepository *mockrepositories.MockAuthRepository)
+		expectedChallenge *entities.WebAuthnChallenge
+		expectedErr       error
+		errorExpected     bool
+	}{
+		{
+			name:          "success",
+			challengeHash: "hash",
+			ceremony:      entities.WebAuthnLoginCeremony,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetWebAuthnChallengeByHash(gomock.Any(), "hash", entities.WebAuthnLoginCeremony).
+					Return(&entities.WebAuthnChallenge{ID: 1, ChallengeHash: "hash", Ceremony: entities.WebAuthnLoginCeremony}, nil).
+					Times(1)
+			},
+			expectedChallenge: &entities.WebAuthnChallenge{ID: 1, ChallengeHash: "hash", Ceremony: entities.WebAuthnLoginCeremony},
+			expectedErr:       nil,
+			errorExpected:     false,
+		},
+		{
+			name:          "repo error",
+			challengeHash: "hash",
+			ceremony:      entities.WebAuthnLoginCeremony,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetWebAuthnChallengeByHash(gomock.Any(), "hash", entities.WebAuthnLoginCeremony).
+					Return(nil, errors.New("repo error")).
+					Times(1)
+			},
+			expectedChallenge: nil,
+			expectedErr:       errors.New("repo error"),
+			errorExpected:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			result, err := service.GetWebAuthnChallengeByHash(context.Background(), tc.challengeHash, tc.ceremony)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedChallenge, result)
+			}
+		})
+	}
+}
+
+func TestAuthService_ExpireWebAuthnChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		challengeID   uint64
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:        "success",
+			challengeID: 1,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					ExpireWebAuthnChallenge(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:        "repo error",
+			challengeID: 1,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					ExpireWebAuthnChallenge(gomock.Any(), uint64(1)).
+					Return(errors.New("repo error")).
+					Times(1)
+			},
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			err := service.ExpireWebAuthnChallenge(context.Background(), tc.challengeID)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_CreateLoginToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		tokenData     entities.CreateLoginTokenDTO
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedID    uint64
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			tokenData: entities.CreateLoginTokenDTO{
+				UserID:    1,
+				TokenHash: "token-hash",
+				CodeHash:  "code-hash",
+				TTL:       time.Minute,
+			},
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreateLoginToken(gomock.Any(), entities.CreateLoginTokenDTO{
+						UserID:    1,
+						TokenHash: "token-hash",
+						CodeHash:  "code-hash",
+						TTL:       time.Minute,
+					}).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectedID:    uint64(1),
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "repo error",
+			tokenData: entities.CreateLoginTokenDTO{
+				UserID:    1,
+				TokenHash: "token-hash",
+				CodeHash:  "code-hash",
+				TTL:       time.Minute,
+			},
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreateLoginToken(gomock.Any(), entities.CreateLoginTokenDTO{
+						UserID:    1,
+						TokenHash: "token-hash",
+						CodeHash:  "code-hash",
+						TTL:       time.Minute,
+					}).
+					Return(uint64(0), errors.New("repo error")).
+					Times(1)
+			},
+			expectedID:    uint64(0),
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			result, err := service.CreateLoginToken(context.Background(), tc.tokenData)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedID, result)
+			}
+		})
+	}
+}
+
+func TestAuthService_GetLoginTokenByHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		tokenHash     string
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedToken *entities.LoginToken
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:      "success",
+			tokenHash: "token-hash",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetLoginTokenByHash(gomock.Any(), "token-hash").
+					Return(&entities.LoginToken{ID: 1, UserID: 1, TokenHash: "token-hash"}, nil).
+					Times(1)
+			},
+			expectedToken: &entities.LoginToken{ID: 1, UserID: 1, TokenHash: "token-hash"},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:      "repo error",
+			tokenHash: "token-hash",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetLoginTokenByHash(gomock.Any(), "token-hash").
+					Return(nil, errors.New("repo error")).
+					Times(1)
+			},
+			expectedToken: nil,
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			result, err := service.GetLoginTokenByHash(context.Background(), tc.tokenHash)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedToken, result)
+			}
+		})
+	}
+}
+
+func TestAuthService_GetLoginTokenByUserID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		userID        uint64
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedToken *entities.LoginToken
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:   "success",
+			userID: 1,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetLoginTokenByUserID(gomock.Any(), uint64(1)).
+					Return(&entities.LoginToken{ID: 1, UserID: 1, CodeHash: "code-hash"}, nil).
+					Times(1)
+			},
+			expectedToken: &entities.LoginToken{ID: 1, UserID: 1, CodeHash: "code-hash"},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:   "repo error",
+			userID: 1,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetLoginTokenByUserID(gomock.Any(), uint64(1)).
+					Return(nil, errors.New("repo error")).
+					Times(1)
+			},
+			expectedToken: nil,
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			result, err := service.GetLoginTokenByUserID(context.Background(), tc.userID)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedToken, result)
+			}
+		})
+	}
+}
+
+func TestAuthService_UseLoginToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		userID        uint64
+		loginTokenID  uint64
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:         "success",
+			userID:       1,
+			loginTokenID: 2,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					UseLoginToken(gomock.Any(), uint64(1), uint64(2)).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:         "repo error",
+			userID:       1,
+			loginTokenID: 2,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					UseLoginToken(gomock.Any(), uint64(1), uint64(2)).
+					Return(errors.New("repo error")).
+					Times(1)
+			},
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			err := service.UseLoginToken(context.Background(), tc.userID, tc.loginTokenID)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_CreatePhoneVerificationCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		codeData      entities.CreatePhoneVerificationCodeDTO
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedID    uint64
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			codeData: entities.CreatePhoneVerificationCodeDTO{
+				UserID:   1,
+				Phone:    "+79991234567",
+				CodeHash: "code-hash",
+				TTL:      time.Minute,
+			},
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreatePhoneVerificationCode(gomock.Any(), entities.CreatePhoneVerificationCodeDTO{
+						UserID:   1,
+						Phone:    "+79991234567",
+						CodeHash: "code-hash",
+						TTL:      time.Minute,
+					}).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectedID:    uint64(1),
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "repo error",
+			codeData: entities.CreatePhoneVerificationCodeDTO{
+				UserID:   1,
+				Phone:    "+79991234567",
+				CodeHash: "code-hash",
+				TTL:      time.Minute,
+			},
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreatePhoneVerificationCode(gomock.Any(), entities.CreatePhoneVerificationCodeDTO{
+						UserID:   1,
+						Phone:    "+79991234567",
+						CodeHash: "code-hash",
+						TTL:      time.Minute,
+					}).
+					Return(uint64(0), errors.New("repo error")).
+					Times(1)
+			},
+			expectedID:    uint64(0),
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			result, err := service.CreatePhoneVerificationCode(context.Background(), tc.codeData)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedID, result)
+			}
+		})
+	}
+}
+
+func TestAuthService_GetPhoneVerificationCodeByUserID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		userID        uint64
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedCode  *entities.PhoneVerificationCode
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:   "success",
+			userID: 1,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetPhoneVerificationCodeByUserID(gomock.Any(), uint64(1)).
+					Return(&entities.PhoneVerificationCode{ID: 1, UserID: 1, Phone: "+79991234567", CodeHash: "code-hash"}, nil).
+					Times(1)
+			},
+			expectedCode:  &entities.PhoneVerificationCode{ID: 1, UserID: 1, Phone: "+79991234567", CodeHash: "code-hash"},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:   "repo error",
+			userID: 1,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetPhoneVerificationCodeByUserID(gomock.Any(), uint64(1)).
+					Return(nil, errors.New("repo error")).
+					Times(1)
+			},
+			expectedCode:  nil,
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			result, err := service.GetPhoneVerificationCodeByUserID(context.Background(), tc.userID)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedCode, result)
+			}
+		})
+	}
+}
+
+func TestAuthService_VerifyUserPhone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name                    string
+		userID                  uint64
+		phoneVerificationCodeID uint64
+		setupMocks              func(authRepository *mockrepositories.MockAuthRepository)
+		expectedErr             error
+		errorExpected           bool
+	}{
+		{
+			name:                    "success",
+			userID:                  1,
+			phoneVerificationCodeID: 2,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					VerifyUserPhone(gomock.Any(), uint64(1), uint64(2)).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:                    "repo error",
+			userID:                  1,
+			phoneVerificationCodeID: 2,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					VerifyUserPhone(gomock.Any(), uint64(1), uint64(2)).
+					Return(errors.New("repo error")).
+					Times(1)
+			},
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			err := service.VerifyUserPhone(context.Background(), tc.userID, tc.phoneVerificationCodeID)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_CreateAuthorizationCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	codeData := entities.CreateAuthorizationCodeDTO{
+		UserID:        1,
+		ClientID:      "shop",
+		CodeHash:      "code-hash",
+		RedirectURI:   "https://shop.example.com/callback",
+		Scope:         "openid",
+		CodeChallenge: "challenge",
+		TTL:           time.Minute,
+	}
+
+	testCases := []struct {
+		name          string
+		codeData      entities.CreateAuthorizationCodeDTO
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedID    uint64
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:     "success",
+			codeData: codeData,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreateAuthorizationCode(gomock.Any(), codeData).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectedID:    uint64(1),
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:     "repo error",
+			codeData: codeData,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreateAuthorizationCode(gomock.Any(), codeData).
+					Return(uint64(0), errors.New("repo error")).
+					Times(1)
+			},
+			expectedID:    uint64(0),
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			result, err := service.CreateAuthorizationCode(context.Background(), tc.codeData)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedID, result)
+			}
+		})
+	}
+}
+
+func TestAuthService_GetAuthorizationCodeByHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		codeHash      string
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedCode  *entities.AuthorizationCode
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:     "success",
+			codeHash: "code-hash",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetAuthorizationCodeByHash(gomock.Any(), "code-hash").
+					Return(&entities.AuthorizationCode{ID: 1, UserID: 1, CodeHash: "code-hash"}, nil).
+					Times(1)
+			},
+			expectedCode:  &entities.AuthorizationCode{ID: 1, UserID: 1, CodeHash: "code-hash"},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:     "repo error",
+			codeHash: "code-hash",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetAuthorizationCodeByHash(gomock.Any(), "code-hash").
+					Return(nil, errors.New("repo error")).
+					Times(1)
+			},
+			expectedCode:  nil,
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			result, err := service.GetAuthorizationCodeByHash(context.Background(), tc.codeHash)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedCode, result)
+			}
+		})
+	}
+}
+
+func TestAuthService_UseAuthorizationCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name                string
+		authorizationCodeID uint64
+		setupMocks          func(authRepository *mockrepositories.MockAuthRepository)
+		expectedErr         error
+		errorExpected       bool
+	}{
+		{
+			name:                "success",
+			authorizationCodeID: 1,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					UseAuthorizationCode(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:                "repo error",
+			authorizationCodeID: 1,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					UseAuthorizationCode(gomock.Any(), uint64(1)).
+					Return(errors.New("repo error")).
+					Times(1)
+			},
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			err := service.UseAuthorizationCode(context.Background(), tc.authorizationCodeID)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_CreateClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	clientData := entities.CreateClientDTO{
+		ClientID:        "shop",
+		RedirectURIs:    "https://shop.example.com/callback",
+		GrantTypes:      "authorization_code,refresh_token",
+		Scopes:          "openid email",
+		AccessTokenTTL:  time.Minute,
+		RefreshTokenTTL: time.Hour,
+	}
+
+	testCases := []struct {
+		name          string
+		clientData    entities.CreateClientDTO
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedID    uint64
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:       "success",
+			clientData: clientData,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreateClient(gomock.Any(), clientData).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectedID:    uint64(1),
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:       "repo error",
+			clientData: clientData,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreateClient(gomock.Any(), clientData).
+					Return(uint64(0), errors.New("repo error")).
+					Times(1)
+			},
+			expectedID:    uint64(0),
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			result, err := service.CreateClient(context.Background(), tc.clientData)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedID, result)
+			}
+		})
+	}
+}
+
+func TestAuthService_GetClientByClientID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name           string
+		clientID       string
+		setupMocks     func(authRepository *mockrepositories.MockAuthRepository)
+		expectedClient *entities.Client
+		expectedErr    error
+		errorExpected  bool
+	}{
+		{
+			name:     "success",
+			clientID: "shop",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetClientByClientID(gomock.Any(), "shop").
+					Return(&entities.Client{ID: 1, ClientID: "shop"}, nil).
+					Times(1)
+			},
+			expectedClient: &entities.Client{ID: 1, ClientID: "shop"},
+			expectedErr:    nil,
+			errorExpected:  false,
+		},
+		{
+			name:     "repo error",
+			clientID: "shop",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetClientByClientID(gomock.Any(), "shop").
+					Return(nil, errors.New("repo error")).
+					Times(1)
+			},
+			expectedClient: nil,
+			expectedErr:    errors.New("repo error"),
+			errorExpected:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			result, err := service.GetClientByClientID(context.Background(), tc.clientID)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedClient, result)
+			}
+		})
+	}
+}
+
+func TestAuthService_GetClients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name            string
+		setupMocks      func(authRepository *mockrepositories.MockAuthRepository)
+		expectedClients []entities.Client
+		expectedErr     error
+		errorExpected   bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetClients(gomock.Any()).
+					Return([]entities.Client{{ID: 1, ClientID: "shop"}}, nil).
+					Times(1)
+			},
+			expectedClients: []entities.Client{{ID: 1, ClientID: "shop"}},
+			expectedErr:     nil,
+			errorExpected:   false,
+		},
+		{
+			name: "repo error",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetClients(gomock.Any()).
+					Return(nil, errors.New("repo error")).
+					Times(1)
+			},
+			expectedClients: nil,
+			expectedErr:     errors.New("repo error"),
+			errorExpected:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			result, err := service.GetClients(context.Background())
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedClients, result)
+			}
+		})
+	}
+}
+
+func TestAuthService_UpdateClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	clientData := entities.UpdateClientSettingsDTO{
+		ClientID:   "shop",
+		GrantTypes: "password",
+		Scopes:     "openid",
+	}
+
+	testCases := []struct {
+		name          string
+		clientData    entities.UpdateClientSettingsDTO
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:       "success",
+			clientData: clientData,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					UpdateClient(gomock.Any(), clientData).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:       "repo error",
+			clientData: clientData,
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					UpdateClient(gomock.Any(), clientData).
+					Return(errors.New("repo error")).
+					Times(1)
+			},
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			err := service.UpdateClient(context.Background(), tc.clientData)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_DeleteClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		clientID      string
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:     "success",
+			clientID: "shop",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					DeleteClient(gomock.Any(), "shop").
+					Return(nil).
+					Times(1)
+			},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:     "repo error",
+			clientID: "shop",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					DeleteClient(gomock.Any(), "shop").
+					Return(errors.New("repo error")).
+					Times(1)
+			},
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			err := service.DeleteClient(context.Background(), tc.clientID)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_CreateUserIdentity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	identityData := entities.CreateUserIdentityDTO{
+		UserID:   1,
+		Provider: "google",
+		Subject:  "subject",
+		Email:    "user@example.com",
+	}
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(authRepository *mockrepositories.MockAuthRepository)
+		expectedID    uint64
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreateUserIdentity(gomock.Any(), identityData).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectedID:    1,
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name: "repo error",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					CreateUserIdentity(gomock.Any(), identityData).
+					Return(uint64(0), errors.New("repo error")).
+					Times(1)
+			},
+			expectedID:    0,
+			expectedErr:   errors.New("repo error"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			identityID, err := service.CreateUserIdentity(context.Background(), identityData)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expectedID, identityID)
+		})
+	}
+}
+
+func TestAuthService_GetUserIdentity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authRepository := mockrepositories.NewMockAuthRepository(ctrl)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewAuthService(authRepository, usersRepository, logger)
+
+	testCases := []struct {
+		name             string
+		setupMocks       func(authRepository *mockrepositories.MockAuthRepository)
+		expectedIdentity *entities.UserIdentity
+		expectedErr      error
+		errorExpected    bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetUserIdentity(gomock.Any(), "google", "subject").
+					Return(&entities.UserIdentity{ID: 1, UserID: 1, Provider: "google", Subject: "subject"}, nil).
+					Times(1)
+			},
+			expectedIdentity: &entities.UserIdentity{ID: 1, UserID: 1, Provider: "google", Subject: "subject"},
+			expectedErr:      nil,
+			errorExpected:    false,
+		},
+		{
+			name: "repo error",
+			setupMocks: func(authRepository *mockrepositories.MockAuthRepository) {
+				authRepository.
+					EXPECT().
+					GetUserIdentity(gomock.Any(), "google", "subject").
+					Return(nil, errors.New("repo error")).
+					Times(1)
+			},
+			expectedIdentity: nil,
+			expectedErr:      errors.New("repo error"),
+			errorExpected:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authRepository)
+			}
+
+			result, err := service.GetUserIdentity(context.Background(), "google", "subject")
 			if tc.errorExpected {
 				require.Error(t, err)
 				require.Equal(t, tc.expectedErr, err)
 			} else {
 				require.NoError(t, err)
+				require.Equal(t, tc.expectedIdentity, result)
 			}
 		})
 	}
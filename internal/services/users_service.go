@@ -46,6 +46,22 @@ func (service *UsersService) GetUserByID(ctx context.Context, id uint64) (*entit
 	return user, nil
 }
 
+func (service *UsersService) IsUserBlocked(ctx context.Context, id uint64) (bool, error) {
+	blocked, err := service.usersRepository.IsUserBlocked(ctx, id)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			service.logger,
+			fmt.Sprintf("Error occurred while trying to check if User with ID=%d is blocked", id),
+			err,
+		)
+
+		return false, &customerrors.UserNotFoundError{}
+	}
+
+	return blocked, nil
+}
+
 func (service *UsersService) GetUserByEmail(
 	ctx context.Context,
 	email string,
@@ -65,6 +81,44 @@ func (service *UsersService) GetUserByEmail(
 	return user, nil
 }
 
+func (service *UsersService) GetUserByPhone(
+	ctx context.Context,
+	phone string,
+) (*entities.User, error) {
+	user, err := service.usersRepository.GetUserByPhone(ctx, phone)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			service.logger,
+			"Error occurred while trying to get User with Phone="+phone,
+			err,
+		)
+
+		return nil, &customerrors.UserNotFoundError{}
+	}
+
+	return user, nil
+}
+
+func (service *UsersService) GetUserByTelegram(
+	ctx context.Context,
+	telegram string,
+) (*entities.User, error) {
+	user, err := service.usersRepository.GetUserByTelegram(ctx, telegram)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			service.logger,
+			"Error occurred while trying to get User with Telegram="+telegram,
+			err,
+		)
+
+		return nil, &customerrors.UserNotFoundError{}
+	}
+
+	return user, nil
+}
+
 func (service *UsersService) UpdateUserProfile(
 	ctx context.Context,
 	userProfileData entities.UpdateUserProfileDTO,
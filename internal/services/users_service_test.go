@@ -173,6 +173,74 @@ func TestUsersService_GetUserByID(t *testing.T) {
 	}
 }
 
+func TestUsersService_IsUserBlocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewUsersService(usersRepository, logger)
+
+	testCases := []struct {
+		name            string
+		userID          uint64
+		setupMocks      func(usersRepository *mockrepositories.MockUsersRepository, logger *mocklogging.MockLogger)
+		expectedBlocked bool
+		expectedErr     error
+		errorExpected   bool
+	}{
+		{
+			name:   "success",
+			userID: 1,
+			setupMocks: func(usersRepository *mockrepositories.MockUsersRepository, logger *mocklogging.MockLogger) {
+				usersRepository.
+					EXPECT().
+					IsUserBlocked(gomock.Any(), uint64(1)).
+					Return(true, nil).
+					Times(1)
+			},
+			expectedBlocked: true,
+			expectedErr:     nil,
+			errorExpected:   false,
+		},
+		{
+			name:   "not found",
+			userID: 1,
+			setupMocks: func(usersRepository *mockrepositories.MockUsersRepository, logger *mocklogging.MockLogger) {
+				usersRepository.
+					EXPECT().
+					IsUserBlocked(gomock.Any(), uint64(1)).
+					Return(false, errors.New("user not found")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedBlocked: false,
+			expectedErr:     &customerrors.UserNotFoundError{},
+			errorExpected:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(usersRepository, logger)
+			}
+
+			blocked, err := service.IsUserBlocked(context.Background(), tc.userID)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.expectedBlocked, blocked)
+		})
+	}
+}
+
 func TestUsersService_GetUserByEmail(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
@@ -242,6 +310,144 @@ func TestUsersService_GetUserByEmail(t *testing.T) {
 	}
 }
 
+func TestUsersService_GetUserByPhone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewUsersService(usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		phone         string
+		setupMocks    func(usersRepository *mockrepositories.MockUsersRepository, logger *mocklogging.MockLogger)
+		expectedUser  *entities.User
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:  "success",
+			phone: "79991234567",
+			setupMocks: func(usersRepository *mockrepositories.MockUsersRepository, logger *mocklogging.MockLogger) {
+				user := &entities.User{ID: 1, Phone: pointers.New("+7 999 123-45-67"), PhoneConfirmed: true}
+				usersRepository.
+					EXPECT().
+					GetUserByPhone(gomock.Any(), "79991234567").
+					Return(user, nil).
+					Times(1)
+			},
+			expectedUser:  &entities.User{ID: 1, Phone: pointers.New("+7 999 123-45-67"), PhoneConfirmed: true},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:  "not found",
+			phone: "79991234567",
+			setupMocks: func(usersRepository *mockrepositories.MockUsersRepository, logger *mocklogging.MockLogger) {
+				usersRepository.
+					EXPECT().
+					GetUserByPhone(gomock.Any(), "79991234567").
+					Return(nil, errors.New("user not found")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedUser:  nil,
+			expectedErr:   &customerrors.UserNotFoundError{},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(usersRepository, logger)
+			}
+
+			user, err := service.GetUserByPhone(context.Background(), tc.phone)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, user)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedUser, user)
+			}
+		})
+	}
+}
+
+func TestUsersService_GetUserByTelegram(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	service := NewUsersService(usersRepository, logger)
+
+	testCases := []struct {
+		name          string
+		telegram      string
+		setupMocks    func(usersRepository *mockrepositories.MockUsersRepository, logger *mocklogging.MockLogger)
+		expectedUser  *entities.User
+		expectedErr   error
+		errorExpected bool
+	}{
+		{
+			name:     "success",
+			telegram: "@ivan_petrov",
+			setupMocks: func(usersRepository *mockrepositories.MockUsersRepository, logger *mocklogging.MockLogger) {
+				user := &entities.User{ID: 1, Telegram: pointers.New("@ivan_petrov"), TelegramConfirmed: true}
+				usersRepository.
+					EXPECT().
+					GetUserByTelegram(gomock.Any(), "@ivan_petrov").
+					Return(user, nil).
+					Times(1)
+			},
+			expectedUser:  &entities.User{ID: 1, Telegram: pointers.New("@ivan_petrov"), TelegramConfirmed: true},
+			expectedErr:   nil,
+			errorExpected: false,
+		},
+		{
+			name:     "not found",
+			telegram: "@ivan_petrov",
+			setupMocks: func(usersRepository *mockrepositories.MockUsersRepository, logger *mocklogging.MockLogger) {
+				usersRepository.
+					EXPECT().
+					GetUserByTelegram(gomock.Any(), "@ivan_petrov").
+					Return(nil, errors.New("user not found")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedUser:  nil,
+			expectedErr:   &customerrors.UserNotFoundError{},
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(usersRepository, logger)
+			}
+
+			user, err := service.GetUserByTelegram(context.Background(), tc.telegram)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, user)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedUser, user)
+			}
+		})
+	}
+}
+
 func TestUsersService_UpdateUserProfile(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
@@ -0,0 +1,181 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/DKhorkov/libs/security"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+)
+
+const signatureKeyUse = "sig"
+
+type key struct {
+	id        string
+	method    jwt.SigningMethod
+	publicKey crypto.PublicKey
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", path)
+	}
+
+	return block, nil
+}
+
+// loadPrivateKey reads PEM file with private key in PKCS #8, PKCS #1 or SEC 1 format.
+func loadPrivateKey(path string) (crypto.Signer, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePrivateKey(block.Bytes)
+}
+
+func parsePrivateKey(der []byte) (crypto.Signer, error) {
+	if privateKey, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		signer, ok := privateKey.(crypto.Signer)
+		if !ok {
+			return nil, errors.New("unsupported private key type")
+		}
+
+		return signer, nil
+	}
+
+	if privateKey, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return privateKey, nil
+	}
+
+	if privateKey, err := x509.ParseECPrivateKey(der); err == nil {
+		return privateKey, nil
+	}
+
+	return nil, errors.New("unsupported private key format")
+}
+
+// loadPublicKey reads PEM file with public key. Private keys are accepted as well,
+// so previous signing key can be used for verification during rotation as is.
+func loadPublicKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasSuffix(block.Type, "PRIVATE KEY") {
+		privateKey, err := parsePrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		return privateKey.Public(), nil
+	}
+
+	if publicKey, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		return publicKey, nil
+	}
+
+	if publicKey, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return publicKey, nil
+	}
+
+	return nil, fmt.Errorf("unsupported public key format in %s", path)
+}
+
+// defaultMethod returns signing method for verification key, since algorithm is not stored in PEM file.
+func defaultMethod(publicKey crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch publicKey := publicKey.(type) {
+	case *rsa.PublicKey:
+		return jwt.SigningMethodRS256, nil
+	case *ecdsa.PublicKey:
+		switch publicKey.Curve {
+		case elliptic.P256():
+			return jwt.SigningMethodES256, nil
+		case elliptic.P384():
+			return jwt.SigningMethodES384, nil
+		case elliptic.P521():
+			return jwt.SigningMethodES512, nil
+		}
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	}
+
+	return nil, errors.New("unsupported public key type")
+}
+
+// checkMethod checks, that key can be used with provided signing method.
+func checkMethod(method jwt.SigningMethod, publicKey crypto.PublicKey) error {
+	var compatible bool
+
+	switch method := method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		_, compatible = publicKey.(*rsa.PublicKey)
+	case *jwt.SigningMethodECDSA:
+		ecdsaPublicKey, ok := publicKey.(*ecdsa.PublicKey)
+		compatible = ok && ecdsaPublicKey.Curve.Params().BitSize == method.CurveBits
+	case *jwt.SigningMethodEd25519:
+		_, compatible = publicKey.(ed25519.PublicKey)
+	}
+
+	if !compatible {
+		return fmt.Errorf("key can not be used with %s algorithm", method.Alg())
+	}
+
+	return nil
+}
+
+func mapKeyToJWK(key key) (entities.JWK, error) {
+	jwk := entities.JWK{
+		KeyID:     key.id,
+		Use:       signatureKeyUse,
+		Algorithm: key.method.Alg(),
+	}
+
+	switch publicKey := key.publicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = security.RawEncode(publicKey.N.Bytes())
+		jwk.E = security.RawEncode(big.NewInt(int64(publicKey.E)).Bytes())
+	case *ecdsa.PublicKey:
+		ecdhPublicKey, err := publicKey.ECDH()
+		if err != nil {
+			return jwk, err
+		}
+
+		// Uncompressed point format: 0x04 || X || Y, where coordinates have equal length:
+		point := ecdhPublicKey.Bytes()
+		coordinateLength := (len(point) - 1) / 2
+
+		jwk.KeyType = "EC"
+		jwk.Curve = publicKey.Curve.Params().Name
+		jwk.X = security.RawEncode(point[1 : 1+coordinateLength])
+		jwk.Y = security.RawEncode(point[1+coordinateLength:])
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = security.RawEncode(publicKey)
+	default:
+		return jwk, errors.New("unsupported public key type")
+	}
+
+	return jwk, nil
+}
@@ -0,0 +1,49 @@
+package signing
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"math/big"
+	"testing"
+
+	"github.com/DKhorkov/libs/security"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapKeyToJWK(t *testing.T) {
+	t.Run("RSA", func(t *testing.T) {
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		jwk, err := mapKeyToJWK(key{id: "rsa", method: jwt.SigningMethodRS256, publicKey: privateKey.Public()})
+		require.NoError(t, err)
+
+		n, err := security.RawDecode(jwk.N)
+		require.NoError(t, err)
+		require.Equal(t, privateKey.N, new(big.Int).SetBytes(n))
+		require.Equal(t, "AQAB", jwk.E) // 65537
+	})
+
+	t.Run("EC", func(t *testing.T) {
+		privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+		require.NoError(t, err)
+
+		jwk, err := mapKeyToJWK(key{id: "ec", method: jwt.SigningMethodES512, publicKey: privateKey.Public()})
+		require.NoError(t, err)
+		require.Equal(t, "P-521", jwk.Curve)
+
+		x, err := security.RawDecode(jwk.X)
+		require.NoError(t, err)
+		require.Len(t, x, 66) // Coordinates are padded to curve size
+
+		y, err := security.RawDecode(jwk.Y)
+		require.NoError(t, err)
+		require.Len(t, y, 66)
+
+		require.Equal(t, privateKey.X, new(big.Int).SetBytes(x))
+		require.Equal(t, privateKey.Y, new(big.Int).SetBytes(y))
+	})
+}
@@ -0,0 +1,163 @@
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/DKhorkov/libs/security"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/DKhorkov/hmtm-sso/internal/config"
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+)
+
+const keyIDHeader = "kid"
+
+var (
+	errUnknownKey          = errors.New("unknown JWT signing key")
+	errUnexpectedAlgorithm = errors.New("unexpected JWT signing algorithm")
+)
+
+// New creates JWT Provider. Tokens are signed with shared secret for HMAC algorithms
+// and with private key from keysConfig for asymmetric algorithms.
+func New(jwtConfig security.JWTConfig, keysConfig config.JWTKeysConfig) (*Provider, error) {
+	method := jwt.GetSigningMethod(jwtConfig.Algorithm)
+	if method == nil {
+		return nil, fmt.Errorf("unsupported JWT algorithm %q", jwtConfig.Algorithm)
+	}
+
+	provider := &Provider{
+		method:    method,
+		secretKey: jwtConfig.SecretKey,
+		keys:      make(map[string]key),
+		jwks:      entities.JWKS{Keys: []entities.JWK{}},
+	}
+
+	if _, ok := method.(*jwt.SigningMethodHMAC); ok {
+		provider.signingKey = []byte(jwtConfig.SecretKey)
+	} else {
+		if keysConfig.SigningKeyID == "" || keysConfig.SigningKeyPath == "" {
+			return nil, fmt.Errorf("signing key ID and path are required for %s algorithm", method.Alg())
+		}
+
+		privateKey, err := loadPrivateKey(keysConfig.SigningKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load signing key: %w", err)
+		}
+
+		if err = checkMethod(method, privateKey.Public()); err != nil {
+			return nil, err
+		}
+
+		provider.signingKey = privateKey
+		provider.signingKeyID = keysConfig.SigningKeyID
+
+		if err = provider.addKey(
+			key{
+				id:        keysConfig.SigningKeyID,
+				method:    method,
+				publicKey: privateKey.Public(),
+			},
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, verificationKey := range keysConfig.VerificationKeys {
+		keyID, path, found := strings.Cut(verificationKey, "=")
+		if !found || keyID == "" || path == "" {
+			return nil, fmt.Errorf("invalid verification key %q, expected \"kid=path\" format", verificationKey)
+		}
+
+		publicKey, err := loadPublicKey(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load verification key with kid=%s: %w", keyID, err)
+		}
+
+		keyMethod, err := defaultMethod(publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load verification key with kid=%s: %w", keyID, err)
+		}
+
+		if err = provider.addKey(key{id: keyID, method: keyMethod, publicKey: publicKey}); err != nil {
+			return nil, err
+		}
+	}
+
+	return provider, nil
+}
+
+// Provider signs JWT with current key and verifies JWT with any of active keys, so keys can be rotated
+// without downtime: new key becomes signing one and previous key stays as verification key, until all issued
+// tokens are expired.
+type Provider struct {
+	method       jwt.SigningMethod
+	signingKey   any
+	signingKeyID string
+	secretKey    string
+	keys         map[string]key
+	jwks         entities.JWKS
+}
+
+func (provider *Provider) addKey(key key) error {
+	if _, ok := provider.keys[key.id]; ok {
+		return fmt.Errorf("duplicate JWT key with kid=%s", key.id)
+	}
+
+	jwk, err := mapKeyToJWK(key)
+	if err != nil {
+		return fmt.Errorf("failed to export JWT key with kid=%s: %w", key.id, err)
+	}
+
+	provider.keys[key.id] = key
+	provider.jwks.Keys = append(provider.jwks.Keys, jwk)
+
+	return nil
+}
+
+func (provider *Provider) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(provider.method, claims)
+	if provider.signingKeyID != "" {
+		token.Header[keyIDHeader] = provider.signingKeyID
+	}
+
+	return token.SignedString(provider.signingKey)
+}
+
+func (provider *Provider) Parse(token string, claims jwt.Claims, opts ...jwt.ParserOption) error {
+	_, err := jwt.ParseWithClaims(token, claims, provider.getVerificationKey, opts...)
+	return err
+}
+
+// GetJWKS returns public keys, which can be used by other services to verify issued JWT.
+func (provider *Provider) GetJWKS() entities.JWKS {
+	return entities.JWKS{Keys: slices.Clone(provider.jwks.Keys)}
+}
+
+// getVerificationKey selects key by "kid" header. Tokens without "kid" are signed with shared secret:
+// either HMAC algorithm is used or token was issued before asymmetric keys were introduced. Such legacy tokens
+// are rejected, if shared secret is empty.
+func (provider *Provider) getVerificationKey(token *jwt.Token) (any, error) {
+	keyID, _ := token.Header[keyIDHeader].(string)
+	if keyID == "" {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok || provider.secretKey == "" {
+			return nil, errUnknownKey
+		}
+
+		return []byte(provider.secretKey), nil
+	}
+
+	key, ok := provider.keys[keyID]
+	if !ok {
+		return nil, errUnknownKey
+	}
+
+	// Prevents algorithm confusion, when token is signed by algorithm, which differs from key's one:
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, errUnexpectedAlgorithm
+	}
+
+	return key.publicKey, nil
+}
@@ -0,0 +1,329 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DKhorkov/libs/security"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DKhorkov/hmtm-sso/internal/config"
+)
+
+// writePrivateKey saves private key to PEM file in PKCS #8 format and returns path to it.
+func writePrivateKey(t *testing.T, privateKey crypto.Signer) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "private.pem")
+	require.NoError(
+		t,
+		os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600),
+	)
+
+	return path
+}
+
+// writePublicKey saves public key to PEM file in PKIX format and returns path to it.
+func writePublicKey(t *testing.T, publicKey crypto.PublicKey) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "public.pem")
+	require.NoError(
+		t,
+		os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600),
+	)
+
+	return path
+}
+
+func testClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"value": float64(1),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestProvider_SignAndParse(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name            string
+		algorithm       string
+		privateKey      crypto.Signer
+		expectedKeyType string
+	}{
+		{
+			name:            "RS256",
+			algorithm:       "RS256",
+			privateKey:      rsaKey,
+			expectedKeyType: "RSA",
+		},
+		{
+			name:            "ES256",
+			algorithm:       "ES256",
+			privateKey:      ecdsaKey,
+			expectedKeyType: "EC",
+		},
+		{
+			name:            "EdDSA",
+			algorithm:       "EdDSA",
+			privateKey:      ed25519Key,
+			expectedKeyType: "OKP",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider, err := New(
+				security.JWTConfig{Algorithm: tc.algorithm},
+				config.JWTKeysConfig{
+					SigningKeyID:   "current",
+					SigningKeyPath: writePrivateKey(t, tc.privateKey),
+				},
+			)
+			require.NoError(t, err)
+
+			token, err := provider.Sign(testClaims())
+			require.NoError(t, err)
+
+			claims := jwt.MapClaims{}
+			require.NoError(t, provider.Parse(token, claims))
+			require.Equal(t, float64(1), claims["value"])
+
+			parsedToken, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+			require.NoError(t, err)
+			require.Equal(t, "current", parsedToken.Header[keyIDHeader])
+			require.Equal(t, tc.algorithm, parsedToken.Method.Alg())
+
+			jwks := provider.GetJWKS()
+			require.Len(t, jwks.Keys, 1)
+			require.Equal(t, "current", jwks.Keys[0].KeyID)
+			require.Equal(t, tc.algorithm, jwks.Keys[0].Algorithm)
+			require.Equal(t, tc.expectedKeyType, jwks.Keys[0].KeyType)
+			require.Equal(t, signatureKeyUse, jwks.Keys[0].Use)
+		})
+	}
+}
+
+func TestProvider_HMAC(t *testing.T) {
+	provider, err := New(security.JWTConfig{Algorithm: "HS256", SecretKey: "secret"}, config.JWTKeysConfig{})
+	require.NoError(t, err)
+	require.Empty(t, provider.GetJWKS().Keys) // Shared secret must not be published
+
+	token, err := provider.Sign(testClaims())
+	require.NoError(t, err)
+	require.NoError(t, provider.Parse(token, jwt.MapClaims{}))
+
+	// Tokens, which were issued by security package, stay valid:
+	legacyToken, err := security.GenerateJWT(1, "secret", time.Hour, "HS256")
+	require.NoError(t, err)
+	require.NoError(t, provider.Parse(legacyToken, jwt.MapClaims{}))
+
+	anotherSecretToken, err := security.GenerateJWT(1, "another secret", time.Hour, "HS256")
+	require.NoError(t, err)
+	require.Error(t, provider.Parse(anotherSecretToken, jwt.MapClaims{}))
+}
+
+func TestProvider_Rotation(t *testing.T) {
+	previousKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	currentKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	previousProvider, err := New(
+		security.JWTConfig{Algorithm: "ES384"},
+		config.JWTKeysConfig{
+			SigningKeyID:   "previous",
+			SigningKeyPath: writePrivateKey(t, previousKey),
+		},
+	)
+	require.NoError(t, err)
+
+	previousToken, err := previousProvider.Sign(testClaims())
+	require.NoError(t, err)
+
+	provider, err := New(
+		security.JWTConfig{Algorithm: "RS256", SecretKey: "secret"},
+		config.JWTKeysConfig{
+			SigningKeyID:     "current",
+			SigningKeyPath:   writePrivateKey(t, currentKey),
+			VerificationKeys: []string{"previous=" + writePublicKey(t, previousKey.Public())},
+		},
+	)
+	require.NoError(t, err)
+
+	currentToken, err := provider.Sign(testClaims())
+	require.NoError(t, err)
+
+	require.NoError(t, provider.Parse(previousToken, jwt.MapClaims{}))
+	require.NoError(t, provider.Parse(currentToken, jwt.MapClaims{}))
+	require.Error(t, previousProvider.Parse(currentToken, jwt.MapClaims{}))
+
+	jwks := provider.GetJWKS()
+	require.Len(t, jwks.Keys, 2)
+	require.Equal(t, "current", jwks.Keys[0].KeyID)
+	require.Equal(t, "previous", jwks.Keys[1].KeyID)
+	require.Equal(t, "ES384", jwks.Keys[1].Algorithm)
+	require.Equal(t, "P-384", jwks.Keys[1].Curve)
+
+	// Tokens, which were signed with shared secret before rotation to asymmetric keys:
+	legacyToken, err := security.GenerateJWT(1, "secret", time.Hour, "HS256")
+	require.NoError(t, err)
+	require.NoError(t, provider.Parse(legacyToken, jwt.MapClaims{}))
+}
+
+func TestProvider_ParseRejectsUntrustedTokens(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	provider, err := New(
+		security.JWTConfig{Algorithm: "RS256"},
+		config.JWTKeysConfig{
+			SigningKeyID:   "current",
+			SigningKeyPath: writePrivateKey(t, rsaKey),
+		},
+	)
+	require.NoError(t, err)
+
+	anotherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	unknownKeyToken := jwt.NewWithClaims(jwt.SigningMethodRS256, testClaims())
+	unknownKeyToken.Header[keyIDHeader] = "unknown"
+	signedUnknownKeyToken, err := unknownKeyToken.SignedString(anotherKey)
+	require.NoError(t, err)
+
+	forgedToken := jwt.NewWithClaims(jwt.SigningMethodRS256, testClaims())
+	forgedToken.Header[keyIDHeader] = "current"
+	signedForgedToken, err := forgedToken.SignedString(anotherKey)
+	require.NoError(t, err)
+
+	// Public key is known to everyone, so it must not be accepted as HMAC secret:
+	publicKeyDER, err := x509.MarshalPKIXPublicKey(rsaKey.Public())
+	require.NoError(t, err)
+
+	confusedToken := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims())
+	confusedToken.Header[keyIDHeader] = "current"
+	signedConfusedToken, err := confusedToken.SignedString(publicKeyDER)
+	require.NoError(t, err)
+
+	// Shared secret is not configured, so tokens without "kid" are not accepted:
+	emptySecretToken, err := security.GenerateJWT(1, "", time.Hour, "HS256")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name  string
+		token string
+	}{
+		{
+			name:  "unknown kid",
+			token: signedUnknownKeyToken,
+		},
+		{
+			name:  "signed with another key",
+			token: signedForgedToken,
+		},
+		{
+			name:  "algorithm confusion",
+			token: signedConfusedToken,
+		},
+		{
+			name:  "without kid",
+			token: emptySecretToken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Error(t, provider.Parse(tc.token, jwt.MapClaims{}))
+		})
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	rsaKeyPath := writePrivateKey(t, rsaKey)
+
+	testCases := []struct {
+		name       string
+		jwtConfig  security.JWTConfig
+		keysConfig config.JWTKeysConfig
+	}{
+		{
+			name:      "unsupported algorithm",
+			jwtConfig: security.JWTConfig{Algorithm: "unknown"},
+		},
+		{
+			name:       "signing key is not configured",
+			jwtConfig:  security.JWTConfig{Algorithm: "RS256"},
+			keysConfig: config.JWTKeysConfig{SigningKeyID: "current"},
+		},
+		{
+			name:      "signing key file does not exist",
+			jwtConfig: security.JWTConfig{Algorithm: "RS256"},
+			keysConfig: config.JWTKeysConfig{
+				SigningKeyID:   "current",
+				SigningKeyPath: filepath.Join(t.TempDir(), "missing.pem"),
+			},
+		},
+		{
+			name:      "incompatible signing key",
+			jwtConfig: security.JWTConfig{Algorithm: "ES256"},
+			keysConfig: config.JWTKeysConfig{
+				SigningKeyID:   "current",
+				SigningKeyPath: rsaKeyPath,
+			},
+		},
+		{
+			name:      "invalid verification key format",
+			jwtConfig: security.JWTConfig{Algorithm: "HS256", SecretKey: "secret"},
+			keysConfig: config.JWTKeysConfig{
+				VerificationKeys: []string{rsaKeyPath},
+			},
+		},
+		{
+			name:      "duplicate kid",
+			jwtConfig: security.JWTConfig{Algorithm: "RS256"},
+			keysConfig: config.JWTKeysConfig{
+				SigningKeyID:     "current",
+				SigningKeyPath:   rsaKeyPath,
+				VerificationKeys: []string{"current=" + rsaKeyPath},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider, err := New(tc.jwtConfig, tc.keysConfig)
+			require.Error(t, err)
+			require.Nil(t, provider)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Package telegram verifies data, which is received from Telegram Login Widget after User has authorized bot.
+// See https://core.telegram.org/widgets/login#checking-authorization for details.
+package telegram
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidHash     = errors.New("telegram auth data hash is invalid")
+	ErrAuthDataExpired = errors.New("telegram auth data is expired")
+)
+
+// AuthData contains fields of Telegram User, which are signed by bot token. Optional fields are empty,
+// if Telegram has not sent them.
+type AuthData struct {
+	ID        int64
+	FirstName string
+	LastName  string
+	Username  string
+	PhotoURL  string
+	AuthDate  int64 // unix time of authorization
+	Hash      string
+}
+
+// DataCheckString returns all received fields except hash, which are sorted alphabetically
+// in "key=value" format and separated by line feed.
+func DataCheckString(data AuthData) string {
+	fields := map[string]string{
+		"id":         strconv.FormatInt(data.ID, 10),
+		"first_name": data.FirstName,
+		"last_name":  data.LastName,
+		"username":   data.Username,
+		"photo_url":  data.PhotoURL,
+		"auth_date":  strconv.FormatInt(data.AuthDate, 10),
+	}
+
+	pairs := make([]string, 0, len(fields))
+	for key, value := range fields {
+		if value != "" {
+			pairs = append(pairs, key+"="+value)
+		}
+	}
+
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, "\n")
+}
+
+// Sign returns hex encoded HMAC-SHA256 of data check string. Key is SHA-256 of bot token.
+func Sign(botToken string, data AuthData) string {
+	return hex.EncodeToString(sign(botToken, data))
+}
+
+func sign(botToken string, data AuthData) []byte {
+	secretKey := sha256.Sum256([]byte(botToken))
+	mac := hmac.New(sha256.New, secretKey[:])
+	mac.Write([]byte(DataCheckString(data)))
+
+	return mac.Sum(nil)
+}
+
+// Verify checks, that data has been signed by Telegram for provided bot and has been received
+// not earlier than maxAge ago.
+func Verify(botToken string, data AuthData, maxAge time.Duration, now time.Time) error {
+	hash, err := hex.DecodeString(data.Hash)
+	if err != nil || !hmac.Equal(sign(botToken, data), hash) {
+		return ErrInvalidHash
+	}
+
+	if now.Sub(time.Unix(data.AuthDate, 0)) > maxAge {
+		return ErrAuthDataExpired
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package telegram_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DKhorkov/hmtm-sso/internal/telegram"
+)
+
+const botToken = "123456:ABC-DEF1234ghIkl-zyx57W2v1u123ew11"
+
+func TestDataCheckString(t *testing.T) {
+	data := telegram.AuthData{
+		ID:        42,
+		FirstName: "Иван",
+		Username:  "ivan_petrov",
+		AuthDate:  1700000000,
+		Hash:      "ignored",
+	}
+
+	require.Equal(
+		t,
+		"auth_date=1700000000\nfirst_name=Иван\nid=42\nusername=ivan_petrov",
+		telegram.DataCheckString(data),
+	)
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1700000060, 0)
+	data := telegram.AuthData{
+		ID:        42,
+		FirstName: "Иван",
+		LastName:  "Петров",
+		Username:  "ivan_petrov",
+		PhotoURL:  "https://t.me/i/userpic/320/ivan_petrov.jpg",
+		AuthDate:  1700000000,
+	}
+	data.Hash = telegram.Sign(botToken, data)
+
+	testCases := []struct {
+		name        string
+		botToken    string
+		modify      func(data *telegram.AuthData)
+		maxAge      time.Duration
+		expectedErr error
+	}{
+		{
+			name:     "success",
+			botToken: botToken,
+			maxAge:   time.Minute,
+		},
+		{
+			name:     "uppercase hash",
+			botToken: botToken,
+			modify: func(data *telegram.AuthData) {
+				data.Hash = strings.ToUpper(data.Hash)
+			},
+			maxAge: time.Minute,
+		},
+		{
+			name:        "another bot",
+			botToken:    "654321:ABC",
+			maxAge:      time.Minute,
+			expectedErr: telegram.ErrInvalidHash,
+		},
+		{
+			name:     "tampered username",
+			botToken: botToken,
+			modify: func(data *telegram.AuthData) {
+				data.Username = "admin"
+			},
+			maxAge:      time.Minute,
+			expectedErr: telegram.ErrInvalidHash,
+		},
+		{
+			name:     "hash is not hex",
+			botToken: botToken,
+			modify: func(data *telegram.AuthData) {
+				data.Hash = "not hex"
+			},
+			maxAge:      time.Minute,
+			expectedErr: telegram.ErrInvalidHash,
+		},
+		{
+			name:        "expired",
+			botToken:    botToken,
+			maxAge:      time.Second * 30,
+			expectedErr: telegram.ErrAuthDataExpired,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			authData := data
+			if tc.modify != nil {
+				tc.modify(&authData)
+			}
+
+			err := telegram.Verify(tc.botToken, authData, tc.maxAge, now)
+			require.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
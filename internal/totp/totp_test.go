@@ -0,0 +1,85 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Secret from RFC 6238 test vectors for HMAC-SHA1.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerateCode(t *testing.T) {
+	// RFC 6238 Appendix B contains 8 digits codes, so only last 6 digits are compared:
+	testCases := []struct {
+		unix     int64
+		expected string
+	}{
+		{unix: 59, expected: "287082"},
+		{unix: 1111111109, expected: "081804"},
+		{unix: 1111111111, expected: "050471"},
+		{unix: 1234567890, expected: "005924"},
+		{unix: 2000000000, expected: "279037"},
+		{unix: 20000000000, expected: "353130"},
+	}
+
+	for _, tc := range testCases {
+		code, err := GenerateCode(rfcSecret, Step(time.Unix(tc.unix, 0)))
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, code)
+	}
+
+	_, err := GenerateCode("not base32!", 1)
+	require.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+
+	code, err := GenerateCode(rfcSecret, Step(now))
+	require.NoError(t, err)
+
+	step, ok := Validate(rfcSecret, code, now)
+	require.True(t, ok)
+	require.Equal(t, Step(now), step)
+
+	// Clock drift of one period is tolerated:
+	step, ok = Validate(rfcSecret, code, now.Add(Period))
+	require.True(t, ok)
+	require.Equal(t, Step(now), step)
+
+	_, ok = Validate(rfcSecret, code, now.Add(3*Period))
+	require.False(t, ok)
+
+	_, ok = Validate(rfcSecret, "12345", now)
+	require.False(t, ok)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	first, err := GenerateSecret()
+	require.NoError(t, err)
+
+	second, err := GenerateSecret()
+	require.NoError(t, err)
+
+	require.NotEqual(t, first, second)
+	require.Len(t, first, 32) // 20 bytes in base32 without padding
+
+	_, err = GenerateCode(first, 1)
+	require.NoError(t, err)
+}
+
+func TestURI(t *testing.T) {
+	uri, err := url.Parse(URI("hmtm", "user@example.com", rfcSecret))
+	require.NoError(t, err)
+	require.Equal(t, "otpauth", uri.Scheme)
+	require.Equal(t, "totp", uri.Host)
+	require.Equal(t, "/hmtm:user@example.com", uri.Path)
+	require.Equal(t, rfcSecret, uri.Query().Get("secret"))
+	require.Equal(t, "hmtm", uri.Query().Get("issuer"))
+	require.Equal(t, "6", uri.Query().Get("digits"))
+	require.Equal(t, "30", uri.Query().Get("period"))
+}
@@ -0,0 +1,36 @@
+package usecases
+
+import "github.com/golang-jwt/jwt/v5"
+
+// accessTokenClaims are registered JWT claims (RFC 7519) with Session and roles of User.
+// Subject claim contains User's ID, client_id claim (RFC 9068) - ID of Client, which token was issued for.
+// Scope claim contains scopes, granted to token, and is absent in tokens, issued without scopes.
+// Grant type claim is set only in tokens of Clients and is parsed to reject them, when User's token is expected.
+type accessTokenClaims struct {
+	jwt.RegisteredClaims
+	SessionID string   `json:"sid,omitempty"`
+	ClientID  string   `json:"client_id,omitempty"`
+	Roles     []string `json:"roles,omitempty"`
+	Scope     string   `json:"scope,omitempty"`
+	GrantType string   `json:"gty,omitempty"`
+}
+
+// clientTokenClaims are claims of access token, which has been issued to Client on its own behalf
+// by client credentials grant. Subject and client_id claims both contain ID of Client (RFC 9068).
+type clientTokenClaims struct {
+	jwt.RegisteredClaims
+	ClientID  string `json:"client_id"`
+	Scope     string `json:"scope,omitempty"`
+	GrantType string `json:"gty"`
+}
+
+// idTokenClaims are claims of OpenID Connect ID token. Audience claim contains ID of client,
+// which User has been authorized for.
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce         string `json:"nonce,omitempty"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified *bool  `json:"email_verified,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Picture       string `json:"picture,omitempty"`
+}
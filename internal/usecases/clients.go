@@ -0,0 +1,261 @@
+package usecases
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DKhorkov/libs/security"
+	"github.com/DKhorkov/libs/validation"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+)
+
+const (
+	// Lists of Client settings are stored as strings in Database:
+	redirectURIsSeparator = ","
+	grantTypesSeparator   = ","
+	scopesSeparator       = " "
+)
+
+var (
+	clientIDRegExp = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,255}$`)
+	scopeRegExp    = regexp.MustCompile(`^[a-zA-Z0-9._:/-]{1,255}$`)
+
+	supportedGrantTypes = []string{
+		entities.PasswordGrantType,
+		entities.AuthorizationCodeGrantType,
+		entities.RefreshTokenGrantType,
+		entities.ClientCredentialsGrantType,
+	}
+)
+
+// verifyAdministrator checks, that access token has been issued for User with administrator role.
+func (useCases *UseCases) verifyAdministrator(ctx context.Context, accessToken string) error {
+	accessTokenPayload, err := useCases.verifyAccessToken(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(accessTokenPayload.Roles, entities.AdminRole) {
+		return &customerrors.PermissionDeniedError{Message: "administrator role is required"}
+	}
+
+	return nil
+}
+
+// validateClientSettings checks settings of Client. Access token TTL of Client can not exceed default one,
+// because revoked Sessions are kept in revocation list only for default access token TTL.
+func (useCases *UseCases) validateClientSettings(settings entities.ClientSettingsDTO) error {
+	if len(settings.GrantTypes) == 0 {
+		return &validation.Error{Message: "at least one grant type is required"}
+	}
+
+	for _, grantType := range settings.GrantTypes {
+		if !slices.Contains(supportedGrantTypes, grantType) {
+			return &validation.Error{Message: "unsupported grant type: " + grantType}
+		}
+	}
+
+	if slices.Contains(settings.GrantTypes, entities.AuthorizationCodeGrantType) && len(settings.RedirectURIs) == 0 {
+		return &validation.Error{Message: "redirect URIs are required for authorization_code grant type"}
+	}
+
+	for _, redirectURI := range settings.RedirectURIs {
+		parsedURI, err := url.Parse(redirectURI)
+		if err != nil || parsedURI.Scheme == "" || parsedURI.Host == "" || parsedURI.Fragment != "" ||
+			strings.Contains(redirectURI, redirectURIsSeparator) {
+			return &validation.Error{Message: "invalid redirect URI: " + redirectURI}
+		}
+	}
+
+	for _, scope := range settings.Scopes {
+		if !scopeRegExp.MatchString(scope) {
+			return &validation.Error{Message: "invalid scope: " + scope}
+		}
+	}
+
+	if settings.AccessTokenTTL < 0 || settings.AccessTokenTTL > useCases.securityConfig.JWT.AccessTokenTTL {
+		return &validation.Error{Message: "access token TTL must not exceed default access token TTL"}
+	}
+
+	if settings.RefreshTokenTTL < 0 {
+		return &validation.Error{Message: "refresh token TTL must not be negative"}
+	}
+
+	return nil
+}
+
+// authenticateClient checks secret of confidential Client and, that Client is allowed to use provided grant type.
+// Public Clients have no secret, so they are identified only by Client ID and can not use client credentials grant.
+func (useCases *UseCases) authenticateClient(
+	ctx context.Context,
+	clientID string,
+	clientSecret string,
+	grantType string,
+) (*entities.Client, error) {
+	client, err := useCases.authService.GetClientByClientID(ctx, clientID)
+	if err != nil {
+		var clientNotFoundError *customerrors.ClientNotFoundError
+		if errors.As(err, &clientNotFoundError) {
+			// Base error is not wrapped, because message of error is reported to client:
+			return nil, &customerrors.InvalidClientError{Message: "unknown client"}
+		}
+
+		return nil, err
+	}
+
+	if client.SecretHash != "" && subtle.ConstantTimeCompare(
+		[]byte(hashToken(useCases.tokensConfig.SecretKey, clientSecret)),
+		[]byte(client.SecretHash),
+	) != 1 {
+		return nil, &customerrors.InvalidClientError{}
+	}
+
+	if !slices.Contains(strings.Split(client.GrantTypes, grantTypesSeparator), grantType) {
+		return nil, &customerrors.UnauthorizedClientError{
+			Message: "client is not allowed to use grant type: " + grantType,
+		}
+	}
+
+	// Client, which requests token on its own behalf, must prove its identity with secret:
+	if grantType == entities.ClientCredentialsGrantType && client.SecretHash == "" {
+		return nil, &customerrors.UnauthorizedClientError{
+			Message: "public client is not allowed to use grant type: " + grantType,
+		}
+	}
+
+	return client, nil
+}
+
+// authenticateLoginClient returns Client, which User logs in through, or nil, if no Client has been provided.
+func (useCases *UseCases) authenticateLoginClient(
+	ctx context.Context,
+	clientInfo entities.ClientInfo,
+) (*entities.Client, error) {
+	if clientInfo.ClientID == "" {
+		return nil, nil
+	}
+
+	return useCases.authenticateClient(
+		ctx,
+		clientInfo.ClientID,
+		clientInfo.ClientSecret,
+		entities.PasswordGrantType,
+	)
+}
+
+// accessTokenTTL returns access token TTL of Client or default one.
+func (useCases *UseCases) accessTokenTTL(client *entities.Client) time.Duration {
+	if client != nil && client.AccessTokenTTL > 0 {
+		return time.Duration(client.AccessTokenTTL) * time.Second
+	}
+
+	return useCases.securityConfig.JWT.AccessTokenTTL
+}
+
+// refreshTokenTTL returns refresh token TTL of Client or default one.
+func (useCases *UseCases) refreshTokenTTL(client *entities.Client) time.Duration {
+	if client != nil && client.RefreshTokenTTL > 0 {
+		return time.Duration(client.RefreshTokenTTL) * time.Second
+	}
+
+	return useCases.securityConfig.JWT.RefreshTokenTTL
+}
+
+// getRefreshTokenClient returns Client, which refresh token has been issued for. Refresh tokens
+// of deleted Clients or Clients, which are not allowed to refresh tokens anymore, are invalid.
+func (useCases *UseCases) getRefreshTokenClient(
+	ctx context.Context,
+	dbRefreshToken *entities.RefreshToken,
+) (*entities.Client, error) {
+	if dbRefreshToken.ClientID == nil {
+		return nil, nil
+	}
+
+	client, err := useCases.authService.GetClientByClientID(ctx, *dbRefreshToken.ClientID)
+	if err != nil {
+		var clientNotFoundError *customerrors.ClientNotFoundError
+		if errors.As(err, &clientNotFoundError) {
+			return nil, &security.InvalidJWTError{}
+		}
+
+		return nil, err
+	}
+
+	if !slices.Contains(strings.Split(client.GrantTypes, grantTypesSeparator), entities.RefreshTokenGrantType) {
+		return nil, &security.InvalidJWTError{}
+	}
+
+	return client, nil
+}
+
+// grantClientScope returns scope, which is granted to Client. All scopes of Client are granted,
+// if no scope has been requested.
+func grantClientScope(client *entities.Client, requestedScope string) (string, error) {
+	clientScopes := strings.Fields(client.Scopes)
+	requestedScopes := strings.Fields(requestedScope)
+	if len(requestedScopes) == 0 {
+		return strings.Join(clientScopes, scopesSeparator), nil
+	}
+
+	for _, scope := range requestedScopes {
+		if !slices.Contains(clientScopes, scope) {
+			return "", &customerrors.InvalidScopeError{Message: "scope is not allowed for client: " + scope}
+		}
+	}
+
+	return strings.Join(requestedScopes, scopesSeparator), nil
+}
+
+// issueClientToken signs access token of Client with granted scope.
+func (useCases *UseCases) issueClientToken(
+	client *entities.Client,
+	requestedScope string,
+) (*entities.ClientTokenDTO, error) {
+	scope, err := grantClientScope(client, requestedScope)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenID, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	accessTokenTTL := useCases.accessTokenTTL(client)
+
+	accessToken, err := useCases.jwtProvider.Sign(
+		clientTokenClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID:        tokenID,
+				Subject:   client.ClientID,
+				Issuer:    useCases.accessTokensConfig.Issuer,
+				Audience:  jwt.ClaimStrings{useCases.accessTokensConfig.Audience},
+				IssuedAt:  jwt.NewNumericDate(now),
+				ExpiresAt: jwt.NewNumericDate(now.Add(accessTokenTTL)),
+			},
+			ClientID:  client.ClientID,
+			Scope:     scope,
+			GrantType: entities.ClientCredentialsGrantType,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.ClientTokenDTO{
+		AccessToken: accessToken,
+		TokenType:   entities.BearerTokenType,
+		ExpiresIn:   accessTokenTTL,
+		Scope:       scope,
+	}, nil
+}
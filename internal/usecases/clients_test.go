@@ -0,0 +1,1221 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	mocknats "github.com/DKhorkov/libs/nats/mocks"
+	"github.com/DKhorkov/libs/pointers"
+	"github.com/DKhorkov/libs/security"
+	"github.com/DKhorkov/libs/validation"
+
+	"github.com/DKhorkov/hmtm-sso/internal/config"
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+	mockcache "github.com/DKhorkov/hmtm-sso/mocks/cache"
+	mockservices "github.com/DKhorkov/hmtm-sso/mocks/services"
+)
+
+// newAdminAccessToken issues access token for administrator with provided ID and Session.
+func newAdminAccessToken(t *testing.T, jwtConfig security.JWTConfig, userID, sessionID uint64) string {
+	t.Helper()
+
+	claims := accessTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "jti",
+			Subject:   strconv.FormatUint(userID, 10),
+			Issuer:    accessTokensConfig.Issuer,
+			Audience:  jwt.ClaimStrings{accessTokensConfig.Audience},
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(jwtConfig.AccessTokenTTL)),
+		},
+		SessionID: strconv.FormatUint(sessionID, 10),
+		Roles:     []string{entities.UserRole, entities.AdminRole},
+	}
+
+	accessToken, err := newJWTProvider(t, jwtConfig).Sign(claims)
+	require.NoError(t, err)
+
+	return accessToken
+}
+
+// clientRefreshTokenData matches refresh token, which is issued for provided Client with provided TTL.
+func clientRefreshTokenData(clientID string, ttl time.Duration) gomock.Matcher {
+	return gomock.Cond(func(refreshTokenData entities.CreateRefreshTokenDTO) bool {
+		return refreshTokenData.ClientID != nil &&
+			*refreshTokenData.ClientID == clientID &&
+			refreshTokenData.TTL == ttl
+	})
+}
+
+func newClientsUseCases(
+	t *testing.T,
+	authService *mockservices.MockAuthService,
+	usersService *mockservices.MockUsersService,
+	cacheProvider *mockcache.MockCacheProvider,
+	securityConfig security.Config,
+) *UseCases {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	return New(
+		authService,
+		usersService,
+		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		mocknats.NewMockPublisher(ctrl),
+		config.NATSConfig{},
+		mocklogging.NewMockLogger(ctrl),
+		cacheProvider,
+	)
+}
+
+func TestUseCases_RegisterClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+
+	useCases := newClientsUseCases(t, authService, usersService, cacheProvider, securityConfig)
+	adminAccessToken := newAdminAccessToken(t, securityConfig.JWT, 1, 2)
+	clientData := entities.RegisterClientDTO{
+		AccessToken:  adminAccessToken,
+		ClientID:     "shop",
+		Confidential: true,
+		Settings: entities.ClientSettingsDTO{
+			RedirectURIs:    []string{"https://shop.example.com/callback"},
+			GrantTypes:      []string{entities.AuthorizationCodeGrantType, entities.RefreshTokenGrantType},
+			Scopes:          []string{entities.OpenIDScope, entities.EmailScope},
+			AccessTokenTTL:  time.Minute * 5,
+			RefreshTokenTTL: time.Hour * 24,
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		clientData func() entities.RegisterClientDTO
+		setupMocks func(
+			authService *mockservices.MockAuthService,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectSecret bool
+		expectedErr  error
+	}{
+		{
+			name: "success",
+			clientData: func() entities.RegisterClientDTO {
+				return clientData
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					GetClientByClientID(gomock.Any(), "shop").
+					Return(nil, &customerrors.ClientNotFoundError{}).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateClient(
+						gomock.Any(),
+						gomock.Cond(func(createClientData entities.CreateClientDTO) bool {
+							return createClientData.ClientID == "shop" &&
+								createClientData.SecretHash != "" &&
+								createClientData.RedirectURIs == "https://shop.example.com/callback" &&
+								createClientData.GrantTypes == "authorization_code,refresh_token" &&
+								createClientData.Scopes == "openid email" &&
+								createClientData.AccessTokenTTL == time.Minute*5 &&
+								createClientData.RefreshTokenTTL == time.Hour*24
+						}),
+					).
+					Return(uint64(1), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetClientByClientID(gomock.Any(), "shop").
+					Return(&entities.Client{ID: 1, ClientID: "shop"}, nil).
+					Times(1)
+			},
+			expectSecret: true,
+			expectedErr:  nil,
+		},
+		{
+			name: "not administrator",
+			clientData: func() entities.RegisterClientDTO {
+				data := clientData
+				data.AccessToken = newAccessToken(t, securityConfig.JWT, 1, 2)
+
+				return data
+			},
+			setupMocks: func(
+				_ *mockservices.MockAuthService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+			},
+			expectedErr: &customerrors.PermissionDeniedError{Message: "administrator role is required"},
+		},
+		{
+			name: "invalid access token",
+			clientData: func() entities.RegisterClientDTO {
+				data := clientData
+				data.AccessToken = "invalid"
+
+				return data
+			},
+			expectedErr: &security.InvalidJWTError{},
+		},
+		{
+			name: "invalid client ID",
+			clientData: func() entities.RegisterClientDTO {
+				data := clientData
+				data.ClientID = "shop client"
+
+				return data
+			},
+			setupMocks: func(
+				_ *mockservices.MockAuthService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+			},
+			expectedErr: &validation.Error{Message: "invalid client ID"},
+		},
+		{
+			name: "unsupported grant type",
+			clientData: func() entities.RegisterClientDTO {
+				data := clientData
+				data.Settings.GrantTypes = []string{"implicit"}
+
+				return data
+			},
+			setupMocks: func(
+				_ *mockservices.MockAuthService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+			},
+			expectedErr: &validation.Error{Message: "unsupported grant type: implicit"},
+		},
+		{
+			name: "redirect uri is required for authorization code",
+			clientData: func() entities.RegisterClientDTO {
+				data := clientData
+				data.Settings.RedirectURIs = nil
+
+				return data
+			},
+			setupMocks: func(
+				_ *mockservices.MockAuthService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+			},
+			expectedErr: &validation.Error{
+				Message: "redirect URIs are required for authorization_code grant type",
+			},
+		},
+		{
+			name: "relative redirect uri",
+			clientData: func() entities.RegisterClientDTO {
+				data := clientData
+				data.Settings.RedirectURIs = []string{"/callback"}
+
+				return data
+			},
+			setupMocks: func(
+				_ *mockservices.MockAuthService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+			},
+			expectedErr: &validation.Error{Message: "invalid redirect URI: /callback"},
+		},
+		{
+			name: "access token TTL exceeds default one",
+			clientData: func() entities.RegisterClientDTO {
+				data := clientData
+				data.Settings.AccessTokenTTL = time.Hour * 2
+
+				return data
+			},
+			setupMocks: func(
+				_ *mockservices.MockAuthService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+			},
+			expectedErr: &validation.Error{Message: "access token TTL must not exceed default access token TTL"},
+		},
+		{
+			name: "client credentials grant type for public client",
+			clientData: func() entities.RegisterClientDTO {
+				data := clientData
+				data.Confidential = false
+				data.Settings.GrantTypes = []string{entities.ClientCredentialsGrantType}
+
+				return data
+			},
+			setupMocks: func(
+				_ *mockservices.MockAuthService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+			},
+			expectedErr: &validation.Error{
+				Message: "client_credentials grant type is allowed only for confidential clients",
+			},
+		},
+		{
+			name: "client already exists",
+			clientData: func() entities.RegisterClientDTO {
+				return clientData
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					GetClientByClientID(gomock.Any(), "shop").
+					Return(&entities.Client{ID: 1, ClientID: "shop"}, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.ClientAlreadyExistsError{},
+		},
+		{
+			name: "create client error",
+			clientData: func() entities.RegisterClientDTO {
+				data := clientData
+				data.Confidential = false
+
+				return data
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					GetClientByClientID(gomock.Any(), "shop").
+					Return(nil, &customerrors.ClientNotFoundError{}).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateClient(
+						gomock.Any(),
+						gomock.Cond(func(createClientData entities.CreateClientDTO) bool {
+							// Secret is not generated for public Client:
+							return createClientData.SecretHash == ""
+						}),
+					).
+					Return(uint64(0), errors.New("test error")).
+					Times(1)
+			},
+			expectedErr: errors.New("test error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authService, cacheProvider)
+			}
+
+			registeredClient, err := useCases.RegisterClient(context.Background(), tc.clientData())
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, registeredClient)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, registeredClient)
+			require.Equal(t, "shop", registeredClient.Client.ClientID)
+
+			if tc.expectSecret {
+				require.NotEmpty(t, registeredClient.Secret)
+			}
+		})
+	}
+}
+
+func TestUseCases_GetClients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+
+	useCases := newClientsUseCases(t, authService, usersService, cacheProvider, securityConfig)
+
+	testCases := []struct {
+		name        string
+		accessToken string
+		setupMocks  func(
+			authService *mockservices.MockAuthService,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedClients []entities.Client
+		expectedErr     error
+	}{
+		{
+			name:        "success",
+			accessToken: newAdminAccessToken(t, securityConfig.JWT, 1, 2),
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					GetClients(gomock.Any()).
+					Return([]entities.Client{{ID: 1, ClientID: "shop"}}, nil).
+					Times(1)
+			},
+			expectedClients: []entities.Client{{ID: 1, ClientID: "shop"}},
+			expectedErr:     nil,
+		},
+		{
+			name:        "not administrator",
+			accessToken: newAccessToken(t, securityConfig.JWT, 1, 2),
+			setupMocks: func(
+				_ *mockservices.MockAuthService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+			},
+			expectedErr: &customerrors.PermissionDeniedError{Message: "administrator role is required"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authService, cacheProvider)
+			}
+
+			clients, err := useCases.GetClients(context.Background(), tc.accessToken)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, clients)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedClients, clients)
+		})
+	}
+}
+
+func TestUseCases_UpdateClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+
+	useCases := newClientsUseCases(t, authService, usersService, cacheProvider, securityConfig)
+	clientData := entities.UpdateClientDTO{
+		AccessToken: newAdminAccessToken(t, securityConfig.JWT, 1, 2),
+		ClientID:    "shop",
+		Settings: entities.ClientSettingsDTO{
+			GrantTypes: []string{entities.PasswordGrantType, entities.RefreshTokenGrantType},
+			Scopes:     []string{"orders:read"},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		clientData func() entities.UpdateClientDTO
+		setupMocks func(
+			authService *mockservices.MockAuthService,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			clientData: func() entities.UpdateClientDTO {
+				return clientData
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					UpdateClient(
+						gomock.Any(),
+						entities.UpdateClientSettingsDTO{
+							ClientID:   "shop",
+							GrantTypes: "password,refresh_token",
+							Scopes:     "orders:read",
+						},
+					).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "invalid scope",
+			clientData: func() entities.UpdateClientDTO {
+				data := clientData
+				data.Settings.Scopes = []string{"orders read"}
+
+				return data
+			},
+			setupMocks: func(
+				_ *mockservices.MockAuthService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+			},
+			expectedErr: &validation.Error{Message: "invalid scope: orders read"},
+		},
+		{
+			name: "client not found",
+			clientData: func() entities.UpdateClientDTO {
+				return clientData
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					UpdateClient(gomock.Any(), gomock.Any()).
+					Return(&customerrors.ClientNotFoundError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.ClientNotFoundError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authService, cacheProvider)
+			}
+
+			err := useCases.UpdateClient(context.Background(), tc.clientData())
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUseCases_DeleteClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+
+	useCases := newClientsUseCases(t, authService, usersService, cacheProvider, securityConfig)
+
+	testCases := []struct {
+		name        string
+		accessToken string
+		setupMocks  func(
+			authService *mockservices.MockAuthService,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedErr error
+	}{
+		{
+			name:        "success",
+			accessToken: newAdminAccessToken(t, securityConfig.JWT, 1, 2),
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					DeleteClient(gomock.Any(), "shop").
+					Return(nil).
+					Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "not administrator",
+			accessToken: newAccessToken(t, securityConfig.JWT, 1, 2),
+			setupMocks: func(
+				_ *mockservices.MockAuthService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+			},
+			expectedErr: &customerrors.PermissionDeniedError{Message: "administrator role is required"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authService, cacheProvider)
+			}
+
+			err := useCases.DeleteClient(context.Background(), tc.accessToken, "shop")
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUseCases_LoginUserThroughClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+
+	useCases := newClientsUseCases(t, authService, usersService, cacheProvider, securityConfig)
+	mobileClient := &entities.Client{
+		ID:              1,
+		ClientID:        "mobile",
+		SecretHash:      hashToken(tokensConfig.SecretKey, "client-secret"),
+		GrantTypes:      "password,refresh_token",
+		AccessTokenTTL:  300,
+		RefreshTokenTTL: 86400,
+	}
+
+	hashedPassword, err := security.Hash("password123", 10)
+	require.NoError(t, err)
+
+	user := &entities.User{
+		ID:             1,
+		Email:          "test@example.com",
+		Password:       hashedPassword,
+		EmailConfirmed: true,
+	}
+
+	testCases := []struct {
+		name         string
+		clientSecret string
+		client       *entities.Client
+		setupMocks   func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+		)
+		expectedErr error
+	}{
+		{
+			name:         "success",
+			clientSecret: "client-secret",
+			client:       mobileClient,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), user.Email).
+					Return(user, nil).
+					Times(1)
+
+				expectLoginIsNotLocked(cacheProvider, "", 1)
+				expectLoginFailuresAreReset(cacheProvider, 1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(nil, &customerrors.MFANotEnabledError{}).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ExpireForgetPasswordTokens(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateSession(
+						gomock.Any(),
+						gomock.Cond(func(sessionData entities.CreateSessionDTO) bool {
+							return sessionData.TTL == time.Hour*24
+						}),
+					).
+					Return(uint64(2), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateRefreshToken(gomock.Any(), clientRefreshTokenData("mobile", time.Hour*24)).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:         "wrong client secret",
+			clientSecret: "wrong-secret",
+			client:       mobileClient,
+			expectedErr:  &customerrors.InvalidClientError{},
+		},
+		{
+			name:         "client is not allowed to use password grant type",
+			clientSecret: "client-secret",
+			client: &entities.Client{
+				ID:         1,
+				ClientID:   "mobile",
+				SecretHash: hashToken(tokensConfig.SecretKey, "client-secret"),
+				GrantTypes: "authorization_code",
+			},
+			expectedErr: &customerrors.UnauthorizedClientError{
+				Message: "client is not allowed to use grant type: password",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			authService.
+				EXPECT().
+				GetClientByClientID(gomock.Any(), "mobile").
+				Return(tc.client, nil).
+				Times(1)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(authService, usersService)
+			}
+
+			tokens, err := useCases.LoginUser(
+				context.Background(),
+				entities.LoginUserDTO{
+					Identifier: user.Email,
+					Password:   "password123",
+					ClientInfo: entities.ClientInfo{
+						ClientID:     "mobile",
+						ClientSecret: tc.clientSecret,
+					},
+				},
+			)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, tokens)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, time.Minute*5, tokens.ExpiresIn)
+
+			claims := &accessTokenClaims{}
+			_, err = jwt.ParseWithClaims(
+				tokens.AccessToken,
+				claims,
+				func(*jwt.Token) (any, error) {
+					return []byte(securityConfig.JWT.SecretKey), nil
+				},
+			)
+			require.NoError(t, err)
+			require.Equal(t, "mobile", claims.ClientID)
+		})
+	}
+}
+
+func TestUseCases_RefreshTokensOfClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+
+	useCases := newClientsUseCases(t, authService, usersService, cacheProvider, securityConfig)
+
+	refreshToken, err := generateToken()
+	require.NoError(t, err)
+
+	dbRefreshToken := &entities.RefreshToken{
+		ID:        1,
+		UserID:    1,
+		SessionID: pointers.New[uint64](2),
+		FamilyID:  pointers.New("family"),
+		TTL:       time.Now().UTC().Add(time.Hour),
+		ClientID:  pointers.New("mobile"),
+	}
+
+	testCases := []struct {
+		name       string
+		setupMocks func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+		)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+			) {
+				authService.
+					EXPECT().
+					GetClientByClientID(gomock.Any(), "mobile").
+					Return(
+						&entities.Client{
+							ID:              1,
+							ClientID:        "mobile",
+							GrantTypes:      "password,refresh_token",
+							AccessTokenTTL:  300,
+							RefreshTokenTTL: 86400,
+						},
+						nil,
+					).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					RotateRefreshToken(gomock.Any(), uint64(1), "family").
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateRefreshToken(gomock.Any(), clientRefreshTokenData("mobile", time.Hour*24)).
+					Return(uint64(2), nil).
+					Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "client has been deleted",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				_ *mockservices.MockUsersService,
+			) {
+				authService.
+					EXPECT().
+					GetClientByClientID(gomock.Any(), "mobile").
+					Return(nil, &customerrors.ClientNotFoundError{}).
+					Times(1)
+			},
+			expectedErr: &security.InvalidJWTError{},
+		},
+		{
+			name: "client is not allowed to refresh tokens",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				_ *mockservices.MockUsersService,
+			) {
+				authService.
+					EXPECT().
+					GetClientByClientID(gomock.Any(), "mobile").
+					Return(&entities.Client{ID: 1, ClientID: "mobile", GrantTypes: "password"}, nil).
+					Times(1)
+			},
+			expectedErr: &security.InvalidJWTError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			authService.
+				EXPECT().
+				GetRefreshTokenByValue(gomock.Any(), hashRefreshToken(refreshToken)).
+				Return(dbRefreshToken, nil).
+				Times(1)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(authService, usersService)
+			}
+
+			tokens, err := useCases.RefreshTokens(context.Background(), refreshToken)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, tokens)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, time.Minute*5, tokens.ExpiresIn)
+		})
+	}
+}
+
+func TestUseCases_IssueClientToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+
+	useCases := newClientsUseCases(t, authService, usersService, cacheProvider, securityConfig)
+	notificationsClient := &entities.Client{
+		ID:             1,
+		ClientID:       "notifications",
+		SecretHash:     hashToken(tokensConfig.SecretKey, "client-secret"),
+		GrantTypes:     "client_credentials",
+		Scopes:         "users:read users:write",
+		AccessTokenTTL: 300,
+	}
+
+	testCases := []struct {
+		name          string
+		tokenRequest  entities.IssueClientTokenDTO
+		client        *entities.Client
+		clientErr     error
+		expectedScope string
+		expectedErr   error
+	}{
+		{
+			name: "success",
+			tokenRequest: entities.IssueClientTokenDTO{
+				ClientID:     "notifications",
+				ClientSecret: "client-secret",
+				Scope:        "users:read",
+			},
+			client:        notificationsClient,
+			expectedScope: "users:read",
+		},
+		{
+			name: "all scopes of client are granted by default",
+			tokenRequest: entities.IssueClientTokenDTO{
+				ClientID:     "notifications",
+				ClientSecret: "client-secret",
+			},
+			client:        notificationsClient,
+			expectedScope: "users:read users:write",
+		},
+		{
+			name: "scope is not allowed for client",
+			tokenRequest: entities.IssueClientTokenDTO{
+				ClientID:     "notifications",
+				ClientSecret: "client-secret",
+				Scope:        "users:read users:delete",
+			},
+			client:      notificationsClient,
+			expectedErr: &customerrors.InvalidScopeError{Message: "scope is not allowed for client: users:delete"},
+		},
+		{
+			name: "wrong client secret",
+			tokenRequest: entities.IssueClientTokenDTO{
+				ClientID:     "notifications",
+				ClientSecret: "wrong-secret",
+			},
+			client:      notificationsClient,
+			expectedErr: &customerrors.InvalidClientError{},
+		},
+		{
+			name: "unknown client",
+			tokenRequest: entities.IssueClientTokenDTO{
+				ClientID:     "notifications",
+				ClientSecret: "client-secret",
+			},
+			clientErr:   &customerrors.ClientNotFoundError{},
+			expectedErr: &customerrors.InvalidClientError{Message: "unknown client"},
+		},
+		{
+			name: "public client",
+			tokenRequest: entities.IssueClientTokenDTO{
+				ClientID: "notifications",
+			},
+			client: &entities.Client{
+				ID:         1,
+				ClientID:   "notifications",
+				GrantTypes: "client_credentials",
+				Scopes:     "users:read",
+			},
+			expectedErr: &customerrors.UnauthorizedClientError{
+				Message: "public client is not allowed to use grant type: client_credentials",
+			},
+		},
+		{
+			name: "client is not allowed to use client credentials grant type",
+			tokenRequest: entities.IssueClientTokenDTO{
+				ClientID:     "notifications",
+				ClientSecret: "client-secret",
+			},
+			client: &entities.Client{
+				ID:         1,
+				ClientID:   "notifications",
+				SecretHash: hashToken(tokensConfig.SecretKey, "client-secret"),
+				GrantTypes: "password",
+			},
+			expectedErr: &customerrors.UnauthorizedClientError{
+				Message: "client is not allowed to use grant type: client_credentials",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			authService.
+				EXPECT().
+				GetClientByClientID(gomock.Any(), "notifications").
+				Return(tc.client, tc.clientErr).
+				Times(1)
+
+			clientToken, err := useCases.IssueClientToken(context.Background(), tc.tokenRequest)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, clientToken)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, entities.BearerTokenType, clientToken.TokenType)
+			require.Equal(t, time.Minute*5, clientToken.ExpiresIn)
+			require.Equal(t, tc.expectedScope, clientToken.Scope)
+
+			clientTokenPayload, err := useCases.VerifyClientToken(clientToken.AccessToken)
+			require.NoError(t, err)
+			require.Equal(t, "notifications", clientTokenPayload.ClientID)
+			require.Equal(t, strings.Fields(tc.expectedScope), clientTokenPayload.Scopes)
+
+			// Token of Client can not be used on behalf of User:
+			_, err = useCases.parseAccessToken(clientToken.AccessToken)
+			require.Equal(t, &security.InvalidJWTError{}, err)
+		})
+	}
+}
+
+func TestUseCases_VerifyClientToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+
+	useCases := newClientsUseCases(t, authService, usersService, cacheProvider, securityConfig)
+
+	signClientToken := func(claims clientTokenClaims) string {
+		accessToken, err := newJWTProvider(t, securityConfig.JWT).Sign(claims)
+		require.NoError(t, err)
+
+		return accessToken
+	}
+
+	registeredClaims := jwt.RegisteredClaims{
+		ID:        "jti",
+		Subject:   "notifications",
+		Issuer:    accessTokensConfig.Issuer,
+		Audience:  jwt.ClaimStrings{accessTokensConfig.Audience},
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+	}
+
+	expiredClaims := registeredClaims
+	expiredClaims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+
+	testCases := []struct {
+		name            string
+		accessToken     string
+		expectedPayload *entities.ClientTokenPayload
+		expectedErr     error
+	}{
+		{
+			name: "success",
+			accessToken: signClientToken(clientTokenClaims{
+				RegisteredClaims: registeredClaims,
+				ClientID:         "notifications",
+				Scope:            "users:read",
+				GrantType:        entities.ClientCredentialsGrantType,
+			}),
+			expectedPayload: &entities.ClientTokenPayload{
+				ID:        "jti",
+				ClientID:  "notifications",
+				Scopes:    []string{"users:read"},
+				ExpiresAt: registeredClaims.ExpiresAt.Time,
+			},
+		},
+		{
+			name:        "token of user",
+			accessToken: newAccessToken(t, securityConfig.JWT, 1, 2),
+			expectedErr: &security.InvalidJWTError{},
+		},
+		{
+			name: "subject differs from client ID",
+			accessToken: signClientToken(clientTokenClaims{
+				RegisteredClaims: registeredClaims,
+				ClientID:         "orders",
+				Scope:            "users:read",
+				GrantType:        entities.ClientCredentialsGrantType,
+			}),
+			expectedErr: &security.InvalidJWTError{},
+		},
+		{
+			name: "expired token",
+			accessToken: signClientToken(clientTokenClaims{
+				RegisteredClaims: expiredClaims,
+				ClientID:         "notifications",
+				Scope:            "users:read",
+				GrantType:        entities.ClientCredentialsGrantType,
+			}),
+			expectedErr: &security.InvalidJWTError{},
+		},
+		{
+			name:        "invalid token",
+			accessToken: "invalid-token",
+			expectedErr: &security.InvalidJWTError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientTokenPayload, err := useCases.VerifyClientToken(tc.accessToken)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, clientTokenPayload)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedPayload.ID, clientTokenPayload.ID)
+			require.Equal(t, tc.expectedPayload.ClientID, clientTokenPayload.ClientID)
+			require.Equal(t, tc.expectedPayload.Scopes, clientTokenPayload.Scopes)
+			require.WithinDuration(t, tc.expectedPayload.ExpiresAt, clientTokenPayload.ExpiresAt, time.Second)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/DKhorkov/libs/validation"
+
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+)
+
+// defaultFederatedDisplayName is used for Users, whose name from identity provider is not valid display name.
+const defaultFederatedDisplayName = "Пользователь"
+
+// federatedLoginState is passed through identity provider back to SSO. It is encrypted, so PKCE code verifier
+// is not disclosed and state can not be forged for another provider.
+type federatedLoginState struct {
+	Provider     string    `json:"provider"`
+	CodeVerifier string    `json:"codeVerifier"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+}
+
+func (useCases *UseCases) createFederatedLoginState(provider, codeVerifier string) (string, error) {
+	encoded, err := json.Marshal(
+		federatedLoginState{
+			Provider:     provider,
+			CodeVerifier: codeVerifier,
+			ExpiresAt:    time.Now().UTC().Add(useCases.tokensConfig.FederatedLoginState.TTL),
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return encryptSecret(useCases.tokensConfig.SecretKey, string(encoded))
+}
+
+func (useCases *UseCases) parseFederatedLoginState(provider, state string) (*federatedLoginState, error) {
+	decrypted, err := decryptSecret(useCases.tokensConfig.SecretKey, state)
+	if err != nil {
+		return nil, &customerrors.InvalidFederatedLoginStateError{BaseErr: err}
+	}
+
+	var loginState federatedLoginState
+	if err = json.Unmarshal([]byte(decrypted), &loginState); err != nil {
+		return nil, &customerrors.InvalidFederatedLoginStateError{BaseErr: err}
+	}
+
+	if loginState.Provider != provider || time.Now().UTC().After(loginState.ExpiresAt) {
+		return nil, &customerrors.InvalidFederatedLoginStateError{}
+	}
+
+	return &loginState, nil
+}
+
+// getFederatedUser returns User, who is linked to provided external identity. Unknown identity is linked
+// to User with the same verified email, who is registered if needed.
+func (useCases *UseCases) getFederatedUser(
+	ctx context.Context,
+	identity *entities.ExternalIdentity,
+) (*entities.User, error) {
+	userIdentity, err := useCases.authService.GetUserIdentity(ctx, identity.Provider, identity.Subject)
+	if err == nil {
+		return useCases.GetUserByID(ctx, userIdentity.UserID)
+	}
+
+	var userIdentityNotFoundError *customerrors.UserIdentityNotFoundError
+	if !errors.As(err, &userIdentityNotFoundError) {
+		return nil, err
+	}
+
+	// Unverified email can belong to anyone, so linking by it would allow account takeover:
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, &customerrors.EmailIsNotConfirmedError{
+			Message: "email is not verified by identity provider",
+		}
+	}
+
+	user, err := useCases.GetUserByEmail(ctx, identity.Email)
+	if err != nil {
+		var userNotFoundError *customerrors.UserNotFoundError
+		if !errors.As(err, &userNotFoundError) {
+			return nil, err
+		}
+
+		if user, err = useCases.registerFederatedUser(ctx, identity); err != nil {
+			return nil, err
+		}
+	} else if !user.EmailConfirmed {
+		// Account could have been registered by someone else, who did not own this email:
+		return nil, &customerrors.EmailIsNotConfirmedError{}
+	}
+
+	if _, err = useCases.authService.CreateUserIdentity(
+		ctx,
+		entities.CreateUserIdentityDTO{
+			UserID:   user.ID,
+			Provider: identity.Provider,
+			Subject:  identity.Subject,
+			Email:    identity.Email,
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// registerFederatedUser creates User with email, which has already been verified by identity provider.
+// Password is random, so User can log in only via provider until password is reset.
+func (useCases *UseCases) registerFederatedUser(
+	ctx context.Context,
+	identity *entities.ExternalIdentity,
+) (*entities.User, error) {
+	displayName := strings.TrimSpace(identity.Name)
+	if !validation.ValidateValueByRules(displayName, useCases.validationConfig.DisplayNameRegExps) ||
+		validation.ContainsForbiddenWords(displayName) {
+		displayName = defaultFederatedDisplayName
+	}
+
+	password, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := useCases.passwordHasher.Hash(password)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := useCases.authService.RegisterUser(
+		ctx,
+		entities.RegisterUserDTO{
+			DisplayName: displayName,
+			Email:       identity.Email,
+			Password:    hashedPassword,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = useCases.authService.VerifyUserEmail(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return useCases.GetUserByID(ctx, userID)
+}
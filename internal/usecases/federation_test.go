@@ -0,0 +1,557 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	mocknats "github.com/DKhorkov/libs/nats/mocks"
+	"github.com/DKhorkov/libs/security"
+
+	"github.com/DKhorkov/hmtm-sso/internal/config"
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+	"github.com/DKhorkov/hmtm-sso/internal/interfaces"
+	mockcache "github.com/DKhorkov/hmtm-sso/mocks/cache"
+	mockfederation "github.com/DKhorkov/hmtm-sso/mocks/federation"
+	mockservices "github.com/DKhorkov/hmtm-sso/mocks/services"
+)
+
+const (
+	identityProviderName  = "google"
+	federatedLoginCode    = "authorization-code"
+	federatedCodeVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+)
+
+// newIdentityProvider creates mock of identity provider, which is registered in UseCases by its name.
+func newIdentityProvider(ctrl *gomock.Controller) *mockfederation.MockIdentityProvider {
+	identityProvider := mockfederation.NewMockIdentityProvider(ctrl)
+	identityProvider.EXPECT().Name().Return(identityProviderName).AnyTimes()
+
+	return identityProvider
+}
+
+// newFederatedLoginState creates encrypted state of login via provider, which expires at provided time.
+func newFederatedLoginState(t *testing.T, provider string, expiresAt time.Time) string {
+	t.Helper()
+
+	encoded, err := json.Marshal(
+		federatedLoginState{
+			Provider:     provider,
+			CodeVerifier: federatedCodeVerifier,
+			ExpiresAt:    expiresAt,
+		},
+	)
+	require.NoError(t, err)
+
+	state, err := encryptSecret(tokensConfig.SecretKey, string(encoded))
+	require.NoError(t, err)
+
+	return state
+}
+
+// federatedUserData matches registration data of User from identity provider with random hashed password.
+func federatedUserData(displayName, email string) gomock.Matcher {
+	return gomock.Cond(func(userData entities.RegisterUserDTO) bool {
+		return userData.DisplayName == displayName &&
+			userData.Email == email &&
+			userData.Password != ""
+	})
+}
+
+func TestUseCases_BeginFederatedLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	identityProvider := newIdentityProvider(ctrl)
+
+	useCases := New(
+		mockservices.NewMockAuthService(ctrl),
+		mockservices.NewMockUsersService(ctrl),
+		security.Config{},
+		nil, // JWT is not used
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		[]interfaces.IdentityProvider{identityProvider},
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		mocknats.NewMockPublisher(ctrl),
+		config.NATSConfig{},
+		mocklogging.NewMockLogger(ctrl),
+		mockcache.NewMockCacheProvider(ctrl),
+	)
+
+	t.Run("success", func(t *testing.T) {
+		var state, codeChallenge string
+		identityProvider.
+			EXPECT().
+			AuthCodeURL(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(s, c string) string {
+				state, codeChallenge = s, c
+				return "https://accounts.google.com/o/oauth2/v2/auth?" + url.Values{"state": {s}}.Encode()
+			}).
+			Times(1)
+
+		authorizationURL, err := useCases.BeginFederatedLogin(context.Background(), identityProviderName)
+		require.NoError(t, err)
+		require.Contains(t, authorizationURL, url.QueryEscape(state))
+
+		loginState, err := useCases.parseFederatedLoginState(identityProviderName, state)
+		require.NoError(t, err)
+		require.True(t, verifyCodeChallenge(codeChallenge, loginState.CodeVerifier))
+
+		// State is bound to provider, which login has been started with:
+		_, err = useCases.parseFederatedLoginState("yandex", state)
+		require.IsType(t, &customerrors.InvalidFederatedLoginStateError{}, err)
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		authorizationURL, err := useCases.BeginFederatedLogin(context.Background(), "unknown")
+		require.Error(t, err)
+		require.IsType(t, &customerrors.IdentityProviderNotFoundError{}, err)
+		require.Empty(t, authorizationURL)
+	})
+}
+
+func TestUseCases_FinishFederatedLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+	identityProvider := newIdentityProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		[]interfaces.IdentityProvider{identityProvider},
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		config.NATSConfig{},
+		logger,
+		cacheProvider,
+	)
+
+	state := newFederatedLoginState(t, identityProviderName, time.Now().UTC().Add(time.Minute))
+	loginData := entities.FinishFederatedLoginDTO{
+		Provider: identityProviderName,
+		Code:     federatedLoginCode,
+		State:    state,
+	}
+	identity := &entities.ExternalIdentity{
+		Provider:      identityProviderName,
+		Subject:       "108",
+		Email:         "test@example.com",
+		EmailVerified: true,
+		Name:          "Иван Петров",
+	}
+	user := &entities.User{ID: 1, Email: "test@example.com", EmailConfirmed: true}
+	userIdentityData := entities.CreateUserIdentityDTO{
+		UserID:   1,
+		Provider: identityProviderName,
+		Subject:  "108",
+		Email:    "test@example.com",
+	}
+
+	expectExchange := func(identityProvider *mockfederation.MockIdentityProvider, identity *entities.ExternalIdentity) {
+		identityProvider.
+			EXPECT().
+			Exchange(gomock.Any(), federatedLoginCode, federatedCodeVerifier).
+			Return(identity, nil).
+			Times(1)
+	}
+
+	expectLogin := func(authService *mockservices.MockAuthService) {
+		authService.
+			EXPECT().
+			GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+			Return(nil, &customerrors.MFANotEnabledError{}).
+			Times(1)
+
+		authService.
+			EXPECT().
+			CreateSession(gomock.Any(), gomock.Any()).
+			Return(uint64(2), nil).
+			Times(1)
+
+		authService.
+			EXPECT().
+			CreateRefreshToken(gomock.Any(), refreshTokenData(1, 2, "")).
+			Return(uint64(1), nil).
+			Times(1)
+	}
+
+	testCases := []struct {
+		name       string
+		loginData  entities.FinishFederatedLoginDTO
+		setupMocks func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+			identityProvider *mockfederation.MockIdentityProvider,
+		)
+		expectMFAChallenge bool
+		expectedErr        error
+	}{
+		{
+			name:      "success with linked identity",
+			loginData: loginData,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				identityProvider *mockfederation.MockIdentityProvider,
+			) {
+				expectExchange(identityProvider, identity)
+
+				authService.
+					EXPECT().
+					GetUserIdentity(gomock.Any(), identityProviderName, "108").
+					Return(&entities.UserIdentity{ID: 1, UserID: 1}, nil).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(user, nil).
+					Times(1)
+
+				expectLogin(authService)
+			},
+		},
+		{
+			name:      "success with linking of existing User",
+			loginData: loginData,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				identityProvider *mockfederation.MockIdentityProvider,
+			) {
+				expectExchange(identityProvider, identity)
+
+				authService.
+					EXPECT().
+					GetUserIdentity(gomock.Any(), identityProviderName, "108").
+					Return(nil, &customerrors.UserIdentityNotFoundError{}).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(user, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateUserIdentity(gomock.Any(), userIdentityData).
+					Return(uint64(1), nil).
+					Times(1)
+
+				expectLogin(authService)
+			},
+		},
+		{
+			name:      "success with registration of new User",
+			loginData: loginData,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				identityProvider *mockfederation.MockIdentityProvider,
+			) {
+				expectExchange(identityProvider, identity)
+
+				authService.
+					EXPECT().
+					GetUserIdentity(gomock.Any(), identityProviderName, "108").
+					Return(nil, &customerrors.UserIdentityNotFoundError{}).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(nil, &customerrors.UserNotFoundError{}).
+					Times(1)
+
+				authService.
+					EXPECT().
+					RegisterUser(gomock.Any(), federatedUserData("Иван Петров", "test@example.com")).
+					Return(uint64(1), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					VerifyUserEmail(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(user, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateUserIdentity(gomock.Any(), userIdentityData).
+					Return(uint64(1), nil).
+					Times(1)
+
+				expectLogin(authService)
+			},
+		},
+		{
+			name:      "success with registration of User with invalid name",
+			loginData: loginData,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				identityProvider *mockfederation.MockIdentityProvider,
+			) {
+				latinIdentity := *identity
+				latinIdentity.Name = "John Smith"
+				expectExchange(identityProvider, &latinIdentity)
+
+				authService.
+					EXPECT().
+					GetUserIdentity(gomock.Any(), identityProviderName, "108").
+					Return(nil, &customerrors.UserIdentityNotFoundError{}).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(nil, &customerrors.UserNotFoundError{}).
+					Times(1)
+
+				authService.
+					EXPECT().
+					RegisterUser(gomock.Any(), federatedUserData(defaultFederatedDisplayName, "test@example.com")).
+					Return(uint64(1), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					VerifyUserEmail(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(user, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateUserIdentity(gomock.Any(), userIdentityData).
+					Return(uint64(1), nil).
+					Times(1)
+
+				expectLogin(authService)
+			},
+		},
+		{
+			name:      "mfa enabled",
+			loginData: loginData,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				identityProvider *mockfederation.MockIdentityProvider,
+			) {
+				expectExchange(identityProvider, identity)
+
+				authService.
+					EXPECT().
+					GetUserIdentity(gomock.Any(), identityProviderName, "108").
+					Return(&entities.UserIdentity{ID: 1, UserID: 1}, nil).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(user, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(&entities.TOTPSecret{ID: 1, UserID: 1, Confirmed: true}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateMFAChallenge(gomock.Any(), gomock.Any()).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectMFAChallenge: true,
+		},
+		{
+			name: "unknown provider",
+			loginData: entities.FinishFederatedLoginDTO{
+				Provider: "unknown",
+				Code:     federatedLoginCode,
+				State:    state,
+			},
+			expectedErr: &customerrors.IdentityProviderNotFoundError{},
+		},
+		{
+			name: "invalid state",
+			loginData: entities.FinishFederatedLoginDTO{
+				Provider: identityProviderName,
+				Code:     federatedLoginCode,
+				State:    "invalid",
+			},
+			expectedErr: &customerrors.InvalidFederatedLoginStateError{},
+		},
+		{
+			name: "state of another provider",
+			loginData: entities.FinishFederatedLoginDTO{
+				Provider: identityProviderName,
+				Code:     federatedLoginCode,
+				State:    newFederatedLoginState(t, "yandex", time.Now().UTC().Add(time.Minute)),
+			},
+			expectedErr: &customerrors.InvalidFederatedLoginStateError{},
+		},
+		{
+			name: "expired state",
+			loginData: entities.FinishFederatedLoginDTO{
+				Provider: identityProviderName,
+				Code:     federatedLoginCode,
+				State:    newFederatedLoginState(t, identityProviderName, time.Now().UTC().Add(-time.Minute)),
+			},
+			expectedErr: &customerrors.InvalidFederatedLoginStateError{},
+		},
+		{
+			name:      "exchange error",
+			loginData: loginData,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				identityProvider *mockfederation.MockIdentityProvider,
+			) {
+				identityProvider.
+					EXPECT().
+					Exchange(gomock.Any(), federatedLoginCode, federatedCodeVerifier).
+					Return(nil, errors.New("invalid_grant")).
+					Times(1)
+			},
+			expectedErr: &customerrors.FederatedLoginError{},
+		},
+		{
+			name:      "email is not verified by provider",
+			loginData: loginData,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				identityProvider *mockfederation.MockIdentityProvider,
+			) {
+				unverifiedIdentity := *identity
+				unverifiedIdentity.EmailVerified = false
+				expectExchange(identityProvider, &unverifiedIdentity)
+
+				authService.
+					EXPECT().
+					GetUserIdentity(gomock.Any(), identityProviderName, "108").
+					Return(nil, &customerrors.UserIdentityNotFoundError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.EmailIsNotConfirmedError{},
+		},
+		{
+			name:      "existing User with not confirmed email",
+			loginData: loginData,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				identityProvider *mockfederation.MockIdentityProvider,
+			) {
+				expectExchange(identityProvider, identity)
+
+				authService.
+					EXPECT().
+					GetUserIdentity(gomock.Any(), identityProviderName, "108").
+					Return(nil, &customerrors.UserIdentityNotFoundError{}).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{ID: 1, Email: "test@example.com"}, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.EmailIsNotConfirmedError{},
+		},
+		{
+			name:      "get user identity error",
+			loginData: loginData,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				identityProvider *mockfederation.MockIdentityProvider,
+			) {
+				expectExchange(identityProvider, identity)
+
+				authService.
+					EXPECT().
+					GetUserIdentity(gomock.Any(), identityProviderName, "108").
+					Return(nil, errors.New("test error")).
+					Times(1)
+			},
+			expectedErr: errors.New("test error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authService, usersService, identityProvider)
+			}
+
+			tokens, err := useCases.FinishFederatedLogin(context.Background(), tc.loginData)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, tokens)
+
+				return
+			}
+
+			require.NoError(t, err)
+			if tc.expectMFAChallenge {
+				require.NotNil(t, tokens.MFAChallenge)
+				require.Empty(t, tokens.AccessToken)
+			} else {
+				require.NotEmpty(t, tokens.AccessToken)
+				require.NotEmpty(t, tokens.RefreshToken)
+				require.Nil(t, tokens.MFAChallenge)
+			}
+		})
+	}
+}
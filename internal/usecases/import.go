@@ -0,0 +1,79 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/DKhorkov/libs/validation"
+
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+)
+
+// ImportUsers imports Users from other platform. Each line of JSONL data is ImportUserDTO with already hashed
+// password. Lines are imported independently, so invalid line does not prevent import of other ones and is reported
+// in result instead. Imported Users log in with their old passwords, which are rehashed on first login.
+func (useCases *UseCases) ImportUsers(
+	ctx context.Context,
+	accessToken string,
+	data []byte,
+) (*entities.ImportUsersResult, error) {
+	if err := useCases.verifyAdministrator(ctx, accessToken); err != nil {
+		return nil, err
+	}
+
+	result := &entities.ImportUsersResult{Errors: []entities.ImportUserError{}}
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		if err := useCases.importUser(ctx, line); err != nil {
+			result.Errors = append(
+				result.Errors,
+				entities.ImportUserError{
+					Line:    uint64(i + 1), //nolint:gosec // index is never negative
+					Message: err.Error(),
+				},
+			)
+
+			continue
+		}
+
+		result.Imported++
+	}
+
+	return result, nil
+}
+
+func (useCases *UseCases) importUser(ctx context.Context, line []byte) error {
+	var userData entities.ImportUserDTO
+	if err := json.Unmarshal(line, &userData); err != nil {
+		return &validation.Error{Message: "invalid JSON", BaseErr: err}
+	}
+
+	// Other platform could store emails in any case, while emails are registered in lower case:
+	userData.Email = strings.ToLower(strings.TrimSpace(userData.Email))
+	if !validation.ValidateValueByRule(userData.Email, useCases.validationConfig.EmailRegExp) {
+		return &validation.Error{Message: "invalid email address"}
+	}
+
+	if !validation.ValidateValueByRules(
+		userData.DisplayName,
+		useCases.validationConfig.DisplayNameRegExps,
+	) || validation.ContainsForbiddenWords(
+		userData.DisplayName,
+	) {
+		return &validation.Error{Message: "invalid display name"}
+	}
+
+	if !useCases.passwordHasher.Supports(userData.PasswordHash) {
+		return &validation.Error{Message: "unsupported password hash format"}
+	}
+
+	// Confirmation state is preserved, so Users, who have confirmed email on other platform, are not asked again.
+	// It is stored together with User, so failed line can be imported again:
+	return useCases.authService.ImportUser(ctx, userData)
+}
@@ -0,0 +1,205 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/DKhorkov/libs/security"
+
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+	mockcache "github.com/DKhorkov/hmtm-sso/mocks/cache"
+	mockservices "github.com/DKhorkov/hmtm-sso/mocks/services"
+)
+
+func TestUseCases_ImportUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+
+	useCases := newClientsUseCases(t, authService, usersService, cacheProvider, securityConfig)
+
+	// Salted SHA-1 and PBKDF2-SHA256 hashes of "password123" with "salt" salt:
+	const (
+		saltedSHA1Hash   = "$sha1$c2FsdA$ynTsj4lLd3s2pvX/v1T39sGuNS8"
+		pbkdf2SHA256Hash = "$pbkdf2-sha256$i=1000$c2FsdA$Sb2Qz3yU7dS3/pId7dysiT++t0Drq1dqlDFPs2N9e0A"
+	)
+
+	testCases := []struct {
+		name           string
+		accessToken    string
+		data           string
+		setupMocks     func(authService *mockservices.MockAuthService, cacheProvider *mockcache.MockCacheProvider)
+		expectedResult *entities.ImportUsersResult
+		expectedErr    error
+	}{
+		{
+			name:        "success",
+			accessToken: newAdminAccessToken(t, securityConfig.JWT, 1, 2),
+			data: strings.Join(
+				[]string{
+					`{"email":"Seller@Example.com","displayName":"Продавец","passwordHash":"` + saltedSHA1Hash +
+						`","emailConfirmed":true}`,
+					"",
+					`{"email":"buyer@example.com","displayName":"Покупатель","passwordHash":"` + pbkdf2SHA256Hash + `"}`,
+				},
+				"\n",
+			),
+			setupMocks: func(authService *mockservices.MockAuthService, cacheProvider *mockcache.MockCacheProvider) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					ImportUser(
+						gomock.Any(),
+						entities.ImportUserDTO{
+							DisplayName:    "Продавец",
+							Email:          "seller@example.com",
+							PasswordHash:   saltedSHA1Hash,
+							EmailConfirmed: true,
+						},
+					).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ImportUser(
+						gomock.Any(),
+						entities.ImportUserDTO{
+							DisplayName:  "Покупатель",
+							Email:        "buyer@example.com",
+							PasswordHash: pbkdf2SHA256Hash,
+						},
+					).
+					Return(nil).
+					Times(1)
+			},
+			expectedResult: &entities.ImportUsersResult{
+				Imported: 2,
+				Errors:   []entities.ImportUserError{},
+			},
+		},
+		{
+			name:        "invalid lines",
+			accessToken: newAdminAccessToken(t, securityConfig.JWT, 1, 2),
+			data: strings.Join(
+				[]string{
+					`{"email":`,
+					`{"email":"invalid","displayName":"Продавец","passwordHash":"` + saltedSHA1Hash + `"}`,
+					`{"email":"seller@example.com","displayName":"Продавец","passwordHash":"md5$hash"}`,
+					`{"email":"buyer@example.com","displayName":"Покупатель","passwordHash":"` + saltedSHA1Hash + `"}`,
+					// Too many iterations would make each login attempt of imported User arbitrarily expensive:
+					`{"email":"admin@example.com","displayName":"Админ","passwordHash":"$pbkdf2-sha256$i=2147483647$c2FsdA$a2V5"}`,
+				},
+				"\n",
+			),
+			setupMocks: func(authService *mockservices.MockAuthService, cacheProvider *mockcache.MockCacheProvider) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					ImportUser(gomock.Any(), gomock.Any()).
+					Return(&customerrors.UserAlreadyExistsError{}).
+					Times(1)
+			},
+			expectedResult: &entities.ImportUsersResult{
+				Imported: 0,
+				Errors: []entities.ImportUserError{
+					{Line: 1, Message: "invalid JSON"},
+					{Line: 2, Message: "invalid email address"},
+					{Line: 3, Message: "unsupported password hash format"},
+					{Line: 4, Message: (&customerrors.UserAlreadyExistsError{}).Error()},
+					{Line: 5, Message: "unsupported password hash format"},
+				},
+			},
+		},
+		{
+			name:        "import error",
+			accessToken: newAdminAccessToken(t, securityConfig.JWT, 1, 2),
+			data: strings.Join(
+				[]string{
+					`{"email":"seller@example.com","displayName":"Продавец","passwordHash":"` + saltedSHA1Hash +
+						`","emailConfirmed":true}`,
+					`{"email":"buyer@example.com","displayName":"Покупатель","passwordHash":"` + pbkdf2SHA256Hash + `"}`,
+				},
+				"\n",
+			),
+			setupMocks: func(authService *mockservices.MockAuthService, cacheProvider *mockcache.MockCacheProvider) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				// User is stored with confirmed email by single write, so failed line is not imported at all
+				// and can be imported again:
+				authService.
+					EXPECT().
+					ImportUser(gomock.Any(), gomock.Cond(func(userData entities.ImportUserDTO) bool {
+						return userData.Email == "seller@example.com" && userData.EmailConfirmed
+					})).
+					Return(errors.New("connection refused")).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ImportUser(gomock.Any(), gomock.Cond(func(userData entities.ImportUserDTO) bool {
+						return userData.Email == "buyer@example.com"
+					})).
+					Return(nil).
+					Times(1)
+			},
+			expectedResult: &entities.ImportUsersResult{
+				Imported: 1,
+				Errors: []entities.ImportUserError{
+					{Line: 1, Message: "connection refused"},
+				},
+			},
+		},
+		{
+			name:        "not administrator",
+			accessToken: newAccessToken(t, securityConfig.JWT, 1, 2),
+			setupMocks: func(_ *mockservices.MockAuthService, cacheProvider *mockcache.MockCacheProvider) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+			},
+			expectedErr: &customerrors.PermissionDeniedError{Message: "administrator role is required"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authService, cacheProvider)
+			}
+
+			result, err := useCases.ImportUsers(context.Background(), tc.accessToken, []byte(tc.data))
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, result)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+)
+
+const (
+	loginFailuresCachePrefix   = "login-failures"
+	loginLockCachePrefix       = "login-lock"
+	ipLoginFailuresCachePrefix = "ip-login-failures"
+	ipLoginLockCachePrefix     = "ip-login-lock"
+)
+
+func loginFailuresCacheKey(userID uint64) string {
+	return fmt.Sprintf("%s-%d", loginFailuresCachePrefix, userID)
+}
+
+func loginLockCacheKey(userID uint64) string {
+	return fmt.Sprintf("%s-%d", loginLockCachePrefix, userID)
+}
+
+func ipLoginFailuresCacheKey(ip string) string {
+	return fmt.Sprintf("%s-%s", ipLoginFailuresCachePrefix, ip)
+}
+
+func ipLoginLockCacheKey(ip string) string {
+	return fmt.Sprintf("%s-%s", ipLoginLockCachePrefix, ip)
+}
+
+// checkLoginLock returns AccountLockedError, if login is locked under provided cache key.
+// If cache is unavailable, login is not locked, so Users are not locked out due to cache failures.
+func (useCases *UseCases) checkLoginLock(ctx context.Context, cacheKey string) error {
+	strLockedUntil, err := useCases.cacheProvider.Get(ctx, cacheKey)
+	if err != nil || strLockedUntil == "" {
+		return nil
+	}
+
+	lockedUntil, err := strconv.ParseInt(strLockedUntil, 10, 64)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			useCases.logger,
+			fmt.Sprintf("Invalid value=%s for %s cache key", strLockedUntil, cacheKey),
+			err,
+		)
+
+		return nil
+	}
+
+	retryAfter := time.Until(time.UnixMilli(lockedUntil))
+	if retryAfter <= 0 {
+		return nil
+	}
+
+	// Retry-after is rounded up, so client does not retry before lock ends:
+	return &customerrors.AccountLockedError{RetryAfter: (retryAfter + time.Second - 1).Truncate(time.Second)}
+}
+
+// checkIPLoginLock returns AccountLockedError, if login from client IP is locked.
+func (useCases *UseCases) checkIPLoginLock(ctx context.Context, ip string) error {
+	if ip == "" {
+		return nil
+	}
+
+	return useCases.checkLoginLock(ctx, ipLoginLockCacheKey(ip))
+}
+
+// lockLogin stores time, until which login is locked, under provided cache key.
+func (useCases *UseCases) lockLogin(ctx context.Context, cacheKey string, duration time.Duration) {
+	if err := useCases.cacheProvider.Set(
+		ctx,
+		cacheKey,
+		time.Now().Add(duration).UnixMilli(),
+		duration,
+	); err != nil {
+		logging.LogErrorContext(
+			ctx,
+			useCases.logger,
+			fmt.Sprintf("Failed to lock login for %s cache key", cacheKey),
+			err,
+		)
+	}
+}
+
+// countLoginFailure counts failed login attempt to account and delays next attempt. When threshold is reached,
+// account is locked and AccountLockedError is returned instead of WrongPasswordError.
+func (useCases *UseCases) countLoginFailure(ctx context.Context, userID uint64) error {
+	failures := useCases.countFailure(ctx, loginFailuresCacheKey(userID))
+	if failures == 0 {
+		return &customerrors.WrongPasswordError{}
+	}
+
+	if useCases.lockoutConfig.Threshold > 0 && failures >= useCases.lockoutConfig.Threshold {
+		useCases.lockLogin(ctx, loginLockCacheKey(userID), useCases.lockoutConfig.Duration)
+
+		return &customerrors.AccountLockedError{RetryAfter: useCases.lockoutConfig.Duration}
+	}
+
+	if delay := useCases.loginDelay(failures); delay > 0 {
+		useCases.lockLogin(ctx, loginLockCacheKey(userID), delay)
+	}
+
+	return &customerrors.WrongPasswordError{}
+}
+
+// countIPLoginFailure counts failed login attempt from client IP and locks login from it, when threshold is reached.
+// It protects from attacker, who tries the same password for many accounts.
+func (useCases *UseCases) countIPLoginFailure(ctx context.Context, ip string) {
+	if ip == "" || useCases.lockoutConfig.IPThreshold <= 0 {
+		return
+	}
+
+	failures := useCases.countFailure(ctx, ipLoginFailuresCacheKey(ip))
+	if failures > 0 && failures >= useCases.lockoutConfig.IPThreshold {
+		useCases.lockLogin(ctx, ipLoginLockCacheKey(ip), useCases.lockoutConfig.Duration)
+	}
+}
+
+// countFailure atomically counts failed login attempt under provided cache key and returns number of failures during
+// window. If cache is unavailable, zero is returned, so Users are not locked out due to cache failures.
+func (useCases *UseCases) countFailure(ctx context.Context, cacheKey string) int64 {
+	failures, err := useCases.cacheProvider.IncrWithTTL(ctx, cacheKey, useCases.lockoutConfig.Window)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			useCases.logger,
+			fmt.Sprintf("Failed to count login failure for %s cache key", cacheKey),
+			err,
+		)
+
+		return 0
+	}
+
+	return failures
+}
+
+// loginDelay doubles base delay for each failed attempt after first one.
+func (useCases *UseCases) loginDelay(failures int64) time.Duration {
+	delay := useCases.lockoutConfig.BaseDelay
+	for i := int64(1); i < failures && delay < useCases.lockoutConfig.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, useCases.lockoutConfig.MaxDelay)
+}
+
+// resetLoginFailures forgets failed login attempts to account. Cache provider can not delete keys,
+// so counter is overwritten with zero.
+func (useCases *UseCases) resetLoginFailures(ctx context.Context, userID uint64) {
+	if err := useCases.cacheProvider.Set(
+		ctx,
+		loginFailuresCacheKey(userID),
+		0,
+		useCases.lockoutConfig.Window,
+	); err != nil {
+		logging.LogErrorContext(
+			ctx,
+			useCases.logger,
+			fmt.Sprintf("Failed to reset login failures for User with ID=%d", userID),
+			err,
+		)
+	}
+}
+
+// unlockLogin removes lockout of account together with its failed login attempts.
+// Lock, which is overwritten with zero time, has already ended.
+func (useCases *UseCases) unlockLogin(ctx context.Context, userID uint64) {
+	useCases.resetLoginFailures(ctx, userID)
+
+	if err := useCases.cacheProvider.Set(
+		ctx,
+		loginLockCacheKey(userID),
+		0,
+		useCases.lockoutConfig.Duration,
+	); err != nil {
+		logging.LogErrorContext(
+			ctx,
+			useCases.logger,
+			fmt.Sprintf("Failed to unlock login for User with ID=%d", userID),
+			err,
+		)
+	}
+}
@@ -0,0 +1,219 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/libs/security"
+
+	"github.com/DKhorkov/hmtm-sso/internal/config"
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+	"github.com/DKhorkov/hmtm-sso/internal/memorycache"
+	mockcache "github.com/DKhorkov/hmtm-sso/mocks/cache"
+	mockservices "github.com/DKhorkov/hmtm-sso/mocks/services"
+)
+
+func TestUseCases_UnlockUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+
+	useCases := newClientsUseCases(t, authService, usersService, cacheProvider, securityConfig)
+
+	testCases := []struct {
+		name        string
+		accessToken string
+		setupMocks  func(
+			usersService *mockservices.MockUsersService,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedErr error
+	}{
+		{
+			name:        "success",
+			accessToken: newAdminAccessToken(t, securityConfig.JWT, 1, 2),
+			setupMocks: func(
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(3)).
+					Return(&entities.User{ID: 3}, nil).
+					Times(1)
+
+				expectLoginIsUnlocked(cacheProvider, 3)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "not administrator",
+			accessToken: newAccessToken(t, securityConfig.JWT, 1, 2),
+			setupMocks: func(
+				_ *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+			},
+			expectedErr: &customerrors.PermissionDeniedError{Message: "administrator role is required"},
+		},
+		{
+			name:        "user not found",
+			accessToken: newAdminAccessToken(t, securityConfig.JWT, 1, 2),
+			setupMocks: func(
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(3)).
+					Return(nil, &customerrors.UserNotFoundError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.UserNotFoundError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(usersService, cacheProvider)
+			}
+
+			err := useCases.UnlockUser(context.Background(), tc.accessToken, 3)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUseCases_loginDelay(t *testing.T) {
+	useCases := &UseCases{
+		lockoutConfig: config.LockoutConfig{
+			BaseDelay: time.Second,
+			MaxDelay:  time.Second * 10,
+		},
+	}
+
+	testCases := []struct {
+		failures      int64
+		expectedDelay time.Duration
+	}{
+		{failures: 1, expectedDelay: time.Second},
+		{failures: 2, expectedDelay: time.Second * 2},
+		{failures: 4, expectedDelay: time.Second * 8},
+		{failures: 5, expectedDelay: time.Second * 10},
+		{failures: 100, expectedDelay: time.Second * 10},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.expectedDelay, useCases.loginDelay(tc.failures))
+	}
+}
+
+func TestUseCases_countLoginFailure(t *testing.T) {
+	lockoutConfig := config.LockoutConfig{
+		Threshold: 50,
+		Window:    time.Minute,
+		Duration:  time.Minute,
+		BaseDelay: time.Second,
+		MaxDelay:  time.Second * 10,
+	}
+
+	t.Run("concurrent failures", func(t *testing.T) {
+		cacheProvider := memorycache.New()
+		t.Cleanup(func() {
+			require.NoError(t, cacheProvider.Close())
+		})
+
+		useCases := &UseCases{
+			lockoutConfig: lockoutConfig,
+			cacheProvider: cacheProvider,
+		}
+
+		var (
+			wg     sync.WaitGroup
+			locked atomic.Int64
+		)
+
+		for range 100 {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				var accountLockedError *customerrors.AccountLockedError
+				if errors.As(useCases.countLoginFailure(context.Background(), 1), &accountLockedError) {
+					locked.Add(1)
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		// Concurrent failures are not lost, so account is locked by each failure since threshold:
+		failures, err := cacheProvider.Get(context.Background(), loginFailuresCacheKey(1))
+		require.NoError(t, err)
+		require.Equal(t, "100", failures)
+		require.Equal(t, int64(100-lockoutConfig.Threshold+1), locked.Load())
+	})
+
+	t.Run("cache is unavailable", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+		logger := mocklogging.NewMockLogger(ctrl)
+
+		useCases := &UseCases{
+			lockoutConfig: lockoutConfig,
+			cacheProvider: cacheProvider,
+			logger:        logger,
+		}
+
+		cacheProvider.
+			EXPECT().
+			IncrWithTTL(gomock.Any(), loginFailuresCacheKey(1), lockoutConfig.Window).
+			Return(int64(0), errors.New("cache is unavailable")).
+			Times(1)
+
+		logger.
+			EXPECT().
+			ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+			Times(1)
+
+		// Users are not locked out due to cache failures:
+		err := useCases.countLoginFailure(context.Background(), 1)
+		require.IsType(t, &customerrors.WrongPasswordError{}, err)
+	})
+}
@@ -0,0 +1,542 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	mocknats "github.com/DKhorkov/libs/nats/mocks"
+	"github.com/DKhorkov/libs/security"
+
+	"github.com/DKhorkov/hmtm-sso/internal/config"
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+	mockcache "github.com/DKhorkov/hmtm-sso/mocks/cache"
+	mockservices "github.com/DKhorkov/hmtm-sso/mocks/services"
+)
+
+// loginLinkContent matches NATS message with login link credentials for User with provided ID.
+func loginLinkContent(userID uint64) gomock.Matcher {
+	return gomock.Cond(func(content []byte) bool {
+		var loginLinkDTO entities.LoginLinkDTO
+		if err := json.Unmarshal(content, &loginLinkDTO); err != nil {
+			return false
+		}
+
+		return loginLinkDTO.UserID == userID &&
+			loginLinkDTO.Token != "" &&
+			len(loginLinkDTO.Code) == codeLength
+	})
+}
+
+// loginTokenData matches login token, which expires according to config.
+func loginTokenData(userID uint64) gomock.Matcher {
+	return gomock.Cond(func(loginTokenData entities.CreateLoginTokenDTO) bool {
+		return loginTokenData.UserID == userID &&
+			loginTokenData.TokenHash != "" &&
+			loginTokenData.CodeHash != "" &&
+			loginTokenData.TTL == tokensConfig.Login.TTL
+	})
+}
+
+func TestUseCases_SendLoginLink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{}
+	natsConfig := config.NATSConfig{
+		Subjects: config.NATSSubjects{
+			LoginLink: "login-link",
+		},
+	}
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		nil, // JWT is not used
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		natsConfig,
+		logger,
+		cacheProvider,
+	)
+
+	testCases := []struct {
+		name       string
+		email      string
+		setupMocks func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+			natsPublisher *mocknats.MockPublisher,
+			logger *mocklogging.MockLogger,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedErr error
+	}{
+		{
+			name:  "success",
+			email: "test@example.com",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{ID: 1, EmailConfirmed: true}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateLoginToken(gomock.Any(), loginTokenData(1)).
+					Return(uint64(1), nil).
+					Times(1)
+
+				natsPublisher.
+					EXPECT().
+					Publish("login-link", loginLinkContent(1)).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "user not found",
+			email: "test@example.com",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(nil, &customerrors.UserNotFoundError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.UserNotFoundError{},
+		},
+		{
+			name:  "email is not confirmed",
+			email: "test@example.com",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{ID: 1, EmailConfirmed: false}, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.EmailIsNotConfirmedError{},
+		},
+		{
+			name:  "publish error",
+			email: "test@example.com",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{ID: 1, EmailConfirmed: true}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateLoginToken(gomock.Any(), loginTokenData(1)).
+					Return(uint64(1), nil).
+					Times(1)
+
+				natsPublisher.
+					EXPECT().
+					Publish("login-link", loginLinkContent(1)).
+					Return(errors.New("publish failed")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: errors.New("publish failed"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(
+					authService,
+					usersService,
+					natsPublisher,
+					logger,
+					cacheProvider,
+				)
+			}
+
+			err := useCases.SendLoginLink(context.Background(), tc.email)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUseCases_LoginWithCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+	natsConfig := config.NATSConfig{}
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		natsConfig,
+		logger,
+		cacheProvider,
+	)
+
+	user := &entities.User{ID: 1, Email: "test@example.com", EmailConfirmed: true}
+	loginToken := &entities.LoginToken{
+		ID:        2,
+		UserID:    1,
+		TokenHash: hashToken(tokensConfig.SecretKey, "login-token"),
+		CodeHash:  hashCode(tokensConfig.SecretKey, 1, "123456"),
+	}
+
+	testCases := []struct {
+		name       string
+		loginData  entities.LoginWithCodeDTO
+		setupMocks func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectMFAChallenge bool
+		expectedErr        error
+	}{
+		{
+			name:      "success with token",
+			loginData: entities.LoginWithCodeDTO{Token: "login-token"},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetLoginTokenByHash(gomock.Any(), loginToken.TokenHash).
+					Return(loginToken, nil).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(user, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					UseLoginToken(gomock.Any(), uint64(1), uint64(2)).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(nil, &customerrors.MFANotEnabledError{}).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Return(uint64(3), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateRefreshToken(gomock.Any(), refreshTokenData(1, 3, "")).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:      "success with code and enabled MFA",
+			loginData: entities.LoginWithCodeDTO{Email: "test@example.com", Code: "123456"},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(user, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetLoginTokenByUserID(gomock.Any(), uint64(1)).
+					Return(loginToken, nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), loginCodeCacheKey(2), tokensConfig.Login.TTL).
+					Return(int64(1), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					UseLoginToken(gomock.Any(), uint64(1), uint64(2)).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(&entities.TOTPSecret{ID: 1, UserID: 1, Confirmed: true}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateMFAChallenge(gomock.Any(), gomock.Any()).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectMFAChallenge: true,
+			expectedErr:        nil,
+		},
+		{
+			name:      "invalid token",
+			loginData: entities.LoginWithCodeDTO{Token: "login-token"},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetLoginTokenByHash(gomock.Any(), loginToken.TokenHash).
+					Return(nil, errors.New("not found")).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidLoginTokenError{},
+		},
+		{
+			name:      "no active code",
+			loginData: entities.LoginWithCodeDTO{Email: "test@example.com", Code: "123456"},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(user, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetLoginTokenByUserID(gomock.Any(), uint64(1)).
+					Return(nil, errors.New("not found")).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidLoginTokenError{},
+		},
+		{
+			name:      "wrong code",
+			loginData: entities.LoginWithCodeDTO{Email: "test@example.com", Code: "654321"},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(user, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetLoginTokenByUserID(gomock.Any(), uint64(1)).
+					Return(loginToken, nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), loginCodeCacheKey(2), tokensConfig.Login.TTL).
+					Return(int64(2), nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidLoginTokenError{},
+		},
+		{
+			name:      "too many attempts",
+			loginData: entities.LoginWithCodeDTO{Email: "test@example.com", Code: "123456"},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(user, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetLoginTokenByUserID(gomock.Any(), uint64(1)).
+					Return(loginToken, nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), loginCodeCacheKey(2), tokensConfig.Login.TTL).
+					Return(int64(loginCodeAttemptsLimit+1), nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidLoginTokenError{},
+		},
+		{
+			name:      "email is not confirmed",
+			loginData: entities.LoginWithCodeDTO{Token: "login-token"},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetLoginTokenByHash(gomock.Any(), loginToken.TokenHash).
+					Return(loginToken, nil).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(&entities.User{ID: 1, EmailConfirmed: false}, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.EmailIsNotConfirmedError{},
+		},
+		{
+			name:      "token is already used",
+			loginData: entities.LoginWithCodeDTO{Token: "login-token"},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetLoginTokenByHash(gomock.Any(), loginToken.TokenHash).
+					Return(loginToken, nil).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(user, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					UseLoginToken(gomock.Any(), uint64(1), uint64(2)).
+					Return(&customerrors.InvalidLoginTokenError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidLoginTokenError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authService, usersService, cacheProvider)
+			}
+
+			tokens, err := useCases.LoginWithCode(context.Background(), tc.loginData)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, tokens)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, tokens)
+
+				if tc.expectMFAChallenge {
+					require.NotNil(t, tokens.MFAChallenge)
+					require.Empty(t, tokens.AccessToken)
+				} else {
+					require.Nil(t, tokens.MFAChallenge)
+					require.NotEmpty(t, tokens.AccessToken)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+	"github.com/DKhorkov/hmtm-sso/internal/totp"
+)
+
+const (
+	recoveryCodesCount        = 10
+	mfaChallengeCachePrefix   = "mfa-challenge"
+	mfaChallengeAttemptsLimit = 5
+)
+
+func mfaChallengeCacheKey(challengeID uint64) string {
+	return fmt.Sprintf("%s-%d", mfaChallengeCachePrefix, challengeID)
+}
+
+// hashRecoveryCode binds recovery code to User and ignores its formatting.
+func hashRecoveryCode(secretKey string, userID uint64, code string) string {
+	return hashCode(secretKey, userID, normalizeRecoveryCode(code))
+}
+
+// loginUser creates new Session for User and issues tokens for it. Blocked User can not be logged in.
+func (useCases *UseCases) loginUser(
+	ctx context.Context,
+	user *entities.User,
+	client *entities.Client,
+	clientInfo entities.ClientInfo,
+) (*entities.TokensDTO, error) {
+	if user.Blocked {
+		return nil, &customerrors.UserBlockedError{}
+	}
+
+	// Each login creates new Session for User to be logged in on several devices simultaneously:
+	sessionID, err := useCases.authService.CreateSession(
+		ctx,
+		entities.CreateSessionDTO{
+			UserID:     user.ID,
+			ClientInfo: clientInfo,
+			TTL:        useCases.refreshTokenTTL(client),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each Session has its own rotation chain of refresh tokens:
+	familyID, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	return useCases.createTokens(ctx, user, client, sessionID, familyID)
+}
+
+// isMFAEnabled checks, if User has confirmed TOTP secret. Not confirmed secret means,
+// that User has started, but not finished enrollment.
+func (useCases *UseCases) isMFAEnabled(ctx context.Context, userID uint64) (bool, error) {
+	totpSecret, err := useCases.authService.GetTOTPSecretByUserID(ctx, userID)
+	if err != nil {
+		var mfaNotEnabledError *customerrors.MFANotEnabledError
+		if errors.As(err, &mfaNotEnabledError) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return totpSecret.Confirmed, nil
+}
+
+// createMFAChallenge is issued instead of tokens, so Blocked User is rejected before second factor is requested.
+func (useCases *UseCases) createMFAChallenge(ctx context.Context, user *entities.User) (*entities.TokensDTO, error) {
+	if user.Blocked {
+		return nil, &customerrors.UserBlockedError{}
+	}
+
+	challengeToken, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = useCases.authService.CreateMFAChallenge(
+		ctx,
+		entities.CreateMFAChallengeDTO{
+			UserID:    user.ID,
+			TokenHash: hashToken(useCases.tokensConfig.SecretKey, challengeToken),
+			TTL:       useCases.tokensConfig.MFAChallenge.TTL,
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return &entities.TokensDTO{
+		MFAChallenge: &entities.MFAChallengeDTO{
+			Token:     challengeToken,
+			ExpiresIn: useCases.tokensConfig.MFAChallenge.TTL,
+		},
+	}, nil
+}
+
+// verifyMFACode accepts TOTP code or unused recovery code of User. Both can be used only once.
+func (useCases *UseCases) verifyMFACode(ctx context.Context, totpSecret *entities.TOTPSecret, code string) error {
+	// Recovery codes are longer, than TOTP codes:
+	if len(code) != totp.Digits {
+		return useCases.authService.UseRecoveryCode(
+			ctx,
+			totpSecret.UserID,
+			hashRecoveryCode(useCases.tokensConfig.SecretKey, totpSecret.UserID, code),
+		)
+	}
+
+	step, err := useCases.validateTOTPCode(totpSecret, code)
+	if err != nil {
+		return err
+	}
+
+	return useCases.authService.UseTOTPStep(ctx, totpSecret.ID, step)
+}
+
+// validateTOTPCode returns step of valid TOTP code. Step is used to reject reuse of code.
+func (useCases *UseCases) validateTOTPCode(totpSecret *entities.TOTPSecret, code string) (int64, error) {
+	secret, err := decryptSecret(useCases.tokensConfig.SecretKey, totpSecret.Secret)
+	if err != nil {
+		return 0, err
+	}
+
+	step, ok := totp.Validate(secret, code, time.Now())
+	if !ok || step <= totpSecret.LastUsedStep {
+		return 0, &customerrors.InvalidMFACodeError{}
+	}
+
+	return step, nil
+}
+
+// countAttempt atomically counts attempt under provided cache key and returns number of attempts, including current
+// one. Attempts are counted before code is checked, so concurrent guesses can not exceed limit.
+func (useCases *UseCases) countAttempt(ctx context.Context, cacheKey string, ttl time.Duration) (int64, error) {
+	return useCases.cacheProvider.IncrWithTTL(ctx, cacheKey, ttl)
+}
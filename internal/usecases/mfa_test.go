@@ -0,0 +1,1117 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	mocknats "github.com/DKhorkov/libs/nats/mocks"
+	"github.com/DKhorkov/libs/security"
+
+	"github.com/DKhorkov/hmtm-sso/internal/config"
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+	"github.com/DKhorkov/hmtm-sso/internal/totp"
+	mockcache "github.com/DKhorkov/hmtm-sso/mocks/cache"
+	mockservices "github.com/DKhorkov/hmtm-sso/mocks/services"
+)
+
+// newTOTPSecret creates TOTP secret of User with ID=1 in the same form, as it is stored in database,
+// and returns it with valid code for current time.
+func newTOTPSecret(t *testing.T, confirmed bool) (*entities.TOTPSecret, string) {
+	t.Helper()
+
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	encryptedSecret, err := encryptSecret(tokensConfig.SecretKey, secret)
+	require.NoError(t, err)
+
+	code, err := totp.GenerateCode(secret, totp.Step(time.Now()))
+	require.NoError(t, err)
+
+	return &entities.TOTPSecret{
+		ID:        1,
+		UserID:    1,
+		Secret:    encryptedSecret,
+		Confirmed: confirmed,
+	}, code
+}
+
+func TestUseCases_CompleteMFALogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+	natsConfig := config.NATSConfig{}
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		natsConfig,
+		logger,
+		cacheProvider,
+	)
+
+	totpSecret, code := newTOTPSecret(t, true)
+	challengeHash := hashToken(tokensConfig.SecretKey, "challenge")
+	recoveryCodeHash := hashRecoveryCode(tokensConfig.SecretKey, 1, "ABCDE-FGHIJ")
+	challenge := &entities.MFAChallenge{ID: 1, UserID: 1, TokenHash: challengeHash}
+	clientInfo := entities.ClientInfo{IP: "127.0.0.1", UserAgent: "test"}
+
+	testCases := []struct {
+		name       string
+		loginData  entities.CompleteMFALoginDTO
+		setupMocks func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+			natsPublisher *mocknats.MockPublisher,
+			logger *mocklogging.MockLogger,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedErr error
+	}{
+		{
+			name: "success with totp code",
+			loginData: entities.CompleteMFALoginDTO{
+				MFAChallenge: "challenge",
+				Code:         code,
+				ClientInfo:   clientInfo,
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetMFAChallengeByHash(gomock.Any(), challengeHash).
+					Return(challenge, nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), mfaChallengeCacheKey(1), tokensConfig.MFAChallenge.TTL).
+					Return(int64(1), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(totpSecret, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					UseTOTPStep(gomock.Any(), uint64(1), gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ExpireMFAChallenge(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(&entities.User{ID: 1, Email: "test@example.com"}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Return(uint64(2), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateRefreshToken(gomock.Any(), refreshTokenData(1, 2, "")).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "success with recovery code",
+			loginData: entities.CompleteMFALoginDTO{
+				MFAChallenge: "challenge",
+				Code:         "abcde fghij",
+				ClientInfo:   clientInfo,
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetMFAChallengeByHash(gomock.Any(), challengeHash).
+					Return(challenge, nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), mfaChallengeCacheKey(1), tokensConfig.MFAChallenge.TTL).
+					Return(int64(2), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(totpSecret, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					UseRecoveryCode(gomock.Any(), uint64(1), recoveryCodeHash).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ExpireMFAChallenge(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(&entities.User{ID: 1, Email: "test@example.com"}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Return(uint64(2), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateRefreshToken(gomock.Any(), refreshTokenData(1, 2, "")).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "invalid mfa challenge",
+			loginData: entities.CompleteMFALoginDTO{
+				MFAChallenge: "challenge",
+				Code:         code,
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetMFAChallengeByHash(gomock.Any(), challengeHash).
+					Return(nil, errors.New("test")).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidMFAChallengeError{},
+		},
+		{
+			name: "too many attempts",
+			loginData: entities.CompleteMFALoginDTO{
+				MFAChallenge: "challenge",
+				Code:         code,
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetMFAChallengeByHash(gomock.Any(), challengeHash).
+					Return(challenge, nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), mfaChallengeCacheKey(1), tokensConfig.MFAChallenge.TTL).
+					Return(int64(mfaChallengeAttemptsLimit+1), nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidMFAChallengeError{},
+		},
+		{
+			name: "attempts can not be counted",
+			loginData: entities.CompleteMFALoginDTO{
+				MFAChallenge: "challenge",
+				Code:         code,
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetMFAChallengeByHash(gomock.Any(), challengeHash).
+					Return(challenge, nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), mfaChallengeCacheKey(1), tokensConfig.MFAChallenge.TTL).
+					Return(int64(0), errors.New("cache is unavailable")).
+					Times(1)
+			},
+			expectedErr: errors.New("cache is unavailable"),
+		},
+		{
+			name: "invalid code on first attempt",
+			loginData: entities.CompleteMFALoginDTO{
+				MFAChallenge: "challenge",
+				Code:         "000000",
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetMFAChallengeByHash(gomock.Any(), challengeHash).
+					Return(challenge, nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), mfaChallengeCacheKey(1), tokensConfig.MFAChallenge.TTL).
+					Return(int64(1), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(totpSecret, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidMFACodeError{},
+		},
+		{
+			name: "used recovery code on next attempt",
+			loginData: entities.CompleteMFALoginDTO{
+				MFAChallenge: "challenge",
+				Code:         "ABCDE-FGHIJ",
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetMFAChallengeByHash(gomock.Any(), challengeHash).
+					Return(challenge, nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), mfaChallengeCacheKey(1), tokensConfig.MFAChallenge.TTL).
+					Return(int64(3), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(totpSecret, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					UseRecoveryCode(gomock.Any(), uint64(1), recoveryCodeHash).
+					Return(&customerrors.InvalidMFACodeError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidMFACodeError{},
+		},
+		{
+			name: "expire mfa challenge error",
+			loginData: entities.CompleteMFALoginDTO{
+				MFAChallenge: "challenge",
+				Code:         code,
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetMFAChallengeByHash(gomock.Any(), challengeHash).
+					Return(challenge, nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), mfaChallengeCacheKey(1), tokensConfig.MFAChallenge.TTL).
+					Return(int64(1), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(totpSecret, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					UseTOTPStep(gomock.Any(), uint64(1), gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ExpireMFAChallenge(gomock.Any(), uint64(1)).
+					Return(&customerrors.InvalidMFAChallengeError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidMFAChallengeError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(
+					authService,
+					usersService,
+					natsPublisher,
+					logger,
+					cacheProvider,
+				)
+			}
+
+			tokens, err := useCases.CompleteMFALogin(context.Background(), tc.loginData)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, tokens)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotEmpty(t, tokens.AccessToken)
+			require.NotEmpty(t, tokens.RefreshToken)
+			require.Nil(t, tokens.MFAChallenge)
+		})
+	}
+}
+
+func TestUseCases_StartTOTPEnrollment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:      "secret",
+			Algorithm:      "HS256",
+			AccessTokenTTL: time.Hour,
+		},
+	}
+	natsConfig := config.NATSConfig{}
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		natsConfig,
+		logger,
+		cacheProvider,
+	)
+
+	accessToken := newAccessToken(t, securityConfig.JWT, 1, 2)
+	user := &entities.User{ID: 1, Email: "test@example.com"}
+
+	testCases := []struct {
+		name        string
+		accessToken string
+		setupMocks  func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+			natsPublisher *mocknats.MockPublisher,
+			logger *mocklogging.MockLogger,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedErr error
+	}{
+		{
+			name:        "success",
+			accessToken: accessToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(user, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(nil, &customerrors.MFANotEnabledError{}).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateTOTPSecret(gomock.Any(), gomock.Any()).
+					DoAndReturn(
+						func(_ context.Context, secretData entities.CreateTOTPSecretDTO) (uint64, error) {
+							require.Equal(t, uint64(1), secretData.UserID)
+
+							// Secret must be stored encrypted:
+							_, err := decryptSecret(tokensConfig.SecretKey, secretData.Secret)
+							require.NoError(t, err)
+
+							return 1, nil
+						},
+					).
+					Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "restart of not finished enrollment",
+			accessToken: accessToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(user, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(&entities.TOTPSecret{ID: 1, UserID: 1, Confirmed: false}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateTOTPSecret(gomock.Any(), gomock.Any()).
+					Return(uint64(2), nil).
+					Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "already enabled",
+			accessToken: accessToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(user, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(&entities.TOTPSecret{ID: 1, UserID: 1, Confirmed: true}, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.MFAAlreadyEnabledError{},
+		},
+		{
+			name:        "user not found",
+			accessToken: accessToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(nil, &customerrors.UserNotFoundError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.UserNotFoundError{},
+		},
+		{
+			name:        "create totp secret error",
+			accessToken: accessToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(user, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(nil, &customerrors.MFANotEnabledError{}).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateTOTPSecret(gomock.Any(), gomock.Any()).
+					Return(uint64(0), errors.New("test")).
+					Times(1)
+			},
+			expectedErr: errors.New("test"),
+		},
+		{
+			name:        "invalid token",
+			accessToken: "invalid_token",
+			expectedErr: &security.InvalidJWTError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(
+					authService,
+					usersService,
+					natsPublisher,
+					logger,
+					cacheProvider,
+				)
+			}
+
+			enrollment, err := useCases.StartTOTPEnrollment(context.Background(), tc.accessToken)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, enrollment)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotEmpty(t, enrollment.Secret)
+			require.Contains(t, enrollment.URI, "otpauth://totp/")
+			require.Contains(t, enrollment.URI, enrollment.Secret)
+		})
+	}
+}
+
+func TestUseCases_ConfirmTOTPEnrollment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:      "secret",
+			Algorithm:      "HS256",
+			AccessTokenTTL: time.Hour,
+		},
+	}
+	natsConfig := config.NATSConfig{}
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		natsConfig,
+		logger,
+		cacheProvider,
+	)
+
+	accessToken := newAccessToken(t, securityConfig.JWT, 1, 2)
+	totpSecret, code := newTOTPSecret(t, false)
+	confirmedTOTPSecret, _ := newTOTPSecret(t, true)
+
+	testCases := []struct {
+		name        string
+		accessToken string
+		code        string
+		setupMocks  func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+			natsPublisher *mocknats.MockPublisher,
+			logger *mocklogging.MockLogger,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedErr error
+	}{
+		{
+			name:        "success",
+			accessToken: accessToken,
+			code:        code,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(totpSecret, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ConfirmTOTPSecret(gomock.Any(), gomock.Any()).
+					DoAndReturn(
+						func(_ context.Context, confirmData entities.ConfirmTOTPSecretDTO) error {
+							require.Equal(t, uint64(1), confirmData.TOTPSecretID)
+							require.Equal(t, uint64(1), confirmData.UserID)
+							require.NotZero(t, confirmData.Step)
+							require.Len(t, confirmData.RecoveryCodeHashes, recoveryCodesCount)
+
+							return nil
+						},
+					).
+					Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "already enabled",
+			accessToken: accessToken,
+			code:        code,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(confirmedTOTPSecret, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.MFAAlreadyEnabledError{},
+		},
+		{
+			name:        "enrollment not started",
+			accessToken: accessToken,
+			code:        code,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(nil, &customerrors.MFANotEnabledError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.MFANotEnabledError{},
+		},
+		{
+			name:        "invalid code",
+			accessToken: accessToken,
+			code:        "000000",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(totpSecret, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidMFACodeError{},
+		},
+		{
+			name:        "confirm totp secret error",
+			accessToken: accessToken,
+			code:        code,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(totpSecret, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ConfirmTOTPSecret(gomock.Any(), gomock.Any()).
+					Return(&customerrors.InvalidMFACodeError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidMFACodeError{},
+		},
+		{
+			name:        "invalid token",
+			accessToken: "invalid_token",
+			code:        code,
+			expectedErr: &security.InvalidJWTError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(
+					authService,
+					usersService,
+					natsPublisher,
+					logger,
+					cacheProvider,
+				)
+			}
+
+			recoveryCodes, err := useCases.ConfirmTOTPEnrollment(context.Background(), tc.accessToken, tc.code)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, recoveryCodes)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, recoveryCodes, recoveryCodesCount)
+		})
+	}
+}
+
+func TestUseCases_DisableTOTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:      "secret",
+			Algorithm:      "HS256",
+			AccessTokenTTL: time.Hour,
+		},
+	}
+	natsConfig := config.NATSConfig{}
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		natsConfig,
+		logger,
+		cacheProvider,
+	)
+
+	accessToken := newAccessToken(t, securityConfig.JWT, 1, 2)
+	totpSecret, code := newTOTPSecret(t, true)
+	unconfirmedTOTPSecret, _ := newTOTPSecret(t, false)
+
+	testCases := []struct {
+		name        string
+		accessToken string
+		code        string
+		setupMocks  func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+			natsPublisher *mocknats.MockPublisher,
+			logger *mocklogging.MockLogger,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedErr error
+	}{
+		{
+			name:        "success",
+			accessToken: accessToken,
+			code:        code,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(totpSecret, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					UseTOTPStep(gomock.Any(), uint64(1), gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					DeleteTOTPSecret(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "not enabled",
+			accessToken: accessToken,
+			code:        code,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(unconfirmedTOTPSecret, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.MFANotEnabledError{},
+		},
+		{
+			name:        "invalid code",
+			accessToken: accessToken,
+			code:        "000000",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(totpSecret, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidMFACodeError{},
+		},
+		{
+			name:        "delete totp secret error",
+			accessToken: accessToken,
+			code:        "ABCDE-FGHIJ",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(totpSecret, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					UseRecoveryCode(
+						gomock.Any(),
+						uint64(1),
+						hashRecoveryCode(tokensConfig.SecretKey, 1, "ABCDE-FGHIJ"),
+					).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					DeleteTOTPSecret(gomock.Any(), uint64(1)).
+					Return(errors.New("test")).
+					Times(1)
+			},
+			expectedErr: errors.New("test"),
+		},
+		{
+			name:        "invalid token",
+			accessToken: "invalid_token",
+			code:        code,
+			expectedErr: &security.InvalidJWTError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(
+					authService,
+					usersService,
+					natsPublisher,
+					logger,
+					cacheProvider,
+				)
+			}
+
+			err := useCases.DisableTOTP(context.Background(), tc.accessToken, tc.code)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,439 @@
+package usecases
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DKhorkov/libs/security"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+)
+
+// OpenID Connect endpoints, which are served by HTTP controller relatively to issuer:
+const (
+	authorizationEndpoint = "/authorize"
+	tokenEndpoint         = "/token"
+	userInfoEndpoint      = "/userinfo"
+	jwksEndpoint          = "/.well-known/jwks.json"
+)
+
+const (
+	returnToParam = "return_to"
+
+	// Code verifier is 43-128 characters long according to RFC 7636, so S256 challenge has fixed length:
+	codeVerifierMinLength = 43
+	codeVerifierMaxLength = 128
+	codeChallengeLength   = 43
+)
+
+var supportedScopes = []string{entities.OpenIDScope, entities.EmailScope, entities.ProfileScope}
+
+// getOIDCClient looks for registered Client, which is allowed to use authorization code flow.
+func (useCases *UseCases) getOIDCClient(ctx context.Context, clientID string) (*entities.Client, error) {
+	client, err := useCases.authService.GetClientByClientID(ctx, clientID)
+	if err != nil {
+		var clientNotFoundError *customerrors.ClientNotFoundError
+		if errors.As(err, &clientNotFoundError) {
+			return nil, &customerrors.OAuthError{
+				Code:    customerrors.InvalidClientOAuthErrorCode,
+				Message: "unknown client",
+				BaseErr: err,
+			}
+		}
+
+		return nil, err
+	}
+
+	if !slices.Contains(strings.Split(client.GrantTypes, grantTypesSeparator), entities.AuthorizationCodeGrantType) {
+		return nil, &customerrors.OAuthError{
+			Code:    customerrors.UnauthorizedClientOAuthErrorCode,
+			Message: "client is not allowed to use authorization_code grant type",
+		}
+	}
+
+	return client, nil
+}
+
+// authenticateOIDCClient authenticates Client of token request and maps authentication errors to OAuth ones.
+func (useCases *UseCases) authenticateOIDCClient(
+	ctx context.Context,
+	tokenRequest entities.OIDCTokenRequestDTO,
+) (*entities.Client, error) {
+	client, err := useCases.authenticateClient(
+		ctx,
+		tokenRequest.ClientID,
+		tokenRequest.ClientSecret,
+		tokenRequest.GrantType,
+	)
+	if err != nil {
+		var (
+			invalidClientError      *customerrors.InvalidClientError
+			unauthorizedClientError *customerrors.UnauthorizedClientError
+		)
+
+		switch {
+		case errors.As(err, &invalidClientError):
+			return nil, &customerrors.OAuthError{
+				Code:    customerrors.InvalidClientOAuthErrorCode,
+				Message: invalidClientError.Error(),
+				BaseErr: err,
+			}
+		case errors.As(err, &unauthorizedClientError):
+			return nil, &customerrors.OAuthError{
+				Code:    customerrors.UnauthorizedClientOAuthErrorCode,
+				Message: unauthorizedClientError.Error(),
+				BaseErr: err,
+			}
+		default:
+			return nil, err
+		}
+	}
+
+	return client, nil
+}
+
+// validateAuthorizationRequest checks parameters of authorization request, which are reported
+// to client via redirect. Only authorization code flow with S256 PKCE is supported.
+func validateAuthorizationRequest(authorizeData entities.AuthorizeDTO, client *entities.Client) error {
+	if authorizeData.ResponseType != entities.CodeResponseType {
+		return &customerrors.OAuthError{
+			Code:    customerrors.UnsupportedResponseTypeOAuthErrorCode,
+			Message: "only code response type is supported",
+		}
+	}
+
+	if !slices.Contains(strings.Fields(authorizeData.Scope), entities.OpenIDScope) {
+		return &customerrors.OAuthError{
+			Code:    customerrors.InvalidScopeOAuthErrorCode,
+			Message: "openid scope is required",
+		}
+	}
+
+	if !slices.Contains(strings.Fields(client.Scopes), entities.OpenIDScope) {
+		return &customerrors.OAuthError{
+			Code:    customerrors.InvalidScopeOAuthErrorCode,
+			Message: "openid scope is not allowed for client",
+		}
+	}
+
+	if authorizeData.CodeChallengeMethod != entities.S256CodeChallengeMethod ||
+		len(authorizeData.CodeChallenge) != codeChallengeLength {
+		return &customerrors.OAuthError{
+			Code:    customerrors.InvalidRequestOAuthErrorCode,
+			Message: "code_challenge with S256 code_challenge_method is required",
+		}
+	}
+
+	return nil
+}
+
+// filterScope removes unsupported scopes and scopes, which are not allowed for Client.
+// Such scopes are ignored according to RFC 6749.
+func filterScope(scope string, client *entities.Client) string {
+	allowedScopes := strings.Fields(client.Scopes)
+	scopes := make([]string, 0, len(supportedScopes))
+	for _, requestedScope := range strings.Fields(scope) {
+		if slices.Contains(supportedScopes, requestedScope) && slices.Contains(allowedScopes, requestedScope) &&
+			!slices.Contains(scopes, requestedScope) {
+			scopes = append(scopes, requestedScope)
+		}
+	}
+
+	return strings.Join(scopes, " ")
+}
+
+// verifyCodeChallenge checks, that code verifier is the one, which S256 code challenge was created from.
+func verifyCodeChallenge(codeChallenge, codeVerifier string) bool {
+	if len(codeVerifier) < codeVerifierMinLength || len(codeVerifier) > codeVerifierMaxLength {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(codeChallengeS256(codeVerifier)), []byte(codeChallenge)) == 1
+}
+
+func codeChallengeS256(codeVerifier string) string {
+	digest := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
+
+// buildRedirectURL adds parameters to query of registered redirect URI, keeping its own parameters.
+func buildRedirectURL(redirectURI string, params url.Values) (string, error) {
+	redirectURL, err := url.Parse(redirectURI)
+	if err != nil {
+		return "", err
+	}
+
+	query := redirectURL.Query()
+	for key := range params {
+		query.Set(key, params.Get(key))
+	}
+
+	redirectURL.RawQuery = query.Encode()
+
+	return redirectURL.String(), nil
+}
+
+// buildErrorRedirectURL reports error of authorization request to client according to RFC 6749.
+func buildErrorRedirectURL(authorizeData entities.AuthorizeDTO, oauthError *customerrors.OAuthError) (string, error) {
+	params := url.Values{}
+	params.Set("error", oauthError.Code)
+
+	if oauthError.Message != "" {
+		params.Set("error_description", oauthError.Message)
+	}
+
+	if authorizeData.State != "" {
+		params.Set("state", authorizeData.State)
+	}
+
+	return buildRedirectURL(authorizeData.RedirectURI, params)
+}
+
+// buildLoginURL returns URL of login page, which redirects User back to authorization endpoint after login.
+func (useCases *UseCases) buildLoginURL(authorizeData entities.AuthorizeDTO) (string, error) {
+	authorizationParams := url.Values{}
+	authorizationParams.Set("client_id", authorizeData.ClientID)
+	authorizationParams.Set("redirect_uri", authorizeData.RedirectURI)
+	authorizationParams.Set("response_type", authorizeData.ResponseType)
+	authorizationParams.Set("scope", authorizeData.Scope)
+	authorizationParams.Set("code_challenge", authorizeData.CodeChallenge)
+	authorizationParams.Set("code_challenge_method", authorizeData.CodeChallengeMethod)
+
+	if authorizeData.State != "" {
+		authorizationParams.Set("state", authorizeData.State)
+	}
+
+	if authorizeData.Nonce != "" {
+		authorizationParams.Set("nonce", authorizeData.Nonce)
+	}
+
+	params := url.Values{}
+	params.Set(
+		returnToParam,
+		useCases.oidcConfig.Issuer+authorizationEndpoint+"?"+authorizationParams.Encode(),
+	)
+
+	return buildRedirectURL(useCases.oidcConfig.LoginURL, params)
+}
+
+// createIDToken issues ID token for client. Claims of User are included according to granted scope.
+func (useCases *UseCases) createIDToken(
+	user *entities.User,
+	client *entities.Client,
+	authorizationCode *entities.AuthorizationCode,
+) (string, error) {
+	now := time.Now()
+	claims := idTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatUint(user.ID, 10),
+			Issuer:    useCases.oidcConfig.Issuer,
+			Audience:  jwt.ClaimStrings{authorizationCode.ClientID},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(useCases.accessTokenTTL(client))),
+		},
+		Nonce: authorizationCode.Nonce,
+	}
+
+	scopes := strings.Fields(authorizationCode.Scope)
+	if slices.Contains(scopes, entities.EmailScope) {
+		claims.Email = user.Email
+		claims.EmailVerified = &user.EmailConfirmed
+	}
+
+	if slices.Contains(scopes, entities.ProfileScope) {
+		claims.Name = user.DisplayName
+		if user.Avatar != nil {
+			claims.Picture = *user.Avatar
+		}
+	}
+
+	return useCases.jwtProvider.Sign(claims)
+}
+
+func mapUserToUserInfo(user *entities.User) *entities.UserInfo {
+	userInfo := &entities.UserInfo{
+		Subject:             strconv.FormatUint(user.ID, 10),
+		Email:               user.Email,
+		EmailVerified:       user.EmailConfirmed,
+		Name:                user.DisplayName,
+		PhoneNumberVerified: user.PhoneConfirmed,
+	}
+
+	if user.Avatar != nil {
+		userInfo.Picture = *user.Avatar
+	}
+
+	if user.Phone != nil {
+		userInfo.PhoneNumber = *user.Phone
+	}
+
+	return userInfo
+}
+
+// exchangeAuthorizationCode issues tokens for client, which has received authorization code and proves
+// possession of code verifier. Each exchange creates new Session for User.
+func (useCases *UseCases) exchangeAuthorizationCode(
+	ctx context.Context,
+	tokenRequest entities.OIDCTokenRequestDTO,
+) (*entities.OIDCTokensDTO, error) {
+	if tokenRequest.Code == "" || tokenRequest.CodeVerifier == "" {
+		return nil, &customerrors.OAuthError{
+			Code:    customerrors.InvalidRequestOAuthErrorCode,
+			Message: "code and code_verifier are required",
+		}
+	}
+
+	client, err := useCases.authenticateOIDCClient(ctx, tokenRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizationCode, err := useCases.authService.GetAuthorizationCodeByHash(
+		ctx,
+		hashToken(useCases.tokensConfig.SecretKey, tokenRequest.Code),
+	)
+	if err != nil {
+		return nil, &customerrors.OAuthError{
+			Code:    customerrors.InvalidGrantOAuthErrorCode,
+			Message: "authorization code is invalid or expired",
+			BaseErr: err,
+		}
+	}
+
+	if authorizationCode.ClientID != tokenRequest.ClientID ||
+		authorizationCode.RedirectURI != tokenRequest.RedirectURI {
+		return nil, &customerrors.OAuthError{
+			Code:    customerrors.InvalidGrantOAuthErrorCode,
+			Message: "authorization code was issued for another client or redirect_uri",
+		}
+	}
+
+	if !verifyCodeChallenge(authorizationCode.CodeChallenge, tokenRequest.CodeVerifier) {
+		return nil, &customerrors.OAuthError{
+			Code:    customerrors.InvalidGrantOAuthErrorCode,
+			Message: "code_verifier does not match code_challenge",
+		}
+	}
+
+	if err = useCases.authService.UseAuthorizationCode(ctx, authorizationCode.ID); err != nil {
+		var invalidAuthorizationCodeError *customerrors.InvalidAuthorizationCodeError
+		if errors.As(err, &invalidAuthorizationCodeError) {
+			return nil, &customerrors.OAuthError{
+				Code:    customerrors.InvalidGrantOAuthErrorCode,
+				Message: "authorization code has been already used",
+				BaseErr: err,
+			}
+		}
+
+		return nil, err
+	}
+
+	user, err := useCases.GetUserByID(ctx, authorizationCode.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := useCases.loginUser(ctx, user, client, tokenRequest.ClientInfo)
+	if err != nil {
+		var userBlockedError *customerrors.UserBlockedError
+		if errors.As(err, &userBlockedError) {
+			return nil, &customerrors.OAuthError{
+				Code:    customerrors.InvalidGrantOAuthErrorCode,
+				Message: "user has been blocked",
+				BaseErr: err,
+			}
+		}
+
+		return nil, err
+	}
+
+	idToken, err := useCases.createIDToken(user, client, authorizationCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.OIDCTokensDTO{
+		TokensDTO: *tokens,
+		IDToken:   idToken,
+		Scope:     authorizationCode.Scope,
+	}, nil
+}
+
+// refreshOIDCTokens rotates refresh token of client the same way as RefreshTokens does,
+// but only if refresh token has been issued for authenticated client.
+func (useCases *UseCases) refreshOIDCTokens(
+	ctx context.Context,
+	tokenRequest entities.OIDCTokenRequestDTO,
+) (*entities.OIDCTokensDTO, error) {
+	client, err := useCases.authenticateOIDCClient(ctx, tokenRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := useCases.refreshTokens(ctx, tokenRequest.RefreshToken, client)
+	if err != nil {
+		var (
+			invalidJWTError                *security.InvalidJWTError
+			refreshTokenReuseDetectedError *customerrors.RefreshTokenReuseDetectedError
+			userBlockedError               *customerrors.UserBlockedError
+		)
+
+		if errors.As(err, &invalidJWTError) ||
+			errors.As(err, &refreshTokenReuseDetectedError) ||
+			errors.As(err, &userBlockedError) {
+			return nil, &customerrors.OAuthError{
+				Code:    customerrors.InvalidGrantOAuthErrorCode,
+				Message: "refresh token is invalid or expired",
+				BaseErr: err,
+			}
+		}
+
+		return nil, err
+	}
+
+	return &entities.OIDCTokensDTO{TokensDTO: *tokens}, nil
+}
+
+// issueOIDCClientToken issues access token to Client on its own behalf according to client credentials grant.
+func (useCases *UseCases) issueOIDCClientToken(
+	ctx context.Context,
+	tokenRequest entities.OIDCTokenRequestDTO,
+) (*entities.OIDCTokensDTO, error) {
+	client, err := useCases.authenticateOIDCClient(ctx, tokenRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	clientToken, err := useCases.issueClientToken(client, tokenRequest.Scope)
+	if err != nil {
+		var invalidScopeError *customerrors.InvalidScopeError
+		if errors.As(err, &invalidScopeError) {
+			return nil, &customerrors.OAuthError{
+				Code:    customerrors.InvalidScopeOAuthErrorCode,
+				Message: invalidScopeError.Error(),
+				BaseErr: err,
+			}
+		}
+
+		return nil, err
+	}
+
+	return &entities.OIDCTokensDTO{
+		TokensDTO: entities.TokensDTO{
+			AccessToken: clientToken.AccessToken,
+			ExpiresIn:   clientToken.ExpiresIn,
+			TokenType:   clientToken.TokenType,
+		},
+		Scope: clientToken.Scope,
+	}, nil
+}
@@ -0,0 +1,997 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	mocknats "github.com/DKhorkov/libs/nats/mocks"
+	"github.com/DKhorkov/libs/pointers"
+	"github.com/DKhorkov/libs/security"
+
+	"github.com/DKhorkov/hmtm-sso/internal/config"
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+	mockcache "github.com/DKhorkov/hmtm-sso/mocks/cache"
+	mockservices "github.com/DKhorkov/hmtm-sso/mocks/services"
+)
+
+// Code verifier and its S256 code challenge from RFC 7636 example:
+const (
+	testCodeVerifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	testCodeChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+)
+
+// shopClient is public Client, which is allowed to use authorization code flow.
+var shopClient = &entities.Client{
+	ID:           1,
+	ClientID:     "shop",
+	RedirectURIs: "https://shop.example.com/callback,https://shop.example.com/silent",
+	GrantTypes:   "authorization_code,refresh_token",
+	Scopes:       "openid email profile",
+}
+
+// ordersClient is confidential Client of backend service, which requests tokens on its own behalf.
+var ordersClient = &entities.Client{
+	ID:         2,
+	ClientID:   "orders",
+	SecretHash: hashToken(tokensConfig.SecretKey, "orders-secret"),
+	GrantTypes: "client_credentials",
+	Scopes:     "users:read",
+}
+
+func expectClient(authService *mockservices.MockAuthService, client *entities.Client) {
+	authService.
+		EXPECT().
+		GetClientByClientID(gomock.Any(), client.ClientID).
+		Return(client, nil).
+		Times(1)
+}
+
+// authorizationCodeData matches authorization code, which is issued for shop client.
+func authorizationCodeData(userID uint64, scope string) gomock.Matcher {
+	return gomock.Cond(func(codeData entities.CreateAuthorizationCodeDTO) bool {
+		return codeData.UserID == userID &&
+			codeData.ClientID == "shop" &&
+			codeData.CodeHash != "" &&
+			codeData.RedirectURI == "https://shop.example.com/callback" &&
+			codeData.Scope == scope &&
+			codeData.Nonce == "nonce" &&
+			codeData.CodeChallenge == testCodeChallenge &&
+			codeData.TTL == tokensConfig.AuthorizationCode.TTL
+	})
+}
+
+func TestUseCases_GetOpenIDConfiguration(t *testing.T) {
+	securityConfig := security.Config{JWT: security.JWTConfig{Algorithm: "RS256"}}
+	useCases := New(
+		nil,
+		nil,
+		securityConfig,
+		nil, // JWT is not used
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		nil,
+		config.NATSConfig{},
+		nil,
+		nil,
+	)
+
+	configuration := useCases.GetOpenIDConfiguration()
+	require.Equal(t, "https://sso.example.com", configuration.Issuer)
+	require.Equal(t, "https://sso.example.com/authorize", configuration.AuthorizationEndpoint)
+	require.Equal(t, "https://sso.example.com/token", configuration.TokenEndpoint)
+	require.Equal(t, "https://sso.example.com/userinfo", configuration.UserInfoEndpoint)
+	require.Equal(t, "https://sso.example.com/.well-known/jwks.json", configuration.JWKSURI)
+	require.Equal(t, []string{"RS256"}, configuration.IDTokenSigningAlgValuesSupported)
+	require.Equal(t, []string{entities.S256CodeChallengeMethod}, configuration.CodeChallengeMethodsSupported)
+	require.Contains(t, configuration.GrantTypesSupported, entities.ClientCredentialsGrantType)
+}
+
+func TestUseCases_Authorize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		config.NATSConfig{},
+		logger,
+		cacheProvider,
+	)
+
+	accessToken := newAccessToken(t, securityConfig.JWT, 1, 2)
+	authorizeData := entities.AuthorizeDTO{
+		ClientID:            "shop",
+		RedirectURI:         "https://shop.example.com/callback",
+		ResponseType:        entities.CodeResponseType,
+		Scope:               "openid email unknown",
+		State:               "state",
+		Nonce:               "nonce",
+		CodeChallenge:       testCodeChallenge,
+		CodeChallengeMethod: entities.S256CodeChallengeMethod,
+		AccessToken:         accessToken,
+	}
+
+	testCases := []struct {
+		name          string
+		authorizeData func() entities.AuthorizeDTO
+		setupMocks    func(
+			authService *mockservices.MockAuthService,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedURL    string
+		expectedParams url.Values
+		expectCode     bool
+		expectedErr    error
+	}{
+		{
+			name: "success",
+			authorizeData: func() entities.AuthorizeDTO {
+				return authorizeData
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectClient(authService, shopClient)
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					CreateAuthorizationCode(gomock.Any(), authorizationCodeData(1, "openid email")).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectedURL:    "https://shop.example.com/callback",
+			expectedParams: url.Values{"state": {"state"}},
+			expectCode:     true,
+			expectedErr:    nil,
+		},
+		{
+			name: "not logged in",
+			authorizeData: func() entities.AuthorizeDTO {
+				data := authorizeData
+				data.AccessToken = ""
+
+				return data
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				_ *mockcache.MockCacheProvider,
+			) {
+				expectClient(authService, shopClient)
+			},
+			expectedURL: "https://example.com/login",
+			expectedErr: nil,
+		},
+		{
+			name: "not logged in with none prompt",
+			authorizeData: func() entities.AuthorizeDTO {
+				data := authorizeData
+				data.AccessToken = ""
+				data.Prompt = entities.NonePrompt
+
+				return data
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				_ *mockcache.MockCacheProvider,
+			) {
+				expectClient(authService, shopClient)
+			},
+			expectedURL: "https://shop.example.com/callback",
+			expectedParams: url.Values{
+				"error": {customerrors.LoginRequiredOAuthErrorCode},
+				"state": {"state"},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "unsupported response type",
+			authorizeData: func() entities.AuthorizeDTO {
+				data := authorizeData
+				data.ResponseType = "token"
+
+				return data
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				_ *mockcache.MockCacheProvider,
+			) {
+				expectClient(authService, shopClient)
+			},
+			expectedURL: "https://shop.example.com/callback",
+			expectedParams: url.Values{
+				"error": {customerrors.UnsupportedResponseTypeOAuthErrorCode},
+				"state": {"state"},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "code challenge is missing",
+			authorizeData: func() entities.AuthorizeDTO {
+				data := authorizeData
+				data.CodeChallenge = ""
+
+				return data
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				_ *mockcache.MockCacheProvider,
+			) {
+				expectClient(authService, shopClient)
+			},
+			expectedURL: "https://shop.example.com/callback",
+			expectedParams: url.Values{
+				"error": {customerrors.InvalidRequestOAuthErrorCode},
+				"state": {"state"},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "unknown client",
+			authorizeData: func() entities.AuthorizeDTO {
+				data := authorizeData
+				data.ClientID = "unknown"
+
+				return data
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				_ *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetClientByClientID(gomock.Any(), "unknown").
+					Return(nil, &customerrors.ClientNotFoundError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.OAuthError{
+				Code:    customerrors.InvalidClientOAuthErrorCode,
+				Message: "unknown client",
+				BaseErr: &customerrors.ClientNotFoundError{},
+			},
+		},
+		{
+			name: "client is not allowed to use authorization code",
+			authorizeData: func() entities.AuthorizeDTO {
+				return authorizeData
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				_ *mockcache.MockCacheProvider,
+			) {
+				client := *shopClient
+				client.GrantTypes = entities.PasswordGrantType
+				expectClient(authService, &client)
+			},
+			expectedErr: &customerrors.OAuthError{
+				Code:    customerrors.UnauthorizedClientOAuthErrorCode,
+				Message: "client is not allowed to use authorization_code grant type",
+			},
+		},
+		{
+			name: "openid scope is not allowed for client",
+			authorizeData: func() entities.AuthorizeDTO {
+				return authorizeData
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				_ *mockcache.MockCacheProvider,
+			) {
+				client := *shopClient
+				client.Scopes = entities.EmailScope
+				expectClient(authService, &client)
+			},
+			expectedURL: "https://shop.example.com/callback",
+			expectedParams: url.Values{
+				"error": {customerrors.InvalidScopeOAuthErrorCode},
+				"state": {"state"},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "redirect uri is not registered",
+			authorizeData: func() entities.AuthorizeDTO {
+				data := authorizeData
+				data.RedirectURI = "https://evil.example.com/callback"
+
+				return data
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				_ *mockcache.MockCacheProvider,
+			) {
+				expectClient(authService, shopClient)
+			},
+			expectedErr: &customerrors.OAuthError{
+				Code:    customerrors.InvalidRequestOAuthErrorCode,
+				Message: "redirect_uri is not registered for client",
+			},
+		},
+		{
+			name: "create authorization code error",
+			authorizeData: func() entities.AuthorizeDTO {
+				return authorizeData
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectClient(authService, shopClient)
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					CreateAuthorizationCode(gomock.Any(), gomock.Any()).
+					Return(uint64(0), errors.New("test error")).
+					Times(1)
+			},
+			expectedErr: errors.New("test error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authService, cacheProvider)
+			}
+
+			redirectURL, err := useCases.Authorize(context.Background(), tc.authorizeData())
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Empty(t, redirectURL)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			parsedURL, err := url.Parse(redirectURL)
+			require.NoError(t, err)
+
+			query := parsedURL.Query()
+			parsedURL.RawQuery = ""
+			require.Equal(t, tc.expectedURL, parsedURL.String())
+
+			for key := range tc.expectedParams {
+				require.Equal(t, tc.expectedParams.Get(key), query.Get(key))
+			}
+
+			if tc.expectCode {
+				require.NotEmpty(t, query.Get("code"))
+			}
+		})
+	}
+}
+
+func TestUseCases_ExchangeOIDCToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		config.NATSConfig{},
+		logger,
+		cacheProvider,
+	)
+
+	user := &entities.User{
+		ID:             1,
+		DisplayName:    "User",
+		Email:          "test@example.com",
+		EmailConfirmed: true,
+		Avatar:         pointers.New("https://example.com/avatar.png"),
+	}
+	authorizationCode := &entities.AuthorizationCode{
+		ID:            2,
+		UserID:        1,
+		ClientID:      "shop",
+		CodeHash:      hashToken(tokensConfig.SecretKey, "authorization-code"),
+		RedirectURI:   "https://shop.example.com/callback",
+		Scope:         "openid email",
+		Nonce:         "nonce",
+		CodeChallenge: testCodeChallenge,
+	}
+	tokenRequest := entities.OIDCTokenRequestDTO{
+		GrantType:    entities.AuthorizationCodeGrantType,
+		ClientID:     "shop",
+		Code:         "authorization-code",
+		RedirectURI:  "https://shop.example.com/callback",
+		CodeVerifier: testCodeVerifier,
+	}
+
+	testCases := []struct {
+		name         string
+		tokenRequest func() entities.OIDCTokenRequestDTO
+		setupMocks   func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+		)
+		expectIDToken bool
+		expectedErr   error
+	}{
+		{
+			name: "success",
+			tokenRequest: func() entities.OIDCTokenRequestDTO {
+				return tokenRequest
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+			) {
+				expectClient(authService, shopClient)
+
+				authService.
+					EXPECT().
+					GetAuthorizationCodeByHash(gomock.Any(), authorizationCode.CodeHash).
+					Return(authorizationCode, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					UseAuthorizationCode(gomock.Any(), uint64(2)).
+					Return(nil).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(user, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Return(uint64(3), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateRefreshToken(gomock.Any(), refreshTokenData(1, 3, "")).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectIDToken: true,
+			expectedErr:   nil,
+		},
+		{
+			name: "code verifier is missing",
+			tokenRequest: func() entities.OIDCTokenRequestDTO {
+				request := tokenRequest
+				request.CodeVerifier = ""
+
+				return request
+			},
+			expectedErr: &customerrors.OAuthError{
+				Code:    customerrors.InvalidRequestOAuthErrorCode,
+				Message: "code and code_verifier are required",
+			},
+		},
+		{
+			name: "unknown client",
+			tokenRequest: func() entities.OIDCTokenRequestDTO {
+				request := tokenRequest
+				request.ClientID = "unknown"
+
+				return request
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				_ *mockservices.MockUsersService,
+			) {
+				authService.
+					EXPECT().
+					GetClientByClientID(gomock.Any(), "unknown").
+					Return(nil, &customerrors.ClientNotFoundError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.OAuthError{
+				Code:    customerrors.InvalidClientOAuthErrorCode,
+				Message: "unknown client",
+				BaseErr: &customerrors.InvalidClientError{Message: "unknown client"},
+			},
+		},
+		{
+			name: "wrong client secret",
+			tokenRequest: func() entities.OIDCTokenRequestDTO {
+				request := tokenRequest
+				request.ClientSecret = "wrong-secret"
+
+				return request
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				_ *mockservices.MockUsersService,
+			) {
+				client := *shopClient
+				client.SecretHash = hashToken(tokensConfig.SecretKey, "secret")
+				expectClient(authService, &client)
+			},
+			expectedErr: &customerrors.OAuthError{
+				Code:    customerrors.InvalidClientOAuthErrorCode,
+				Message: "client authentication failed",
+				BaseErr: &customerrors.InvalidClientError{},
+			},
+		},
+		{
+			name: "authorization code not found",
+			tokenRequest: func() entities.OIDCTokenRequestDTO {
+				return tokenRequest
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				_ *mockservices.MockUsersService,
+			) {
+				expectClient(authService, shopClient)
+
+				authService.
+					EXPECT().
+					GetAuthorizationCodeByHash(gomock.Any(), authorizationCode.CodeHash).
+					Return(nil, errors.New("not found")).
+					Times(1)
+			},
+			expectedErr: &customerrors.OAuthError{
+				Code:    customerrors.InvalidGrantOAuthErrorCode,
+				Message: "authorization code is invalid or expired",
+				BaseErr: errors.New("not found"),
+			},
+		},
+		{
+			name: "redirect uri mismatch",
+			tokenRequest: func() entities.OIDCTokenRequestDTO {
+				request := tokenRequest
+				request.RedirectURI = "https://shop.example.com/silent"
+
+				return request
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				_ *mockservices.MockUsersService,
+			) {
+				expectClient(authService, shopClient)
+
+				authService.
+					EXPECT().
+					GetAuthorizationCodeByHash(gomock.Any(), authorizationCode.CodeHash).
+					Return(authorizationCode, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.OAuthError{
+				Code:    customerrors.InvalidGrantOAuthErrorCode,
+				Message: "authorization code was issued for another client or redirect_uri",
+			},
+		},
+		{
+			name: "wrong code verifier",
+			tokenRequest: func() entities.OIDCTokenRequestDTO {
+				request := tokenRequest
+				request.CodeVerifier = "wrong-code-verifier-which-is-long-enough-for-pkce"
+
+				return request
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				_ *mockservices.MockUsersService,
+			) {
+				expectClient(authService, shopClient)
+
+				authService.
+					EXPECT().
+					GetAuthorizationCodeByHash(gomock.Any(), authorizationCode.CodeHash).
+					Return(authorizationCode, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.OAuthError{
+				Code:    customerrors.InvalidGrantOAuthErrorCode,
+				Message: "code_verifier does not match code_challenge",
+			},
+		},
+		{
+			name: "authorization code already used",
+			tokenRequest: func() entities.OIDCTokenRequestDTO {
+				return tokenRequest
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				_ *mockservices.MockUsersService,
+			) {
+				expectClient(authService, shopClient)
+
+				authService.
+					EXPECT().
+					GetAuthorizationCodeByHash(gomock.Any(), authorizationCode.CodeHash).
+					Return(authorizationCode, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					UseAuthorizationCode(gomock.Any(), uint64(2)).
+					Return(&customerrors.InvalidAuthorizationCodeError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.OAuthError{
+				Code:    customerrors.InvalidGrantOAuthErrorCode,
+				Message: "authorization code has been already used",
+				BaseErr: &customerrors.InvalidAuthorizationCodeError{},
+			},
+		},
+		{
+			name: "invalid refresh token",
+			tokenRequest: func() entities.OIDCTokenRequestDTO {
+				return entities.OIDCTokenRequestDTO{
+					GrantType:    entities.RefreshTokenGrantType,
+					ClientID:     "shop",
+					RefreshToken: "refresh-token",
+				}
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				_ *mockservices.MockUsersService,
+			) {
+				expectClient(authService, shopClient)
+
+				authService.
+					EXPECT().
+					GetRefreshTokenByValue(gomock.Any(), hashRefreshToken("refresh-token")).
+					Return(nil, errors.New("not found")).
+					Times(1)
+			},
+			expectedErr: &customerrors.OAuthError{
+				Code:    customerrors.InvalidGrantOAuthErrorCode,
+				Message: "refresh token is invalid or expired",
+				BaseErr: &security.InvalidJWTError{},
+			},
+		},
+		{
+			name: "refresh token of another client",
+			tokenRequest: func() entities.OIDCTokenRequestDTO {
+				return entities.OIDCTokenRequestDTO{
+					GrantType:    entities.RefreshTokenGrantType,
+					ClientID:     "shop",
+					RefreshToken: "refresh-token",
+				}
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				_ *mockservices.MockUsersService,
+			) {
+				expectClient(authService, shopClient)
+
+				authService.
+					EXPECT().
+					GetRefreshTokenByValue(gomock.Any(), hashRefreshToken("refresh-token")).
+					Return(
+						&entities.RefreshToken{
+							ID:       1,
+							UserID:   1,
+							ClientID: pointers.New("admin-panel"),
+							TTL:      time.Now().UTC().Add(time.Hour),
+						},
+						nil,
+					).
+					Times(1)
+			},
+			expectedErr: &customerrors.OAuthError{
+				Code:    customerrors.InvalidGrantOAuthErrorCode,
+				Message: "refresh token is invalid or expired",
+				BaseErr: &security.InvalidJWTError{},
+			},
+		},
+		{
+			name: "client credentials of public client",
+			tokenRequest: func() entities.OIDCTokenRequestDTO {
+				return entities.OIDCTokenRequestDTO{
+					GrantType: entities.ClientCredentialsGrantType,
+					ClientID:  "shop",
+				}
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				_ *mockservices.MockUsersService,
+			) {
+				expectClient(authService, shopClient)
+			},
+			expectedErr: &customerrors.OAuthError{
+				Code:    customerrors.UnauthorizedClientOAuthErrorCode,
+				Message: "client is not allowed to use grant type: client_credentials",
+				BaseErr: &customerrors.UnauthorizedClientError{
+					Message: "client is not allowed to use grant type: client_credentials",
+				},
+			},
+		},
+		{
+			name: "client credentials with scope, which is not allowed for client",
+			tokenRequest: func() entities.OIDCTokenRequestDTO {
+				return entities.OIDCTokenRequestDTO{
+					GrantType:    entities.ClientCredentialsGrantType,
+					ClientID:     "orders",
+					ClientSecret: "orders-secret",
+					Scope:        "users:write",
+				}
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				_ *mockservices.MockUsersService,
+			) {
+				expectClient(authService, ordersClient)
+			},
+			expectedErr: &customerrors.OAuthError{
+				Code:    customerrors.InvalidScopeOAuthErrorCode,
+				Message: "scope is not allowed for client: users:write",
+				BaseErr: &customerrors.InvalidScopeError{Message: "scope is not allowed for client: users:write"},
+			},
+		},
+		{
+			name: "unsupported grant type",
+			tokenRequest: func() entities.OIDCTokenRequestDTO {
+				return entities.OIDCTokenRequestDTO{GrantType: "password", ClientID: "shop"}
+			},
+			expectedErr: &customerrors.OAuthError{
+				Code:    customerrors.UnsupportedGrantTypeOAuthErrorCode,
+				Message: "grant_type is not supported: password",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authService, usersService)
+			}
+
+			tokens, err := useCases.ExchangeOIDCToken(context.Background(), tc.tokenRequest())
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr, err)
+				require.Nil(t, tokens)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, tokens)
+			require.NotEmpty(t, tokens.AccessToken)
+			require.NotEmpty(t, tokens.RefreshToken)
+			require.Equal(t, authorizationCode.Scope, tokens.Scope)
+
+			if !tc.expectIDToken {
+				return
+			}
+
+			claims := &idTokenClaims{}
+			_, err = jwt.ParseWithClaims(
+				tokens.IDToken,
+				claims,
+				func(*jwt.Token) (any, error) {
+					return []byte(securityConfig.JWT.SecretKey), nil
+				},
+			)
+			require.NoError(t, err)
+			require.Equal(t, oidcConfig.Issuer, claims.Issuer)
+			require.Equal(t, jwt.ClaimStrings{"shop"}, claims.Audience)
+			require.Equal(t, "1", claims.Subject)
+			require.Equal(t, "nonce", claims.Nonce)
+			require.Equal(t, user.Email, claims.Email)
+			require.Equal(t, pointers.New(true), claims.EmailVerified)
+
+			// Profile scope has not been granted:
+			require.Empty(t, claims.Name)
+			require.Empty(t, claims.Picture)
+		})
+	}
+}
+
+func TestUseCases_ExchangeOIDCTokenByClientCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+
+	useCases := newClientsUseCases(t, authService, usersService, cacheProvider, securityConfig)
+	expectClient(authService, ordersClient)
+
+	tokens, err := useCases.ExchangeOIDCToken(
+		context.Background(),
+		entities.OIDCTokenRequestDTO{
+			GrantType:    entities.ClientCredentialsGrantType,
+			ClientID:     "orders",
+			ClientSecret: "orders-secret",
+		},
+	)
+	require.NoError(t, err)
+	require.NotEmpty(t, tokens.AccessToken)
+	require.Equal(t, entities.BearerTokenType, tokens.TokenType)
+	require.Equal(t, time.Hour, tokens.ExpiresIn)
+	require.Equal(t, "users:read", tokens.Scope)
+
+	// Refresh token is not issued for client credentials grant:
+	require.Empty(t, tokens.RefreshToken)
+	require.Empty(t, tokens.IDToken)
+}
+
+func TestUseCases_GetUserInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:      "secret",
+			Algorithm:      "HS256",
+			AccessTokenTTL: time.Hour,
+		},
+	}
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		config.NATSConfig{},
+		logger,
+		cacheProvider,
+	)
+
+	accessToken := newAccessToken(t, securityConfig.JWT, 1, 2)
+
+	testCases := []struct {
+		name        string
+		accessToken string
+		setupMocks  func(
+			usersService *mockservices.MockUsersService,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedUserInfo *entities.UserInfo
+		errorExpected    bool
+	}{
+		{
+			name:        "success",
+			accessToken: accessToken,
+			setupMocks: func(
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(
+						&entities.User{
+							ID:             1,
+							DisplayName:    "User",
+							Email:          "test@example.com",
+							EmailConfirmed: true,
+							Phone:          pointers.New("+79998887766"),
+						},
+						nil,
+					).
+					Times(1)
+			},
+			expectedUserInfo: &entities.UserInfo{
+				Subject:       "1",
+				Email:         "test@example.com",
+				EmailVerified: true,
+				Name:          "User",
+				PhoneNumber:   "+79998887766",
+			},
+		},
+		{
+			name:          "invalid access token",
+			accessToken:   "invalid",
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(usersService, cacheProvider)
+			}
+
+			userInfo, err := useCases.GetUserInfo(context.Background(), tc.accessToken)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.Nil(t, userInfo)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedUserInfo, userInfo)
+		})
+	}
+}
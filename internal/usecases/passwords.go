@@ -0,0 +1,33 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DKhorkov/libs/logging"
+
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+)
+
+// rehashPassword hashes password of User again, if its hash was made with outdated algorithm or parameters.
+// Password is known only during login, so hashes are upgraded gradually. Failure does not prevent login,
+// because outdated hash is still valid and will be upgraded during next login.
+func (useCases *UseCases) rehashPassword(ctx context.Context, user *entities.User, password string) {
+	if !useCases.passwordHasher.NeedsRehash(user.Password) {
+		return
+	}
+
+	hashedPassword, err := useCases.passwordHasher.Hash(password)
+	if err == nil {
+		err = useCases.authService.ChangePassword(ctx, user.ID, hashedPassword)
+	}
+
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			useCases.logger,
+			fmt.Sprintf("Failed to rehash password of User with ID=%d", user.ID),
+			err,
+		)
+	}
+}
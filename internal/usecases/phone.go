@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+)
+
+const (
+	phoneCodeCachePrefix   = "phone-code"
+	phoneCodeAttemptsLimit = 5
+	russianPhoneLength     = 11
+	russianTrunkPrefix     = "8"
+	russianCountryCode     = "7"
+)
+
+// normalizePhone leaves only digits of phone and replaces trunk prefix 8 of Russian phones with country code 7,
+// because default phone rules allow both forms. So formatting of the same phone does not matter.
+func normalizePhone(phone string) string {
+	digits := strings.Map(
+		func(r rune) rune {
+			if unicode.IsDigit(r) {
+				return r
+			}
+
+			return -1
+		},
+		phone,
+	)
+
+	if len(digits) == russianPhoneLength && strings.HasPrefix(digits, russianTrunkPrefix) {
+		return russianCountryCode + digits[len(russianTrunkPrefix):]
+	}
+
+	return digits
+}
+
+func phoneCodeCacheKey(phoneVerificationCodeID uint64) string {
+	return fmt.Sprintf("%s-%d", phoneCodeCachePrefix, phoneVerificationCodeID)
+}
+
+// publishPhoneVerificationCode creates one-time code for User's phone and sends it via NATS.
+func (useCases *UseCases) publishPhoneVerificationCode(ctx context.Context, userID uint64, phone string) error {
+	code, err := generateCode()
+	if err != nil {
+		return err
+	}
+
+	if _, err = useCases.authService.CreatePhoneVerificationCode(
+		ctx,
+		entities.CreatePhoneVerificationCodeDTO{
+			UserID:   userID,
+			Phone:    phone,
+			CodeHash: hashCode(useCases.tokensConfig.SecretKey, userID, code),
+			TTL:      useCases.tokensConfig.PhoneVerification.TTL,
+		},
+	); err != nil {
+		return err
+	}
+
+	content, err := json.Marshal(
+		&entities.PhoneVerificationCodeDTO{
+			UserID: userID,
+			Phone:  phone,
+			Code:   code,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return useCases.natsPublisher.Publish(useCases.natsConfig.Subjects.SMS, content)
+}
+
+// getUserPhone returns phone of User, which can be verified.
+func getUserPhone(user *entities.User) (string, error) {
+	if user.Phone == nil || *user.Phone == "" {
+		return "", &customerrors.PhoneIsNotSetError{}
+	}
+
+	if user.PhoneConfirmed {
+		return "", &customerrors.PhoneAlreadyConfirmedError{}
+	}
+
+	return *user.Phone, nil
+}
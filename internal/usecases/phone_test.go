@@ -0,0 +1,542 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	mocknats "github.com/DKhorkov/libs/nats/mocks"
+	"github.com/DKhorkov/libs/pointers"
+	"github.com/DKhorkov/libs/security"
+
+	"github.com/DKhorkov/hmtm-sso/internal/config"
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+	mockcache "github.com/DKhorkov/hmtm-sso/mocks/cache"
+	mockservices "github.com/DKhorkov/hmtm-sso/mocks/services"
+)
+
+func TestUseCases_SendPhoneVerificationCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+
+	natsConfig := config.NATSConfig{
+		Subjects: config.NATSSubjects{
+			SMS: "sms",
+		},
+	}
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		natsConfig,
+		logger,
+		cacheProvider,
+	)
+
+	accessToken := newAccessToken(t, securityConfig.JWT, 1, 0)
+	phone := "+7 999 123-45-67"
+
+	expectUser := func(
+		usersService *mockservices.MockUsersService,
+		cacheProvider *mockcache.MockCacheProvider,
+		user *entities.User,
+	) {
+		expectAccessTokenIsNotRevoked(cacheProvider, 0)
+		expectUserIsNotBlocked(usersService, 1)
+
+		usersService.
+			EXPECT().
+			GetUserByID(gomock.Any(), uint64(1)).
+			Return(user, nil).
+			Times(1)
+	}
+
+	expectCodeIsCreated := func(authService *mockservices.MockAuthService) {
+		authService.
+			EXPECT().
+			CreatePhoneVerificationCode(gomock.Any(), gomock.Any()).
+			DoAndReturn(
+				func(_ context.Context, codeData entities.CreatePhoneVerificationCodeDTO) (uint64, error) {
+					require.Equal(t, uint64(1), codeData.UserID)
+					require.Equal(t, phone, codeData.Phone)
+					require.NotEmpty(t, codeData.CodeHash)
+					require.Equal(t, tokensConfig.PhoneVerification.TTL, codeData.TTL)
+
+					return 1, nil
+				},
+			).
+			Times(1)
+	}
+
+	testCases := []struct {
+		name       string
+		setupMocks func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+			natsPublisher *mocknats.MockPublisher,
+			logger *mocklogging.MockLogger,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectUser(usersService, cacheProvider, &entities.User{ID: 1, Phone: pointers.New(phone)})
+				expectCodeIsCreated(authService)
+
+				natsPublisher.
+					EXPECT().
+					Publish("sms", gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name: "phone is not set",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectUser(usersService, cacheProvider, &entities.User{ID: 1})
+			},
+			expectedErr: &customerrors.PhoneIsNotSetError{},
+		},
+		{
+			name: "phone is already confirmed",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectUser(
+					usersService,
+					cacheProvider,
+					&entities.User{ID: 1, Phone: pointers.New(phone), PhoneConfirmed: true},
+				)
+			},
+			expectedErr: &customerrors.PhoneAlreadyConfirmedError{},
+		},
+		{
+			name: "publish error",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectUser(usersService, cacheProvider, &entities.User{ID: 1, Phone: pointers.New(phone)})
+				expectCodeIsCreated(authService)
+
+				natsPublisher.
+					EXPECT().
+					Publish("sms", gomock.Any()).
+					Return(errors.New("nats error")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: errors.New("nats error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authService, usersService, natsPublisher, logger, cacheProvider)
+			}
+
+			err := useCases.SendPhoneVerificationCode(context.Background(), accessToken)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("invalid access token", func(t *testing.T) {
+		err := useCases.SendPhoneVerificationCode(context.Background(), "invalid")
+		require.Error(t, err)
+	})
+}
+
+func TestUseCases_VerifyPhone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		config.NATSConfig{},
+		logger,
+		cacheProvider,
+	)
+
+	accessToken := newAccessToken(t, securityConfig.JWT, 1, 0)
+	phone := "+79991234567"
+	code := "123456"
+	phoneVerificationCode := &entities.PhoneVerificationCode{
+		ID:       3,
+		UserID:   1,
+		Phone:    phone,
+		CodeHash: hashCode(tokensConfig.SecretKey, 1, code),
+	}
+
+	expectCode := func(
+		authService *mockservices.MockAuthService,
+		usersService *mockservices.MockUsersService,
+		cacheProvider *mockcache.MockCacheProvider,
+		phoneVerificationCode *entities.PhoneVerificationCode,
+		attempts int64,
+	) {
+		expectAccessTokenIsNotRevoked(cacheProvider, 0)
+		expectUserIsNotBlocked(usersService, 1)
+
+		usersService.
+			EXPECT().
+			GetUserByID(gomock.Any(), uint64(1)).
+			Return(&entities.User{ID: 1, Phone: pointers.New(phone)}, nil).
+			Times(1)
+
+		authService.
+			EXPECT().
+			GetPhoneVerificationCodeByUserID(gomock.Any(), uint64(1)).
+			Return(phoneVerificationCode, nil).
+			Times(1)
+
+		cacheProvider.
+			EXPECT().
+			IncrWithTTL(gomock.Any(), phoneCodeCacheKey(phoneVerificationCode.ID), tokensConfig.PhoneVerification.TTL).
+			Return(attempts, nil).
+			Times(1)
+	}
+
+	testCases := []struct {
+		name       string
+		code       string
+		setupMocks func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			code: code,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectCode(authService, usersService, cacheProvider, phoneVerificationCode, 1)
+
+				authService.
+					EXPECT().
+					VerifyUserPhone(gomock.Any(), uint64(1), uint64(3)).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name: "wrong code",
+			code: "654321",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectCode(authService, usersService, cacheProvider, phoneVerificationCode, 1)
+			},
+			expectedErr: &customerrors.InvalidPhoneVerificationCodeError{},
+		},
+		{
+			name: "too many attempts",
+			code: code,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectCode(authService, usersService, cacheProvider, phoneVerificationCode, phoneCodeAttemptsLimit+1)
+			},
+			expectedErr: &customerrors.InvalidPhoneVerificationCodeError{},
+		},
+		{
+			name: "attempts can not be counted",
+			code: code,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 0)
+				expectUserIsNotBlocked(usersService, 1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(&entities.User{ID: 1, Phone: pointers.New(phone)}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetPhoneVerificationCodeByUserID(gomock.Any(), uint64(1)).
+					Return(phoneVerificationCode, nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), phoneCodeCacheKey(3), tokensConfig.PhoneVerification.TTL).
+					Return(int64(0), errors.New("cache is unavailable")).
+					Times(1)
+			},
+			expectedErr: errors.New("cache is unavailable"),
+		},
+		{
+			name: "code was sent to previous phone",
+			code: code,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectCode(
+					authService,
+					usersService,
+					cacheProvider,
+					&entities.PhoneVerificationCode{
+						ID:       3,
+						UserID:   1,
+						Phone:    "+79997654321",
+						CodeHash: phoneVerificationCode.CodeHash,
+					},
+					1,
+				)
+			},
+			expectedErr: &customerrors.InvalidPhoneVerificationCodeError{},
+		},
+		{
+			name: "code not found",
+			code: code,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 0)
+				expectUserIsNotBlocked(usersService, 1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(&entities.User{ID: 1, Phone: pointers.New(phone)}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetPhoneVerificationCodeByUserID(gomock.Any(), uint64(1)).
+					Return(nil, errors.New("sql: no rows in result set")).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidPhoneVerificationCodeError{},
+		},
+		{
+			name: "phone is already confirmed",
+			code: code,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 0)
+				expectUserIsNotBlocked(usersService, 1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(&entities.User{ID: 1, Phone: pointers.New(phone), PhoneConfirmed: true}, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.PhoneAlreadyConfirmedError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authService, usersService, cacheProvider)
+			}
+
+			err := useCases.VerifyPhone(context.Background(), accessToken, tc.code)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUseCases_GetUserPhone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	usersService := mockservices.NewMockUsersService(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+
+	useCases := New(
+		mockservices.NewMockAuthService(ctrl),
+		usersService,
+		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		mocknats.NewMockPublisher(ctrl),
+		config.NATSConfig{},
+		mocklogging.NewMockLogger(ctrl),
+		mockcache.NewMockCacheProvider(ctrl),
+	)
+
+	accessToken := newAccessToken(t, securityConfig.JWT, 1, 0)
+
+	testCases := []struct {
+		name          string
+		accessToken   string
+		user          *entities.User
+		expectedPhone string
+		expectedErr   error
+	}{
+		{
+			name:          "success",
+			accessToken:   accessToken,
+			user:          &entities.User{ID: 1, Phone: pointers.New("8 999 123-45-67")},
+			expectedPhone: "79991234567",
+		},
+		{
+			name:        "phone is not set",
+			accessToken: accessToken,
+			user:        &entities.User{ID: 1},
+			expectedErr: &customerrors.PhoneIsNotSetError{},
+		},
+		{
+			name:        "invalid access token",
+			accessToken: "invalid",
+			expectedErr: &security.InvalidJWTError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.user != nil {
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(tc.user, nil).
+					Times(1)
+			}
+
+			phone, err := useCases.GetUserPhone(context.Background(), tc.accessToken)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedPhone, phone)
+		})
+	}
+}
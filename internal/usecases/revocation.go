@@ -0,0 +1,144 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+	"github.com/DKhorkov/libs/security"
+
+	"github.com/DKhorkov/hmtm-sso/internal/config"
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+)
+
+const (
+	revokedAccessTokenCachePrefix = "revoked-access-token"
+	revokedSessionCachePrefix     = "revoked-session"
+	revokedCacheValue             = 1
+)
+
+func revokedAccessTokenCacheKey(accessTokenID string) string {
+	return fmt.Sprintf("%s-%s", revokedAccessTokenCachePrefix, accessTokenID)
+}
+
+func revokedSessionCacheKey(sessionID uint64) string {
+	return fmt.Sprintf("%s-%d", revokedSessionCachePrefix, sessionID)
+}
+
+// verifyAccessToken parses access token and checks, that neither token, nor its Session were revoked,
+// and that its User has not been blocked since token was issued.
+// If revocation list is unavailable, decision is made according to configured revocation policy.
+func (useCases *UseCases) verifyAccessToken(
+	ctx context.Context,
+	accessToken string,
+) (*entities.AccessTokenPayload, error) {
+	accessTokenPayload, err := useCases.parseAccessToken(accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = useCases.checkAccessTokenRevocation(ctx, accessTokenPayload); err != nil {
+		return nil, err
+	}
+
+	blocked, err := useCases.usersService.IsUserBlocked(ctx, accessTokenPayload.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if blocked {
+		return nil, &security.InvalidJWTError{Message: "user has been blocked"}
+	}
+
+	return accessTokenPayload, nil
+}
+
+// checkAccessTokenRevocation returns error, if access token was revoked or revocation list is unavailable
+// and fail-closed revocation policy is configured.
+func (useCases *UseCases) checkAccessTokenRevocation(
+	ctx context.Context,
+	accessTokenPayload *entities.AccessTokenPayload,
+) error {
+	revoked, err := useCases.isAccessTokenRevoked(ctx, accessTokenPayload)
+	if err != nil {
+		logging.LogErrorContext(
+			ctx,
+			useCases.logger,
+			fmt.Sprintf("Failed to check revocation of access token for User with ID=%d", accessTokenPayload.UserID),
+			err,
+		)
+
+		if useCases.accessTokensConfig.RevocationPolicy == config.FailClosedRevocationPolicy {
+			return &security.InvalidJWTError{Message: "unable to check access token revocation", BaseErr: err}
+		}
+
+		return nil
+	}
+
+	if revoked {
+		return &security.InvalidJWTError{Message: "access token has been revoked"}
+	}
+
+	return nil
+}
+
+// isAccessTokenRevoked looks for access token and its Session in revocation list.
+// Error is returned only if revocation list is unavailable.
+func (useCases *UseCases) isAccessTokenRevoked(
+	ctx context.Context,
+	accessTokenPayload *entities.AccessTokenPayload,
+) (bool, error) {
+	if _, err := useCases.cacheProvider.Ping(ctx); err != nil {
+		return false, err
+	}
+
+	cacheKeys := []string{revokedAccessTokenCacheKey(accessTokenPayload.ID)}
+	if accessTokenPayload.SessionID != 0 {
+		cacheKeys = append(cacheKeys, revokedSessionCacheKey(accessTokenPayload.SessionID))
+	}
+
+	for _, cacheKey := range cacheKeys {
+		// Missing key means, that access token was not revoked:
+		if value, err := useCases.cacheProvider.Get(ctx, cacheKey); err == nil && value != "" {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// revokeAccessToken adds access token to revocation list until its expiration.
+func (useCases *UseCases) revokeAccessToken(ctx context.Context, accessTokenPayload *entities.AccessTokenPayload) {
+	ttl := time.Until(accessTokenPayload.ExpiresAt)
+	if ttl <= 0 {
+		return
+	}
+
+	useCases.addToRevocationList(ctx, revokedAccessTokenCacheKey(accessTokenPayload.ID), ttl)
+}
+
+// revokeSessions adds Sessions to revocation list to reject access tokens, which were already issued for them.
+// Such access tokens can not outlive access token TTL, so revocation list entries are stored for the same time.
+func (useCases *UseCases) revokeSessions(ctx context.Context, sessionIDs ...uint64) {
+	for _, sessionID := range sessionIDs {
+		useCases.addToRevocationList(
+			ctx,
+			revokedSessionCacheKey(sessionID),
+			useCases.securityConfig.JWT.AccessTokenTTL,
+		)
+	}
+}
+
+// addToRevocationList only logs errors, because Sessions are already ended in Database at this point
+// and access tokens will be rejected after expiration anyway.
+func (useCases *UseCases) addToRevocationList(ctx context.Context, cacheKey string, ttl time.Duration) {
+	if err := useCases.cacheProvider.Set(ctx, cacheKey, revokedCacheValue, ttl); err != nil {
+		logging.LogErrorContext(
+			ctx,
+			useCases.logger,
+			fmt.Sprintf("Failed to set cache for %s key", cacheKey),
+			err,
+		)
+	}
+}
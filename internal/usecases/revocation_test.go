@@ -0,0 +1,266 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	"github.com/DKhorkov/libs/security"
+
+	"github.com/DKhorkov/hmtm-sso/internal/config"
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	mockcache "github.com/DKhorkov/hmtm-sso/mocks/cache"
+	mockservices "github.com/DKhorkov/hmtm-sso/mocks/services"
+)
+
+func TestUseCases_VerifyAccessToken(t *testing.T) {
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:      "secret",
+			Algorithm:      "HS256",
+			AccessTokenTTL: time.Hour,
+		},
+	}
+
+	accessToken := newAccessToken(t, securityConfig.JWT, 1, 2)
+
+	testCases := []struct {
+		name             string
+		revocationPolicy string
+		setupMocks       func(
+			usersService *mockservices.MockUsersService,
+			logger *mocklogging.MockLogger,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedErr error
+	}{
+		{
+			name:             "not revoked",
+			revocationPolicy: config.FailClosedRevocationPolicy,
+			setupMocks: func(
+				usersService *mockservices.MockUsersService,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+			},
+		},
+		{
+			name:             "user blocked",
+			revocationPolicy: config.FailClosedRevocationPolicy,
+			setupMocks: func(
+				usersService *mockservices.MockUsersService,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+
+				usersService.
+					EXPECT().
+					IsUserBlocked(gomock.Any(), uint64(1)).
+					Return(true, nil).
+					Times(1)
+			},
+			expectedErr: &security.InvalidJWTError{},
+		},
+		{
+			name:             "failed to check, if user is blocked",
+			revocationPolicy: config.FailClosedRevocationPolicy,
+			setupMocks: func(
+				usersService *mockservices.MockUsersService,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+
+				usersService.
+					EXPECT().
+					IsUserBlocked(gomock.Any(), uint64(1)).
+					Return(false, errors.New("database error")).
+					Times(1)
+			},
+			expectedErr: errors.New("database error"),
+		},
+		{
+			name:             "revoked by id",
+			revocationPolicy: config.FailOpenRevocationPolicy,
+			setupMocks: func(
+				usersService *mockservices.MockUsersService,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				cacheProvider.
+					EXPECT().
+					Ping(gomock.Any()).
+					Return("PONG", nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), revokedAccessTokenCacheKey("jti")).
+					Return("1", nil).
+					Times(1)
+			},
+			expectedErr: &security.InvalidJWTError{},
+		},
+		{
+			name:             "revoked by session",
+			revocationPolicy: config.FailOpenRevocationPolicy,
+			setupMocks: func(
+				usersService *mockservices.MockUsersService,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				cacheProvider.
+					EXPECT().
+					Ping(gomock.Any()).
+					Return("PONG", nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), revokedAccessTokenCacheKey("jti")).
+					Return("", errors.New("redis: nil")).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), revokedSessionCacheKey(2)).
+					Return("1", nil).
+					Times(1)
+			},
+			expectedErr: &security.InvalidJWTError{},
+		},
+		{
+			name:             "cache unavailable with fail-open policy",
+			revocationPolicy: config.FailOpenRevocationPolicy,
+			setupMocks: func(
+				usersService *mockservices.MockUsersService,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				cacheProvider.
+					EXPECT().
+					Ping(gomock.Any()).
+					Return("", errors.New("connection refused")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				expectUserIsNotBlocked(usersService, 1)
+			},
+		},
+		{
+			name:             "cache unavailable with fail-closed policy",
+			revocationPolicy: config.FailClosedRevocationPolicy,
+			setupMocks: func(
+				usersService *mockservices.MockUsersService,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				cacheProvider.
+					EXPECT().
+					Ping(gomock.Any()).
+					Return("", errors.New("connection refused")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &security.InvalidJWTError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			logger := mocklogging.NewMockLogger(ctrl)
+			cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+			usersService := mockservices.NewMockUsersService(ctrl)
+
+			useCases := New(
+				nil,
+				usersService,
+				securityConfig,
+				newJWTProvider(t, securityConfig.JWT),
+				newPasswordHasher(t),
+				config.AccessTokensConfig{
+					Issuer:           accessTokensConfig.Issuer,
+					Audience:         accessTokensConfig.Audience,
+					RevocationPolicy: tc.revocationPolicy,
+				},
+				tokensConfig,
+				webAuthnConfig,
+				oidcConfig,
+				nil,
+				telegramConfig,
+				lockoutConfig,
+				validationConfig,
+				nil,
+				config.NATSConfig{},
+				logger,
+				cacheProvider,
+			)
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(usersService, logger, cacheProvider)
+			}
+
+			accessTokenPayload, err := useCases.verifyAccessToken(context.Background(), accessToken)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, accessTokenPayload)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, uint64(1), accessTokenPayload.UserID)
+			require.Equal(t, uint64(2), accessTokenPayload.SessionID)
+		})
+	}
+}
+
+func TestUseCases_RevokeAccessToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	useCases := &UseCases{cacheProvider: cacheProvider}
+
+	// Revocation list entry lives no longer, than access token itself:
+	cacheProvider.
+		EXPECT().
+		Set(
+			gomock.Any(),
+			revokedAccessTokenCacheKey("jti"),
+			revokedCacheValue,
+			gomock.Cond(func(ttl time.Duration) bool {
+				return ttl > 0 && ttl <= time.Minute
+			}),
+		).
+		Return(nil).
+		Times(1)
+
+	useCases.revokeAccessToken(
+		context.Background(),
+		&entities.AccessTokenPayload{ID: "jti", ExpiresAt: time.Now().Add(time.Minute)},
+	)
+
+	// Expired access token is already rejected, so it is not added to revocation list:
+	useCases.revokeAccessToken(
+		context.Background(),
+		&entities.AccessTokenPayload{ID: "expired", ExpiresAt: time.Now().Add(-time.Minute)},
+	)
+}
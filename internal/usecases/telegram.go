@@ -0,0 +1,93 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+	"github.com/DKhorkov/libs/validation"
+
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+	"github.com/DKhorkov/hmtm-sso/internal/telegram"
+)
+
+const (
+	telegramAuthCachePrefix = "telegram-auth"
+	telegramHandlePrefix    = "@"
+)
+
+func telegramAuthCacheKey(hash string) string {
+	return fmt.Sprintf("%s-%s", telegramAuthCachePrefix, strings.ToLower(hash))
+}
+
+// verifyTelegramAuth checks signature of Telegram Login Widget data. Each signed data can be used only once
+// during its lifetime. If cache is unavailable, reuse is not prevented, because data is short-lived anyway.
+func (useCases *UseCases) verifyTelegramAuth(ctx context.Context, telegramAuth entities.TelegramAuthDTO) error {
+	// Empty bot token is publicly known, so anyone could sign data with it:
+	if useCases.telegramConfig.BotToken == "" {
+		return &customerrors.IdentityProviderNotFoundError{Message: "telegram login is not configured"}
+	}
+
+	if err := telegram.Verify(
+		useCases.telegramConfig.BotToken,
+		telegram.AuthData{
+			ID:        telegramAuth.ID,
+			FirstName: telegramAuth.FirstName,
+			LastName:  telegramAuth.LastName,
+			Username:  telegramAuth.Username,
+			PhotoURL:  telegramAuth.PhotoURL,
+			AuthDate:  telegramAuth.AuthDate,
+			Hash:      telegramAuth.Hash,
+		},
+		useCases.telegramConfig.AuthTTL,
+		time.Now(),
+	); err != nil {
+		return &customerrors.InvalidTelegramAuthError{BaseErr: err}
+	}
+
+	cacheKey := telegramAuthCacheKey(telegramAuth.Hash)
+	if used, err := useCases.cacheProvider.Get(ctx, cacheKey); err == nil && used != "" {
+		return &customerrors.InvalidTelegramAuthError{Message: "telegram auth data has already been used"}
+	}
+
+	if err := useCases.cacheProvider.Set(ctx, cacheKey, 1, useCases.telegramConfig.AuthTTL); err != nil {
+		logging.LogErrorContext(
+			ctx,
+			useCases.logger,
+			fmt.Sprintf("Failed to mark telegram auth data as used for %s cache key", cacheKey),
+			err,
+		)
+	}
+
+	return nil
+}
+
+// confirmUserTelegram confirms Telegram handle of User, if it matches username of Telegram account.
+// Handle is set, if User has not provided it yet.
+func (useCases *UseCases) confirmUserTelegram(
+	ctx context.Context,
+	user *entities.User,
+	telegramAuth entities.TelegramAuthDTO,
+) error {
+	if telegramAuth.Username == "" {
+		return nil
+	}
+
+	handle := telegramHandlePrefix + telegramAuth.Username
+	if user.Telegram != nil && !strings.EqualFold(*user.Telegram, handle) {
+		return nil
+	}
+
+	if user.Telegram != nil && *user.Telegram == handle && user.TelegramConfirmed {
+		return nil
+	}
+
+	if !validation.ValidateValueByRules(handle, useCases.validationConfig.TelegramRegExps) {
+		return nil
+	}
+
+	return useCases.authService.ConfirmUserTelegram(ctx, user.ID, handle)
+}
@@ -0,0 +1,583 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	mocknats "github.com/DKhorkov/libs/nats/mocks"
+	"github.com/DKhorkov/libs/pointers"
+	"github.com/DKhorkov/libs/security"
+
+	"github.com/DKhorkov/hmtm-sso/internal/config"
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+	"github.com/DKhorkov/hmtm-sso/internal/telegram"
+	mockcache "github.com/DKhorkov/hmtm-sso/mocks/cache"
+	mockservices "github.com/DKhorkov/hmtm-sso/mocks/services"
+)
+
+// newTelegramAuth creates data of Telegram Login Widget, which is signed with bot token from test config.
+func newTelegramAuth(username string, authDate time.Time) entities.TelegramAuthDTO {
+	telegramAuth := entities.TelegramAuthDTO{
+		ID:        42,
+		FirstName: "Иван",
+		Username:  username,
+		AuthDate:  authDate.Unix(),
+	}
+
+	telegramAuth.Hash = telegram.Sign(
+		telegramConfig.BotToken,
+		telegram.AuthData{
+			ID:        telegramAuth.ID,
+			FirstName: telegramAuth.FirstName,
+			Username:  telegramAuth.Username,
+			AuthDate:  telegramAuth.AuthDate,
+		},
+	)
+
+	return telegramAuth
+}
+
+// expectTelegramAuthIsNotUsed sets up cache calls, which are made to prevent reuse of Telegram auth data.
+func expectTelegramAuthIsNotUsed(cacheProvider *mockcache.MockCacheProvider, telegramAuth entities.TelegramAuthDTO) {
+	cacheProvider.
+		EXPECT().
+		Get(gomock.Any(), telegramAuthCacheKey(telegramAuth.Hash)).
+		Return("", errors.New("redis: nil")).
+		Times(1)
+
+	cacheProvider.
+		EXPECT().
+		Set(gomock.Any(), telegramAuthCacheKey(telegramAuth.Hash), 1, telegramConfig.AuthTTL).
+		Return(nil).
+		Times(1)
+}
+
+func TestUseCases_LoginWithTelegram(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		config.NATSConfig{},
+		logger,
+		cacheProvider,
+	)
+
+	telegramAuth := newTelegramAuth("ivan_petrov", time.Now())
+	tamperedTelegramAuth := telegramAuth
+	tamperedTelegramAuth.Username = "admin"
+
+	expectLinkedUser := func(
+		authService *mockservices.MockAuthService,
+		usersService *mockservices.MockUsersService,
+		user *entities.User,
+	) {
+		authService.
+			EXPECT().
+			GetUserIdentity(gomock.Any(), entities.TelegramIdentityProvider, "42").
+			Return(&entities.UserIdentity{ID: 1, UserID: 1}, nil).
+			Times(1)
+
+		usersService.
+			EXPECT().
+			GetUserByID(gomock.Any(), uint64(1)).
+			Return(user, nil).
+			Times(1)
+	}
+
+	expectLogin := func(authService *mockservices.MockAuthService) {
+		authService.
+			EXPECT().
+			GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+			Return(nil, &customerrors.MFANotEnabledError{}).
+			Times(1)
+
+		authService.
+			EXPECT().
+			CreateSession(gomock.Any(), gomock.Any()).
+			Return(uint64(2), nil).
+			Times(1)
+
+		authService.
+			EXPECT().
+			CreateRefreshToken(gomock.Any(), refreshTokenData(1, 2, "")).
+			Return(uint64(1), nil).
+			Times(1)
+	}
+
+	testCases := []struct {
+		name       string
+		loginData  entities.LoginWithTelegramDTO
+		setupMocks func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectMFAChallenge bool
+		expectedErr        error
+	}{
+		{
+			name:      "success with confirmation of telegram",
+			loginData: entities.LoginWithTelegramDTO{TelegramAuth: telegramAuth},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectTelegramAuthIsNotUsed(cacheProvider, telegramAuth)
+				expectLinkedUser(
+					authService,
+					usersService,
+					&entities.User{ID: 1, Telegram: pointers.New("@Ivan_Petrov")},
+				)
+
+				authService.
+					EXPECT().
+					ConfirmUserTelegram(gomock.Any(), uint64(1), "@ivan_petrov").
+					Return(nil).
+					Times(1)
+
+				expectLogin(authService)
+			},
+		},
+		{
+			name:      "success with already confirmed telegram",
+			loginData: entities.LoginWithTelegramDTO{TelegramAuth: telegramAuth},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectTelegramAuthIsNotUsed(cacheProvider, telegramAuth)
+				expectLinkedUser(
+					authService,
+					usersService,
+					&entities.User{ID: 1, Telegram: pointers.New("@ivan_petrov"), TelegramConfirmed: true},
+				)
+
+				expectLogin(authService)
+			},
+		},
+		{
+			name:      "success with another telegram of user",
+			loginData: entities.LoginWithTelegramDTO{TelegramAuth: telegramAuth},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectTelegramAuthIsNotUsed(cacheProvider, telegramAuth)
+				expectLinkedUser(
+					authService,
+					usersService,
+					&entities.User{ID: 1, Telegram: pointers.New("@another_user")},
+				)
+
+				expectLogin(authService)
+			},
+		},
+		{
+			name:      "mfa enabled",
+			loginData: entities.LoginWithTelegramDTO{TelegramAuth: telegramAuth},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectTelegramAuthIsNotUsed(cacheProvider, telegramAuth)
+				expectLinkedUser(
+					authService,
+					usersService,
+					&entities.User{ID: 1, Telegram: pointers.New("@ivan_petrov"), TelegramConfirmed: true},
+				)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(&entities.TOTPSecret{ID: 1, UserID: 1, Confirmed: true}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateMFAChallenge(gomock.Any(), gomock.Any()).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectMFAChallenge: true,
+		},
+		{
+			name:        "tampered auth data",
+			loginData:   entities.LoginWithTelegramDTO{TelegramAuth: tamperedTelegramAuth},
+			expectedErr: &customerrors.InvalidTelegramAuthError{},
+		},
+		{
+			name: "expired auth data",
+			loginData: entities.LoginWithTelegramDTO{
+				TelegramAuth: newTelegramAuth("ivan_petrov", time.Now().Add(-time.Hour)),
+			},
+			expectedErr: &customerrors.InvalidTelegramAuthError{},
+		},
+		{
+			name:      "auth data has already been used",
+			loginData: entities.LoginWithTelegramDTO{TelegramAuth: telegramAuth},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), telegramAuthCacheKey(telegramAuth.Hash)).
+					Return("1", nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidTelegramAuthError{},
+		},
+		{
+			name:      "telegram is not linked",
+			loginData: entities.LoginWithTelegramDTO{TelegramAuth: telegramAuth},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectTelegramAuthIsNotUsed(cacheProvider, telegramAuth)
+
+				authService.
+					EXPECT().
+					GetUserIdentity(gomock.Any(), entities.TelegramIdentityProvider, "42").
+					Return(nil, &customerrors.UserIdentityNotFoundError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.UserIdentityNotFoundError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authService, usersService, cacheProvider)
+			}
+
+			tokens, err := useCases.LoginWithTelegram(context.Background(), tc.loginData)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, tokens)
+
+				return
+			}
+
+			require.NoError(t, err)
+			if tc.expectMFAChallenge {
+				require.NotNil(t, tokens.MFAChallenge)
+				require.Empty(t, tokens.AccessToken)
+			} else {
+				require.NotEmpty(t, tokens.AccessToken)
+				require.NotEmpty(t, tokens.RefreshToken)
+				require.Nil(t, tokens.MFAChallenge)
+			}
+		})
+	}
+
+	t.Run("telegram login is not configured", func(t *testing.T) {
+		useCases := New(
+			authService,
+			usersService,
+			securityConfig,
+			nil, // JWT is not used
+			newPasswordHasher(t),
+			accessTokensConfig,
+			tokensConfig,
+			webAuthnConfig,
+			oidcConfig,
+			nil,
+			config.TelegramConfig{},
+			lockoutConfig,
+			validationConfig,
+			natsPublisher,
+			config.NATSConfig{},
+			logger,
+			cacheProvider,
+		)
+
+		// Data, which is signed with empty bot token, must not be accepted:
+		telegramAuth := entities.TelegramAuthDTO{ID: 42, AuthDate: time.Now().Unix()}
+		telegramAuth.Hash = telegram.Sign("", telegram.AuthData{ID: 42, AuthDate: telegramAuth.AuthDate})
+
+		tokens, err := useCases.LoginWithTelegram(
+			context.Background(),
+			entities.LoginWithTelegramDTO{TelegramAuth: telegramAuth},
+		)
+		require.IsType(t, &customerrors.IdentityProviderNotFoundError{}, err)
+		require.Nil(t, tokens)
+	})
+}
+
+func TestUseCases_LinkTelegram(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		config.NATSConfig{},
+		logger,
+		cacheProvider,
+	)
+
+	accessToken := newAccessToken(t, securityConfig.JWT, 1, 0)
+	telegramAuth := newTelegramAuth("ivan_petrov", time.Now())
+	userIdentityData := entities.CreateUserIdentityDTO{
+		UserID:   1,
+		Provider: entities.TelegramIdentityProvider,
+		Subject:  "42",
+	}
+
+	expectUser := func(
+		usersService *mockservices.MockUsersService,
+		cacheProvider *mockcache.MockCacheProvider,
+		user *entities.User,
+	) {
+		expectAccessTokenIsNotRevoked(cacheProvider, 0)
+		expectUserIsNotBlocked(usersService, 1)
+
+		usersService.
+			EXPECT().
+			GetUserByID(gomock.Any(), uint64(1)).
+			Return(user, nil).
+			Times(1)
+	}
+
+	testCases := []struct {
+		name       string
+		linkData   entities.LinkTelegramDTO
+		setupMocks func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedErr error
+	}{
+		{
+			name:     "success with telegram of user",
+			linkData: entities.LinkTelegramDTO{AccessToken: accessToken, TelegramAuth: telegramAuth},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectUser(usersService, cacheProvider, &entities.User{ID: 1, Telegram: pointers.New("@ivan_petrov")})
+				expectTelegramAuthIsNotUsed(cacheProvider, telegramAuth)
+
+				authService.
+					EXPECT().
+					GetUserIdentity(gomock.Any(), entities.TelegramIdentityProvider, "42").
+					Return(nil, &customerrors.UserIdentityNotFoundError{}).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateUserIdentity(gomock.Any(), userIdentityData).
+					Return(uint64(1), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ConfirmUserTelegram(gomock.Any(), uint64(1), "@ivan_petrov").
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:     "success with setting of telegram",
+			linkData: entities.LinkTelegramDTO{AccessToken: accessToken, TelegramAuth: telegramAuth},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectUser(usersService, cacheProvider, &entities.User{ID: 1})
+				expectTelegramAuthIsNotUsed(cacheProvider, telegramAuth)
+
+				authService.
+					EXPECT().
+					GetUserIdentity(gomock.Any(), entities.TelegramIdentityProvider, "42").
+					Return(nil, &customerrors.UserIdentityNotFoundError{}).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateUserIdentity(gomock.Any(), userIdentityData).
+					Return(uint64(1), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ConfirmUserTelegram(gomock.Any(), uint64(1), "@ivan_petrov").
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:     "success with another telegram of user",
+			linkData: entities.LinkTelegramDTO{AccessToken: accessToken, TelegramAuth: telegramAuth},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectUser(usersService, cacheProvider, &entities.User{ID: 1, Telegram: pointers.New("@another_user")})
+				expectTelegramAuthIsNotUsed(cacheProvider, telegramAuth)
+
+				authService.
+					EXPECT().
+					GetUserIdentity(gomock.Any(), entities.TelegramIdentityProvider, "42").
+					Return(nil, &customerrors.UserIdentityNotFoundError{}).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateUserIdentity(gomock.Any(), userIdentityData).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+		},
+		{
+			name:     "success with already linked telegram",
+			linkData: entities.LinkTelegramDTO{AccessToken: accessToken, TelegramAuth: telegramAuth},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectUser(usersService, cacheProvider, &entities.User{ID: 1, Telegram: pointers.New("@ivan_petrov")})
+				expectTelegramAuthIsNotUsed(cacheProvider, telegramAuth)
+
+				authService.
+					EXPECT().
+					GetUserIdentity(gomock.Any(), entities.TelegramIdentityProvider, "42").
+					Return(&entities.UserIdentity{ID: 1, UserID: 1}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ConfirmUserTelegram(gomock.Any(), uint64(1), "@ivan_petrov").
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:     "telegram is linked to another user",
+			linkData: entities.LinkTelegramDTO{AccessToken: accessToken, TelegramAuth: telegramAuth},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectUser(usersService, cacheProvider, &entities.User{ID: 1})
+				expectTelegramAuthIsNotUsed(cacheProvider, telegramAuth)
+
+				authService.
+					EXPECT().
+					GetUserIdentity(gomock.Any(), entities.TelegramIdentityProvider, "42").
+					Return(&entities.UserIdentity{ID: 1, UserID: 2}, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.UserIdentityAlreadyExistsError{},
+		},
+		{
+			name:     "invalid auth data",
+			linkData: entities.LinkTelegramDTO{AccessToken: accessToken, TelegramAuth: entities.TelegramAuthDTO{ID: 42}},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectUser(usersService, cacheProvider, &entities.User{ID: 1})
+			},
+			expectedErr: &customerrors.InvalidTelegramAuthError{},
+		},
+		{
+			name:        "invalid access token",
+			linkData:    entities.LinkTelegramDTO{AccessToken: "invalid", TelegramAuth: telegramAuth},
+			expectedErr: &security.InvalidJWTError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(authService, usersService, cacheProvider)
+			}
+
+			err := useCases.LinkTelegram(context.Background(), tc.linkData)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
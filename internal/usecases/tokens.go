@@ -0,0 +1,144 @@
+package usecases
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/DKhorkov/libs/security"
+)
+
+const (
+	tokenLength          = 32 // 256 bits of entropy
+	codeLength           = 6
+	recoveryCodeLength   = 10 // base32 characters, which encode 48 random bits
+	encryptionKeyContext = "totp-secret"
+)
+
+var errInvalidCiphertext = errors.New("invalid ciphertext")
+
+// generateToken creates random token, which is safe for usage via internet.
+func generateToken() (string, error) {
+	token := make([]byte, tokenLength)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+
+	return security.RawEncode(token), nil
+}
+
+// generateCode creates random numeric code for clients, where User should type it manually.
+func generateCode() (string, error) {
+	maxValue := new(big.Int).Exp(big.NewInt(10), big.NewInt(codeLength), nil)
+
+	code, err := rand.Int(rand.Reader, maxValue)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%0*d", codeLength, code), nil
+}
+
+// hashToken returns HMAC-SHA256 of provided value, which can be safely stored in Database.
+func hashToken(secretKey, value string) string {
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte(value))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// hashRefreshToken returns SHA-256 digest of refresh token. Refresh tokens have enough entropy,
+// so digest can not be reversed and is used for lookup in Database.
+func hashRefreshToken(refreshToken string) string {
+	digest := sha256.Sum256([]byte(refreshToken))
+
+	return hex.EncodeToString(digest[:])
+}
+
+// hashCode binds code to User, because codes are too short to be unique.
+func hashCode(secretKey string, userID uint64, code string) string {
+	return hashToken(secretKey, fmt.Sprintf("%d:%s", userID, code))
+}
+
+func hashesEqual(first, second string) bool {
+	return subtle.ConstantTimeCompare([]byte(first), []byte(second)) == 1
+}
+
+// generateRecoveryCode creates random code in "XXXXX-XXXXX" format, which is easy to write down.
+func generateRecoveryCode() (string, error) {
+	code := make([]byte, recoveryCodeLength*5/8)
+	if _, err := rand.Read(code); err != nil {
+		return "", err
+	}
+
+	encoded := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(code)
+
+	return encoded[:recoveryCodeLength/2] + "-" + encoded[recoveryCodeLength/2:], nil
+}
+
+// normalizeRecoveryCode allows User to type recovery code in any case and without separator.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+// encryptSecret encrypts secret with AES-256-GCM. Key is derived from secretKey, so it is never used for
+// encryption and HMAC simultaneously.
+func encryptSecret(secretKey, secret string) (string, error) {
+	aead, err := newAEAD(secretKey)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(aead.Seal(nonce, nonce, []byte(secret), nil)), nil
+}
+
+func decryptSecret(secretKey, encryptedSecret string) (string, error) {
+	aead, err := newAEAD(secretKey)
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err := hex.DecodeString(encryptedSecret)
+	if err != nil {
+		return "", err
+	}
+
+	if len(ciphertext) < aead.NonceSize() {
+		return "", errInvalidCiphertext
+	}
+
+	nonce, ciphertext := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+
+	secret, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(secret), nil
+}
+
+func newAEAD(secretKey string) (cipher.AEAD, error) {
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte(encryptionKeyContext))
+
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
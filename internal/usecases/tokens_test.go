@@ -0,0 +1,129 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DKhorkov/libs/security"
+)
+
+func TestGenerateToken(t *testing.T) {
+	first, err := generateToken()
+	require.NoError(t, err)
+
+	second, err := generateToken()
+	require.NoError(t, err)
+
+	require.NotEqual(t, first, second)
+
+	decoded, err := security.RawDecode(first)
+	require.NoError(t, err)
+	require.Len(t, decoded, tokenLength)
+}
+
+func TestGenerateCode(t *testing.T) {
+	for range 100 {
+		code, err := generateCode()
+		require.NoError(t, err)
+		require.Len(t, code, codeLength)
+
+		for _, digit := range code {
+			require.True(t, digit >= '0' && digit <= '9')
+		}
+	}
+}
+
+func TestHashToken(t *testing.T) {
+	testCases := []struct {
+		name      string
+		secretKey string
+		first     string
+		second    string
+		equal     bool
+	}{
+		{
+			name:      "same values",
+			secretKey: "secret",
+			first:     "token",
+			second:    "token",
+			equal:     true,
+		},
+		{
+			name:      "different values",
+			secretKey: "secret",
+			first:     "token",
+			second:    "another token",
+			equal:     false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			first := hashToken(tc.secretKey, tc.first)
+			second := hashToken(tc.secretKey, tc.second)
+			require.NotEqual(t, tc.first, first)
+			require.Equal(t, tc.equal, hashesEqual(first, second))
+		})
+	}
+}
+
+func TestHashRefreshToken(t *testing.T) {
+	// Well-known SHA-256 test vector.
+	require.Equal(
+		t,
+		"ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		hashRefreshToken("abc"),
+	)
+
+	require.NotEqual(t, hashRefreshToken("token"), hashRefreshToken("another token"))
+}
+
+func TestHashCode(t *testing.T) {
+	require.Equal(t, hashCode("secret", 1, "123456"), hashCode("secret", 1, "123456"))
+	require.NotEqual(t, hashCode("secret", 1, "123456"), hashCode("secret", 2, "123456"))
+	require.NotEqual(t, hashCode("secret", 1, "123456"), hashCode("another", 1, "123456"))
+}
+
+func TestGenerateRecoveryCode(t *testing.T) {
+	first, err := generateRecoveryCode()
+	require.NoError(t, err)
+
+	second, err := generateRecoveryCode()
+	require.NoError(t, err)
+
+	require.NotEqual(t, first, second)
+	require.Len(t, first, recoveryCodeLength+1)
+	require.Equal(t, byte('-'), first[recoveryCodeLength/2])
+	require.Len(t, normalizeRecoveryCode(first), recoveryCodeLength)
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	require.Equal(t, "ABCDE23456", normalizeRecoveryCode("ABCDE-23456"))
+	require.Equal(t, "ABCDE23456", normalizeRecoveryCode("abcde 23456"))
+	require.Equal(t, "ABCDE23456", normalizeRecoveryCode("abcde23456"))
+}
+
+func TestEncryptSecret(t *testing.T) {
+	encrypted, err := encryptSecret("secret", "totp secret")
+	require.NoError(t, err)
+	require.NotContains(t, encrypted, "totp secret")
+
+	// Random nonce is used for each encryption:
+	another, err := encryptSecret("secret", "totp secret")
+	require.NoError(t, err)
+	require.NotEqual(t, encrypted, another)
+
+	decrypted, err := decryptSecret("secret", encrypted)
+	require.NoError(t, err)
+	require.Equal(t, "totp secret", decrypted)
+
+	_, err = decryptSecret("another secret", encrypted)
+	require.Error(t, err)
+
+	_, err = decryptSecret("secret", "abcd")
+	require.Error(t, err)
+
+	_, err = decryptSecret("secret", "not hex")
+	require.Error(t, err)
+}
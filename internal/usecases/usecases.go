@@ -1,13 +1,17 @@
 package usecases
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
-	"github.com/DKhorkov/libs/cache"
 	"github.com/DKhorkov/libs/logging"
 	"github.com/DKhorkov/libs/security"
 	"github.com/DKhorkov/libs/validation"
@@ -20,48 +24,80 @@ import (
 	"github.com/DKhorkov/hmtm-sso/internal/entities"
 	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
 	"github.com/DKhorkov/hmtm-sso/internal/interfaces"
+	"github.com/DKhorkov/hmtm-sso/internal/totp"
+	"github.com/DKhorkov/hmtm-sso/internal/webauthn"
 )
 
 const (
-	verifyEmailCachePrefix    = "email-verification"
-	verifyEmailLimit          = 3
-	verifyEmailTTL            = time.Minute
-	forgetPasswordCachePrefix = "forget-password"
-	forgetPasswordLimit       = 3
-	forgetPasswordTTL         = time.Minute
+	loginCodeCachePrefix         = "login-code"
+	loginCodeAttemptsLimit       = 5
+	verifyEmailCodeCachePrefix   = "verify-email-code"
+	verifyEmailCodeAttemptsLimit = 5
 )
 
 func New(
 	authService interfaces.AuthService,
 	usersService interfaces.UsersService,
 	securityConfig security.Config,
+	jwtProvider interfaces.JWTProvider,
+	passwordHasher interfaces.PasswordHasher,
+	accessTokensConfig config.AccessTokensConfig,
+	tokensConfig config.TokensConfig,
+	webAuthnConfig config.WebAuthnConfig,
+	oidcConfig config.OIDCConfig,
+	identityProviders []interfaces.IdentityProvider,
+	telegramConfig config.TelegramConfig,
+	lockoutConfig config.LockoutConfig,
 	validationConfig config.ValidationConfig,
 	natsPublisher customnats.Publisher,
 	natsConfig config.NATSConfig,
 	logger logging.Logger,
-	cacheProvider cache.Provider,
+	cacheProvider interfaces.CacheProvider,
 ) *UseCases {
+	identityProvidersByName := make(map[string]interfaces.IdentityProvider, len(identityProviders))
+	for _, identityProvider := range identityProviders {
+		identityProvidersByName[identityProvider.Name()] = identityProvider
+	}
+
 	return &UseCases{
-		authService:      authService,
-		usersService:     usersService,
-		securityConfig:   securityConfig,
-		validationConfig: validationConfig,
-		natsPublisher:    natsPublisher,
-		natsConfig:       natsConfig,
-		logger:           logger,
-		cacheProvider:    cacheProvider,
+		authService:        authService,
+		usersService:       usersService,
+		securityConfig:     securityConfig,
+		jwtProvider:        jwtProvider,
+		passwordHasher:     passwordHasher,
+		accessTokensConfig: accessTokensConfig,
+		tokensConfig:       tokensConfig,
+		webAuthnConfig:     webAuthnConfig,
+		oidcConfig:         oidcConfig,
+		identityProviders:  identityProvidersByName,
+		telegramConfig:     telegramConfig,
+		lockoutConfig:      lockoutConfig,
+		validationConfig:   validationConfig,
+		natsPublisher:      natsPublisher,
+		natsConfig:         natsConfig,
+		logger:             logger,
+		cacheProvider:      cacheProvider,
 	}
 }
 
 type UseCases struct {
-	authService      interfaces.AuthService
-	usersService     interfaces.UsersService
-	securityConfig   security.Config
-	validationConfig config.ValidationConfig
-	natsPublisher    customnats.Publisher
-	natsConfig       config.NATSConfig
-	logger           logging.Logger
-	cacheProvider    cache.Provider
+	authService        interfaces.AuthService
+	usersService       interfaces.UsersService
+	securityConfig     security.Config
+	jwtProvider        interfaces.JWTProvider
+	passwordHasher     interfaces.PasswordHasher
+	accessTokensConfig config.AccessTokensConfig
+	tokensConfig       config.TokensConfig
+	webAuthnConfig     config.WebAuthnConfig
+	oidcConfig         config.OIDCConfig
+	identityProviders  map[string]interfaces.IdentityProvider
+	telegramConfig     config.TelegramConfig
+	lockoutConfig      config.LockoutConfig
+	validationConfig   config.ValidationConfig
+	natsPublisher      customnats.Publisher
+	natsConfig         config.NATSConfig
+	logger             logging.Logger
+	cacheProvider      interfaces.CacheProvider
 }
 
 func (useCases *UseCases) RegisterUser(
@@ -85,7 +121,7 @@ func (useCases *UseCases) RegisterUser(
 		return 0, &validation.Error{Message: "invalid display name"}
 	}
 
-	hashedPassword, err := security.Hash(userData.Password, useCases.securityConfig.HashCost)
+	hashedPassword, err := useCases.passwordHasher.Hash(userData.Password)
 	if err != nil {
 		return 0, err
 	}
@@ -97,24 +133,7 @@ func (useCases *UseCases) RegisterUser(
 		return 0, err
 	}
 
-	verifyEmailDTO := &notifications.VerifyEmailDTO{
-		UserID: userID,
-	}
-
-	content, err := json.Marshal(verifyEmailDTO)
-	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			useCases.logger,
-			fmt.Sprintf(
-				"Error occurred while trying to encode data for email verification for User with ID=%d",
-				userID,
-			),
-			err,
-		)
-	}
-
-	if err = useCases.natsPublisher.Publish(useCases.natsConfig.Subjects.VerifyEmail, content); err != nil {
+	if err = useCases.publishVerifyEmailMessage(ctx, userID, userData.Email); err != nil {
 		logging.LogErrorContext(
 			ctx,
 			useCases.logger,
@@ -133,429 +152,1655 @@ func (useCases *UseCases) LoginUser(
 	ctx context.Context,
 	userData entities.LoginUserDTO,
 ) (*entities.TokensDTO, error) {
-	// Check if user with provided email exists and password is valid:
-	user, err := useCases.GetUserByEmail(ctx, userData.Email)
+	client, err := useCases.authenticateLoginClient(ctx, userData.ClientInfo)
 	if err != nil {
 		return nil, err
 	}
 
-	if !user.EmailConfirmed {
-		return nil, &customerrors.EmailIsNotConfirmedError{}
-	}
-
-	if !security.ValidateHash(userData.Password, user.Password) {
-		return nil, &customerrors.WrongPasswordError{}
+	if err = useCases.checkIPLoginLock(ctx, userData.ClientInfo.IP); err != nil {
+		return nil, err
 	}
 
-	if dbRefreshToken, err := useCases.authService.GetRefreshTokenByUserID(ctx, user.ID); err == nil {
-		if err = useCases.authService.ExpireRefreshToken(ctx, dbRefreshToken.Value); err != nil {
-			return nil, err
+	// Check if user with provided identifier exists and password is valid:
+	user, err := useCases.getUserByLoginIdentifier(ctx, userData.Identifier)
+	if err != nil {
+		var userNotFoundError *customerrors.UserNotFoundError
+		if errors.As(err, &userNotFoundError) {
+			useCases.countIPLoginFailure(ctx, userData.ClientInfo.IP)
 		}
+
+		return nil, err
 	}
 
-	// Create tokens:
-	accessToken, err := security.GenerateJWT(
-		user.ID,
-		useCases.securityConfig.JWT.SecretKey,
-		useCases.securityConfig.JWT.AccessTokenTTL,
-		useCases.securityConfig.JWT.Algorithm,
-	)
-	if err != nil {
+	// Lock is checked before password, so locked account can not be brute-forced:
+	if err = useCases.checkLoginLock(ctx, loginLockCacheKey(user.ID)); err != nil {
 		return nil, err
 	}
 
-	refreshToken, err := security.GenerateJWT(
-		accessToken,
-		useCases.securityConfig.JWT.SecretKey,
-		useCases.securityConfig.JWT.RefreshTokenTTL,
-		useCases.securityConfig.JWT.Algorithm,
-	)
-	if err != nil {
+	if !useCases.passwordHasher.Verify(userData.Password, user.Password) {
+		useCases.countIPLoginFailure(ctx, userData.ClientInfo.IP)
+
+		return nil, useCases.countLoginFailure(ctx, user.ID)
+	}
+
+	useCases.resetLoginFailures(ctx, user.ID)
+	useCases.rehashPassword(ctx, user, userData.Password)
+
+	// User remembered password, so outstanding forget-password tokens are not needed anymore:
+	if err = useCases.authService.ExpireForgetPasswordTokens(ctx, user.ID); err != nil {
 		return nil, err
 	}
 
-	// Save token to Database:
-	if _, err = useCases.authService.CreateRefreshToken(
-		ctx,
-		user.ID,
-		refreshToken,
-		useCases.securityConfig.JWT.RefreshTokenTTL,
-	); err != nil {
+	mfaEnabled, err := useCases.isMFAEnabled(ctx, user.ID)
+	if err != nil {
 		return nil, err
 	}
 
-	// Encoding refresh token for secure usage via internet:
-	encodedRefreshToken := security.RawEncode([]byte(refreshToken))
+	// Tokens are issued only after second factor is provided via CompleteMFALogin:
+	if mfaEnabled {
+		return useCases.createMFAChallenge(ctx, user)
+	}
 
-	return &entities.TokensDTO{
-		AccessToken:  accessToken,
-		RefreshToken: encodedRefreshToken,
-	}, nil
+	return useCases.loginUser(ctx, user, client, userData.ClientInfo)
 }
 
-func (useCases *UseCases) GetUserByID(ctx context.Context, id uint64) (*entities.User, error) {
-	return useCases.usersService.GetUserByID(ctx, id)
-}
+// getUserByLoginIdentifier finds User by email, phone or Telegram handle, which starts with "@".
+// Only confirmed identifiers can be used for login, because not confirmed ones could belong to someone else.
+func (useCases *UseCases) getUserByLoginIdentifier(ctx context.Context, identifier string) (*entities.User, error) {
+	identifier = strings.TrimSpace(identifier)
+
+	switch {
+	case strings.HasPrefix(identifier, telegramHandlePrefix):
+		return useCases.usersService.GetUserByTelegram(ctx, identifier)
+	case validation.ValidateValueByRules(identifier, useCases.validationConfig.PhoneRegExps):
+		return useCases.usersService.GetUserByPhone(ctx, normalizePhone(identifier))
+	default:
+		user, err := useCases.GetUserByEmail(ctx, identifier)
+		if err != nil {
+			return nil, err
+		}
 
-func (useCases *UseCases) GetUserByEmail(
-	ctx context.Context,
-	email string,
-) (*entities.User, error) {
-	return useCases.usersService.GetUserByEmail(ctx, email)
-}
+		if !user.EmailConfirmed {
+			return nil, &customerrors.EmailIsNotConfirmedError{}
+		}
 
-func (useCases *UseCases) GetUsers(ctx context.Context, pagination *entities.Pagination) ([]entities.User, error) {
-	return useCases.usersService.GetUsers(ctx, pagination)
+		return user, nil
+	}
 }
 
-func (useCases *UseCases) UpdateUserProfile(
+// LoginWithCode issues tokens for User, who has received login link or one-time code via SendLoginLink.
+// Second factor is still required for Users with enabled two-factor authentication.
+func (useCases *UseCases) LoginWithCode(
 	ctx context.Context,
-	rawUserProfileData entities.RawUpdateUserProfileDTO,
-) error {
-	if rawUserProfileData.DisplayName != nil &&
-		(!validation.ValidateValueByRules(
-			*rawUserProfileData.DisplayName,
-			useCases.validationConfig.DisplayNameRegExps,
-		) || validation.ContainsForbiddenWords(
-			*rawUserProfileData.DisplayName,
-		)) {
-		return &validation.Error{Message: "invalid display name"}
-	}
-
-	if rawUserProfileData.Phone != nil &&
-		!validation.ValidateValueByRules(
-			*rawUserProfileData.Phone,
-			useCases.validationConfig.PhoneRegExps,
-		) {
-		return &validation.Error{Message: "invalid phone"}
-	}
-
-	if rawUserProfileData.Telegram != nil &&
-		!validation.ValidateValueByRules(
-			*rawUserProfileData.Telegram,
-			useCases.validationConfig.TelegramRegExps,
-		) {
-		return &validation.Error{Message: "invalid telegram"}
+	loginData entities.LoginWithCodeDTO,
+) (*entities.TokensDTO, error) {
+	client, err := useCases.authenticateLoginClient(ctx, loginData.ClientInfo)
+	if err != nil {
+		return nil, err
 	}
 
-	accessTokenPayload, err := security.ParseJWT(
-		rawUserProfileData.AccessToken,
-		useCases.securityConfig.JWT.SecretKey,
+	var (
+		user       *entities.User
+		loginToken *entities.LoginToken
 	)
-	if err != nil {
-		return &security.InvalidJWTError{}
-	}
 
-	floatUserID, ok := accessTokenPayload.(float64)
-	if !ok {
-		return &security.InvalidJWTError{}
-	}
+	if loginData.Token != "" {
+		if loginToken, err = useCases.authService.GetLoginTokenByHash(
+			ctx,
+			hashToken(useCases.tokensConfig.SecretKey, loginData.Token),
+		); err != nil {
+			return nil, &customerrors.InvalidLoginTokenError{BaseErr: err}
+		}
 
-	userID := uint64(floatUserID)
+		if user, err = useCases.GetUserByID(ctx, loginToken.UserID); err != nil {
+			return nil, err
+		}
+	} else {
+		if user, err = useCases.GetUserByEmail(ctx, loginData.Email); err != nil {
+			return nil, err
+		}
 
-	user, err := useCases.GetUserByID(ctx, userID)
-	if err != nil {
-		return err
+		if loginToken, err = useCases.getLoginTokenByCode(ctx, user.ID, loginData.Code); err != nil {
+			return nil, err
+		}
 	}
 
-	userProfileData := entities.UpdateUserProfileDTO{
-		UserID:      user.ID,
-		DisplayName: rawUserProfileData.DisplayName,
-		Phone:       rawUserProfileData.Phone,
-		Telegram:    rawUserProfileData.Telegram,
-		Avatar:      rawUserProfileData.Avatar,
+	if !user.EmailConfirmed {
+		return nil, &customerrors.EmailIsNotConfirmedError{}
 	}
 
-	return useCases.usersService.UpdateUserProfile(ctx, userProfileData)
-}
+	if err = useCases.authService.UseLoginToken(ctx, user.ID, loginToken.ID); err != nil {
+		return nil, err
+	}
 
-func (useCases *UseCases) GetMe(ctx context.Context, accessToken string) (*entities.User, error) {
-	accessTokenPayload, err := security.ParseJWT(accessToken, useCases.securityConfig.JWT.SecretKey)
+	mfaEnabled, err := useCases.isMFAEnabled(ctx, user.ID)
 	if err != nil {
-		return nil, &security.InvalidJWTError{}
+		return nil, err
 	}
 
-	floatUserID, ok := accessTokenPayload.(float64)
-	if !ok {
-		return nil, &security.InvalidJWTError{}
+	if mfaEnabled {
+		return useCases.createMFAChallenge(ctx, user)
 	}
 
-	userID := uint64(floatUserID)
-
-	return useCases.usersService.GetUserByID(ctx, userID)
+	return useCases.loginUser(ctx, user, client, loginData.ClientInfo)
 }
 
-func (useCases *UseCases) RefreshTokens(
+// CompleteMFALogin issues tokens for User, who has passed password check during LoginUser,
+// after validation of TOTP or recovery code.
+func (useCases *UseCases) CompleteMFALogin(
 	ctx context.Context,
-	refreshToken string,
+	loginData entities.CompleteMFALoginDTO,
 ) (*entities.TokensDTO, error) {
-	// Decoding refresh token to get original JWT and compare its value with value in Database:
-	oldRefreshTokenBytes, err := security.RawDecode(refreshToken)
+	client, err := useCases.authenticateLoginClient(ctx, loginData.ClientInfo)
 	if err != nil {
-		return nil, &security.InvalidJWTError{}
+		return nil, err
 	}
 
-	// Retrieving refresh token payload to get access token from refresh token:
-	oldRefreshToken := string(oldRefreshTokenBytes)
+	challenge, err := useCases.authService.GetMFAChallengeByHash(
+		ctx,
+		hashToken(useCases.tokensConfig.SecretKey, loginData.MFAChallenge),
+	)
+	if err != nil {
+		return nil, &customerrors.InvalidMFAChallengeError{BaseErr: err}
+	}
 
-	refreshTokenPayload, err := security.ParseJWT(
-		oldRefreshToken,
-		useCases.securityConfig.JWT.SecretKey,
+	// Limiting attempts to prevent brute force of short TOTP codes:
+	attempts, err := useCases.countAttempt(
+		ctx,
+		mfaChallengeCacheKey(challenge.ID),
+		useCases.tokensConfig.MFAChallenge.TTL,
 	)
 	if err != nil {
-		return nil, &security.InvalidJWTError{}
+		return nil, err
 	}
 
-	oldAccessToken, ok := refreshTokenPayload.(string)
-	if !ok {
-		return nil, &security.InvalidJWTError{}
+	if attempts > mfaChallengeAttemptsLimit {
+		return nil, &customerrors.InvalidMFAChallengeError{Message: "too many attempts to complete mfa challenge"}
 	}
 
-	// Retrieving access token payload to get user ID:
-	accessTokenPayload, err := security.ParseJWT(
-		oldAccessToken,
-		useCases.securityConfig.JWT.SecretKey,
-		jwt.WithoutClaimsValidation(), // not validating claims due to expiration of JWT TTL
-	)
+	totpSecret, err := useCases.authService.GetTOTPSecretByUserID(ctx, challenge.UserID)
 	if err != nil {
-		return nil, &security.InvalidJWTError{}
+		return nil, err
 	}
 
-	// Selecting refresh token model from Database, if refresh token has not expired yet:
-	floatUserID, ok := accessTokenPayload.(float64)
-	if !ok {
-		return nil, &security.InvalidJWTError{}
+	if err = useCases.verifyMFACode(ctx, totpSecret, loginData.Code); err != nil {
+		return nil, err
 	}
 
-	userID := uint64(floatUserID)
+	if err = useCases.authService.ExpireMFAChallenge(ctx, challenge.ID); err != nil {
+		return nil, err
+	}
 
-	dbRefreshToken, err := useCases.authService.GetRefreshTokenByUserID(ctx, userID)
+	user, err := useCases.GetUserByID(ctx, challenge.UserID)
 	if err != nil {
-		return nil, &security.InvalidJWTError{}
+		return nil, err
 	}
 
-	// Checking if access token belongs to refresh token:
-	if oldRefreshToken != dbRefreshToken.Value {
-		return nil, &customerrors.AccessTokenDoesNotBelongToRefreshTokenError{}
+	return useCases.loginUser(ctx, user, client, loginData.ClientInfo)
+}
+
+// StartTOTPEnrollment creates new TOTP secret for User, which should be confirmed with first code
+// via ConfirmTOTPEnrollment to enable two-factor authentication.
+func (useCases *UseCases) StartTOTPEnrollment(
+	ctx context.Context,
+	accessToken string,
+) (*entities.TOTPEnrollmentDTO, error) {
+	accessTokenPayload, err := useCases.verifyAccessToken(ctx, accessToken)
+	if err != nil {
+		return nil, err
 	}
 
-	// Expiring old refresh token in Database to have only one valid refresh token instance:
-	if err = useCases.authService.ExpireRefreshToken(ctx, dbRefreshToken.Value); err != nil {
-		return nil, &security.InvalidJWTError{}
+	user, err := useCases.GetUserByID(ctx, accessTokenPayload.UserID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Create tokens:
-	newAccessToken, err := security.GenerateJWT(
-		userID,
-		useCases.securityConfig.JWT.SecretKey,
-		useCases.securityConfig.JWT.AccessTokenTTL,
-		useCases.securityConfig.JWT.Algorithm,
-	)
+	mfaEnabled, err := useCases.isMFAEnabled(ctx, user.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	newRefreshToken, err := security.GenerateJWT(
-		newAccessToken,
-		useCases.securityConfig.JWT.SecretKey,
-		useCases.securityConfig.JWT.RefreshTokenTTL,
-		useCases.securityConfig.JWT.Algorithm,
-	)
+	if mfaEnabled {
+		return nil, &customerrors.MFAAlreadyEnabledError{}
+	}
+
+	secret, err := totp.GenerateSecret()
 	if err != nil {
 		return nil, err
 	}
 
-	// Save token to Database:
-	if _, err = useCases.authService.CreateRefreshToken(
+	encryptedSecret, err := encryptSecret(useCases.tokensConfig.SecretKey, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = useCases.authService.CreateTOTPSecret(
 		ctx,
-		userID,
-		newRefreshToken,
-		useCases.securityConfig.JWT.RefreshTokenTTL,
+		entities.CreateTOTPSecretDTO{
+			UserID: user.ID,
+			Secret: encryptedSecret,
+		},
 	); err != nil {
 		return nil, err
 	}
 
-	// Encoding refresh token for secure usage via internet:
-	encodedRefreshToken := security.RawEncode([]byte(newRefreshToken))
-
-	return &entities.TokensDTO{
-		AccessToken:  newAccessToken,
-		RefreshToken: encodedRefreshToken,
+	return &entities.TOTPEnrollmentDTO{
+		Secret: secret,
+		URI:    totp.URI(useCases.accessTokensConfig.Issuer, user.Email, secret),
 	}, nil
 }
 
-func (useCases *UseCases) LogoutUser(ctx context.Context, accessToken string) error {
-	accessTokenPayload, err := security.ParseJWT(accessToken, useCases.securityConfig.JWT.SecretKey)
+// ConfirmTOTPEnrollment enables two-factor authentication and returns recovery codes,
+// which are shown to User only once.
+func (useCases *UseCases) ConfirmTOTPEnrollment(ctx context.Context, accessToken, code string) ([]string, error) {
+	accessTokenPayload, err := useCases.verifyAccessToken(ctx, accessToken)
 	if err != nil {
-		return &security.InvalidJWTError{}
+		return nil, err
 	}
 
-	floatUserID, ok := accessTokenPayload.(float64)
-	if !ok {
-		return &security.InvalidJWTError{}
+	totpSecret, err := useCases.authService.GetTOTPSecretByUserID(ctx, accessTokenPayload.UserID)
+	if err != nil {
+		return nil, err
 	}
 
-	userID := uint64(floatUserID)
+	if totpSecret.Confirmed {
+		return nil, &customerrors.MFAAlreadyEnabledError{}
+	}
 
-	refreshToken, _ := useCases.authService.GetRefreshTokenByUserID(ctx, userID)
-	if refreshToken == nil {
-		return nil
+	step, err := useCases.validateTOTPCode(totpSecret, code)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes := make([]string, recoveryCodesCount)
+	recoveryCodeHashes := make([]string, recoveryCodesCount)
+
+	for i := range recoveryCodes {
+		if recoveryCodes[i], err = generateRecoveryCode(); err != nil {
+			return nil, err
+		}
+
+		recoveryCodeHashes[i] = hashRecoveryCode(
+			useCases.tokensConfig.SecretKey,
+			accessTokenPayload.UserID,
+			recoveryCodes[i],
+		)
+	}
+
+	if err = useCases.authService.ConfirmTOTPSecret(
+		ctx,
+		entities.ConfirmTOTPSecretDTO{
+			TOTPSecretID:       totpSecret.ID,
+			UserID:             accessTokenPayload.UserID,
+			Step:               step,
+			RecoveryCodeHashes: recoveryCodeHashes,
+		},
+	); err != nil {
+		return nil, err
 	}
 
-	return useCases.authService.ExpireRefreshToken(ctx, refreshToken.Value)
+	return recoveryCodes, nil
 }
 
-func (useCases *UseCases) VerifyUserEmail(ctx context.Context, verifyEmailToken string) error {
-	strUserID, err := security.RawDecode(verifyEmailToken)
+// DisableTOTP disables two-factor authentication after validation of TOTP or recovery code,
+// so stolen access token is not enough to turn it off.
+func (useCases *UseCases) DisableTOTP(ctx context.Context, accessToken, code string) error {
+	accessTokenPayload, err := useCases.verifyAccessToken(ctx, accessToken)
 	if err != nil {
 		return err
 	}
 
-	intUserID, err := strconv.Atoi(string(strUserID))
+	totpSecret, err := useCases.authService.GetTOTPSecretByUserID(ctx, accessTokenPayload.UserID)
 	if err != nil {
 		return err
 	}
 
-	user, err := useCases.GetUserByID(ctx, uint64(intUserID))
-	if err != nil {
-		return err
+	if !totpSecret.Confirmed {
+		return &customerrors.MFANotEnabledError{}
 	}
 
-	if user.EmailConfirmed {
-		return &customerrors.EmailAlreadyConfirmedError{}
+	if err = useCases.verifyMFACode(ctx, totpSecret, code); err != nil {
+		return err
 	}
 
-	return useCases.authService.VerifyUserEmail(ctx, user.ID)
+	return useCases.authService.DeleteTOTPSecret(ctx, accessTokenPayload.UserID)
 }
 
-func (useCases *UseCases) ForgetPassword(ctx context.Context, forgetPasswordToken, newPassword string) error {
-	if !validation.ValidateValueByRules(newPassword, useCases.validationConfig.PasswordRegExps) {
-		return &validation.Error{Message: "invalid password"}
-	}
-
-	strUserID, err := security.RawDecode(forgetPasswordToken)
+// BeginWebAuthnRegistration returns JSON encoded options for navigator.credentials.create(),
+// which are used to register new passkey for User.
+func (useCases *UseCases) BeginWebAuthnRegistration(ctx context.Context, accessToken string) (string, error) {
+	accessTokenPayload, err := useCases.verifyAccessToken(ctx, accessToken)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	intUserID, err := strconv.Atoi(string(strUserID))
+	user, err := useCases.GetUserByID(ctx, accessTokenPayload.UserID)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	user, err := useCases.GetUserByID(ctx, uint64(intUserID))
+	credentials, err := useCases.authService.GetUserWebAuthnCredentials(ctx, user.ID)
 	if err != nil {
-		return err
-	}
-
-	if security.ValidateHash(newPassword, user.Password) {
-		return &validation.Error{Message: "new password can not be equal to old password"}
+		return "", err
 	}
 
-	hashedPassword, err := security.Hash(newPassword, useCases.securityConfig.HashCost)
+	challenge, err := useCases.createWebAuthnChallenge(ctx, &user.ID, entities.WebAuthnRegistrationCeremony)
 	if err != nil {
-		return err
-	}
+		return "", err
+	}
+
+	options := webauthn.NewCreationOptions(
+		webauthn.RelyingParty{
+			ID:   useCases.webAuthnConfig.RPID,
+			Name: useCases.webAuthnConfig.RPName,
+		},
+		webauthn.User{
+			ID:          webauthn.Encoding.EncodeToString(webAuthnUserHandle(user.ID)),
+			Name:        user.Email,
+			DisplayName: user.DisplayName,
+		},
+		challenge,
+		useCases.tokensConfig.WebAuthnChallenge.TTL,
+		webAuthnCredentialDescriptors(credentials),
+	)
 
-	return useCases.authService.ForgetPassword(ctx, user.ID, hashedPassword)
+	return marshalWebAuthnOptions(options)
 }
 
-func (useCases *UseCases) ChangePassword(
+// FinishWebAuthnRegistration verifies response of authenticator and saves new passkey for User.
+func (useCases *UseCases) FinishWebAuthnRegistration(
 	ctx context.Context,
-	accessToken string,
-	oldPassword string,
-	newPassword string,
+	registrationData entities.FinishWebAuthnRegistrationDTO,
 ) error {
-	if oldPassword == newPassword {
-		return &validation.Error{Message: "new password can not be equal to old password"}
-	}
-
-	if !validation.ValidateValueByRules(newPassword, useCases.validationConfig.PasswordRegExps) {
-		return &validation.Error{Message: "invalid password"}
+	accessTokenPayload, err := useCases.verifyAccessToken(ctx, registrationData.AccessToken)
+	if err != nil {
+		return err
 	}
 
-	accessTokenPayload, err := security.ParseJWT(accessToken, useCases.securityConfig.JWT.SecretKey)
+	challenge, err := useCases.getWebAuthnChallenge(
+		ctx,
+		registrationData.ClientDataJSON,
+		webauthn.CreateCeremony,
+		entities.WebAuthnRegistrationCeremony,
+	)
 	if err != nil {
-		return &security.InvalidJWTError{}
+		return err
 	}
 
-	floatUserID, ok := accessTokenPayload.(float64)
-	if !ok {
-		return &security.InvalidJWTError{}
+	if challenge.UserID == nil || *challenge.UserID != accessTokenPayload.UserID {
+		return &customerrors.InvalidWebAuthnChallengeError{Message: "webauthn challenge was issued for another user"}
 	}
 
-	userID := uint64(floatUserID)
-
-	user, err := useCases.GetUserByID(ctx, userID)
+	authenticatorData, err := webauthn.ParseAttestationObject(
+		registrationData.AttestationObject,
+		useCases.webAuthnConfig.RPID,
+	)
 	if err != nil {
-		return err
+		return &customerrors.InvalidWebAuthnCredentialError{BaseErr: err}
 	}
 
-	if !security.ValidateHash(oldPassword, user.Password) {
-		return &customerrors.WrongPasswordError{}
+	credentialID := webauthn.Encoding.EncodeToString(authenticatorData.CredentialID)
+	if credentialID != registrationData.CredentialID {
+		return &customerrors.InvalidWebAuthnCredentialError{
+			Message: "credential ID does not match attested credential",
+		}
 	}
 
-	hashedPassword, err := security.Hash(newPassword, useCases.securityConfig.HashCost)
-	if err != nil {
+	_, err = useCases.authService.GetWebAuthnCredential(ctx, credentialID)
+
+	var credentialNotFoundError *customerrors.WebAuthnCredentialNotFoundError
+
+	switch {
+	case err == nil:
+		return &customerrors.InvalidWebAuthnCredentialError{Message: "webauthn credential is already registered"}
+	case !errors.As(err, &credentialNotFoundError):
 		return err
 	}
 
-	return useCases.authService.ChangePassword(ctx, userID, hashedPassword)
-}
+	if err = useCases.authService.ExpireWebAuthnChallenge(ctx, challenge.ID); err != nil {
+		return err
+	}
 
-func (useCases *UseCases) SendVerifyEmailMessage(ctx context.Context, email string) error {
-	var counter int64
+	_, err = useCases.authService.CreateWebAuthnCredential(
+		ctx,
+		entities.CreateWebAuthnCredentialDTO{
+			UserID:       accessTokenPayload.UserID,
+			CredentialID: credentialID,
+			PublicKey:    authenticatorData.PublicKey,
+			SignCount:    authenticatorData.SignCount,
+			Transports:   strings.Join(registrationData.Transports, webAuthnTransportsSeparator),
+		},
+	)
 
-	cacheKey := fmt.Sprintf("%s-%s", verifyEmailCachePrefix, email)
+	return err
+}
 
-	if _, err := useCases.cacheProvider.Ping(ctx); err == nil {
-		var strCounter string
+// BeginWebAuthnLogin returns JSON encoded options for navigator.credentials.get(). If email is not provided,
+// User chooses one of discoverable credentials, which are stored by authenticator.
+func (useCases *UseCases) BeginWebAuthnLogin(ctx context.Context, email string) (string, error) {
+	var (
+		userID      *uint64
+		credentials []entities.WebAuthnCredential
+	)
 
-		if strCounter, err = useCases.cacheProvider.Get(ctx, cacheKey); err != nil {
-			logging.LogErrorContext(
-				ctx,
-				useCases.logger,
-				fmt.Sprintf("Failed to get cache for %s key", cacheKey),
-				err,
-			)
+	if email != "" {
+		user, err := useCases.GetUserByEmail(ctx, email)
+		if err != nil {
+			return "", err
 		}
 
-		if counter, err = strconv.ParseInt(strCounter, 10, 64); err != nil && strCounter != "" {
-			logging.LogErrorContext(
-				ctx,
-				useCases.logger,
-				fmt.Sprintf("Invalid value=%s for %s cache key", strCounter, cacheKey),
-				err,
-			)
+		if credentials, err = useCases.authService.GetUserWebAuthnCredentials(ctx, user.ID); err != nil {
+			return "", err
 		}
 
-		if counter >= verifyEmailLimit {
-			return &customerrors.LimitExceededError{
-				Message: fmt.Sprintf("Too many tries to send message. Limit per minute is %d", verifyEmailLimit),
-			}
+		if len(credentials) == 0 {
+			return "", &customerrors.WebAuthnCredentialNotFoundError{}
 		}
+
+		userID = &user.ID
 	}
 
-	user, err := useCases.GetUserByEmail(ctx, email)
+	challenge, err := useCases.createWebAuthnChallenge(ctx, userID, entities.WebAuthnLoginCeremony)
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	options := webauthn.NewRequestOptions(
+		useCases.webAuthnConfig.RPID,
+		challenge,
+		useCases.tokensConfig.WebAuthnChallenge.TTL,
+		webAuthnCredentialDescriptors(credentials),
+	)
+
+	return marshalWebAuthnOptions(options)
+}
+
+// FinishWebAuthnLogin verifies assertion of authenticator and issues tokens. Passkey requires user verification,
+// so two-factor authentication is not required for this login method.
+func (useCases *UseCases) FinishWebAuthnLogin(
+	ctx context.Context,
+	loginData entities.FinishWebAuthnLoginDTO,
+) (*entities.TokensDTO, error) {
+	client, err := useCases.authenticateLoginClient(ctx, loginData.ClientInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := useCases.getWebAuthnChallenge(
+		ctx,
+		loginData.ClientDataJSON,
+		webauthn.GetCeremony,
+		entities.WebAuthnLoginCeremony,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := useCases.authService.GetWebAuthnCredential(ctx, loginData.CredentialID)
+	if err != nil {
+		return nil, err
+	}
+
+	if challenge.UserID != nil && *challenge.UserID != credential.UserID {
+		return nil, &customerrors.InvalidWebAuthnCredentialError{
+			Message: "webauthn credential is not allowed for this challenge",
+		}
+	}
+
+	// User handle is returned by authenticator only for discoverable credentials:
+	if len(loginData.UserHandle) != 0 &&
+		!bytes.Equal(loginData.UserHandle, webAuthnUserHandle(credential.UserID)) {
+		return nil, &customerrors.InvalidWebAuthnCredentialError{
+			Message: "user handle does not match webauthn credential",
+		}
+	}
+
+	authenticatorData, err := webauthn.ParseAuthenticatorData(
+		loginData.AuthenticatorData,
+		useCases.webAuthnConfig.RPID,
+	)
+	if err != nil {
+		return nil, &customerrors.InvalidWebAuthnCredentialError{BaseErr: err}
+	}
+
+	if err = webauthn.VerifyAssertion(
+		credential.PublicKey,
+		loginData.AuthenticatorData,
+		loginData.ClientDataJSON,
+		loginData.Signature,
+	); err != nil {
+		return nil, &customerrors.InvalidWebAuthnCredentialError{BaseErr: err}
+	}
+
+	if err = useCases.authService.ExpireWebAuthnChallenge(ctx, challenge.ID); err != nil {
+		return nil, err
+	}
+
+	// Authenticators without counter always return zero. Otherwise, counter, which has not increased,
+	// means that credential private key was cloned:
+	if authenticatorData.SignCount != 0 || credential.SignCount != 0 {
+		if err = useCases.authService.UpdateWebAuthnCredentialSignCount(
+			ctx,
+			credential.ID,
+			authenticatorData.SignCount,
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	user, err := useCases.GetUserByID(ctx, credential.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.EmailConfirmed {
+		return nil, &customerrors.EmailIsNotConfirmedError{}
+	}
+
+	return useCases.loginUser(ctx, user, client, loginData.ClientInfo)
+}
+
+// BeginFederatedLogin returns URL of identity provider, which User should be redirected to. Returned URL contains
+// state, which should be passed to FinishFederatedLogin with authorization code after User returns from provider.
+func (useCases *UseCases) BeginFederatedLogin(ctx context.Context, provider string) (string, error) {
+	identityProvider, ok := useCases.identityProviders[provider]
+	if !ok {
+		return "", &customerrors.IdentityProviderNotFoundError{}
+	}
+
+	codeVerifier, err := generateToken()
+	if err != nil {
+		return "", err
+	}
+
+	state, err := useCases.createFederatedLoginState(provider, codeVerifier)
+	if err != nil {
+		return "", err
+	}
+
+	return identityProvider.AuthCodeURL(state, codeChallengeS256(codeVerifier)), nil
+}
+
+// FinishFederatedLogin exchanges authorization code of identity provider and issues tokens for linked User.
+// On first login User is linked by email, which is verified by provider, or registered.
+// Second factor is still required for Users with enabled two-factor authentication.
+func (useCases *UseCases) FinishFederatedLogin(
+	ctx context.Context,
+	loginData entities.FinishFederatedLoginDTO,
+) (*entities.TokensDTO, error) {
+	client, err := useCases.authenticateLoginClient(ctx, loginData.ClientInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	identityProvider, ok := useCases.identityProviders[loginData.Provider]
+	if !ok {
+		return nil, &customerrors.IdentityProviderNotFoundError{}
+	}
+
+	loginState, err := useCases.parseFederatedLoginState(loginData.Provider, loginData.State)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := identityProvider.Exchange(ctx, loginData.Code, loginState.CodeVerifier)
+	if err != nil {
+		return nil, &customerrors.FederatedLoginError{BaseErr: err}
+	}
+
+	user, err := useCases.getFederatedUser(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	mfaEnabled, err := useCases.isMFAEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if mfaEnabled {
+		return useCases.createMFAChallenge(ctx, user)
+	}
+
+	return useCases.loginUser(ctx, user, client, loginData.ClientInfo)
+}
+
+// LoginWithTelegram issues tokens for User, who has linked Telegram account via LinkTelegram.
+// Second factor is still required for Users with enabled two-factor authentication.
+func (useCases *UseCases) LoginWithTelegram(
+	ctx context.Context,
+	loginData entities.LoginWithTelegramDTO,
+) (*entities.TokensDTO, error) {
+	client, err := useCases.authenticateLoginClient(ctx, loginData.ClientInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = useCases.verifyTelegramAuth(ctx, loginData.TelegramAuth); err != nil {
+		return nil, err
+	}
+
+	userIdentity, err := useCases.authService.GetUserIdentity(
+		ctx,
+		entities.TelegramIdentityProvider,
+		strconv.FormatInt(loginData.TelegramAuth.ID, 10),
+	)
+	if err != nil {
+		var userIdentityNotFoundError *customerrors.UserIdentityNotFoundError
+		if errors.As(err, &userIdentityNotFoundError) {
+			return nil, &customerrors.UserIdentityNotFoundError{
+				Message: "telegram account is not linked to any user",
+			}
+		}
+
+		return nil, err
+	}
+
+	user, err := useCases.GetUserByID(ctx, userIdentity.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = useCases.confirmUserTelegram(ctx, user, loginData.TelegramAuth); err != nil {
+		return nil, err
+	}
+
+	mfaEnabled, err := useCases.isMFAEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if mfaEnabled {
+		return useCases.createMFAChallenge(ctx, user)
+	}
+
+	return useCases.loginUser(ctx, user, client, loginData.ClientInfo)
+}
+
+// LinkTelegram links Telegram account to logged-in User, so User can log in via LoginWithTelegram.
+// Telegram handle of User becomes confirmed, if it matches username of Telegram account.
+func (useCases *UseCases) LinkTelegram(ctx context.Context, linkData entities.LinkTelegramDTO) error {
+	accessTokenPayload, err := useCases.verifyAccessToken(ctx, linkData.AccessToken)
+	if err != nil {
+		return err
+	}
+
+	user, err := useCases.GetUserByID(ctx, accessTokenPayload.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err = useCases.verifyTelegramAuth(ctx, linkData.TelegramAuth); err != nil {
+		return err
+	}
+
+	subject := strconv.FormatInt(linkData.TelegramAuth.ID, 10)
+
+	userIdentity, err := useCases.authService.GetUserIdentity(ctx, entities.TelegramIdentityProvider, subject)
+	if err != nil {
+		var userIdentityNotFoundError *customerrors.UserIdentityNotFoundError
+		if !errors.As(err, &userIdentityNotFoundError) {
+			return err
+		}
+
+		if _, err = useCases.authService.CreateUserIdentity(
+			ctx,
+			entities.CreateUserIdentityDTO{
+				UserID:   user.ID,
+				Provider: entities.TelegramIdentityProvider,
+				Subject:  subject,
+			},
+		); err != nil {
+			return err
+		}
+	} else if userIdentity.UserID != user.ID {
+		return &customerrors.UserIdentityAlreadyExistsError{
+			Message: "telegram account is already linked to another user",
+		}
+	}
+
+	return useCases.confirmUserTelegram(ctx, user, linkData.TelegramAuth)
+}
+
+// SendPhoneVerificationCode sends one-time code via SMS to phone from profile of User. SMS costs money, so sending
+// is limited per phone by rate limit policies, and several accounts can not flood one phone.
+func (useCases *UseCases) SendPhoneVerificationCode(ctx context.Context, accessToken string) error {
+	accessTokenPayload, err := useCases.verifyAccessToken(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+
+	user, err := useCases.GetUserByID(ctx, accessTokenPayload.UserID)
+	if err != nil {
+		return err
+	}
+
+	phone, err := getUserPhone(user)
+	if err != nil {
+		return err
+	}
+
+	if err = useCases.publishPhoneVerificationCode(ctx, user.ID, phone); err != nil {
+		logging.LogErrorContext(
+			ctx,
+			useCases.logger,
+			fmt.Sprintf(
+				"Error occurred while trying send phone verification code to User with ID=%d",
+				user.ID,
+			),
+			err,
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+// GetUserPhone returns normalized phone from profile of User, who owns access token. Revocation of token is not
+// checked, so it is cheap enough for checks, which are made before call, such as rate limiting of SMS.
+func (useCases *UseCases) GetUserPhone(ctx context.Context, accessToken string) (string, error) {
+	accessTokenPayload, err := useCases.parseAccessToken(accessToken)
+	if err != nil {
+		return "", err
+	}
+
+	user, err := useCases.GetUserByID(ctx, accessTokenPayload.UserID)
+	if err != nil {
+		return "", err
+	}
+
+	phone, err := getUserPhone(user)
+	if err != nil {
+		return "", err
+	}
+
+	return normalizePhone(phone), nil
+}
+
+// VerifyPhone confirms phone of User with code, which was sent via SendPhoneVerificationCode.
+// Attempts are limited to prevent brute force of short codes.
+func (useCases *UseCases) VerifyPhone(ctx context.Context, accessToken, code string) error {
+	accessTokenPayload, err := useCases.verifyAccessToken(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+
+	user, err := useCases.GetUserByID(ctx, accessTokenPayload.UserID)
+	if err != nil {
+		return err
+	}
+
+	phone, err := getUserPhone(user)
+	if err != nil {
+		return err
+	}
+
+	phoneVerificationCode, err := useCases.authService.GetPhoneVerificationCodeByUserID(ctx, user.ID)
+	if err != nil {
+		return &customerrors.InvalidPhoneVerificationCodeError{BaseErr: err}
+	}
+
+	attempts, err := useCases.countAttempt(
+		ctx,
+		phoneCodeCacheKey(phoneVerificationCode.ID),
+		useCases.tokensConfig.PhoneVerification.TTL,
+	)
+	if err != nil {
+		return err
+	}
+
+	if attempts > phoneCodeAttemptsLimit {
+		return &customerrors.InvalidPhoneVerificationCodeError{Message: "too many attempts to verify phone"}
+	}
+
+	if !hashesEqual(phoneVerificationCode.CodeHash, hashCode(useCases.tokensConfig.SecretKey, user.ID, code)) {
+		return &customerrors.InvalidPhoneVerificationCodeError{}
+	}
+
+	// Code is bound to phone, which User had during code creation:
+	if phoneVerificationCode.Phone != phone {
+		return &customerrors.InvalidPhoneVerificationCodeError{}
+	}
+
+	return useCases.authService.VerifyUserPhone(ctx, user.ID, phoneVerificationCode.ID)
+}
+
+func (useCases *UseCases) GetUserByID(ctx context.Context, id uint64) (*entities.User, error) {
+	return useCases.usersService.GetUserByID(ctx, id)
+}
+
+func (useCases *UseCases) GetUserByEmail(
+	ctx context.Context,
+	email string,
+) (*entities.User, error) {
+	return useCases.usersService.GetUserByEmail(ctx, email)
+}
+
+func (useCases *UseCases) GetUsers(ctx context.Context, pagination *entities.Pagination) ([]entities.User, error) {
+	return useCases.usersService.GetUsers(ctx, pagination)
+}
+
+func (useCases *UseCases) UpdateUserProfile(
+	ctx context.Context,
+	rawUserProfileData entities.RawUpdateUserProfileDTO,
+) error {
+	if rawUserProfileData.DisplayName != nil &&
+		(!validation.ValidateValueByRules(
+			*rawUserProfileData.DisplayName,
+			useCases.validationConfig.DisplayNameRegExps,
+		) || validation.ContainsForbiddenWords(
+			*rawUserProfileData.DisplayName,
+		)) {
+		return &validation.Error{Message: "invalid display name"}
+	}
+
+	if rawUserProfileData.Phone != nil &&
+		!validation.ValidateValueByRules(
+			*rawUserProfileData.Phone,
+			useCases.validationConfig.PhoneRegExps,
+		) {
+		return &validation.Error{Message: "invalid phone"}
+	}
+
+	if rawUserProfileData.Telegram != nil &&
+		!validation.ValidateValueByRules(
+			*rawUserProfileData.Telegram,
+			useCases.validationConfig.TelegramRegExps,
+		) {
+		return &validation.Error{Message: "invalid telegram"}
+	}
+
+	accessTokenPayload, err := useCases.verifyAccessToken(ctx, rawUserProfileData.AccessToken)
+	if err != nil {
+		return err
+	}
+
+	user, err := useCases.GetUserByID(ctx, accessTokenPayload.UserID)
+	if err != nil {
+		return err
+	}
+
+	userProfileData := entities.UpdateUserProfileDTO{
+		UserID:      user.ID,
+		DisplayName: rawUserProfileData.DisplayName,
+		Phone:       rawUserProfileData.Phone,
+		Telegram:    rawUserProfileData.Telegram,
+		Avatar:      rawUserProfileData.Avatar,
+	}
+
+	return useCases.usersService.UpdateUserProfile(ctx, userProfileData)
+}
+
+func (useCases *UseCases) GetMe(ctx context.Context, accessToken string) (*entities.User, error) {
+	accessTokenPayload, err := useCases.verifyAccessToken(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return useCases.usersService.GetUserByID(ctx, accessTokenPayload.UserID)
+}
+
+// UnlockUser removes lockout of User's account after failed login attempts. Only administrators can unlock Users.
+func (useCases *UseCases) UnlockUser(ctx context.Context, accessToken string, userID uint64) error {
+	if err := useCases.verifyAdministrator(ctx, accessToken); err != nil {
+		return err
+	}
+
+	user, err := useCases.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	useCases.unlockLogin(ctx, user.ID)
+
+	return nil
+}
+
+func (useCases *UseCases) RefreshTokens(
+	ctx context.Context,
+	refreshToken string,
+) (*entities.TokensDTO, error) {
+	return useCases.refreshTokens(ctx, refreshToken, nil)
+}
+
+// refreshTokens rotates refresh token. If authenticated Client is provided, refresh token must have been
+// issued for this Client. New tokens are issued with settings of Client, which refresh token belongs to.
+func (useCases *UseCases) refreshTokens(
+	ctx context.Context,
+	refreshToken string,
+	authenticatedClient *entities.Client,
+) (*entities.TokensDTO, error) {
+	dbRefreshToken, err := useCases.getRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if authenticatedClient != nil &&
+		(dbRefreshToken.ClientID == nil || *dbRefreshToken.ClientID != authenticatedClient.ClientID) {
+		return nil, &security.InvalidJWTError{}
+	}
+
+	familyID, err := useCases.getRefreshTokenFamilyID(dbRefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rotated refresh token can be presented only by someone, who has stolen it, or by legitimate client,
+	// whose token has been already used by someone else. In both cases the whole family is compromised:
+	if dbRefreshToken.RotatedAt != nil {
+		return nil, useCases.revokeRefreshTokenFamily(ctx, dbRefreshToken, familyID)
+	}
+
+	if !dbRefreshToken.TTL.After(time.Now().UTC()) {
+		return nil, &security.InvalidJWTError{}
+	}
+
+	client, err := useCases.getRefreshTokenClient(ctx, dbRefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// User's roles could be changed since previous access token was issued:
+	user, err := useCases.usersService.GetUserByID(ctx, dbRefreshToken.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Blocked {
+		return nil, &customerrors.UserBlockedError{}
+	}
+
+	// Rotating old refresh token in Database to have only one valid refresh token instance per Session:
+	if err = useCases.authService.RotateRefreshToken(ctx, dbRefreshToken.ID, familyID); err != nil {
+		var reuseDetectedError *customerrors.RefreshTokenReuseDetectedError
+		if errors.As(err, &reuseDetectedError) {
+			// Refresh token has been rotated by concurrent request with the same token:
+			return nil, useCases.revokeRefreshTokenFamily(ctx, dbRefreshToken, familyID)
+		}
+
+		return nil, &security.InvalidJWTError{}
+	}
+
+	var sessionID uint64
+	if dbRefreshToken.SessionID != nil {
+		sessionID = *dbRefreshToken.SessionID
+	} else {
+		// Refresh token was created before sessions were introduced, so creating Session for it:
+		sessionID, err = useCases.authService.CreateSession(
+			ctx,
+			entities.CreateSessionDTO{
+				UserID: dbRefreshToken.UserID,
+				TTL:    useCases.refreshTokenTTL(client),
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return useCases.createTokens(ctx, user, client, sessionID, familyID)
+}
+
+func (useCases *UseCases) LogoutUser(ctx context.Context, accessToken string) error {
+	accessTokenPayload, err := useCases.verifyAccessToken(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+
+	// Access tokens, issued before sessions were introduced, do not belong to any Session:
+	if accessTokenPayload.SessionID == 0 {
+		useCases.revokeAccessToken(ctx, accessTokenPayload)
+		return nil
+	}
+
+	// Ending only current Session for User to stay logged in on other devices:
+	if err = useCases.authService.ExpireSession(
+		ctx,
+		accessTokenPayload.UserID,
+		accessTokenPayload.SessionID,
+	); err != nil {
+		return err
+	}
+
+	useCases.revokeSessions(ctx, accessTokenPayload.SessionID)
+
+	return nil
+}
+
+// GetUserSessions returns active Sessions of User, who owns provided access token.
+func (useCases *UseCases) GetUserSessions(ctx context.Context, accessToken string) (*entities.SessionsDTO, error) {
+	accessTokenPayload, err := useCases.verifyAccessToken(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := useCases.authService.GetUserSessions(ctx, accessTokenPayload.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.SessionsDTO{
+		Sessions:         sessions,
+		CurrentSessionID: accessTokenPayload.SessionID,
+	}, nil
+}
+
+// RevokeSession ends one of User's Sessions, so that Session's refresh token can not be used anymore.
+func (useCases *UseCases) RevokeSession(ctx context.Context, accessToken string, sessionID uint64) error {
+	accessTokenPayload, err := useCases.verifyAccessToken(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+
+	if err = useCases.authService.ExpireSession(ctx, accessTokenPayload.UserID, sessionID); err != nil {
+		return err
+	}
+
+	useCases.revokeSessions(ctx, sessionID)
+
+	return nil
+}
+
+// LogoutUserEverywhere ends all User's Sessions except the current one.
+func (useCases *UseCases) LogoutUserEverywhere(ctx context.Context, accessToken string) error {
+	accessTokenPayload, err := useCases.verifyAccessToken(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+
+	// For access tokens without Session all Sessions will be ended, because there is no Session to keep:
+	return useCases.expireUserSessions(ctx, accessTokenPayload.UserID, accessTokenPayload.SessionID)
+}
+
+// expireUserSessions ends User's Sessions except provided one and revokes access tokens, issued for them.
+func (useCases *UseCases) expireUserSessions(ctx context.Context, userID, exceptSessionID uint64) error {
+	sessions, err := useCases.authService.GetUserSessions(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err = useCases.authService.ExpireUserSessions(ctx, userID, exceptSessionID); err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		if session.ID != exceptSessionID {
+			useCases.revokeSessions(ctx, session.ID)
+		}
+	}
+
+	return nil
+}
+
+// GetJWKS returns public keys, which are used by other services to verify access tokens without private key.
+func (useCases *UseCases) GetJWKS() entities.JWKS {
+	return useCases.jwtProvider.GetJWKS()
+}
+
+// GetOpenIDConfiguration returns metadata of OpenID Connect provider for discovery by clients.
+func (useCases *UseCases) GetOpenIDConfiguration() entities.OpenIDConfiguration {
+	return entities.OpenIDConfiguration{
+		Issuer:                 useCases.oidcConfig.Issuer,
+		AuthorizationEndpoint:  useCases.oidcConfig.Issuer + authorizationEndpoint,
+		TokenEndpoint:          useCases.oidcConfig.Issuer + tokenEndpoint,
+		UserInfoEndpoint:       useCases.oidcConfig.Issuer + userInfoEndpoint,
+		JWKSURI:                useCases.oidcConfig.Issuer + jwksEndpoint,
+		ScopesSupported:        supportedScopes,
+		ResponseTypesSupported: []string{entities.CodeResponseType},
+		GrantTypesSupported: []string{
+			entities.AuthorizationCodeGrantType,
+			entities.RefreshTokenGrantType,
+			entities.ClientCredentialsGrantType,
+		},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{useCases.securityConfig.JWT.Algorithm},
+		TokenEndpointAuthMethodsSupported: []string{"none", "client_secret_post", "client_secret_basic"},
+		CodeChallengeMethodsSupported:     []string{entities.S256CodeChallengeMethod},
+		ClaimsSupported: []string{
+			"sub",
+			"iss",
+			"aud",
+			"exp",
+			"iat",
+			"nonce",
+			"email",
+			"email_verified",
+			"name",
+			"picture",
+		},
+	}
+}
+
+// Authorize handles authorization request of OpenID Connect client and returns URL, which User should be
+// redirected to: login page, if User has not logged in yet, or redirect URI of client with authorization code
+// or with error. Error is returned only if User can not be redirected back to client.
+func (useCases *UseCases) Authorize(ctx context.Context, authorizeData entities.AuthorizeDTO) (string, error) {
+	client, err := useCases.getOIDCClient(ctx, authorizeData.ClientID)
+	if err != nil {
+		return "", err
+	}
+
+	// Unregistered redirect URI can lead to leakage of authorization code, so User is not redirected to it:
+	if !slices.Contains(strings.Split(client.RedirectURIs, redirectURIsSeparator), authorizeData.RedirectURI) {
+		return "", &customerrors.OAuthError{
+			Code:    customerrors.InvalidRequestOAuthErrorCode,
+			Message: "redirect_uri is not registered for client",
+		}
+	}
+
+	var oauthError *customerrors.OAuthError
+	if err = validateAuthorizationRequest(authorizeData, client); errors.As(err, &oauthError) {
+		return buildErrorRedirectURL(authorizeData, oauthError)
+	}
+
+	accessTokenPayload, err := useCases.verifyAccessToken(ctx, authorizeData.AccessToken)
+	if err != nil {
+		if authorizeData.Prompt == entities.NonePrompt {
+			return buildErrorRedirectURL(
+				authorizeData,
+				&customerrors.OAuthError{Code: customerrors.LoginRequiredOAuthErrorCode},
+			)
+		}
+
+		return useCases.buildLoginURL(authorizeData)
+	}
+
+	code, err := generateToken()
+	if err != nil {
+		return "", err
+	}
+
+	if _, err = useCases.authService.CreateAuthorizationCode(
+		ctx,
+		entities.CreateAuthorizationCodeDTO{
+			UserID:        accessTokenPayload.UserID,
+			ClientID:      client.ClientID,
+			CodeHash:      hashToken(useCases.tokensConfig.SecretKey, code),
+			RedirectURI:   authorizeData.RedirectURI,
+			Scope:         filterScope(authorizeData.Scope, client),
+			Nonce:         authorizeData.Nonce,
+			CodeChallenge: authorizeData.CodeChallenge,
+			TTL:           useCases.tokensConfig.AuthorizationCode.TTL,
+		},
+	); err != nil {
+		return "", err
+	}
+
+	params := url.Values{}
+	params.Set("code", code)
+
+	if authorizeData.State != "" {
+		params.Set("state", authorizeData.State)
+	}
+
+	return buildRedirectURL(authorizeData.RedirectURI, params)
+}
+
+// ExchangeOIDCToken handles token request of OpenID Connect client according to its grant type.
+func (useCases *UseCases) ExchangeOIDCToken(
+	ctx context.Context,
+	tokenRequest entities.OIDCTokenRequestDTO,
+) (*entities.OIDCTokensDTO, error) {
+	switch tokenRequest.GrantType {
+	case entities.AuthorizationCodeGrantType:
+		return useCases.exchangeAuthorizationCode(ctx, tokenRequest)
+	case entities.RefreshTokenGrantType:
+		return useCases.refreshOIDCTokens(ctx, tokenRequest)
+	case entities.ClientCredentialsGrantType:
+		return useCases.issueOIDCClientToken(ctx, tokenRequest)
+	default:
+		return nil, &customerrors.OAuthError{
+			Code:    customerrors.UnsupportedGrantTypeOAuthErrorCode,
+			Message: "grant_type is not supported: " + tokenRequest.GrantType,
+		}
+	}
+}
+
+// GetUserInfo returns claims of User, who access token has been issued for.
+func (useCases *UseCases) GetUserInfo(ctx context.Context, accessToken string) (*entities.UserInfo, error) {
+	user, err := useCases.GetMe(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapUserToUserInfo(user), nil
+}
+
+// RegisterClient registers application, which Users can log in through. Secret is generated only
+// for confidential Clients and is returned only once, because only its hash is stored.
+func (useCases *UseCases) RegisterClient(
+	ctx context.Context,
+	clientData entities.RegisterClientDTO,
+) (*entities.RegisteredClientDTO, error) {
+	if err := useCases.verifyAdministrator(ctx, clientData.AccessToken); err != nil {
+		return nil, err
+	}
+
+	if !clientIDRegExp.MatchString(clientData.ClientID) {
+		return nil, &validation.Error{Message: "invalid client ID"}
+	}
+
+	if err := useCases.validateClientSettings(clientData.Settings); err != nil {
+		return nil, err
+	}
+
+	if !clientData.Confidential &&
+		slices.Contains(clientData.Settings.GrantTypes, entities.ClientCredentialsGrantType) {
+		return nil, &validation.Error{Message: "client_credentials grant type is allowed only for confidential clients"}
+	}
+
+	_, err := useCases.authService.GetClientByClientID(ctx, clientData.ClientID)
+	if err == nil {
+		return nil, &customerrors.ClientAlreadyExistsError{}
+	}
+
+	var clientNotFoundError *customerrors.ClientNotFoundError
+	if !errors.As(err, &clientNotFoundError) {
+		return nil, err
+	}
+
+	var secret, secretHash string
+	if clientData.Confidential {
+		if secret, err = generateToken(); err != nil {
+			return nil, err
+		}
+
+		secretHash = hashToken(useCases.tokensConfig.SecretKey, secret)
+	}
+
+	if _, err = useCases.authService.CreateClient(
+		ctx,
+		entities.CreateClientDTO{
+			ClientID:        clientData.ClientID,
+			SecretHash:      secretHash,
+			RedirectURIs:    strings.Join(clientData.Settings.RedirectURIs, redirectURIsSeparator),
+			GrantTypes:      strings.Join(clientData.Settings.GrantTypes, grantTypesSeparator),
+			Scopes:          strings.Join(clientData.Settings.Scopes, scopesSeparator),
+			AccessTokenTTL:  clientData.Settings.AccessTokenTTL,
+			RefreshTokenTTL: clientData.Settings.RefreshTokenTTL,
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	client, err := useCases.authService.GetClientByClientID(ctx, clientData.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.RegisteredClientDTO{Client: *client, Secret: secret}, nil
+}
+
+// GetClients returns all registered Clients. Secrets of Clients are never returned.
+func (useCases *UseCases) GetClients(ctx context.Context, accessToken string) ([]entities.Client, error) {
+	if err := useCases.verifyAdministrator(ctx, accessToken); err != nil {
+		return nil, err
+	}
+
+	return useCases.authService.GetClients(ctx)
+}
+
+// UpdateClient replaces settings of Client. New settings are applied to tokens, which are issued
+// or refreshed after update.
+func (useCases *UseCases) UpdateClient(ctx context.Context, clientData entities.UpdateClientDTO) error {
+	if err := useCases.verifyAdministrator(ctx, clientData.AccessToken); err != nil {
+		return err
+	}
+
+	if err := useCases.validateClientSettings(clientData.Settings); err != nil {
+		return err
+	}
+
+	return useCases.authService.UpdateClient(
+		ctx,
+		entities.UpdateClientSettingsDTO{
+			ClientID:        clientData.ClientID,
+			RedirectURIs:    strings.Join(clientData.Settings.RedirectURIs, redirectURIsSeparator),
+			GrantTypes:      strings.Join(clientData.Settings.GrantTypes, grantTypesSeparator),
+			Scopes:          strings.Join(clientData.Settings.Scopes, scopesSeparator),
+			AccessTokenTTL:  clientData.Settings.AccessTokenTTL,
+			RefreshTokenTTL: clientData.Settings.RefreshTokenTTL,
+		},
+	)
+}
+
+// DeleteClient removes Client from registry. Refresh tokens of Client can not be used after deletion.
+func (useCases *UseCases) DeleteClient(ctx context.Context, accessToken, clientID string) error {
+	if err := useCases.verifyAdministrator(ctx, accessToken); err != nil {
+		return err
+	}
+
+	return useCases.authService.DeleteClient(ctx, clientID)
+}
+
+// IssueClientToken issues short-lived access token to backend service, which authenticates
+// with its own credentials according to client credentials grant (RFC 6749, section 4.4).
+func (useCases *UseCases) IssueClientToken(
+	ctx context.Context,
+	tokenRequest entities.IssueClientTokenDTO,
+) (*entities.ClientTokenDTO, error) {
+	client, err := useCases.authenticateClient(
+		ctx,
+		tokenRequest.ClientID,
+		tokenRequest.ClientSecret,
+		entities.ClientCredentialsGrantType,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return useCases.issueClientToken(client, tokenRequest.Scope)
+}
+
+// VerifyUserToken validates signature and claims of User's access token. Revocation is not checked,
+// so it is cheap enough for checks, which are made before each call, such as rate limiting.
+func (useCases *UseCases) VerifyUserToken(accessToken string) (*entities.AccessTokenPayload, error) {
+	return useCases.parseAccessToken(accessToken)
+}
+
+// VerifyClientToken validates access token of Client, which has been issued by client credentials grant.
+// Tokens of Users are rejected, because they can not be used for service-to-service calls.
+func (useCases *UseCases) VerifyClientToken(accessToken string) (*entities.ClientTokenPayload, error) {
+	claims := &clientTokenClaims{}
+	if err := useCases.jwtProvider.Parse(
+		accessToken,
+		claims,
+		jwt.WithIssuer(useCases.accessTokensConfig.Issuer),
+		jwt.WithAudience(useCases.accessTokensConfig.Audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	); err != nil {
+		return nil, &security.InvalidJWTError{}
+	}
+
+	if claims.GrantType != entities.ClientCredentialsGrantType ||
+		claims.ClientID == "" ||
+		claims.Subject != claims.ClientID {
+		return nil, &security.InvalidJWTError{}
+	}
+
+	return &entities.ClientTokenPayload{
+		ID:        claims.ID,
+		ClientID:  claims.ClientID,
+		Scopes:    strings.Fields(claims.Scope),
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
+
+// IntrospectToken returns state of access or refresh token for other services according to RFC 7662.
+// Invalid, expired or revoked tokens, as well as tokens of blocked Users, are reported as inactive without error.
+func (useCases *UseCases) IntrospectToken(ctx context.Context, token string) (*entities.TokenIntrospection, error) {
+	// Access tokens are JWT, which consist of three dot-separated parts, while refresh tokens are opaque:
+	if strings.Count(token, ".") == 2 {
+		return useCases.introspectAccessToken(ctx, token)
+	}
+
+	return useCases.introspectRefreshToken(ctx, token)
+}
+
+func (useCases *UseCases) introspectAccessToken(
+	ctx context.Context,
+	accessToken string,
+) (*entities.TokenIntrospection, error) {
+	accessTokenPayload, err := useCases.verifyAccessToken(ctx, accessToken)
+	if err != nil {
+		return &entities.TokenIntrospection{Active: false}, nil
+	}
+
+	return &entities.TokenIntrospection{
+		Active:    true,
+		TokenType: entities.AccessTokenType,
+		UserID:    accessTokenPayload.UserID,
+		SessionID: accessTokenPayload.SessionID,
+		ClientID:  accessTokenPayload.ClientID,
+		Scopes:    accessTokenPayload.Scopes,
+		Roles:     accessTokenPayload.Roles,
+		IssuedAt:  accessTokenPayload.IssuedAt,
+		ExpiresAt: accessTokenPayload.ExpiresAt,
+	}, nil
+}
+
+// introspectRefreshToken checks opaque refresh token. Legacy JWT refresh tokens are reported as inactive,
+// because they can be only exchanged for new tokens.
+func (useCases *UseCases) introspectRefreshToken(
+	ctx context.Context,
+	refreshToken string,
+) (*entities.TokenIntrospection, error) {
+	dbRefreshToken, err := useCases.authService.GetRefreshTokenByValue(ctx, hashRefreshToken(refreshToken))
+	if err != nil {
+		return &entities.TokenIntrospection{Active: false}, nil
+	}
+
+	if dbRefreshToken.RotatedAt != nil || !dbRefreshToken.TTL.After(time.Now().UTC()) {
+		return &entities.TokenIntrospection{Active: false}, nil
+	}
+
+	// Blocked flag of access tokens' owners is checked by verifyAccessToken:
+	blocked, err := useCases.usersService.IsUserBlocked(ctx, dbRefreshToken.UserID)
+	if err != nil {
+		var userNotFoundError *customerrors.UserNotFoundError
+		if errors.As(err, &userNotFoundError) {
+			return &entities.TokenIntrospection{Active: false}, nil
+		}
+
+		return nil, err
+	}
+
+	if blocked {
+		return &entities.TokenIntrospection{Active: false}, nil
+	}
+
+	tokenIntrospection := &entities.TokenIntrospection{
+		Active:    true,
+		TokenType: entities.RefreshTokenType,
+		UserID:    dbRefreshToken.UserID,
+		IssuedAt:  dbRefreshToken.CreatedAt,
+		ExpiresAt: dbRefreshToken.TTL,
+	}
+
+	if dbRefreshToken.SessionID != nil {
+		tokenIntrospection.SessionID = *dbRefreshToken.SessionID
+	}
+
+	if dbRefreshToken.ClientID != nil {
+		tokenIntrospection.ClientID = *dbRefreshToken.ClientID
+	}
+
+	return tokenIntrospection, nil
+}
+
+func (useCases *UseCases) VerifyUserEmail(ctx context.Context, verifyEmailToken string) error {
+	dbVerifyEmailToken, err := useCases.authService.GetVerifyEmailTokenByHash(
+		ctx,
+		hashToken(useCases.tokensConfig.SecretKey, verifyEmailToken),
+	)
+	if err != nil {
+		return &customerrors.InvalidVerifyEmailTokenError{BaseErr: err}
+	}
+
+	user, err := useCases.GetUserByID(ctx, dbVerifyEmailToken.UserID)
+	if err != nil {
+		return err
+	}
+
+	return useCases.verifyUserEmail(ctx, user, dbVerifyEmailToken)
+}
+
+func (useCases *UseCases) VerifyUserEmailByCode(ctx context.Context, email, code string) error {
+	user, err := useCases.GetUserByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	dbVerifyEmailToken, err := useCases.authService.GetVerifyEmailTokenByUserID(ctx, user.ID)
+	if err != nil {
+		return &customerrors.InvalidVerifyEmailTokenError{BaseErr: err}
+	}
+
+	// Attempts are limited to prevent brute force of short codes, so code is not checked, if attempts can not be
+	// counted. After limit is reached, code of token can not be used anymore, so new verify-email message should be
+	// requested:
+	attempts, err := useCases.countAttempt(
+		ctx,
+		verifyEmailCodeCacheKey(dbVerifyEmailToken.ID),
+		useCases.tokensConfig.VerifyEmail.TTL,
+	)
+	if err != nil {
+		return err
+	}
+
+	if attempts > verifyEmailCodeAttemptsLimit {
+		return &customerrors.InvalidVerifyEmailTokenError{Message: "too many attempts to verify email with code"}
+	}
+
+	if !hashesEqual(
+		dbVerifyEmailToken.CodeHash,
+		hashCode(useCases.tokensConfig.SecretKey, user.ID, code),
+	) {
+		return &customerrors.InvalidVerifyEmailTokenError{}
+	}
+
+	return useCases.verifyUserEmail(ctx, user, dbVerifyEmailToken)
+}
+
+func (useCases *UseCases) verifyUserEmail(
+	ctx context.Context,
+	user *entities.User,
+	verifyEmailToken *entities.VerifyEmailToken,
+) error {
+	if user.EmailConfirmed {
+		return &customerrors.EmailAlreadyConfirmedError{}
+	}
+
+	// Token is bound to email, which User had during token creation:
+	if verifyEmailToken.Email != user.Email {
+		return &customerrors.InvalidVerifyEmailTokenError{}
+	}
+
+	return useCases.authService.VerifyUserEmail(ctx, user.ID)
+}
+
+func (useCases *UseCases) ForgetPassword(ctx context.Context, forgetPasswordToken, newPassword string) error {
+	if !validation.ValidateValueByRules(newPassword, useCases.validationConfig.PasswordRegExps) {
+		return &validation.Error{Message: "invalid password"}
+	}
+
+	dbForgetPasswordToken, err := useCases.authService.GetForgetPasswordTokenByHash(
+		ctx,
+		hashToken(useCases.tokensConfig.SecretKey, forgetPasswordToken),
+	)
+	if err != nil {
+		return &customerrors.InvalidForgetPasswordTokenError{BaseErr: err}
+	}
+
+	user, err := useCases.GetUserByID(ctx, dbForgetPasswordToken.UserID)
+	if err != nil {
+		return err
+	}
+
+	if useCases.passwordHasher.Verify(newPassword, user.Password) {
+		return &validation.Error{Message: "new password can not be equal to old password"}
+	}
+
+	hashedPassword, err := useCases.passwordHasher.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+
+	if err = useCases.authService.ForgetPassword(ctx, user.ID, dbForgetPasswordToken.ID, hashedPassword); err != nil {
+		return err
+	}
+
+	// Owner of account has proved access to email, so lockout is not needed anymore:
+	useCases.unlockLogin(ctx, user.ID)
+
+	// Password could be reset due to account compromise, so all Sessions of User are ended:
+	return useCases.expireUserSessions(ctx, user.ID, 0)
+}
+
+func (useCases *UseCases) ChangePassword(
+	ctx context.Context,
+	accessToken string,
+	oldPassword string,
+	newPassword string,
+) error {
+	if oldPassword == newPassword {
+		return &validation.Error{Message: "new password can not be equal to old password"}
+	}
+
+	if !validation.ValidateValueByRules(newPassword, useCases.validationConfig.PasswordRegExps) {
+		return &validation.Error{Message: "invalid password"}
+	}
+
+	accessTokenPayload, err := useCases.verifyAccessToken(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+
+	user, err := useCases.GetUserByID(ctx, accessTokenPayload.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !useCases.passwordHasher.Verify(oldPassword, user.Password) {
+		return &customerrors.WrongPasswordError{}
+	}
+
+	hashedPassword, err := useCases.passwordHasher.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+
+	return useCases.authService.ChangePassword(ctx, user.ID, hashedPassword)
+}
+
+// SendVerifyEmailMessage sends verify-email message to User. Number of messages per email is limited
+// by rate limit policies of gRPC controller.
+func (useCases *UseCases) SendVerifyEmailMessage(ctx context.Context, email string) error {
+	user, err := useCases.GetUserByEmail(ctx, email)
+	if err != nil {
+		return err
 	}
 
 	if user.EmailConfirmed {
 		return &customerrors.EmailAlreadyConfirmedError{}
 	}
 
-	verifyEmailDTO := &notifications.VerifyEmailDTO{
-		UserID: user.ID,
+	if err = useCases.publishVerifyEmailMessage(ctx, user.ID, user.Email); err != nil {
+		logging.LogErrorContext(
+			ctx,
+			useCases.logger,
+			fmt.Sprintf(
+				"Error occurred while trying send verify-email message to User with ID=%d",
+				user.ID,
+			),
+			err,
+		)
+
+		return err
 	}
 
-	content, err := json.Marshal(verifyEmailDTO)
+	return nil
+}
+
+// SendLoginLink sends magic link and one-time code for passwordless login to User via NATS.
+func (useCases *UseCases) SendLoginLink(ctx context.Context, email string) error {
+	user, err := useCases.GetUserByEmail(ctx, email)
 	if err != nil {
+		return err
+	}
+
+	if !user.EmailConfirmed {
+		return &customerrors.EmailIsNotConfirmedError{}
+	}
+
+	if err = useCases.publishLoginLinkMessage(ctx, user.ID); err != nil {
 		logging.LogErrorContext(
 			ctx,
 			useCases.logger,
 			fmt.Sprintf(
-				"Error occurred while trying to encode data for email verification for User with ID=%d",
+				"Error occurred while trying send login-link message to User with ID=%d",
 				user.ID,
 			),
 			err,
@@ -564,12 +1809,25 @@ func (useCases *UseCases) SendVerifyEmailMessage(ctx context.Context, email stri
 		return err
 	}
 
-	if err = useCases.natsPublisher.Publish(useCases.natsConfig.Subjects.VerifyEmail, content); err != nil {
+	return nil
+}
+
+func (useCases *UseCases) SendForgetPasswordMessage(ctx context.Context, email string) error {
+	user, err := useCases.GetUserByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	if !user.EmailConfirmed {
+		return &customerrors.EmailIsNotConfirmedError{}
+	}
+
+	if err = useCases.publishForgetPasswordMessage(ctx, user.ID); err != nil {
 		logging.LogErrorContext(
 			ctx,
 			useCases.logger,
 			fmt.Sprintf(
-				"Error occurred while trying send verify-email message to User with ID=%d",
+				"Error occurred while trying send forget-password message to User with ID=%d",
 				user.ID,
 			),
 			err,
@@ -578,123 +1836,435 @@ func (useCases *UseCases) SendVerifyEmailMessage(ctx context.Context, email stri
 		return err
 	}
 
-	if counter == 0 {
-		if err = useCases.cacheProvider.Set(ctx, cacheKey, 1, verifyEmailTTL); err != nil {
-			logging.LogErrorContext(
-				ctx,
-				useCases.logger,
-				fmt.Sprintf("Failed to set cache for %s key", cacheKey),
-				err,
-			)
-		}
-	} else {
-		if _, err = useCases.cacheProvider.Incr(ctx, cacheKey); err != nil {
-			logging.LogErrorContext(
-				ctx,
-				useCases.logger,
-				fmt.Sprintf("Failed to increment cache for %s key", cacheKey),
-				err,
-			)
-		}
+	return nil
+}
+
+// publishVerifyEmailMessage creates single-use verify-email token and code for User and sends them via NATS.
+func (useCases *UseCases) publishVerifyEmailMessage(ctx context.Context, userID uint64, email string) error {
+	token, err := generateToken()
+	if err != nil {
+		return err
 	}
 
-	return nil
+	code, err := generateCode()
+	if err != nil {
+		return err
+	}
+
+	if _, err = useCases.authService.CreateVerifyEmailToken(
+		ctx,
+		entities.CreateVerifyEmailTokenDTO{
+			UserID:    userID,
+			Email:     email,
+			TokenHash: hashToken(useCases.tokensConfig.SecretKey, token),
+			CodeHash:  hashCode(useCases.tokensConfig.SecretKey, userID, code),
+			TTL:       useCases.tokensConfig.VerifyEmail.TTL,
+		},
+	); err != nil {
+		return err
+	}
+
+	verifyEmailDTO := &entities.VerifyEmailDTO{
+		VerifyEmailDTO: notifications.VerifyEmailDTO{
+			UserID: userID,
+		},
+		Token: token,
+		Code:  code,
+	}
+
+	content, err := json.Marshal(verifyEmailDTO)
+	if err != nil {
+		return err
+	}
+
+	return useCases.natsPublisher.Publish(useCases.natsConfig.Subjects.VerifyEmail, content)
 }
 
-func (useCases *UseCases) SendForgetPasswordMessage(ctx context.Context, email string) error {
-	var counter int64
+// publishForgetPasswordMessage creates single-use forget-password token for User and sends it via NATS.
+func (useCases *UseCases) publishForgetPasswordMessage(ctx context.Context, userID uint64) error {
+	token, err := generateToken()
+	if err != nil {
+		return err
+	}
 
-	cacheKey := fmt.Sprintf("%s-%s", forgetPasswordCachePrefix, email)
+	if _, err = useCases.authService.CreateForgetPasswordToken(
+		ctx,
+		entities.CreateForgetPasswordTokenDTO{
+			UserID:    userID,
+			TokenHash: hashToken(useCases.tokensConfig.SecretKey, token),
+			TTL:       useCases.tokensConfig.ForgetPassword.TTL,
+		},
+	); err != nil {
+		return err
+	}
 
-	if _, err := useCases.cacheProvider.Ping(ctx); err == nil {
-		var strCounter string
+	forgetPasswordDTO := &entities.ForgetPasswordDTO{
+		ForgetPasswordDTO: notifications.ForgetPasswordDTO{
+			UserID: userID,
+		},
+		Token: token,
+	}
 
-		if strCounter, err = useCases.cacheProvider.Get(ctx, cacheKey); err != nil {
-			logging.LogErrorContext(
-				ctx,
-				useCases.logger,
-				fmt.Sprintf("Failed to get cache for %s key", cacheKey),
-				err,
-			)
-		}
+	content, err := json.Marshal(forgetPasswordDTO)
+	if err != nil {
+		return err
+	}
 
-		if counter, err = strconv.ParseInt(strCounter, 10, 64); err != nil && strCounter != "" {
-			logging.LogErrorContext(
-				ctx,
-				useCases.logger,
-				fmt.Sprintf("Invalid value=%s for %s cache key", strCounter, cacheKey),
-				err,
-			)
-		}
+	return useCases.natsPublisher.Publish(useCases.natsConfig.Subjects.ForgetPassword, content)
+}
 
-		if counter >= forgetPasswordLimit {
-			return &customerrors.LimitExceededError{
-				Message: fmt.Sprintf("Too many tries to send message. Limit per minute is %d", forgetPasswordLimit),
-			}
-		}
+// publishLoginLinkMessage creates single-use login token with code for User and sends them via NATS.
+func (useCases *UseCases) publishLoginLinkMessage(ctx context.Context, userID uint64) error {
+	token, err := generateToken()
+	if err != nil {
+		return err
 	}
 
-	user, err := useCases.GetUserByEmail(ctx, email)
+	code, err := generateCode()
 	if err != nil {
 		return err
 	}
 
-	if !user.EmailConfirmed {
-		return &customerrors.EmailIsNotConfirmedError{}
+	if _, err = useCases.authService.CreateLoginToken(
+		ctx,
+		entities.CreateLoginTokenDTO{
+			UserID:    userID,
+			TokenHash: hashToken(useCases.tokensConfig.SecretKey, token),
+			CodeHash:  hashCode(useCases.tokensConfig.SecretKey, userID, code),
+			TTL:       useCases.tokensConfig.Login.TTL,
+		},
+	); err != nil {
+		return err
 	}
 
-	forgetPasswordDTO := &notifications.ForgetPasswordDTO{
-		UserID: user.ID,
+	content, err := json.Marshal(
+		&entities.LoginLinkDTO{
+			UserID: userID,
+			Token:  token,
+			Code:   code,
+		},
+	)
+	if err != nil {
+		return err
 	}
 
-	content, err := json.Marshal(forgetPasswordDTO)
+	return useCases.natsPublisher.Publish(useCases.natsConfig.Subjects.LoginLink, content)
+}
+
+func verifyEmailCodeCacheKey(verifyEmailTokenID uint64) string {
+	return fmt.Sprintf("%s-%d", verifyEmailCodeCachePrefix, verifyEmailTokenID)
+}
+
+func loginCodeCacheKey(loginTokenID uint64) string {
+	return fmt.Sprintf("%s-%d", loginCodeCachePrefix, loginTokenID)
+}
+
+// getLoginTokenByCode returns the latest login token of User, if provided code matches it.
+// Attempts are limited to prevent brute force of short codes.
+func (useCases *UseCases) getLoginTokenByCode(
+	ctx context.Context,
+	userID uint64,
+	code string,
+) (*entities.LoginToken, error) {
+	loginToken, err := useCases.authService.GetLoginTokenByUserID(ctx, userID)
 	if err != nil {
-		logging.LogErrorContext(
-			ctx,
-			useCases.logger,
-			fmt.Sprintf(
-				"Error occurred while trying to encode data for forget-password message for User with ID=%d",
-				user.ID,
-			),
-			err,
-		)
+		return nil, &customerrors.InvalidLoginTokenError{BaseErr: err}
+	}
+
+	attempts, err := useCases.countAttempt(ctx, loginCodeCacheKey(loginToken.ID), useCases.tokensConfig.Login.TTL)
+	if err != nil {
+		return nil, err
+	}
+
+	if attempts > loginCodeAttemptsLimit {
+		return nil, &customerrors.InvalidLoginTokenError{Message: "too many attempts to login with code"}
+	}
+
+	if !hashesEqual(loginToken.CodeHash, hashCode(useCases.tokensConfig.SecretKey, userID, code)) {
+		return nil, &customerrors.InvalidLoginTokenError{}
+	}
+
+	return loginToken, nil
+}
+
+// getRefreshToken selects refresh token model from Database by SHA-256 digest of opaque refresh token.
+// JWT refresh tokens, issued before opaque tokens were introduced, are stored as digests too and stay valid
+// until expiration.
+func (useCases *UseCases) getRefreshToken(ctx context.Context, refreshToken string) (*entities.RefreshToken, error) {
+	if legacyRefreshToken, refreshTokenPayload, ok := useCases.parseLegacyRefreshToken(refreshToken); ok {
+		return useCases.getLegacyRefreshToken(ctx, legacyRefreshToken, refreshTokenPayload)
+	}
+
+	dbRefreshToken, err := useCases.authService.GetRefreshTokenByValue(ctx, hashRefreshToken(refreshToken))
+	if err != nil {
+		return nil, &security.InvalidJWTError{}
+	}
+
+	return dbRefreshToken, nil
+}
+
+// parseLegacyRefreshToken decodes refresh token and checks, if it is signed JWT, which was issued by SSO.
+func (useCases *UseCases) parseLegacyRefreshToken(refreshToken string) (string, any, bool) {
+	legacyRefreshTokenBytes, err := security.RawDecode(refreshToken)
+	if err != nil {
+		return "", nil, false
+	}
+
+	legacyRefreshToken := string(legacyRefreshTokenBytes)
+
+	refreshTokenPayload, err := security.ParseJWT(
+		legacyRefreshToken,
+		useCases.securityConfig.JWT.SecretKey,
+	)
+	if err != nil {
+		return "", nil, false
+	}
+
+	return legacyRefreshToken, refreshTokenPayload, true
+}
+
+// getLegacyRefreshToken selects JWT refresh token from Database and checks, that it was issued with access token,
+// which is stored in its payload.
+func (useCases *UseCases) getLegacyRefreshToken(
+	ctx context.Context,
+	legacyRefreshToken string,
+	refreshTokenPayload any,
+) (*entities.RefreshToken, error) {
+	oldAccessToken, ok := refreshTokenPayload.(string)
+	if !ok {
+		return nil, &security.InvalidJWTError{}
+	}
+
+	oldAccessTokenUserID, err := useCases.parseLegacyAccessToken(oldAccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	dbRefreshToken, err := useCases.authService.GetRefreshTokenByValue(ctx, hashRefreshToken(legacyRefreshToken))
+	if err != nil {
+		return nil, &security.InvalidJWTError{}
+	}
+
+	// Checking if access token belongs to refresh token:
+	if oldAccessTokenUserID != dbRefreshToken.UserID {
+		return nil, &customerrors.AccessTokenDoesNotBelongToRefreshTokenError{}
+	}
+
+	return dbRefreshToken, nil
+}
+
+// getRefreshTokenFamilyID returns family of refresh token or starts new family for refresh tokens,
+// created before families were introduced.
+func (useCases *UseCases) getRefreshTokenFamilyID(refreshToken *entities.RefreshToken) (string, error) {
+	if refreshToken.FamilyID != nil {
+		return *refreshToken.FamilyID, nil
+	}
+
+	return generateToken()
+}
+
+// revokeRefreshTokenFamily ends all Sessions, compromised by refresh token reuse, and notifies about it via NATS.
+func (useCases *UseCases) revokeRefreshTokenFamily(
+	ctx context.Context,
+	refreshToken *entities.RefreshToken,
+	familyID string,
+) error {
+	if err := useCases.authService.RevokeRefreshTokenFamily(ctx, familyID); err != nil {
+		return err
+	}
 
+	// Access tokens of compromised Session could be already issued to attacker:
+	if refreshToken.SessionID != nil {
+		useCases.revokeSessions(ctx, *refreshToken.SessionID)
+	}
+
+	securityEvent := entities.SecurityEventDTO{
+		Type:       entities.RefreshTokenReuseSecurityEvent,
+		UserID:     refreshToken.UserID,
+		SessionID:  refreshToken.SessionID,
+		FamilyID:   familyID,
+		OccurredAt: time.Now().UTC(),
+	}
+
+	content, err := json.Marshal(securityEvent)
+	if err != nil {
 		return err
 	}
 
-	if err = useCases.natsPublisher.Publish(useCases.natsConfig.Subjects.ForgetPassword, content); err != nil {
+	// Family is already revoked, so failed notification should not hide reuse from client:
+	if err = useCases.natsPublisher.Publish(useCases.natsConfig.Subjects.SecurityEvent, content); err != nil {
 		logging.LogErrorContext(
 			ctx,
 			useCases.logger,
-			fmt.Sprintf(
-				"Error occurred while trying send forget-password message to User with ID=%d",
-				user.ID,
-			),
+			fmt.Sprintf("Error occurred while trying to publish security event for User with ID=%d", refreshToken.UserID),
 			err,
 		)
+	}
 
-		return err
+	return &customerrors.RefreshTokenReuseDetectedError{}
+}
+
+// createTokens creates access and refresh tokens for provided Session of User.
+func (useCases *UseCases) createTokens(
+	ctx context.Context,
+	user *entities.User,
+	client *entities.Client,
+	sessionID uint64,
+	familyID string,
+) (*entities.TokensDTO, error) {
+	tokenID, err := generateToken()
+	if err != nil {
+		return nil, err
 	}
 
-	if counter == 0 {
-		if err = useCases.cacheProvider.Set(ctx, cacheKey, 1, forgetPasswordTTL); err != nil {
-			logging.LogErrorContext(
-				ctx,
-				useCases.logger,
-				fmt.Sprintf("Failed to set cache for %s key", cacheKey),
-				err,
-			)
-		}
-	} else {
-		if _, err = useCases.cacheProvider.Incr(ctx, cacheKey); err != nil {
-			logging.LogErrorContext(
-				ctx,
-				useCases.logger,
-				fmt.Sprintf("Failed to increment cache for %s key", cacheKey),
-				err,
-			)
+	// Refresh tokens, issued without Client, are stored with NULL Client ID:
+	var (
+		clientID        string
+		refreshClientID *string
+	)
+
+	if client != nil {
+		clientID = client.ClientID
+		refreshClientID = &client.ClientID
+	}
+
+	now := time.Now()
+	accessTokenTTL := useCases.accessTokenTTL(client)
+
+	accessToken, err := useCases.jwtProvider.Sign(
+		accessTokenClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID:        tokenID,
+				Subject:   strconv.FormatUint(user.ID, 10),
+				Issuer:    useCases.accessTokensConfig.Issuer,
+				Audience:  jwt.ClaimStrings{useCases.accessTokensConfig.Audience},
+				IssuedAt:  jwt.NewNumericDate(now),
+				ExpiresAt: jwt.NewNumericDate(now.Add(accessTokenTTL)),
+			},
+			SessionID: strconv.FormatUint(sessionID, 10),
+			ClientID:  clientID,
+			Roles:     getUserRoles(user),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Refresh token is opaque random value, so only its digest is stored in Database:
+	refreshToken, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = useCases.authService.CreateRefreshToken(
+		ctx,
+		entities.CreateRefreshTokenDTO{
+			UserID:    user.ID,
+			SessionID: sessionID,
+			FamilyID:  familyID,
+			Value:     hashRefreshToken(refreshToken),
+			TTL:       useCases.refreshTokenTTL(client),
+			ClientID:  refreshClientID,
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return &entities.TokensDTO{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    accessTokenTTL,
+		TokenType:    entities.BearerTokenType,
+	}, nil
+}
+
+// parseAccessToken validates access token signature and claims and returns its payload.
+func (useCases *UseCases) parseAccessToken(accessToken string) (*entities.AccessTokenPayload, error) {
+	claims := &accessTokenClaims{}
+	if err := useCases.jwtProvider.Parse(
+		accessToken,
+		claims,
+		jwt.WithIssuer(useCases.accessTokensConfig.Issuer),
+		jwt.WithAudience(useCases.accessTokensConfig.Audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	); err != nil {
+		return nil, &security.InvalidJWTError{}
+	}
+
+	// Tokens of Clients are issued on their own behalf, not on behalf of Users:
+	if claims.GrantType != "" {
+		return nil, &security.InvalidJWTError{}
+	}
+
+	userID, err := strconv.ParseUint(claims.Subject, 10, 64)
+	if err != nil || userID == 0 {
+		return nil, &security.InvalidJWTError{}
+	}
+
+	var sessionID uint64
+	if claims.SessionID != "" {
+		if sessionID, err = strconv.ParseUint(claims.SessionID, 10, 64); err != nil {
+			return nil, &security.InvalidJWTError{}
 		}
 	}
 
-	return nil
+	payload := &entities.AccessTokenPayload{
+		ID:        claims.ID,
+		UserID:    userID,
+		SessionID: sessionID,
+		ClientID:  claims.ClientID,
+		Roles:     claims.Roles,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}
+
+	if claims.IssuedAt != nil {
+		payload.IssuedAt = claims.IssuedAt.Time
+	}
+
+	if claims.Scope != "" {
+		payload.Scopes = strings.Fields(claims.Scope)
+	}
+
+	return payload, nil
+}
+
+// parseLegacyAccessToken retrieves User's ID from access token, issued before standard claims were introduced.
+// Such tokens are used only to check ownership of legacy refresh tokens, so their expiration is not validated.
+func (useCases *UseCases) parseLegacyAccessToken(accessToken string) (uint64, error) {
+	rawPayload, err := security.ParseJWT(
+		accessToken,
+		useCases.securityConfig.JWT.SecretKey,
+		jwt.WithoutClaimsValidation(),
+	)
+	if err != nil {
+		return 0, &security.InvalidJWTError{}
+	}
+
+	// The oldest access tokens contain only User's ID:
+	if floatUserID, ok := rawPayload.(float64); ok {
+		return uint64(floatUserID), nil
+	}
+
+	payload, ok := rawPayload.(map[string]any)
+	if !ok {
+		return 0, &security.InvalidJWTError{}
+	}
+
+	floatUserID, ok := payload["userId"].(float64)
+	if !ok || floatUserID == 0 {
+		return 0, &security.InvalidJWTError{}
+	}
+
+	return uint64(floatUserID), nil
+}
+
+// getUserRoles returns roles of User for access token claims. Each User has base role.
+func getUserRoles(user *entities.User) []string {
+	roles := []string{entities.UserRole}
+	if user.Role != "" && user.Role != entities.UserRole {
+		roles = append(roles, user.Role)
+	}
+
+	return roles
 }
@@ -2,18 +2,20 @@ package usecases
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
-	notifications "github.com/DKhorkov/hmtm-notifications/dto"
-	mockcache "github.com/DKhorkov/libs/cache/mocks"
 	mocklogging "github.com/DKhorkov/libs/logging/mocks"
 	mocknats "github.com/DKhorkov/libs/nats/mocks"
 	"github.com/DKhorkov/libs/pointers"
@@ -23,24 +25,235 @@ import (
 	"github.com/DKhorkov/hmtm-sso/internal/config"
 	"github.com/DKhorkov/hmtm-sso/internal/entities"
 	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+	"github.com/DKhorkov/hmtm-sso/internal/memorycache"
+	"github.com/DKhorkov/hmtm-sso/internal/passwords"
+	"github.com/DKhorkov/hmtm-sso/internal/signing"
+	mockcache "github.com/DKhorkov/hmtm-sso/mocks/cache"
+	mockjwt "github.com/DKhorkov/hmtm-sso/mocks/jwt"
 	mockservices "github.com/DKhorkov/hmtm-sso/mocks/services"
 )
 
 var (
-	cfg              = config.New()
-	validationConfig = cfg.Validation
+	cfg                = config.New()
+	validationConfig   = cfg.Validation
+	tokensConfig       = cfg.Tokens
+	webAuthnConfig     = cfg.WebAuthn
+	accessTokensConfig = cfg.AccessTokens
+	lockoutConfig      = cfg.Lockout
+	oidcConfig         = config.OIDCConfig{
+		Issuer:   "https://sso.example.com",
+		LoginURL: "https://example.com/login",
+	}
+	telegramConfig = config.TelegramConfig{
+		BotToken: "123456:ABC-DEF1234ghIkl-zyx57W2v1u123ew11",
+		AuthTTL:  time.Minute * 5,
+	}
 )
 
+// newJWTProvider creates JWT Provider, which signs tokens with shared secret from provided config.
+func newJWTProvider(t *testing.T, jwtConfig security.JWTConfig) *signing.Provider {
+	t.Helper()
+
+	jwtProvider, err := signing.New(jwtConfig, config.JWTKeysConfig{})
+	require.NoError(t, err)
+
+	return jwtProvider
+}
+
+// newPasswordHasher creates Hasher with bcrypt cost of password hashes in tests, so they are not rehashed on login.
+func newPasswordHasher(t *testing.T) *passwords.Hasher {
+	t.Helper()
+
+	passwordHasher, err := passwords.New(
+		config.PasswordsConfig{
+			Algorithm:  config.BcryptPasswordHashAlgorithm,
+			BcryptCost: 10,
+		},
+	)
+	require.NoError(t, err)
+
+	return passwordHasher
+}
+
+// rehashedPassword matches hash of provided password, which is made by newPasswordHasher.
+func rehashedPassword(password string) gomock.Matcher {
+	return gomock.Cond(func(hashedPassword string) bool {
+		return strings.HasPrefix(hashedPassword, "$2a$10$") && security.ValidateHash(password, hashedPassword)
+	})
+}
+
+// newAccessToken issues access token with standard claims for provided User and Session.
+func newAccessToken(t *testing.T, jwtConfig security.JWTConfig, userID, sessionID uint64) string {
+	t.Helper()
+
+	claims := accessTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "jti",
+			Subject:   strconv.FormatUint(userID, 10),
+			Issuer:    accessTokensConfig.Issuer,
+			Audience:  jwt.ClaimStrings{accessTokensConfig.Audience},
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(jwtConfig.AccessTokenTTL)),
+		},
+		Roles: []string{entities.UserRole},
+	}
+
+	// Access tokens, issued before sessions were introduced, do not contain Session's ID:
+	if sessionID != 0 {
+		claims.SessionID = strconv.FormatUint(sessionID, 10)
+	}
+
+	accessToken, err := newJWTProvider(t, jwtConfig).Sign(claims)
+	require.NoError(t, err)
+
+	return accessToken
+}
+
+// expectAccessTokenIsNotRevoked sets up cache calls, which are made to check revocation of access token,
+// issued by newAccessToken for provided Session.
+func expectAccessTokenIsNotRevoked(cacheProvider *mockcache.MockCacheProvider, sessionID uint64) {
+	cacheProvider.
+		EXPECT().
+		Ping(gomock.Any()).
+		Return("PONG", nil).
+		Times(1)
+
+	cacheProvider.
+		EXPECT().
+		Get(gomock.Any(), revokedAccessTokenCacheKey("jti")).
+		Return("", errors.New("redis: nil")).
+		Times(1)
+
+	if sessionID != 0 {
+		cacheProvider.
+			EXPECT().
+			Get(gomock.Any(), revokedSessionCacheKey(sessionID)).
+			Return("", errors.New("redis: nil")).
+			Times(1)
+	}
+}
+
+// expectUserIsNotBlocked sets up Database call, which is made to check, that owner of access token is not blocked.
+func expectUserIsNotBlocked(usersService *mockservices.MockUsersService, userID uint64) {
+	usersService.
+		EXPECT().
+		IsUserBlocked(gomock.Any(), userID).
+		Return(false, nil).
+		Times(1)
+}
+
+// expectSessionsAreRevoked sets up cache calls, which are made to add provided Sessions to revocation list.
+func expectSessionsAreRevoked(cacheProvider *mockcache.MockCacheProvider, sessionIDs ...uint64) {
+	for _, sessionID := range sessionIDs {
+		cacheProvider.
+			EXPECT().
+			Set(gomock.Any(), revokedSessionCacheKey(sessionID), revokedCacheValue, gomock.Any()).
+			Return(nil).
+			Times(1)
+	}
+}
+
+// expectLoginIsNotLocked sets up cache calls, which are made by LoginUser to check lockout of client IP and User.
+func expectLoginIsNotLocked(cacheProvider *mockcache.MockCacheProvider, ip string, userID uint64) {
+	if ip != "" {
+		cacheProvider.
+			EXPECT().
+			Get(gomock.Any(), ipLoginLockCacheKey(ip)).
+			Return("", errors.New("redis: nil")).
+			Times(1)
+	}
+
+	cacheProvider.
+		EXPECT().
+		Get(gomock.Any(), loginLockCacheKey(userID)).
+		Return("", errors.New("redis: nil")).
+		Times(1)
+}
+
+// expectLoginFailuresAreReset sets up cache call, which is made to forget failed login attempts of User.
+func expectLoginFailuresAreReset(cacheProvider *mockcache.MockCacheProvider, userID uint64) {
+	cacheProvider.
+		EXPECT().
+		Set(gomock.Any(), loginFailuresCacheKey(userID), 0, lockoutConfig.Window).
+		Return(nil).
+		Times(1)
+}
+
+// expectLoginIsUnlocked sets up cache calls, which are made to remove lockout of User.
+func expectLoginIsUnlocked(cacheProvider *mockcache.MockCacheProvider, userID uint64) {
+	expectLoginFailuresAreReset(cacheProvider, userID)
+
+	cacheProvider.
+		EXPECT().
+		Set(gomock.Any(), loginLockCacheKey(userID), 0, lockoutConfig.Duration).
+		Return(nil).
+		Times(1)
+}
+
+// verifyEmailContent matches NATS message with verify-email credentials for User with provided ID.
+func verifyEmailContent(userID uint64) gomock.Matcher {
+	return gomock.Cond(func(content []byte) bool {
+		var verifyEmailDTO entities.VerifyEmailDTO
+		if err := json.Unmarshal(content, &verifyEmailDTO); err != nil {
+			return false
+		}
+
+		return verifyEmailDTO.UserID == userID &&
+			verifyEmailDTO.Token != "" &&
+			len(verifyEmailDTO.Code) == codeLength
+	})
+}
+
+// forgetPasswordContent matches NATS message with forget-password token for User with provided ID.
+func forgetPasswordContent(userID uint64) gomock.Matcher {
+	return gomock.Cond(func(content []byte) bool {
+		var forgetPasswordDTO entities.ForgetPasswordDTO
+		if err := json.Unmarshal(content, &forgetPasswordDTO); err != nil {
+			return false
+		}
+
+		return forgetPasswordDTO.UserID == userID && forgetPasswordDTO.Token != ""
+	})
+}
+
+// refreshTokenData matches refresh token of provided Session. Empty familyID matches any new family.
+func refreshTokenData(userID, sessionID uint64, familyID string) gomock.Matcher {
+	return gomock.Cond(func(refreshTokenData entities.CreateRefreshTokenDTO) bool {
+		if familyID != "" && refreshTokenData.FamilyID != familyID {
+			return false
+		}
+
+		return refreshTokenData.UserID == userID &&
+			refreshTokenData.SessionID == sessionID &&
+			refreshTokenData.FamilyID != "" &&
+			len(refreshTokenData.Value) == sha256.Size*2 &&
+			refreshTokenData.TTL == time.Hour
+	})
+}
+
+// securityEventContent matches NATS message with security event of provided type for User with provided ID.
+func securityEventContent(eventType string, userID uint64, familyID string) gomock.Matcher {
+	return gomock.Cond(func(content []byte) bool {
+		var securityEventDTO entities.SecurityEventDTO
+		if err := json.Unmarshal(content, &securityEventDTO); err != nil {
+			return false
+		}
+
+		return securityEventDTO.Type == eventType &&
+			securityEventDTO.UserID == userID &&
+			securityEventDTO.FamilyID == familyID
+	})
+}
+
 func TestUseCases_RegisterUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	authService := mockservices.NewMockAuthService(ctrl)
 	usersService := mockservices.NewMockUsersService(ctrl)
 	natsPublisher := mocknats.NewMockPublisher(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
-	cacheProvider := mockcache.NewMockProvider(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
 
 	securityConfig := security.Config{
-		HashCost: 10,
 		JWT: security.JWTConfig{
 			SecretKey: "secret",
 		},
@@ -56,6 +269,15 @@ func TestUseCases_RegisterUser(t *testing.T) {
 		authService,
 		usersService,
 		securityConfig,
+		nil, // JWT is not used
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
 		validationConfig,
 		natsPublisher,
 		natsConfig,
@@ -71,7 +293,7 @@ func TestUseCases_RegisterUser(t *testing.T) {
 			usersService *mockservices.MockUsersService,
 			natsPublisher *mocknats.MockPublisher,
 			logger *mocklogging.MockLogger,
-			cacheProvider *mockcache.MockProvider,
+			cacheProvider *mockcache.MockCacheProvider,
 		)
 		expectedID  uint64
 		expectedErr error
@@ -88,7 +310,7 @@ func TestUseCases_RegisterUser(t *testing.T) {
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
 				authService.
 					EXPECT().
@@ -96,11 +318,15 @@ func TestUseCases_RegisterUser(t *testing.T) {
 					Return(uint64(1), nil).
 					Times(1)
 
-				verifyEmailDTO := notifications.VerifyEmailDTO{UserID: uint64(1)}
-				content, _ := json.Marshal(verifyEmailDTO)
+				authService.
+					EXPECT().
+					CreateVerifyEmailToken(gomock.Any(), gomock.Any()).
+					Return(uint64(1), nil).
+					Times(1)
+
 				natsPublisher.
 					EXPECT().
-					Publish("verify-email", content).
+					Publish("verify-email", verifyEmailContent(1)).
 					Return(nil).
 					Times(1)
 			},
@@ -149,7 +375,7 @@ func TestUseCases_RegisterUser(t *testing.T) {
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
 				authService.
 					EXPECT().
@@ -157,11 +383,15 @@ func TestUseCases_RegisterUser(t *testing.T) {
 					Return(uint64(1), nil).
 					Times(1)
 
-				verifyEmailDTO := notifications.VerifyEmailDTO{UserID: uint64(1)}
-				content, _ := json.Marshal(verifyEmailDTO)
+				authService.
+					EXPECT().
+					CreateVerifyEmailToken(gomock.Any(), gomock.Any()).
+					Return(uint64(1), nil).
+					Times(1)
+
 				natsPublisher.
 					EXPECT().
-					Publish("verify-email", content).
+					Publish("verify-email", verifyEmailContent(1)).
 					Return(errors.New("publish failed")).
 					Times(1)
 
@@ -173,6 +403,40 @@ func TestUseCases_RegisterUser(t *testing.T) {
 			expectedID:  1,
 			expectedErr: nil,
 		},
+		{
+			name: "create verify-email token error",
+			userData: entities.RegisterUserDTO{
+				Email:       "test@example.com",
+				Password:    "Password123@",
+				DisplayName: "Иван",
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					RegisterUser(gomock.Any(), gomock.Any()).
+					Return(uint64(1), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateVerifyEmailToken(gomock.Any(), gomock.Any()).
+					Return(uint64(0), errors.New("create failed")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedID:  1,
+			expectedErr: nil,
+		},
 		{
 			name: "error",
 			userData: entities.RegisterUserDTO{
@@ -185,7 +449,7 @@ func TestUseCases_RegisterUser(t *testing.T) {
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
 				authService.
 					EXPECT().
@@ -236,7 +500,7 @@ func TestUseCases_LoginUser(t *testing.T) {
 	usersService := mockservices.NewMockUsersService(ctrl)
 	natsPublisher := mocknats.NewMockPublisher(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
-	cacheProvider := mockcache.NewMockProvider(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
 
 	securityConfig := security.Config{
 		JWT: security.JWTConfig{
@@ -245,7 +509,6 @@ func TestUseCases_LoginUser(t *testing.T) {
 			AccessTokenTTL:  time.Hour,
 			RefreshTokenTTL: time.Hour,
 		},
-		HashCost: 10,
 	}
 	natsConfig := config.NATSConfig{}
 
@@ -253,6 +516,15 @@ func TestUseCases_LoginUser(t *testing.T) {
 		authService,
 		usersService,
 		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
 		validationConfig,
 		natsPublisher,
 		natsConfig,
@@ -260,6 +532,12 @@ func TestUseCases_LoginUser(t *testing.T) {
 		cacheProvider,
 	)
 
+	clientInfo := entities.ClientInfo{
+		DeviceName: "iPhone",
+		UserAgent:  "grpc-go/1.70.0",
+		IP:         "127.0.0.1",
+	}
+
 	testCases := []struct {
 		name       string
 		userData   entities.LoginUserDTO
@@ -268,23 +546,31 @@ func TestUseCases_LoginUser(t *testing.T) {
 			usersService *mockservices.MockUsersService,
 			natsPublisher *mocknats.MockPublisher,
 			logger *mocklogging.MockLogger,
-			cacheProvider *mockcache.MockProvider,
+			cacheProvider *mockcache.MockCacheProvider,
 		)
+		mfaRequired bool
 		expectedErr error
 	}{
 		{
 			name: "success",
 			userData: entities.LoginUserDTO{
-				Email:    "test@example.com",
-				Password: "password123",
+				Identifier: "test@example.com",
+				Password:   "password123",
+				ClientInfo: clientInfo,
 			},
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(nil, &customerrors.MFANotEnabledError{}).
+					Times(1)
+
 				hashedPassword, _ := security.Hash("password123", 10)
 				usersService.
 					EXPECT().
@@ -299,63 +585,125 @@ func TestUseCases_LoginUser(t *testing.T) {
 
 				authService.
 					EXPECT().
-					GetRefreshTokenByUserID(gomock.Any(), uint64(1)).
-					Return(nil, errors.New("not found")).
+					ExpireForgetPasswordTokens(gomock.Any(), uint64(1)).
+					Return(nil).
 					Times(1)
 
 				authService.
 					EXPECT().
-					CreateRefreshToken(
+					CreateSession(
 						gomock.Any(),
-						uint64(1),
-						gomock.Any(),
-						time.Hour,
+						entities.CreateSessionDTO{
+							UserID:     1,
+							ClientInfo: clientInfo,
+							TTL:        time.Hour,
+						},
 					).
+					Return(uint64(2), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateRefreshToken(gomock.Any(), refreshTokenData(1, 2, "")).
 					Return(uint64(1), nil).
 					Times(1)
+
+				expectLoginIsNotLocked(cacheProvider, clientInfo.IP, 1)
+				expectLoginFailuresAreReset(cacheProvider, 1)
 			},
 			expectedErr: nil,
 		},
 		{
-			name: "email not confirmed",
+			name: "success with outdated password hash",
 			userData: entities.LoginUserDTO{
-				Email:    "test@example.com",
-				Password: "password123",
+				Identifier: "test@example.com",
+				Password:   "password123",
+				ClientInfo: clientInfo,
 			},
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(nil, &customerrors.MFANotEnabledError{}).
+					Times(1)
+
+				// Hash with lower cost than configured one is outdated:
+				hashedPassword, _ := security.Hash("password123", 4)
 				usersService.
 					EXPECT().
 					GetUserByEmail(gomock.Any(), "test@example.com").
 					Return(&entities.User{
 						ID:             1,
 						Email:          "test@example.com",
-						Password:       "hashed_password",
-						EmailConfirmed: false,
+						Password:       hashedPassword,
+						EmailConfirmed: true,
 					}, nil).
 					Times(1)
+
+				authService.
+					EXPECT().
+					ChangePassword(gomock.Any(), uint64(1), rehashedPassword("password123")).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ExpireForgetPasswordTokens(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateSession(
+						gomock.Any(),
+						entities.CreateSessionDTO{
+							UserID:     1,
+							ClientInfo: clientInfo,
+							TTL:        time.Hour,
+						},
+					).
+					Return(uint64(2), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateRefreshToken(gomock.Any(), refreshTokenData(1, 2, "")).
+					Return(uint64(1), nil).
+					Times(1)
+
+				expectLoginIsNotLocked(cacheProvider, clientInfo.IP, 1)
+				expectLoginFailuresAreReset(cacheProvider, 1)
 			},
-			expectedErr: &customerrors.EmailIsNotConfirmedError{},
+			expectedErr: nil,
 		},
 		{
-			name: "wrong password",
+			name: "rehash error does not prevent login",
 			userData: entities.LoginUserDTO{
-				Email:    "test@example.com",
-				Password: "wrong_password",
+				Identifier: "test@example.com",
+				Password:   "password123",
+				ClientInfo: clientInfo,
 			},
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
-				hashedPassword, _ := security.Hash("password123", 10)
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(nil, &customerrors.MFANotEnabledError{}).
+					Times(1)
+
+				// Hash with lower cost than configured one is outdated:
+				hashedPassword, _ := security.Hash("password123", 4)
 				usersService.
 					EXPECT().
 					GetUserByEmail(gomock.Any(), "test@example.com").
@@ -366,212 +714,2181 @@ func TestUseCases_LoginUser(t *testing.T) {
 						EmailConfirmed: true,
 					}, nil).
 					Times(1)
-			},
-			expectedErr: &customerrors.WrongPasswordError{},
-		},
-		{
-			name: "user not found",
-			userData: entities.LoginUserDTO{
-				Email:    "test@example.com",
-				Password: "wrong_password",
-			},
-			setupMocks: func(
-				authService *mockservices.MockAuthService,
-				usersService *mockservices.MockUsersService,
-				natsPublisher *mocknats.MockPublisher,
-				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
-			) {
-				usersService.
+
+				authService.
 					EXPECT().
-					GetUserByEmail(gomock.Any(), "test@example.com").
-					Return(nil, &customerrors.UserNotFoundError{}).
+					ChangePassword(gomock.Any(), uint64(1), rehashedPassword("password123")).
+					Return(errors.New("update failed")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ExpireForgetPasswordTokens(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateSession(
+						gomock.Any(),
+						entities.CreateSessionDTO{
+							UserID:     1,
+							ClientInfo: clientInfo,
+							TTL:        time.Hour,
+						},
+					).
+					Return(uint64(2), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateRefreshToken(gomock.Any(), refreshTokenData(1, 2, "")).
+					Return(uint64(1), nil).
 					Times(1)
+
+				expectLoginIsNotLocked(cacheProvider, clientInfo.IP, 1)
+				expectLoginFailuresAreReset(cacheProvider, 1)
 			},
-			expectedErr: &customerrors.UserNotFoundError{},
+			expectedErr: nil,
 		},
 		{
-			name: "expire refresh token error",
+			name: "success with phone",
 			userData: entities.LoginUserDTO{
-				Email:    "test@example.com",
-				Password: "password123",
+				Identifier: "8 999 123-45-67",
+				Password:   "password123",
+				ClientInfo: clientInfo,
 			},
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(nil, &customerrors.MFANotEnabledError{}).
+					Times(1)
+
 				hashedPassword, _ := security.Hash("password123", 10)
 				usersService.
 					EXPECT().
-					GetUserByEmail(gomock.Any(), "test@example.com").
+					GetUserByPhone(gomock.Any(), "79991234567").
 					Return(&entities.User{
 						ID:             1,
 						Email:          "test@example.com",
 						Password:       hashedPassword,
-						EmailConfirmed: true,
+						Phone:          pointers.New("8 999 123-45-67"),
+						PhoneConfirmed: true,
 					}, nil).
 					Times(1)
 
 				authService.
 					EXPECT().
-					GetRefreshTokenByUserID(gomock.Any(), uint64(1)).
-					Return(&entities.RefreshToken{}, nil).
+					ExpireForgetPasswordTokens(gomock.Any(), uint64(1)).
+					Return(nil).
 					Times(1)
 
 				authService.
 					EXPECT().
-					ExpireRefreshToken(gomock.Any(), gomock.Any()).
-					Return(errors.New("test")).
+					CreateSession(
+						gomock.Any(),
+						entities.CreateSessionDTO{
+							UserID:     1,
+							ClientInfo: clientInfo,
+							TTL:        time.Hour,
+						},
+					).
+					Return(uint64(2), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateRefreshToken(gomock.Any(), refreshTokenData(1, 2, "")).
+					Return(uint64(1), nil).
 					Times(1)
+
+				expectLoginIsNotLocked(cacheProvider, clientInfo.IP, 1)
+				expectLoginFailuresAreReset(cacheProvider, 1)
 			},
-			expectedErr: errors.New("test"),
+			expectedErr: nil,
 		},
 		{
-			name: "create refresh token error",
+			name: "success with telegram",
 			userData: entities.LoginUserDTO{
-				Email:    "test@example.com",
-				Password: "password123",
+				Identifier: "@Ivan_Petrov",
+				Password:   "password123",
+				ClientInfo: clientInfo,
 			},
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(nil, &customerrors.MFANotEnabledError{}).
+					Times(1)
+
 				hashedPassword, _ := security.Hash("password123", 10)
 				usersService.
 					EXPECT().
-					GetUserByEmail(gomock.Any(), "test@example.com").
+					GetUserByTelegram(gomock.Any(), "@Ivan_Petrov").
 					Return(&entities.User{
-						ID:             1,
-						Email:          "test@example.com",
-						Password:       hashedPassword,
-						EmailConfirmed: true,
+						ID:                1,
+						Email:             "test@example.com",
+						Password:          hashedPassword,
+						Telegram:          pointers.New("@ivan_petrov"),
+						TelegramConfirmed: true,
 					}, nil).
 					Times(1)
 
 				authService.
 					EXPECT().
-					GetRefreshTokenByUserID(gomock.Any(), uint64(1)).
-					Return(nil, &customerrors.RefreshTokenNotFoundError{}).
+					ExpireForgetPasswordTokens(gomock.Any(), uint64(1)).
+					Return(nil).
 					Times(1)
 
 				authService.
 					EXPECT().
-					CreateRefreshToken(
+					CreateSession(
 						gomock.Any(),
-						uint64(1),
-						gomock.Any(),
-						time.Hour,
+						entities.CreateSessionDTO{
+							UserID:     1,
+							ClientInfo: clientInfo,
+							TTL:        time.Hour,
+						},
 					).
-					Return(uint64(0), errors.New("test")).
+					Return(uint64(2), nil).
 					Times(1)
-			},
-			expectedErr: errors.New("test"),
-		},
-	}
 
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			if tc.setupMocks != nil {
-				tc.setupMocks(
-					authService,
-					usersService,
-					natsPublisher,
-					logger,
-					cacheProvider,
-				)
-			}
+				authService.
+					EXPECT().
+					CreateRefreshToken(gomock.Any(), refreshTokenData(1, 2, "")).
+					Return(uint64(1), nil).
+					Times(1)
 
-			tokens, err := useCases.LoginUser(context.Background(), tc.userData)
-			if tc.expectedErr != nil {
-				require.Error(t, err)
-				require.IsType(t, tc.expectedErr, err)
-			} else {
-				require.NoError(t, err)
-				require.NotZero(t, tokens.AccessToken)
-				require.NotZero(t, tokens.RefreshToken)
+				expectLoginIsNotLocked(cacheProvider, clientInfo.IP, 1)
+				expectLoginFailuresAreReset(cacheProvider, 1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "phone not confirmed",
+			userData: entities.LoginUserDTO{
+				Identifier: "+79991234567",
+				Password:   "password123",
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByPhone(gomock.Any(), "79991234567").
+					Return(nil, &customerrors.UserNotFoundError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.UserNotFoundError{},
+		},
+		{
+			name: "email not confirmed",
+			userData: entities.LoginUserDTO{
+				Identifier: "test@example.com",
+				Password:   "password123",
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{
+						ID:             1,
+						Email:          "test@example.com",
+						Password:       "hashed_password",
+						EmailConfirmed: false,
+					}, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.EmailIsNotConfirmedError{},
+		},
+		{
+			name: "wrong password",
+			userData: entities.LoginUserDTO{
+				Identifier: "test@example.com",
+				Password:   "wrong_password",
+				ClientInfo: clientInfo,
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				hashedPassword, _ := security.Hash("password123", 10)
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{
+						ID:             1,
+						Email:          "test@example.com",
+						Password:       hashedPassword,
+						EmailConfirmed: true,
+					}, nil).
+					Times(1)
+
+				expectLoginIsNotLocked(cacheProvider, clientInfo.IP, 1)
+
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), ipLoginFailuresCacheKey(clientInfo.IP), lockoutConfig.Window).
+					Return(int64(1), nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), loginFailuresCacheKey(1), lockoutConfig.Window).
+					Return(int64(1), nil).
+					Times(1)
+
+				// First failed attempt delays next one:
+				cacheProvider.
+					EXPECT().
+					Set(gomock.Any(), loginLockCacheKey(1), gomock.Any(), lockoutConfig.BaseDelay).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.WrongPasswordError{},
+		},
+		{
+			name: "user not found",
+			userData: entities.LoginUserDTO{
+				Identifier: "test@example.com",
+				Password:   "wrong_password",
+				ClientInfo: clientInfo,
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(nil, &customerrors.UserNotFoundError{}).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), ipLoginLockCacheKey(clientInfo.IP)).
+					Return("", errors.New("redis: nil")).
+					Times(1)
+
+				// Guessing of identifiers is counted for client IP too:
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), ipLoginFailuresCacheKey(clientInfo.IP), lockoutConfig.Window).
+					Return(int64(2), nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.UserNotFoundError{},
+		},
+		{
+			name: "login from ip is locked",
+			userData: entities.LoginUserDTO{
+				Identifier: "test@example.com",
+				Password:   "password123",
+				ClientInfo: clientInfo,
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), ipLoginLockCacheKey(clientInfo.IP)).
+					Return(strconv.FormatInt(time.Now().Add(time.Minute).UnixMilli(), 10), nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.AccountLockedError{},
+		},
+		{
+			name: "account is locked",
+			userData: entities.LoginUserDTO{
+				Identifier: "test@example.com",
+				Password:   "password123",
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{
+						ID:             1,
+						Email:          "test@example.com",
+						Password:       "hashed_password",
+						EmailConfirmed: true,
+					}, nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					Get(gomock.Any(), loginLockCacheKey(1)).
+					Return(strconv.FormatInt(time.Now().Add(time.Minute).UnixMilli(), 10), nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.AccountLockedError{},
+		},
+		{
+			name: "account is locked after threshold of failed attempts",
+			userData: entities.LoginUserDTO{
+				Identifier: "test@example.com",
+				Password:   "wrong_password",
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				hashedPassword, _ := security.Hash("password123", 10)
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{
+						ID:             1,
+						Email:          "test@example.com",
+						Password:       hashedPassword,
+						EmailConfirmed: true,
+					}, nil).
+					Times(1)
+
+				expectLoginIsNotLocked(cacheProvider, "", 1)
+
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), loginFailuresCacheKey(1), lockoutConfig.Window).
+					Return(lockoutConfig.Threshold, nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					Set(gomock.Any(), loginLockCacheKey(1), gomock.Any(), lockoutConfig.Duration).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.AccountLockedError{},
+		},
+		{
+			name: "expire forget-password tokens error",
+			userData: entities.LoginUserDTO{
+				Identifier: "test@example.com",
+				Password:   "password123",
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				hashedPassword, _ := security.Hash("password123", 10)
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{
+						ID:             1,
+						Email:          "test@example.com",
+						Password:       hashedPassword,
+						EmailConfirmed: true,
+					}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ExpireForgetPasswordTokens(gomock.Any(), uint64(1)).
+					Return(errors.New("test")).
+					Times(1)
+
+				expectLoginIsNotLocked(cacheProvider, "", 1)
+				expectLoginFailuresAreReset(cacheProvider, 1)
+			},
+			expectedErr: errors.New("test"),
+		},
+		{
+			name: "create session error",
+			userData: entities.LoginUserDTO{
+				Identifier: "test@example.com",
+				Password:   "password123",
+				ClientInfo: clientInfo,
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(nil, &customerrors.MFANotEnabledError{}).
+					Times(1)
+
+				hashedPassword, _ := security.Hash("password123", 10)
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{
+						ID:             1,
+						Email:          "test@example.com",
+						Password:       hashedPassword,
+						EmailConfirmed: true,
+					}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ExpireForgetPasswordTokens(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Return(uint64(0), errors.New("test")).
+					Times(1)
+
+				expectLoginIsNotLocked(cacheProvider, clientInfo.IP, 1)
+				expectLoginFailuresAreReset(cacheProvider, 1)
+			},
+			expectedErr: errors.New("test"),
+		},
+		{
+			name: "create refresh token error",
+			userData: entities.LoginUserDTO{
+				Identifier: "test@example.com",
+				Password:   "password123",
+				ClientInfo: clientInfo,
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(nil, &customerrors.MFANotEnabledError{}).
+					Times(1)
+
+				hashedPassword, _ := security.Hash("password123", 10)
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{
+						ID:             1,
+						Email:          "test@example.com",
+						Password:       hashedPassword,
+						EmailConfirmed: true,
+					}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ExpireForgetPasswordTokens(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateSession(
+						gomock.Any(),
+						entities.CreateSessionDTO{
+							UserID:     1,
+							ClientInfo: clientInfo,
+							TTL:        time.Hour,
+						},
+					).
+					Return(uint64(2), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateRefreshToken(gomock.Any(), refreshTokenData(1, 2, "")).
+					Return(uint64(0), errors.New("test")).
+					Times(1)
+
+				expectLoginIsNotLocked(cacheProvider, clientInfo.IP, 1)
+				expectLoginFailuresAreReset(cacheProvider, 1)
+			},
+			expectedErr: errors.New("test"),
+		},
+		{
+			name: "blocked user",
+			userData: entities.LoginUserDTO{
+				Identifier: "test@example.com",
+				Password:   "password123",
+				ClientInfo: clientInfo,
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				hashedPassword, _ := security.Hash("password123", 10)
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{
+						ID:             1,
+						Email:          "test@example.com",
+						Password:       hashedPassword,
+						EmailConfirmed: true,
+						Blocked:        true,
+					}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ExpireForgetPasswordTokens(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(nil, &customerrors.MFANotEnabledError{}).
+					Times(1)
+
+				expectLoginIsNotLocked(cacheProvider, clientInfo.IP, 1)
+				expectLoginFailuresAreReset(cacheProvider, 1)
+			},
+			expectedErr: &customerrors.UserBlockedError{},
+		},
+		{
+			name: "blocked user with two-factor authentication enabled",
+			userData: entities.LoginUserDTO{
+				Identifier: "test@example.com",
+				Password:   "password123",
+				ClientInfo: clientInfo,
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				hashedPassword, _ := security.Hash("password123", 10)
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{
+						ID:             1,
+						Email:          "test@example.com",
+						Password:       hashedPassword,
+						EmailConfirmed: true,
+						Blocked:        true,
+					}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ExpireForgetPasswordTokens(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(&entities.TOTPSecret{ID: 1, UserID: 1, Confirmed: true}, nil).
+					Times(1)
+
+				expectLoginIsNotLocked(cacheProvider, clientInfo.IP, 1)
+				expectLoginFailuresAreReset(cacheProvider, 1)
+			},
+			expectedErr: &customerrors.UserBlockedError{},
+		},
+		{
+			name: "two-factor authentication is enabled",
+			userData: entities.LoginUserDTO{
+				Identifier: "test@example.com",
+				Password:   "password123",
+				ClientInfo: clientInfo,
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				hashedPassword, _ := security.Hash("password123", 10)
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{
+						ID:             1,
+						Email:          "test@example.com",
+						Password:       hashedPassword,
+						EmailConfirmed: true,
+					}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ExpireForgetPasswordTokens(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(&entities.TOTPSecret{ID: 1, UserID: 1, Confirmed: true}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateMFAChallenge(gomock.Any(), gomock.Any()).
+					DoAndReturn(
+						func(_ context.Context, challengeData entities.CreateMFAChallengeDTO) (uint64, error) {
+							require.Equal(t, uint64(1), challengeData.UserID)
+							require.Equal(t, tokensConfig.MFAChallenge.TTL, challengeData.TTL)
+							require.NotEmpty(t, challengeData.TokenHash)
+
+							return 1, nil
+						},
+					).
+					Times(1)
+
+				expectLoginIsNotLocked(cacheProvider, clientInfo.IP, 1)
+				expectLoginFailuresAreReset(cacheProvider, 1)
+			},
+			mfaRequired: true,
+			expectedErr: nil,
+		},
+		{
+			name: "two-factor authentication enrollment is not finished",
+			userData: entities.LoginUserDTO{
+				Identifier: "test@example.com",
+				Password:   "password123",
+				ClientInfo: clientInfo,
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				hashedPassword, _ := security.Hash("password123", 10)
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{
+						ID:             1,
+						Email:          "test@example.com",
+						Password:       hashedPassword,
+						EmailConfirmed: true,
+					}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ExpireForgetPasswordTokens(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(&entities.TOTPSecret{ID: 1, UserID: 1, Confirmed: false}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Return(uint64(2), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateRefreshToken(gomock.Any(), refreshTokenData(1, 2, "")).
+					Return(uint64(1), nil).
+					Times(1)
+
+				expectLoginIsNotLocked(cacheProvider, clientInfo.IP, 1)
+				expectLoginFailuresAreReset(cacheProvider, 1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "get totp secret error",
+			userData: entities.LoginUserDTO{
+				Identifier: "test@example.com",
+				Password:   "password123",
+				ClientInfo: clientInfo,
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				hashedPassword, _ := security.Hash("password123", 10)
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{
+						ID:             1,
+						Email:          "test@example.com",
+						Password:       hashedPassword,
+						EmailConfirmed: true,
+					}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ExpireForgetPasswordTokens(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(nil, errors.New("test")).
+					Times(1)
+
+				expectLoginIsNotLocked(cacheProvider, clientInfo.IP, 1)
+				expectLoginFailuresAreReset(cacheProvider, 1)
+			},
+			expectedErr: errors.New("test"),
+		},
+		{
+			name: "create mfa challenge error",
+			userData: entities.LoginUserDTO{
+				Identifier: "test@example.com",
+				Password:   "password123",
+				ClientInfo: clientInfo,
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				hashedPassword, _ := security.Hash("password123", 10)
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{
+						ID:             1,
+						Email:          "test@example.com",
+						Password:       hashedPassword,
+						EmailConfirmed: true,
+					}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ExpireForgetPasswordTokens(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetTOTPSecretByUserID(gomock.Any(), uint64(1)).
+					Return(&entities.TOTPSecret{ID: 1, UserID: 1, Confirmed: true}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateMFAChallenge(gomock.Any(), gomock.Any()).
+					Return(uint64(0), errors.New("test")).
+					Times(1)
+
+				expectLoginIsNotLocked(cacheProvider, clientInfo.IP, 1)
+				expectLoginFailuresAreReset(cacheProvider, 1)
+			},
+			expectedErr: errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(
+					authService,
+					usersService,
+					natsPublisher,
+					logger,
+					cacheProvider,
+				)
+			}
+
+			tokens, err := useCases.LoginUser(context.Background(), tc.userData)
+			switch {
+			case tc.expectedErr != nil:
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+			case tc.mfaRequired:
+				require.NoError(t, err)
+				require.Empty(t, tokens.AccessToken)
+				require.Empty(t, tokens.RefreshToken)
+				require.NotNil(t, tokens.MFAChallenge)
+				require.NotEmpty(t, tokens.MFAChallenge.Token)
+				require.Equal(t, tokensConfig.MFAChallenge.TTL, tokens.MFAChallenge.ExpiresIn)
+			default:
+				require.NoError(t, err)
+				require.NotZero(t, tokens.AccessToken)
+				require.NotZero(t, tokens.RefreshToken)
+				require.Equal(t, securityConfig.JWT.AccessTokenTTL, tokens.ExpiresIn)
+				require.Equal(t, entities.BearerTokenType, tokens.TokenType)
+
+				accessTokenPayload, err := useCases.parseAccessToken(tokens.AccessToken)
+				require.NoError(t, err)
+				require.NotZero(t, accessTokenPayload.ID)
+				require.NotZero(t, accessTokenPayload.UserID)
+				require.NotZero(t, accessTokenPayload.SessionID)
+				require.Equal(t, []string{entities.UserRole}, accessTokenPayload.Roles)
+			}
+		})
+	}
+}
+
+func TestUseCases_GetUserByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{}
+	natsConfig := config.NATSConfig{}
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		nil, // JWT is not used
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		natsConfig,
+		logger,
+		cacheProvider,
+	)
+
+	testCases := []struct {
+		name       string
+		id         uint64
+		setupMocks func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+			natsPublisher *mocknats.MockPublisher,
+			logger *mocklogging.MockLogger,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedUser *entities.User
+		expectedErr  error
+	}{
+		{
+			name: "success",
+			id:   1,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+			},
+			expectedUser: &entities.User{ID: 1},
+			expectedErr:  nil,
+		},
+		{
+			name: "error",
+			id:   1,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(nil, errors.New("not found")).
+					Times(1)
+			},
+			expectedUser: nil,
+			expectedErr:  errors.New("not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(
+					authService,
+					usersService,
+					natsPublisher,
+					logger,
+					cacheProvider,
+				)
+			}
+
+			user, err := useCases.GetUserByID(context.Background(), tc.id)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedUser, user)
+			}
+		})
+	}
+}
+
+func TestUseCases_GetUserByEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{}
+	natsConfig := config.NATSConfig{}
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		nil, // JWT is not used
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		natsConfig,
+		logger,
+		cacheProvider,
+	)
+
+	testCases := []struct {
+		name       string
+		email      string
+		setupMocks func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+			natsPublisher *mocknats.MockPublisher,
+			logger *mocklogging.MockLogger,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedUser *entities.User
+		expectedErr  error
+	}{
+		{
+			name:  "success",
+			email: "test@example.com",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{ID: 1, Email: "test@example.com"}, nil).
+					Times(1)
+			},
+			expectedUser: &entities.User{ID: 1, Email: "test@example.com"},
+			expectedErr:  nil,
+		},
+		{
+			name:  "error",
+			email: "test@example.com",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(nil, errors.New("not found")).
+					Times(1)
+			},
+			expectedUser: nil,
+			expectedErr:  errors.New("not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(
+					authService,
+					usersService,
+					natsPublisher,
+					logger,
+					cacheProvider,
+				)
+			}
+
+			user, err := useCases.GetUserByEmail(context.Background(), tc.email)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedUser, user)
+			}
+		})
+	}
+}
+
+func TestUseCases_GetUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{}
+	natsConfig := config.NATSConfig{}
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		nil, // JWT is not used
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		natsConfig,
+		logger,
+		cacheProvider,
+	)
+
+	testCases := []struct {
+		name       string
+		pagination *entities.Pagination
+		setupMocks func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+			natsPublisher *mocknats.MockPublisher,
+			logger *mocklogging.MockLogger,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedUsers []entities.User
+		expectedErr   error
+	}{
+		{
+			name: "success",
+			pagination: &entities.Pagination{
+				Limit:  pointers.New[uint64](1),
+				Offset: pointers.New[uint64](1),
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUsers(
+						gomock.Any(),
+						&entities.Pagination{
+							Limit:  pointers.New[uint64](1),
+							Offset: pointers.New[uint64](1),
+						},
+					).
+					Return([]entities.User{{ID: 1}, {ID: 2}}, nil).
+					Times(1)
+			},
+			expectedUsers: []entities.User{{ID: 1}, {ID: 2}},
+			expectedErr:   nil,
+		},
+		{
+			name: "error",
+			pagination: &entities.Pagination{
+				Limit:  pointers.New[uint64](1),
+				Offset: pointers.New[uint64](1),
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUsers(
+						gomock.Any(),
+						&entities.Pagination{
+							Limit:  pointers.New[uint64](1),
+							Offset: pointers.New[uint64](1),
+						},
+					).
+					Return(nil, errors.New("fetch failed")).
+					Times(1)
+			},
+			expectedUsers: nil,
+			expectedErr:   errors.New("fetch failed"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(
+					authService,
+					usersService,
+					natsPublisher,
+					logger,
+					cacheProvider,
+				)
+			}
+
+			users, err := useCases.GetUsers(context.Background(), tc.pagination)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedUsers, users)
+			}
+		})
+	}
+}
+
+func TestUseCases_UpdateUserProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+
+	natsConfig := config.NATSConfig{}
+
+	accessToken := newAccessToken(t, securityConfig.JWT, 1, 0)
+
+	invalidAccessToken, err := security.GenerateJWT(
+		"invalid",
+		securityConfig.JWT.SecretKey,
+		securityConfig.JWT.AccessTokenTTL,
+		securityConfig.JWT.Algorithm,
+	)
+	require.NoError(t, err)
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		natsConfig,
+		logger,
+		cacheProvider,
+	)
+
+	testCases := []struct {
+		name       string
+		userData   entities.RawUpdateUserProfileDTO
+		setupMocks func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+			natsPublisher *mocknats.MockPublisher,
+			logger *mocklogging.MockLogger,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			userData: entities.RawUpdateUserProfileDTO{
+				AccessToken: accessToken,
+				DisplayName: pointers.New("Иван"),
+				Phone:       pointers.New("89112580162"),
+				Telegram:    pointers.New("@tests"),
+				Avatar:      pointers.New("http://someurl"),
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 0)
+				expectUserIsNotBlocked(usersService, 1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					UpdateUserProfile(gomock.Any(), entities.UpdateUserProfileDTO{
+						UserID:      1,
+						DisplayName: pointers.New("Иван"),
+						Phone:       pointers.New("89112580162"),
+						Telegram:    pointers.New("@tests"),
+						Avatar:      pointers.New("http://someurl"),
+					}).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "invalid display name",
+			userData: entities.RawUpdateUserProfileDTO{
+				AccessToken: "valid_token",
+				DisplayName: pointers.New(""),
+			},
+			expectedErr: &validation.Error{},
+		},
+		{
+			name: "invalid phone",
+			userData: entities.RawUpdateUserProfileDTO{
+				AccessToken: "valid_token",
+				DisplayName: pointers.New("Иван"),
+				Phone:       pointers.New("t"),
+			},
+			expectedErr: &validation.Error{},
+		},
+		{
+			name: "invalid telegram",
+			userData: entities.RawUpdateUserProfileDTO{
+				AccessToken: "valid_token",
+				DisplayName: pointers.New("Иван"),
+				Telegram:    pointers.New("t"),
+			},
+			expectedErr: &validation.Error{},
+		},
+		{
+			name: "invalid access token",
+			userData: entities.RawUpdateUserProfileDTO{
+				AccessToken: "invalid_token",
+				DisplayName: pointers.New("Иван"),
+			},
+			expectedErr: &security.InvalidJWTError{},
+		},
+		{
+			name: "invalid access token payload",
+			userData: entities.RawUpdateUserProfileDTO{
+				AccessToken: invalidAccessToken,
+				DisplayName: pointers.New("Иван"),
+			},
+			expectedErr: &security.InvalidJWTError{},
+		},
+		{
+			name: "get user by id error",
+			userData: entities.RawUpdateUserProfileDTO{
+				AccessToken: accessToken,
+				DisplayName: pointers.New("Иван"),
+				Phone:       pointers.New("89112580162"),
+				Telegram:    pointers.New("@tests"),
+				Avatar:      pointers.New("http://someurl"),
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 0)
+				expectUserIsNotBlocked(usersService, 1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(nil, &customerrors.UserNotFoundError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.UserNotFoundError{},
+		},
+		{
+			name: "forbidden word in display name",
+			userData: entities.RawUpdateUserProfileDTO{
+				AccessToken: accessToken,
+				DisplayName: pointers.New("Сука"),
+				Phone:       pointers.New("89112580162"),
+				Telegram:    pointers.New("@tests"),
+				Avatar:      pointers.New("http://someurl"),
+			},
+			expectedErr: &validation.Error{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(
+					authService,
+					usersService,
+					natsPublisher,
+					logger,
+					cacheProvider,
+				)
+			}
+
+			err = useCases.UpdateUserProfile(context.Background(), tc.userData)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUseCases_GetMe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+	natsConfig := config.NATSConfig{}
+
+	accessToken := newAccessToken(t, securityConfig.JWT, 1, 0)
+
+	invalidAccessToken, err := security.GenerateJWT(
+		"invalid",
+		securityConfig.JWT.SecretKey,
+		securityConfig.JWT.AccessTokenTTL,
+		securityConfig.JWT.Algorithm,
+	)
+	require.NoError(t, err)
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		natsConfig,
+		logger,
+		cacheProvider,
+	)
+
+	testCases := []struct {
+		name        string
+		accessToken string
+		setupMocks  func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+			natsPublisher *mocknats.MockPublisher,
+			logger *mocklogging.MockLogger,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedUser *entities.User
+		expectedErr  error
+	}{
+		{
+			name:        "success",
+			accessToken: accessToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 0)
+				expectUserIsNotBlocked(usersService, 1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+			},
+			expectedUser: &entities.User{ID: 1},
+			expectedErr:  nil,
+		},
+		{
+			name:         "invalid token",
+			accessToken:  "invalid_token",
+			expectedUser: nil,
+			expectedErr:  &security.InvalidJWTError{},
+		},
+		{
+			name:        "invalid token payload",
+			accessToken: invalidAccessToken,
+			expectedErr: &security.InvalidJWTError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(
+					authService,
+					usersService,
+					natsPublisher,
+					logger,
+					cacheProvider,
+				)
+			}
+
+			user, err := useCases.GetMe(context.Background(), tc.accessToken)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedUser, user)
 			}
 		})
 	}
 }
 
-func TestUseCases_GetUserByID(t *testing.T) {
+func TestUseCases_RefreshTokens(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	authService := mockservices.NewMockAuthService(ctrl)
 	usersService := mockservices.NewMockUsersService(ctrl)
 	natsPublisher := mocknats.NewMockPublisher(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
-	cacheProvider := mockcache.NewMockProvider(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+	natsConfig := config.NATSConfig{
+		Subjects: config.NATSSubjects{
+			SecurityEvent: "security-event",
+		},
+	}
+
+	accessToken, err := security.GenerateJWT(
+		entities.AccessTokenPayload{UserID: 1, SessionID: 2},
+		securityConfig.JWT.SecretKey,
+		securityConfig.JWT.AccessTokenTTL,
+		securityConfig.JWT.Algorithm,
+	)
+	require.NoError(t, err)
+
+	invalidAccessToken, err := security.GenerateJWT(
+		"invalid",
+		securityConfig.JWT.SecretKey,
+		securityConfig.JWT.AccessTokenTTL,
+		securityConfig.JWT.Algorithm,
+	)
+	require.NoError(t, err)
+
+	refreshToken, err := security.GenerateJWT(
+		accessToken,
+		securityConfig.JWT.SecretKey,
+		securityConfig.JWT.RefreshTokenTTL,
+		securityConfig.JWT.Algorithm,
+	)
+	require.NoError(t, err)
+
+	invalidRefreshTokenInt, err := security.GenerateJWT(
+		1,
+		securityConfig.JWT.SecretKey,
+		securityConfig.JWT.RefreshTokenTTL,
+		securityConfig.JWT.Algorithm,
+	)
+	require.NoError(t, err)
+
+	invalidRefreshTokenString, err := security.GenerateJWT(
+		"invalid",
+		securityConfig.JWT.SecretKey,
+		securityConfig.JWT.RefreshTokenTTL,
+		securityConfig.JWT.Algorithm,
+	)
+	require.NoError(t, err)
+
+	invalidRefreshTokenWithInvalidAccessToken, err := security.GenerateJWT(
+		invalidAccessToken,
+		securityConfig.JWT.SecretKey,
+		securityConfig.JWT.RefreshTokenTTL,
+		securityConfig.JWT.Algorithm,
+	)
+	require.NoError(t, err)
+
+	// JWT refresh token, issued before opaque refresh tokens were introduced:
+	encodedRefreshToken := security.RawEncode([]byte(refreshToken))
+
+	opaqueRefreshToken, err := generateToken()
+	require.NoError(t, err)
+
+	opaqueRefreshTokenHash := hashRefreshToken(opaqueRefreshToken)
+
+	dbRefreshToken := &entities.RefreshToken{
+		ID:        1,
+		UserID:    1,
+		SessionID: pointers.New[uint64](2),
+		FamilyID:  pointers.New("family"),
+		Value:     hashRefreshToken(refreshToken),
+		TTL:       time.Now().UTC().Add(time.Hour),
+	}
+
+	// Refresh token, created before sessions and families were introduced:
+	legacyRefreshToken := &entities.RefreshToken{
+		ID:     1,
+		UserID: 1,
+		Value:  hashRefreshToken(refreshToken),
+		TTL:    time.Now().UTC().Add(time.Hour),
+	}
+
+	rotatedRefreshToken := *dbRefreshToken
+	rotatedRefreshToken.RotatedAt = pointers.New(time.Now().UTC())
+	rotatedRefreshToken.TTL = time.Now().UTC().Add(-time.Hour)
+
+	expiredRefreshToken := *dbRefreshToken
+	expiredRefreshToken.TTL = time.Now().UTC().Add(-time.Hour)
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		natsConfig,
+		logger,
+		cacheProvider,
+	)
+
+	testCases := []struct {
+		name         string
+		refreshToken string
+		setupMocks   func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+			natsPublisher *mocknats.MockPublisher,
+			logger *mocklogging.MockLogger,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedErr error
+	}{
+		{
+			name:         "success",
+			refreshToken: opaqueRefreshToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetRefreshTokenByValue(gomock.Any(), opaqueRefreshTokenHash).
+					Return(dbRefreshToken, nil).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					RotateRefreshToken(gomock.Any(), uint64(1), "family").
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateRefreshToken(gomock.Any(), refreshTokenData(1, 2, "family")).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:         "blocked user",
+			refreshToken: opaqueRefreshToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetRefreshTokenByValue(gomock.Any(), opaqueRefreshTokenHash).
+					Return(dbRefreshToken, nil).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(&entities.User{ID: 1, Blocked: true}, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.UserBlockedError{},
+		},
+		{
+			name:         "success for legacy refresh token",
+			refreshToken: encodedRefreshToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetRefreshTokenByValue(gomock.Any(), hashRefreshToken(refreshToken)).
+					Return(dbRefreshToken, nil).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					RotateRefreshToken(gomock.Any(), uint64(1), "family").
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateRefreshToken(gomock.Any(), refreshTokenData(1, 2, "family")).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:         "success for refresh token without session",
+			refreshToken: encodedRefreshToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetRefreshTokenByValue(gomock.Any(), hashRefreshToken(refreshToken)).
+					Return(legacyRefreshToken, nil).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					RotateRefreshToken(gomock.Any(), uint64(1), gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateSession(
+						gomock.Any(),
+						entities.CreateSessionDTO{UserID: 1, TTL: time.Hour},
+					).
+					Return(uint64(3), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateRefreshToken(gomock.Any(), refreshTokenData(1, 3, "")).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:         "create session for refresh token without session error",
+			refreshToken: encodedRefreshToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetRefreshTokenByValue(gomock.Any(), hashRefreshToken(refreshToken)).
+					Return(legacyRefreshToken, nil).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					RotateRefreshToken(gomock.Any(), uint64(1), gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Return(uint64(0), errors.New("test")).
+					Times(1)
+			},
+			expectedErr: errors.New("test"),
+		},
+		{
+			name:         "unknown refresh token",
+			refreshToken: "invalid_token",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetRefreshTokenByValue(gomock.Any(), hashRefreshToken("invalid_token")).
+					Return(nil, &customerrors.RefreshTokenNotFoundError{}).
+					Times(1)
+			},
+			expectedErr: &security.InvalidJWTError{},
+		},
+		{
+			name:         "invalid refresh token after encoding",
+			refreshToken: security.RawEncode([]byte(invalidRefreshTokenInt)),
+			expectedErr:  &security.InvalidJWTError{},
+		},
+		{
+			name:         "invalid access token payload",
+			refreshToken: security.RawEncode([]byte(invalidRefreshTokenString)),
+			expectedErr:  &security.InvalidJWTError{},
+		},
+		{
+			name:         "invalid access token payload",
+			refreshToken: security.RawEncode([]byte(invalidRefreshTokenWithInvalidAccessToken)),
+			expectedErr:  &security.InvalidJWTError{},
+		},
+		{
+			name:         "get db legacy refresh token error",
+			refreshToken: encodedRefreshToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetRefreshTokenByValue(gomock.Any(), hashRefreshToken(refreshToken)).
+					Return(nil, &customerrors.RefreshTokenNotFoundError{}).
+					Times(1)
+			},
+			expectedErr: &security.InvalidJWTError{},
+		},
+		{
+			name:         "get user error",
+			refreshToken: opaqueRefreshToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetRefreshTokenByValue(gomock.Any(), opaqueRefreshTokenHash).
+					Return(dbRefreshToken, nil).
+					Times(1)
 
-	securityConfig := security.Config{}
-	natsConfig := config.NATSConfig{}
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(nil, &customerrors.UserNotFoundError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.UserNotFoundError{},
+		},
+		{
+			name:         "rotate db refresh token error",
+			refreshToken: opaqueRefreshToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetRefreshTokenByValue(gomock.Any(), opaqueRefreshTokenHash).
+					Return(dbRefreshToken, nil).
+					Times(1)
 
-	useCases := New(
-		authService,
-		usersService,
-		securityConfig,
-		validationConfig,
-		natsPublisher,
-		natsConfig,
-		logger,
-		cacheProvider,
-	)
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
 
-	testCases := []struct {
-		name       string
-		id         uint64
-		setupMocks func(
-			authService *mockservices.MockAuthService,
-			usersService *mockservices.MockUsersService,
-			natsPublisher *mocknats.MockPublisher,
-			logger *mocklogging.MockLogger,
-			cacheProvider *mockcache.MockProvider,
-		)
-		expectedUser *entities.User
-		expectedErr  error
-	}{
+				authService.
+					EXPECT().
+					RotateRefreshToken(gomock.Any(), uint64(1), "family").
+					Return(errors.New("test")).
+					Times(1)
+			},
+			expectedErr: &security.InvalidJWTError{},
+		},
 		{
-			name: "success",
-			id:   1,
+			name:         "create db refresh token error",
+			refreshToken: opaqueRefreshToken,
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
+				authService.
+					EXPECT().
+					GetRefreshTokenByValue(gomock.Any(), opaqueRefreshTokenHash).
+					Return(dbRefreshToken, nil).
+					Times(1)
+
 				usersService.
 					EXPECT().
 					GetUserByID(gomock.Any(), uint64(1)).
 					Return(&entities.User{ID: 1}, nil).
 					Times(1)
+
+				authService.
+					EXPECT().
+					RotateRefreshToken(gomock.Any(), uint64(1), "family").
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateRefreshToken(gomock.Any(), refreshTokenData(1, 2, "")).
+					Return(uint64(0), errors.New("test")).
+					Times(1)
 			},
-			expectedUser: &entities.User{ID: 1},
-			expectedErr:  nil,
+			expectedErr: errors.New("test"),
 		},
 		{
-			name: "error",
-			id:   1,
+			name:         "access token does not belong to refresh token",
+			refreshToken: encodedRefreshToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetRefreshTokenByValue(gomock.Any(), hashRefreshToken(refreshToken)).
+					Return(&entities.RefreshToken{UserID: 2, Value: refreshToken}, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.AccessTokenDoesNotBelongToRefreshTokenError{},
+		},
+		{
+			name:         "expired refresh token",
+			refreshToken: opaqueRefreshToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetRefreshTokenByValue(gomock.Any(), opaqueRefreshTokenHash).
+					Return(&expiredRefreshToken, nil).
+					Times(1)
+			},
+			expectedErr: &security.InvalidJWTError{},
+		},
+		{
+			name:         "reuse of rotated refresh token",
+			refreshToken: opaqueRefreshToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectSessionsAreRevoked(cacheProvider, 2)
+
+				authService.
+					EXPECT().
+					GetRefreshTokenByValue(gomock.Any(), opaqueRefreshTokenHash).
+					Return(&rotatedRefreshToken, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					RevokeRefreshTokenFamily(gomock.Any(), "family").
+					Return(nil).
+					Times(1)
+
+				natsPublisher.
+					EXPECT().
+					Publish(
+						"security-event",
+						securityEventContent(entities.RefreshTokenReuseSecurityEvent, 1, "family"),
+					).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.RefreshTokenReuseDetectedError{},
+		},
+		{
+			name:         "refresh token rotated by concurrent request",
+			refreshToken: opaqueRefreshToken,
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
+				expectSessionsAreRevoked(cacheProvider, 2)
+
+				authService.
+					EXPECT().
+					GetRefreshTokenByValue(gomock.Any(), opaqueRefreshTokenHash).
+					Return(dbRefreshToken, nil).
+					Times(1)
+
 				usersService.
 					EXPECT().
 					GetUserByID(gomock.Any(), uint64(1)).
-					Return(nil, errors.New("not found")).
+					Return(&entities.User{ID: 1}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					RotateRefreshToken(gomock.Any(), uint64(1), "family").
+					Return(&customerrors.RefreshTokenReuseDetectedError{}).
+					Times(1)
+
+				authService.
+					EXPECT().
+					RevokeRefreshTokenFamily(gomock.Any(), "family").
+					Return(nil).
+					Times(1)
+
+				natsPublisher.
+					EXPECT().
+					Publish(
+						"security-event",
+						securityEventContent(entities.RefreshTokenReuseSecurityEvent, 1, "family"),
+					).
+					Return(nil).
 					Times(1)
 			},
-			expectedUser: nil,
-			expectedErr:  errors.New("not found"),
+			expectedErr: &customerrors.RefreshTokenReuseDetectedError{},
+		},
+		{
+			name:         "revoke refresh token family error",
+			refreshToken: opaqueRefreshToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetRefreshTokenByValue(gomock.Any(), opaqueRefreshTokenHash).
+					Return(&rotatedRefreshToken, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					RevokeRefreshTokenFamily(gomock.Any(), "family").
+					Return(errors.New("test")).
+					Times(1)
+			},
+			expectedErr: errors.New("test"),
+		},
+		{
+			name:         "publish security event error",
+			refreshToken: opaqueRefreshToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectSessionsAreRevoked(cacheProvider, 2)
+
+				authService.
+					EXPECT().
+					GetRefreshTokenByValue(gomock.Any(), opaqueRefreshTokenHash).
+					Return(&rotatedRefreshToken, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					RevokeRefreshTokenFamily(gomock.Any(), "family").
+					Return(nil).
+					Times(1)
+
+				natsPublisher.
+					EXPECT().
+					Publish("security-event", gomock.Any()).
+					Return(errors.New("test")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: &customerrors.RefreshTokenReuseDetectedError{},
 		},
 	}
 
@@ -587,33 +2904,61 @@ func TestUseCases_GetUserByID(t *testing.T) {
 				)
 			}
 
-			user, err := useCases.GetUserByID(context.Background(), tc.id)
+			tokens, err := useCases.RefreshTokens(context.Background(), tc.refreshToken)
 			if tc.expectedErr != nil {
 				require.Error(t, err)
-				require.Equal(t, tc.expectedErr.Error(), err.Error())
+				require.IsType(t, tc.expectedErr, err)
 			} else {
 				require.NoError(t, err)
-				require.Equal(t, tc.expectedUser, user)
+				require.NotZero(t, tokens.AccessToken)
+				require.NotZero(t, tokens.RefreshToken)
 			}
 		})
 	}
 }
 
-func TestUseCases_GetUserByEmail(t *testing.T) {
+func TestUseCases_LogoutUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	authService := mockservices.NewMockAuthService(ctrl)
 	usersService := mockservices.NewMockUsersService(ctrl)
 	natsPublisher := mocknats.NewMockPublisher(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
-	cacheProvider := mockcache.NewMockProvider(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
 
-	securityConfig := security.Config{}
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:      "secret",
+			Algorithm:      "HS256",
+			AccessTokenTTL: time.Hour,
+		},
+	}
 	natsConfig := config.NATSConfig{}
 
+	accessToken := newAccessToken(t, securityConfig.JWT, 1, 2)
+
+	accessTokenWithoutSession := newAccessToken(t, securityConfig.JWT, 1, 0)
+
+	invalidAccessToken, err := security.GenerateJWT(
+		"invalid",
+		securityConfig.JWT.SecretKey,
+		securityConfig.JWT.AccessTokenTTL,
+		securityConfig.JWT.Algorithm,
+	)
+	require.NoError(t, err)
+
 	useCases := New(
 		authService,
 		usersService,
 		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
 		validationConfig,
 		natsPublisher,
 		natsConfig,
@@ -622,55 +2967,89 @@ func TestUseCases_GetUserByEmail(t *testing.T) {
 	)
 
 	testCases := []struct {
-		name       string
-		email      string
-		setupMocks func(
+		name        string
+		accessToken string
+		setupMocks  func(
 			authService *mockservices.MockAuthService,
 			usersService *mockservices.MockUsersService,
 			natsPublisher *mocknats.MockPublisher,
 			logger *mocklogging.MockLogger,
-			cacheProvider *mockcache.MockProvider,
+			cacheProvider *mockcache.MockCacheProvider,
 		)
-		expectedUser *entities.User
-		expectedErr  error
+		expectedErr error
 	}{
 		{
-			name:  "success",
-			email: "test@example.com",
+			name:        "success",
+			accessToken: accessToken,
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
-				usersService.
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+				expectSessionsAreRevoked(cacheProvider, 2)
+
+				authService.
 					EXPECT().
-					GetUserByEmail(gomock.Any(), "test@example.com").
-					Return(&entities.User{ID: 1, Email: "test@example.com"}, nil).
+					ExpireSession(gomock.Any(), uint64(1), uint64(2)).
+					Return(nil).
 					Times(1)
 			},
-			expectedUser: &entities.User{ID: 1, Email: "test@example.com"},
-			expectedErr:  nil,
+			expectedErr: nil,
 		},
 		{
-			name:  "error",
-			email: "test@example.com",
+			name:        "access token without session",
+			accessToken: accessTokenWithoutSession,
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
-				usersService.
+				expectAccessTokenIsNotRevoked(cacheProvider, 0)
+				expectUserIsNotBlocked(usersService, 1)
+				cacheProvider.
 					EXPECT().
-					GetUserByEmail(gomock.Any(), "test@example.com").
-					Return(nil, errors.New("not found")).
+					Set(gomock.Any(), revokedAccessTokenCacheKey("jti"), revokedCacheValue, gomock.Any()).
+					Return(nil).
 					Times(1)
 			},
-			expectedUser: nil,
-			expectedErr:  errors.New("not found"),
+			expectedErr: nil,
+		},
+		{
+			name:        "expire session error",
+			accessToken: accessToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					ExpireSession(gomock.Any(), uint64(1), uint64(2)).
+					Return(errors.New("test")).
+					Times(1)
+			},
+			expectedErr: errors.New("test"),
+		},
+		{
+			name:        "invalid token",
+			accessToken: "invalid_token",
+			expectedErr: &security.InvalidJWTError{},
+		},
+		{
+			name:        "invalid token payload",
+			accessToken: invalidAccessToken,
+			expectedErr: &security.InvalidJWTError{},
 		},
 	}
 
@@ -686,33 +3065,59 @@ func TestUseCases_GetUserByEmail(t *testing.T) {
 				)
 			}
 
-			user, err := useCases.GetUserByEmail(context.Background(), tc.email)
+			err = useCases.LogoutUser(context.Background(), tc.accessToken)
 			if tc.expectedErr != nil {
 				require.Error(t, err)
-				require.Equal(t, tc.expectedErr.Error(), err.Error())
+				require.IsType(t, tc.expectedErr, err)
 			} else {
 				require.NoError(t, err)
-				require.Equal(t, tc.expectedUser, user)
 			}
 		})
 	}
 }
 
-func TestUseCases_GetUsers(t *testing.T) {
+func TestUseCases_LogoutUserEverywhere(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	authService := mockservices.NewMockAuthService(ctrl)
 	usersService := mockservices.NewMockUsersService(ctrl)
 	natsPublisher := mocknats.NewMockPublisher(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
-	cacheProvider := mockcache.NewMockProvider(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
 
-	securityConfig := security.Config{}
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:      "secret",
+			Algorithm:      "HS256",
+			AccessTokenTTL: time.Hour,
+		},
+	}
 	natsConfig := config.NATSConfig{}
 
+	accessToken := newAccessToken(t, securityConfig.JWT, 1, 2)
+
+	accessTokenWithoutSession := newAccessToken(t, securityConfig.JWT, 1, 0)
+
+	invalidAccessToken, err := security.GenerateJWT(
+		"invalid",
+		securityConfig.JWT.SecretKey,
+		securityConfig.JWT.AccessTokenTTL,
+		securityConfig.JWT.Algorithm,
+	)
+	require.NoError(t, err)
+
 	useCases := New(
 		authService,
 		usersService,
 		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
 		validationConfig,
 		natsPublisher,
 		natsConfig,
@@ -721,73 +3126,108 @@ func TestUseCases_GetUsers(t *testing.T) {
 	)
 
 	testCases := []struct {
-		name       string
-		pagination *entities.Pagination
-		setupMocks func(
+		name        string
+		accessToken string
+		setupMocks  func(
 			authService *mockservices.MockAuthService,
 			usersService *mockservices.MockUsersService,
 			natsPublisher *mocknats.MockPublisher,
 			logger *mocklogging.MockLogger,
-			cacheProvider *mockcache.MockProvider,
+			cacheProvider *mockcache.MockCacheProvider,
 		)
-		expectedUsers []entities.User
-		expectedErr   error
+		expectedErr error
 	}{
 		{
-			name: "success",
-			pagination: &entities.Pagination{
-				Limit:  pointers.New[uint64](1),
-				Offset: pointers.New[uint64](1),
-			},
+			name:        "success",
+			accessToken: accessToken,
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
-				usersService.
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+				authService.
 					EXPECT().
-					GetUsers(
-						gomock.Any(),
-						&entities.Pagination{
-							Limit:  pointers.New[uint64](1),
-							Offset: pointers.New[uint64](1),
-						},
-					).
-					Return([]entities.User{{ID: 1}, {ID: 2}}, nil).
+					GetUserSessions(gomock.Any(), uint64(1)).
+					Return([]entities.Session{{ID: 2, UserID: 1}, {ID: 3, UserID: 1}}, nil).
+					Times(1)
+
+				expectSessionsAreRevoked(cacheProvider, 3)
+
+				authService.
+					EXPECT().
+					ExpireUserSessions(gomock.Any(), uint64(1), uint64(2)).
+					Return(nil).
 					Times(1)
 			},
-			expectedUsers: []entities.User{{ID: 1}, {ID: 2}},
-			expectedErr:   nil,
+			expectedErr: nil,
 		},
 		{
-			name: "error",
-			pagination: &entities.Pagination{
-				Limit:  pointers.New[uint64](1),
-				Offset: pointers.New[uint64](1),
+			name:        "access token without session",
+			accessToken: accessTokenWithoutSession,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 0)
+				expectUserIsNotBlocked(usersService, 1)
+				authService.
+					EXPECT().
+					GetUserSessions(gomock.Any(), uint64(1)).
+					Return([]entities.Session{{ID: 2, UserID: 1}, {ID: 3, UserID: 1}}, nil).
+					Times(1)
+
+				expectSessionsAreRevoked(cacheProvider, 2, 3)
+
+				authService.
+					EXPECT().
+					ExpireUserSessions(gomock.Any(), uint64(1), uint64(0)).
+					Return(nil).
+					Times(1)
 			},
+			expectedErr: nil,
+		},
+		{
+			name:        "expire sessions error",
+			accessToken: accessToken,
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
-				usersService.
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+				authService.
 					EXPECT().
-					GetUsers(
-						gomock.Any(),
-						&entities.Pagination{
-							Limit:  pointers.New[uint64](1),
-							Offset: pointers.New[uint64](1),
-						},
-					).
-					Return(nil, errors.New("fetch failed")).
+					GetUserSessions(gomock.Any(), uint64(1)).
+					Return([]entities.Session{{ID: 2, UserID: 1}, {ID: 3, UserID: 1}}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ExpireUserSessions(gomock.Any(), uint64(1), uint64(2)).
+					Return(errors.New("test")).
 					Times(1)
 			},
-			expectedUsers: nil,
-			expectedErr:   errors.New("fetch failed"),
+			expectedErr: errors.New("test"),
+		},
+		{
+			name:        "invalid token",
+			accessToken: "invalid_token",
+			expectedErr: &security.InvalidJWTError{},
+		},
+		{
+			name:        "invalid token payload",
+			accessToken: invalidAccessToken,
+			expectedErr: &security.InvalidJWTError{},
 		},
 	}
 
@@ -803,45 +3243,37 @@ func TestUseCases_GetUsers(t *testing.T) {
 				)
 			}
 
-			users, err := useCases.GetUsers(context.Background(), tc.pagination)
+			err = useCases.LogoutUserEverywhere(context.Background(), tc.accessToken)
 			if tc.expectedErr != nil {
 				require.Error(t, err)
-				require.Equal(t, tc.expectedErr.Error(), err.Error())
+				require.IsType(t, tc.expectedErr, err)
 			} else {
 				require.NoError(t, err)
-				require.Equal(t, tc.expectedUsers, users)
 			}
 		})
 	}
 }
 
-func TestUseCases_UpdateUserProfile(t *testing.T) {
+func TestUseCases_GetUserSessions(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	authService := mockservices.NewMockAuthService(ctrl)
 	usersService := mockservices.NewMockUsersService(ctrl)
 	natsPublisher := mocknats.NewMockPublisher(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
-	cacheProvider := mockcache.NewMockProvider(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
 
 	securityConfig := security.Config{
 		JWT: security.JWTConfig{
-			SecretKey:       "secret",
-			Algorithm:       "HS256",
-			AccessTokenTTL:  time.Hour,
-			RefreshTokenTTL: time.Hour,
+			SecretKey:      "secret",
+			Algorithm:      "HS256",
+			AccessTokenTTL: time.Hour,
 		},
-		HashCost: 10,
 	}
-
 	natsConfig := config.NATSConfig{}
 
-	accessToken, err := security.GenerateJWT(
-		uint64(1),
-		securityConfig.JWT.SecretKey,
-		securityConfig.JWT.AccessTokenTTL,
-		securityConfig.JWT.Algorithm,
-	)
-	require.NoError(t, err)
+	accessToken := newAccessToken(t, securityConfig.JWT, 1, 2)
+
+	accessTokenWithoutSession := newAccessToken(t, securityConfig.JWT, 1, 0)
 
 	invalidAccessToken, err := security.GenerateJWT(
 		"invalid",
@@ -855,6 +3287,15 @@ func TestUseCases_UpdateUserProfile(t *testing.T) {
 		authService,
 		usersService,
 		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
 		validationConfig,
 		natsPublisher,
 		natsConfig,
@@ -863,129 +3304,98 @@ func TestUseCases_UpdateUserProfile(t *testing.T) {
 	)
 
 	testCases := []struct {
-		name       string
-		userData   entities.RawUpdateUserProfileDTO
-		setupMocks func(
+		name        string
+		accessToken string
+		setupMocks  func(
 			authService *mockservices.MockAuthService,
 			usersService *mockservices.MockUsersService,
 			natsPublisher *mocknats.MockPublisher,
 			logger *mocklogging.MockLogger,
-			cacheProvider *mockcache.MockProvider,
+			cacheProvider *mockcache.MockCacheProvider,
 		)
+		expected    *entities.SessionsDTO
 		expectedErr error
 	}{
 		{
-			name: "success",
-			userData: entities.RawUpdateUserProfileDTO{
-				AccessToken: accessToken,
-				DisplayName: pointers.New("Иван"),
-				Phone:       pointers.New("89112580162"),
-				Telegram:    pointers.New("@tests"),
-				Avatar:      pointers.New("http://someurl"),
-			},
+			name:        "success",
+			accessToken: accessToken,
 			setupMocks: func(
-				authService *mockservices.MockAuthService,
-				usersService *mockservices.MockUsersService,
-				natsPublisher *mocknats.MockPublisher,
-				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
-			) {
-				usersService.
-					EXPECT().
-					GetUserByID(gomock.Any(), uint64(1)).
-					Return(&entities.User{ID: 1}, nil).
-					Times(1)
-
-				usersService.
-					EXPECT().
-					UpdateUserProfile(gomock.Any(), entities.UpdateUserProfileDTO{
-						UserID:      1,
-						DisplayName: pointers.New("Иван"),
-						Phone:       pointers.New("89112580162"),
-						Telegram:    pointers.New("@tests"),
-						Avatar:      pointers.New("http://someurl"),
-					}).
-					Return(nil).
-					Times(1)
-			},
-			expectedErr: nil,
-		},
-		{
-			name: "invalid display name",
-			userData: entities.RawUpdateUserProfileDTO{
-				AccessToken: "valid_token",
-				DisplayName: pointers.New(""),
-			},
-			expectedErr: &validation.Error{},
-		},
-		{
-			name: "invalid phone",
-			userData: entities.RawUpdateUserProfileDTO{
-				AccessToken: "valid_token",
-				DisplayName: pointers.New("Иван"),
-				Phone:       pointers.New("t"),
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					GetUserSessions(gomock.Any(), uint64(1)).
+					Return([]entities.Session{{ID: 2, UserID: 1}, {ID: 3, UserID: 1}}, nil).
+					Times(1)
 			},
-			expectedErr: &validation.Error{},
-		},
-		{
-			name: "invalid telegram",
-			userData: entities.RawUpdateUserProfileDTO{
-				AccessToken: "valid_token",
-				DisplayName: pointers.New("Иван"),
-				Telegram:    pointers.New("t"),
+			expected: &entities.SessionsDTO{
+				Sessions:         []entities.Session{{ID: 2, UserID: 1}, {ID: 3, UserID: 1}},
+				CurrentSessionID: 2,
 			},
-			expectedErr: &validation.Error{},
+			expectedErr: nil,
 		},
 		{
-			name: "invalid access token",
-			userData: entities.RawUpdateUserProfileDTO{
-				AccessToken: "invalid_token",
-				DisplayName: pointers.New("Иван"),
+			name:        "access token without session",
+			accessToken: accessTokenWithoutSession,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 0)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					GetUserSessions(gomock.Any(), uint64(1)).
+					Return([]entities.Session{{ID: 2, UserID: 1}}, nil).
+					Times(1)
 			},
-			expectedErr: &security.InvalidJWTError{},
-		},
-		{
-			name: "invalid access token payload",
-			userData: entities.RawUpdateUserProfileDTO{
-				AccessToken: invalidAccessToken,
-				DisplayName: pointers.New("Иван"),
+			expected: &entities.SessionsDTO{
+				Sessions: []entities.Session{{ID: 2, UserID: 1}},
 			},
-			expectedErr: &security.InvalidJWTError{},
+			expectedErr: nil,
 		},
 		{
-			name: "get user by id error",
-			userData: entities.RawUpdateUserProfileDTO{
-				AccessToken: accessToken,
-				DisplayName: pointers.New("Иван"),
-				Phone:       pointers.New("89112580162"),
-				Telegram:    pointers.New("@tests"),
-				Avatar:      pointers.New("http://someurl"),
-			},
+			name:        "get sessions error",
+			accessToken: accessToken,
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
-				usersService.
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
 					EXPECT().
-					GetUserByID(gomock.Any(), uint64(1)).
-					Return(nil, &customerrors.UserNotFoundError{}).
+					GetUserSessions(gomock.Any(), uint64(1)).
+					Return(nil, errors.New("test")).
 					Times(1)
 			},
-			expectedErr: &customerrors.UserNotFoundError{},
+			expected:    nil,
+			expectedErr: errors.New("test"),
 		},
 		{
-			name: "forbidden word in display name",
-			userData: entities.RawUpdateUserProfileDTO{
-				AccessToken: accessToken,
-				DisplayName: pointers.New("Сука"),
-				Phone:       pointers.New("89112580162"),
-				Telegram:    pointers.New("@tests"),
-				Avatar:      pointers.New("http://someurl"),
-			},
-			expectedErr: &validation.Error{},
+			name:        "invalid token",
+			accessToken: "invalid_token",
+			expectedErr: &security.InvalidJWTError{},
+		},
+		{
+			name:        "invalid token payload",
+			accessToken: invalidAccessToken,
+			expectedErr: &security.InvalidJWTError{},
 		},
 	}
 
@@ -1001,43 +3411,39 @@ func TestUseCases_UpdateUserProfile(t *testing.T) {
 				)
 			}
 
-			err = useCases.UpdateUserProfile(context.Background(), tc.userData)
+			sessions, err := useCases.GetUserSessions(context.Background(), tc.accessToken)
 			if tc.expectedErr != nil {
 				require.Error(t, err)
 				require.IsType(t, tc.expectedErr, err)
 			} else {
 				require.NoError(t, err)
 			}
+
+			require.Equal(t, tc.expected, sessions)
 		})
 	}
 }
 
-func TestUseCases_GetMe(t *testing.T) {
+func TestUseCases_RevokeSession(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	authService := mockservices.NewMockAuthService(ctrl)
 	usersService := mockservices.NewMockUsersService(ctrl)
 	natsPublisher := mocknats.NewMockPublisher(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
-	cacheProvider := mockcache.NewMockProvider(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
 
 	securityConfig := security.Config{
 		JWT: security.JWTConfig{
-			SecretKey:       "secret",
-			Algorithm:       "HS256",
-			AccessTokenTTL:  time.Hour,
-			RefreshTokenTTL: time.Hour,
+			SecretKey:      "secret",
+			Algorithm:      "HS256",
+			AccessTokenTTL: time.Hour,
 		},
-		HashCost: 10,
 	}
 	natsConfig := config.NATSConfig{}
 
-	accessToken, err := security.GenerateJWT(
-		uint64(1),
-		securityConfig.JWT.SecretKey,
-		securityConfig.JWT.AccessTokenTTL,
-		securityConfig.JWT.Algorithm,
-	)
-	require.NoError(t, err)
+	accessToken := newAccessToken(t, securityConfig.JWT, 1, 2)
+
+	accessTokenWithoutSession := newAccessToken(t, securityConfig.JWT, 1, 0)
 
 	invalidAccessToken, err := security.GenerateJWT(
 		"invalid",
@@ -1051,6 +3457,15 @@ func TestUseCases_GetMe(t *testing.T) {
 		authService,
 		usersService,
 		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
 		validationConfig,
 		natsPublisher,
 		natsConfig,
@@ -1066,10 +3481,9 @@ func TestUseCases_GetMe(t *testing.T) {
 			usersService *mockservices.MockUsersService,
 			natsPublisher *mocknats.MockPublisher,
 			logger *mocklogging.MockLogger,
-			cacheProvider *mockcache.MockProvider,
+			cacheProvider *mockcache.MockCacheProvider,
 		)
-		expectedUser *entities.User
-		expectedErr  error
+		expectedErr error
 	}{
 		{
 			name:        "success",
@@ -1079,22 +3493,67 @@ func TestUseCases_GetMe(t *testing.T) {
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
-				usersService.
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+				expectSessionsAreRevoked(cacheProvider, 3)
+
+				authService.
 					EXPECT().
-					GetUserByID(gomock.Any(), uint64(1)).
-					Return(&entities.User{ID: 1}, nil).
+					ExpireSession(gomock.Any(), uint64(1), uint64(3)).
+					Return(nil).
 					Times(1)
 			},
-			expectedUser: &entities.User{ID: 1},
-			expectedErr:  nil,
+			expectedErr: nil,
 		},
 		{
-			name:         "invalid token",
-			accessToken:  "invalid_token",
-			expectedUser: nil,
-			expectedErr:  &security.InvalidJWTError{},
+			name:        "access token without session",
+			accessToken: accessTokenWithoutSession,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 0)
+				expectUserIsNotBlocked(usersService, 1)
+				expectSessionsAreRevoked(cacheProvider, 3)
+
+				authService.
+					EXPECT().
+					ExpireSession(gomock.Any(), uint64(1), uint64(3)).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "session not found",
+			accessToken: accessToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					ExpireSession(gomock.Any(), uint64(1), uint64(3)).
+					Return(&customerrors.SessionNotFoundError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.SessionNotFoundError{},
+		},
+		{
+			name:        "invalid token",
+			accessToken: "invalid_token",
+			expectedErr: &security.InvalidJWTError{},
 		},
 		{
 			name:        "invalid token payload",
@@ -1115,91 +3574,41 @@ func TestUseCases_GetMe(t *testing.T) {
 				)
 			}
 
-			user, err := useCases.GetMe(context.Background(), tc.accessToken)
+			err = useCases.RevokeSession(context.Background(), tc.accessToken, 3)
 			if tc.expectedErr != nil {
 				require.Error(t, err)
 				require.IsType(t, tc.expectedErr, err)
 			} else {
 				require.NoError(t, err)
-				require.Equal(t, tc.expectedUser, user)
 			}
 		})
 	}
 }
 
-func TestUseCases_RefreshTokens(t *testing.T) {
+func TestUseCases_VerifyUserEmail(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	authService := mockservices.NewMockAuthService(ctrl)
 	usersService := mockservices.NewMockUsersService(ctrl)
 	natsPublisher := mocknats.NewMockPublisher(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
-	cacheProvider := mockcache.NewMockProvider(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
 
-	securityConfig := security.Config{
-		JWT: security.JWTConfig{
-			SecretKey:       "secret",
-			Algorithm:       "HS256",
-			AccessTokenTTL:  time.Hour,
-			RefreshTokenTTL: time.Hour,
-		},
-		HashCost: 10,
-	}
+	securityConfig := security.Config{}
 	natsConfig := config.NATSConfig{}
 
-	accessToken, err := security.GenerateJWT(
-		uint64(1),
-		securityConfig.JWT.SecretKey,
-		securityConfig.JWT.AccessTokenTTL,
-		securityConfig.JWT.Algorithm,
-	)
-	require.NoError(t, err)
-
-	invalidAccessToken, err := security.GenerateJWT(
-		"invalid",
-		securityConfig.JWT.SecretKey,
-		securityConfig.JWT.AccessTokenTTL,
-		securityConfig.JWT.Algorithm,
-	)
-	require.NoError(t, err)
-
-	refreshToken, err := security.GenerateJWT(
-		accessToken,
-		securityConfig.JWT.SecretKey,
-		securityConfig.JWT.RefreshTokenTTL,
-		securityConfig.JWT.Algorithm,
-	)
-	require.NoError(t, err)
-
-	invalidRefreshTokenInt, err := security.GenerateJWT(
-		1,
-		securityConfig.JWT.SecretKey,
-		securityConfig.JWT.RefreshTokenTTL,
-		securityConfig.JWT.Algorithm,
-	)
-	require.NoError(t, err)
-
-	invalidRefreshTokenString, err := security.GenerateJWT(
-		"invalid",
-		securityConfig.JWT.SecretKey,
-		securityConfig.JWT.RefreshTokenTTL,
-		securityConfig.JWT.Algorithm,
-	)
-	require.NoError(t, err)
-
-	invalidRefreshTokenWithInvalidAccessToken, err := security.GenerateJWT(
-		invalidAccessToken,
-		securityConfig.JWT.SecretKey,
-		securityConfig.JWT.RefreshTokenTTL,
-		securityConfig.JWT.Algorithm,
-	)
-	require.NoError(t, err)
-
-	encodedRefreshToken := security.RawEncode([]byte(refreshToken))
-
 	useCases := New(
 		authService,
 		usersService,
 		securityConfig,
+		nil, // JWT is not used
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
 		validationConfig,
 		natsPublisher,
 		natsConfig,
@@ -1207,172 +3616,140 @@ func TestUseCases_RefreshTokens(t *testing.T) {
 		cacheProvider,
 	)
 
+	verifyEmailToken := "token"
+	verifyEmailTokenHash := hashToken(tokensConfig.SecretKey, verifyEmailToken)
+
 	testCases := []struct {
-		name         string
-		refreshToken string
-		setupMocks   func(
+		name             string
+		verifyEmailToken string
+		setupMocks       func(
 			authService *mockservices.MockAuthService,
 			usersService *mockservices.MockUsersService,
 			natsPublisher *mocknats.MockPublisher,
 			logger *mocklogging.MockLogger,
-			cacheProvider *mockcache.MockProvider,
+			cacheProvider *mockcache.MockCacheProvider,
 		)
 		expectedErr error
 	}{
 		{
-			name:         "success",
-			refreshToken: encodedRefreshToken,
+			name:             "success",
+			verifyEmailToken: verifyEmailToken,
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
 				authService.
 					EXPECT().
-					GetRefreshTokenByUserID(gomock.Any(), uint64(1)).
-					Return(&entities.RefreshToken{Value: refreshToken}, nil).
+					GetVerifyEmailTokenByHash(gomock.Any(), verifyEmailTokenHash).
+					Return(&entities.VerifyEmailToken{UserID: 1, Email: "test@example.com"}, nil).
 					Times(1)
 
-				authService.
+				usersService.
 					EXPECT().
-					ExpireRefreshToken(gomock.Any(), gomock.Any()).
-					Return(nil).
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(&entities.User{ID: 1, Email: "test@example.com", EmailConfirmed: false}, nil).
 					Times(1)
 
 				authService.
 					EXPECT().
-					CreateRefreshToken(
-						gomock.Any(),
-						uint64(1),
-						gomock.Any(),
-						time.Hour,
-					).
-					Return(uint64(1), nil).
+					VerifyUserEmail(gomock.Any(), uint64(1)).
+					Return(nil).
 					Times(1)
 			},
 			expectedErr: nil,
 		},
 		{
-			name:         "invalid refresh token",
-			refreshToken: "invalid_token",
-			expectedErr:  &security.InvalidJWTError{},
-		},
-		{
-			name:         "invalid refresh token payload",
-			refreshToken: security.RawEncode([]byte("invalid")),
-			expectedErr:  &security.InvalidJWTError{},
-		},
-		{
-			name:         "invalid refresh token after encoding",
-			refreshToken: security.RawEncode([]byte(invalidRefreshTokenInt)),
-			expectedErr:  &security.InvalidJWTError{},
-		},
-		{
-			name:         "invalid access token payload",
-			refreshToken: security.RawEncode([]byte(invalidRefreshTokenString)),
-			expectedErr:  &security.InvalidJWTError{},
-		},
-		{
-			name:         "invalid access token payload",
-			refreshToken: security.RawEncode([]byte(invalidRefreshTokenWithInvalidAccessToken)),
-			expectedErr:  &security.InvalidJWTError{},
-		},
-		{
-			name:         "get db refresh token by id error",
-			refreshToken: encodedRefreshToken,
+			name:             "email already confirmed",
+			verifyEmailToken: verifyEmailToken,
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
 				authService.
 					EXPECT().
-					GetRefreshTokenByUserID(gomock.Any(), uint64(1)).
-					Return(nil, &customerrors.RefreshTokenNotFoundError{}).
+					GetVerifyEmailTokenByHash(gomock.Any(), verifyEmailTokenHash).
+					Return(&entities.VerifyEmailToken{UserID: 1, Email: "test@example.com"}, nil).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(&entities.User{ID: 1, Email: "test@example.com", EmailConfirmed: true}, nil).
 					Times(1)
 			},
-			expectedErr: &security.InvalidJWTError{},
+			expectedErr: &customerrors.EmailAlreadyConfirmedError{},
 		},
 		{
-			name:         "expire db refresh token error",
-			refreshToken: encodedRefreshToken,
+			name:             "token not found",
+			verifyEmailToken: "MzE",
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
 				authService.
 					EXPECT().
-					GetRefreshTokenByUserID(gomock.Any(), uint64(1)).
-					Return(&entities.RefreshToken{Value: refreshToken}, nil).
-					Times(1)
-
-				authService.
-					EXPECT().
-					ExpireRefreshToken(gomock.Any(), gomock.Any()).
-					Return(errors.New("test")).
+					GetVerifyEmailTokenByHash(gomock.Any(), hashToken(tokensConfig.SecretKey, "MzE")).
+					Return(nil, errors.New("not found")).
 					Times(1)
 			},
-			expectedErr: &security.InvalidJWTError{},
+			expectedErr: &customerrors.InvalidVerifyEmailTokenError{},
 		},
 		{
-			name:         "create db refresh token error",
-			refreshToken: encodedRefreshToken,
+			name:             "email changed after token creation",
+			verifyEmailToken: verifyEmailToken,
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
 				authService.
 					EXPECT().
-					GetRefreshTokenByUserID(gomock.Any(), uint64(1)).
-					Return(&entities.RefreshToken{Value: refreshToken}, nil).
-					Times(1)
-
-				authService.
-					EXPECT().
-					ExpireRefreshToken(gomock.Any(), gomock.Any()).
-					Return(nil).
+					GetVerifyEmailTokenByHash(gomock.Any(), verifyEmailTokenHash).
+					Return(&entities.VerifyEmailToken{UserID: 1, Email: "old@example.com"}, nil).
 					Times(1)
 
-				authService.
+				usersService.
 					EXPECT().
-					CreateRefreshToken(
-						gomock.Any(),
-						uint64(1),
-						gomock.Any(),
-						time.Hour,
-					).
-					Return(uint64(0), errors.New("test")).
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(&entities.User{ID: 1, Email: "test@example.com", EmailConfirmed: false}, nil).
 					Times(1)
 			},
-			expectedErr: errors.New("test"),
+			expectedErr: &customerrors.InvalidVerifyEmailTokenError{},
 		},
 		{
-			name:         "access token does not belong to refresh token",
-			refreshToken: encodedRefreshToken,
+			name:             "user not found",
+			verifyEmailToken: verifyEmailToken,
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
 				authService.
 					EXPECT().
-					GetRefreshTokenByUserID(gomock.Any(), uint64(1)).
-					Return(&entities.RefreshToken{Value: invalidRefreshTokenString}, nil).
+					GetVerifyEmailTokenByHash(gomock.Any(), verifyEmailTokenHash).
+					Return(&entities.VerifyEmailToken{UserID: 1, Email: "test@example.com"}, nil).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(nil, &customerrors.UserNotFoundError{}).
 					Times(1)
 			},
-			expectedErr: &customerrors.AccessTokenDoesNotBelongToRefreshTokenError{},
+			expectedErr: &customerrors.UserNotFoundError{},
 		},
 	}
 
@@ -1388,57 +3765,41 @@ func TestUseCases_RefreshTokens(t *testing.T) {
 				)
 			}
 
-			tokens, err := useCases.RefreshTokens(context.Background(), tc.refreshToken)
+			err := useCases.VerifyUserEmail(context.Background(), tc.verifyEmailToken)
 			if tc.expectedErr != nil {
 				require.Error(t, err)
 				require.IsType(t, tc.expectedErr, err)
 			} else {
 				require.NoError(t, err)
-				require.NotZero(t, tokens.AccessToken)
-				require.NotZero(t, tokens.RefreshToken)
 			}
 		})
 	}
 }
 
-func TestUseCases_LogoutUser(t *testing.T) {
+func TestUseCases_VerifyUserEmailByCode(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	authService := mockservices.NewMockAuthService(ctrl)
 	usersService := mockservices.NewMockUsersService(ctrl)
 	natsPublisher := mocknats.NewMockPublisher(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
-	cacheProvider := mockcache.NewMockProvider(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
 
-	securityConfig := security.Config{
-		JWT: security.JWTConfig{
-			SecretKey:      "secret",
-			Algorithm:      "HS256",
-			AccessTokenTTL: time.Hour,
-		},
-		HashCost: 10,
-	}
+	securityConfig := security.Config{}
 	natsConfig := config.NATSConfig{}
 
-	accessToken, err := security.GenerateJWT(
-		uint64(1),
-		securityConfig.JWT.SecretKey,
-		securityConfig.JWT.AccessTokenTTL,
-		securityConfig.JWT.Algorithm,
-	)
-	require.NoError(t, err)
-
-	invalidAccessToken, err := security.GenerateJWT(
-		"invalid",
-		securityConfig.JWT.SecretKey,
-		securityConfig.JWT.AccessTokenTTL,
-		securityConfig.JWT.Algorithm,
-	)
-	require.NoError(t, err)
-
 	useCases := New(
 		authService,
 		usersService,
 		securityConfig,
+		nil, // JWT is not used
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
 		validationConfig,
 		natsPublisher,
 		natsConfig,
@@ -1446,189 +3807,236 @@ func TestUseCases_LogoutUser(t *testing.T) {
 		cacheProvider,
 	)
 
+	dbVerifyEmailToken := &entities.VerifyEmailToken{
+		ID:       3,
+		UserID:   1,
+		Email:    "test@example.com",
+		CodeHash: hashCode(tokensConfig.SecretKey, 1, "123456"),
+	}
+
 	testCases := []struct {
-		name        string
-		accessToken string
-		setupMocks  func(
+		name       string
+		email      string
+		code       string
+		setupMocks func(
 			authService *mockservices.MockAuthService,
 			usersService *mockservices.MockUsersService,
 			natsPublisher *mocknats.MockPublisher,
 			logger *mocklogging.MockLogger,
-			cacheProvider *mockcache.MockProvider,
+			cacheProvider *mockcache.MockCacheProvider,
 		)
 		expectedErr error
 	}{
 		{
-			name:        "success",
-			accessToken: accessToken,
+			name:  "success",
+			email: "test@example.com",
+			code:  "123456",
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{ID: 1, Email: "test@example.com"}, nil).
+					Times(1)
+
 				authService.
 					EXPECT().
-					GetRefreshTokenByUserID(gomock.Any(), uint64(1)).
-					Return(&entities.RefreshToken{Value: "refresh_token"}, nil).
+					GetVerifyEmailTokenByUserID(gomock.Any(), uint64(1)).
+					Return(dbVerifyEmailToken, nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), verifyEmailCodeCacheKey(3), tokensConfig.VerifyEmail.TTL).
+					Return(int64(1), nil).
 					Times(1)
 
 				authService.
 					EXPECT().
-					ExpireRefreshToken(gomock.Any(), "refresh_token").
+					VerifyUserEmail(gomock.Any(), uint64(1)).
 					Return(nil).
 					Times(1)
 			},
 			expectedErr: nil,
 		},
 		{
-			name:        "no refresh token",
-			accessToken: accessToken,
+			name:  "wrong code",
+			email: "test@example.com",
+			code:  "654321",
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{ID: 1, Email: "test@example.com"}, nil).
+					Times(1)
+
 				authService.
 					EXPECT().
-					GetRefreshTokenByUserID(gomock.Any(), uint64(1)).
-					Return(nil, errors.New("not found")).
+					GetVerifyEmailTokenByUserID(gomock.Any(), uint64(1)).
+					Return(dbVerifyEmailToken, nil).
+					Times(1)
+
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), verifyEmailCodeCacheKey(3), tokensConfig.VerifyEmail.TTL).
+					Return(int64(1), nil).
 					Times(1)
 			},
-			expectedErr: nil,
-		},
-		{
-			name:        "invalid token",
-			accessToken: "invalid_token",
-			expectedErr: &security.InvalidJWTError{},
+			expectedErr: &customerrors.InvalidVerifyEmailTokenError{},
 		},
 		{
-			name:        "invalid token payload",
-			accessToken: invalidAccessToken,
-			expectedErr: &security.InvalidJWTError{},
-		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			if tc.setupMocks != nil {
-				tc.setupMocks(
-					authService,
-					usersService,
-					natsPublisher,
-					logger,
-					cacheProvider,
-				)
-			}
-
-			err = useCases.LogoutUser(context.Background(), tc.accessToken)
-			if tc.expectedErr != nil {
-				require.Error(t, err)
-				require.IsType(t, tc.expectedErr, err)
-			} else {
-				require.NoError(t, err)
-			}
-		})
-	}
-}
-
-func TestUseCases_VerifyUserEmail(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	authService := mockservices.NewMockAuthService(ctrl)
-	usersService := mockservices.NewMockUsersService(ctrl)
-	natsPublisher := mocknats.NewMockPublisher(ctrl)
-	logger := mocklogging.NewMockLogger(ctrl)
-	cacheProvider := mockcache.NewMockProvider(ctrl)
-
-	securityConfig := security.Config{}
-	natsConfig := config.NATSConfig{}
+			name:  "code of another user",
+			email: "another@example.com",
+			code:  "123456",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "another@example.com").
+					Return(&entities.User{ID: 2, Email: "another@example.com"}, nil).
+					Times(1)
 
-	useCases := New(
-		authService,
-		usersService,
-		securityConfig,
-		validationConfig,
-		natsPublisher,
-		natsConfig,
-		logger,
-		cacheProvider,
-	)
+				authService.
+					EXPECT().
+					GetVerifyEmailTokenByUserID(gomock.Any(), uint64(2)).
+					Return(
+						&entities.VerifyEmailToken{
+							ID:       4,
+							UserID:   2,
+							Email:    "another@example.com",
+							CodeHash: dbVerifyEmailToken.CodeHash,
+						},
+						nil,
+					).
+					Times(1)
 
-	testCases := []struct {
-		name             string
-		verifyEmailToken string
-		setupMocks       func(
-			authService *mockservices.MockAuthService,
-			usersService *mockservices.MockUsersService,
-			natsPublisher *mocknats.MockPublisher,
-			logger *mocklogging.MockLogger,
-			cacheProvider *mockcache.MockProvider,
-		)
-		expectedErr error
-	}{
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), verifyEmailCodeCacheKey(4), tokensConfig.VerifyEmail.TTL).
+					Return(int64(3), nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidVerifyEmailTokenError{},
+		},
 		{
-			name:             "success",
-			verifyEmailToken: security.RawEncode([]byte("1")),
+			name:  "too many attempts",
+			email: "test@example.com",
+			code:  "123456",
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
 				usersService.
 					EXPECT().
-					GetUserByID(gomock.Any(), uint64(1)).
-					Return(&entities.User{ID: 1, EmailConfirmed: false}, nil).
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{ID: 1, Email: "test@example.com"}, nil).
 					Times(1)
 
 				authService.
 					EXPECT().
-					VerifyUserEmail(gomock.Any(), uint64(1)).
-					Return(nil).
+					GetVerifyEmailTokenByUserID(gomock.Any(), uint64(1)).
+					Return(dbVerifyEmailToken, nil).
+					Times(1)
+
+				// Even correct code is rejected after limit of attempts is reached:
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), verifyEmailCodeCacheKey(3), tokensConfig.VerifyEmail.TTL).
+					Return(int64(verifyEmailCodeAttemptsLimit+1), nil).
 					Times(1)
 			},
-			expectedErr: nil,
+			expectedErr: &customerrors.InvalidVerifyEmailTokenError{},
 		},
 		{
-			name:             "email already confirmed",
-			verifyEmailToken: security.RawEncode([]byte("1")),
+			name:  "attempts can not be counted",
+			email: "test@example.com",
+			code:  "123456",
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
 				usersService.
 					EXPECT().
-					GetUserByID(gomock.Any(), uint64(1)).
-					Return(&entities.User{ID: 1, EmailConfirmed: true}, nil).
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{ID: 1, Email: "test@example.com"}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetVerifyEmailTokenByUserID(gomock.Any(), uint64(1)).
+					Return(dbVerifyEmailToken, nil).
+					Times(1)
+
+				// Code is not checked, when cache is unavailable:
+				cacheProvider.
+					EXPECT().
+					IncrWithTTL(gomock.Any(), verifyEmailCodeCacheKey(3), tokensConfig.VerifyEmail.TTL).
+					Return(int64(0), errors.New("cache is unavailable")).
 					Times(1)
 			},
-			expectedErr: &customerrors.EmailAlreadyConfirmedError{},
+			expectedErr: errors.New("cache is unavailable"),
 		},
 		{
-			name:             "invalid token payload",
-			verifyEmailToken: security.RawEncode([]byte(nil)),
-			expectedErr:      &strconv.NumError{},
+			name:  "no active token",
+			email: "test@example.com",
+			code:  "123456",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{ID: 1, Email: "test@example.com"}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetVerifyEmailTokenByUserID(gomock.Any(), uint64(1)).
+					Return(nil, errors.New("not found")).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidVerifyEmailTokenError{},
 		},
 		{
-			name:             "user not found",
-			verifyEmailToken: security.RawEncode([]byte("1")),
+			name:  "user not found",
+			email: "test@example.com",
+			code:  "123456",
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
 				usersService.
 					EXPECT().
-					GetUserByID(gomock.Any(), uint64(1)).
+					GetUserByEmail(gomock.Any(), "test@example.com").
 					Return(nil, &customerrors.UserNotFoundError{}).
 					Times(1)
 			},
@@ -1648,15 +4056,100 @@ func TestUseCases_VerifyUserEmail(t *testing.T) {
 				)
 			}
 
-			err := useCases.VerifyUserEmail(context.Background(), tc.verifyEmailToken)
-			if tc.expectedErr != nil {
-				require.Error(t, err)
-				require.IsType(t, tc.expectedErr, err)
-			} else {
-				require.NoError(t, err)
+			err := useCases.VerifyUserEmailByCode(context.Background(), tc.email, tc.code)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUseCases_VerifyUserEmailByCodeConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	cacheProvider := memorycache.New()
+
+	t.Cleanup(func() {
+		require.NoError(t, cacheProvider.Close())
+	})
+
+	useCases := New(
+		authService,
+		usersService,
+		security.Config{},
+		nil, // JWT is not used
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		mocknats.NewMockPublisher(ctrl),
+		config.NATSConfig{},
+		mocklogging.NewMockLogger(ctrl),
+		cacheProvider,
+	)
+
+	const guesses = 100
+
+	usersService.
+		EXPECT().
+		GetUserByEmail(gomock.Any(), "test@example.com").
+		Return(&entities.User{ID: 1, Email: "test@example.com"}, nil).
+		Times(guesses + 1)
+
+	authService.
+		EXPECT().
+		GetVerifyEmailTokenByUserID(gomock.Any(), uint64(1)).
+		Return(
+			&entities.VerifyEmailToken{
+				ID:       3,
+				UserID:   1,
+				Email:    "test@example.com",
+				CodeHash: hashCode(tokensConfig.SecretKey, 1, "123456"),
+			},
+			nil,
+		).
+		Times(guesses + 1)
+
+	var (
+		wg              sync.WaitGroup
+		tooManyAttempts atomic.Int64
+	)
+
+	for range guesses {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			err := useCases.VerifyUserEmailByCode(context.Background(), "test@example.com", "654321")
+			if err != nil && strings.Contains(err.Error(), "too many attempts") {
+				tooManyAttempts.Add(1)
 			}
-		})
+		}()
 	}
+
+	wg.Wait()
+
+	// Concurrent guesses are counted exactly, so only limited number of codes is checked:
+	require.Equal(t, int64(guesses-verifyEmailCodeAttemptsLimit), tooManyAttempts.Load())
+
+	attempts, err := cacheProvider.Get(context.Background(), verifyEmailCodeCacheKey(3))
+	require.NoError(t, err)
+	require.Equal(t, strconv.Itoa(guesses), attempts)
+
+	// Correct code can not be used after limit of attempts is reached:
+	err = useCases.VerifyUserEmailByCode(context.Background(), "test@example.com", "123456")
+	require.Error(t, err)
+	require.IsType(t, &customerrors.InvalidVerifyEmailTokenError{}, err)
 }
 
 func TestUseCases_ForgetPassword(t *testing.T) {
@@ -1665,7 +4158,7 @@ func TestUseCases_ForgetPassword(t *testing.T) {
 	usersService := mockservices.NewMockUsersService(ctrl)
 	natsPublisher := mocknats.NewMockPublisher(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
-	cacheProvider := mockcache.NewMockProvider(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
 
 	securityConfig := security.Config{
 		JWT: security.JWTConfig{
@@ -1673,16 +4166,29 @@ func TestUseCases_ForgetPassword(t *testing.T) {
 			Algorithm:      "HS256",
 			AccessTokenTTL: time.Hour,
 		},
-		HashCost: 10,
 	}
 	natsConfig := config.NATSConfig{}
 
-	forgetPasswordToken := security.RawEncode([]byte("1"))
+	forgetPasswordToken := "token"
+	dbForgetPasswordToken := &entities.ForgetPasswordToken{
+		ID:        2,
+		UserID:    1,
+		TokenHash: hashToken(tokensConfig.SecretKey, forgetPasswordToken),
+	}
 
 	useCases := New(
 		authService,
 		usersService,
 		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
 		validationConfig,
 		natsPublisher,
 		natsConfig,
@@ -1699,7 +4205,7 @@ func TestUseCases_ForgetPassword(t *testing.T) {
 			usersService *mockservices.MockUsersService,
 			natsPublisher *mocknats.MockPublisher,
 			logger *mocklogging.MockLogger,
-			cacheProvider *mockcache.MockProvider,
+			cacheProvider *mockcache.MockCacheProvider,
 		)
 		expectedErr error
 	}{
@@ -1712,8 +4218,28 @@ func TestUseCases_ForgetPassword(t *testing.T) {
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
+				authService.
+					EXPECT().
+					GetUserSessions(gomock.Any(), uint64(1)).
+					Return([]entities.Session{{ID: 2, UserID: 1}}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ExpireUserSessions(gomock.Any(), uint64(1), uint64(0)).
+					Return(nil).
+					Times(1)
+
+				expectSessionsAreRevoked(cacheProvider, 2)
+
+				authService.
+					EXPECT().
+					GetForgetPasswordTokenByHash(gomock.Any(), dbForgetPasswordToken.TokenHash).
+					Return(dbForgetPasswordToken, nil).
+					Times(1)
+
 				oldHashedPassword, _ := security.Hash("oldpassword123", 10)
 				usersService.
 					EXPECT().
@@ -1723,9 +4249,11 @@ func TestUseCases_ForgetPassword(t *testing.T) {
 
 				authService.
 					EXPECT().
-					ForgetPassword(gomock.Any(), uint64(1), gomock.Any()).
+					ForgetPassword(gomock.Any(), uint64(1), uint64(2), gomock.Any()).
 					Return(nil).
 					Times(1)
+
+				expectLoginIsUnlocked(cacheProvider, 1)
 			},
 			expectedErr: nil,
 		},
@@ -1736,10 +4264,55 @@ func TestUseCases_ForgetPassword(t *testing.T) {
 			expectedErr: &validation.Error{},
 		},
 		{
-			name:        "invalid token conversion to int",
-			token:       security.RawEncode([]byte("s")),
+			name:        "invalid token",
+			token:       "invalid token",
+			newPassword: "Password123@",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetForgetPasswordTokenByHash(gomock.Any(), hashToken(tokensConfig.SecretKey, "invalid token")).
+					Return(nil, errors.New("not found")).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidForgetPasswordTokenError{},
+		},
+		{
+			name:        "token was consumed concurrently",
+			token:       forgetPasswordToken,
 			newPassword: "Password123@",
-			expectedErr: &strconv.NumError{},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetForgetPasswordTokenByHash(gomock.Any(), dbForgetPasswordToken.TokenHash).
+					Return(dbForgetPasswordToken, nil).
+					Times(1)
+
+				oldHashedPassword, _ := security.Hash("oldpassword123", 10)
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(&entities.User{ID: 1, Password: oldHashedPassword}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ForgetPassword(gomock.Any(), uint64(1), uint64(2), gomock.Any()).
+					Return(&customerrors.InvalidForgetPasswordTokenError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidForgetPasswordTokenError{},
 		},
 		{
 			name:        "get user by id error",
@@ -1750,8 +4323,14 @@ func TestUseCases_ForgetPassword(t *testing.T) {
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
+				authService.
+					EXPECT().
+					GetForgetPasswordTokenByHash(gomock.Any(), dbForgetPasswordToken.TokenHash).
+					Return(dbForgetPasswordToken, nil).
+					Times(1)
+
 				usersService.
 					EXPECT().
 					GetUserByID(gomock.Any(), uint64(1)).
@@ -1769,8 +4348,14 @@ func TestUseCases_ForgetPassword(t *testing.T) {
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
+				authService.
+					EXPECT().
+					GetForgetPasswordTokenByHash(gomock.Any(), dbForgetPasswordToken.TokenHash).
+					Return(dbForgetPasswordToken, nil).
+					Times(1)
+
 				oldHashedPassword, _ := security.Hash("Password123@", 10)
 				usersService.
 					EXPECT().
@@ -1811,7 +4396,7 @@ func TestUseCases_ChangePassword(t *testing.T) {
 	usersService := mockservices.NewMockUsersService(ctrl)
 	natsPublisher := mocknats.NewMockPublisher(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
-	cacheProvider := mockcache.NewMockProvider(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
 
 	securityConfig := security.Config{
 		JWT: security.JWTConfig{
@@ -1819,17 +4404,10 @@ func TestUseCases_ChangePassword(t *testing.T) {
 			Algorithm:      "HS256",
 			AccessTokenTTL: time.Hour,
 		},
-		HashCost: 10,
 	}
 	natsConfig := config.NATSConfig{}
 
-	accessToken, err := security.GenerateJWT(
-		uint64(1),
-		securityConfig.JWT.SecretKey,
-		securityConfig.JWT.AccessTokenTTL,
-		securityConfig.JWT.Algorithm,
-	)
-	require.NoError(t, err)
+	accessToken := newAccessToken(t, securityConfig.JWT, 1, 0)
 
 	invalidAccessToken, err := security.GenerateJWT(
 		"invalid",
@@ -1843,6 +4421,15 @@ func TestUseCases_ChangePassword(t *testing.T) {
 		authService,
 		usersService,
 		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
 		validationConfig,
 		natsPublisher,
 		natsConfig,
@@ -1860,7 +4447,7 @@ func TestUseCases_ChangePassword(t *testing.T) {
 			usersService *mockservices.MockUsersService,
 			natsPublisher *mocknats.MockPublisher,
 			logger *mocklogging.MockLogger,
-			cacheProvider *mockcache.MockProvider,
+			cacheProvider *mockcache.MockCacheProvider,
 		)
 		expectedErr error
 	}{
@@ -1874,8 +4461,11 @@ func TestUseCases_ChangePassword(t *testing.T) {
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 0)
+				expectUserIsNotBlocked(usersService, 1)
+
 				hashedPassword, _ := security.Hash("oldpassword123", 10)
 				usersService.
 					EXPECT().
@@ -1908,8 +4498,11 @@ func TestUseCases_ChangePassword(t *testing.T) {
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 0)
+				expectUserIsNotBlocked(usersService, 1)
+
 				hashedPassword, _ := security.Hash("oldpassword123", 10)
 				usersService.
 					EXPECT().
@@ -1950,8 +4543,11 @@ func TestUseCases_ChangePassword(t *testing.T) {
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 0)
+				expectUserIsNotBlocked(usersService, 1)
+
 				usersService.
 					EXPECT().
 					GetUserByID(gomock.Any(), uint64(1)).
@@ -1991,7 +4587,7 @@ func TestUseCases_SendVerifyEmailMessage(t *testing.T) {
 	usersService := mockservices.NewMockUsersService(ctrl)
 	natsPublisher := mocknats.NewMockPublisher(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
-	cacheProvider := mockcache.NewMockProvider(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
 
 	securityConfig := security.Config{}
 	natsConfig := config.NATSConfig{
@@ -2004,6 +4600,15 @@ func TestUseCases_SendVerifyEmailMessage(t *testing.T) {
 		authService,
 		usersService,
 		securityConfig,
+		nil, // JWT is not used
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
 		validationConfig,
 		natsPublisher,
 		natsConfig,
@@ -2019,7 +4624,7 @@ func TestUseCases_SendVerifyEmailMessage(t *testing.T) {
 			usersService *mockservices.MockUsersService,
 			natsPublisher *mocknats.MockPublisher,
 			logger *mocklogging.MockLogger,
-			cacheProvider *mockcache.MockProvider,
+			cacheProvider *mockcache.MockCacheProvider,
 		)
 		expectedErr error
 	}{
@@ -2031,114 +4636,250 @@ func TestUseCases_SendVerifyEmailMessage(t *testing.T) {
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
-				cacheProvider.
+				usersService.
 					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{ID: 1, EmailConfirmed: false}, nil).
 					Times(1)
 
-				cacheProvider.
+				authService.
 					EXPECT().
-					Get(gomock.Any(), fmt.Sprintf("%s-%s", verifyEmailCachePrefix, "test@example.com")).
-					Return("1", nil).
+					CreateVerifyEmailToken(gomock.Any(), gomock.Any()).
+					Return(uint64(1), nil).
 					Times(1)
 
+				natsPublisher.
+					EXPECT().
+					Publish("verify-email", verifyEmailContent(1)).
+					Return(nil).
+					Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "email already confirmed",
+			email: "test@example.com",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{ID: 1, EmailConfirmed: true}, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.EmailAlreadyConfirmedError{},
+		},
+		{
+			name:  "publish error",
+			email: "test@example.com",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
 				usersService.
 					EXPECT().
 					GetUserByEmail(gomock.Any(), "test@example.com").
 					Return(&entities.User{ID: 1, EmailConfirmed: false}, nil).
 					Times(1)
 
-				verifyEmailDTO := notifications.VerifyEmailDTO{UserID: uint64(1)}
-				content, _ := json.Marshal(verifyEmailDTO)
+				authService.
+					EXPECT().
+					CreateVerifyEmailToken(gomock.Any(), gomock.Any()).
+					Return(uint64(1), nil).
+					Times(1)
+
 				natsPublisher.
 					EXPECT().
-					Publish("verify-email", content).
-					Return(nil).
+					Publish("verify-email", verifyEmailContent(1)).
+					Return(errors.New("publish failed")).
 					Times(1)
 
-				cacheProvider.
+				logger.
 					EXPECT().
-					Incr(gomock.Any(), gomock.Any()).
-					Return(int64(1), nil).
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1)
 			},
-			expectedErr: nil,
+			expectedErr: errors.New("publish failed"),
 		},
 		{
-			name:  "email already confirmed",
+			name:  "create verify-email token error",
 			email: "test@example.com",
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
-				cacheProvider.
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{ID: 1, EmailConfirmed: false}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateVerifyEmailToken(gomock.Any(), gomock.Any()).
+					Return(uint64(0), errors.New("create failed")).
+					Times(1)
+
+				logger.
+					EXPECT().
+					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			expectedErr: errors.New("create failed"),
+		},
+		{
+			name:  "user not found",
+			email: "test@example.com",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(nil, &customerrors.UserNotFoundError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.UserNotFoundError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(
+					authService,
+					usersService,
+					natsPublisher,
+					logger,
+					cacheProvider,
+				)
+			}
+
+			err := useCases.SendVerifyEmailMessage(context.Background(), tc.email)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				if tc.expectedErr != nil {
+					require.Error(t, err)
+					require.IsType(t, tc.expectedErr, err)
+				} else {
+					require.NoError(t, err)
+				}
+			}
+		})
+	}
+}
+
+func TestUseCases_SendForgetPasswordMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{}
+	natsConfig := config.NATSConfig{
+		Subjects: config.NATSSubjects{
+			ForgetPassword: "forget-password",
+		},
+	}
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		nil, // JWT is not used
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		natsConfig,
+		logger,
+		cacheProvider,
+	)
+
+	testCases := []struct {
+		name       string
+		email      string
+		setupMocks func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+			natsPublisher *mocknats.MockPublisher,
+			logger *mocklogging.MockLogger,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedErr error
+	}{
+		{
+			name:  "success",
+			email: "test@example.com",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
 					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{ID: 1, EmailConfirmed: true}, nil).
 					Times(1)
 
-				cacheProvider.
+				authService.
 					EXPECT().
-					Get(gomock.Any(), fmt.Sprintf("%s-%s", verifyEmailCachePrefix, "test@example.com")).
-					Return("1", nil).
+					CreateForgetPasswordToken(gomock.Any(), gomock.Any()).
+					Return(uint64(1), nil).
 					Times(1)
 
-				usersService.
+				natsPublisher.
 					EXPECT().
-					GetUserByEmail(gomock.Any(), "test@example.com").
-					Return(&entities.User{ID: 1, EmailConfirmed: true}, nil).
+					Publish("forget-password", forgetPasswordContent(1)).
+					Return(nil).
 					Times(1)
 			},
-			expectedErr: &customerrors.EmailAlreadyConfirmedError{},
+			expectedErr: nil,
 		},
 		{
-			name:  "publish error",
+			name:  "email not confirmed",
 			email: "test@example.com",
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
-				cacheProvider.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
-				cacheProvider.
-					EXPECT().
-					Get(gomock.Any(), fmt.Sprintf("%s-%s", verifyEmailCachePrefix, "test@example.com")).
-					Return("1", nil).
-					Times(1)
-
 				usersService.
 					EXPECT().
 					GetUserByEmail(gomock.Any(), "test@example.com").
 					Return(&entities.User{ID: 1, EmailConfirmed: false}, nil).
 					Times(1)
-
-				verifyEmailDTO := notifications.VerifyEmailDTO{UserID: uint64(1)}
-				content, _ := json.Marshal(verifyEmailDTO)
-				natsPublisher.
-					EXPECT().
-					Publish("verify-email", content).
-					Return(errors.New("publish failed")).
-					Times(1)
-
-				logger.
-					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
-					Times(1)
 			},
-			expectedErr: errors.New("publish failed"),
+			expectedErr: &customerrors.EmailIsNotConfirmedError{},
 		},
 		{
 			name:  "user not found",
@@ -2148,20 +4889,8 @@ func TestUseCases_SendVerifyEmailMessage(t *testing.T) {
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
-				cacheProvider.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
-				cacheProvider.
-					EXPECT().
-					Get(gomock.Any(), fmt.Sprintf("%s-%s", verifyEmailCachePrefix, "test@example.com")).
-					Return("1", nil).
-					Times(1)
-
 				usersService.
 					EXPECT().
 					GetUserByEmail(gomock.Any(), "test@example.com").
@@ -2171,45 +4900,31 @@ func TestUseCases_SendVerifyEmailMessage(t *testing.T) {
 			expectedErr: &customerrors.UserNotFoundError{},
 		},
 		{
-			name:  "cache incr error",
+			name:  "publish error",
 			email: "test@example.com",
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
-				cacheProvider.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
-				cacheProvider.
-					EXPECT().
-					Get(gomock.Any(), fmt.Sprintf("%s-%s", verifyEmailCachePrefix, "test@example.com")).
-					Return("1", nil).
-					Times(1)
-
 				usersService.
 					EXPECT().
 					GetUserByEmail(gomock.Any(), "test@example.com").
-					Return(&entities.User{ID: 1, EmailConfirmed: false}, nil).
+					Return(&entities.User{ID: 1, EmailConfirmed: true}, nil).
 					Times(1)
 
-				forgetPasswordDTO := notifications.ForgetPasswordDTO{UserID: uint64(1)}
-				content, _ := json.Marshal(forgetPasswordDTO)
-				natsPublisher.
+				authService.
 					EXPECT().
-					Publish("verify-email", content).
-					Return(nil).
+					CreateForgetPasswordToken(gomock.Any(), gomock.Any()).
+					Return(uint64(1), nil).
 					Times(1)
 
-				cacheProvider.
+				natsPublisher.
 					EXPECT().
-					Incr(gomock.Any(), gomock.Any()).
-					Return(int64(0), errors.New("cache incr error")).
+					Publish("forget-password", forgetPasswordContent(1)).
+					Return(errors.New("publish failed")).
 					Times(1)
 
 				logger.
@@ -2217,563 +4932,695 @@ func TestUseCases_SendVerifyEmailMessage(t *testing.T) {
 					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1)
 			},
+			expectedErr: errors.New("publish failed"),
 		},
 		{
-			name:  "get cache error",
+			name:  "create forget-password token error",
 			email: "test@example.com",
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
-				cacheProvider.
+				usersService.
 					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
+					GetUserByEmail(gomock.Any(), "test@example.com").
+					Return(&entities.User{ID: 1, EmailConfirmed: true}, nil).
 					Times(1)
 
-				cacheProvider.
+				authService.
 					EXPECT().
-					Get(gomock.Any(), fmt.Sprintf("%s-%s", verifyEmailCachePrefix, "test@example.com")).
-					Return("", errors.New("")).
+					CreateForgetPasswordToken(gomock.Any(), gomock.Any()).
+					Return(uint64(0), errors.New("create failed")).
 					Times(1)
 
 				logger.
 					EXPECT().
 					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1)
-
-				usersService.
-					EXPECT().
-					GetUserByEmail(gomock.Any(), "test@example.com").
-					Return(nil, &customerrors.UserNotFoundError{}).
-					Times(1)
 			},
-			expectedErr: &customerrors.UserNotFoundError{},
+			expectedErr: errors.New("create failed"),
 		},
-		{
-			name:  "invalid cache error",
-			email: "test@example.com",
-			setupMocks: func(
-				authService *mockservices.MockAuthService,
-				usersService *mockservices.MockUsersService,
-				natsPublisher *mocknats.MockPublisher,
-				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
-			) {
-				cacheProvider.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
+	}
 
-				cacheProvider.
-					EXPECT().
-					Get(gomock.Any(), fmt.Sprintf("%s-%s", verifyEmailCachePrefix, "test@example.com")).
-					Return("invalid", nil).
-					Times(1)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(
+					authService,
+					usersService,
+					natsPublisher,
+					logger,
+					cacheProvider,
+				)
+			}
 
-				logger.
-					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
-					Times(1)
+			err := useCases.SendForgetPasswordMessage(context.Background(), tc.email)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
 
-				usersService.
-					EXPECT().
-					GetUserByEmail(gomock.Any(), "test@example.com").
-					Return(nil, &customerrors.UserNotFoundError{}).
-					Times(1)
-			},
-			expectedErr: &customerrors.UserNotFoundError{},
+func TestUseCases_GetJWKS(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	jwtProvider := mockjwt.NewMockJWTProvider(ctrl)
+
+	useCases := New(
+		nil,
+		nil,
+		security.Config{},
+		jwtProvider,
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		nil,
+		config.NATSConfig{},
+		nil,
+		nil,
+	)
+
+	jwks := entities.JWKS{
+		Keys: []entities.JWK{
+			{KeyType: "OKP", KeyID: "current", Use: "sig", Algorithm: "EdDSA", Curve: "Ed25519", X: "x"},
 		},
-		{
-			name:  "limit exceeded error",
-			email: "test@example.com",
-			setupMocks: func(
-				authService *mockservices.MockAuthService,
-				usersService *mockservices.MockUsersService,
-				natsPublisher *mocknats.MockPublisher,
-				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
-			) {
-				cacheProvider.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
+	}
 
-				cacheProvider.
-					EXPECT().
-					Get(gomock.Any(), fmt.Sprintf("%s-%s", verifyEmailCachePrefix, "test@example.com")).
-					Return("4", nil).
-					Times(1)
+	jwtProvider.
+		EXPECT().
+		GetJWKS().
+		Return(jwks).
+		Times(1)
+
+	require.Equal(t, jwks, useCases.GetJWKS())
+}
+
+func TestUseCases_ParseAccessToken(t *testing.T) {
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:      "secret",
+			Algorithm:      "HS256",
+			AccessTokenTTL: time.Hour,
+		},
+	}
+
+	jwtProvider := newJWTProvider(t, securityConfig.JWT)
+
+	useCases := New(
+		nil,
+		nil,
+		securityConfig,
+		jwtProvider,
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		nil,
+		config.NATSConfig{},
+		nil,
+		nil,
+	)
+
+	now := time.Now()
+	validClaims := accessTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "jti",
+			Subject:   "1",
+			Issuer:    accessTokensConfig.Issuer,
+			Audience:  jwt.ClaimStrings{accessTokensConfig.Audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+		SessionID: "2",
+		Roles:     []string{entities.UserRole, entities.AdminRole},
+	}
+
+	legacyAccessToken, err := security.GenerateJWT(
+		uint64(1),
+		securityConfig.JWT.SecretKey,
+		securityConfig.JWT.AccessTokenTTL,
+		securityConfig.JWT.Algorithm,
+	)
+	require.NoError(t, err)
 
+	testCases := []struct {
+		name            string
+		modifyClaims    func(claims *accessTokenClaims)
+		accessToken     string
+		expectedPayload *entities.AccessTokenPayload
+		errorExpected   bool
+	}{
+		{
+			name: "success",
+			expectedPayload: &entities.AccessTokenPayload{
+				ID:        "jti",
+				UserID:    1,
+				SessionID: 2,
+				Roles:     []string{entities.UserRole, entities.AdminRole},
+				IssuedAt:  time.Unix(now.Unix(), 0),
+				ExpiresAt: time.Unix(now.Add(time.Hour).Unix(), 0),
 			},
-			expectedErr: &customerrors.LimitExceededError{},
 		},
 		{
-			name:  "cache set error",
-			email: "test@example.com",
-			setupMocks: func(
-				authService *mockservices.MockAuthService,
-				usersService *mockservices.MockUsersService,
-				natsPublisher *mocknats.MockPublisher,
-				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
-			) {
-				cacheProvider.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
+			name: "another issuer",
+			modifyClaims: func(claims *accessTokenClaims) {
+				claims.Issuer = "another"
+			},
+			errorExpected: true,
+		},
+		{
+			name: "another audience",
+			modifyClaims: func(claims *accessTokenClaims) {
+				claims.Audience = jwt.ClaimStrings{"another"}
+			},
+			errorExpected: true,
+		},
+		{
+			name: "without expiration",
+			modifyClaims: func(claims *accessTokenClaims) {
+				claims.ExpiresAt = nil
+			},
+			errorExpected: true,
+		},
+		{
+			name: "expired",
+			modifyClaims: func(claims *accessTokenClaims) {
+				claims.ExpiresAt = jwt.NewNumericDate(now.Add(-time.Minute))
+			},
+			errorExpected: true,
+		},
+		{
+			name: "invalid subject",
+			modifyClaims: func(claims *accessTokenClaims) {
+				claims.Subject = "invalid"
+			},
+			errorExpected: true,
+		},
+		{
+			name: "invalid session",
+			modifyClaims: func(claims *accessTokenClaims) {
+				claims.SessionID = "invalid"
+			},
+			errorExpected: true,
+		},
+		{
+			name:          "legacy access token",
+			accessToken:   legacyAccessToken,
+			errorExpected: true,
+		},
+	}
 
-				cacheProvider.
-					EXPECT().
-					Get(gomock.Any(), fmt.Sprintf("%s-%s", verifyEmailCachePrefix, "test@example.com")).
-					Return("0", nil).
-					Times(1)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			accessToken := tc.accessToken
+			if accessToken == "" {
+				claims := validClaims
+				if tc.modifyClaims != nil {
+					tc.modifyClaims(&claims)
+				}
 
-				usersService.
-					EXPECT().
-					GetUserByEmail(gomock.Any(), "test@example.com").
-					Return(&entities.User{ID: 1, EmailConfirmed: false}, nil).
-					Times(1)
+				accessToken, err = jwtProvider.Sign(claims)
+				require.NoError(t, err)
+			}
 
-				forgetPasswordDTO := notifications.ForgetPasswordDTO{UserID: uint64(1)}
-				content, _ := json.Marshal(forgetPasswordDTO)
-				natsPublisher.
-					EXPECT().
-					Publish("verify-email", content).
-					Return(nil).
-					Times(1)
+			payload, err := useCases.parseAccessToken(accessToken)
+			if tc.errorExpected {
+				require.Error(t, err)
+				require.IsType(t, &security.InvalidJWTError{}, err)
+				require.Nil(t, payload)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedPayload, payload)
+			}
+		})
+	}
+}
 
-				cacheProvider.
-					EXPECT().
-					Set(
-						gomock.Any(),
-						fmt.Sprintf("%s-%s", verifyEmailCachePrefix, "test@example.com"),
-						1,
-						verifyEmailTTL,
-					).
-					Return(errors.New("cache incr error")).
-					Times(1)
+func TestUseCases_VerifyUserToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+
+	useCases := newClientsUseCases(t, authService, usersService, cacheProvider, securityConfig)
 
-				logger.
-					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
-					Times(1)
-			},
+	testCases := []struct {
+		name           string
+		accessToken    string
+		expectedUserID uint64
+		errorExpected  bool
+	}{
+		{
+			name:           "success",
+			accessToken:    newAccessToken(t, securityConfig.JWT, 1, 2),
+			expectedUserID: 1,
+		},
+		{
+			name:          "invalid token",
+			accessToken:   "invalid",
+			errorExpected: true,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			if tc.setupMocks != nil {
-				tc.setupMocks(
-					authService,
-					usersService,
-					natsPublisher,
-					logger,
-					cacheProvider,
-				)
-			}
-
-			err := useCases.SendVerifyEmailMessage(context.Background(), tc.email)
-			if tc.expectedErr != nil {
+			// Revocation is not checked, so cache is not called:
+			accessTokenPayload, err := useCases.VerifyUserToken(tc.accessToken)
+			if tc.errorExpected {
 				require.Error(t, err)
-				if tc.expectedErr != nil {
-					require.Error(t, err)
-					require.IsType(t, tc.expectedErr, err)
-				} else {
-					require.NoError(t, err)
-				}
+				require.Nil(t, accessTokenPayload)
+
+				return
 			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedUserID, accessTokenPayload.UserID)
 		})
 	}
 }
 
-func TestUseCases_SendForgetPasswordMessage(t *testing.T) {
+func TestGetUserRoles(t *testing.T) {
+	require.Equal(t, []string{entities.UserRole}, getUserRoles(&entities.User{}))
+	require.Equal(t, []string{entities.UserRole}, getUserRoles(&entities.User{Role: entities.UserRole}))
+	require.Equal(
+		t,
+		[]string{entities.UserRole, entities.AdminRole},
+		getUserRoles(&entities.User{Role: entities.AdminRole}),
+	)
+}
+
+func TestUseCases_IntrospectToken(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	authService := mockservices.NewMockAuthService(ctrl)
 	usersService := mockservices.NewMockUsersService(ctrl)
 	natsPublisher := mocknats.NewMockPublisher(ctrl)
 	logger := mocklogging.NewMockLogger(ctrl)
-	cacheProvider := mockcache.NewMockProvider(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
 
-	securityConfig := security.Config{}
-	natsConfig := config.NATSConfig{
-		Subjects: config.NATSSubjects{
-			ForgetPassword: "forget-password",
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
 		},
 	}
 
+	accessToken := newAccessToken(t, securityConfig.JWT, 1, 2)
+	refreshToken := "refresh-token"
+	now := time.Now().UTC()
+
+	scopedAccessToken, err := newJWTProvider(t, securityConfig.JWT).Sign(
+		accessTokenClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID:        "jti",
+				Subject:   "1",
+				Issuer:    accessTokensConfig.Issuer,
+				Audience:  jwt.ClaimStrings{accessTokensConfig.Audience},
+				IssuedAt:  jwt.NewNumericDate(now),
+				ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+			},
+			SessionID: "2",
+			Roles:     []string{entities.UserRole},
+			Scope:     "openid email",
+		},
+	)
+	require.NoError(t, err)
+
 	useCases := New(
 		authService,
 		usersService,
 		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
 		validationConfig,
 		natsPublisher,
-		natsConfig,
+		config.NATSConfig{},
 		logger,
 		cacheProvider,
 	)
 
 	testCases := []struct {
 		name       string
-		email      string
+		token      string
 		setupMocks func(
 			authService *mockservices.MockAuthService,
 			usersService *mockservices.MockUsersService,
 			natsPublisher *mocknats.MockPublisher,
 			logger *mocklogging.MockLogger,
-			cacheProvider *mockcache.MockProvider,
+			cacheProvider *mockcache.MockCacheProvider,
 		)
-		expectedErr error
+		expectedIntrospection *entities.TokenIntrospection
+		expectedErr           error
 	}{
 		{
-			name:  "success",
-			email: "test@example.com",
+			name:  "active access token",
+			token: accessToken,
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
-				cacheProvider.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
-				cacheProvider.
-					EXPECT().
-					Get(gomock.Any(), fmt.Sprintf("%s-%s", forgetPasswordCachePrefix, "test@example.com")).
-					Return("1", nil).
-					Times(1)
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
 
 				usersService.
 					EXPECT().
-					GetUserByEmail(gomock.Any(), "test@example.com").
-					Return(&entities.User{ID: 1, EmailConfirmed: true}, nil).
-					Times(1)
-
-				forgetPasswordDTO := notifications.ForgetPasswordDTO{UserID: uint64(1)}
-				content, _ := json.Marshal(forgetPasswordDTO)
-				natsPublisher.
-					EXPECT().
-					Publish("forget-password", content).
-					Return(nil).
-					Times(1)
-
-				cacheProvider.
-					EXPECT().
-					Incr(gomock.Any(), gomock.Any()).
-					Return(int64(1), nil).
+					IsUserBlocked(gomock.Any(), uint64(1)).
+					Return(false, nil).
 					Times(1)
 			},
-			expectedErr: nil,
+			expectedIntrospection: &entities.TokenIntrospection{
+				Active:    true,
+				TokenType: entities.AccessTokenType,
+				UserID:    1,
+				SessionID: 2,
+				Roles:     []string{entities.UserRole},
+			},
 		},
 		{
-			name:  "email not confirmed",
-			email: "test@example.com",
+			name:  "active access token with scopes",
+			token: scopedAccessToken,
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
-				cacheProvider.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
-				cacheProvider.
-					EXPECT().
-					Get(gomock.Any(), fmt.Sprintf("%s-%s", forgetPasswordCachePrefix, "test@example.com")).
-					Return("1", nil).
-					Times(1)
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
 
 				usersService.
 					EXPECT().
-					GetUserByEmail(gomock.Any(), "test@example.com").
-					Return(&entities.User{ID: 1, EmailConfirmed: false}, nil).
+					IsUserBlocked(gomock.Any(), uint64(1)).
+					Return(false, nil).
 					Times(1)
 			},
-			expectedErr: &customerrors.EmailIsNotConfirmedError{},
+			expectedIntrospection: &entities.TokenIntrospection{
+				Active:    true,
+				TokenType: entities.AccessTokenType,
+				UserID:    1,
+				SessionID: 2,
+				Scopes:    []string{entities.OpenIDScope, entities.EmailScope},
+				Roles:     []string{entities.UserRole},
+			},
 		},
 		{
-			name:  "user not found",
-			email: "test@example.com",
+			name:                  "invalid access token",
+			token:                 "header.payload.signature",
+			expectedIntrospection: &entities.TokenIntrospection{Active: false},
+		},
+		{
+			name:  "revoked access token",
+			token: accessToken,
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
 				cacheProvider.
 					EXPECT().
 					Ping(gomock.Any()).
-					Return("", nil).
+					Return("PONG", nil).
 					Times(1)
 
 				cacheProvider.
 					EXPECT().
-					Get(gomock.Any(), fmt.Sprintf("%s-%s", forgetPasswordCachePrefix, "test@example.com")).
-					Return("1", nil).
+					Get(gomock.Any(), revokedAccessTokenCacheKey("jti")).
+					Return("", errors.New("redis: nil")).
 					Times(1)
 
-				usersService.
+				cacheProvider.
 					EXPECT().
-					GetUserByEmail(gomock.Any(), "test@example.com").
-					Return(nil, &customerrors.UserNotFoundError{}).
+					Get(gomock.Any(), revokedSessionCacheKey(2)).
+					Return("1", nil).
 					Times(1)
 			},
-			expectedErr: &customerrors.UserNotFoundError{},
+			expectedIntrospection: &entities.TokenIntrospection{Active: false},
 		},
 		{
-			name:  "publish error",
-			email: "test@example.com",
+			name:  "blocked user",
+			token: accessToken,
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
-				cacheProvider.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
-				cacheProvider.
-					EXPECT().
-					Get(gomock.Any(), fmt.Sprintf("%s-%s", forgetPasswordCachePrefix, "test@example.com")).
-					Return("1", nil).
-					Times(1)
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
 
 				usersService.
 					EXPECT().
-					GetUserByEmail(gomock.Any(), "test@example.com").
-					Return(&entities.User{ID: 1, EmailConfirmed: true}, nil).
-					Times(1)
-
-				forgetPasswordDTO := notifications.ForgetPasswordDTO{UserID: uint64(1)}
-				content, _ := json.Marshal(forgetPasswordDTO)
-				natsPublisher.
-					EXPECT().
-					Publish("forget-password", content).
-					Return(errors.New("publish failed")).
-					Times(1)
-
-				logger.
-					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					IsUserBlocked(gomock.Any(), uint64(1)).
+					Return(true, nil).
 					Times(1)
 			},
-			expectedErr: errors.New("publish failed"),
+			expectedIntrospection: &entities.TokenIntrospection{Active: false},
 		},
 		{
-			name:  "cache incr error",
-			email: "test@example.com",
+			name:  "refresh token of blocked user",
+			token: refreshToken,
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
-				cacheProvider.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
-				cacheProvider.
+				authService.
 					EXPECT().
-					Get(gomock.Any(), fmt.Sprintf("%s-%s", forgetPasswordCachePrefix, "test@example.com")).
-					Return("1", nil).
+					GetRefreshTokenByValue(gomock.Any(), hashRefreshToken(refreshToken)).
+					Return(
+						&entities.RefreshToken{
+							ID:        3,
+							UserID:    1,
+							SessionID: pointers.New[uint64](2),
+							TTL:       now.Add(time.Hour),
+							CreatedAt: now,
+						},
+						nil,
+					).
 					Times(1)
 
 				usersService.
 					EXPECT().
-					GetUserByEmail(gomock.Any(), "test@example.com").
-					Return(&entities.User{ID: 1, EmailConfirmed: true}, nil).
-					Times(1)
-
-				forgetPasswordDTO := notifications.ForgetPasswordDTO{UserID: uint64(1)}
-				content, _ := json.Marshal(forgetPasswordDTO)
-				natsPublisher.
-					EXPECT().
-					Publish("forget-password", content).
-					Return(nil).
-					Times(1)
-
-				cacheProvider.
-					EXPECT().
-					Incr(gomock.Any(), gomock.Any()).
-					Return(int64(0), errors.New("cache incr error")).
-					Times(1)
-
-				logger.
-					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					IsUserBlocked(gomock.Any(), uint64(1)).
+					Return(true, nil).
 					Times(1)
 			},
+			expectedIntrospection: &entities.TokenIntrospection{Active: false},
 		},
 		{
-			name:  "get cache error",
-			email: "test@example.com",
+			name:  "deleted user",
+			token: refreshToken,
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
-				cacheProvider.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
-				cacheProvider.
-					EXPECT().
-					Get(gomock.Any(), fmt.Sprintf("%s-%s", forgetPasswordCachePrefix, "test@example.com")).
-					Return("", errors.New("")).
-					Times(1)
-
-				logger.
+				authService.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					GetRefreshTokenByValue(gomock.Any(), hashRefreshToken(refreshToken)).
+					Return(
+						&entities.RefreshToken{
+							ID:        3,
+							UserID:    1,
+							SessionID: pointers.New[uint64](2),
+							TTL:       now.Add(time.Hour),
+							CreatedAt: now,
+						},
+						nil,
+					).
 					Times(1)
 
 				usersService.
 					EXPECT().
-					GetUserByEmail(gomock.Any(), "test@example.com").
-					Return(nil, &customerrors.UserNotFoundError{}).
+					IsUserBlocked(gomock.Any(), uint64(1)).
+					Return(false, &customerrors.UserNotFoundError{}).
 					Times(1)
 			},
-			expectedErr: &customerrors.UserNotFoundError{},
+			expectedIntrospection: &entities.TokenIntrospection{Active: false},
 		},
 		{
-			name:  "invalid cache error",
-			email: "test@example.com",
+			name:  "check if user is blocked error",
+			token: refreshToken,
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
-				cacheProvider.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
-				cacheProvider.
-					EXPECT().
-					Get(gomock.Any(), fmt.Sprintf("%s-%s", forgetPasswordCachePrefix, "test@example.com")).
-					Return("invalid", nil).
-					Times(1)
-
-				logger.
+				authService.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					GetRefreshTokenByValue(gomock.Any(), hashRefreshToken(refreshToken)).
+					Return(
+						&entities.RefreshToken{
+							ID:        3,
+							UserID:    1,
+							SessionID: pointers.New[uint64](2),
+							TTL:       now.Add(time.Hour),
+							CreatedAt: now,
+						},
+						nil,
+					).
 					Times(1)
 
 				usersService.
 					EXPECT().
-					GetUserByEmail(gomock.Any(), "test@example.com").
-					Return(nil, &customerrors.UserNotFoundError{}).
+					IsUserBlocked(gomock.Any(), uint64(1)).
+					Return(false, errors.New("test")).
 					Times(1)
 			},
-			expectedErr: &customerrors.UserNotFoundError{},
+			expectedErr: errors.New("test"),
 		},
 		{
-			name:  "limit exceeded error",
-			email: "test@example.com",
+			name:  "active refresh token",
+			token: refreshToken,
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
-				cacheProvider.
+				authService.
 					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
+					GetRefreshTokenByValue(gomock.Any(), hashRefreshToken(refreshToken)).
+					Return(
+						&entities.RefreshToken{
+							ID:        3,
+							UserID:    1,
+							SessionID: pointers.New[uint64](2),
+							TTL:       now.Add(time.Hour),
+							CreatedAt: now,
+						},
+						nil,
+					).
 					Times(1)
 
-				cacheProvider.
+				usersService.
 					EXPECT().
-					Get(gomock.Any(), fmt.Sprintf("%s-%s", forgetPasswordCachePrefix, "test@example.com")).
-					Return("4", nil).
+					IsUserBlocked(gomock.Any(), uint64(1)).
+					Return(false, nil).
 					Times(1)
-
 			},
-			expectedErr: &customerrors.LimitExceededError{},
+			expectedIntrospection: &entities.TokenIntrospection{
+				Active:    true,
+				TokenType: entities.RefreshTokenType,
+				UserID:    1,
+				SessionID: 2,
+				IssuedAt:  now,
+				ExpiresAt: now.Add(time.Hour),
+			},
 		},
 		{
-			name:  "cache set error",
-			email: "test@example.com",
+			name:  "unknown refresh token",
+			token: refreshToken,
 			setupMocks: func(
 				authService *mockservices.MockAuthService,
 				usersService *mockservices.MockUsersService,
 				natsPublisher *mocknats.MockPublisher,
 				logger *mocklogging.MockLogger,
-				cacheProvider *mockcache.MockProvider,
+				cacheProvider *mockcache.MockCacheProvider,
 			) {
-				cacheProvider.
-					EXPECT().
-					Ping(gomock.Any()).
-					Return("", nil).
-					Times(1)
-
-				cacheProvider.
-					EXPECT().
-					Get(gomock.Any(), fmt.Sprintf("%s-%s", forgetPasswordCachePrefix, "test@example.com")).
-					Return("0", nil).
-					Times(1)
-
-				usersService.
-					EXPECT().
-					GetUserByEmail(gomock.Any(), "test@example.com").
-					Return(&entities.User{ID: 1, EmailConfirmed: true}, nil).
-					Times(1)
-
-				forgetPasswordDTO := notifications.ForgetPasswordDTO{UserID: uint64(1)}
-				content, _ := json.Marshal(forgetPasswordDTO)
-				natsPublisher.
+				authService.
 					EXPECT().
-					Publish("forget-password", content).
-					Return(nil).
+					GetRefreshTokenByValue(gomock.Any(), hashRefreshToken(refreshToken)).
+					Return(nil, errors.New("not found")).
 					Times(1)
-
-				cacheProvider.
+			},
+			expectedIntrospection: &entities.TokenIntrospection{Active: false},
+		},
+		{
+			name:  "rotated refresh token",
+			token: refreshToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
 					EXPECT().
-					Set(
-						gomock.Any(),
-						fmt.Sprintf("%s-%s", forgetPasswordCachePrefix, "test@example.com"),
-						1,
-						forgetPasswordTTL,
+					GetRefreshTokenByValue(gomock.Any(), hashRefreshToken(refreshToken)).
+					Return(
+						&entities.RefreshToken{
+							ID:        3,
+							UserID:    1,
+							TTL:       now.Add(time.Hour),
+							RotatedAt: pointers.New(now),
+						},
+						nil,
 					).
-					Return(errors.New("cache incr error")).
 					Times(1)
-
-				logger.
+			},
+			expectedIntrospection: &entities.TokenIntrospection{Active: false},
+		},
+		{
+			name:  "expired refresh token",
+			token: refreshToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
 					EXPECT().
-					ErrorContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					GetRefreshTokenByValue(gomock.Any(), hashRefreshToken(refreshToken)).
+					Return(
+						&entities.RefreshToken{
+							ID:     3,
+							UserID: 1,
+							TTL:    now.Add(-time.Hour),
+						},
+						nil,
+					).
 					Times(1)
 			},
+			expectedIntrospection: &entities.TokenIntrospection{Active: false},
 		},
 	}
 
@@ -2789,12 +5636,27 @@ func TestUseCases_SendForgetPasswordMessage(t *testing.T) {
 				)
 			}
 
-			err := useCases.SendForgetPasswordMessage(context.Background(), tc.email)
+			tokenIntrospection, err := useCases.IntrospectToken(context.Background(), tc.token)
 			if tc.expectedErr != nil {
 				require.Error(t, err)
 				require.IsType(t, tc.expectedErr, err)
-			} else {
-				require.NoError(t, err)
+				require.Nil(t, tokenIntrospection)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedIntrospection.Active, tokenIntrospection.Active)
+			require.Equal(t, tc.expectedIntrospection.TokenType, tokenIntrospection.TokenType)
+			require.Equal(t, tc.expectedIntrospection.UserID, tokenIntrospection.UserID)
+			require.Equal(t, tc.expectedIntrospection.SessionID, tokenIntrospection.SessionID)
+			require.Equal(t, tc.expectedIntrospection.Scopes, tokenIntrospection.Scopes)
+			require.Equal(t, tc.expectedIntrospection.Roles, tokenIntrospection.Roles)
+
+			// Issue and expiration times of access tokens depend on the time of their creation:
+			if tc.expectedIntrospection.TokenType == entities.RefreshTokenType {
+				require.Equal(t, tc.expectedIntrospection.IssuedAt, tokenIntrospection.IssuedAt)
+				require.Equal(t, tc.expectedIntrospection.ExpiresAt, tokenIntrospection.ExpiresAt)
 			}
 		})
 	}
@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+	"github.com/DKhorkov/hmtm-sso/internal/webauthn"
+)
+
+const webAuthnTransportsSeparator = ","
+
+// webAuthnUserHandle returns user handle, which is stored by authenticator with discoverable credential.
+// It is User ID, because user handle should not contain personal information such as email.
+func webAuthnUserHandle(userID uint64) []byte {
+	return []byte(strconv.FormatUint(userID, 10))
+}
+
+func webAuthnCredentialDescriptors(credentials []entities.WebAuthnCredential) []webauthn.CredentialDescriptor {
+	descriptors := make([]webauthn.CredentialDescriptor, 0, len(credentials))
+	for _, credential := range credentials {
+		descriptor := webauthn.CredentialDescriptor{
+			Type: webauthn.PublicKeyCredentialType,
+			ID:   credential.CredentialID,
+		}
+
+		if credential.Transports != "" {
+			descriptor.Transports = strings.Split(credential.Transports, webAuthnTransportsSeparator)
+		}
+
+		descriptors = append(descriptors, descriptor)
+	}
+
+	return descriptors
+}
+
+func marshalWebAuthnOptions(options any) (string, error) {
+	encoded, err := json.Marshal(options)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
+
+// createWebAuthnChallenge stores hash of new challenge, so it can be used only once during provided ceremony.
+func (useCases *UseCases) createWebAuthnChallenge(
+	ctx context.Context,
+	userID *uint64,
+	ceremony string,
+) (string, error) {
+	challenge, err := webauthn.GenerateChallenge()
+	if err != nil {
+		return "", err
+	}
+
+	if _, err = useCases.authService.CreateWebAuthnChallenge(
+		ctx,
+		entities.CreateWebAuthnChallengeDTO{
+			UserID:        userID,
+			ChallengeHash: hashToken(useCases.tokensConfig.SecretKey, challenge),
+			Ceremony:      ceremony,
+			TTL:           useCases.tokensConfig.WebAuthnChallenge.TTL,
+		},
+	); err != nil {
+		return "", err
+	}
+
+	return challenge, nil
+}
+
+// getWebAuthnChallenge checks client data, which was collected by browser, and returns challenge,
+// which was signed by authenticator.
+func (useCases *UseCases) getWebAuthnChallenge(
+	ctx context.Context,
+	clientDataJSON []byte,
+	clientDataType string,
+	ceremony string,
+) (*entities.WebAuthnChallenge, error) {
+	clientData, err := webauthn.ParseClientData(clientDataJSON, clientDataType, useCases.webAuthnConfig.Origins)
+	if err != nil {
+		return nil, &customerrors.InvalidWebAuthnCredentialError{BaseErr: err}
+	}
+
+	challenge, err := useCases.authService.GetWebAuthnChallengeByHash(
+		ctx,
+		hashToken(useCases.tokensConfig.SecretKey, clientData.Challenge),
+		ceremony,
+	)
+	if err != nil {
+		return nil, &customerrors.InvalidWebAuthnChallengeError{BaseErr: err}
+	}
+
+	return challenge, nil
+}
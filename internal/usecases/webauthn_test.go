@@ -0,0 +1,1248 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocklogging "github.com/DKhorkov/libs/logging/mocks"
+	mocknats "github.com/DKhorkov/libs/nats/mocks"
+	"github.com/DKhorkov/libs/pointers"
+	"github.com/DKhorkov/libs/security"
+
+	"github.com/DKhorkov/hmtm-sso/internal/config"
+	"github.com/DKhorkov/hmtm-sso/internal/entities"
+	customerrors "github.com/DKhorkov/hmtm-sso/internal/errors"
+	"github.com/DKhorkov/hmtm-sso/internal/webauthn"
+	"github.com/DKhorkov/hmtm-sso/internal/webauthn/webauthntest"
+	mockcache "github.com/DKhorkov/hmtm-sso/mocks/cache"
+	mockservices "github.com/DKhorkov/hmtm-sso/mocks/services"
+)
+
+const webAuthnChallenge = "webauthn-challenge"
+
+// newAuthenticator creates software authenticator for Relying Party from test config.
+func newAuthenticator(t *testing.T) *webauthntest.Authenticator {
+	t.Helper()
+
+	authenticator, err := webauthntest.New(
+		webAuthnConfig.RPID,
+		webAuthnConfig.Origins[0],
+		webauthn.AlgorithmES256,
+	)
+	require.NoError(t, err)
+
+	return authenticator
+}
+
+// newWebAuthnCredential registers credential of authenticator for User with ID=1 in the same form,
+// as it is stored in database.
+func newWebAuthnCredential(t *testing.T, authenticator *webauthntest.Authenticator) *entities.WebAuthnCredential {
+	t.Helper()
+
+	attestation, err := authenticator.Create(webAuthnChallenge)
+	require.NoError(t, err)
+
+	authenticatorData, err := webauthn.ParseAttestationObject(attestation.AttestationObject, webAuthnConfig.RPID)
+	require.NoError(t, err)
+
+	return &entities.WebAuthnCredential{
+		ID:           1,
+		UserID:       1,
+		CredentialID: attestation.CredentialID,
+		PublicKey:    authenticatorData.PublicKey,
+		SignCount:    authenticatorData.SignCount,
+		Transports:   "internal,hybrid",
+	}
+}
+
+// newWebAuthnLoginData signs challenge with authenticator and returns data, which is sent by browser.
+func newWebAuthnLoginData(
+	t *testing.T,
+	authenticator *webauthntest.Authenticator,
+	userHandle []byte,
+) entities.FinishWebAuthnLoginDTO {
+	t.Helper()
+
+	assertion, err := authenticator.Get(webAuthnChallenge, userHandle)
+	require.NoError(t, err)
+
+	return entities.FinishWebAuthnLoginDTO{
+		CredentialID:      assertion.CredentialID,
+		ClientDataJSON:    assertion.ClientDataJSON,
+		AuthenticatorData: assertion.AuthenticatorData,
+		Signature:         assertion.Signature,
+		UserHandle:        assertion.UserHandle,
+	}
+}
+
+func TestUseCases_BeginWebAuthnRegistration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:      "secret",
+			Algorithm:      "HS256",
+			AccessTokenTTL: time.Hour,
+		},
+	}
+	natsConfig := config.NATSConfig{}
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		natsConfig,
+		logger,
+		cacheProvider,
+	)
+
+	accessToken := newAccessToken(t, securityConfig.JWT, 1, 2)
+	user := &entities.User{ID: 1, Email: "test@example.com", DisplayName: "test"}
+	credential := &entities.WebAuthnCredential{ID: 1, UserID: 1, CredentialID: "credential", Transports: "internal"}
+
+	var challengeHash string
+
+	testCases := []struct {
+		name        string
+		accessToken string
+		setupMocks  func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+			natsPublisher *mocknats.MockPublisher,
+			logger *mocklogging.MockLogger,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedErr error
+	}{
+		{
+			name:        "success",
+			accessToken: accessToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(user, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetUserWebAuthnCredentials(gomock.Any(), uint64(1)).
+					Return([]entities.WebAuthnCredential{*credential}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateWebAuthnChallenge(gomock.Any(), gomock.Any()).
+					DoAndReturn(
+						func(_ context.Context, challengeData entities.CreateWebAuthnChallengeDTO) (uint64, error) {
+							require.Equal(t, pointers.New[uint64](1), challengeData.UserID)
+							require.Equal(t, entities.WebAuthnRegistrationCeremony, challengeData.Ceremony)
+							require.Equal(t, tokensConfig.WebAuthnChallenge.TTL, challengeData.TTL)
+
+							challengeHash = challengeData.ChallengeHash
+
+							return 1, nil
+						},
+					).
+					Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "user not found",
+			accessToken: accessToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(nil, &customerrors.UserNotFoundError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.UserNotFoundError{},
+		},
+		{
+			name:        "create webauthn challenge error",
+			accessToken: accessToken,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(user, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetUserWebAuthnCredentials(gomock.Any(), uint64(1)).
+					Return(nil, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateWebAuthnChallenge(gomock.Any(), gomock.Any()).
+					Return(uint64(0), errors.New("test")).
+					Times(1)
+			},
+			expectedErr: errors.New("test"),
+		},
+		{
+			name:        "invalid token",
+			accessToken: "invalid_token",
+			expectedErr: &security.InvalidJWTError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(
+					authService,
+					usersService,
+					natsPublisher,
+					logger,
+					cacheProvider,
+				)
+			}
+
+			options, err := useCases.BeginWebAuthnRegistration(context.Background(), tc.accessToken)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Empty(t, options)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			var creationOptions webauthn.CreationOptions
+			require.NoError(t, json.Unmarshal([]byte(options), &creationOptions))
+			require.Equal(t, challengeHash, hashToken(tokensConfig.SecretKey, creationOptions.Challenge))
+			require.Equal(t, webAuthnConfig.RPID, creationOptions.RelyingParty.ID)
+			require.Equal(t, webauthn.Encoding.EncodeToString([]byte("1")), creationOptions.User.ID)
+			require.Equal(t, user.Email, creationOptions.User.Name)
+			require.Equal(
+				t,
+				[]webauthn.CredentialDescriptor{
+					{
+						Type:       webauthn.PublicKeyCredentialType,
+						ID:         credential.CredentialID,
+						Transports: []string{"internal"},
+					},
+				},
+				creationOptions.ExcludeCredentials,
+			)
+		})
+	}
+}
+
+func TestUseCases_FinishWebAuthnRegistration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:      "secret",
+			Algorithm:      "HS256",
+			AccessTokenTTL: time.Hour,
+		},
+	}
+	natsConfig := config.NATSConfig{}
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		natsConfig,
+		logger,
+		cacheProvider,
+	)
+
+	accessToken := newAccessToken(t, securityConfig.JWT, 1, 2)
+	challengeHash := hashToken(tokensConfig.SecretKey, webAuthnChallenge)
+	challenge := &entities.WebAuthnChallenge{
+		ID:            1,
+		UserID:        pointers.New[uint64](1),
+		ChallengeHash: challengeHash,
+		Ceremony:      entities.WebAuthnRegistrationCeremony,
+	}
+
+	authenticator := newAuthenticator(t)
+	attestation, err := authenticator.Create(webAuthnChallenge)
+	require.NoError(t, err)
+
+	registrationData := entities.FinishWebAuthnRegistrationDTO{
+		AccessToken:       accessToken,
+		CredentialID:      attestation.CredentialID,
+		ClientDataJSON:    attestation.ClientDataJSON,
+		AttestationObject: attestation.AttestationObject,
+		Transports:        []string{"internal", "hybrid"},
+	}
+
+	// Authenticator of phishing site creates credential for another origin and Relying Party:
+	phishingAuthenticator, err := webauthntest.New("example.com", "https://example.com", webauthn.AlgorithmES256)
+	require.NoError(t, err)
+
+	phishingAttestation, err := phishingAuthenticator.Create(webAuthnChallenge)
+	require.NoError(t, err)
+
+	phishingAuthenticator.Origin = webAuthnConfig.Origins[0]
+	anotherRPAttestation, err := phishingAuthenticator.Create(webAuthnChallenge)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name             string
+		registrationData entities.FinishWebAuthnRegistrationDTO
+		setupMocks       func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+			natsPublisher *mocknats.MockPublisher,
+			logger *mocklogging.MockLogger,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedErr error
+	}{
+		{
+			name:             "success",
+			registrationData: registrationData,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					GetWebAuthnChallengeByHash(gomock.Any(), challengeHash, entities.WebAuthnRegistrationCeremony).
+					Return(challenge, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetWebAuthnCredential(gomock.Any(), attestation.CredentialID).
+					Return(nil, &customerrors.WebAuthnCredentialNotFoundError{}).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ExpireWebAuthnChallenge(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateWebAuthnCredential(gomock.Any(), gomock.Any()).
+					DoAndReturn(
+						func(_ context.Context, credentialData entities.CreateWebAuthnCredentialDTO) (uint64, error) {
+							require.Equal(t, uint64(1), credentialData.UserID)
+							require.Equal(t, attestation.CredentialID, credentialData.CredentialID)
+							require.NotEmpty(t, credentialData.PublicKey)
+							require.Equal(t, "internal,hybrid", credentialData.Transports)
+
+							return 1, nil
+						},
+					).
+					Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "another origin",
+			registrationData: entities.FinishWebAuthnRegistrationDTO{
+				AccessToken:       accessToken,
+				CredentialID:      phishingAttestation.CredentialID,
+				ClientDataJSON:    phishingAttestation.ClientDataJSON,
+				AttestationObject: phishingAttestation.AttestationObject,
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+			},
+			expectedErr: &customerrors.InvalidWebAuthnCredentialError{},
+		},
+		{
+			name:             "invalid webauthn challenge",
+			registrationData: registrationData,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					GetWebAuthnChallengeByHash(gomock.Any(), challengeHash, entities.WebAuthnRegistrationCeremony).
+					Return(nil, errors.New("test")).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidWebAuthnChallengeError{},
+		},
+		{
+			name:             "webauthn challenge of another user",
+			registrationData: registrationData,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					GetWebAuthnChallengeByHash(gomock.Any(), challengeHash, entities.WebAuthnRegistrationCeremony).
+					Return(
+						&entities.WebAuthnChallenge{ID: 1, UserID: pointers.New[uint64](2), ChallengeHash: challengeHash},
+						nil,
+					).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidWebAuthnChallengeError{},
+		},
+		{
+			name: "credential of another relying party",
+			registrationData: entities.FinishWebAuthnRegistrationDTO{
+				AccessToken:       accessToken,
+				CredentialID:      anotherRPAttestation.CredentialID,
+				ClientDataJSON:    anotherRPAttestation.ClientDataJSON,
+				AttestationObject: anotherRPAttestation.AttestationObject,
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					GetWebAuthnChallengeByHash(gomock.Any(), challengeHash, entities.WebAuthnRegistrationCeremony).
+					Return(challenge, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidWebAuthnCredentialError{},
+		},
+		{
+			name: "credential ID does not match attested credential",
+			registrationData: entities.FinishWebAuthnRegistrationDTO{
+				AccessToken:       accessToken,
+				CredentialID:      "another_credential",
+				ClientDataJSON:    attestation.ClientDataJSON,
+				AttestationObject: attestation.AttestationObject,
+			},
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					GetWebAuthnChallengeByHash(gomock.Any(), challengeHash, entities.WebAuthnRegistrationCeremony).
+					Return(challenge, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidWebAuthnCredentialError{},
+		},
+		{
+			name:             "credential is already registered",
+			registrationData: registrationData,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					GetWebAuthnChallengeByHash(gomock.Any(), challengeHash, entities.WebAuthnRegistrationCeremony).
+					Return(challenge, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetWebAuthnCredential(gomock.Any(), attestation.CredentialID).
+					Return(&entities.WebAuthnCredential{ID: 1, UserID: 1}, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidWebAuthnCredentialError{},
+		},
+		{
+			name:             "expire webauthn challenge error",
+			registrationData: registrationData,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				expectAccessTokenIsNotRevoked(cacheProvider, 2)
+				expectUserIsNotBlocked(usersService, 1)
+
+				authService.
+					EXPECT().
+					GetWebAuthnChallengeByHash(gomock.Any(), challengeHash, entities.WebAuthnRegistrationCeremony).
+					Return(challenge, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetWebAuthnCredential(gomock.Any(), attestation.CredentialID).
+					Return(nil, &customerrors.WebAuthnCredentialNotFoundError{}).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ExpireWebAuthnChallenge(gomock.Any(), uint64(1)).
+					Return(&customerrors.InvalidWebAuthnChallengeError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidWebAuthnChallengeError{},
+		},
+		{
+			name: "invalid token",
+			registrationData: entities.FinishWebAuthnRegistrationDTO{
+				AccessToken: "invalid_token",
+			},
+			expectedErr: &security.InvalidJWTError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(
+					authService,
+					usersService,
+					natsPublisher,
+					logger,
+					cacheProvider,
+				)
+			}
+
+			err := useCases.FinishWebAuthnRegistration(context.Background(), tc.registrationData)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUseCases_BeginWebAuthnLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:      "secret",
+			Algorithm:      "HS256",
+			AccessTokenTTL: time.Hour,
+		},
+	}
+	natsConfig := config.NATSConfig{}
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		natsConfig,
+		logger,
+		cacheProvider,
+	)
+
+	user := &entities.User{ID: 1, Email: "test@example.com"}
+	credential := entities.WebAuthnCredential{ID: 1, UserID: 1, CredentialID: "credential", Transports: "usb,nfc"}
+
+	testCases := []struct {
+		name       string
+		email      string
+		setupMocks func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+			natsPublisher *mocknats.MockPublisher,
+			logger *mocklogging.MockLogger,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedAllowCredentials []webauthn.CredentialDescriptor
+		expectedErr              error
+	}{
+		{
+			name:  "success with discoverable credentials",
+			email: "",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					CreateWebAuthnChallenge(gomock.Any(), gomock.Any()).
+					DoAndReturn(
+						func(_ context.Context, challengeData entities.CreateWebAuthnChallengeDTO) (uint64, error) {
+							require.Nil(t, challengeData.UserID)
+							require.Equal(t, entities.WebAuthnLoginCeremony, challengeData.Ceremony)
+
+							return 1, nil
+						},
+					).
+					Times(1)
+			},
+			expectedAllowCredentials: []webauthn.CredentialDescriptor{},
+			expectedErr:              nil,
+		},
+		{
+			name:  "success with email",
+			email: user.Email,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), user.Email).
+					Return(user, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetUserWebAuthnCredentials(gomock.Any(), uint64(1)).
+					Return([]entities.WebAuthnCredential{credential}, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateWebAuthnChallenge(gomock.Any(), gomock.Any()).
+					DoAndReturn(
+						func(_ context.Context, challengeData entities.CreateWebAuthnChallengeDTO) (uint64, error) {
+							require.Equal(t, pointers.New[uint64](1), challengeData.UserID)
+							require.Equal(t, entities.WebAuthnLoginCeremony, challengeData.Ceremony)
+
+							return 1, nil
+						},
+					).
+					Times(1)
+			},
+			expectedAllowCredentials: []webauthn.CredentialDescriptor{
+				{
+					Type:       webauthn.PublicKeyCredentialType,
+					ID:         credential.CredentialID,
+					Transports: []string{"usb", "nfc"},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "user not found",
+			email: user.Email,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), user.Email).
+					Return(nil, &customerrors.UserNotFoundError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.UserNotFoundError{},
+		},
+		{
+			name:  "user has no credentials",
+			email: user.Email,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				usersService.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), user.Email).
+					Return(user, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetUserWebAuthnCredentials(gomock.Any(), uint64(1)).
+					Return(nil, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.WebAuthnCredentialNotFoundError{},
+		},
+		{
+			name:  "create webauthn challenge error",
+			email: "",
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					CreateWebAuthnChallenge(gomock.Any(), gomock.Any()).
+					Return(uint64(0), errors.New("test")).
+					Times(1)
+			},
+			expectedErr: errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(
+					authService,
+					usersService,
+					natsPublisher,
+					logger,
+					cacheProvider,
+				)
+			}
+
+			options, err := useCases.BeginWebAuthnLogin(context.Background(), tc.email)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Empty(t, options)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			var requestOptions webauthn.RequestOptions
+			require.NoError(t, json.Unmarshal([]byte(options), &requestOptions))
+			require.NotEmpty(t, requestOptions.Challenge)
+			require.Equal(t, webAuthnConfig.RPID, requestOptions.RelyingPartyID)
+			require.Equal(t, tc.expectedAllowCredentials, requestOptions.AllowCredentials)
+		})
+	}
+}
+
+func TestUseCases_FinishWebAuthnLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mockservices.NewMockAuthService(ctrl)
+	usersService := mockservices.NewMockUsersService(ctrl)
+	natsPublisher := mocknats.NewMockPublisher(ctrl)
+	logger := mocklogging.NewMockLogger(ctrl)
+	cacheProvider := mockcache.NewMockCacheProvider(ctrl)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey:       "secret",
+			Algorithm:       "HS256",
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: time.Hour,
+		},
+	}
+	natsConfig := config.NATSConfig{}
+
+	useCases := New(
+		authService,
+		usersService,
+		securityConfig,
+		newJWTProvider(t, securityConfig.JWT),
+		newPasswordHasher(t),
+		accessTokensConfig,
+		tokensConfig,
+		webAuthnConfig,
+		oidcConfig,
+		nil,
+		telegramConfig,
+		lockoutConfig,
+		validationConfig,
+		natsPublisher,
+		natsConfig,
+		logger,
+		cacheProvider,
+	)
+
+	authenticator := newAuthenticator(t)
+	credential := newWebAuthnCredential(t, authenticator)
+	challengeHash := hashToken(tokensConfig.SecretKey, webAuthnChallenge)
+	discoverableChallenge := &entities.WebAuthnChallenge{
+		ID:            1,
+		ChallengeHash: challengeHash,
+		Ceremony:      entities.WebAuthnLoginCeremony,
+	}
+	user := &entities.User{ID: 1, Email: "test@example.com", EmailConfirmed: true}
+
+	// Each assertion increases sign count of authenticator, so login data is created for each case:
+	tamperedLoginData := newWebAuthnLoginData(t, authenticator, nil)
+	tamperedLoginData.Signature[len(tamperedLoginData.Signature)-1] ^= 0xff
+
+	testCases := []struct {
+		name       string
+		loginData  entities.FinishWebAuthnLoginDTO
+		setupMocks func(
+			authService *mockservices.MockAuthService,
+			usersService *mockservices.MockUsersService,
+			natsPublisher *mocknats.MockPublisher,
+			logger *mocklogging.MockLogger,
+			cacheProvider *mockcache.MockCacheProvider,
+		)
+		expectedErr error
+	}{
+		{
+			name:      "success with discoverable credential",
+			loginData: newWebAuthnLoginData(t, authenticator, []byte("1")),
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetWebAuthnChallengeByHash(gomock.Any(), challengeHash, entities.WebAuthnLoginCeremony).
+					Return(discoverableChallenge, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetWebAuthnCredential(gomock.Any(), credential.CredentialID).
+					Return(credential, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ExpireWebAuthnChallenge(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					UpdateWebAuthnCredentialSignCount(gomock.Any(), uint64(1), uint32(2)).
+					Return(nil).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(user, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Return(uint64(2), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateRefreshToken(gomock.Any(), refreshTokenData(1, 2, "")).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:      "success with allowed credential",
+			loginData: newWebAuthnLoginData(t, authenticator, nil),
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetWebAuthnChallengeByHash(gomock.Any(), challengeHash, entities.WebAuthnLoginCeremony).
+					Return(
+						&entities.WebAuthnChallenge{ID: 1, UserID: pointers.New[uint64](1), ChallengeHash: challengeHash},
+						nil,
+					).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetWebAuthnCredential(gomock.Any(), credential.CredentialID).
+					Return(credential, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ExpireWebAuthnChallenge(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					UpdateWebAuthnCredentialSignCount(gomock.Any(), uint64(1), uint32(3)).
+					Return(nil).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(user, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Return(uint64(2), nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					CreateRefreshToken(gomock.Any(), refreshTokenData(1, 2, "")).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:      "invalid webauthn challenge",
+			loginData: newWebAuthnLoginData(t, authenticator, nil),
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetWebAuthnChallengeByHash(gomock.Any(), challengeHash, entities.WebAuthnLoginCeremony).
+					Return(nil, errors.New("test")).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidWebAuthnChallengeError{},
+		},
+		{
+			name:      "credential not found",
+			loginData: newWebAuthnLoginData(t, authenticator, nil),
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetWebAuthnChallengeByHash(gomock.Any(), challengeHash, entities.WebAuthnLoginCeremony).
+					Return(discoverableChallenge, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetWebAuthnCredential(gomock.Any(), credential.CredentialID).
+					Return(nil, &customerrors.WebAuthnCredentialNotFoundError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.WebAuthnCredentialNotFoundError{},
+		},
+		{
+			name:      "credential is not allowed for challenge",
+			loginData: newWebAuthnLoginData(t, authenticator, nil),
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetWebAuthnChallengeByHash(gomock.Any(), challengeHash, entities.WebAuthnLoginCeremony).
+					Return(
+						&entities.WebAuthnChallenge{ID: 1, UserID: pointers.New[uint64](2), ChallengeHash: challengeHash},
+						nil,
+					).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetWebAuthnCredential(gomock.Any(), credential.CredentialID).
+					Return(credential, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidWebAuthnCredentialError{},
+		},
+		{
+			name:      "user handle does not match credential",
+			loginData: newWebAuthnLoginData(t, authenticator, []byte("2")),
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetWebAuthnChallengeByHash(gomock.Any(), challengeHash, entities.WebAuthnLoginCeremony).
+					Return(discoverableChallenge, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetWebAuthnCredential(gomock.Any(), credential.CredentialID).
+					Return(credential, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidWebAuthnCredentialError{},
+		},
+		{
+			name:      "invalid signature",
+			loginData: tamperedLoginData,
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetWebAuthnChallengeByHash(gomock.Any(), challengeHash, entities.WebAuthnLoginCeremony).
+					Return(discoverableChallenge, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetWebAuthnCredential(gomock.Any(), credential.CredentialID).
+					Return(credential, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidWebAuthnCredentialError{},
+		},
+		{
+			name:      "sign count has not increased",
+			loginData: newWebAuthnLoginData(t, authenticator, nil),
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetWebAuthnChallengeByHash(gomock.Any(), challengeHash, entities.WebAuthnLoginCeremony).
+					Return(discoverableChallenge, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetWebAuthnCredential(gomock.Any(), credential.CredentialID).
+					Return(credential, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ExpireWebAuthnChallenge(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					UpdateWebAuthnCredentialSignCount(gomock.Any(), uint64(1), gomock.Any()).
+					Return(&customerrors.InvalidWebAuthnCredentialError{}).
+					Times(1)
+			},
+			expectedErr: &customerrors.InvalidWebAuthnCredentialError{},
+		},
+		{
+			name:      "email is not confirmed",
+			loginData: newWebAuthnLoginData(t, authenticator, nil),
+			setupMocks: func(
+				authService *mockservices.MockAuthService,
+				usersService *mockservices.MockUsersService,
+				natsPublisher *mocknats.MockPublisher,
+				logger *mocklogging.MockLogger,
+				cacheProvider *mockcache.MockCacheProvider,
+			) {
+				authService.
+					EXPECT().
+					GetWebAuthnChallengeByHash(gomock.Any(), challengeHash, entities.WebAuthnLoginCeremony).
+					Return(discoverableChallenge, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					GetWebAuthnCredential(gomock.Any(), credential.CredentialID).
+					Return(credential, nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					ExpireWebAuthnChallenge(gomock.Any(), uint64(1)).
+					Return(nil).
+					Times(1)
+
+				authService.
+					EXPECT().
+					UpdateWebAuthnCredentialSignCount(gomock.Any(), uint64(1), gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				usersService.
+					EXPECT().
+					GetUserByID(gomock.Any(), uint64(1)).
+					Return(&entities.User{ID: 1, Email: "test@example.com"}, nil).
+					Times(1)
+			},
+			expectedErr: &customerrors.EmailIsNotConfirmedError{},
+		},
+		{
+			name:        "invalid client data",
+			loginData:   entities.FinishWebAuthnLoginDTO{ClientDataJSON: []byte("invalid")},
+			expectedErr: &customerrors.InvalidWebAuthnCredentialError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setupMocks != nil {
+				tc.setupMocks(
+					authService,
+					usersService,
+					natsPublisher,
+					logger,
+					cacheProvider,
+				)
+			}
+
+			tokens, err := useCases.FinishWebAuthnLogin(context.Background(), tc.loginData)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				require.IsType(t, tc.expectedErr, err)
+				require.Nil(t, tokens)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotEmpty(t, tokens.AccessToken)
+			require.NotEmpty(t, tokens.RefreshToken)
+			require.Nil(t, tokens.MFAChallenge)
+		})
+	}
+}
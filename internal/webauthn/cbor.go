@@ -0,0 +1,206 @@
+package webauthn
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// CBOR major types (RFC 8949):
+const (
+	cborUnsignedInt = iota
+	cborNegativeInt
+	cborByteString
+	cborTextString
+	cborArray
+	cborMap
+	cborTag
+	cborSimple
+)
+
+const maxCBORDepth = 16 // attestation objects and COSE keys are shallow, so deep nesting is malformed input
+
+var errUnexpectedCBOREnd = errors.New("unexpected end of CBOR data")
+
+// decodeCBOR decodes subset of CBOR, which is used by authenticators, and returns number of consumed bytes,
+// because credential public key in authenticator data has no length prefix.
+// Integers are decoded to int64, maps to map[any]any with int64 or string keys.
+func decodeCBOR(data []byte) (any, int, error) {
+	decoder := &cborDecoder{data: data}
+
+	value, err := decoder.decode(0)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return value, decoder.offset, nil
+}
+
+type cborDecoder struct {
+	data   []byte
+	offset int
+}
+
+func (d *cborDecoder) decode(depth int) (any, error) {
+	if depth > maxCBORDepth {
+		return nil, errors.New("CBOR data is too deeply nested")
+	}
+
+	if d.offset >= len(d.data) {
+		return nil, errUnexpectedCBOREnd
+	}
+
+	initial := d.data[d.offset]
+	d.offset++
+
+	majorType, info := initial>>5, initial&0x1f
+	if majorType == cborSimple {
+		return d.decodeSimple(info)
+	}
+
+	argument, err := d.argument(info)
+	if err != nil {
+		return nil, err
+	}
+
+	switch majorType {
+	case cborUnsignedInt:
+		if argument > math.MaxInt64 {
+			return nil, errors.New("CBOR integer overflows int64")
+		}
+
+		return int64(argument), nil
+	case cborNegativeInt:
+		if argument > math.MaxInt64 {
+			return nil, errors.New("CBOR integer overflows int64")
+		}
+
+		return -1 - int64(argument), nil
+	case cborByteString:
+		value, err := d.read(argument)
+		if err != nil {
+			return nil, err
+		}
+
+		return append([]byte(nil), value...), nil
+	case cborTextString:
+		value, err := d.read(argument)
+		if err != nil {
+			return nil, err
+		}
+
+		return string(value), nil
+	case cborArray:
+		return d.decodeArray(argument, depth)
+	case cborMap:
+		return d.decodeMap(argument, depth)
+	default:
+		return nil, fmt.Errorf("unsupported CBOR major type %d", majorType)
+	}
+}
+
+func (d *cborDecoder) decodeSimple(info byte) (any, error) {
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23: // null and undefined
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported CBOR simple value %d", info)
+	}
+}
+
+func (d *cborDecoder) decodeArray(length uint64, depth int) ([]any, error) {
+	// Each item takes at least one byte, so length can not exceed rest of data:
+	if length > uint64(len(d.data)-d.offset) {
+		return nil, errUnexpectedCBOREnd
+	}
+
+	items := make([]any, 0, length)
+	for range length {
+		item, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func (d *cborDecoder) decodeMap(length uint64, depth int) (map[any]any, error) {
+	if length > uint64(len(d.data)-d.offset) {
+		return nil, errUnexpectedCBOREnd
+	}
+
+	items := make(map[any]any, length)
+	for range length {
+		key, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		switch key.(type) {
+		case int64, string:
+		default:
+			return nil, fmt.Errorf("unsupported CBOR map key type %T", key)
+		}
+
+		if _, ok := items[key]; ok {
+			return nil, fmt.Errorf("duplicate CBOR map key %v", key)
+		}
+
+		if items[key], err = d.decode(depth + 1); err != nil {
+			return nil, err
+		}
+	}
+
+	return items, nil
+}
+
+func (d *cborDecoder) argument(info byte) (uint64, error) {
+	if info < 24 {
+		return uint64(info), nil
+	}
+
+	var size uint64
+	switch info {
+	case 24:
+		size = 1
+	case 25:
+		size = 2
+	case 26:
+		size = 4
+	case 27:
+		size = 8
+	default:
+		// Indefinite lengths are not allowed in CTAP2 canonical encoding:
+		return 0, fmt.Errorf("unsupported CBOR additional information %d", info)
+	}
+
+	value, err := d.read(size)
+	if err != nil {
+		return 0, err
+	}
+
+	var argument uint64
+	for _, b := range value {
+		argument = argument<<8 | uint64(b)
+	}
+
+	return argument, nil
+}
+
+func (d *cborDecoder) read(length uint64) ([]byte, error) {
+	if length > uint64(len(d.data)-d.offset) {
+		return nil, errUnexpectedCBOREnd
+	}
+
+	value := d.data[d.offset : d.offset+int(length)] //nolint:gosec // length is checked above
+	d.offset += int(length)                          //nolint:gosec // length is checked above
+
+	return value, nil
+}
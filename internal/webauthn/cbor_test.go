@@ -0,0 +1,79 @@
+package webauthn
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeCBOR(t *testing.T) {
+	// Examples from RFC 8949 Appendix A:
+	testCases := []struct {
+		name     string
+		hex      string
+		expected any
+	}{
+		{name: "small unsigned integer", hex: "17", expected: int64(23)},
+		{name: "one byte unsigned integer", hex: "1818", expected: int64(24)},
+		{name: "two bytes unsigned integer", hex: "1903e8", expected: int64(1000)},
+		{name: "negative integer", hex: "3863", expected: int64(-100)},
+		{name: "byte string", hex: "4401020304", expected: []byte{1, 2, 3, 4}},
+		{name: "text string", hex: "6449455446", expected: "IETF"},
+		{name: "array", hex: "83010203", expected: []any{int64(1), int64(2), int64(3)}},
+		{name: "map", hex: "a201020304", expected: map[any]any{int64(1): int64(2), int64(3): int64(4)}},
+		{name: "map with text keys", hex: "a26161016162820203", expected: map[any]any{
+			"a": int64(1),
+			"b": []any{int64(2), int64(3)},
+		}},
+		{name: "false", hex: "f4", expected: false},
+		{name: "true", hex: "f5", expected: true},
+		{name: "null", hex: "f6", expected: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := hex.DecodeString(tc.hex)
+			require.NoError(t, err)
+
+			value, consumed, err := decodeCBOR(data)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, value)
+			require.Equal(t, len(data), consumed)
+		})
+	}
+}
+
+func TestDecodeCBORConsumesSingleItem(t *testing.T) {
+	value, consumed, err := decodeCBOR([]byte{0x01, 0x02})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), value)
+	require.Equal(t, 1, consumed)
+}
+
+func TestDecodeCBORErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: []byte{}},
+		{name: "truncated argument", data: []byte{0x19, 0x03}},
+		{name: "truncated byte string", data: []byte{0x44, 0x01, 0x02}},
+		{name: "indefinite length", data: []byte{0x5f, 0x41, 0x01, 0xff}},
+		{name: "tag", data: []byte{0xc0, 0x00}},
+		{name: "float", data: []byte{0xf9, 0x00, 0x00}},
+		{name: "duplicate map key", data: []byte{0xa2, 0x01, 0x02, 0x01, 0x03}},
+		{name: "array map key", data: []byte{0xa1, 0x80, 0x01}},
+		{name: "huge array length", data: []byte{0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{name: "integer overflow", data: []byte{0x1b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{name: "too deep nesting", data: append(bytes.Repeat([]byte{0x81}, maxCBORDepth+1), 0x00)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := decodeCBOR(tc.data)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// COSE algorithms (https://www.iana.org/assignments/cose/cose.xhtml#algorithms), which are supported
+// for credential public keys:
+const (
+	AlgorithmES256 int64 = -7
+	AlgorithmEdDSA int64 = -8
+	AlgorithmRS256 int64 = -257
+)
+
+// SupportedAlgorithms are offered to authenticators in order of preference.
+var SupportedAlgorithms = []int64{AlgorithmES256, AlgorithmEdDSA, AlgorithmRS256}
+
+// COSE key parameters (RFC 9052 and RFC 9053). Negative labels depend on key type:
+const (
+	coseKeyType   int64 = 1
+	coseAlgorithm int64 = 3
+	coseCurve     int64 = -1 // OKP and EC2
+	coseX         int64 = -2 // OKP and EC2
+	coseY         int64 = -3 // EC2
+	coseModulus   int64 = -1 // RSA
+	coseExponent  int64 = -2 // RSA
+
+	coseKeyTypeOKP int64 = 1
+	coseKeyTypeEC2 int64 = 2
+	coseKeyTypeRSA int64 = 3
+
+	coseCurveP256    int64 = 1
+	coseCurveEd25519 int64 = 6
+
+	minRSAKeyBits = 2048
+)
+
+var errInvalidSignature = errors.New("invalid signature")
+
+type publicKey struct {
+	algorithm int64
+	key       crypto.PublicKey
+}
+
+// parsePublicKey parses credential public key in COSE_Key format.
+func parsePublicKey(coseKey []byte) (*publicKey, error) {
+	value, consumed, err := decodeCBOR(coseKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if consumed != len(coseKey) {
+		return nil, errors.New("unexpected data after COSE key")
+	}
+
+	params, ok := value.(map[any]any)
+	if !ok {
+		return nil, errors.New("COSE key is not a map")
+	}
+
+	keyType, _ := params[coseKeyType].(int64)
+	algorithm, _ := params[coseAlgorithm].(int64)
+
+	switch {
+	case keyType == coseKeyTypeEC2 && algorithm == AlgorithmES256:
+		curve, _ := params[coseCurve].(int64)
+		x, _ := params[coseX].([]byte)
+		y, _ := params[coseY].([]byte)
+
+		if curve != coseCurveP256 || len(x) != 32 || len(y) != 32 {
+			return nil, errors.New("invalid ES256 COSE key")
+		}
+
+		key := &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+
+		// Conversion to ECDH key checks, that point is on curve:
+		if _, err = key.ECDH(); err != nil {
+			return nil, fmt.Errorf("invalid ES256 COSE key: %w", err)
+		}
+
+		return &publicKey{algorithm: algorithm, key: key}, nil
+	case keyType == coseKeyTypeOKP && algorithm == AlgorithmEdDSA:
+		curve, _ := params[coseCurve].(int64)
+		x, _ := params[coseX].([]byte)
+
+		if curve != coseCurveEd25519 || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid EdDSA COSE key")
+		}
+
+		return &publicKey{algorithm: algorithm, key: ed25519.PublicKey(x)}, nil
+	case keyType == coseKeyTypeRSA && algorithm == AlgorithmRS256:
+		modulus, _ := params[coseModulus].([]byte)
+		exponent, _ := params[coseExponent].([]byte)
+
+		key := &rsa.PublicKey{
+			N: new(big.Int).SetBytes(modulus),
+			E: int(new(big.Int).SetBytes(exponent).Int64()),
+		}
+
+		if key.N.BitLen() < minRSAKeyBits || len(exponent) > 4 || key.E < 3 || key.E%2 == 0 {
+			return nil, errors.New("invalid RS256 COSE key")
+		}
+
+		return &publicKey{algorithm: algorithm, key: key}, nil
+	default:
+		return nil, fmt.Errorf("unsupported COSE key type %d with algorithm %d", keyType, algorithm)
+	}
+}
+
+func (k *publicKey) verify(data, signature []byte) error {
+	var valid bool
+
+	switch key := k.key.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(data)
+		valid = ecdsa.VerifyASN1(key, digest[:], signature)
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(key, data, signature)
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(data)
+		valid = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	}
+
+	if !valid {
+		return errInvalidSignature
+	}
+
+	return nil
+}
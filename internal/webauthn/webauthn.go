@@ -0,0 +1,328 @@
+// Package webauthn implements verification of WebAuthn registration and authentication ceremonies
+// (https://www.w3.org/TR/webauthn-2/) for passkeys. Credentials are requested with "none" attestation
+// conveyance, so attestation statements are not verified and authenticator model is not trusted.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Types of client data for registration and authentication ceremonies:
+const (
+	CreateCeremony = "webauthn.create"
+	GetCeremony    = "webauthn.get"
+)
+
+const (
+	PublicKeyCredentialType = "public-key"
+
+	// User verification (PIN or biometrics) is required, so passkey replaces both password and second factor:
+	userVerificationRequired = "required"
+	residentKeyPreferred     = "preferred"
+	attestationNone          = "none"
+
+	challengeLength = 32
+)
+
+// Authenticator data layout (https://www.w3.org/TR/webauthn-2/#sctn-authenticator-data):
+const (
+	rpIDHashLength             = sha256.Size
+	signCountOffset            = rpIDHashLength + 1
+	authenticatorDataMinLength = signCountOffset + 4
+	aaguidLength               = 16
+
+	flagUserPresent            byte = 0x01
+	flagUserVerified           byte = 0x04
+	flagAttestedCredentialData byte = 0x40
+	flagExtensionData          byte = 0x80
+)
+
+// Encoding of binary values in client data and JSON options, which is also used for credential IDs.
+var Encoding = base64.RawURLEncoding
+
+var (
+	errAuthenticatorDataTooShort = errors.New("authenticator data is too short")
+	errUserNotVerified           = errors.New("user presence and verification are required")
+)
+
+// GenerateChallenge creates random challenge, which should be signed by authenticator.
+func GenerateChallenge() (string, error) {
+	challenge := make([]byte, challengeLength)
+	if _, err := rand.Read(challenge); err != nil {
+		return "", err
+	}
+
+	return Encoding.EncodeToString(challenge), nil
+}
+
+// ClientData is collected by browser and signed by authenticator together with authenticator data.
+type ClientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+// ParseClientData parses client data JSON and checks its type and origin.
+// Challenge should be checked by caller, since it is stored outside of this package.
+func ParseClientData(raw []byte, ceremony string, origins []string) (*ClientData, error) {
+	var clientData ClientData
+	if err := json.Unmarshal(raw, &clientData); err != nil {
+		return nil, fmt.Errorf("invalid client data: %w", err)
+	}
+
+	if clientData.Type != ceremony {
+		return nil, fmt.Errorf("unexpected client data type %q", clientData.Type)
+	}
+
+	if clientData.CrossOrigin || !slices.Contains(origins, clientData.Origin) {
+		return nil, fmt.Errorf("unexpected origin %q", clientData.Origin)
+	}
+
+	if clientData.Challenge == "" {
+		return nil, errors.New("client data does not contain challenge")
+	}
+
+	return &clientData, nil
+}
+
+// AuthenticatorData contains state of authenticator. Credential is attested only during registration.
+type AuthenticatorData struct {
+	Flags        byte
+	SignCount    uint32
+	CredentialID []byte
+	PublicKey    []byte // credential public key in COSE_Key format
+}
+
+// ParseAuthenticatorData parses authenticator data and checks, that it was created for provided
+// Relying Party ID with user verification.
+func ParseAuthenticatorData(raw []byte, rpID string) (*AuthenticatorData, error) {
+	if len(raw) < authenticatorDataMinLength {
+		return nil, errAuthenticatorDataTooShort
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	if !bytes.Equal(raw[:rpIDHashLength], rpIDHash[:]) {
+		return nil, errors.New("authenticator data was created for another relying party")
+	}
+
+	authenticatorData := &AuthenticatorData{
+		Flags:     raw[rpIDHashLength],
+		SignCount: binary.BigEndian.Uint32(raw[signCountOffset:authenticatorDataMinLength]),
+	}
+
+	if authenticatorData.Flags&flagUserPresent == 0 || authenticatorData.Flags&flagUserVerified == 0 {
+		return nil, errUserNotVerified
+	}
+
+	rest := raw[authenticatorDataMinLength:]
+	if authenticatorData.Flags&flagAttestedCredentialData != 0 {
+		if len(rest) < aaguidLength+2 {
+			return nil, errAuthenticatorDataTooShort
+		}
+
+		rest = rest[aaguidLength:]
+		credentialIDLength := int(binary.BigEndian.Uint16(rest))
+		rest = rest[2:]
+
+		if credentialIDLength == 0 || len(rest) < credentialIDLength {
+			return nil, errors.New("invalid credential ID in authenticator data")
+		}
+
+		authenticatorData.CredentialID = rest[:credentialIDLength]
+		rest = rest[credentialIDLength:]
+
+		// Public key has no length prefix, so its end is found by decoding:
+		_, consumed, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid credential public key: %w", err)
+		}
+
+		authenticatorData.PublicKey = rest[:consumed]
+		rest = rest[consumed:]
+	}
+
+	if authenticatorData.Flags&flagExtensionData != 0 {
+		_, consumed, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid extensions in authenticator data: %w", err)
+		}
+
+		rest = rest[consumed:]
+	}
+
+	if len(rest) != 0 {
+		return nil, errors.New("unexpected data after authenticator data")
+	}
+
+	return authenticatorData, nil
+}
+
+// ParseAttestationObject returns authenticator data of new credential from attestation object
+// and checks, that credential public key is supported.
+func ParseAttestationObject(raw []byte, rpID string) (*AuthenticatorData, error) {
+	value, consumed, err := decodeCBOR(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attestation object: %w", err)
+	}
+
+	attestationObject, ok := value.(map[any]any)
+	if !ok || consumed != len(raw) {
+		return nil, errors.New("invalid attestation object")
+	}
+
+	rawAuthenticatorData, ok := attestationObject["authData"].([]byte)
+	if !ok {
+		return nil, errors.New("attestation object does not contain authenticator data")
+	}
+
+	authenticatorData, err := ParseAuthenticatorData(rawAuthenticatorData, rpID)
+	if err != nil {
+		return nil, err
+	}
+
+	if authenticatorData.CredentialID == nil {
+		return nil, errors.New("attestation object does not contain credential")
+	}
+
+	if _, err = parsePublicKey(authenticatorData.PublicKey); err != nil {
+		return nil, err
+	}
+
+	return authenticatorData, nil
+}
+
+// VerifyAssertion checks signature, which authenticator has created over authenticator data
+// and hash of client data with credential private key.
+func VerifyAssertion(publicKey, authenticatorData, clientDataJSON, signature []byte) error {
+	key, err := parsePublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signedData := make([]byte, 0, len(authenticatorData)+len(clientDataHash))
+	signedData = append(signedData, authenticatorData...)
+	signedData = append(signedData, clientDataHash[:]...)
+
+	return key.verify(signedData, signature)
+}
+
+// RelyingParty is the service, which User registers credentials with.
+type RelyingParty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// User is account, which credential belongs to. ID is user handle, which is returned by
+// authenticator during login with discoverable credential.
+type User struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type CredentialParameter struct {
+	Type      string `json:"type"`
+	Algorithm int64  `json:"alg"`
+}
+
+type CredentialDescriptor struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+type AuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// CreationOptions are JSON encoded PublicKeyCredentialCreationOptions, which are accepted by
+// PublicKeyCredential.parseCreationOptionsFromJSON in browser.
+type CreationOptions struct {
+	Challenge              string                 `json:"challenge"`
+	RelyingParty           RelyingParty           `json:"rp"`
+	User                   User                   `json:"user"`
+	CredentialParameters   []CredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"` // in milliseconds
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// RequestOptions are JSON encoded PublicKeyCredentialRequestOptions, which are accepted by
+// PublicKeyCredential.parseRequestOptionsFromJSON in browser.
+type RequestOptions struct {
+	Challenge        string                 `json:"challenge"`
+	Timeout          int64                  `json:"timeout"` // in milliseconds
+	RelyingPartyID   string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+// NewCreationOptions returns options for registration of new credential. Already registered
+// credentials are excluded to prevent registration of the same authenticator twice.
+func NewCreationOptions(
+	relyingParty RelyingParty,
+	user User,
+	challenge string,
+	timeout time.Duration,
+	excludeCredentials []CredentialDescriptor,
+) *CreationOptions {
+	if excludeCredentials == nil {
+		excludeCredentials = []CredentialDescriptor{}
+	}
+
+	credentialParameters := make([]CredentialParameter, 0, len(SupportedAlgorithms))
+	for _, algorithm := range SupportedAlgorithms {
+		credentialParameters = append(
+			credentialParameters,
+			CredentialParameter{Type: PublicKeyCredentialType, Algorithm: algorithm},
+		)
+	}
+
+	return &CreationOptions{
+		Challenge:            challenge,
+		RelyingParty:         relyingParty,
+		User:                 user,
+		CredentialParameters: credentialParameters,
+		Timeout:              timeout.Milliseconds(),
+		ExcludeCredentials:   excludeCredentials,
+		AuthenticatorSelection: AuthenticatorSelection{
+			ResidentKey:      residentKeyPreferred,
+			UserVerification: userVerificationRequired,
+		},
+		Attestation: attestationNone,
+	}
+}
+
+// NewRequestOptions returns options for login. If allowed credentials are not provided,
+// authenticator offers discoverable credentials of Relying Party.
+func NewRequestOptions(
+	rpID string,
+	challenge string,
+	timeout time.Duration,
+	allowCredentials []CredentialDescriptor,
+) *RequestOptions {
+	if allowCredentials == nil {
+		allowCredentials = []CredentialDescriptor{}
+	}
+
+	return &RequestOptions{
+		Challenge:        challenge,
+		Timeout:          timeout.Milliseconds(),
+		RelyingPartyID:   rpID,
+		AllowCredentials: allowCredentials,
+		UserVerification: userVerificationRequired,
+	}
+}
@@ -0,0 +1,222 @@
+package webauthn_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DKhorkov/hmtm-sso/internal/webauthn"
+	"github.com/DKhorkov/hmtm-sso/internal/webauthn/webauthntest"
+)
+
+const (
+	rpID   = "example.com"
+	origin = "https://example.com"
+)
+
+var origins = []string{origin}
+
+func newAuthenticator(t *testing.T, algorithm int64) *webauthntest.Authenticator {
+	t.Helper()
+
+	authenticator, err := webauthntest.New(rpID, origin, algorithm)
+	require.NoError(t, err)
+
+	return authenticator
+}
+
+func TestCeremonies(t *testing.T) {
+	for _, algorithm := range webauthn.SupportedAlgorithms {
+		authenticator := newAuthenticator(t, algorithm)
+
+		challenge, err := webauthn.GenerateChallenge()
+		require.NoError(t, err)
+
+		attestation, err := authenticator.Create(challenge)
+		require.NoError(t, err)
+
+		clientData, err := webauthn.ParseClientData(attestation.ClientDataJSON, webauthn.CreateCeremony, origins)
+		require.NoError(t, err)
+		require.Equal(t, challenge, clientData.Challenge)
+
+		credential, err := webauthn.ParseAttestationObject(attestation.AttestationObject, rpID)
+		require.NoError(t, err)
+		require.Equal(t, attestation.CredentialID, webauthn.Encoding.EncodeToString(credential.CredentialID))
+		require.Zero(t, credential.SignCount)
+
+		assertion, err := authenticator.Get(challenge, []byte("1"))
+		require.NoError(t, err)
+
+		clientData, err = webauthn.ParseClientData(assertion.ClientDataJSON, webauthn.GetCeremony, origins)
+		require.NoError(t, err)
+		require.Equal(t, challenge, clientData.Challenge)
+
+		authenticatorData, err := webauthn.ParseAuthenticatorData(assertion.AuthenticatorData, rpID)
+		require.NoError(t, err)
+		require.Equal(t, uint32(1), authenticatorData.SignCount)
+		require.Nil(t, authenticatorData.CredentialID)
+
+		err = webauthn.VerifyAssertion(
+			credential.PublicKey,
+			assertion.AuthenticatorData,
+			assertion.ClientDataJSON,
+			assertion.Signature,
+		)
+		require.NoError(t, err)
+
+		// Signature covers client data, so challenge can not be replaced:
+		err = webauthn.VerifyAssertion(
+			credential.PublicKey,
+			assertion.AuthenticatorData,
+			attestation.ClientDataJSON,
+			assertion.Signature,
+		)
+		require.Error(t, err)
+
+		// Signature of another credential is rejected:
+		anotherAssertion, err := newAuthenticator(t, algorithm).Get(challenge, nil)
+		require.NoError(t, err)
+
+		err = webauthn.VerifyAssertion(
+			credential.PublicKey,
+			anotherAssertion.AuthenticatorData,
+			anotherAssertion.ClientDataJSON,
+			anotherAssertion.Signature,
+		)
+		require.Error(t, err)
+	}
+}
+
+func TestParseClientData(t *testing.T) {
+	testCases := []struct {
+		name       string
+		clientData string
+		ceremony   string
+		expectErr  bool
+	}{
+		{
+			name:       "success",
+			clientData: `{"type":"webauthn.get","challenge":"abc","origin":"https://example.com"}`,
+			ceremony:   webauthn.GetCeremony,
+		},
+		{
+			name:       "unexpected type",
+			clientData: `{"type":"webauthn.create","challenge":"abc","origin":"https://example.com"}`,
+			ceremony:   webauthn.GetCeremony,
+			expectErr:  true,
+		},
+		{
+			name:       "unexpected origin",
+			clientData: `{"type":"webauthn.get","challenge":"abc","origin":"https://evil.com"}`,
+			ceremony:   webauthn.GetCeremony,
+			expectErr:  true,
+		},
+		{
+			name:       "cross origin",
+			clientData: `{"type":"webauthn.get","challenge":"abc","origin":"https://example.com","crossOrigin":true}`,
+			ceremony:   webauthn.GetCeremony,
+			expectErr:  true,
+		},
+		{
+			name:       "without challenge",
+			clientData: `{"type":"webauthn.get","origin":"https://example.com"}`,
+			ceremony:   webauthn.GetCeremony,
+			expectErr:  true,
+		},
+		{
+			name:       "invalid json",
+			clientData: `{`,
+			ceremony:   webauthn.GetCeremony,
+			expectErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientData, err := webauthn.ParseClientData([]byte(tc.clientData), tc.ceremony, origins)
+			if tc.expectErr {
+				require.Error(t, err)
+				require.Nil(t, clientData)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "abc", clientData.Challenge)
+			}
+		})
+	}
+}
+
+func TestParseAuthenticatorDataErrors(t *testing.T) {
+	authenticator := newAuthenticator(t, webauthn.AlgorithmES256)
+
+	assertion, err := authenticator.Get("challenge", nil)
+	require.NoError(t, err)
+
+	_, err = webauthn.ParseAuthenticatorData(assertion.AuthenticatorData, "another.com")
+	require.Error(t, err)
+
+	_, err = webauthn.ParseAuthenticatorData(assertion.AuthenticatorData[:36], rpID)
+	require.Error(t, err)
+
+	_, err = webauthn.ParseAuthenticatorData(append(assertion.AuthenticatorData, 0x00), rpID)
+	require.Error(t, err)
+
+	// User was not verified with PIN or biometrics:
+	authenticator.Flags = 0x01
+	assertion, err = authenticator.Get("challenge", nil)
+	require.NoError(t, err)
+
+	_, err = webauthn.ParseAuthenticatorData(assertion.AuthenticatorData, rpID)
+	require.Error(t, err)
+
+	_, err = webauthn.ParseAttestationObject(assertion.AuthenticatorData, rpID)
+	require.Error(t, err)
+}
+
+func TestNewCreationOptions(t *testing.T) {
+	options := webauthn.NewCreationOptions(
+		webauthn.RelyingParty{ID: rpID, Name: "Example"},
+		webauthn.User{ID: "MQ", Name: "user@example.com", DisplayName: "User"},
+		"challenge",
+		time.Minute,
+		nil,
+	)
+
+	encoded, err := json.Marshal(options)
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.Equal(t, "challenge", decoded["challenge"])
+	require.Equal(t, float64(60000), decoded["timeout"])
+	require.Equal(t, "none", decoded["attestation"])
+	require.Equal(t, []any{}, decoded["excludeCredentials"])
+	require.Len(t, decoded["pubKeyCredParams"], len(webauthn.SupportedAlgorithms))
+	require.Equal(t, map[string]any{"id": rpID, "name": "Example"}, decoded["rp"])
+}
+
+func TestNewRequestOptions(t *testing.T) {
+	options := webauthn.NewRequestOptions(
+		rpID,
+		"challenge",
+		time.Minute,
+		[]webauthn.CredentialDescriptor{
+			{Type: webauthn.PublicKeyCredentialType, ID: "credential", Transports: []string{"internal"}},
+		},
+	)
+
+	encoded, err := json.Marshal(options)
+	require.NoError(t, err)
+	require.JSONEq(
+		t,
+		`{
+			"challenge": "challenge",
+			"timeout": 60000,
+			"rpId": "example.com",
+			"allowCredentials": [{"type": "public-key", "id": "credential", "transports": ["internal"]}],
+			"userVerification": "required"
+		}`,
+		string(encoded),
+	)
+}
@@ -0,0 +1,276 @@
+// Package webauthntest provides software authenticator, which creates WebAuthn credentials and assertions
+// without browser and hardware, so WebAuthn ceremonies can be tested offline.
+package webauthntest
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/DKhorkov/hmtm-sso/internal/webauthn"
+)
+
+const (
+	credentialIDLength = 16
+	rsaKeyBits         = 2048
+
+	// Flags of user presence and verification, which are set by platform authenticator after biometrics check:
+	flagUserPresent            byte = 0x01
+	flagUserVerified           byte = 0x04
+	flagAttestedCredentialData byte = 0x40
+)
+
+// Authenticator holds single credential for provided Relying Party and signs every request
+// with user presence and verification.
+type Authenticator struct {
+	RPID      string
+	Origin    string
+	SignCount uint32 // incremented before each assertion, can be changed to emulate cloned authenticator
+	Flags     byte   // flags of next authenticator data
+
+	algorithm    int64
+	signer       crypto.Signer
+	credentialID []byte
+}
+
+// Attestation is response of authenticator to navigator.credentials.create().
+type Attestation struct {
+	CredentialID      string
+	ClientDataJSON    []byte
+	AttestationObject []byte
+}
+
+// Assertion is response of authenticator to navigator.credentials.get().
+type Assertion struct {
+	CredentialID      string
+	ClientDataJSON    []byte
+	AuthenticatorData []byte
+	Signature         []byte
+	UserHandle        []byte
+}
+
+// New creates Authenticator with new credential, which key is generated for provided COSE algorithm.
+func New(rpID, origin string, algorithm int64) (*Authenticator, error) {
+	var (
+		signer crypto.Signer
+		err    error
+	)
+
+	switch algorithm {
+	case webauthn.AlgorithmES256:
+		signer, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case webauthn.AlgorithmEdDSA:
+		_, signer, err = ed25519.GenerateKey(rand.Reader)
+	case webauthn.AlgorithmRS256:
+		signer, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %d", algorithm)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	credentialID := make([]byte, credentialIDLength)
+	if _, err = rand.Read(credentialID); err != nil {
+		return nil, err
+	}
+
+	return &Authenticator{
+		RPID:         rpID,
+		Origin:       origin,
+		Flags:        flagUserPresent | flagUserVerified,
+		algorithm:    algorithm,
+		signer:       signer,
+		credentialID: credentialID,
+	}, nil
+}
+
+// CredentialID returns base64url encoded ID of credential, as it is provided by browser.
+func (a *Authenticator) CredentialID() string {
+	return webauthn.Encoding.EncodeToString(a.credentialID)
+}
+
+// Create registers credential for provided challenge with "none" attestation.
+func (a *Authenticator) Create(challenge string) (*Attestation, error) {
+	clientDataJSON, err := a.clientData(webauthn.CreateCeremony, challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := a.publicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	authenticatorData := a.authenticatorData(a.Flags | flagAttestedCredentialData)
+	authenticatorData = append(authenticatorData, make([]byte, 16)...) // zero AAGUID
+	authenticatorData = binary.BigEndian.AppendUint16(authenticatorData, uint16(len(a.credentialID)))
+	authenticatorData = append(authenticatorData, a.credentialID...)
+	authenticatorData = append(authenticatorData, publicKey...)
+
+	attestationObject := encodeCBOR(
+		[]cborPair{
+			{key: "fmt", value: "none"},
+			{key: "attStmt", value: []cborPair{}},
+			{key: "authData", value: authenticatorData},
+		},
+	)
+
+	return &Attestation{
+		CredentialID:      a.CredentialID(),
+		ClientDataJSON:    clientDataJSON,
+		AttestationObject: attestationObject,
+	}, nil
+}
+
+// Get signs provided challenge. User handle is returned for discoverable credentials.
+func (a *Authenticator) Get(challenge string, userHandle []byte) (*Assertion, error) {
+	clientDataJSON, err := a.clientData(webauthn.GetCeremony, challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	a.SignCount++
+	authenticatorData := a.authenticatorData(a.Flags)
+
+	signature, err := a.Sign(authenticatorData, clientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Assertion{
+		CredentialID:      a.CredentialID(),
+		ClientDataJSON:    clientDataJSON,
+		AuthenticatorData: authenticatorData,
+		Signature:         signature,
+		UserHandle:        userHandle,
+	}, nil
+}
+
+// Sign creates assertion signature over authenticator data and hash of client data.
+func (a *Authenticator) Sign(authenticatorData, clientDataJSON []byte) ([]byte, error) {
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signedData := append(append([]byte(nil), authenticatorData...), clientDataHash[:]...)
+
+	if a.algorithm == webauthn.AlgorithmEdDSA {
+		return a.signer.Sign(rand.Reader, signedData, crypto.Hash(0))
+	}
+
+	digest := sha256.Sum256(signedData)
+
+	return a.signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
+
+func (a *Authenticator) clientData(ceremony, challenge string) ([]byte, error) {
+	return json.Marshal(
+		map[string]any{
+			"type":        ceremony,
+			"challenge":   challenge,
+			"origin":      a.Origin,
+			"crossOrigin": false,
+		},
+	)
+}
+
+func (a *Authenticator) authenticatorData(flags byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(a.RPID))
+
+	authenticatorData := append([]byte(nil), rpIDHash[:]...)
+	authenticatorData = append(authenticatorData, flags)
+
+	return binary.BigEndian.AppendUint32(authenticatorData, a.SignCount)
+}
+
+// publicKey returns credential public key in COSE_Key format.
+func (a *Authenticator) publicKey() ([]byte, error) {
+	switch key := a.signer.Public().(type) {
+	case *ecdsa.PublicKey:
+		return encodeCBOR(
+			[]cborPair{
+				{key: 1, value: 2}, // kty: EC2
+				{key: 3, value: webauthn.AlgorithmES256},
+				{key: -1, value: 1}, // crv: P-256
+				{key: -2, value: key.X.FillBytes(make([]byte, 32))},
+				{key: -3, value: key.Y.FillBytes(make([]byte, 32))},
+			},
+		), nil
+	case ed25519.PublicKey:
+		return encodeCBOR(
+			[]cborPair{
+				{key: 1, value: 1}, // kty: OKP
+				{key: 3, value: webauthn.AlgorithmEdDSA},
+				{key: -1, value: 6}, // crv: Ed25519
+				{key: -2, value: []byte(key)},
+			},
+		), nil
+	case *rsa.PublicKey:
+		return encodeCBOR(
+			[]cborPair{
+				{key: 1, value: 3}, // kty: RSA
+				{key: 3, value: webauthn.AlgorithmRS256},
+				{key: -1, value: key.N.Bytes()},
+				{key: -2, value: big.NewInt(int64(key.E)).Bytes()},
+			},
+		), nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// cborPair is map entry, which keeps order of keys in encoded map.
+type cborPair struct {
+	key   any
+	value any
+}
+
+// encodeCBOR encodes subset of CBOR, which is needed for attestation objects and COSE keys.
+func encodeCBOR(value any) []byte {
+	switch v := value.(type) {
+	case int:
+		return encodeCBOR(int64(v))
+	case int64:
+		if v >= 0 {
+			return cborHeader(0, uint64(v))
+		}
+
+		return cborHeader(1, uint64(-1-v))
+	case []byte:
+		return append(cborHeader(2, uint64(len(v))), v...)
+	case string:
+		return append(cborHeader(3, uint64(len(v))), v...)
+	case []cborPair:
+		encoded := cborHeader(5, uint64(len(v)))
+		for _, pair := range v {
+			encoded = append(encoded, encodeCBOR(pair.key)...)
+			encoded = append(encoded, encodeCBOR(pair.value)...)
+		}
+
+		return encoded
+	default:
+		panic(fmt.Sprintf("unsupported CBOR value type %T", value))
+	}
+}
+
+func cborHeader(majorType byte, argument uint64) []byte {
+	switch {
+	case argument < 24:
+		return []byte{majorType<<5 | byte(argument)}
+	case argument <= 0xff:
+		return []byte{majorType<<5 | 24, byte(argument)}
+	case argument <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{majorType<<5 | 25}, uint16(argument))
+	case argument <= 0xffffffff:
+		return binary.BigEndian.AppendUint32([]byte{majorType<<5 | 26}, uint32(argument))
+	default:
+		return binary.BigEndian.AppendUint64([]byte{majorType<<5 | 27}, argument)
+	}
+}
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS verify_email_tokens
+(
+    id         SERIAL PRIMARY KEY,
+    user_id    INTEGER      NOT NULL,
+    email      VARCHAR(255) NOT NULL,
+    token_hash VARCHAR      NOT NULL UNIQUE,
+    code_hash  VARCHAR      NOT NULL,
+    ttl        TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS verify_email_tokens;
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS sessions
+(
+    id          SERIAL PRIMARY KEY,
+    user_id     INTEGER      NOT NULL,
+    device_name VARCHAR(255) NOT NULL DEFAULT '',
+    user_agent  VARCHAR      NOT NULL DEFAULT '',
+    ip          VARCHAR(45)  NOT NULL DEFAULT '',
+    ttl         TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE refresh_tokens
+    ADD COLUMN session_id INTEGER REFERENCES sessions (id) ON DELETE CASCADE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS refresh_tokens_session_id_idx ON refresh_tokens (session_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS refresh_tokens_session_id_idx;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE refresh_tokens
+    DROP COLUMN session_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS sessions;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE refresh_tokens
+    ADD COLUMN family_id VARCHAR(64);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE refresh_tokens
+    ADD COLUMN rotated_at TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS refresh_tokens_family_id_idx ON refresh_tokens (family_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS refresh_tokens_family_id_idx;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE refresh_tokens
+    DROP COLUMN rotated_at;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE refresh_tokens
+    DROP COLUMN family_id;
+-- +goose StatementEnd
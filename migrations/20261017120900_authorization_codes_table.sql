@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS authorization_codes
+(
+    id             SERIAL PRIMARY KEY,
+    user_id        INTEGER   NOT NULL,
+    client_id      VARCHAR   NOT NULL,
+    code_hash      VARCHAR   NOT NULL UNIQUE,
+    redirect_uri   VARCHAR   NOT NULL,
+    scope          VARCHAR   NOT NULL,
+    nonce          VARCHAR   NOT NULL DEFAULT '',
+    code_challenge VARCHAR   NOT NULL,
+    ttl            TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS authorization_codes;
+-- +goose StatementEnd
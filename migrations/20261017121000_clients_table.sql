@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS clients
+(
+    id                SERIAL PRIMARY KEY,
+    client_id         VARCHAR(255) NOT NULL UNIQUE,
+    secret_hash       VARCHAR      NOT NULL DEFAULT '',
+    redirect_uris     TEXT         NOT NULL DEFAULT '',
+    grant_types       VARCHAR      NOT NULL DEFAULT '',
+    scopes            TEXT         NOT NULL DEFAULT '',
+    access_token_ttl  INTEGER      NOT NULL DEFAULT 0,
+    refresh_token_ttl INTEGER      NOT NULL DEFAULT 0,
+    created_at        TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at        TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE refresh_tokens
+    ADD COLUMN client_id VARCHAR(255);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE refresh_tokens
+    DROP COLUMN client_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS clients;
+-- +goose StatementEnd
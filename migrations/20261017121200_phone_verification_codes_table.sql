@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS phone_verification_codes
+(
+    id         SERIAL PRIMARY KEY,
+    user_id    INTEGER     NOT NULL,
+    phone      VARCHAR(30) NOT NULL,
+    code_hash  VARCHAR     NOT NULL,
+    ttl        TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS phone_verification_codes;
+-- +goose StatementEnd
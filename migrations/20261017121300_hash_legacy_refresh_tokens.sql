@@ -0,0 +1,11 @@
+-- +goose Up
+-- JWT refresh tokens, issued before opaque tokens were introduced, were stored as is. They are replaced with their
+-- SHA-256 digests like opaque ones. JWTs contain dots, which never appear in hex digests:
+-- +goose StatementBegin
+UPDATE refresh_tokens
+SET value = encode(sha256(convert_to(value, 'UTF8')), 'hex')
+WHERE value LIKE '%.%';
+-- +goose StatementEnd
+
+-- +goose Down
+-- Digests can not be reverted to tokens, and tokens are looked up by digests anyway.
@@ -0,0 +1,90 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: jwt.go
+//
+// Generated by this command:
+//
+//	mockgen -source=jwt.go -destination=../../mocks/jwt/jwt_provider.go -package=mockjwt
+//
+
+// Package mockjwt is a generated GoMock package.
+package mockjwt
+
+import (
+	reflect "reflect"
+
+	entities "github.com/DKhorkov/hmtm-sso/internal/entities"
+	jwt "github.com/golang-jwt/jwt/v5"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockJWTProvider is a mock of JWTProvider interface.
+type MockJWTProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockJWTProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockJWTProviderMockRecorder is the mock recorder for MockJWTProvider.
+type MockJWTProviderMockRecorder struct {
+	mock *MockJWTProvider
+}
+
+// NewMockJWTProvider creates a new mock instance.
+func NewMockJWTProvider(ctrl *gomock.Controller) *MockJWTProvider {
+	mock := &MockJWTProvider{ctrl: ctrl}
+	mock.recorder = &MockJWTProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJWTProvider) EXPECT() *MockJWTProviderMockRecorder {
+	return m.recorder
+}
+
+// GetJWKS mocks base method.
+func (m *MockJWTProvider) GetJWKS() entities.JWKS {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJWKS")
+	ret0, _ := ret[0].(entities.JWKS)
+	return ret0
+}
+
+// GetJWKS indicates an expected call of GetJWKS.
+func (mr *MockJWTProviderMockRecorder) GetJWKS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJWKS", reflect.TypeOf((*MockJWTProvider)(nil).GetJWKS))
+}
+
+// Parse mocks base method.
+func (m *MockJWTProvider) Parse(token string, claims jwt.Claims, opts ...jwt.ParserOption) error {
+	m.ctrl.T.Helper()
+	varargs := []any{token, claims}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Parse", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Parse indicates an expected call of Parse.
+func (mr *MockJWTProviderMockRecorder) Parse(token, claims any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{token, claims}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockJWTProvider)(nil).Parse), varargs...)
+}
+
+// Sign mocks base method.
+func (m *MockJWTProvider) Sign(claims jwt.Claims) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sign", claims)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sign indicates an expected call of Sign.
+func (mr *MockJWTProviderMockRecorder) Sign(claims any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sign", reflect.TypeOf((*MockJWTProvider)(nil).Sign), claims)
+}
@@ -12,7 +12,6 @@ package mockrepositories
 import (
 	context "context"
 	reflect "reflect"
-	time "time"
 
 	entities "github.com/DKhorkov/hmtm-sso/internal/entities"
 	gomock "go.uber.org/mock/gomock"
@@ -56,19 +55,283 @@ func (mr *MockAuthRepositoryMockRecorder) ChangePassword(ctx, userID, newPasswor
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockAuthRepository)(nil).ChangePassword), ctx, userID, newPassword)
 }
 
+// ConfirmTOTPSecret mocks base method.
+func (m *MockAuthRepository) ConfirmTOTPSecret(ctx context.Context, confirmData entities.ConfirmTOTPSecretDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTPSecret", ctx, confirmData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmTOTPSecret indicates an expected call of ConfirmTOTPSecret.
+func (mr *MockAuthRepositoryMockRecorder) ConfirmTOTPSecret(ctx, confirmData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTPSecret", reflect.TypeOf((*MockAuthRepository)(nil).ConfirmTOTPSecret), ctx, confirmData)
+}
+
+// ConfirmUserTelegram mocks base method.
+func (m *MockAuthRepository) ConfirmUserTelegram(ctx context.Context, userID uint64, telegram string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmUserTelegram", ctx, userID, telegram)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmUserTelegram indicates an expected call of ConfirmUserTelegram.
+func (mr *MockAuthRepositoryMockRecorder) ConfirmUserTelegram(ctx, userID, telegram any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmUserTelegram", reflect.TypeOf((*MockAuthRepository)(nil).ConfirmUserTelegram), ctx, userID, telegram)
+}
+
+// CreateAuthorizationCode mocks base method.
+func (m *MockAuthRepository) CreateAuthorizationCode(ctx context.Context, codeData entities.CreateAuthorizationCodeDTO) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuthorizationCode", ctx, codeData)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuthorizationCode indicates an expected call of CreateAuthorizationCode.
+func (mr *MockAuthRepositoryMockRecorder) CreateAuthorizationCode(ctx, codeData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuthorizationCode", reflect.TypeOf((*MockAuthRepository)(nil).CreateAuthorizationCode), ctx, codeData)
+}
+
+// CreateClient mocks base method.
+func (m *MockAuthRepository) CreateClient(ctx context.Context, clientData entities.CreateClientDTO) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateClient", ctx, clientData)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateClient indicates an expected call of CreateClient.
+func (mr *MockAuthRepositoryMockRecorder) CreateClient(ctx, clientData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClient", reflect.TypeOf((*MockAuthRepository)(nil).CreateClient), ctx, clientData)
+}
+
+// CreateForgetPasswordToken mocks base method.
+func (m *MockAuthRepository) CreateForgetPasswordToken(ctx context.Context, tokenData entities.CreateForgetPasswordTokenDTO) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateForgetPasswordToken", ctx, tokenData)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateForgetPasswordToken indicates an expected call of CreateForgetPasswordToken.
+func (mr *MockAuthRepositoryMockRecorder) CreateForgetPasswordToken(ctx, tokenData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateForgetPasswordToken", reflect.TypeOf((*MockAuthRepository)(nil).CreateForgetPasswordToken), ctx, tokenData)
+}
+
+// CreateLoginToken mocks base method.
+func (m *MockAuthRepository) CreateLoginToken(ctx context.Context, tokenData entities.CreateLoginTokenDTO) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLoginToken", ctx, tokenData)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLoginToken indicates an expected call of CreateLoginToken.
+func (mr *MockAuthRepositoryMockRecorder) CreateLoginToken(ctx, tokenData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoginToken", reflect.TypeOf((*MockAuthRepository)(nil).CreateLoginToken), ctx, tokenData)
+}
+
+// CreateMFAChallenge mocks base method.
+func (m *MockAuthRepository) CreateMFAChallenge(ctx context.Context, challengeData entities.CreateMFAChallengeDTO) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMFAChallenge", ctx, challengeData)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMFAChallenge indicates an expected call of CreateMFAChallenge.
+func (mr *MockAuthRepositoryMockRecorder) CreateMFAChallenge(ctx, challengeData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMFAChallenge", reflect.TypeOf((*MockAuthRepository)(nil).CreateMFAChallenge), ctx, challengeData)
+}
+
+// CreatePhoneVerificationCode mocks base method.
+func (m *MockAuthRepository) CreatePhoneVerificationCode(ctx context.Context, codeData entities.CreatePhoneVerificationCodeDTO) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePhoneVerificationCode", ctx, codeData)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePhoneVerificationCode indicates an expected call of CreatePhoneVerificationCode.
+func (mr *MockAuthRepositoryMockRecorder) CreatePhoneVerificationCode(ctx, codeData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePhoneVerificationCode", reflect.TypeOf((*MockAuthRepository)(nil).CreatePhoneVerificationCode), ctx, codeData)
+}
+
 // CreateRefreshToken mocks base method.
-func (m *MockAuthRepository) CreateRefreshToken(ctx context.Context, userID uint64, refreshToken string, ttl time.Duration) (uint64, error) {
+func (m *MockAuthRepository) CreateRefreshToken(ctx context.Context, refreshTokenData entities.CreateRefreshTokenDTO) (uint64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateRefreshToken", ctx, userID, refreshToken, ttl)
+	ret := m.ctrl.Call(m, "CreateRefreshToken", ctx, refreshTokenData)
 	ret0, _ := ret[0].(uint64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateRefreshToken indicates an expected call of CreateRefreshToken.
-func (mr *MockAuthRepositoryMockRecorder) CreateRefreshToken(ctx, userID, refreshToken, ttl any) *gomock.Call {
+func (mr *MockAuthRepositoryMockRecorder) CreateRefreshToken(ctx, refreshTokenData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*MockAuthRepository)(nil).CreateRefreshToken), ctx, refreshTokenData)
+}
+
+// CreateSession mocks base method.
+func (m *MockAuthRepository) CreateSession(ctx context.Context, sessionData entities.CreateSessionDTO) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, sessionData)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockAuthRepositoryMockRecorder) CreateSession(ctx, sessionData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockAuthRepository)(nil).CreateSession), ctx, sessionData)
+}
+
+// CreateTOTPSecret mocks base method.
+func (m *MockAuthRepository) CreateTOTPSecret(ctx context.Context, totpSecretData entities.CreateTOTPSecretDTO) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTOTPSecret", ctx, totpSecretData)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTOTPSecret indicates an expected call of CreateTOTPSecret.
+func (mr *MockAuthRepositoryMockRecorder) CreateTOTPSecret(ctx, totpSecretData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTOTPSecret", reflect.TypeOf((*MockAuthRepository)(nil).CreateTOTPSecret), ctx, totpSecretData)
+}
+
+// CreateUserIdentity mocks base method.
+func (m *MockAuthRepository) CreateUserIdentity(ctx context.Context, identityData entities.CreateUserIdentityDTO) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserIdentity", ctx, identityData)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserIdentity indicates an expected call of CreateUserIdentity.
+func (mr *MockAuthRepositoryMockRecorder) CreateUserIdentity(ctx, identityData any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*MockAuthRepository)(nil).CreateRefreshToken), ctx, userID, refreshToken, ttl)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserIdentity", reflect.TypeOf((*MockAuthRepository)(nil).CreateUserIdentity), ctx, identityData)
+}
+
+// CreateVerifyEmailToken mocks base method.
+func (m *MockAuthRepository) CreateVerifyEmailToken(ctx context.Context, tokenData entities.CreateVerifyEmailTokenDTO) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVerifyEmailToken", ctx, tokenData)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVerifyEmailToken indicates an expected call of CreateVerifyEmailToken.
+func (mr *MockAuthRepositoryMockRecorder) CreateVerifyEmailToken(ctx, tokenData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVerifyEmailToken", reflect.TypeOf((*MockAuthRepository)(nil).CreateVerifyEmailToken), ctx, tokenData)
+}
+
+// CreateWebAuthnChallenge mocks base method.
+func (m *MockAuthRepository) CreateWebAuthnChallenge(ctx context.Context, challengeData entities.CreateWebAuthnChallengeDTO) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebAuthnChallenge", ctx, challengeData)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebAuthnChallenge indicates an expected call of CreateWebAuthnChallenge.
+func (mr *MockAuthRepositoryMockRecorder) CreateWebAuthnChallenge(ctx, challengeData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebAuthnChallenge", reflect.TypeOf((*MockAuthRepository)(nil).CreateWebAuthnChallenge), ctx, challengeData)
+}
+
+// CreateWebAuthnCredential mocks base method.
+func (m *MockAuthRepository) CreateWebAuthnCredential(ctx context.Context, credentialData entities.CreateWebAuthnCredentialDTO) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebAuthnCredential", ctx, credentialData)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebAuthnCredential indicates an expected call of CreateWebAuthnCredential.
+func (mr *MockAuthRepositoryMockRecorder) CreateWebAuthnCredential(ctx, credentialData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebAuthnCredential", reflect.TypeOf((*MockAuthRepository)(nil).CreateWebAuthnCredential), ctx, credentialData)
+}
+
+// DeleteClient mocks base method.
+func (m *MockAuthRepository) DeleteClient(ctx context.Context, clientID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClient", ctx, clientID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteClient indicates an expected call of DeleteClient.
+func (mr *MockAuthRepositoryMockRecorder) DeleteClient(ctx, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClient", reflect.TypeOf((*MockAuthRepository)(nil).DeleteClient), ctx, clientID)
+}
+
+// DeleteTOTPSecret mocks base method.
+func (m *MockAuthRepository) DeleteTOTPSecret(ctx context.Context, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTOTPSecret", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTOTPSecret indicates an expected call of DeleteTOTPSecret.
+func (mr *MockAuthRepositoryMockRecorder) DeleteTOTPSecret(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTOTPSecret", reflect.TypeOf((*MockAuthRepository)(nil).DeleteTOTPSecret), ctx, userID)
+}
+
+// ExpireForgetPasswordTokens mocks base method.
+func (m *MockAuthRepository) ExpireForgetPasswordTokens(ctx context.Context, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireForgetPasswordTokens", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireForgetPasswordTokens indicates an expected call of ExpireForgetPasswordTokens.
+func (mr *MockAuthRepositoryMockRecorder) ExpireForgetPasswordTokens(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireForgetPasswordTokens", reflect.TypeOf((*MockAuthRepository)(nil).ExpireForgetPasswordTokens), ctx, userID)
+}
+
+// ExpireMFAChallenge mocks base method.
+func (m *MockAuthRepository) ExpireMFAChallenge(ctx context.Context, challengeID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireMFAChallenge", ctx, challengeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireMFAChallenge indicates an expected call of ExpireMFAChallenge.
+func (mr *MockAuthRepositoryMockRecorder) ExpireMFAChallenge(ctx, challengeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireMFAChallenge", reflect.TypeOf((*MockAuthRepository)(nil).ExpireMFAChallenge), ctx, challengeID)
 }
 
 // ExpireRefreshToken mocks base method.
@@ -85,33 +348,329 @@ func (mr *MockAuthRepositoryMockRecorder) ExpireRefreshToken(ctx, refreshToken a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireRefreshToken", reflect.TypeOf((*MockAuthRepository)(nil).ExpireRefreshToken), ctx, refreshToken)
 }
 
+// ExpireSession mocks base method.
+func (m *MockAuthRepository) ExpireSession(ctx context.Context, userID, sessionID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireSession", ctx, userID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireSession indicates an expected call of ExpireSession.
+func (mr *MockAuthRepositoryMockRecorder) ExpireSession(ctx, userID, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireSession", reflect.TypeOf((*MockAuthRepository)(nil).ExpireSession), ctx, userID, sessionID)
+}
+
+// ExpireUserSessions mocks base method.
+func (m *MockAuthRepository) ExpireUserSessions(ctx context.Context, userID, exceptSessionID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireUserSessions", ctx, userID, exceptSessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireUserSessions indicates an expected call of ExpireUserSessions.
+func (mr *MockAuthRepositoryMockRecorder) ExpireUserSessions(ctx, userID, exceptSessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireUserSessions", reflect.TypeOf((*MockAuthRepository)(nil).ExpireUserSessions), ctx, userID, exceptSessionID)
+}
+
+// ExpireWebAuthnChallenge mocks base method.
+func (m *MockAuthRepository) ExpireWebAuthnChallenge(ctx context.Context, challengeID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireWebAuthnChallenge", ctx, challengeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireWebAuthnChallenge indicates an expected call of ExpireWebAuthnChallenge.
+func (mr *MockAuthRepositoryMockRecorder) ExpireWebAuthnChallenge(ctx, challengeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireWebAuthnChallenge", reflect.TypeOf((*MockAuthRepository)(nil).ExpireWebAuthnChallenge), ctx, challengeID)
+}
+
 // ForgetPassword mocks base method.
-func (m *MockAuthRepository) ForgetPassword(ctx context.Context, userID uint64, newPassword string) error {
+func (m *MockAuthRepository) ForgetPassword(ctx context.Context, userID, forgetPasswordTokenID uint64, newPassword string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ForgetPassword", ctx, userID, newPassword)
+	ret := m.ctrl.Call(m, "ForgetPassword", ctx, userID, forgetPasswordTokenID, newPassword)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ForgetPassword indicates an expected call of ForgetPassword.
-func (mr *MockAuthRepositoryMockRecorder) ForgetPassword(ctx, userID, newPassword any) *gomock.Call {
+func (mr *MockAuthRepositoryMockRecorder) ForgetPassword(ctx, userID, forgetPasswordTokenID, newPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgetPassword", reflect.TypeOf((*MockAuthRepository)(nil).ForgetPassword), ctx, userID, forgetPasswordTokenID, newPassword)
+}
+
+// GetAuthorizationCodeByHash mocks base method.
+func (m *MockAuthRepository) GetAuthorizationCodeByHash(ctx context.Context, codeHash string) (*entities.AuthorizationCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorizationCodeByHash", ctx, codeHash)
+	ret0, _ := ret[0].(*entities.AuthorizationCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorizationCodeByHash indicates an expected call of GetAuthorizationCodeByHash.
+func (mr *MockAuthRepositoryMockRecorder) GetAuthorizationCodeByHash(ctx, codeHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorizationCodeByHash", reflect.TypeOf((*MockAuthRepository)(nil).GetAuthorizationCodeByHash), ctx, codeHash)
+}
+
+// GetClientByClientID mocks base method.
+func (m *MockAuthRepository) GetClientByClientID(ctx context.Context, clientID string) (*entities.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientByClientID", ctx, clientID)
+	ret0, _ := ret[0].(*entities.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientByClientID indicates an expected call of GetClientByClientID.
+func (mr *MockAuthRepositoryMockRecorder) GetClientByClientID(ctx, clientID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgetPassword", reflect.TypeOf((*MockAuthRepository)(nil).ForgetPassword), ctx, userID, newPassword)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientByClientID", reflect.TypeOf((*MockAuthRepository)(nil).GetClientByClientID), ctx, clientID)
 }
 
-// GetRefreshTokenByUserID mocks base method.
-func (m *MockAuthRepository) GetRefreshTokenByUserID(ctx context.Context, userID uint64) (*entities.RefreshToken, error) {
+// GetClients mocks base method.
+func (m *MockAuthRepository) GetClients(ctx context.Context) ([]entities.Client, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRefreshTokenByUserID", ctx, userID)
+	ret := m.ctrl.Call(m, "GetClients", ctx)
+	ret0, _ := ret[0].([]entities.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClients indicates an expected call of GetClients.
+func (mr *MockAuthRepositoryMockRecorder) GetClients(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClients", reflect.TypeOf((*MockAuthRepository)(nil).GetClients), ctx)
+}
+
+// GetForgetPasswordTokenByHash mocks base method.
+func (m *MockAuthRepository) GetForgetPasswordTokenByHash(ctx context.Context, tokenHash string) (*entities.ForgetPasswordToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForgetPasswordTokenByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*entities.ForgetPasswordToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForgetPasswordTokenByHash indicates an expected call of GetForgetPasswordTokenByHash.
+func (mr *MockAuthRepositoryMockRecorder) GetForgetPasswordTokenByHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForgetPasswordTokenByHash", reflect.TypeOf((*MockAuthRepository)(nil).GetForgetPasswordTokenByHash), ctx, tokenHash)
+}
+
+// GetLoginTokenByHash mocks base method.
+func (m *MockAuthRepository) GetLoginTokenByHash(ctx context.Context, tokenHash string) (*entities.LoginToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginTokenByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*entities.LoginToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginTokenByHash indicates an expected call of GetLoginTokenByHash.
+func (mr *MockAuthRepositoryMockRecorder) GetLoginTokenByHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginTokenByHash", reflect.TypeOf((*MockAuthRepository)(nil).GetLoginTokenByHash), ctx, tokenHash)
+}
+
+// GetLoginTokenByUserID mocks base method.
+func (m *MockAuthRepository) GetLoginTokenByUserID(ctx context.Context, userID uint64) (*entities.LoginToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginTokenByUserID", ctx, userID)
+	ret0, _ := ret[0].(*entities.LoginToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginTokenByUserID indicates an expected call of GetLoginTokenByUserID.
+func (mr *MockAuthRepositoryMockRecorder) GetLoginTokenByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginTokenByUserID", reflect.TypeOf((*MockAuthRepository)(nil).GetLoginTokenByUserID), ctx, userID)
+}
+
+// GetMFAChallengeByHash mocks base method.
+func (m *MockAuthRepository) GetMFAChallengeByHash(ctx context.Context, tokenHash string) (*entities.MFAChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMFAChallengeByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*entities.MFAChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMFAChallengeByHash indicates an expected call of GetMFAChallengeByHash.
+func (mr *MockAuthRepositoryMockRecorder) GetMFAChallengeByHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMFAChallengeByHash", reflect.TypeOf((*MockAuthRepository)(nil).GetMFAChallengeByHash), ctx, tokenHash)
+}
+
+// GetPhoneVerificationCodeByUserID mocks base method.
+func (m *MockAuthRepository) GetPhoneVerificationCodeByUserID(ctx context.Context, userID uint64) (*entities.PhoneVerificationCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPhoneVerificationCodeByUserID", ctx, userID)
+	ret0, _ := ret[0].(*entities.PhoneVerificationCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPhoneVerificationCodeByUserID indicates an expected call of GetPhoneVerificationCodeByUserID.
+func (mr *MockAuthRepositoryMockRecorder) GetPhoneVerificationCodeByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPhoneVerificationCodeByUserID", reflect.TypeOf((*MockAuthRepository)(nil).GetPhoneVerificationCodeByUserID), ctx, userID)
+}
+
+// GetRefreshTokenByValue mocks base method.
+func (m *MockAuthRepository) GetRefreshTokenByValue(ctx context.Context, refreshToken string) (*entities.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefreshTokenByValue", ctx, refreshToken)
 	ret0, _ := ret[0].(*entities.RefreshToken)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRefreshTokenByUserID indicates an expected call of GetRefreshTokenByUserID.
-func (mr *MockAuthRepositoryMockRecorder) GetRefreshTokenByUserID(ctx, userID any) *gomock.Call {
+// GetRefreshTokenByValue indicates an expected call of GetRefreshTokenByValue.
+func (mr *MockAuthRepositoryMockRecorder) GetRefreshTokenByValue(ctx, refreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefreshTokenByValue", reflect.TypeOf((*MockAuthRepository)(nil).GetRefreshTokenByValue), ctx, refreshToken)
+}
+
+// GetTOTPSecretByUserID mocks base method.
+func (m *MockAuthRepository) GetTOTPSecretByUserID(ctx context.Context, userID uint64) (*entities.TOTPSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTOTPSecretByUserID", ctx, userID)
+	ret0, _ := ret[0].(*entities.TOTPSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTOTPSecretByUserID indicates an expected call of GetTOTPSecretByUserID.
+func (mr *MockAuthRepositoryMockRecorder) GetTOTPSecretByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTOTPSecretByUserID", reflect.TypeOf((*MockAuthRepository)(nil).GetTOTPSecretByUserID), ctx, userID)
+}
+
+// GetUserIdentity mocks base method.
+func (m *MockAuthRepository) GetUserIdentity(ctx context.Context, provider, subject string) (*entities.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIdentity", ctx, provider, subject)
+	ret0, _ := ret[0].(*entities.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIdentity indicates an expected call of GetUserIdentity.
+func (mr *MockAuthRepositoryMockRecorder) GetUserIdentity(ctx, provider, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIdentity", reflect.TypeOf((*MockAuthRepository)(nil).GetUserIdentity), ctx, provider, subject)
+}
+
+// GetUserSessions mocks base method.
+func (m *MockAuthRepository) GetUserSessions(ctx context.Context, userID uint64) ([]entities.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSessions", ctx, userID)
+	ret0, _ := ret[0].([]entities.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSessions indicates an expected call of GetUserSessions.
+func (mr *MockAuthRepositoryMockRecorder) GetUserSessions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSessions", reflect.TypeOf((*MockAuthRepository)(nil).GetUserSessions), ctx, userID)
+}
+
+// GetUserWebAuthnCredentials mocks base method.
+func (m *MockAuthRepository) GetUserWebAuthnCredentials(ctx context.Context, userID uint64) ([]entities.WebAuthnCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserWebAuthnCredentials", ctx, userID)
+	ret0, _ := ret[0].([]entities.WebAuthnCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserWebAuthnCredentials indicates an expected call of GetUserWebAuthnCredentials.
+func (mr *MockAuthRepositoryMockRecorder) GetUserWebAuthnCredentials(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserWebAuthnCredentials", reflect.TypeOf((*MockAuthRepository)(nil).GetUserWebAuthnCredentials), ctx, userID)
+}
+
+// GetVerifyEmailTokenByHash mocks base method.
+func (m *MockAuthRepository) GetVerifyEmailTokenByHash(ctx context.Context, tokenHash string) (*entities.VerifyEmailToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVerifyEmailTokenByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*entities.VerifyEmailToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVerifyEmailTokenByHash indicates an expected call of GetVerifyEmailTokenByHash.
+func (mr *MockAuthRepositoryMockRecorder) GetVerifyEmailTokenByHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVerifyEmailTokenByHash", reflect.TypeOf((*MockAuthRepository)(nil).GetVerifyEmailTokenByHash), ctx, tokenHash)
+}
+
+// GetVerifyEmailTokenByUserID mocks base method.
+func (m *MockAuthRepository) GetVerifyEmailTokenByUserID(ctx context.Context, userID uint64) (*entities.VerifyEmailToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVerifyEmailTokenByUserID", ctx, userID)
+	ret0, _ := ret[0].(*entities.VerifyEmailToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVerifyEmailTokenByUserID indicates an expected call of GetVerifyEmailTokenByUserID.
+func (mr *MockAuthRepositoryMockRecorder) GetVerifyEmailTokenByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVerifyEmailTokenByUserID", reflect.TypeOf((*MockAuthRepository)(nil).GetVerifyEmailTokenByUserID), ctx, userID)
+}
+
+// GetWebAuthnChallengeByHash mocks base method.
+func (m *MockAuthRepository) GetWebAuthnChallengeByHash(ctx context.Context, challengeHash, ceremony string) (*entities.WebAuthnChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebAuthnChallengeByHash", ctx, challengeHash, ceremony)
+	ret0, _ := ret[0].(*entities.WebAuthnChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebAuthnChallengeByHash indicates an expected call of GetWebAuthnChallengeByHash.
+func (mr *MockAuthRepositoryMockRecorder) GetWebAuthnChallengeByHash(ctx, challengeHash, ceremony any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebAuthnChallengeByHash", reflect.TypeOf((*MockAuthRepository)(nil).GetWebAuthnChallengeByHash), ctx, challengeHash, ceremony)
+}
+
+// GetWebAuthnCredential mocks base method.
+func (m *MockAuthRepository) GetWebAuthnCredential(ctx context.Context, credentialID string) (*entities.WebAuthnCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebAuthnCredential", ctx, credentialID)
+	ret0, _ := ret[0].(*entities.WebAuthnCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebAuthnCredential indicates an expected call of GetWebAuthnCredential.
+func (mr *MockAuthRepositoryMockRecorder) GetWebAuthnCredential(ctx, credentialID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebAuthnCredential", reflect.TypeOf((*MockAuthRepository)(nil).GetWebAuthnCredential), ctx, credentialID)
+}
+
+// ImportUser mocks base method.
+func (m *MockAuthRepository) ImportUser(ctx context.Context, userData entities.ImportUserDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportUser", ctx, userData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportUser indicates an expected call of ImportUser.
+func (mr *MockAuthRepositoryMockRecorder) ImportUser(ctx, userData any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefreshTokenByUserID", reflect.TypeOf((*MockAuthRepository)(nil).GetRefreshTokenByUserID), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportUser", reflect.TypeOf((*MockAuthRepository)(nil).ImportUser), ctx, userData)
 }
 
 // RegisterUser mocks base method.
@@ -129,6 +688,118 @@ func (mr *MockAuthRepositoryMockRecorder) RegisterUser(ctx, userData any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockAuthRepository)(nil).RegisterUser), ctx, userData)
 }
 
+// RevokeRefreshTokenFamily mocks base method.
+func (m *MockAuthRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshTokenFamily", ctx, familyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshTokenFamily indicates an expected call of RevokeRefreshTokenFamily.
+func (mr *MockAuthRepositoryMockRecorder) RevokeRefreshTokenFamily(ctx, familyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshTokenFamily", reflect.TypeOf((*MockAuthRepository)(nil).RevokeRefreshTokenFamily), ctx, familyID)
+}
+
+// RotateRefreshToken mocks base method.
+func (m *MockAuthRepository) RotateRefreshToken(ctx context.Context, refreshTokenID uint64, familyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateRefreshToken", ctx, refreshTokenID, familyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateRefreshToken indicates an expected call of RotateRefreshToken.
+func (mr *MockAuthRepositoryMockRecorder) RotateRefreshToken(ctx, refreshTokenID, familyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRefreshToken", reflect.TypeOf((*MockAuthRepository)(nil).RotateRefreshToken), ctx, refreshTokenID, familyID)
+}
+
+// UpdateClient mocks base method.
+func (m *MockAuthRepository) UpdateClient(ctx context.Context, clientData entities.UpdateClientSettingsDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateClient", ctx, clientData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateClient indicates an expected call of UpdateClient.
+func (mr *MockAuthRepositoryMockRecorder) UpdateClient(ctx, clientData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClient", reflect.TypeOf((*MockAuthRepository)(nil).UpdateClient), ctx, clientData)
+}
+
+// UpdateWebAuthnCredentialSignCount mocks base method.
+func (m *MockAuthRepository) UpdateWebAuthnCredentialSignCount(ctx context.Context, credentialID uint64, signCount uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebAuthnCredentialSignCount", ctx, credentialID, signCount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebAuthnCredentialSignCount indicates an expected call of UpdateWebAuthnCredentialSignCount.
+func (mr *MockAuthRepositoryMockRecorder) UpdateWebAuthnCredentialSignCount(ctx, credentialID, signCount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebAuthnCredentialSignCount", reflect.TypeOf((*MockAuthRepository)(nil).UpdateWebAuthnCredentialSignCount), ctx, credentialID, signCount)
+}
+
+// UseAuthorizationCode mocks base method.
+func (m *MockAuthRepository) UseAuthorizationCode(ctx context.Context, authorizationCodeID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseAuthorizationCode", ctx, authorizationCodeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseAuthorizationCode indicates an expected call of UseAuthorizationCode.
+func (mr *MockAuthRepositoryMockRecorder) UseAuthorizationCode(ctx, authorizationCodeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseAuthorizationCode", reflect.TypeOf((*MockAuthRepository)(nil).UseAuthorizationCode), ctx, authorizationCodeID)
+}
+
+// UseLoginToken mocks base method.
+func (m *MockAuthRepository) UseLoginToken(ctx context.Context, userID, loginTokenID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseLoginToken", ctx, userID, loginTokenID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseLoginToken indicates an expected call of UseLoginToken.
+func (mr *MockAuthRepositoryMockRecorder) UseLoginToken(ctx, userID, loginTokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseLoginToken", reflect.TypeOf((*MockAuthRepository)(nil).UseLoginToken), ctx, userID, loginTokenID)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockAuthRepository) UseRecoveryCode(ctx context.Context, userID uint64, codeHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, userID, codeHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockAuthRepositoryMockRecorder) UseRecoveryCode(ctx, userID, codeHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockAuthRepository)(nil).UseRecoveryCode), ctx, userID, codeHash)
+}
+
+// UseTOTPStep mocks base method.
+func (m *MockAuthRepository) UseTOTPStep(ctx context.Context, totpSecretID uint64, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTOTPStep", ctx, totpSecretID, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseTOTPStep indicates an expected call of UseTOTPStep.
+func (mr *MockAuthRepositoryMockRecorder) UseTOTPStep(ctx, totpSecretID, step any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTOTPStep", reflect.TypeOf((*MockAuthRepository)(nil).UseTOTPStep), ctx, totpSecretID, step)
+}
+
 // VerifyUserEmail mocks base method.
 func (m *MockAuthRepository) VerifyUserEmail(ctx context.Context, userID uint64) error {
 	m.ctrl.T.Helper()
@@ -142,3 +813,17 @@ func (mr *MockAuthRepositoryMockRecorder) VerifyUserEmail(ctx, userID any) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyUserEmail", reflect.TypeOf((*MockAuthRepository)(nil).VerifyUserEmail), ctx, userID)
 }
+
+// VerifyUserPhone mocks base method.
+func (m *MockAuthRepository) VerifyUserPhone(ctx context.Context, userID, phoneVerificationCodeID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyUserPhone", ctx, userID, phoneVerificationCodeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyUserPhone indicates an expected call of VerifyUserPhone.
+func (mr *MockAuthRepositoryMockRecorder) VerifyUserPhone(ctx, userID, phoneVerificationCodeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyUserPhone", reflect.TypeOf((*MockAuthRepository)(nil).VerifyUserPhone), ctx, userID, phoneVerificationCodeID)
+}
@@ -71,6 +71,36 @@ func (mr *MockUsersRepositoryMockRecorder) GetUserByID(ctx, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUsersRepository)(nil).GetUserByID), ctx, id)
 }
 
+// GetUserByPhone mocks base method.
+func (m *MockUsersRepository) GetUserByPhone(ctx context.Context, phone string) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByPhone", ctx, phone)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByPhone indicates an expected call of GetUserByPhone.
+func (mr *MockUsersRepositoryMockRecorder) GetUserByPhone(ctx, phone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByPhone", reflect.TypeOf((*MockUsersRepository)(nil).GetUserByPhone), ctx, phone)
+}
+
+// GetUserByTelegram mocks base method.
+func (m *MockUsersRepository) GetUserByTelegram(ctx context.Context, telegram string) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByTelegram", ctx, telegram)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByTelegram indicates an expected call of GetUserByTelegram.
+func (mr *MockUsersRepositoryMockRecorder) GetUserByTelegram(ctx, telegram any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByTelegram", reflect.TypeOf((*MockUsersRepository)(nil).GetUserByTelegram), ctx, telegram)
+}
+
 // GetUsers mocks base method.
 func (m *MockUsersRepository) GetUsers(ctx context.Context, pagination *entities.Pagination) ([]entities.User, error) {
 	m.ctrl.T.Helper()
@@ -86,6 +116,21 @@ func (mr *MockUsersRepositoryMockRecorder) GetUsers(ctx, pagination any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockUsersRepository)(nil).GetUsers), ctx, pagination)
 }
 
+// IsUserBlocked mocks base method.
+func (m *MockUsersRepository) IsUserBlocked(ctx context.Context, id uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUserBlocked", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsUserBlocked indicates an expected call of IsUserBlocked.
+func (mr *MockUsersRepositoryMockRecorder) IsUserBlocked(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserBlocked", reflect.TypeOf((*MockUsersRepository)(nil).IsUserBlocked), ctx, id)
+}
+
 // UpdateUserProfile mocks base method.
 func (m *MockUsersRepository) UpdateUserProfile(ctx context.Context, userProfileData entities.UpdateUserProfileDTO) error {
 	m.ctrl.T.Helper()
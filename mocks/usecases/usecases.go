@@ -41,6 +41,66 @@ func (m *MockUseCases) EXPECT() *MockUseCasesMockRecorder {
 	return m.recorder
 }
 
+// Authorize mocks base method.
+func (m *MockUseCases) Authorize(ctx context.Context, authorizeData entities.AuthorizeDTO) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", ctx, authorizeData)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockUseCasesMockRecorder) Authorize(ctx, authorizeData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockUseCases)(nil).Authorize), ctx, authorizeData)
+}
+
+// BeginFederatedLogin mocks base method.
+func (m *MockUseCases) BeginFederatedLogin(ctx context.Context, provider string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginFederatedLogin", ctx, provider)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginFederatedLogin indicates an expected call of BeginFederatedLogin.
+func (mr *MockUseCasesMockRecorder) BeginFederatedLogin(ctx, provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginFederatedLogin", reflect.TypeOf((*MockUseCases)(nil).BeginFederatedLogin), ctx, provider)
+}
+
+// BeginWebAuthnLogin mocks base method.
+func (m *MockUseCases) BeginWebAuthnLogin(ctx context.Context, email string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginWebAuthnLogin", ctx, email)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginWebAuthnLogin indicates an expected call of BeginWebAuthnLogin.
+func (mr *MockUseCasesMockRecorder) BeginWebAuthnLogin(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginWebAuthnLogin", reflect.TypeOf((*MockUseCases)(nil).BeginWebAuthnLogin), ctx, email)
+}
+
+// BeginWebAuthnRegistration mocks base method.
+func (m *MockUseCases) BeginWebAuthnRegistration(ctx context.Context, accessToken string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginWebAuthnRegistration", ctx, accessToken)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginWebAuthnRegistration indicates an expected call of BeginWebAuthnRegistration.
+func (mr *MockUseCasesMockRecorder) BeginWebAuthnRegistration(ctx, accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginWebAuthnRegistration", reflect.TypeOf((*MockUseCases)(nil).BeginWebAuthnRegistration), ctx, accessToken)
+}
+
 // ChangePassword mocks base method.
 func (m *MockUseCases) ChangePassword(ctx context.Context, accessToken, oldPassword, newPassword string) error {
 	m.ctrl.T.Helper()
@@ -55,6 +115,123 @@ func (mr *MockUseCasesMockRecorder) ChangePassword(ctx, accessToken, oldPassword
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockUseCases)(nil).ChangePassword), ctx, accessToken, oldPassword, newPassword)
 }
 
+// CompleteMFALogin mocks base method.
+func (m *MockUseCases) CompleteMFALogin(ctx context.Context, loginData entities.CompleteMFALoginDTO) (*entities.TokensDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteMFALogin", ctx, loginData)
+	ret0, _ := ret[0].(*entities.TokensDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteMFALogin indicates an expected call of CompleteMFALogin.
+func (mr *MockUseCasesMockRecorder) CompleteMFALogin(ctx, loginData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteMFALogin", reflect.TypeOf((*MockUseCases)(nil).CompleteMFALogin), ctx, loginData)
+}
+
+// ConfirmTOTPEnrollment mocks base method.
+func (m *MockUseCases) ConfirmTOTPEnrollment(ctx context.Context, accessToken, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTPEnrollment", ctx, accessToken, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTOTPEnrollment indicates an expected call of ConfirmTOTPEnrollment.
+func (mr *MockUseCasesMockRecorder) ConfirmTOTPEnrollment(ctx, accessToken, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTPEnrollment", reflect.TypeOf((*MockUseCases)(nil).ConfirmTOTPEnrollment), ctx, accessToken, code)
+}
+
+// DeleteClient mocks base method.
+func (m *MockUseCases) DeleteClient(ctx context.Context, accessToken, clientID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClient", ctx, accessToken, clientID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteClient indicates an expected call of DeleteClient.
+func (mr *MockUseCasesMockRecorder) DeleteClient(ctx, accessToken, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClient", reflect.TypeOf((*MockUseCases)(nil).DeleteClient), ctx, accessToken, clientID)
+}
+
+// DisableTOTP mocks base method.
+func (m *MockUseCases) DisableTOTP(ctx context.Context, accessToken, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTOTP", ctx, accessToken, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTOTP indicates an expected call of DisableTOTP.
+func (mr *MockUseCasesMockRecorder) DisableTOTP(ctx, accessToken, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTOTP", reflect.TypeOf((*MockUseCases)(nil).DisableTOTP), ctx, accessToken, code)
+}
+
+// ExchangeOIDCToken mocks base method.
+func (m *MockUseCases) ExchangeOIDCToken(ctx context.Context, tokenRequest entities.OIDCTokenRequestDTO) (*entities.OIDCTokensDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExchangeOIDCToken", ctx, tokenRequest)
+	ret0, _ := ret[0].(*entities.OIDCTokensDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExchangeOIDCToken indicates an expected call of ExchangeOIDCToken.
+func (mr *MockUseCasesMockRecorder) ExchangeOIDCToken(ctx, tokenRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExchangeOIDCToken", reflect.TypeOf((*MockUseCases)(nil).ExchangeOIDCToken), ctx, tokenRequest)
+}
+
+// FinishFederatedLogin mocks base method.
+func (m *MockUseCases) FinishFederatedLogin(ctx context.Context, loginData entities.FinishFederatedLoginDTO) (*entities.TokensDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishFederatedLogin", ctx, loginData)
+	ret0, _ := ret[0].(*entities.TokensDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishFederatedLogin indicates an expected call of FinishFederatedLogin.
+func (mr *MockUseCasesMockRecorder) FinishFederatedLogin(ctx, loginData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishFederatedLogin", reflect.TypeOf((*MockUseCases)(nil).FinishFederatedLogin), ctx, loginData)
+}
+
+// FinishWebAuthnLogin mocks base method.
+func (m *MockUseCases) FinishWebAuthnLogin(ctx context.Context, loginData entities.FinishWebAuthnLoginDTO) (*entities.TokensDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishWebAuthnLogin", ctx, loginData)
+	ret0, _ := ret[0].(*entities.TokensDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishWebAuthnLogin indicates an expected call of FinishWebAuthnLogin.
+func (mr *MockUseCasesMockRecorder) FinishWebAuthnLogin(ctx, loginData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishWebAuthnLogin", reflect.TypeOf((*MockUseCases)(nil).FinishWebAuthnLogin), ctx, loginData)
+}
+
+// FinishWebAuthnRegistration mocks base method.
+func (m *MockUseCases) FinishWebAuthnRegistration(ctx context.Context, registrationData entities.FinishWebAuthnRegistrationDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishWebAuthnRegistration", ctx, registrationData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishWebAuthnRegistration indicates an expected call of FinishWebAuthnRegistration.
+func (mr *MockUseCasesMockRecorder) FinishWebAuthnRegistration(ctx, registrationData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishWebAuthnRegistration", reflect.TypeOf((*MockUseCases)(nil).FinishWebAuthnRegistration), ctx, registrationData)
+}
+
 // ForgetPassword mocks base method.
 func (m *MockUseCases) ForgetPassword(ctx context.Context, forgetPasswordToken, newPassword string) error {
 	m.ctrl.T.Helper()
@@ -69,6 +246,35 @@ func (mr *MockUseCasesMockRecorder) ForgetPassword(ctx, forgetPasswordToken, new
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgetPassword", reflect.TypeOf((*MockUseCases)(nil).ForgetPassword), ctx, forgetPasswordToken, newPassword)
 }
 
+// GetClients mocks base method.
+func (m *MockUseCases) GetClients(ctx context.Context, accessToken string) ([]entities.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClients", ctx, accessToken)
+	ret0, _ := ret[0].([]entities.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClients indicates an expected call of GetClients.
+func (mr *MockUseCasesMockRecorder) GetClients(ctx, accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClients", reflect.TypeOf((*MockUseCases)(nil).GetClients), ctx, accessToken)
+}
+
+// GetJWKS mocks base method.
+func (m *MockUseCases) GetJWKS() entities.JWKS {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJWKS")
+	ret0, _ := ret[0].(entities.JWKS)
+	return ret0
+}
+
+// GetJWKS indicates an expected call of GetJWKS.
+func (mr *MockUseCasesMockRecorder) GetJWKS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJWKS", reflect.TypeOf((*MockUseCases)(nil).GetJWKS))
+}
+
 // GetMe mocks base method.
 func (m *MockUseCases) GetMe(ctx context.Context, accessToken string) (*entities.User, error) {
 	m.ctrl.T.Helper()
@@ -84,6 +290,20 @@ func (mr *MockUseCasesMockRecorder) GetMe(ctx, accessToken any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMe", reflect.TypeOf((*MockUseCases)(nil).GetMe), ctx, accessToken)
 }
 
+// GetOpenIDConfiguration mocks base method.
+func (m *MockUseCases) GetOpenIDConfiguration() entities.OpenIDConfiguration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenIDConfiguration")
+	ret0, _ := ret[0].(entities.OpenIDConfiguration)
+	return ret0
+}
+
+// GetOpenIDConfiguration indicates an expected call of GetOpenIDConfiguration.
+func (mr *MockUseCasesMockRecorder) GetOpenIDConfiguration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenIDConfiguration", reflect.TypeOf((*MockUseCases)(nil).GetOpenIDConfiguration))
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockUseCases) GetUserByEmail(ctx context.Context, email string) (*entities.User, error) {
 	m.ctrl.T.Helper()
@@ -114,6 +334,51 @@ func (mr *MockUseCasesMockRecorder) GetUserByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUseCases)(nil).GetUserByID), ctx, id)
 }
 
+// GetUserInfo mocks base method.
+func (m *MockUseCases) GetUserInfo(ctx context.Context, accessToken string) (*entities.UserInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserInfo", ctx, accessToken)
+	ret0, _ := ret[0].(*entities.UserInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserInfo indicates an expected call of GetUserInfo.
+func (mr *MockUseCasesMockRecorder) GetUserInfo(ctx, accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserInfo", reflect.TypeOf((*MockUseCases)(nil).GetUserInfo), ctx, accessToken)
+}
+
+// GetUserPhone mocks base method.
+func (m *MockUseCases) GetUserPhone(ctx context.Context, accessToken string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPhone", ctx, accessToken)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPhone indicates an expected call of GetUserPhone.
+func (mr *MockUseCasesMockRecorder) GetUserPhone(ctx, accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPhone", reflect.TypeOf((*MockUseCases)(nil).GetUserPhone), ctx, accessToken)
+}
+
+// GetUserSessions mocks base method.
+func (m *MockUseCases) GetUserSessions(ctx context.Context, accessToken string) (*entities.SessionsDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSessions", ctx, accessToken)
+	ret0, _ := ret[0].(*entities.SessionsDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSessions indicates an expected call of GetUserSessions.
+func (mr *MockUseCasesMockRecorder) GetUserSessions(ctx, accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSessions", reflect.TypeOf((*MockUseCases)(nil).GetUserSessions), ctx, accessToken)
+}
+
 // GetUsers mocks base method.
 func (m *MockUseCases) GetUsers(ctx context.Context, pagination *entities.Pagination) ([]entities.User, error) {
 	m.ctrl.T.Helper()
@@ -129,6 +394,65 @@ func (mr *MockUseCasesMockRecorder) GetUsers(ctx, pagination any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockUseCases)(nil).GetUsers), ctx, pagination)
 }
 
+// ImportUsers mocks base method.
+func (m *MockUseCases) ImportUsers(ctx context.Context, accessToken string, data []byte) (*entities.ImportUsersResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportUsers", ctx, accessToken, data)
+	ret0, _ := ret[0].(*entities.ImportUsersResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportUsers indicates an expected call of ImportUsers.
+func (mr *MockUseCasesMockRecorder) ImportUsers(ctx, accessToken, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportUsers", reflect.TypeOf((*MockUseCases)(nil).ImportUsers), ctx, accessToken, data)
+}
+
+// IntrospectToken mocks base method.
+func (m *MockUseCases) IntrospectToken(ctx context.Context, token string) (*entities.TokenIntrospection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IntrospectToken", ctx, token)
+	ret0, _ := ret[0].(*entities.TokenIntrospection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IntrospectToken indicates an expected call of IntrospectToken.
+func (mr *MockUseCasesMockRecorder) IntrospectToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntrospectToken", reflect.TypeOf((*MockUseCases)(nil).IntrospectToken), ctx, token)
+}
+
+// IssueClientToken mocks base method.
+func (m *MockUseCases) IssueClientToken(ctx context.Context, tokenRequest entities.IssueClientTokenDTO) (*entities.ClientTokenDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueClientToken", ctx, tokenRequest)
+	ret0, _ := ret[0].(*entities.ClientTokenDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueClientToken indicates an expected call of IssueClientToken.
+func (mr *MockUseCasesMockRecorder) IssueClientToken(ctx, tokenRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueClientToken", reflect.TypeOf((*MockUseCases)(nil).IssueClientToken), ctx, tokenRequest)
+}
+
+// LinkTelegram mocks base method.
+func (m *MockUseCases) LinkTelegram(ctx context.Context, linkData entities.LinkTelegramDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkTelegram", ctx, linkData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkTelegram indicates an expected call of LinkTelegram.
+func (mr *MockUseCasesMockRecorder) LinkTelegram(ctx, linkData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkTelegram", reflect.TypeOf((*MockUseCases)(nil).LinkTelegram), ctx, linkData)
+}
+
 // LoginUser mocks base method.
 func (m *MockUseCases) LoginUser(ctx context.Context, userData entities.LoginUserDTO) (*entities.TokensDTO, error) {
 	m.ctrl.T.Helper()
@@ -144,6 +468,36 @@ func (mr *MockUseCasesMockRecorder) LoginUser(ctx, userData any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUser", reflect.TypeOf((*MockUseCases)(nil).LoginUser), ctx, userData)
 }
 
+// LoginWithCode mocks base method.
+func (m *MockUseCases) LoginWithCode(ctx context.Context, loginData entities.LoginWithCodeDTO) (*entities.TokensDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginWithCode", ctx, loginData)
+	ret0, _ := ret[0].(*entities.TokensDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginWithCode indicates an expected call of LoginWithCode.
+func (mr *MockUseCasesMockRecorder) LoginWithCode(ctx, loginData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginWithCode", reflect.TypeOf((*MockUseCases)(nil).LoginWithCode), ctx, loginData)
+}
+
+// LoginWithTelegram mocks base method.
+func (m *MockUseCases) LoginWithTelegram(ctx context.Context, loginData entities.LoginWithTelegramDTO) (*entities.TokensDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginWithTelegram", ctx, loginData)
+	ret0, _ := ret[0].(*entities.TokensDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginWithTelegram indicates an expected call of LoginWithTelegram.
+func (mr *MockUseCasesMockRecorder) LoginWithTelegram(ctx, loginData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginWithTelegram", reflect.TypeOf((*MockUseCases)(nil).LoginWithTelegram), ctx, loginData)
+}
+
 // LogoutUser mocks base method.
 func (m *MockUseCases) LogoutUser(ctx context.Context, accessToken string) error {
 	m.ctrl.T.Helper()
@@ -158,6 +512,20 @@ func (mr *MockUseCasesMockRecorder) LogoutUser(ctx, accessToken any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutUser", reflect.TypeOf((*MockUseCases)(nil).LogoutUser), ctx, accessToken)
 }
 
+// LogoutUserEverywhere mocks base method.
+func (m *MockUseCases) LogoutUserEverywhere(ctx context.Context, accessToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutUserEverywhere", ctx, accessToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutUserEverywhere indicates an expected call of LogoutUserEverywhere.
+func (mr *MockUseCasesMockRecorder) LogoutUserEverywhere(ctx, accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutUserEverywhere", reflect.TypeOf((*MockUseCases)(nil).LogoutUserEverywhere), ctx, accessToken)
+}
+
 // RefreshTokens mocks base method.
 func (m *MockUseCases) RefreshTokens(ctx context.Context, refreshToken string) (*entities.TokensDTO, error) {
 	m.ctrl.T.Helper()
@@ -173,6 +541,21 @@ func (mr *MockUseCasesMockRecorder) RefreshTokens(ctx, refreshToken any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshTokens", reflect.TypeOf((*MockUseCases)(nil).RefreshTokens), ctx, refreshToken)
 }
 
+// RegisterClient mocks base method.
+func (m *MockUseCases) RegisterClient(ctx context.Context, clientData entities.RegisterClientDTO) (*entities.RegisteredClientDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterClient", ctx, clientData)
+	ret0, _ := ret[0].(*entities.RegisteredClientDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterClient indicates an expected call of RegisterClient.
+func (mr *MockUseCasesMockRecorder) RegisterClient(ctx, clientData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterClient", reflect.TypeOf((*MockUseCases)(nil).RegisterClient), ctx, clientData)
+}
+
 // RegisterUser mocks base method.
 func (m *MockUseCases) RegisterUser(ctx context.Context, userData entities.RegisterUserDTO) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -188,6 +571,20 @@ func (mr *MockUseCasesMockRecorder) RegisterUser(ctx, userData any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockUseCases)(nil).RegisterUser), ctx, userData)
 }
 
+// RevokeSession mocks base method.
+func (m *MockUseCases) RevokeSession(ctx context.Context, accessToken string, sessionID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, accessToken, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockUseCasesMockRecorder) RevokeSession(ctx, accessToken, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockUseCases)(nil).RevokeSession), ctx, accessToken, sessionID)
+}
+
 // SendForgetPasswordMessage mocks base method.
 func (m *MockUseCases) SendForgetPasswordMessage(ctx context.Context, email string) error {
 	m.ctrl.T.Helper()
@@ -202,6 +599,34 @@ func (mr *MockUseCasesMockRecorder) SendForgetPasswordMessage(ctx, email any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendForgetPasswordMessage", reflect.TypeOf((*MockUseCases)(nil).SendForgetPasswordMessage), ctx, email)
 }
 
+// SendLoginLink mocks base method.
+func (m *MockUseCases) SendLoginLink(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendLoginLink", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendLoginLink indicates an expected call of SendLoginLink.
+func (mr *MockUseCasesMockRecorder) SendLoginLink(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendLoginLink", reflect.TypeOf((*MockUseCases)(nil).SendLoginLink), ctx, email)
+}
+
+// SendPhoneVerificationCode mocks base method.
+func (m *MockUseCases) SendPhoneVerificationCode(ctx context.Context, accessToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPhoneVerificationCode", ctx, accessToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPhoneVerificationCode indicates an expected call of SendPhoneVerificationCode.
+func (mr *MockUseCasesMockRecorder) SendPhoneVerificationCode(ctx, accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPhoneVerificationCode", reflect.TypeOf((*MockUseCases)(nil).SendPhoneVerificationCode), ctx, accessToken)
+}
+
 // SendVerifyEmailMessage mocks base method.
 func (m *MockUseCases) SendVerifyEmailMessage(ctx context.Context, email string) error {
 	m.ctrl.T.Helper()
@@ -216,6 +641,49 @@ func (mr *MockUseCasesMockRecorder) SendVerifyEmailMessage(ctx, email any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendVerifyEmailMessage", reflect.TypeOf((*MockUseCases)(nil).SendVerifyEmailMessage), ctx, email)
 }
 
+// StartTOTPEnrollment mocks base method.
+func (m *MockUseCases) StartTOTPEnrollment(ctx context.Context, accessToken string) (*entities.TOTPEnrollmentDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTOTPEnrollment", ctx, accessToken)
+	ret0, _ := ret[0].(*entities.TOTPEnrollmentDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartTOTPEnrollment indicates an expected call of StartTOTPEnrollment.
+func (mr *MockUseCasesMockRecorder) StartTOTPEnrollment(ctx, accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTOTPEnrollment", reflect.TypeOf((*MockUseCases)(nil).StartTOTPEnrollment), ctx, accessToken)
+}
+
+// UnlockUser mocks base method.
+func (m *MockUseCases) UnlockUser(ctx context.Context, accessToken string, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", ctx, accessToken, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockUseCasesMockRecorder) UnlockUser(ctx, accessToken, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockUseCases)(nil).UnlockUser), ctx, accessToken, userID)
+}
+
+// UpdateClient mocks base method.
+func (m *MockUseCases) UpdateClient(ctx context.Context, clientData entities.UpdateClientDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateClient", ctx, clientData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateClient indicates an expected call of UpdateClient.
+func (mr *MockUseCasesMockRecorder) UpdateClient(ctx, clientData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClient", reflect.TypeOf((*MockUseCases)(nil).UpdateClient), ctx, clientData)
+}
+
 // UpdateUserProfile mocks base method.
 func (m *MockUseCases) UpdateUserProfile(ctx context.Context, rawUserProfileData entities.RawUpdateUserProfileDTO) error {
 	m.ctrl.T.Helper()
@@ -230,6 +698,35 @@ func (mr *MockUseCasesMockRecorder) UpdateUserProfile(ctx, rawUserProfileData an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserProfile", reflect.TypeOf((*MockUseCases)(nil).UpdateUserProfile), ctx, rawUserProfileData)
 }
 
+// VerifyClientToken mocks base method.
+func (m *MockUseCases) VerifyClientToken(accessToken string) (*entities.ClientTokenPayload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyClientToken", accessToken)
+	ret0, _ := ret[0].(*entities.ClientTokenPayload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyClientToken indicates an expected call of VerifyClientToken.
+func (mr *MockUseCasesMockRecorder) VerifyClientToken(accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyClientToken", reflect.TypeOf((*MockUseCases)(nil).VerifyClientToken), accessToken)
+}
+
+// VerifyPhone mocks base method.
+func (m *MockUseCases) VerifyPhone(ctx context.Context, accessToken, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyPhone", ctx, accessToken, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyPhone indicates an expected call of VerifyPhone.
+func (mr *MockUseCasesMockRecorder) VerifyPhone(ctx, accessToken, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyPhone", reflect.TypeOf((*MockUseCases)(nil).VerifyPhone), ctx, accessToken, code)
+}
+
 // VerifyUserEmail mocks base method.
 func (m *MockUseCases) VerifyUserEmail(ctx context.Context, verifyEmailToken string) error {
 	m.ctrl.T.Helper()
@@ -243,3 +740,32 @@ func (mr *MockUseCasesMockRecorder) VerifyUserEmail(ctx, verifyEmailToken any) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyUserEmail", reflect.TypeOf((*MockUseCases)(nil).VerifyUserEmail), ctx, verifyEmailToken)
 }
+
+// VerifyUserEmailByCode mocks base method.
+func (m *MockUseCases) VerifyUserEmailByCode(ctx context.Context, email, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyUserEmailByCode", ctx, email, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyUserEmailByCode indicates an expected call of VerifyUserEmailByCode.
+func (mr *MockUseCasesMockRecorder) VerifyUserEmailByCode(ctx, email, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyUserEmailByCode", reflect.TypeOf((*MockUseCases)(nil).VerifyUserEmailByCode), ctx, email, code)
+}
+
+// VerifyUserToken mocks base method.
+func (m *MockUseCases) VerifyUserToken(accessToken string) (*entities.AccessTokenPayload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyUserToken", accessToken)
+	ret0, _ := ret[0].(*entities.AccessTokenPayload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyUserToken indicates an expected call of VerifyUserToken.
+func (mr *MockUseCasesMockRecorder) VerifyUserToken(accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyUserToken", reflect.TypeOf((*MockUseCases)(nil).VerifyUserToken), accessToken)
+}